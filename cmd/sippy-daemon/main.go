@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -91,8 +90,7 @@ func NewSippyDaemonCommand() *cobra.Command {
 			// Serve our metrics endpoint for prometheus to scrape
 			if f.MetricsAddr != "" {
 				go func() {
-					http.Handle("/metrics", promhttp.Handler())
-					err := http.ListenAndServe(f.MetricsAddr, nil) //nolint
+					err := http.ListenAndServe(f.MetricsAddr, sippyserver.NewMetricsHandler(false)) //nolint
 					if err != nil {
 						panic(err)
 					}
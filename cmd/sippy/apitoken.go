@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/flags"
+)
+
+func init() {
+	f := flags.NewPostgresDatabaseFlags()
+	var name string
+	var scopes []string
+	var expiresIn time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "api-token",
+		Short: "Manage API tokens used by automation.",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates an API token and prints it. Useful to create the first admin token.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbc, err := f.GetDBClient()
+			if err != nil {
+				return errors.WithMessage(err, "could not connect to db")
+			}
+
+			req := api.CreateAPITokenRequest{Name: name, Scopes: scopes}
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				req.ExpiresAt = &expiresAt
+			}
+			created, err := api.CreateAPIToken(dbc, req)
+			if err != nil {
+				return errors.WithMessage(err, "could not create api token")
+			}
+			fmt.Println(created.Token)
+			return nil
+		},
+	}
+	f.BindFlags(createCmd.Flags())
+	createCmd.Flags().StringVar(&name, "name", "", "Name describing what the token is used for")
+	createCmd.Flags().StringSliceVar(&scopes, "scope", []string{api.APITokenScopeWrite}, "Scopes to grant, write or admin")
+	createCmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "How long the token is valid for, never expires if zero")
+
+	cmd.AddCommand(createCmd)
+	rootCmd.AddCommand(cmd)
+}
@@ -22,17 +22,24 @@ import (
 	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
 	"github.com/openshift/sippy/pkg/dataloader"
 	"github.com/openshift/sippy/pkg/dataloader/bugloader"
+	"github.com/openshift/sippy/pkg/dataloader/disruptionloader"
 	"github.com/openshift/sippy/pkg/dataloader/jiraloader"
+	"github.com/openshift/sippy/pkg/dataloader/jobrunclassifier"
 	"github.com/openshift/sippy/pkg/dataloader/loaderwithmetrics"
+	"github.com/openshift/sippy/pkg/dataloader/loadverifier"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/github"
 	"github.com/openshift/sippy/pkg/dataloader/releaseloader"
+	"github.com/openshift/sippy/pkg/dataloader/stepregistryloader"
 	"github.com/openshift/sippy/pkg/dataloader/testownershiploader"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/flags"
 	"github.com/openshift/sippy/pkg/github/commenter"
+	"github.com/openshift/sippy/pkg/notify"
 	"github.com/openshift/sippy/pkg/sippyserver"
+	"github.com/openshift/sippy/pkg/synthetictests"
+	"github.com/openshift/sippy/pkg/testidentification"
 )
 
 type LoadFlags struct {
@@ -44,11 +51,33 @@ type LoadFlags struct {
 	Architectures []string
 	Releases      []string
 
+	// LoadConcurrency is the number of prow job runs imported in parallel.
+	LoadConcurrency int
+
+	// ProwBackfill is a local directory or gs:// path of dated prow job list snapshots to import instead of
+	// the current job lists.
+	ProwBackfill string
+
+	// PartitionRetention is how long job run and test results are kept before their partitions are dropped.
+	PartitionRetention time.Duration
+
+	// VerifyMinRunRatio and VerifyFailOnAnomaly configure the checks of the job runs loaded.
+	VerifyMinRunRatio   float64
+	VerifyFailOnAnomaly bool
+
+	// StepRegistryMetadata is the URL or path of the job steps metadata for the step-registry loader.
+	StepRegistryMetadata string
+
+	// Config, if set, is loaded instead of the file in ConfigFlags, the server uses it to load with the
+	// configuration it last reloaded.
+	Config *v1.SippyConfig
+
 	BigQueryFlags        *flags.BigQueryFlags
 	ConfigFlags          *flags.ConfigFlags
 	DBFlags              *flags.PostgresFlags
 	GithubCommenterFlags *flags.GithubCommenterFlags
 	GoogleCloudFlags     *flags.GoogleCloudFlags
+	MatViewFlags         *flags.MatViewRefreshFlags
 	ModeFlags            *flags.ModeFlags
 	JobVariantsInputFile string
 }
@@ -60,7 +89,10 @@ func NewLoadFlags() *LoadFlags {
 		DBFlags:              flags.NewPostgresDatabaseFlags(),
 		GithubCommenterFlags: flags.NewGithubCommenterFlags(),
 		GoogleCloudFlags:     flags.NewGoogleCloudFlags(),
+		MatViewFlags:         flags.NewMatViewRefreshFlags(),
 		ModeFlags:            flags.NewModeFlags(),
+		LoadConcurrency:      10,
+		VerifyMinRunRatio:    loadverifier.DefaultMinRunRatio,
 	}
 }
 
@@ -70,6 +102,7 @@ func (f *LoadFlags) BindFlags(fs *pflag.FlagSet) {
 	f.DBFlags.BindFlags(fs)
 	f.GithubCommenterFlags.BindFlags(fs)
 	f.GoogleCloudFlags.BindFlags(fs)
+	f.MatViewFlags.BindFlags(fs)
 	f.ModeFlags.BindFlags(fs)
 
 	fs.BoolVar(&f.InitDatabase, "init-database", false, "Migrate the DB before loading")
@@ -77,6 +110,12 @@ func (f *LoadFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&f.Loaders, "loader", []string{"prow", "releases", "jira", "github", "bugs", "test-mapping"}, "Which data sources to use for data loading")
 	fs.StringArrayVar(&f.Releases, "release", f.Releases, "Which releases to load (one per arg instance)")
 	fs.StringArrayVar(&f.Architectures, "arch", f.Architectures, "Which architectures to load (one per arg instance)")
+	fs.IntVar(&f.LoadConcurrency, "load-concurrency", f.LoadConcurrency, "Number of prow job runs to import concurrently")
+	fs.StringVar(&f.ProwBackfill, "prow-backfill", "", "Import job runs from a directory or gs://bucket/prefix of dated prowjobs.json snapshots instead of the current prow job lists")
+	fs.DurationVar(&f.PartitionRetention, "partition-retention", 0, "Drop monthly partitions of job run and test results older than this, 0 keeps everything")
+	fs.Float64Var(&f.VerifyMinRunRatio, "verify-min-run-ratio", f.VerifyMinRunRatio, "Warn when a release had less than this fraction of its usual daily job runs in the last day")
+	fs.BoolVar(&f.VerifyFailOnAnomaly, "verify-fail-on-anomaly", false, "Fail the load when verifying the loaded job runs finds anomalies, rather than only recording warnings")
+	fs.StringVar(&f.StepRegistryMetadata, "step-registry-metadata", "", "URL or path of the JSON metadata listing each job's steps, for the step-registry loader")
 	fs.StringVar(&f.JobVariantsInputFile, "job-variants-input-file", "expected-job-variants.json", "JSON input file for the job-variants loader")
 }
 
@@ -87,145 +126,257 @@ func NewLoadCommand() *cobra.Command {
 		Use:   "load",
 		Short: "Load data in the database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			loaders := make([]dataloader.DataLoader, 0)
-			allErrs := []error{}
+			return f.Run(cmd.Context())
+		},
+	}
 
-			// Cancel syncing after 4 hours
-			ctx, cancel := context.WithTimeout(context.Background(), time.Hour*4)
-			defer cancel()
+	f.BindFlags(cmd.Flags())
 
-			start := time.Now()
+	return cmd
+}
 
-			// Get a DB client if possible. Some loaders do not need one, so this dbErr may end up non-nil,
-			// each loader block should check if it needs the db connection.
-			var dbErr error
-			dbc, err := f.DBFlags.GetDBClient()
-			if err != nil {
-				dbErr = errors.WithMessage(err, "could not get db client: %+v")
-			} else if f.InitDatabase {
-				t := f.DBFlags.GetPinnedTime()
-				if err := dbc.UpdateSchema(t); err != nil {
-					dbErr = errors.WithMessage(err, "could not migrate db")
+// Run loads data from each of the configured loaders, then refreshes the materialized views and evaluates alerts.
+func (f *LoadFlags) Run(ctx context.Context) (retErr error) {
+	if err := f.ModeFlags.Validate(); err != nil {
+		return err
+	}
+
+	loaders := make([]dataloader.DataLoader, 0)
+	allErrs := []error{}
+	var allWarnings []string
+	var counts dataloader.Counts
+
+	// Cancel syncing after 4 hours
+	ctx, cancel := context.WithTimeout(ctx, time.Hour*4)
+	defer cancel()
+
+	start := time.Now()
+
+	// Get a DB client if possible. Some loaders do not need one, so this dbErr may end up non-nil,
+	// each loader block should check if it needs the db connection.
+	var dbErr error
+	dbc, err := f.DBFlags.GetDBClient()
+	if err != nil {
+		dbErr = errors.WithMessage(err, "could not get db client: %+v")
+	} else {
+		// The server runs loads on a schedule, each must return its connections.
+		defer func() {
+			if err := dbc.Close(); err != nil {
+				log.WithError(err).Warning("could not close db client")
+			}
+		}()
+		dbc.PartitionRetention = f.PartitionRetention
+		if f.InitDatabase {
+			t := f.DBFlags.GetPinnedTime()
+			if err := dbc.UpdateSchema(t); err != nil {
+				dbErr = errors.WithMessage(err, "could not migrate db")
+			}
+		} else if err := dbc.SyncPartitions(time.Now()); err != nil {
+			// Partitions are created months ahead, so a failure here doesn't stop the load.
+			log.WithError(err).Error("could not sync partitions")
+		}
+	}
+
+	// Record the load so operators can see when data was last updated and by what
+	if dbErr == nil {
+		event, err := dbc.StartLoadEvent(f.Loaders, f.Releases, f.Architectures)
+		if err != nil {
+			log.WithError(err).Warning("could not record load event")
+		} else {
+			defer func() {
+				errs := allErrs
+				if len(errs) == 0 && retErr != nil {
+					errs = []error{retErr}
 				}
+				if err := dbc.FinishLoadEvent(event, counts.JobRuns, counts.TestResults, errs, allWarnings); err != nil {
+					log.WithError(err).Warning("could not record load event")
+				}
+			}()
+		}
+	}
+
+	// Sippy Config
+	config := f.Config
+	if config == nil {
+		config, err = f.ConfigFlags.GetConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, l := range f.Loaders {
+		if l == "releases" {
+			if dbErr != nil {
+				return dbErr
 			}
+			loaders = append(loaders, releaseloader.New(dbc, f.Releases, f.Architectures))
+		}
 
-			// Sippy Config
-			config, err := f.ConfigFlags.GetConfig()
+		// Prow Loader
+		if l == "prow" {
+			if dbErr != nil {
+				return dbErr
+			}
+			prowLoader, err := f.prowLoader(ctx, dbc, config)
 			if err != nil {
 				return err
 			}
 
-			for _, l := range f.Loaders {
-				if l == "releases" {
-					if dbErr != nil {
-						return dbErr
-					}
-					loaders = append(loaders, releaseloader.New(dbc, f.Releases, f.Architectures))
-				}
+			loaders = append(loaders, prowLoader)
+		}
 
-				// Prow Loader
-				if l == "prow" {
-					if dbErr != nil {
-						return dbErr
-					}
-					prowLoader, err := f.prowLoader(ctx, dbc, config)
-					if err != nil {
-						return err
-					}
-
-					loaders = append(loaders, prowLoader)
-				}
+		// JIRA Loader
+		if l == "jira" {
+			if dbErr != nil {
+				return dbErr
+			}
+			loaders = append(loaders, jiraloader.New(dbc))
+		}
 
-				// JIRA Loader
-				if l == "jira" {
-					if dbErr != nil {
-						return dbErr
-					}
-					loaders = append(loaders, jiraloader.New(dbc))
-				}
+		// Load mapping for jira components to tests
+		if l == "test-mapping" {
+			if dbErr != nil {
+				return dbErr
+			}
+			cl, err := testownershiploader.New(ctx,
+				dbc,
+				f.GoogleCloudFlags.ServiceAccountCredentialFile,
+				f.GoogleCloudFlags.OAuthClientCredentialFile)
+			if err != nil {
+				return errors.WithMessage(err, "failed to create component loader")
+			}
 
-				// Load mapping for jira components to tests
-				if l == "test-mapping" {
-					if dbErr != nil {
-						return dbErr
-					}
-					cl, err := testownershiploader.New(ctx,
-						dbc,
-						f.GoogleCloudFlags.ServiceAccountCredentialFile,
-						f.GoogleCloudFlags.OAuthClientCredentialFile)
-					if err != nil {
-						return errors.WithMessage(err, "failed to create component loader")
-					}
-
-					loaders = append(loaders, cl)
-				}
+			loaders = append(loaders, cl)
+		}
 
-				// Bug Loader
-				if l == "bugs" {
-					if dbErr != nil {
-						return dbErr
-					}
-					// Get a bigquery client
-					bqc, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
-					if err != nil {
-						return errors.WithMessage(err, "could not get bigquery client")
-					}
-					loaders = append(loaders, bugloader.New(dbc, bqc))
-				}
+		// Steps composing each job, from the step registry
+		if l == "step-registry" {
+			if dbErr != nil {
+				return dbErr
+			}
+			if f.StepRegistryMetadata == "" {
+				return fmt.Errorf("the step-registry loader requires --step-registry-metadata")
+			}
+			loaders = append(loaders, stepregistryloader.New(dbc, f.StepRegistryMetadata))
+		}
 
-				// Sync postgres variants from BigQuery -- directly updates all jobs immediately
-				// without us waiting to see the job again.
-				if l == "sync-variants" {
-					bqc, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
-					if err != nil {
-						return errors.WithMessage(err, "could not get bigquery client")
-					}
-					vs, err := variantsyncer.New(dbc, bqc)
-					if err != nil {
-						return err
-					}
-					loaders = append(loaders, vs)
-				}
+		// Bug Loader
+		if l == "bugs" {
+			if dbErr != nil {
+				return dbErr
+			}
+			// Get a bigquery client
+			bqc, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
+			if err != nil {
+				return errors.WithMessage(err, "could not get bigquery client")
+			}
+			loaders = append(loaders, bugloader.New(dbc, bqc))
+		}
 
-				// Job Variants Loader from BigQuery
-				if l == "job-variants" {
-					variantsLoader, err := f.jobVariantsLoader(ctx)
-					if err != nil {
-						return err
-					}
-					loaders = append(loaders, variantsLoader)
-				}
+		// Disruption percentiles from BigQuery, so disruption can be analyzed without it
+		if l == "disruption" {
+			if dbErr != nil {
+				return dbErr
+			}
+			bqc, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
+			if err != nil {
+				return errors.WithMessage(err, "could not get bigquery client")
+			}
+			loaders = append(loaders, disruptionloader.New(dbc, bqc))
+		}
 
+		// Sync postgres variants from BigQuery -- directly updates all jobs immediately
+		// without us waiting to see the job again.
+		if l == "sync-variants" {
+			bqc, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
+			if err != nil {
+				return errors.WithMessage(err, "could not get bigquery client")
+			}
+			vs, err := variantsyncer.New(dbc, bqc)
+			if err != nil {
+				return err
 			}
+			loaders = append(loaders, vs)
+		}
 
-			// Run loaders with the metrics wrapper
-			l := loaderwithmetrics.New(loaders)
-			l.Load()
-			if len(l.Errors()) > 0 {
-				allErrs = append(allErrs, l.Errors()...)
+		// Job Variants Loader from BigQuery
+		if l == "job-variants" {
+			variantsLoader, err := f.jobVariantsLoader(ctx)
+			if err != nil {
+				return err
 			}
+			loaders = append(loaders, variantsLoader)
+		}
+
+	}
+
+	// Classify and verify the job runs just imported, after every loader so bugs linked to their failed tests are
+	// taken into account
+	for _, l := range f.Loaders {
+		if l == "prow" {
+			loaders = append(loaders,
+				jobrunclassifier.New(dbc, start),
+				loadverifier.New(dbc, start, f.Releases, f.VerifyMinRunRatio, f.VerifyFailOnAnomaly))
+			break
+		}
+	}
 
-			elapsed := time.Since(start)
-			log.WithField("elapsed", elapsed).Info("database load complete")
+	// Run loaders with the metrics wrapper
+	l := loaderwithmetrics.New(loaders)
+	l.Load()
+	if len(l.Errors()) > 0 {
+		allErrs = append(allErrs, l.Errors()...)
+	}
+	allWarnings = l.Warnings()
+	counts = l.Counts()
 
-			pinnedTime := f.DBFlags.GetPinnedTime()
-			sippyserver.RefreshData(dbc, pinnedTime, false)
+	elapsed := time.Since(start)
+	log.WithField("elapsed", elapsed).Info("database load complete")
 
-			if len(allErrs) > 0 {
-				log.Warningf("%d errors were encountered while loading database:", len(allErrs))
-				for _, err := range allErrs {
-					log.Error(err.Error())
-				}
-				return fmt.Errorf("errors were encountered while loading database, see logs for details")
-			}
-			log.Info("no errors encountered during db refresh")
-			return nil
-		},
+	pinnedTime := f.DBFlags.GetPinnedTime()
+	sippyserver.RefreshData(ctx, dbc, pinnedTime, f.MatViewFlags.GetRefreshOptions(false))
+	recordTableSizes(dbc)
+	if err := evaluateAlerts(ctx, dbc, config, pinnedTime); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := sendWatchlistDigests(ctx, dbc, config); err != nil {
+		allErrs = append(allErrs, err)
 	}
 
-	f.BindFlags(cmd.Flags())
+	if len(allErrs) > 0 {
+		log.Warningf("%d errors were encountered while loading database:", len(allErrs))
+		for _, err := range allErrs {
+			log.Error(err.Error())
+		}
+		notifyLoadErrors(config, allErrs)
+		return fmt.Errorf("errors were encountered while loading database, see logs for details")
+	}
+	log.Info("no errors encountered during db refresh")
+	return nil
+}
 
-	return cmd
+// notifyLoadErrors sends the errors of a load to the senders routed load notifications.
+func notifyLoadErrors(config *v1.SippyConfig, errs []error) {
+	notifier, err := notify.New(config)
+	if err != nil {
+		log.WithError(err).Error("could not notify of load errors")
+		return
+	}
+	details := make([]string, 0, len(errs))
+	for _, err := range errs {
+		details = append(details, err.Error())
+	}
+	// The load's context may have timed out, which is one of the errors we want to report.
+	err = notifier.Notify(context.Background(), notify.Message{
+		Source:   notify.SourceLoad,
+		Name:     "load",
+		Severity: notify.SeverityWarning,
+		Summary:  fmt.Sprintf("Sippy load encountered %d errors, the first was: %s", len(errs), errs[0]),
+		Details:  details,
+	})
+	if err != nil {
+		log.WithError(err).Error("could not notify of load errors")
+	}
 }
 
 func (f *LoadFlags) jobVariantsLoader(ctx context.Context) (dataloader.DataLoader, error) {
@@ -276,7 +427,7 @@ func (f *LoadFlags) prowLoader(ctx context.Context, dbc *db.DB, sippyConfig *v1.
 
 	var bigQueryClient *bqcachedclient.Client
 	if f.LoadOpenShiftCIBigQuery {
-		bigQueryClient, err = bqcachedclient.New(ctx, f.GoogleCloudFlags.ServiceAccountCredentialFile, f.BigQueryFlags.BigQueryProject, f.BigQueryFlags.BigQueryDataset, nil)
+		bigQueryClient, err = f.BigQueryFlags.GetBigQueryClient(ctx, nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
 		if err != nil {
 			log.WithError(err).Error("CRITICAL error getting BigQuery client which prevents importing prow jobs")
 			return nil, err
@@ -297,16 +448,31 @@ func (f *LoadFlags) prowLoader(ctx context.Context, dbc *db.DB, sippyConfig *v1.
 		return nil, err
 	}
 
-	return prowloader.New(
+	prowLoader, err := prowloader.New(
 		ctx,
 		dbc,
 		gcsClient,
-		bigQueryClient.BQ,
+		bigQueryClient,
 		f.GoogleCloudFlags.StorageBucket,
 		githubClient,
 		f.ModeFlags.GetVariantManager(ctx, bigQueryClient),
 		f.ModeFlags.GetSyntheticTestManager(),
+		func(mode string) (testidentification.VariantManager, synthetictests.SyntheticTestManager, error) {
+			modeFlags := &flags.ModeFlags{Mode: mode}
+			if err := modeFlags.Validate(); err != nil {
+				return nil, nil, err
+			}
+			return modeFlags.GetVariantManager(ctx, bigQueryClient), modeFlags.GetSyntheticTestManager(), nil
+		},
 		f.Releases,
 		sippyConfig,
-		ghCommenter), nil
+		ghCommenter,
+		f.LoadConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	if f.ProwBackfill != "" {
+		prowLoader.SetBackfillSource(f.ProwBackfill)
+	}
+	return prowLoader, nil
 }
@@ -41,6 +41,8 @@ func main() {
 		NewLoadJobVariantsCommand(),
 		NewComponentReadinessCommand(),
 		NewTrackRegressionsCommand(),
+		NewRevariantCommand(),
+		NewQueryCommand(),
 	)
 
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info",
@@ -1,9 +1,10 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	gormlogger "gorm.io/gorm/logger"
 
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/flags"
@@ -11,16 +12,29 @@ import (
 
 func init() {
 	f := flags.NewPostgresDatabaseFlags()
+	var downTo int
+	var status bool
 
 	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Migrates or initializes the PostgreSQL database to the latest schema.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			dbc, err := db.New(f.DSN, gormlogger.LogLevel(f.LogLevel))
+			dbc, err := f.GetDBClient()
 			if err != nil {
 				return errors.WithMessage(err, "could not connect to db")
 			}
 
+			if status {
+				return printMigrationStatus(dbc)
+			}
+
+			if cmd.Flags().Changed("down-to") {
+				if err := dbc.MigrateDown(downTo); err != nil {
+					return errors.WithMessage(err, "could not roll back db")
+				}
+				return nil
+			}
+
 			t := f.GetPinnedTime()
 			if err := dbc.UpdateSchema(t); err != nil {
 				return errors.WithMessage(err, "could not migrate db")
@@ -31,6 +45,26 @@ func init() {
 	}
 
 	f.BindFlags(cmd.Flags())
+	cmd.Flags().IntVar(&downTo, "down-to", 0, "Roll back versioned migrations newer than this version")
+	cmd.Flags().BoolVar(&status, "status", false, "Print applied and pending versioned migrations without changing anything")
 
 	rootCmd.AddCommand(cmd)
 }
+
+func printMigrationStatus(dbc *db.DB) error {
+	applied, err := dbc.AppliedMigrations()
+	if err != nil {
+		return err
+	}
+	pending, err := dbc.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	for _, m := range applied {
+		fmt.Printf("applied  %04d_%s at %s\n", m.Version, m.Name, m.AppliedAt.Format("2006-01-02 15:04:05"))
+	}
+	for _, m := range pending {
+		fmt.Printf("pending  %04d_%s\n", m.Version, m.Name)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+// queryResource is a list endpoint the query command can report on.
+type queryResource struct {
+	name    string
+	short   string
+	path    string
+	columns []string
+}
+
+var queryResources = []queryResource{
+	{
+		name:    "tests",
+		short:   "Report test pass, failure and flake rates",
+		path:    "/api/tests",
+		columns: []string{"name", "current_runs", "current_pass_percentage", "current_flake_percentage", "previous_pass_percentage", "net_working_improvement"},
+	},
+	{
+		name:    "jobs",
+		short:   "Report job pass rates",
+		path:    "/api/jobs",
+		columns: []string{"name", "current_runs", "current_pass_percentage", "previous_pass_percentage", "net_improvement"},
+	},
+	{
+		name:    "job-runs",
+		short:   "List job runs",
+		path:    "/api/jobs/runs",
+		columns: []string{"id", "job", "overall_result", "test_failures", "url"},
+	},
+	{
+		name:    "variants",
+		short:   "Report job pass rates by variant",
+		path:    "/api/variants",
+		columns: []string{"name", "current_runs", "current_pass_percentage", "previous_pass_percentage", "net_improvement"},
+	},
+}
+
+type QueryFlags struct {
+	SippyURL  string
+	Release   string
+	Filters   []string
+	FilterOr  bool
+	SortField string
+	Sort      string
+	Limit     int
+	Params    []string
+	Columns   []string
+	Output    string
+	Timeout   time.Duration
+}
+
+func NewQueryFlags() *QueryFlags {
+	return &QueryFlags{
+		SippyURL: "https://sippy.dptools.openshift.org",
+		Output:   "table",
+		Timeout:  5 * time.Minute,
+	}
+}
+
+func (f *QueryFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&f.SippyURL, "sippy-url", f.SippyURL, "Sippy server to query")
+	fs.StringVar(&f.Release, "release", f.Release, "Release to report on (i.e. 4.14)")
+	fs.StringArrayVar(&f.Filters, "filter", f.Filters, "Filter of the form '<field> [not] <operator> [value]', i.e. 'name contains aws' or 'current_runs >= 10'. May be repeated, results match all filters")
+	fs.BoolVar(&f.FilterOr, "filter-or", f.FilterOr, "Match results matching any filter rather than all of them")
+	fs.StringVar(&f.SortField, "sort-field", f.SortField, "Field to sort results by")
+	fs.StringVar(&f.Sort, "sort", f.Sort, "Sort direction, asc or desc")
+	fs.IntVar(&f.Limit, "limit", f.Limit, "Maximum number of results, all if zero")
+	fs.StringArrayVar(&f.Params, "param", f.Params, "Additional query parameter of the form key=value, i.e. period=twoDay. May be repeated")
+	fs.StringSliceVar(&f.Columns, "columns", f.Columns, "Fields to show as table columns, a default set for each report if empty")
+	fs.StringVar(&f.Output, "output", f.Output, "Output format, table or json")
+	fs.DurationVar(&f.Timeout, "timeout", f.Timeout, "Timeout for the whole query, including following pagination")
+}
+
+func (f *QueryFlags) Validate() error {
+	if f.Output != "table" && f.Output != "json" {
+		return fmt.Errorf("--output must be table or json, not %q", f.Output)
+	}
+	if f.Sort != "" && f.Sort != "asc" && f.Sort != "desc" {
+		return fmt.Errorf("--sort must be asc or desc, not %q", f.Sort)
+	}
+	return nil
+}
+
+// queryParams returns the query parameters the flags select.
+func (f *QueryFlags) queryParams() (url.Values, error) {
+	params := url.Values{}
+	for _, p := range f.Params {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("--param %q must be of the form key=value", p)
+		}
+		params.Add(k, v)
+	}
+	if f.Release != "" {
+		params.Set("release", f.Release)
+	}
+	if len(f.Filters) > 0 {
+		filterParam, err := sippyclient.FilterParam(f.Filters, f.FilterOr)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("filter", filterParam)
+	}
+	if f.SortField != "" {
+		params.Set("sortField", f.SortField)
+	}
+	if f.Sort != "" {
+		params.Set("sort", f.Sort)
+	}
+	if f.Limit > 0 {
+		params.Set("limit", strconv.Itoa(f.Limit))
+	}
+	return params, nil
+}
+
+func NewQueryCommand() *cobra.Command {
+	f := NewQueryFlags()
+
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Report on tests, jobs and variants from a remote sippy server's API",
+		Long: `Query a remote sippy server's API and render the results as a table, or as json
+for scripting, i.e.:
+
+  sippy query tests --release 4.14 --filter 'name contains [sig-network]' --filter 'current_runs >= 10' \
+    --sort-field current_pass_percentage --sort asc --limit 20`,
+	}
+	f.BindFlags(cmd.PersistentFlags())
+
+	for _, r := range queryResources {
+		resource := r
+		cmd.AddCommand(&cobra.Command{
+			Use:   resource.name,
+			Short: resource.short,
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if err := f.Validate(); err != nil {
+					return errors.WithMessage(err, "error validating options")
+				}
+				params, err := f.queryParams()
+				if err != nil {
+					return errors.WithMessage(err, "error validating options")
+				}
+
+				ctx, cancel := context.WithTimeout(context.Background(), f.Timeout)
+				defer cancel()
+				rows, err := sippyclient.New(f.SippyURL, f.Timeout).List(ctx, resource.path, params)
+				if err != nil {
+					return errors.WithMessagef(err, "couldn't query %s", resource.name)
+				}
+
+				if f.Output == "json" {
+					encoder := json.NewEncoder(os.Stdout)
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(rows)
+				}
+				columns := f.Columns
+				if len(columns) == 0 {
+					columns = resource.columns
+				}
+				return sippyclient.WriteTable(os.Stdout, rows, columns)
+			},
+		})
+	}
+
+	return cmd
+}
@@ -1,26 +1,42 @@
 package main
 
 import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift/sippy/pkg/alerts"
+	"github.com/openshift/sippy/pkg/api"
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/flags"
+	"github.com/openshift/sippy/pkg/notify"
 	"github.com/openshift/sippy/pkg/sippyserver"
+	"github.com/openshift/sippy/pkg/util"
 )
 
 type RefreshFlags struct {
+	ConfigFlags        *flags.ConfigFlags
 	DBFlags            *flags.PostgresFlags
+	MatViewFlags       *flags.MatViewRefreshFlags
 	RefreshOnlyIfEmpty bool
 }
 
 func NewRefreshFlags() *RefreshFlags {
 	return &RefreshFlags{
-		DBFlags: flags.NewPostgresDatabaseFlags(),
+		ConfigFlags:  flags.NewConfigFlags(),
+		DBFlags:      flags.NewPostgresDatabaseFlags(),
+		MatViewFlags: flags.NewMatViewRefreshFlags(),
 	}
 }
 
 func (f *RefreshFlags) BindFlags(fs *pflag.FlagSet) {
+	f.ConfigFlags.BindFlags(fs)
 	f.DBFlags.BindFlags(fs)
+	f.MatViewFlags.BindFlags(fs)
 	fs.BoolVar(&f.RefreshOnlyIfEmpty, "refresh-only-if-empty", f.RefreshOnlyIfEmpty, "only refresh matviews if they're empty")
 }
 
@@ -31,13 +47,21 @@ func NewRefreshCommand() *cobra.Command {
 		Use:   "refresh",
 		Short: "Refresh data in database such as materialized views",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := f.ConfigFlags.GetConfig()
+			if err != nil {
+				return err
+			}
 			dbc, err := f.DBFlags.GetDBClient()
 			if err != nil {
 				return err
 			}
 			pinnedDateTime := f.DBFlags.GetPinnedTime()
-			sippyserver.RefreshData(dbc, pinnedDateTime, f.RefreshOnlyIfEmpty)
-			return nil
+			sippyserver.RefreshData(cmd.Context(), dbc, pinnedDateTime, f.MatViewFlags.GetRefreshOptions(f.RefreshOnlyIfEmpty))
+			recordTableSizes(dbc)
+			if err := evaluateAlerts(cmd.Context(), dbc, config, pinnedDateTime); err != nil {
+				return err
+			}
+			return sendWatchlistDigests(cmd.Context(), dbc, config)
 		},
 	}
 
@@ -45,3 +69,40 @@ func NewRefreshCommand() *cobra.Command {
 
 	return cmd
 }
+
+// evaluateAlerts checks any configured alert rules against freshly refreshed data.
+func evaluateAlerts(ctx context.Context, dbc *db.DB, config *v1.SippyConfig, pinnedDateTime *time.Time) error {
+	if len(config.Alerting.Rules) == 0 {
+		return nil
+	}
+	notifier, err := notify.New(config)
+	if err != nil {
+		return err
+	}
+	evaluator, err := alerts.New(dbc, config.Alerting, notifier)
+	if err != nil {
+		return err
+	}
+	_, err = evaluator.Evaluate(ctx, util.GetReportEnd(pinnedDateTime))
+	return err
+}
+
+// recordTableSizes snapshots table sizes to measure their growth, a failure only being logged as it doesn't affect
+// the data.
+func recordTableSizes(dbc *db.DB) {
+	if err := api.RecordTableSizes(dbc, time.Now()); err != nil {
+		log.WithError(err).Warn("error recording table sizes")
+	}
+}
+
+// sendWatchlistDigests sends the watchlist digests that are due, once data has been refreshed.
+func sendWatchlistDigests(ctx context.Context, dbc *db.DB, config *v1.SippyConfig) error {
+	if len(config.Notifications.Routes) == 0 {
+		return nil
+	}
+	notifier, err := notify.New(config)
+	if err != nil {
+		return err
+	}
+	return api.SendWatchlistDigests(ctx, dbc, notifier, time.Now())
+}
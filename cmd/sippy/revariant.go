@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	bqcachedclient "github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/dataloader/variantsyncer"
+	"github.com/openshift/sippy/pkg/flags"
+	"github.com/openshift/sippy/pkg/sippyserver"
+)
+
+type RevariantFlags struct {
+	BigQueryFlags    *flags.BigQueryFlags
+	DBFlags          *flags.PostgresFlags
+	GoogleCloudFlags *flags.GoogleCloudFlags
+	MatViewFlags     *flags.MatViewRefreshFlags
+	ModeFlags        *flags.ModeFlags
+	DryRun           bool
+}
+
+func NewRevariantFlags() *RevariantFlags {
+	return &RevariantFlags{
+		BigQueryFlags:    flags.NewBigQueryFlags(),
+		DBFlags:          flags.NewPostgresDatabaseFlags(),
+		GoogleCloudFlags: flags.NewGoogleCloudFlags(),
+		MatViewFlags:     flags.NewMatViewRefreshFlags(),
+		ModeFlags:        flags.NewModeFlags(),
+	}
+}
+
+func (f *RevariantFlags) BindFlags(fs *pflag.FlagSet) {
+	f.BigQueryFlags.BindFlags(fs)
+	f.DBFlags.BindFlags(fs)
+	f.GoogleCloudFlags.BindFlags(fs)
+	f.MatViewFlags.BindFlags(fs)
+	f.ModeFlags.BindFlags(fs)
+	fs.BoolVar(&f.DryRun, "dry-run", f.DryRun, "print the variant changes without updating any jobs")
+}
+
+func NewRevariantCommand() *cobra.Command {
+	f := NewRevariantFlags()
+
+	cmd := &cobra.Command{
+		Use:   "revariant",
+		Short: "Recompute the variants of all jobs using the current variant rules",
+		Long:  "Jobs keep the variants they were given when first loaded. After the variant rules change, this recomputes the variants of every job in the database, prints the differences, and unless --dry-run is set updates the jobs and refreshes the materialized views.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.ModeFlags.Validate(); err != nil {
+				return err
+			}
+			dbc, err := f.DBFlags.GetDBClient()
+			if err != nil {
+				return errors.WithMessage(err, "could not connect to db")
+			}
+
+			var bqc *bqcachedclient.Client
+			if f.ModeFlags.Mode == flags.ModeOpenshift {
+				bqc, err = f.BigQueryFlags.GetBigQueryClient(context.Background(), nil, f.GoogleCloudFlags.ServiceAccountCredentialFile)
+				if err != nil {
+					return errors.WithMessage(err, "could not get bigquery client")
+				}
+			}
+
+			syncer := variantsyncer.NewWithManager(dbc, f.ModeFlags.GetVariantManager(cmd.Context(), bqc))
+			changes := syncer.Diff()
+			for _, c := range changes {
+				fmt.Printf("%s (%s)\n  - %s\n  + %s\n", c.Job, c.Release, strings.Join(c.Original, ","), strings.Join(c.Updated, ","))
+			}
+			fmt.Printf("%d jobs with changed variants\n", len(changes))
+			if f.DryRun || len(changes) == 0 {
+				return nil
+			}
+
+			if err := syncer.Apply(cmd.Context(), changes); err != nil {
+				return errors.WithMessage(err, "could not update job variants")
+			}
+			sippyserver.RefreshData(cmd.Context(), dbc, f.DBFlags.GetPinnedTime(), f.MatViewFlags.GetRefreshOptions(false))
+			return nil
+		},
+	}
+
+	f.BindFlags(cmd.Flags())
+
+	return cmd
+}
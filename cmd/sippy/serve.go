@@ -2,23 +2,37 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io/fs"
 	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	resources "github.com/openshift/sippy"
+	"github.com/openshift/sippy/pkg/api"
+	apitype "github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/apis/cache"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
 	"github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/dataloader/autoloader"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/flags"
+	"github.com/openshift/sippy/pkg/grpcapi"
+	"github.com/openshift/sippy/pkg/leaderelection"
 	"github.com/openshift/sippy/pkg/sippyserver"
 	"github.com/openshift/sippy/pkg/sippyserver/metrics"
 	"github.com/openshift/sippy/pkg/util"
@@ -27,33 +41,59 @@ import (
 type ServerFlags struct {
 	BigQueryFlags           *flags.BigQueryFlags
 	CacheFlags              *flags.CacheFlags
+	ConfigFlags             *flags.ConfigFlags
 	DBFlags                 *flags.PostgresFlags
 	GoogleCloudFlags        *flags.GoogleCloudFlags
 	ModeFlags               *flags.ModeFlags
 	ProwFlags               *flags.ProwFlags
 	ComponentReadinessFlags *flags.ComponentReadinessFlags
 
-	ListenAddr  string
-	MetricsAddr string
+	ListenAddr       string
+	MetricsAddr      string
+	GRPCAddr         string
+	RequireAPITokens bool
+	// PromotionWarningThreshold is how long a payload stream can go without promoting before sippy warns about it.
+	PromotionWarningThreshold time.Duration
+	// ReportTemplateRole is the database role report templates run as, granted SELECT on the matviews only.
+	ReportTemplateRole string
+	EnableProfiling    bool
+
+	// UIDevProxy is a frontend dev server URL to proxy the UI to, or a directory to serve it from, instead of the
+	// frontend embedded in the binary.
+	UIDevProxy string
+
+	// PDFRenderer is the headless renderer command, e.g. wkhtmltopdf or chromium, used to export reports as PDF.
+	PDFRenderer string
+
+	// FeatureFlags roll out experimental features, as name=percentage or just the name for everyone.
+	FeatureFlags []string
+
+	// AutoLoadInterval is how often the server loads data itself, disabled if zero.
+	AutoLoadInterval time.Duration
+	AutoLoadFlags    *LoadFlags
 }
 
 func NewServerFlags() *ServerFlags {
 	return &ServerFlags{
-		BigQueryFlags:           flags.NewBigQueryFlags(),
-		CacheFlags:              flags.NewCacheFlags(),
-		DBFlags:                 flags.NewPostgresDatabaseFlags(),
-		GoogleCloudFlags:        flags.NewGoogleCloudFlags(),
-		ModeFlags:               flags.NewModeFlags(),
-		ProwFlags:               flags.NewProwFlags(),
-		ComponentReadinessFlags: flags.NewComponentReadinessFlags(),
-		ListenAddr:              ":8080",
-		MetricsAddr:             ":2112",
+		BigQueryFlags:             flags.NewBigQueryFlags(),
+		CacheFlags:                flags.NewCacheFlags(),
+		ConfigFlags:               flags.NewConfigFlags(),
+		DBFlags:                   flags.NewPostgresDatabaseFlags(),
+		GoogleCloudFlags:          flags.NewGoogleCloudFlags(),
+		ModeFlags:                 flags.NewModeFlags(),
+		ProwFlags:                 flags.NewProwFlags(),
+		ComponentReadinessFlags:   flags.NewComponentReadinessFlags(),
+		ListenAddr:                ":8080",
+		MetricsAddr:               ":2112",
+		PromotionWarningThreshold: api.DefaultPromotionWarningThreshold,
+		AutoLoadFlags:             NewLoadFlags(),
 	}
 }
 
 func (f *ServerFlags) BindFlags(flagSet *pflag.FlagSet) {
 	f.BigQueryFlags.BindFlags(flagSet)
 	f.CacheFlags.BindFlags(flagSet)
+	f.ConfigFlags.BindFlags(flagSet)
 	f.DBFlags.BindFlags(flagSet)
 	f.GoogleCloudFlags.BindFlags(flagSet)
 	f.ModeFlags.BindFlags(flagSet)
@@ -62,10 +102,47 @@ func (f *ServerFlags) BindFlags(flagSet *pflag.FlagSet) {
 
 	flagSet.StringVar(&f.ListenAddr, "listen", f.ListenAddr, "The address to serve analysis reports on (default :8080)")
 	flagSet.StringVar(&f.MetricsAddr, "listen-metrics", f.MetricsAddr, "The address to serve prometheus metrics on (default :2112)")
+	flagSet.StringVar(&f.GRPCAddr, "listen-grpc", f.GRPCAddr, "The address to serve the gRPC API on, disabled if empty")
+	flagSet.BoolVar(&f.EnableProfiling, "enable-profiling", f.EnableProfiling, "Serve pprof endpoints under /debug/pprof/ and database connection pool metrics on the metrics listener")
+	flagSet.StringVar(&f.UIDevProxy, "ui-dev-proxy", f.UIDevProxy, "For frontend development, proxy the UI to this dev server URL (e.g. http://localhost:3000) or serve it from this directory (e.g. sippy-ng/build) instead of the embedded build")
+	flagSet.StringVar(&f.PDFRenderer, "pdf-renderer", f.PDFRenderer, "Headless renderer command used to export reports as PDF, e.g. wkhtmltopdf or chromium; PDF export is disabled if empty")
+	flagSet.StringArrayVar(&f.FeatureFlags, "feature-flag", f.FeatureFlags, "Roll out an experimental feature to a percentage of clients, as name=percentage, or just the name for all of them; may be repeated. See /api/flags")
+	flagSet.StringVar(&f.ReportTemplateRole, "report-template-role", f.ReportTemplateRole, "Database role report templates run as, which should be granted SELECT on the materialized views only and of which sippy's role is a member")
+	flagSet.DurationVar(&f.PromotionWarningThreshold, "promotion-warning-threshold", f.PromotionWarningThreshold, "Warn about payload streams that have not promoted for longer than this")
+	flagSet.BoolVar(&f.RequireAPITokens, "require-api-tokens", f.RequireAPITokens, "Require an API token for endpoints that change state, admin endpoints always require one; see sippy api-token")
+
+	// The scheduled load shares the server's config, database, cloud and mode flags; only load specific flags are
+	// bound here.
+	f.AutoLoadFlags.BigQueryFlags = f.BigQueryFlags
+	f.AutoLoadFlags.ConfigFlags = f.ConfigFlags
+	f.AutoLoadFlags.DBFlags = f.DBFlags
+	f.AutoLoadFlags.GoogleCloudFlags = f.GoogleCloudFlags
+	f.AutoLoadFlags.ModeFlags = f.ModeFlags
+	f.AutoLoadFlags.GithubCommenterFlags.BindFlags(flagSet)
+	f.AutoLoadFlags.MatViewFlags.BindFlags(flagSet)
+	flagSet.DurationVar(&f.AutoLoadInterval, "auto-load-interval", f.AutoLoadInterval, "Periodically load data, refresh materialized views and sync bugs on this interval, disabled if zero. With multiple replicas only the elected leader loads")
+	flagSet.StringArrayVar(&f.AutoLoadFlags.Loaders, "auto-load-loader", []string{"prow", "releases", "jira", "github", "bugs", "test-mapping"}, "Which data sources to use for scheduled data loading")
+	flagSet.StringArrayVar(&f.AutoLoadFlags.Releases, "auto-load-release", f.AutoLoadFlags.Releases, "Which releases to load on schedule (one per arg instance), defaults to every release in the config")
+	flagSet.StringArrayVar(&f.AutoLoadFlags.Architectures, "auto-load-arch", f.AutoLoadFlags.Architectures, "Which architectures to load on schedule (one per arg instance)")
+	flagSet.BoolVar(&f.AutoLoadFlags.LoadOpenShiftCIBigQuery, "auto-load-openshift-ci-bigquery", false, "Load ProwJobs from OpenShift CI BigQuery on schedule")
 }
 
 func (f *ServerFlags) Validate() error {
 	// TODO: Validate other flags
+	if err := f.ModeFlags.Validate(); err != nil {
+		return err
+	}
+	if f.AutoLoadInterval < 0 {
+		return errors.New("--auto-load-interval must not be negative")
+	}
+	if f.PromotionWarningThreshold <= 0 {
+		return errors.New("--promotion-warning-threshold must be positive")
+	}
+	if f.UIDevProxy != "" && !isURL(f.UIDevProxy) {
+		if info, err := os.Stat(f.UIDevProxy); err != nil || !info.IsDir() {
+			return fmt.Errorf("--ui-dev-proxy must be a URL or a directory: %s", f.UIDevProxy)
+		}
+	}
 	return f.ProwFlags.Validate()
 }
 
@@ -80,11 +157,38 @@ func NewServeCommand() *cobra.Command {
 				return errors.WithMessage(err, "error validating options")
 			}
 
+			// Background work such as scheduled loads stops when the server is asked to shut down.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
 			dbc, err := f.DBFlags.GetDBClient()
 			if err != nil {
 				return errors.WithMessage(err, "couldn't get DB client")
 			}
 
+			// Refuse to serve against a schema older than this version of sippy expects
+			if err := dbc.CheckSchemaVersion(); err != nil {
+				return err
+			}
+
+			// When several replicas share the database, only the one holding the leader lock does the background
+			// work: scheduled loads, metrics refreshes and BigQuery scans.
+			sqlDB, err := dbc.DB.DB()
+			if err != nil {
+				return errors.WithMessage(err, "couldn't get database connection pool")
+			}
+			identity, err := os.Hostname()
+			if err != nil {
+				return errors.WithMessage(err, "couldn't get hostname for leader election")
+			}
+			// Advisory locks are database wide, environments in other schemas elect their own leader.
+			election := "background"
+			if dbc.Schema != "" {
+				election = dbc.Schema + "/" + election
+			}
+			elector := leaderelection.New(sqlDB, election, identity)
+			elector.Start(ctx)
+
 			cacheClient, err := f.CacheFlags.GetCacheClient()
 			if err != nil {
 				return errors.WithMessage(err, "couldn't get cache client")
@@ -123,6 +227,10 @@ func NewServeCommand() *cobra.Command {
 			if err != nil {
 				log.WithError(err).Fatal("could not load frontend")
 			}
+			if f.UIDevProxy != "" && !isURL(f.UIDevProxy) {
+				log.Infof("serving the frontend from %s", f.UIDevProxy)
+				webRoot = os.DirFS(f.UIDevProxy)
+			}
 
 			pinnedDateTime := f.DBFlags.GetPinnedTime()
 
@@ -151,12 +259,106 @@ func NewServeCommand() *cobra.Command {
 				views,
 			)
 
-			if f.MetricsAddr != "" {
-				// Do an immediate metrics update
-				err = metrics.RefreshMetricsDB(context.Background(), dbc, bigQueryClient, f.ProwFlags.URL, f.GoogleCloudFlags.StorageBucket, variantManager, util.GetReportEnd(pinnedDateTime), cache.RequestOptions{CRTimeRoundingFactor: f.ComponentReadinessFlags.CRTimeRoundingFactor}, views.ComponentReadiness)
+			// Warm the component readiness test variants cache on the leader. Leadership is checked on every tick,
+			// as it may not have been won yet, or may move from a replica being replaced during a rollout. Scans
+			// are served from the cache until it expires.
+			go func() {
+				ticker := time.NewTicker(5 * time.Minute)
+				defer ticker.Stop()
+				for {
+					if elector.IsLeader() {
+						server.ScanComponentTestVariants(ctx)
+					} else {
+						log.Debug("not the leader, skipping component test variants scan")
+					}
+					select {
+					case <-ticker.C:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			refreshMetrics := func() {
+				if !elector.IsLeader() {
+					log.Debug("not the leader, skipping metrics refresh")
+					return
+				}
+				err := metrics.RefreshMetricsDB(context.Background(), dbc, bigQueryClient, f.ProwFlags.URL, f.GoogleCloudFlags.StorageBucket, variantManager, f.PromotionWarningThreshold, util.GetReportEnd(pinnedDateTime), cache.RequestOptions{CRTimeRoundingFactor: f.ComponentReadinessFlags.CRTimeRoundingFactor}, server.GetViews().ComponentReadiness)
 				if err != nil {
 					log.WithError(err).Error("error refreshing metrics")
 				}
+				server.RefreshSLOMetrics()
+			}
+
+			server.SetRequireAPITokens(f.RequireAPITokens)
+			server.SetRefreshOptions(f.AutoLoadFlags.MatViewFlags.GetRefreshOptions(false))
+			server.SetPromotionWarningThreshold(f.PromotionWarningThreshold)
+			if isURL(f.UIDevProxy) {
+				target, err := url.Parse(f.UIDevProxy)
+				if err != nil {
+					return errors.WithMessage(err, "invalid --ui-dev-proxy")
+				}
+				server.SetUIDevProxy(target)
+			}
+
+			if f.PDFRenderer != "" {
+				server.SetPDFRenderer(f.PDFRenderer)
+			}
+
+			rollouts, err := api.ParseFeatureFlags(f.FeatureFlags)
+			if err != nil {
+				return errors.WithMessage(err, "invalid --feature-flag")
+			}
+			if err := server.SetFeatureFlags(rollouts); err != nil {
+				return err
+			}
+
+			sippyConfig, err := f.ConfigFlags.GetConfig()
+			if err != nil {
+				return err
+			}
+			server.SetConfig(sippyConfig)
+			server.SetIndicators(sippyConfig.Indicators)
+			if err := server.SetReportTemplates(sippyConfig.ReportTemplates, f.ReportTemplateRole); err != nil {
+				return errors.WithMessage(err, "invalid report templates")
+			}
+			if err := server.SetSLOs(sippyConfig.SLOs); err != nil {
+				return errors.WithMessage(err, "invalid SLOs")
+			}
+
+			// Allow configuration to be reloaded without downtime, either with SIGHUP or the admin API. Newly
+			// added views have their data loaded via a metrics refresh, and newly added releases and prow
+			// deployments by the next scheduled load.
+			server.SetConfigReloader(func() (*apitype.SippyViews, *v1config.SippyConfig, error) {
+				views, err := f.ComponentReadinessFlags.ParseViewsFile()
+				if err != nil {
+					return nil, nil, err
+				}
+				config, err := f.ConfigFlags.GetConfig()
+				if err != nil {
+					return nil, nil, err
+				}
+				return views, config, nil
+			}, func() {
+				if f.MetricsAddr != "" {
+					refreshMetrics()
+				}
+			})
+			hup := make(chan os.Signal, 1)
+			signal.Notify(hup, syscall.SIGHUP)
+			go func() {
+				for range hup {
+					log.Info("received SIGHUP, reloading configuration")
+					if err := server.ReloadConfig(); err != nil {
+						log.WithError(err).Error("error reloading configuration")
+					}
+				}
+			}()
+
+			if f.MetricsAddr != "" {
+				// Do an immediate metrics update
+				refreshMetrics()
 
 				// Refresh our metrics every 5 minutes:
 				ticker := time.NewTicker(5 * time.Minute)
@@ -166,10 +368,7 @@ func NewServeCommand() *cobra.Command {
 						select {
 						case <-ticker.C:
 							log.Info("tick")
-							err := metrics.RefreshMetricsDB(context.Background(), dbc, bigQueryClient, f.ProwFlags.URL, f.GoogleCloudFlags.StorageBucket, variantManager, util.GetReportEnd(pinnedDateTime), cache.RequestOptions{CRTimeRoundingFactor: f.ComponentReadinessFlags.CRTimeRoundingFactor}, views.ComponentReadiness)
-							if err != nil {
-								log.WithError(err).Error("error refreshing metrics")
-							}
+							refreshMetrics()
 						case <-quit:
 							ticker.Stop()
 							return
@@ -177,16 +376,52 @@ func NewServeCommand() *cobra.Command {
 					}
 				}()
 
+				// Go runtime memory, GC and goroutine metrics are always exported, when profiling we add the
+				// database connection pool as well.
+				if f.EnableProfiling {
+					sqlDB, err := dbc.DB.DB()
+					if err != nil {
+						return errors.WithMessage(err, "couldn't get database connection pool")
+					}
+					prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, "sippy"))
+				}
+
 				// Serve our metrics endpoint for prometheus to scrape
 				go func() {
-					http.Handle("/metrics", promhttp.Handler())
-					err := http.ListenAndServe(f.MetricsAddr, nil) // nolint
+					err := http.ListenAndServe(f.MetricsAddr, sippyserver.NewMetricsHandler(f.EnableProfiling)) // nolint
 					if err != nil {
 						panic(err)
 					}
 				}()
 			}
 
+			if f.AutoLoadInterval > 0 {
+				loader := autoloader.New(f.AutoLoadInterval, func(ctx context.Context) error {
+					return autoLoadFlags(f.AutoLoadFlags, server.GetConfig()).Run(ctx)
+				})
+				loader.SetLeaderCheck(elector.IsLeader)
+
+				go loader.Run(ctx)
+			}
+
+			if f.GRPCAddr != "" {
+				go func() {
+					if err := grpcapi.NewServer(dbc, pinnedDateTime, server.GetIndicators, f.ModeFlags.Mode != flags.ModeNone, f.PromotionWarningThreshold).Serve(f.GRPCAddr); err != nil {
+						log.WithError(err).Fatal("error serving gRPC API")
+					}
+				}()
+			}
+
+			go func() {
+				<-ctx.Done()
+				log.Info("shutting down")
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				if err := server.Shutdown(shutdownCtx); err != nil {
+					log.WithError(err).Warning("error shutting down the server")
+				}
+			}()
+
 			server.Serve()
 			return nil
 		},
@@ -195,3 +430,23 @@ func NewServeCommand() *cobra.Command {
 	f.BindFlags(cmd.Flags())
 	return cmd
 }
+
+// autoLoadFlags returns the flags of a scheduled load using the server's current configuration. Unless releases
+// were given with --auto-load-release, every configured release is loaded, so releases added to the configuration
+// are loaded once it is reloaded.
+func autoLoadFlags(f *LoadFlags, config *v1config.SippyConfig) *LoadFlags {
+	loadFlags := *f
+	loadFlags.Config = config
+	if len(f.Releases) == 0 && config != nil {
+		for release := range config.Releases {
+			loadFlags.Releases = append(loadFlags.Releases, release)
+		}
+		sort.Strings(loadFlags.Releases)
+	}
+	return &loadFlags
+}
+
+// isURL reports whether a --ui-dev-proxy value is a dev server URL rather than a directory.
+func isURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/openshift/sippy/pkg/api"
 	"github.com/openshift/sippy/pkg/api/componentreadiness"
 	"github.com/openshift/sippy/pkg/apis/cache"
-	bqcachedclient "github.com/openshift/sippy/pkg/bigquery"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -54,10 +53,8 @@ func NewTrackRegressionsCommand() *cobra.Command {
 				log.WithError(err).Fatal("couldn't get cache client")
 			}
 
-			bigQueryClient, err := bqcachedclient.New(ctx,
-				f.GoogleCloudFlags.ServiceAccountCredentialFile,
-				f.BigQueryFlags.BigQueryProject,
-				f.BigQueryFlags.BigQueryDataset, cacheClient)
+			bigQueryClient, err := f.BigQueryFlags.GetBigQueryClient(ctx, cacheClient,
+				f.GoogleCloudFlags.ServiceAccountCredentialFile)
 			if err != nil {
 				log.WithError(err).Fatal("CRITICAL error getting BigQuery client which prevents regression tracking")
 			}
@@ -0,0 +1,235 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/push"
+	log "github.com/sirupsen/logrus"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/notify"
+)
+
+const (
+	MetricJobPassPercentage  = "job_pass_percentage"
+	MetricTestPassPercentage = "test_pass_percentage"
+
+	periodDefault = "default"
+	periodTwoDay  = "twoDay"
+)
+
+var alertFiringMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sippy_alert_firing",
+	Help: "Set to 1 when a sippy alert rule is firing, 0 otherwise",
+}, []string{"rule", "release", "variant"})
+
+// Evaluator checks the configured alert rules against the database, records the results, and notifies
+// when a rule begins firing.
+type Evaluator struct {
+	dbc      *db.DB
+	rules    []v1.AlertRule
+	notifier *notify.Notifier
+}
+
+// New returns an Evaluator for the given alerting config, or an error if any rule is invalid. Firing alerts are
+// sent through the notifier.
+func New(dbc *db.DB, config v1.AlertingConfig, notifier *notify.Notifier) (*Evaluator, error) {
+	names := map[string]bool{}
+	for _, rule := range config.Rules {
+		if err := ValidateRule(rule); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate alert rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+
+	return &Evaluator{
+		dbc:      dbc,
+		rules:    config.Rules,
+		notifier: notifier,
+	}, nil
+}
+
+// Message returns a human readable description of a firing alert.
+func Message(result models.AlertResult) string {
+	return fmt.Sprintf("Sippy alert %s is firing: %s for %s variant %s is %.2f over %d runs (alerts when %s %.2f)",
+		result.Rule, result.Metric, result.Release, result.Variant, result.Value, result.Runs,
+		result.Operator, result.Threshold)
+}
+
+// ValidateRule returns an error if the rule is missing required fields or uses an unknown metric,
+// period, or operator.
+func ValidateRule(rule v1.AlertRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("alert rule is missing a name")
+	}
+	if rule.Release == "" || rule.Variant == "" {
+		return fmt.Errorf("alert rule %q must specify a release and variant", rule.Name)
+	}
+	switch rule.Metric {
+	case MetricJobPassPercentage:
+	case MetricTestPassPercentage:
+		if rule.Test == "" {
+			return fmt.Errorf("alert rule %q uses %s but does not specify a test", rule.Name, rule.Metric)
+		}
+	default:
+		return fmt.Errorf("alert rule %q has unknown metric %q", rule.Name, rule.Metric)
+	}
+	switch rule.Period {
+	case "", periodDefault, periodTwoDay:
+	default:
+		return fmt.Errorf("alert rule %q has unknown period %q", rule.Name, rule.Period)
+	}
+	if _, err := compare(rule.Operator, 0, 0); err != nil {
+		return errors.WithMessagef(err, "alert rule %q", rule.Name)
+	}
+	return nil
+}
+
+// Evaluate checks every rule as of reportEnd, stores the results, and notifies for rules which were not
+// previously firing.
+func (e *Evaluator) Evaluate(ctx context.Context, reportEnd time.Time) ([]models.AlertResult, error) {
+	results := make([]models.AlertResult, 0, len(e.rules))
+	if len(e.rules) == 0 {
+		return results, nil
+	}
+
+	previous, err := query.LatestAlertResults(e.dbc)
+	if err != nil {
+		return results, errors.WithMessage(err, "error querying previous alert results")
+	}
+	previouslyFiring := map[string]bool{}
+	for _, p := range previous {
+		previouslyFiring[p.Rule] = p.Firing
+	}
+
+	var errs []error
+	now := time.Now()
+	for _, rule := range e.rules {
+		rLog := log.WithField("rule", rule.Name)
+		runs, value, err := e.measure(rule, reportEnd)
+		if err != nil {
+			rLog.WithError(err).Error("error evaluating alert rule")
+			errs = append(errs, errors.WithMessagef(err, "error evaluating alert rule %q", rule.Name))
+			continue
+		}
+
+		firing, _ := compare(rule.Operator, value, rule.Threshold)
+		firing = firing && runs >= rule.MinRuns
+		result := models.AlertResult{
+			Rule:        rule.Name,
+			Release:     rule.Release,
+			Variant:     rule.Variant,
+			Metric:      rule.Metric,
+			Operator:    rule.Operator,
+			Threshold:   rule.Threshold,
+			Value:       value,
+			Runs:        runs,
+			Firing:      firing,
+			EvaluatedAt: now,
+		}
+		if res := e.dbc.DB.Create(&result); res.Error != nil {
+			errs = append(errs, errors.WithMessagef(res.Error, "error storing result for alert rule %q", rule.Name))
+		}
+		results = append(results, result)
+
+		gauge := 0.0
+		if firing {
+			gauge = 1
+		}
+		alertFiringMetric.WithLabelValues(rule.Name, rule.Release, rule.Variant).Set(gauge)
+		rLog.WithFields(log.Fields{"value": value, "runs": runs, "firing": firing}).Info("evaluated alert rule")
+
+		if !firing && previouslyFiring[rule.Name] {
+			if err := query.ClearAcknowledgements(e.dbc, models.AcknowledgementKindAlert, rule.Name); err != nil {
+				rLog.WithError(err).Error("error clearing acknowledgements of cleared alert")
+			}
+		}
+
+		if firing && !previouslyFiring[rule.Name] {
+			err := e.notifier.Notify(ctx, notify.Message{
+				Source:   notify.SourceAlerts,
+				Name:     rule.Name,
+				Severity: notify.SeverityWarning,
+				Summary:  Message(result),
+				Details:  result,
+			})
+			if err != nil {
+				rLog.WithError(err).Error("error sending alert notification")
+				errs = append(errs, errors.WithMessagef(err, "error notifying for alert rule %q", rule.Name))
+			}
+		}
+	}
+
+	pushMetrics()
+
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d errors evaluating alert rules, see logs for details", len(errs))
+	}
+	return results, nil
+}
+
+func (e *Evaluator) measure(rule v1.AlertRule, reportEnd time.Time) (int, float64, error) {
+	switch rule.Metric {
+	case MetricJobPassPercentage:
+		start, boundary := reportEnd.Add(-14*24*time.Hour), reportEnd.Add(-7*24*time.Hour)
+		if rule.Period == periodTwoDay {
+			start, boundary = reportEnd.Add(-9*24*time.Hour), reportEnd.Add(-2*24*time.Hour)
+		}
+		variants, err := query.VariantReports(e.dbc, rule.Release, start, boundary, reportEnd)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, v := range variants {
+			if v.Name == rule.Variant {
+				return v.CurrentRuns, v.CurrentPassPercentage, nil
+			}
+		}
+		return 0, 0, nil
+	case MetricTestPassPercentage:
+		table := "prow_test_report_7d_matview"
+		if rule.Period == periodTwoDay {
+			table = "prow_test_report_2d_matview"
+		}
+		return query.VariantTestPassRate(e.dbc, table, rule.Release, rule.Variant, rule.Test)
+	}
+	return 0, 0, fmt.Errorf("unknown metric %q", rule.Metric)
+}
+
+// compare returns true if value is on the alerting side of threshold.
+func compare(operator string, value, threshold float64) (bool, error) {
+	switch operator {
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	}
+	return false, fmt.Errorf("unknown operator %q", operator)
+}
+
+// pushMetrics sends the alert metrics to the prometheus pushgateway if one is configured, as evaluation
+// usually happens in a short-lived load or refresh job.
+func pushMetrics() {
+	pushgateway := os.Getenv("SIPPY_PROMETHEUS_PUSHGATEWAY")
+	if pushgateway == "" {
+		return
+	}
+	if err := push.New(pushgateway, "sippy-alerts").Collector(alertFiringMetric).Add(); err != nil {
+		log.WithError(err).Error("could not push alert metrics to prometheus pushgateway")
+	}
+}
@@ -0,0 +1,97 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+func TestValidateRule(t *testing.T) {
+	validRule := v1.AlertRule{
+		Name:      "metal-install",
+		Release:   "4.16",
+		Variant:   "metal",
+		Metric:    MetricTestPassPercentage,
+		Test:      "install should succeed: overall",
+		Operator:  "<",
+		Threshold: 80,
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(r *v1.AlertRule)
+		expectError bool
+	}{
+		{
+			name:   "valid rule",
+			mutate: func(r *v1.AlertRule) {},
+		},
+		{
+			name:        "missing name",
+			mutate:      func(r *v1.AlertRule) { r.Name = "" },
+			expectError: true,
+		},
+		{
+			name:        "test metric without test",
+			mutate:      func(r *v1.AlertRule) { r.Test = "" },
+			expectError: true,
+		},
+		{
+			name:        "unknown metric",
+			mutate:      func(r *v1.AlertRule) { r.Metric = "flake_rate" },
+			expectError: true,
+		},
+		{
+			name:        "unknown operator",
+			mutate:      func(r *v1.AlertRule) { r.Operator = "!=" },
+			expectError: true,
+		},
+		{
+			name:        "unknown period",
+			mutate:      func(r *v1.AlertRule) { r.Period = "month" },
+			expectError: true,
+		},
+		{
+			name: "job metric with two day period",
+			mutate: func(r *v1.AlertRule) {
+				r.Metric = MetricJobPassPercentage
+				r.Test = ""
+				r.Period = periodTwoDay
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := validRule
+			tc.mutate(&rule)
+			err := ValidateRule(rule)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		operator  string
+		value     float64
+		threshold float64
+		expected  bool
+	}{
+		{operator: "<", value: 79.9, threshold: 80, expected: true},
+		{operator: "<", value: 80, threshold: 80, expected: false},
+		{operator: "<=", value: 80, threshold: 80, expected: true},
+		{operator: ">", value: 5, threshold: 10, expected: false},
+		{operator: ">=", value: 10, threshold: 10, expected: true},
+	}
+	for _, tc := range tests {
+		firing, err := compare(tc.operator, tc.value, tc.threshold)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, firing, "%v %s %v", tc.value, tc.operator, tc.threshold)
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// ValidateAcknowledgement checks an acknowledgement is well-formed before we store it.
+func ValidateAcknowledgement(ack models.Acknowledgement, now time.Time) error {
+	switch ack.Kind {
+	case models.AcknowledgementKindAlert, models.AcknowledgementKindDisruptionRegression:
+	default:
+		return fmt.Errorf("kind must be %s or %s", models.AcknowledgementKindAlert, models.AcknowledgementKindDisruptionRegression)
+	}
+	if ack.Target == "" {
+		return fmt.Errorf("target is required")
+	}
+	if ack.User == "" {
+		return fmt.Errorf("user is required")
+	}
+	if ack.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if ack.ExpiresAt != nil && !ack.ExpiresAt.After(now) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+	return nil
+}
+
+// CreateAcknowledgement validates and stores an acknowledgement of a firing alert or current disruption regression,
+// replacing any earlier acknowledgement of it.
+func CreateAcknowledgement(dbc *db.DB, ack models.Acknowledgement, now time.Time) (models.Acknowledgement, error) {
+	ack.ID = 0
+	if err := ValidateAcknowledgement(ack, now); err != nil {
+		return ack, err
+	}
+	if err := checkAcknowledgementTarget(dbc, ack); err != nil {
+		return ack, err
+	}
+	err := dbc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kind = ? AND target = ?", ack.Kind, ack.Target).Delete(&models.Acknowledgement{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&ack).Error
+	})
+	return ack, err
+}
+
+// checkAcknowledgementTarget returns an error unless the target is an alert rule that's firing or a disruption
+// regression that's regressed, there's nothing to acknowledge otherwise.
+func checkAcknowledgementTarget(dbc *db.DB, ack models.Acknowledgement) error {
+	switch ack.Kind {
+	case models.AcknowledgementKindAlert:
+		results, err := query.LatestAlertResults(dbc)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			if r.Rule == ack.Target && r.Firing {
+				return nil
+			}
+		}
+		return fmt.Errorf("alert rule %q is not firing", ack.Target)
+	case models.AcknowledgementKindDisruptionRegression:
+		id, err := strconv.ParseUint(ack.Target, 10, 64)
+		if err != nil {
+			return fmt.Errorf("disruption regression target must be its id")
+		}
+		state := models.DisruptionRegressionState{}
+		if res := dbc.DB.Limit(1).Find(&state, id); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 || !state.Regressed {
+			return fmt.Errorf("disruption regression %d is not regressed", id)
+		}
+	}
+	return nil
+}
+
+// DeleteAcknowledgement removes an acknowledgement, returning it.
+func DeleteAcknowledgement(dbc *db.DB, id uint) (models.Acknowledgement, error) {
+	ack := models.Acknowledgement{}
+	if res := dbc.DB.Limit(1).Find(&ack, id); res.Error != nil {
+		return ack, res.Error
+	} else if res.RowsAffected == 0 {
+		return ack, fmt.Errorf("no acknowledgement with id %d", id)
+	}
+	return ack, dbc.DB.Delete(&ack).Error
+}
+
+// AcknowledgeAlerts sets the acknowledgement of each firing alert that has one as of now.
+func AcknowledgeAlerts(dbc *db.DB, results []models.AlertResult, now time.Time) error {
+	byTarget, err := acknowledgementsByTarget(dbc, models.AcknowledgementKindAlert, now)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		if results[i].Firing {
+			results[i].Acknowledgement = byTarget[results[i].Rule]
+		}
+	}
+	return nil
+}
+
+// AcknowledgeDisruptionRegressions sets the acknowledgement of each regression that has one as of now.
+func AcknowledgeDisruptionRegressions(dbc *db.DB, regressions []models.DisruptionRegressionState, now time.Time) error {
+	byTarget, err := acknowledgementsByTarget(dbc, models.AcknowledgementKindDisruptionRegression, now)
+	if err != nil {
+		return err
+	}
+	for i := range regressions {
+		regressions[i].Acknowledgement = byTarget[strconv.FormatUint(uint64(regressions[i].ID), 10)]
+	}
+	return nil
+}
+
+func acknowledgementsByTarget(dbc *db.DB, kind string, now time.Time) (map[string]*models.Acknowledgement, error) {
+	acks, err := query.ListAcknowledgements(dbc, kind, now)
+	if err != nil {
+		return nil, err
+	}
+	byTarget := make(map[string]*models.Acknowledgement, len(acks))
+	for i := range acks {
+		byTarget[acks[i].Target] = &acks[i]
+	}
+	return byTarget, nil
+}
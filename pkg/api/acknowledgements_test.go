@@ -0,0 +1,85 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestValidateAcknowledgement(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	valid := models.Acknowledgement{
+		Kind:   models.AcknowledgementKindAlert,
+		Target: "aws-ovn-pass-rate",
+		User:   "jdoe",
+		Reason: "OCPBUGS-1 is fixing the install failures",
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(a *models.Acknowledgement)
+		expectError bool
+	}{
+		{
+			name:   "acknowledged",
+			mutate: func(a *models.Acknowledgement) {},
+		},
+		{
+			name: "snoozed",
+			mutate: func(a *models.Acknowledgement) {
+				expires := now.Add(48 * time.Hour)
+				a.ExpiresAt = &expires
+			},
+		},
+		{
+			name: "disruption regression",
+			mutate: func(a *models.Acknowledgement) {
+				a.Kind = models.AcknowledgementKindDisruptionRegression
+				a.Target = "42"
+			},
+		},
+		{
+			name:        "unknown kind",
+			mutate:      func(a *models.Acknowledgement) { a.Kind = "test" },
+			expectError: true,
+		},
+		{
+			name:        "missing target",
+			mutate:      func(a *models.Acknowledgement) { a.Target = "" },
+			expectError: true,
+		},
+		{
+			name:        "missing user",
+			mutate:      func(a *models.Acknowledgement) { a.User = "" },
+			expectError: true,
+		},
+		{
+			name:        "missing reason",
+			mutate:      func(a *models.Acknowledgement) { a.Reason = "" },
+			expectError: true,
+		},
+		{
+			name: "already expired",
+			mutate: func(a *models.Acknowledgement) {
+				expires := now.Add(-time.Hour)
+				a.ExpiresAt = &expires
+			},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ack := valid
+			tt.mutate(&ack)
+			err := ValidateAcknowledgement(ack, now)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
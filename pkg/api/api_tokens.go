@@ -0,0 +1,160 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	// APITokenScopeWrite allows changes such as test suppressions and job lineage overrides.
+	APITokenScopeWrite = "write"
+	// APITokenScopeAdmin allows the admin endpoints, including managing tokens, and implies every other scope.
+	APITokenScopeAdmin = "admin"
+
+	apiTokenPrefix = "sippy_"
+)
+
+// APITokenScopes are the scopes a token can be granted.
+var APITokenScopes = []string{APITokenScopeWrite, APITokenScopeAdmin}
+
+// CreateAPITokenRequest is the body used to create a token.
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPITokenResponse includes the token itself, which cannot be retrieved again.
+type CreateAPITokenResponse struct {
+	models.APIToken
+	Token string `json:"token"`
+}
+
+// Validate checks the request names a token and only grants known scopes.
+func (r CreateAPITokenRequest) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range r.Scopes {
+		if !validAPITokenScope(scope) {
+			return fmt.Errorf("unknown scope %q, must be one of %s", scope, strings.Join(APITokenScopes, ", "))
+		}
+	}
+	if r.ExpiresAt != nil && r.ExpiresAt.Before(time.Now()) {
+		return fmt.Errorf("expires_at is in the past")
+	}
+	return nil
+}
+
+func validAPITokenScope(scope string) bool {
+	for _, s := range APITokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIToken returns the hash stored in place of a token.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + hex.EncodeToString(b), nil
+}
+
+// CreateAPIToken stores a new token and returns it; only its hash is kept in the database.
+func CreateAPIToken(dbc *db.DB, req CreateAPITokenRequest) (CreateAPITokenResponse, error) {
+	if err := req.Validate(); err != nil {
+		return CreateAPITokenResponse{}, err
+	}
+	token, err := generateAPIToken()
+	if err != nil {
+		return CreateAPITokenResponse{}, err
+	}
+	apiToken := models.APIToken{
+		Name:      req.Name,
+		TokenHash: HashAPIToken(token),
+		Scopes:    pq.StringArray(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+	}
+	if res := dbc.DB.Create(&apiToken); res.Error != nil {
+		return CreateAPITokenResponse{}, res.Error
+	}
+	return CreateAPITokenResponse{APIToken: apiToken, Token: token}, nil
+}
+
+// ListAPITokens returns every token, without their hashes.
+func ListAPITokens(dbc *db.DB) ([]models.APIToken, error) {
+	tokens := []models.APIToken{}
+	res := dbc.DB.Order("name").Find(&tokens)
+	return tokens, res.Error
+}
+
+// DeleteAPIToken revokes a token.
+func DeleteAPIToken(dbc *db.DB, id uint) error {
+	res := dbc.DB.Delete(&models.APIToken{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("no api token with id %d", id)
+	}
+	return nil
+}
+
+// BearerToken returns the token from the request's Authorization header, if any.
+func BearerToken(req *http.Request) string {
+	header := req.Header.Get("Authorization")
+	if len(header) > len("Bearer ") && strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(header[len("Bearer "):])
+	}
+	return ""
+}
+
+// AuthenticateAPIToken looks up an unexpired token and records that it was used.
+func AuthenticateAPIToken(dbc *db.DB, token string) (*models.APIToken, error) {
+	apiToken := &models.APIToken{}
+	res := dbc.DB.Where("token_hash = ?", HashAPIToken(token)).Limit(1).Find(apiToken)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, fmt.Errorf("invalid api token")
+	}
+	now := time.Now()
+	if apiToken.ExpiresAt != nil && apiToken.ExpiresAt.Before(now) {
+		return nil, fmt.Errorf("api token %s has expired", apiToken.Name)
+	}
+	dbc.DB.Model(apiToken).UpdateColumn("last_used_at", now)
+	return apiToken, nil
+}
+
+// APITokenHasScope reports whether the token grants scope. Admin tokens have every scope.
+func APITokenHasScope(token *models.APIToken, scope string) bool {
+	for _, s := range token.Scopes {
+		if s == scope || s == APITokenScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestCreateAPITokenRequestValidate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		req     CreateAPITokenRequest
+		wantErr string
+	}{
+		{
+			name: "valid",
+			req:  CreateAPITokenRequest{Name: "release-controller", Scopes: []string{APITokenScopeWrite}},
+		},
+		{
+			name:    "missing name",
+			req:     CreateAPITokenRequest{Scopes: []string{APITokenScopeWrite}},
+			wantErr: "name is required",
+		},
+		{
+			name:    "no scopes",
+			req:     CreateAPITokenRequest{Name: "ci"},
+			wantErr: "at least one scope",
+		},
+		{
+			name:    "unknown scope",
+			req:     CreateAPITokenRequest{Name: "ci", Scopes: []string{"root"}},
+			wantErr: "unknown scope",
+		},
+		{
+			name:    "expired",
+			req:     CreateAPITokenRequest{Name: "ci", Scopes: []string{APITokenScopeAdmin}, ExpiresAt: &past},
+			wantErr: "in the past",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPITokenHasScope(t *testing.T) {
+	write := &models.APIToken{Scopes: []string{APITokenScopeWrite}}
+	admin := &models.APIToken{Scopes: []string{APITokenScopeAdmin}}
+
+	assert.True(t, APITokenHasScope(write, APITokenScopeWrite))
+	assert.False(t, APITokenHasScope(write, APITokenScopeAdmin))
+	assert.True(t, APITokenHasScope(admin, APITokenScopeWrite))
+	assert.True(t, APITokenHasScope(admin, APITokenScopeAdmin))
+}
+
+func TestGenerateAPIToken(t *testing.T) {
+	a, err := generateAPIToken()
+	assert.NoError(t, err)
+	b, err := generateAPIToken()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(a, apiTokenPrefix))
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, HashAPIToken(a), HashAPIToken(b))
+	assert.Len(t, HashAPIToken(a), 64)
+}
+
+func TestBearerToken(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/admin/reload", nil)
+	assert.Equal(t, "", BearerToken(req))
+
+	req.Header.Set("Authorization", "Bearer sippy_abc")
+	assert.Equal(t, "sippy_abc", BearerToken(req))
+
+	req.Header.Set("Authorization", "Basic Zm9vOmJhcg==")
+	assert.Equal(t, "", BearerToken(req))
+}
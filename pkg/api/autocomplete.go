@@ -57,7 +57,7 @@ func PrintAutocompleteFromDB(w http.ResponseWriter, req *http.Request, dbc *db.D
 			Select("name").
 			Order("name")
 	default:
-		RespondWithJSON(404, w, map[string]string{"message": "Autocomplete field not found."})
+		RespondWithError(w, http.StatusNotFound, "Autocomplete field not found.")
 	}
 
 	if release != "" {
@@ -71,7 +71,7 @@ func PrintAutocompleteFromDB(w http.ResponseWriter, req *http.Request, dbc *db.D
 
 	q = q.Limit(50).Scan(&result)
 	if q.Error != nil {
-		RespondWithJSON(503, w, map[string]string{"message": q.Error.Error()})
+		RespondWithError(w, http.StatusServiceUnavailable, q.Error.Error())
 		return
 	}
 
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// ErrBugNotFound is returned when the requested bug is not known to sippy.
+var ErrBugNotFound = fmt.Errorf("bug not found")
+
+// GetBugImpactFromDB lists the job runs that failed a test linked to the bug, which may be given by its jira key or
+// ID, between start and end. Runs on or after boundary are counted as current, earlier runs as previous.
+func GetBugImpactFromDB(dbc *db.DB, bug, release string, start, boundary, end time.Time) (apitype.BugImpact, error) {
+	dbBug := models.Bug{}
+	q := dbc.DB.Where("key = ?", bug)
+	if id, err := strconv.ParseUint(bug, 10, 64); err == nil {
+		q = dbc.DB.Where("id = ?", id)
+	}
+	res := q.Limit(1).Find(&dbBug)
+	if res.Error != nil {
+		return apitype.BugImpact{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apitype.BugImpact{}, ErrBugNotFound
+	}
+
+	runs, err := query.BugImpactJobRuns(dbc, dbBug.ID, release, start, end)
+	if err != nil {
+		return apitype.BugImpact{}, err
+	}
+
+	impact := groupBugImpact(runs, boundary)
+	impact.Bug = dbBug.Key
+	impact.Start = start
+	impact.Boundary = boundary
+	impact.End = end
+	return impact, nil
+}
+
+// groupBugImpact groups the runs by job, most impacted first.
+func groupBugImpact(runs []apitype.BugImpactJobRun, boundary time.Time) apitype.BugImpact {
+	impact := apitype.BugImpact{Jobs: []apitype.BugImpactGroup{}}
+	byJob := map[string]*apitype.BugImpactGroup{}
+	for _, run := range runs {
+		group, ok := byJob[run.ProwJobName]
+		if !ok {
+			group = &apitype.BugImpactGroup{
+				Job:      run.ProwJobName,
+				Release:  run.Release,
+				Variants: run.Variants,
+			}
+			byJob[run.ProwJobName] = group
+		}
+		if run.Timestamp.Before(boundary) {
+			group.PreviousRuns++
+			impact.PreviousRuns++
+		} else {
+			group.CurrentRuns++
+			impact.CurrentRuns++
+		}
+		group.JobRuns = append(group.JobRuns, run)
+	}
+
+	for _, group := range byJob {
+		impact.Jobs = append(impact.Jobs, *group)
+	}
+	sort.Slice(impact.Jobs, func(i, j int) bool {
+		a, b := impact.Jobs[i], impact.Jobs[j]
+		if a.CurrentRuns+a.PreviousRuns != b.CurrentRuns+b.PreviousRuns {
+			return a.CurrentRuns+a.PreviousRuns > b.CurrentRuns+b.PreviousRuns
+		}
+		return a.Job < b.Job
+	})
+	return impact
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestGroupBugImpact(t *testing.T) {
+	boundary := time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)
+	before := boundary.Add(-24 * time.Hour)
+	after := boundary.Add(24 * time.Hour)
+
+	runs := []apitype.BugImpactJobRun{
+		{ProwJobRunID: 1, ProwJobName: "aws", Release: "4.16", Variants: []string{"aws"}, Timestamp: after},
+		{ProwJobRunID: 2, ProwJobName: "gcp", Release: "4.16", Variants: []string{"gcp"}, Timestamp: after},
+		{ProwJobRunID: 3, ProwJobName: "aws", Release: "4.16", Variants: []string{"aws"}, Timestamp: before},
+		{ProwJobRunID: 4, ProwJobName: "aws", Release: "4.16", Variants: []string{"aws"}, Timestamp: boundary},
+	}
+
+	impact := groupBugImpact(runs, boundary)
+	assert.Equal(t, 3, impact.CurrentRuns)
+	assert.Equal(t, 1, impact.PreviousRuns)
+	if assert.Len(t, impact.Jobs, 2) {
+		assert.Equal(t, "aws", impact.Jobs[0].Job)
+		assert.Equal(t, 2, impact.Jobs[0].CurrentRuns)
+		assert.Equal(t, 1, impact.Jobs[0].PreviousRuns)
+		assert.Len(t, impact.Jobs[0].JobRuns, 3)
+		assert.Equal(t, []string{"aws"}, impact.Jobs[0].Variants)
+		assert.Equal(t, "gcp", impact.Jobs[1].Job)
+		assert.Equal(t, 1, impact.Jobs[1].CurrentRuns)
+	}
+
+	assert.Empty(t, groupBugImpact(nil, boundary).Jobs)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/util"
+)
+
+const (
+	BugResolutionByComponent = "component"
+	BugResolutionByRelease   = "release"
+
+	unknownBugGroup = "Unknown"
+)
+
+// bugVersionRegexp matches the X.Y release of a jira version such as 4.16, 4.16.z or 4.16.0.
+var bugVersionRegexp = regexp.MustCompile(`^(\d+\.\d+)(\.|$)`)
+
+// GetBugResolutionStatsFromDB reports how long the bugs linked to tests or jobs stay open, grouped by component or
+// by affected release, optionally only for bugs affecting one release.
+func GetBugResolutionStatsFromDB(dbc *db.DB, groupBy, release string, now time.Time) ([]apitype.BugResolutionStats, error) {
+	if groupBy != BugResolutionByComponent && groupBy != BugResolutionByRelease {
+		return nil, fmt.Errorf("unknown bug grouping %q", groupBy)
+	}
+
+	bugs := []models.Bug{}
+	res := dbc.DB.Where("opened_time IS NOT NULL").
+		Where("(EXISTS (SELECT 1 FROM bug_tests WHERE bug_tests.bug_id = bugs.id) OR EXISTS (SELECT 1 FROM bug_jobs WHERE bug_jobs.bug_id = bugs.id))").
+		Find(&bugs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return groupBugResolutions(bugs, groupBy, release, now), nil
+}
+
+// groupBugResolutions summarizes the resolution times of the bugs in each group, slowest to resolve first. A bug
+// with several components or releases counts towards each of them.
+func groupBugResolutions(bugs []models.Bug, groupBy, release string, now time.Time) []apitype.BugResolutionStats {
+	resolved := map[string][]float64{}
+	open := map[string][]float64{}
+	for _, bug := range bugs {
+		if bug.OpenedTime == nil {
+			continue
+		}
+		releases := bugReleases(bug.AffectsVersions)
+		if release != "" && !util.StrSliceContains(releases, release) {
+			continue
+		}
+
+		groups := releases
+		if groupBy == BugResolutionByComponent {
+			groups = bug.Components
+		}
+		if len(groups) == 0 {
+			groups = []string{unknownBugGroup}
+		}
+		for _, group := range groups {
+			if bug.ResolvedTime != nil {
+				resolved[group] = append(resolved[group], bug.ResolvedTime.Sub(*bug.OpenedTime).Hours())
+			} else {
+				open[group] = append(open[group], now.Sub(*bug.OpenedTime).Hours())
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for name := range resolved {
+		names[name] = true
+	}
+	for name := range open {
+		names[name] = true
+	}
+
+	results := make([]apitype.BugResolutionStats, 0, len(names))
+	for name := range names {
+		result := apitype.BugResolutionStats{
+			Name:          name,
+			ResolvedBugs:  len(resolved[name]),
+			OpenBugs:      len(open[name]),
+			MeanOpenHours: mean(open[name]),
+		}
+		if len(resolved[name]) > 0 {
+			result.MeanHoursToResolve = mean(resolved[name])
+			result.MedianHoursToResolve, _ = stats.Median(resolved[name])
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].MeanHoursToResolve != results[j].MeanHoursToResolve {
+			return results[i].MeanHoursToResolve > results[j].MeanHoursToResolve
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// bugReleases returns the distinct X.Y releases of the bug's jira versions.
+func bugReleases(versions []string) []string {
+	releases := []string{}
+	for _, v := range versions {
+		m := bugVersionRegexp.FindStringSubmatch(v)
+		if m != nil && !util.StrSliceContains(releases, m[1]) {
+			releases = append(releases, m[1])
+		}
+	}
+	return releases
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestGroupBugResolutions(t *testing.T) {
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	at := func(hoursAgo int) *time.Time {
+		t := now.Add(-time.Duration(hoursAgo) * time.Hour)
+		return &t
+	}
+	bugs := []models.Bug{
+		{Key: "OCPBUGS-1", Components: []string{"Networking"}, AffectsVersions: []string{"4.16", "4.16.z"},
+			OpenedTime: at(100), ResolvedTime: at(90)},
+		{Key: "OCPBUGS-2", Components: []string{"Networking", "Etcd"}, AffectsVersions: []string{"4.15.0"},
+			OpenedTime: at(100), ResolvedTime: at(70)},
+		{Key: "OCPBUGS-3", Components: []string{"Etcd"}, AffectsVersions: []string{"4.16"},
+			OpenedTime: at(48)},
+		{Key: "OCPBUGS-4", OpenedTime: at(24)},
+		{Key: "OCPBUGS-5", Components: []string{"Networking"}},
+	}
+
+	tests := []struct {
+		name    string
+		groupBy string
+		release string
+		want    []apitype.BugResolutionStats
+	}{
+		{
+			name:    "by component",
+			groupBy: BugResolutionByComponent,
+			want: []apitype.BugResolutionStats{
+				{Name: "Etcd", ResolvedBugs: 1, MeanHoursToResolve: 30, MedianHoursToResolve: 30, OpenBugs: 1, MeanOpenHours: 48},
+				{Name: "Networking", ResolvedBugs: 2, MeanHoursToResolve: 20, MedianHoursToResolve: 20},
+				{Name: unknownBugGroup, OpenBugs: 1, MeanOpenHours: 24},
+			},
+		},
+		{
+			name:    "by release",
+			groupBy: BugResolutionByRelease,
+			want: []apitype.BugResolutionStats{
+				{Name: "4.15", ResolvedBugs: 1, MeanHoursToResolve: 30, MedianHoursToResolve: 30},
+				{Name: "4.16", ResolvedBugs: 1, MeanHoursToResolve: 10, MedianHoursToResolve: 10, OpenBugs: 1, MeanOpenHours: 48},
+				{Name: unknownBugGroup, OpenBugs: 1, MeanOpenHours: 24},
+			},
+		},
+		{
+			name:    "by component for one release",
+			groupBy: BugResolutionByComponent,
+			release: "4.16",
+			want: []apitype.BugResolutionStats{
+				{Name: "Networking", ResolvedBugs: 1, MeanHoursToResolve: 10, MedianHoursToResolve: 10},
+				{Name: "Etcd", OpenBugs: 1, MeanOpenHours: 48},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupBugResolutions(bugs, tt.groupBy, tt.release, now))
+		})
+	}
+}
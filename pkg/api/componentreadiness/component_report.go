@@ -16,7 +16,6 @@ import (
 
 	"cloud.google.com/go/bigquery"
 	"github.com/apache/thrift/lib/go/thrift"
-	fischer "github.com/glycerine/golang-fisher-exact"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/api/iterator"
@@ -59,9 +58,9 @@ func newFallbackReleases() crtype.FallbackReleases {
 	return fb
 }
 
-func getSingleColumnResultToSlice(ctx context.Context, query *bigquery.Query) ([]string, error) {
+func getSingleColumnResultToSlice(ctx context.Context, client *bqcachedclient.Client, query *bigquery.Query) ([]string, error) {
 	names := []string{}
-	it, err := query.Read(ctx)
+	it, err := client.Read(ctx, query, "component_readiness")
 	if err != nil {
 		log.WithError(err).Error("error querying test status from bigquery")
 		return names, err
@@ -220,7 +219,7 @@ func (c *componentReportGenerator) GenerateJobVariants(ctx context.Context) (crt
 					GROUP BY
 						variant_name`, c.client.Dataset)
 	query := c.client.BQ.Query(queryString)
-	it, err := query.Read(ctx)
+	it, err := c.client.Read(ctx, query, "component_readiness")
 	if err != nil {
 		log.WithError(err).Errorf("error querying variants from bigquery for %s", queryString)
 		return variants, []error{err}
@@ -633,7 +632,7 @@ func (c *componentReportGenerator) fetchJobRunTestStatusResults(ctx context.Cont
 	status := map[string][]crtype.JobRunTestStatusRow{}
 	log.Infof("Fetching job run test details with:\n%s\nParameters:\n%+v\n", query.Q, query.Parameters)
 
-	it, err := query.Read(ctx)
+	it, err := c.client.Read(ctx, query, "component_readiness")
 	if err != nil {
 		log.WithError(err).Error("error querying job run test status from bigquery")
 		errs = append(errs, err)
@@ -882,7 +881,7 @@ func (t *triagedIncidentsModifiedTimeGenerator) fetchLastModified(ctx context.Co
 	[]error) {
 	log.Infof("Fetching triaged incidents last modified time with:\n%s\nParameters:\n%+v\n", query.Q, query.Parameters)
 
-	it, err := query.Read(ctx)
+	it, err := t.client.Read(ctx, query, "triaged_incidents")
 	if err != nil {
 		log.WithError(err).Error("error querying triaged incidents last modified time from bigquery")
 		return nil, []error{err}
@@ -1018,7 +1017,7 @@ func (t *triagedIncidentsGenerator) fetchTriagedIssues(ctx context.Context,
 	incidents := make([]crtype.TriagedIncident, 0)
 	log.Infof("Fetching triaged incidents with:\n%s\nParameters:\n%+v\n", query.Q, query.Parameters)
 
-	it, err := query.Read(ctx)
+	it, err := t.client.Read(ctx, query, "triaged_incidents")
 	if err != nil {
 		log.WithError(err).Error("error querying triaged incidents from bigquery")
 		errs = append(errs, err)
@@ -1479,7 +1478,7 @@ func getSuccessRate(success, failure, flake int) float64 {
 }
 
 func getRegressionStatus(basisPassPercentage, samplePassPercentage float64, isTriage bool) crtype.Status {
-	if (basisPassPercentage - samplePassPercentage) > 0.15 {
+	if (basisPassPercentage - samplePassPercentage) > api.ExtremeRegressionThreshold {
 		if isTriage {
 			return crtype.ExtremeTriagedRegression
 		}
@@ -1634,7 +1633,7 @@ func (c *componentReportGenerator) buildFisherExactTestStats(requiredConfidence,
 		// pass percentage is below the basis
 		if initialSampleTotal > sampleTotal && initialPassPercentage < basisPassPercentage {
 			if basisPassPercentage-initialPassPercentage > float64(c.PityFactor)/100 {
-				wasSignificant, _ = c.fischerExactTest(requiredConfidence, initialSampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake)
+				wasSignificant, _ = api.FisherExactTest(requiredConfidence, initialSampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake)
 			}
 			// if it was significant without the adjustment use
 			// ExtremeTriagedRegression or SignificantTriagedRegression
@@ -1665,33 +1664,23 @@ func (c *componentReportGenerator) buildFisherExactTestStats(requiredConfidence,
 			status = crtype.NotSignificant
 		}
 
-		// now that we know sampleTotal is non zero
-		samplePassPercentage := float64(sampleSuccess+sampleFlake) / float64(sampleTotal)
-
 		// did we remove enough failures that we are below the MinimumFailure threshold?
 		if c.MinimumFailure != 0 && (sampleTotal-sampleSuccess-sampleFlake) < c.MinimumFailure {
 			testStats.ReportStatus = status
 			testStats.FisherExact = thrift.Float64Ptr(0.0)
 			return testStats
 		}
-		significant := false
-		improved := samplePassPercentage >= basisPassPercentage
-
-		if improved {
-			// flip base and sample when improved
-			significant, fisherExact = c.fischerExactTest(requiredConfidence, baseTotal, baseSuccess, baseFlake, sampleTotal, sampleSuccess, sampleFlake)
-		} else if basisPassPercentage-samplePassPercentage > float64(effectivePityFactor)/100 {
-			significant, fisherExact = c.fischerExactTest(requiredConfidence, sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake)
-		}
-		if significant {
-			if improved {
-				// only show improvements if we are not dropping out triaged results
-				if initialSampleTotal == sampleTotal {
-					status = crtype.SignificantImprovement
-				}
-			} else {
-				status = getRegressionStatus(basisPassPercentage, samplePassPercentage, false)
+		var significance crtype.Status
+		significance, fisherExact = api.AssessSignificance(requiredConfidence, effectivePityFactor,
+			sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake)
+		switch significance {
+		case crtype.SignificantImprovement:
+			// only show improvements if we are not dropping out triaged results
+			if initialSampleTotal == sampleTotal {
+				status = crtype.SignificantImprovement
 			}
+		case crtype.SignificantRegression, crtype.ExtremeRegression:
+			status = significance
 		}
 	}
 	testStats.ReportStatus = status
@@ -1756,14 +1745,6 @@ func (c *componentReportGenerator) buildPassRateTestStats(sampleSuccess, sampleF
 	}
 }
 
-func (c *componentReportGenerator) fischerExactTest(confidenceRequired, sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake int) (bool, float64) {
-	_, _, r, _ := fischer.FisherExactTest(sampleTotal-sampleSuccess-sampleFlake,
-		sampleSuccess+sampleFlake,
-		baseTotal-baseSuccess-baseFlake,
-		baseSuccess+baseFlake)
-	return r < 1-float64(confidenceRequired)/100, r
-}
-
 func (c *componentReportGenerator) getUniqueJUnitColumnValuesLast60Days(ctx context.Context, field string,
 	nested bool) ([]string,
 	error) {
@@ -1791,7 +1772,7 @@ func (c *componentReportGenerator) getUniqueJUnitColumnValuesLast60Days(ctx cont
 		},
 	}
 
-	return getSingleColumnResultToSlice(ctx, query)
+	return getSingleColumnResultToSlice(ctx, c.client, query)
 }
 
 func init() {
@@ -151,7 +151,7 @@ func (b *baseQueryGenerator) queryTestStatus(ctx context.Context) (crtype.Report
 		},
 	}...)
 
-	baseStatus, baseErrs := fetchTestStatusResults(ctx, baseQuery)
+	baseStatus, baseErrs := fetchTestStatusResults(ctx, b.client, baseQuery)
 
 	if len(baseErrs) != 0 {
 		errs = append(errs, baseErrs...)
@@ -220,7 +220,7 @@ func (s *sampleQueryGenerator) queryTestStatus(ctx context.Context) (crtype.Repo
 		}...)
 	}
 
-	sampleStatus, sampleErrs := fetchTestStatusResults(ctx, sampleQuery)
+	sampleStatus, sampleErrs := fetchTestStatusResults(ctx, s.client, sampleQuery)
 
 	if len(sampleErrs) != 0 {
 		errs = append(errs, sampleErrs...)
@@ -419,7 +419,7 @@ func (f *fallbackTestQueryGenerator) getTestFallbackRelease(ctx context.Context)
 		},
 	}...)
 
-	baseStatus, baseErrs := fetchTestStatusResults(ctx, baseQuery)
+	baseStatus, baseErrs := fetchTestStatusResults(ctx, f.client, baseQuery)
 
 	if len(baseErrs) != 0 {
 		errs = append(errs, baseErrs...)
@@ -668,12 +668,12 @@ func getTestDetailsQuery(c *componentReportGenerator, allJobVariants crtype.JobV
 	return queryString, groupString, commonParams
 }
 
-func fetchTestStatusResults(ctx context.Context, query *bigquery.Query) (map[string]crtype.TestStatus, []error) {
+func fetchTestStatusResults(ctx context.Context, client *bqcachedclient.Client, query *bigquery.Query) (map[string]crtype.TestStatus, []error) {
 	errs := []error{}
 	status := map[string]crtype.TestStatus{}
 	log.Infof("Fetching test status with:\n%s\nParameters:\n%+v\n", query.Q, query.Parameters)
 
-	it, err := query.Read(ctx)
+	it, err := client.Read(ctx, query, "component_readiness")
 	if err != nil {
 		log.WithError(err).Error("error querying test status from bigquery")
 		errs = append(errs, err)
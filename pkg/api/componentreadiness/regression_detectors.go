@@ -0,0 +1,102 @@
+package componentreadiness
+
+import (
+	"fmt"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+)
+
+// Regression detectors.
+const (
+	DetectorFisherExact   = "fisher_exact"
+	DetectorPassRateDelta = "pass_rate_delta"
+)
+
+// RegressionDetector decides whether a test the component report marks regressed, or that was triaged, is tracked
+// as a regression. Detectors are chosen per view, so different algorithms can be compared without code changes.
+type RegressionDetector interface {
+	Name() string
+	IsRegressed(test crtype.ReportTestSummary) bool
+}
+
+// NewRegressionDetector returns the detector described by the config.
+func NewRegressionDetector(config crtype.RegressionDetectorConfig) (RegressionDetector, error) {
+	switch config.Name {
+	case "", DetectorFisherExact:
+		return fisherExactDetector{}, nil
+	case DetectorPassRateDelta:
+		if config.MinPassRateDelta <= 0 || config.MinPassRateDelta > 100 {
+			return nil, fmt.Errorf("%s detector needs a min_pass_rate_delta between 0 and 100", config.Name)
+		}
+		return passRateDeltaDetector{minDelta: config.MinPassRateDelta}, nil
+	}
+	return nil, fmt.Errorf("unknown regression detector %q", config.Name)
+}
+
+// ValidateRegressionDetectors returns an error if any of the view's regression detectors are invalid.
+func ValidateRegressionDetectors(view crtype.View) error {
+	configs := append([]crtype.RegressionDetectorConfig{view.RegressionTracking.Detector},
+		view.RegressionTracking.ShadowDetectors...)
+	for _, config := range configs {
+		if _, err := NewRegressionDetector(config); err != nil {
+			return fmt.Errorf("view %s: %w", view.Name, err)
+		}
+	}
+	return nil
+}
+
+// fisherExactDetector tracks every test the report marks regressed, which it does with Fisher's exact test against
+// the basis release at the view's confidence and pity factor.
+type fisherExactDetector struct{}
+
+func (fisherExactDetector) Name() string {
+	return DetectorFisherExact
+}
+
+func (fisherExactDetector) IsRegressed(crtype.ReportTestSummary) bool {
+	return true
+}
+
+// passRateDeltaDetector only tracks tests whose sample pass rate dropped at least minDelta percentage points below
+// the basis, ignoring significant but small drops in tests with many runs. Tests with no basis, marked regressed
+// on their pass rate alone, are always tracked.
+type passRateDeltaDetector struct {
+	minDelta float64
+}
+
+func (passRateDeltaDetector) Name() string {
+	return DetectorPassRateDelta
+}
+
+func (d passRateDeltaDetector) IsRegressed(test crtype.ReportTestSummary) bool {
+	if test.BaseStats == nil {
+		return true
+	}
+	return (test.BaseStats.SuccessRate-test.SampleStats.SuccessRate)*100 >= d.minDelta
+}
+
+// detectRegressions returns the tests the detector considers regressed.
+func detectRegressions(detector RegressionDetector, tests []crtype.ReportTestSummary) []crtype.ReportTestSummary {
+	regressed := make([]crtype.ReportTestSummary, 0, len(tests))
+	for _, test := range tests {
+		if detector.IsRegressed(test) {
+			regressed = append(regressed, test)
+		}
+	}
+	return regressed
+}
+
+// compareDetectors counts the tests only the shadow detector considers regressed, and those only the detector in
+// use does.
+func compareDetectors(detector, shadow RegressionDetector, tests []crtype.ReportTestSummary) (onlyShadow, onlyDetector int) {
+	for _, test := range tests {
+		inUse, inShadow := detector.IsRegressed(test), shadow.IsRegressed(test)
+		switch {
+		case inShadow && !inUse:
+			onlyShadow++
+		case inUse && !inShadow:
+			onlyDetector++
+		}
+	}
+	return onlyShadow, onlyDetector
+}
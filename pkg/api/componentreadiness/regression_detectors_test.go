@@ -0,0 +1,109 @@
+package componentreadiness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+)
+
+func regressedTest(testID string, sampleRate float64, baseRate *float64) crtype.ReportTestSummary {
+	test := crtype.ReportTestSummary{
+		ReportTestIdentification: crtype.ReportTestIdentification{
+			RowIdentification: crtype.RowIdentification{TestID: testID},
+		},
+		ReportTestStats: crtype.ReportTestStats{
+			SampleStats: crtype.TestDetailsReleaseStats{
+				TestDetailsTestStats: crtype.TestDetailsTestStats{SuccessRate: sampleRate},
+			},
+		},
+	}
+	if baseRate != nil {
+		test.BaseStats = &crtype.TestDetailsReleaseStats{
+			TestDetailsTestStats: crtype.TestDetailsTestStats{SuccessRate: *baseRate},
+		}
+	}
+	return test
+}
+
+func rate(r float64) *float64 {
+	return &r
+}
+
+func TestNewRegressionDetector(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       crtype.RegressionDetectorConfig
+		expectedName string
+		wantErr      bool
+	}{
+		{
+			name:         "default",
+			expectedName: DetectorFisherExact,
+		},
+		{
+			name:         "pass rate delta",
+			config:       crtype.RegressionDetectorConfig{Name: DetectorPassRateDelta, MinPassRateDelta: 5},
+			expectedName: DetectorPassRateDelta,
+		},
+		{
+			name:    "pass rate delta without a delta",
+			config:  crtype.RegressionDetectorConfig{Name: DetectorPassRateDelta},
+			wantErr: true,
+		},
+		{
+			name:    "unknown",
+			config:  crtype.RegressionDetectorConfig{Name: "ewma"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector, err := NewRegressionDetector(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, detector.Name())
+		})
+	}
+}
+
+func TestDetectRegressions(t *testing.T) {
+	tests := []crtype.ReportTestSummary{
+		regressedTest("large-drop", 0.80, rate(0.99)),
+		regressedTest("small-drop", 0.97, rate(0.99)),
+		regressedTest("new-test", 0.50, nil),
+	}
+
+	fisher, err := NewRegressionDetector(crtype.RegressionDetectorConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, tests, detectRegressions(fisher, tests))
+
+	delta, err := NewRegressionDetector(crtype.RegressionDetectorConfig{Name: DetectorPassRateDelta, MinPassRateDelta: 5})
+	require.NoError(t, err)
+	regressed := []string{}
+	for _, test := range detectRegressions(delta, tests) {
+		regressed = append(regressed, test.TestID)
+	}
+	assert.Equal(t, []string{"large-drop", "new-test"}, regressed)
+
+	onlyShadow, onlyDetector := compareDetectors(fisher, delta, tests)
+	assert.Equal(t, 0, onlyShadow)
+	assert.Equal(t, 1, onlyDetector)
+}
+
+func TestValidateRegressionDetectors(t *testing.T) {
+	view := crtype.View{Name: "4.18-main", RegressionTracking: crtype.ViewRegressionTracking{
+		Enabled:         true,
+		ShadowDetectors: []crtype.RegressionDetectorConfig{{Name: DetectorPassRateDelta, MinPassRateDelta: 10}},
+	}}
+	assert.NoError(t, ValidateRegressionDetectors(view))
+
+	view.RegressionTracking.ShadowDetectors = append(view.RegressionTracking.ShadowDetectors,
+		crtype.RegressionDetectorConfig{Name: "changepoint"})
+	assert.Error(t, ValidateRegressionDetectors(view))
+}
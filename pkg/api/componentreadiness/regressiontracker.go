@@ -50,7 +50,7 @@ func (bq *BigQueryRegressionStore) ListCurrentRegressions(ctx context.Context) (
 	regressions := make([]*crtype.TestRegression, 0)
 	log.Infof("Fetching current test regressions with: %s", sampleQuery.Q)
 
-	it, err := sampleQuery.Read(ctx)
+	it, err := bq.client.Read(ctx, sampleQuery, "regression_tracker")
 	if err != nil {
 		log.WithError(err).Error("error querying triaged incidents from bigquery")
 		return regressions, err
@@ -179,6 +179,19 @@ func (rt *RegressionTracker) syncRegressionsForView(
 	// The updated slice of regressions we'll return to send to the db for this release.
 	releaseRegressions := []*crtype.TestRegression{}
 
+	detector, err := NewRegressionDetector(view.RegressionTracking.Detector)
+	if err != nil {
+		return releaseRegressions, false, err
+	}
+	shadows := make([]RegressionDetector, 0, len(view.RegressionTracking.ShadowDetectors))
+	for _, config := range view.RegressionTracking.ShadowDetectors {
+		shadow, err := NewRegressionDetector(config)
+		if err != nil {
+			return releaseRegressions, false, err
+		}
+		shadows = append(shadows, shadow)
+	}
+
 	baseRelease, err := GetViewReleaseOptions(
 		rt.releases, "basis", view.BaseRelease, rt.cacheOpts.CRTimeRoundingFactor)
 	if err != nil {
@@ -229,7 +242,18 @@ func (rt *RegressionTracker) syncRegressionsForView(
 		}
 	}
 
-	rLog.Infof("found %d current regressions in the component report", len(regressedTestsReport))
+	for _, shadow := range shadows {
+		onlyShadow, onlyDetector := compareDetectors(detector, shadow, regressedTestsReport)
+		rLog.WithFields(log.Fields{
+			"detector":      detector.Name(),
+			"shadow":        shadow.Name(),
+			"only_shadow":   onlyShadow,
+			"only_detector": onlyDetector,
+		}).Info("compared shadow regression detector")
+	}
+	regressedTestsReport = detectRegressions(detector, regressedTestsReport)
+
+	rLog.WithField("detector", detector.Name()).Infof("found %d current regressions in the component report", len(regressedTestsReport))
 	var opened, closed, ongoing int
 	for _, regTest := range regressedTestsReport {
 		if openReg := FindOpenRegression(view.Name, regTest.TestID, regTest.Variants, currentReleaseRegressions); openReg != nil {
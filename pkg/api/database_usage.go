@@ -0,0 +1,163 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// tableSizeSnapshotInterval is how often table sizes are recorded, a little under a day so a daily load or
+	// refresh doesn't skip one.
+	tableSizeSnapshotInterval = 20 * time.Hour
+	// tableSizeRetention is how long table size snapshots are kept.
+	tableSizeRetention = 90 * 24 * time.Hour
+	// tableGrowthWindow is how far back growth is measured from.
+	tableGrowthWindow = 7 * 24 * time.Hour
+
+	// diskBudgetWarningFraction is how much of the disk budget can be used before warning.
+	diskBudgetWarningFraction = 0.8
+	// diskBudgetWarningDays is how soon the database must be projected to exceed its disk budget to warn.
+	diskBudgetWarningDays = 30.0
+)
+
+// RecordTableSizes snapshots the size of each table and materialized view, to measure their growth, unless one was
+// recorded recently. Snapshots past their retention are removed.
+func RecordTableSizes(dbc *db.DB, now time.Time) error {
+	var last *time.Time
+	if res := dbc.DB.Model(&models.TableSizeSnapshot{}).Select("MAX(recorded_at)").Scan(&last); res.Error != nil {
+		return res.Error
+	}
+	if last != nil && now.Sub(*last) < tableSizeSnapshotInterval {
+		return nil
+	}
+
+	sizes, err := query.TableSizes(dbc)
+	if err != nil {
+		return err
+	}
+	snapshots := make([]models.TableSizeSnapshot, 0, len(sizes))
+	for _, s := range sizes {
+		snapshots = append(snapshots, models.TableSizeSnapshot{Name: s.Name, Kind: s.Kind, TotalBytes: s.TotalBytes, RecordedAt: now})
+	}
+	if len(snapshots) > 0 {
+		if res := dbc.DB.CreateInBatches(snapshots, 500); res.Error != nil {
+			return res.Error
+		}
+	}
+	return dbc.DB.Where("recorded_at < ?", now.Add(-tableSizeRetention)).Delete(&models.TableSizeSnapshot{}).Error
+}
+
+// GetDatabaseUsageFromDB reports the disk usage of the database and each of its tables and materialized views,
+// warning when it is close to or projected to exceed the configured disk budget.
+func GetDatabaseUsageFromDB(dbc *db.DB, now time.Time) (apitype.DatabaseUsage, error) {
+	databaseBytes, err := query.DatabaseSize(dbc)
+	if err != nil {
+		return apitype.DatabaseUsage{}, err
+	}
+	sizes, err := query.TableSizes(dbc)
+	if err != nil {
+		return apitype.DatabaseUsage{}, err
+	}
+
+	// the oldest snapshot of each table within the growth window
+	snapshots := []models.TableSizeSnapshot{}
+	res := dbc.DB.Raw(`
+		SELECT DISTINCT ON (name) name, kind, total_bytes, recorded_at
+		FROM table_size_snapshots
+		WHERE recorded_at >= ?
+		ORDER BY name, recorded_at`, now.Add(-tableGrowthWindow)).Scan(&snapshots)
+	if res.Error != nil {
+		return apitype.DatabaseUsage{}, res.Error
+	}
+
+	return databaseUsage(databaseBytes, dbc.DiskBudget, sizes, snapshots, now), nil
+}
+
+// databaseUsage estimates the bloat and growth of each table, and projects when the database will exceed budget.
+func databaseUsage(databaseBytes, budget int64, sizes []query.TableSize, snapshots []models.TableSizeSnapshot, now time.Time) apitype.DatabaseUsage {
+	oldest := make(map[string]models.TableSizeSnapshot, len(snapshots))
+	for _, s := range snapshots {
+		oldest[s.Name] = s
+	}
+
+	usage := apitype.DatabaseUsage{
+		DatabaseBytes: databaseBytes,
+		DiskBudget:    budget,
+		Warnings:      []string{},
+		Tables:        make([]apitype.TableUsage, 0, len(sizes)),
+	}
+	for _, s := range sizes {
+		t := apitype.TableUsage{
+			Name:       s.Name,
+			Kind:       s.Kind,
+			TotalBytes: s.TotalBytes,
+			TableBytes: s.TableBytes,
+			IndexBytes: s.IndexBytes,
+			LiveRows:   s.LiveRows,
+			DeadRows:   s.DeadRows,
+			BloatBytes: bloatBytes(s),
+		}
+		if snapshot, ok := oldest[s.Name]; ok {
+			t.GrowthBytesPerDay = growthPerDay(snapshot.TotalBytes, s.TotalBytes, now.Sub(snapshot.RecordedAt))
+		}
+		usage.GrowthBytesPerDay += t.GrowthBytesPerDay
+		usage.Tables = append(usage.Tables, t)
+	}
+	sort.SliceStable(usage.Tables, func(i, j int) bool { return usage.Tables[i].TotalBytes > usage.Tables[j].TotalBytes })
+
+	if budget <= 0 {
+		return usage
+	}
+	if float64(databaseBytes) >= diskBudgetWarningFraction*float64(budget) {
+		usage.Warnings = append(usage.Warnings, fmt.Sprintf("database uses %.1f GiB, %.0f%% of its %.1f GiB disk budget",
+			gibibytes(float64(databaseBytes)), 100*float64(databaseBytes)/float64(budget), gibibytes(float64(budget))))
+	}
+	if days, ok := daysUntilFull(databaseBytes, budget, usage.GrowthBytesPerDay); ok {
+		usage.DaysUntilFull = &days
+		if days <= diskBudgetWarningDays {
+			usage.Warnings = append(usage.Warnings, fmt.Sprintf("database is projected to exceed its %.1f GiB disk budget in %.0f days, growing %.2f GiB a day",
+				gibibytes(float64(budget)), days, gibibytes(usage.GrowthBytesPerDay)))
+		}
+	}
+	return usage
+}
+
+// bloatBytes estimates the space a table's dead rows take, assuming they are the size of its live ones.
+func bloatBytes(s query.TableSize) int64 {
+	if s.LiveRows+s.DeadRows <= 0 {
+		return 0
+	}
+	return int64(float64(s.TableBytes) * float64(s.DeadRows) / float64(s.LiveRows+s.DeadRows))
+}
+
+// growthPerDay is how many bytes a day a table grew from before to after over elapsed, 0 with less than an hour
+// between them.
+func growthPerDay(before, after int64, elapsed time.Duration) float64 {
+	if elapsed < time.Hour {
+		return 0
+	}
+	return float64(after-before) / (elapsed.Hours() / 24)
+}
+
+// daysUntilFull projects how many days until used reaches budget at growth bytes a day, false when it isn't
+// growing.
+func daysUntilFull(used, budget int64, growth float64) (float64, bool) {
+	if used >= budget {
+		return 0, true
+	}
+	if growth <= 0 {
+		return 0, false
+	}
+	return float64(budget-used) / growth, true
+}
+
+// gibibytes converts bytes to GiB for warnings.
+func gibibytes(bytes float64) float64 {
+	return bytes / (1 << 30)
+}
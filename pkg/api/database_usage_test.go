@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const gib = 1 << 30
+
+func TestDatabaseUsage(t *testing.T) {
+	now := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	sizes := []query.TableSize{
+		{Name: "bugs", Kind: "table", TotalBytes: 2 * gib, TableBytes: gib, LiveRows: 75, DeadRows: 25},
+		{Name: "prow_test_report_7d_matview", Kind: "matview", TotalBytes: 10 * gib, TableBytes: 8 * gib},
+		{Name: "watchlists", Kind: "table"},
+	}
+	snapshots := []models.TableSizeSnapshot{
+		// grew 4GiB over 4 days
+		{Name: "prow_test_report_7d_matview", TotalBytes: 6 * gib, RecordedAt: now.Add(-4 * 24 * time.Hour)},
+		// too recent to measure growth from
+		{Name: "bugs", TotalBytes: gib, RecordedAt: now.Add(-time.Minute)},
+	}
+
+	usage := databaseUsage(12*gib, 40*gib, sizes, snapshots, now)
+	require.Len(t, usage.Tables, 3)
+	assert.Equal(t, "prow_test_report_7d_matview", usage.Tables[0].Name)
+	assert.Equal(t, float64(gib), usage.Tables[0].GrowthBytesPerDay)
+	assert.Equal(t, "bugs", usage.Tables[1].Name)
+	assert.Equal(t, int64(gib/4), usage.Tables[1].BloatBytes)
+	assert.Equal(t, float64(0), usage.Tables[1].GrowthBytesPerDay)
+	assert.Equal(t, int64(0), usage.Tables[2].BloatBytes)
+
+	// 28GiB left at 1GiB a day
+	require.NotNil(t, usage.DaysUntilFull)
+	assert.Equal(t, 28.0, *usage.DaysUntilFull)
+	require.Len(t, usage.Warnings, 1)
+	assert.Contains(t, usage.Warnings[0], "in 28 days")
+
+	// a larger budget is neither close to full nor projected to fill soon
+	usage = databaseUsage(12*gib, 100*gib, sizes, snapshots, now)
+	require.NotNil(t, usage.DaysUntilFull)
+	assert.Equal(t, 88.0, *usage.DaysUntilFull)
+	assert.Empty(t, usage.Warnings)
+
+	// nearly full, and no longer growing
+	usage = databaseUsage(36*gib, 40*gib, sizes, nil, now)
+	assert.Nil(t, usage.DaysUntilFull)
+	require.Len(t, usage.Warnings, 1)
+	assert.Contains(t, usage.Warnings[0], "90% of its 40.0 GiB disk budget")
+
+	// no budget, no projection or warnings
+	usage = databaseUsage(36*gib, 0, sizes, snapshots, now)
+	assert.Nil(t, usage.DaysUntilFull)
+	assert.Empty(t, usage.Warnings)
+}
+
+func TestDaysUntilFull(t *testing.T) {
+	days, ok := daysUntilFull(50, 40, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, days)
+
+	_, ok = daysUntilFull(10, 40, -1)
+	assert.False(t, ok)
+
+	days, ok = daysUntilFull(10, 40, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, days)
+}
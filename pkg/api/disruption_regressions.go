@@ -0,0 +1,108 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// DisruptionRegressionP95Threshold is how many seconds P95 disruption must have grown vs two weeks ago
+	// before we consider it regressed.
+	DisruptionRegressionP95Threshold = 1.0
+
+	// DefaultDisruptionRegressionMinDays is how many consecutive regressed days are required before a
+	// NURP is reported as a regression.
+	DefaultDisruptionRegressionMinDays = 3
+)
+
+// UpdateDisruptionRegressionStates records the current disruption vs two weeks ago report into the persisted
+// per-NURP regression state, so we can tell how long disruption has been worse than normal.
+func UpdateDisruptionRegressionStates(dbc *db.DB, rows []apitype.DisruptionReportRow, now time.Time) error {
+	day := now.UTC().Truncate(24 * time.Hour)
+	for _, row := range rows {
+		// Use a map for the lookup as struct conditions would skip empty fields, which are valid here.
+		nurp := map[string]interface{}{
+			"release":              row.Release,
+			"backend_name":         row.BackendName,
+			"platform":             row.Platform,
+			"upgrade_type":         row.UpgradeType,
+			"master_nodes_updated": row.MasterNodesUpdated,
+			"network":              row.Network,
+			"topology":             row.Topology,
+			"architecture":         row.Architecture,
+		}
+		state := models.DisruptionRegressionState{}
+		res := dbc.DB.Where(nurp).Attrs(models.DisruptionRegressionState{
+			Release:            row.Release,
+			BackendName:        row.BackendName,
+			Platform:           row.Platform,
+			UpgradeType:        row.UpgradeType,
+			MasterNodesUpdated: row.MasterNodesUpdated,
+			Network:            row.Network,
+			Topology:           row.Topology,
+			Architecture:       row.Architecture,
+		}).FirstOrInit(&state)
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "error looking up disruption regression state")
+		}
+
+		wasRegressed := state.Regressed
+		state = nextDisruptionRegressionState(state, row, day)
+		if res := dbc.DB.Save(&state); res.Error != nil {
+			return errors.Wrap(res.Error, "error saving disruption regression state")
+		}
+		if wasRegressed && !state.Regressed {
+			target := strconv.FormatUint(uint64(state.ID), 10)
+			if err := query.ClearAcknowledgements(dbc, models.AcknowledgementKindDisruptionRegression, target); err != nil {
+				return errors.Wrap(err, "error clearing acknowledgements of disruption regression")
+			}
+		}
+	}
+	return nil
+}
+
+// nextDisruptionRegressionState applies a new observation to the previous state. Only one bad day is counted
+// per calendar day no matter how often we refresh, and a gap of more than a day resets the count.
+func nextDisruptionRegressionState(prev models.DisruptionRegressionState, row apitype.DisruptionReportRow, day time.Time) models.DisruptionRegressionState {
+	next := prev
+	next.P95Delta = float64(row.P95)
+	next.Regressed = next.P95Delta > DisruptionRegressionP95Threshold
+	next.LastEvaluatedDate = day
+
+	sameDay := prev.LastEvaluatedDate.Equal(day)
+	switch {
+	case !next.Regressed:
+		if !sameDay || !prev.Regressed {
+			next.ConsecutiveBadDays = 0
+			next.FirstBadDate = nil
+		}
+	case sameDay && prev.Regressed:
+		// already counted today
+	case prev.Regressed && prev.LastEvaluatedDate.Equal(day.Add(-24*time.Hour)):
+		next.ConsecutiveBadDays = prev.ConsecutiveBadDays + 1
+	default:
+		next.ConsecutiveBadDays = 1
+		first := day
+		next.FirstBadDate = &first
+	}
+	return next
+}
+
+// GetDisruptionRegressionsFromDB returns the NURPs whose disruption has been regressed for at least minDays
+// consecutive days, optionally limited to a release.
+func GetDisruptionRegressionsFromDB(dbc *db.DB, release string, minDays int) ([]models.DisruptionRegressionState, error) {
+	results := make([]models.DisruptionRegressionState, 0)
+	q := dbc.DB.Where("regressed = ? AND consecutive_bad_days >= ?", true, minDays)
+	if release != "" {
+		q = q.Where("release = ?", release)
+	}
+	res := q.Order("consecutive_bad_days DESC, p95_delta DESC").Find(&results)
+	return results, res.Error
+}
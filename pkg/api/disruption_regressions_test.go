@@ -0,0 +1,76 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestNextDisruptionRegressionState(t *testing.T) {
+	day := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	yesterday := day.Add(-24 * time.Hour)
+	bad := apitype.DisruptionReportRow{P95: 5}
+	good := apitype.DisruptionReportRow{P95: 0.2}
+
+	tests := []struct {
+		name               string
+		prev               models.DisruptionRegressionState
+		row                apitype.DisruptionReportRow
+		expectedRegressed  bool
+		expectedBadDays    int
+		expectedFirstIsDay bool
+	}{
+		{
+			name:               "first bad day",
+			prev:               models.DisruptionRegressionState{},
+			row:                bad,
+			expectedRegressed:  true,
+			expectedBadDays:    1,
+			expectedFirstIsDay: true,
+		},
+		{
+			name:              "bad again the next day",
+			prev:              models.DisruptionRegressionState{Regressed: true, ConsecutiveBadDays: 2, LastEvaluatedDate: yesterday},
+			row:               bad,
+			expectedRegressed: true,
+			expectedBadDays:   3,
+		},
+		{
+			name:              "bad again the same day is not counted twice",
+			prev:              models.DisruptionRegressionState{Regressed: true, ConsecutiveBadDays: 2, LastEvaluatedDate: day},
+			row:               bad,
+			expectedRegressed: true,
+			expectedBadDays:   2,
+		},
+		{
+			name:               "gap in evaluations resets the count",
+			prev:               models.DisruptionRegressionState{Regressed: true, ConsecutiveBadDays: 4, LastEvaluatedDate: day.Add(-72 * time.Hour)},
+			row:                bad,
+			expectedRegressed:  true,
+			expectedBadDays:    1,
+			expectedFirstIsDay: true,
+		},
+		{
+			name:              "recovered",
+			prev:              models.DisruptionRegressionState{Regressed: true, ConsecutiveBadDays: 4, LastEvaluatedDate: yesterday},
+			row:               good,
+			expectedRegressed: false,
+			expectedBadDays:   0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := nextDisruptionRegressionState(tc.prev, tc.row, day)
+			assert.Equal(t, tc.expectedRegressed, next.Regressed)
+			assert.Equal(t, tc.expectedBadDays, next.ConsecutiveBadDays)
+			assert.Equal(t, day, next.LastEvaluatedDate)
+			if tc.expectedFirstIsDay {
+				assert.Equal(t, day, *next.FirstBadDate)
+			}
+		})
+	}
+}
@@ -5,18 +5,29 @@ import (
 	"fmt"
 	"time"
 
-	"cloud.google.com/go/bigquery"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/api/iterator"
 
 	apitype "github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/apis/cache"
 	bqcachedclient "github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// DisruptionPercentilesDays is how many days of disruption percentiles the API returns.
+const DisruptionPercentilesDays = 30
+
+// DisruptionJobRunsDays is how far back the API looks for the most disrupted job runs, and
+// DefaultDisruptionJobRunsLimit how many it returns per backend by default.
+const (
+	DisruptionJobRunsDays         = 7
+	DefaultDisruptionJobRunsLimit = 10
 )
 
 func GetDisruptionVsPrevGAReportFromBigQuery(ctx context.Context, client *bqcachedclient.Client) (apitype.DisruptionReport, []error) {
 	generator := disruptionReportGenerator{
-		client:   client.BQ,
+		client:   client,
 		ViewName: "BackendDisruptionPercentilesDeltaCurrentVsPrevGA",
 	}
 
@@ -25,7 +36,7 @@ func GetDisruptionVsPrevGAReportFromBigQuery(ctx context.Context, client *bqcach
 
 func GetDisruptionVsTwoWeeksAgoReportFromBigQuery(ctx context.Context, client *bqcachedclient.Client) (apitype.DisruptionReport, []error) {
 	generator := disruptionReportGenerator{
-		client:   client.BQ,
+		client:   client,
 		ViewName: "BackendDisruptionPercentilesDeltaCurrentVs14DaysAgo",
 	}
 
@@ -33,7 +44,7 @@ func GetDisruptionVsTwoWeeksAgoReportFromBigQuery(ctx context.Context, client *b
 }
 
 type disruptionReportGenerator struct {
-	client   *bigquery.Client
+	client   *bqcachedclient.Client
 	ViewName string
 }
 
@@ -55,8 +66,8 @@ func (c *disruptionReportGenerator) getDisruptionDeltasFromBigQuery(ctx context.
 						FROM openshift-ci-data-analysis.ci_data.%s
 						WHERE LookbackDays = 3`, c.ViewName)
 
-	query := c.client.Query(queryString)
-	it, err := query.Read(ctx)
+	query := c.client.BQ.Query(queryString)
+	it, err := c.client.Read(ctx, query, "disruption_report")
 	if err != nil {
 		log.WithError(err).Error("error querying disruption data from bigquery")
 		return apitype.DisruptionReport{}, err
@@ -80,3 +91,13 @@ func (c *disruptionReportGenerator) getDisruptionDeltasFromBigQuery(ctx context.
 		Rows: rows,
 	}, nil
 }
+
+// GetDisruptionVsTwoWeeksAgoReportFromDB is the equivalent of GetDisruptionVsTwoWeeksAgoReportFromBigQuery using
+// the disruption percentiles loaded into postgres by the disruption loader.
+func GetDisruptionVsTwoWeeksAgoReportFromDB(dbc *db.DB) (apitype.DisruptionReport, error) {
+	rows, err := query.DisruptionVsTwoWeeksAgo(dbc)
+	if err != nil {
+		return apitype.DisruptionReport{}, err
+	}
+	return apitype.DisruptionReport{Rows: rows}, nil
+}
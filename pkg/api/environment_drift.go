@@ -0,0 +1,82 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// environmentDriftWindow is how far back fingerprint changes are looked for.
+	environmentDriftWindow = 14 * 24 * time.Hour
+
+	// DefaultEnvironmentDriftMinRuns is how many runs a job needs with each fingerprint value to compare them.
+	DefaultEnvironmentDriftMinRuns = 5
+	// DefaultEnvironmentDriftThreshold is how many percentage points a job's failure percentage must rise after a
+	// fingerprint changed to be reported.
+	DefaultEnvironmentDriftThreshold = 20.0
+)
+
+// GetEnvironmentDriftFromDB lists the environment fingerprint changes in the jobs of release over the last two weeks
+// after which the job's failure percentage rose by threshold points or more, with minRuns runs before and after.
+func GetEnvironmentDriftFromDB(dbc *db.DB, release string, minRuns int, threshold float64, reportEnd time.Time) ([]apitype.EnvironmentDrift, error) {
+	counts, err := query.FingerprintValuesByJob(dbc, release, reportEnd.Add(-environmentDriftWindow), reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	return environmentDrift(counts, minRuns, threshold), nil
+}
+
+// environmentDrift compares each value of a job's fingerprint with the value seen before it, flagging those the job
+// failed more often with. Values are ordered by when they were first seen, so a value that comes back after another
+// is counted as one.
+func environmentDrift(counts []query.FingerprintValueCount, minRuns int, threshold float64) []apitype.EnvironmentDrift {
+	type jobFingerprint struct{ job, fingerprint string }
+	values := map[jobFingerprint][]query.FingerprintValueCount{}
+	for _, c := range counts {
+		key := jobFingerprint{c.JobName, c.Fingerprint}
+		values[key] = append(values[key], c)
+	}
+
+	results := make([]apitype.EnvironmentDrift, 0)
+	for _, v := range values {
+		sort.Slice(v, func(i, j int) bool { return v[i].FirstSeen.Before(v[j].FirstSeen) })
+		for i := 1; i < len(v); i++ {
+			prev, cur := v[i-1], v[i]
+			if prev.Runs < minRuns || cur.Runs < minRuns {
+				continue
+			}
+			prevFailures := percentOf(prev.Failures, prev.Runs)
+			curFailures := percentOf(cur.Failures, cur.Runs)
+			if curFailures-prevFailures < threshold {
+				continue
+			}
+			results = append(results, apitype.EnvironmentDrift{
+				JobName:                   cur.JobName,
+				Fingerprint:               cur.Fingerprint,
+				PreviousValue:             prev.Value,
+				Value:                     cur.Value,
+				ChangedAt:                 cur.FirstSeen,
+				PreviousRuns:              prev.Runs,
+				PreviousFailurePercentage: prevFailures,
+				CurrentRuns:               cur.Runs,
+				CurrentFailurePercentage:  curFailures,
+				FailureIncrease:           curFailures - prevFailures,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.FailureIncrease != b.FailureIncrease {
+			return a.FailureIncrease > b.FailureIncrease
+		}
+		if a.JobName != b.JobName {
+			return a.JobName < b.JobName
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return results
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+func TestEnvironmentDrift(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	counts := []query.FingerprintValueCount{
+		// the installer changed on the 3rd and the job started failing
+		{JobName: "aws", Fingerprint: "installer-version", Value: "b", FirstSeen: day(3), Runs: 10, Failures: 6},
+		{JobName: "aws", Fingerprint: "installer-version", Value: "a", FirstSeen: day(1), Runs: 20, Failures: 2},
+		// the commit changed too, but with too few runs since to tell
+		{JobName: "aws", Fingerprint: "installer-commit", Value: "1", FirstSeen: day(1), Runs: 20, Failures: 2},
+		{JobName: "aws", Fingerprint: "installer-commit", Value: "2", FirstSeen: day(5), Runs: 2, Failures: 2},
+		// a change the job failed no more often with
+		{JobName: "gcp", Fingerprint: "installer-version", Value: "a", FirstSeen: day(1), Runs: 10, Failures: 1},
+		{JobName: "gcp", Fingerprint: "installer-version", Value: "b", FirstSeen: day(3), Runs: 10, Failures: 2},
+	}
+
+	results := environmentDrift(counts, DefaultEnvironmentDriftMinRuns, DefaultEnvironmentDriftThreshold)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "aws", results[0].JobName)
+		assert.Equal(t, "a", results[0].PreviousValue)
+		assert.Equal(t, "b", results[0].Value)
+		assert.Equal(t, day(3), results[0].ChangedAt)
+		assert.InDelta(t, 50.0, results[0].FailureIncrease, 0.01)
+	}
+
+	assert.Len(t, environmentDrift(counts, 1, DefaultEnvironmentDriftThreshold), 2)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader carries the ID of a request, set by the server on every response. Clients may provide their
+// own ID in the request header to correlate with their logs.
+const RequestIDHeader = "X-Request-ID"
+
+// ErrorResponse is the JSON envelope returned by every API error.
+type ErrorResponse struct {
+	// Code is the HTTP status code.
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	// Details optionally holds structured information about the error, such as which params were invalid.
+	Details interface{} `json:"details,omitempty"`
+	// RequestID matches the request_id field of the server logs for this request.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// RespondWithError writes an ErrorResponse and logs it with the request ID, so errors reported by users can be
+// found in the logs.
+func RespondWithError(w http.ResponseWriter, code int, message string) {
+	RespondWithErrorDetails(w, code, message, nil)
+}
+
+// RespondWithErrorDetails writes an ErrorResponse including structured details.
+func RespondWithErrorDetails(w http.ResponseWriter, code int, message string, details interface{}) {
+	requestID := w.Header().Get(RequestIDHeader)
+	entry := log.WithFields(log.Fields{
+		"request_id": requestID,
+		"code":       code,
+	})
+	if code >= http.StatusInternalServerError {
+		entry.Error(message)
+	} else {
+		entry.Info(message)
+	}
+
+	RespondWithJSON(code, w, ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: requestID,
+	})
+}
+
+// RequestID returns the ID to use for a request: the one provided by the client if any, otherwise a new one.
+func RequestID(req *http.Request) string {
+	if id := req.Header.Get(RequestIDHeader); id != "" && len(id) <= 128 {
+		return id
+	}
+	return uuid.NewString()
+}
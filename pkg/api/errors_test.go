@@ -0,0 +1,32 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(RequestIDHeader, "abc123")
+	RespondWithErrorDetails(w, http.StatusBadRequest, "invalid release", map[string]string{"param": "release"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := ErrorResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "invalid release", resp.Message)
+	assert.Equal(t, "abc123", resp.RequestID)
+	assert.Equal(t, map[string]interface{}{"param": "release"}, resp.Details)
+}
+
+func TestRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	assert.Len(t, RequestID(req), 36, "a uuid should be generated when the client provides no ID")
+
+	req.Header.Set(RequestIDHeader, "client-id")
+	assert.Equal(t, "client-id", RequestID(req))
+}
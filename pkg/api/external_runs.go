@@ -0,0 +1,266 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/apis/junit"
+	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+const (
+	ExternalTestPassed = "passed"
+	ExternalTestFailed = "failed"
+	ExternalTestFlaked = "flaked"
+
+	// MaxExternalJobRunBytes limits the size of a reported job run, including its junit.
+	MaxExternalJobRunBytes = 32 << 20
+
+	// externalJobRunIDBit marks the IDs of external job runs. Prow build IDs are well below it, so the two can't
+	// collide in prow_job_runs.
+	externalJobRunIDBit = uint64(1) << 62
+)
+
+var (
+	// ErrExternalJobRunExists is returned when a run with the same source, job and build ID was already reported.
+	ErrExternalJobRunExists = errors.New("job run was already reported")
+	// ErrExternalJobSource is returned when a run is reported for a job that belongs to another source.
+	ErrExternalJobSource = errors.New("job belongs to another source")
+)
+
+// ExternalJobRunFromJUnit sets the tests of an external job run from its junit XML, which may be a testsuites
+// document or a single testsuite. Skipped tests are dropped, and a test that both passed and failed is a flake.
+func ExternalJobRunFromJUnit(run apitype.ExternalJobRun, junitXML []byte) (apitype.ExternalJobRun, error) {
+	suites := &junit.TestSuites{}
+	if err := xml.Unmarshal(junitXML, suites); err != nil {
+		suite := &junit.TestSuite{}
+		if err := xml.Unmarshal(junitXML, suite); err != nil {
+			return run, errors.Wrap(err, "could not parse junit")
+		}
+		suites.Suites = []*junit.TestSuite{suite}
+	}
+
+	results := map[string]int{}
+	var addSuite func(suite *junit.TestSuite)
+	addSuite = func(suite *junit.TestSuite) {
+		for _, tc := range suite.TestCases {
+			if tc.SkipMessage != nil {
+				continue
+			}
+			result := apitype.ExternalTestResult{
+				Name:            tc.Name,
+				Suite:           suite.Name,
+				Status:          ExternalTestPassed,
+				DurationSeconds: tc.Duration,
+			}
+			if tc.FailureOutput != nil {
+				result.Status = ExternalTestFailed
+				result.Output = tc.FailureOutput.Output
+			}
+
+			key := suite.Name + "." + tc.Name
+			i, ok := results[key]
+			if !ok {
+				results[key] = len(run.Tests)
+				run.Tests = append(run.Tests, result)
+				continue
+			}
+			if existing := &run.Tests[i]; existing.Status != result.Status && existing.Status != ExternalTestFlaked {
+				existing.Status = ExternalTestFlaked
+				if existing.Output == "" {
+					existing.Output = result.Output
+				}
+			}
+		}
+		for _, child := range suite.Children {
+			addSuite(child)
+		}
+	}
+	for _, suite := range suites.Suites {
+		addSuite(suite)
+	}
+	return run, nil
+}
+
+// ValidateExternalJobRun checks an external job run has what's needed to store it.
+func ValidateExternalJobRun(run apitype.ExternalJobRun) error {
+	switch {
+	case run.Source == "":
+		return fmt.Errorf("source is required")
+	case run.Source == models.JobSourceProw:
+		return fmt.Errorf("prow job runs are loaded from prow and can't be reported")
+	case run.Job == "":
+		return fmt.Errorf("job is required")
+	case run.Release == "":
+		return fmt.Errorf("release is required")
+	case run.BuildID == "":
+		return fmt.Errorf("build_id is required")
+	}
+	for _, t := range run.Tests {
+		if t.Name == "" {
+			return fmt.Errorf("every test needs a name")
+		}
+		if _, ok := externalTestStatuses[t.Status]; !ok {
+			return fmt.Errorf("test %q has unknown status %q, must be passed, failed or flaked", t.Name, t.Status)
+		}
+	}
+	return nil
+}
+
+var externalTestStatuses = map[string]sippyprocessingv1.TestStatus{
+	ExternalTestPassed: sippyprocessingv1.TestStatusSuccess,
+	ExternalTestFailed: sippyprocessingv1.TestStatusFailure,
+	ExternalTestFlaked: sippyprocessingv1.TestStatusFlake,
+}
+
+// ExternalJobRunID is the prow_job_runs ID of an external run, derived from its source, job and build ID so a run
+// reported twice is recognized.
+func ExternalJobRunID(source, job, buildID string) uint {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(source + "/" + job + "/" + buildID))
+	return uint(externalJobRunIDBit | (h.Sum64() & (externalJobRunIDBit - 1)))
+}
+
+// StoreExternalJobRun stores a run reported by another CI system alongside the prow job runs, so its results
+// appear in the same reports. The job is created on its first run, with variants identified from its name.
+func StoreExternalJobRun(dbc *db.DB, variantManager testidentification.VariantManager, run apitype.ExternalJobRun, now time.Time) (*models.ProwJobRun, error) {
+	if err := ValidateExternalJobRun(run); err != nil {
+		return nil, err
+	}
+	if run.Timestamp.IsZero() {
+		run.Timestamp = now
+	}
+
+	jobRun := &models.ProwJobRun{
+		Model:     gorm.Model{ID: ExternalJobRunID(run.Source, run.Job, run.BuildID)},
+		URL:       run.URL,
+		Timestamp: run.Timestamp,
+		Duration:  time.Duration(run.DurationSeconds * float64(time.Second)),
+	}
+	tests := make([]*models.ProwJobRunTest, 0, len(run.Tests))
+	testResults := make([]apitype.ExternalTestResult, 0, len(run.Tests))
+	flakes := 0
+	for _, t := range run.Tests {
+		if testidentification.IsIgnoredTest(t.Name) {
+			continue
+		}
+		testResults = append(testResults, t)
+		status := externalTestStatuses[t.Status]
+		test := &models.ProwJobRunTest{
+			ProwJobRunID: jobRun.ID,
+			Status:       int(status),
+			Duration:     t.DurationSeconds,
+		}
+		if status != sippyprocessingv1.TestStatusSuccess && t.Output != "" {
+			test.ProwJobRunTestOutput = &models.ProwJobRunTestOutput{Output: t.Output}
+		}
+		switch status {
+		case sippyprocessingv1.TestStatusFailure:
+			jobRun.TestFailures++
+		case sippyprocessingv1.TestStatusFlake:
+			flakes++
+		}
+		tests = append(tests, test)
+	}
+	jobRun.Succeeded = jobRun.TestFailures == 0
+	if run.Succeeded != nil {
+		jobRun.Succeeded = *run.Succeeded
+	}
+	jobRun.Failed = !jobRun.Succeeded
+	switch {
+	case !jobRun.Succeeded:
+		jobRun.OverallResult = sippyprocessingv1.JobTestFailure
+	case flakes > 0:
+		jobRun.OverallResult = sippyprocessingv1.JobSucceededWithFlakes
+	default:
+		jobRun.OverallResult = sippyprocessingv1.JobSucceeded
+	}
+
+	err := dbc.DB.Transaction(func(tx *gorm.DB) error {
+		job := models.ProwJob{}
+		if res := tx.Where("name = ?", run.Job).Limit(1).Find(&job); res.Error != nil {
+			return res.Error
+		}
+		if job.ID == 0 {
+			job = models.ProwJob{
+				Name:     run.Job,
+				Kind:     models.ProwPeriodic,
+				Release:  run.Release,
+				Variants: variantManager.IdentifyVariants(run.Job),
+				Source:   run.Source,
+			}
+			if res := tx.Create(&job); res.Error != nil {
+				return res.Error
+			}
+		} else if job.Source != run.Source {
+			return errors.Wrapf(ErrExternalJobSource, "job %s is from %s", run.Job, job.Source)
+		}
+		jobRun.ProwJobID = job.ID
+
+		var existing int64
+		if res := tx.Model(&models.ProwJobRun{}).Where("id = ?", jobRun.ID).Count(&existing); res.Error != nil {
+			return res.Error
+		}
+		if existing > 0 {
+			return ErrExternalJobRunExists
+		}
+		if res := tx.Omit("ProwJob").Create(jobRun); res.Error != nil {
+			return res.Error
+		}
+
+		for i, t := range testResults {
+			testID, suiteID, err := findOrAddExternalTest(tx, t)
+			if err != nil {
+				return err
+			}
+			tests[i].TestID = testID
+			tests[i].SuiteID = suiteID
+		}
+		if len(tests) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(tests, 1000).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobRun, nil
+}
+
+// findOrAddExternalTest returns the IDs of a test and its suite, creating them if needed.
+func findOrAddExternalTest(tx *gorm.DB, t apitype.ExternalTestResult) (uint, *uint, error) {
+	test := models.Test{}
+	if res := tx.Where("name = ?", t.Name).Limit(1).Find(&test); res.Error != nil {
+		return 0, nil, res.Error
+	}
+	if test.ID == 0 {
+		test.Name = t.Name
+		if res := tx.Create(&test); res.Error != nil {
+			return 0, nil, res.Error
+		}
+	}
+	if t.Suite == "" {
+		return test.ID, nil, nil
+	}
+
+	suite := models.Suite{}
+	if res := tx.Where("name = ?", t.Suite).Limit(1).Find(&suite); res.Error != nil {
+		return 0, nil, res.Error
+	}
+	if suite.ID == 0 {
+		suite.Name = t.Suite
+		if res := tx.Create(&suite); res.Error != nil {
+			return 0, nil, res.Error
+		}
+	}
+	return test.ID, &suite.ID, nil
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestExternalJobRunFromJUnit(t *testing.T) {
+	tests := []struct {
+		name  string
+		junit string
+		want  []apitype.ExternalTestResult
+	}{
+		{
+			name: "testsuites with a retried test",
+			junit: `<testsuites>
+  <testsuite name="smoke">
+    <testcase name="login" time="1.5"/>
+    <testcase name="checkout" time="2"><failure message="boom">timed out</failure></testcase>
+    <testcase name="checkout" time="2"/>
+    <testcase name="search"><skipped/></testcase>
+    <testsuite name="nested"><testcase name="logout"><failure>503</failure></testcase></testsuite>
+  </testsuite>
+</testsuites>`,
+			want: []apitype.ExternalTestResult{
+				{Name: "login", Suite: "smoke", Status: ExternalTestPassed, DurationSeconds: 1.5},
+				{Name: "checkout", Suite: "smoke", Status: ExternalTestFlaked, DurationSeconds: 2, Output: "timed out"},
+				{Name: "logout", Suite: "nested", Status: ExternalTestFailed, Output: "503"},
+			},
+		},
+		{
+			name:  "single testsuite",
+			junit: `<testsuite name="unit"><testcase name="TestParse"/></testsuite>`,
+			want: []apitype.ExternalTestResult{
+				{Name: "TestParse", Suite: "unit", Status: ExternalTestPassed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := ExternalJobRunFromJUnit(apitype.ExternalJobRun{Job: "jenkins-e2e"}, []byte(tt.junit))
+			require.NoError(t, err)
+			assert.Equal(t, "jenkins-e2e", run.Job)
+			assert.Equal(t, tt.want, run.Tests)
+		})
+	}
+
+	_, err := ExternalJobRunFromJUnit(apitype.ExternalJobRun{}, []byte("not xml"))
+	assert.Error(t, err)
+}
+
+func TestValidateExternalJobRun(t *testing.T) {
+	valid := apitype.ExternalJobRun{Source: "jenkins", Job: "e2e", Release: "4.16", BuildID: "42",
+		Tests: []apitype.ExternalTestResult{{Name: "test", Status: ExternalTestFailed}}}
+	assert.NoError(t, ValidateExternalJobRun(valid))
+
+	prow := valid
+	prow.Source = "prow"
+	assert.Error(t, ValidateExternalJobRun(prow))
+
+	noBuild := valid
+	noBuild.BuildID = ""
+	assert.Error(t, ValidateExternalJobRun(noBuild))
+
+	badStatus := valid
+	badStatus.Tests = []apitype.ExternalTestResult{{Name: "test", Status: "skipped"}}
+	assert.Error(t, ValidateExternalJobRun(badStatus))
+}
+
+func TestExternalJobRunID(t *testing.T) {
+	id := ExternalJobRunID("jenkins", "e2e", "42")
+	assert.Equal(t, id, ExternalJobRunID("jenkins", "e2e", "42"))
+	assert.NotEqual(t, id, ExternalJobRunID("jenkins", "e2e", "43"))
+	assert.NotZero(t, uint64(id)&externalJobRunIDBit)
+	assert.Less(t, uint64(id), uint64(1)<<63, "IDs must fit in a bigint")
+}
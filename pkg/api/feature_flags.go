@@ -0,0 +1,207 @@
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	// FeatureReleaseScorecard gates the release readiness scorecard.
+	FeatureReleaseScorecard = "release-scorecard"
+	// FeaturePromotionRecommendation gates payload promotion recommendations.
+	FeaturePromotionRecommendation = "promotion-recommendation"
+
+	// FeatureFlagsHeader opts a client in to features regardless of their rollout, as a comma separated list of
+	// names, so dark features can be tried out.
+	FeatureFlagsHeader = "X-Sippy-Features"
+
+	featureFlagSourceDefault  = "default"
+	featureFlagSourceFlag     = "flag"
+	featureFlagSourceDatabase = "database"
+)
+
+// FeatureFlags describes the experimental features that can be gated. They are disabled unless rolled out.
+var FeatureFlags = map[string]string{
+	FeatureReleaseScorecard:        "Release readiness scorecard, /api/releases/{release}/scorecard",
+	FeaturePromotionRecommendation: "Payload promotion recommendations, /api/releases/promotion_recommendation",
+}
+
+// FeatureFlagSet decides which clients experimental features are enabled for. Each feature is rolled out to a
+// percentage of clients, set by the server's --feature-flag flag or, taking precedence, in the database.
+type FeatureFlagSet struct {
+	dbc *db.DB
+	// rollouts are the percentages set on the command line, by feature.
+	rollouts map[string]int
+}
+
+// NewFeatureFlagSet returns the feature flags with the given rollouts, which may be overridden in the database if
+// dbc is set.
+func NewFeatureFlagSet(dbc *db.DB, rollouts map[string]int) (*FeatureFlagSet, error) {
+	for name, percentage := range rollouts {
+		if err := validateFeatureFlag(name, percentage); err != nil {
+			return nil, err
+		}
+	}
+	return &FeatureFlagSet{dbc: dbc, rollouts: rollouts}, nil
+}
+
+// ParseFeatureFlags reads the rollouts given as name=percentage, or just the name to enable a feature for everyone.
+func ParseFeatureFlags(values []string) (map[string]int, error) {
+	rollouts := map[string]int{}
+	for _, value := range values {
+		name, percentage, found := strings.Cut(value, "=")
+		rollouts[name] = 100
+		if found {
+			p, err := strconv.Atoi(percentage)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rollout percentage for feature %s: %q", name, percentage)
+			}
+			rollouts[name] = p
+		}
+		if err := validateFeatureFlag(name, rollouts[name]); err != nil {
+			return nil, err
+		}
+	}
+	return rollouts, nil
+}
+
+func validateFeatureFlag(name string, percentage int) error {
+	if _, ok := FeatureFlags[name]; !ok {
+		names := make([]string, 0, len(FeatureFlags))
+		for n := range FeatureFlags {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown feature %q, must be one of %s", name, strings.Join(names, ", "))
+	}
+	if percentage < 0 || percentage > 100 {
+		return fmt.Errorf("rollout percentage for feature %s must be between 0 and 100", name)
+	}
+	return nil
+}
+
+// List returns every feature flag, and whether it's enabled for the client making the request.
+func (f *FeatureFlagSet) List(req *http.Request) ([]apitype.FeatureFlag, error) {
+	stored, err := f.storedRollouts()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]apitype.FeatureFlag, 0, len(FeatureFlags))
+	for name, description := range FeatureFlags {
+		flag := apitype.FeatureFlag{Name: name, Description: description, Source: featureFlagSourceDefault}
+		if percentage, ok := f.rollouts[name]; ok {
+			flag.RolloutPercentage, flag.Source = percentage, featureFlagSourceFlag
+		}
+		if percentage, ok := stored[name]; ok {
+			flag.RolloutPercentage, flag.Source = percentage, featureFlagSourceDatabase
+		}
+		flag.Enabled = featureEnabledFor(name, flag.RolloutPercentage, req)
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+	return flags, nil
+}
+
+// Enabled reports whether a feature is enabled for the client making the request.
+func (f *FeatureFlagSet) Enabled(name string, req *http.Request) (bool, error) {
+	flags, err := f.List(req)
+	if err != nil {
+		return false, err
+	}
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag.Enabled, nil
+		}
+	}
+	return false, fmt.Errorf("unknown feature %q", name)
+}
+
+// SetRollout stores a feature's rollout percentage in the database, overriding the command line.
+func (f *FeatureFlagSet) SetRollout(rollout models.FeatureFlagRollout) (models.FeatureFlagRollout, error) {
+	rollout.ID = 0
+	if err := validateFeatureFlag(rollout.Name, rollout.RolloutPercentage); err != nil {
+		return rollout, err
+	}
+	res := f.dbc.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rollout_percentage", "updated_at", "deleted_at"}),
+	}).Create(&rollout)
+	return rollout, res.Error
+}
+
+// ClearRollout removes a feature's rollout from the database, reverting it to the command line's.
+func (f *FeatureFlagSet) ClearRollout(name string) error {
+	res := f.dbc.DB.Where("name = ?", name).Delete(&models.FeatureFlagRollout{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("feature %q has no rollout in the database", name)
+	}
+	return nil
+}
+
+func (f *FeatureFlagSet) storedRollouts() (map[string]int, error) {
+	stored := map[string]int{}
+	if f.dbc == nil {
+		return stored, nil
+	}
+	rollouts := []models.FeatureFlagRollout{}
+	if res := f.dbc.DB.Find(&rollouts); res.Error != nil {
+		return nil, res.Error
+	}
+	for _, r := range rollouts {
+		stored[r.Name] = r.RolloutPercentage
+	}
+	return stored, nil
+}
+
+// featureEnabledFor decides whether a feature rolled out to a percentage of clients is enabled for this client.
+// Each client is placed in a stable bucket per feature, so it keeps seeing the same features as the rollout grows.
+func featureEnabledFor(name string, percentage int, req *http.Request) bool {
+	for _, optIn := range strings.Split(req.Header.Get(FeatureFlagsHeader), ",") {
+		if strings.TrimSpace(optIn) == name {
+			return true
+		}
+	}
+	if percentage <= 0 {
+		return false
+	}
+	if percentage >= 100 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + "/" + featureFlagClient(req)))
+	return int(h.Sum32()%100) < percentage
+}
+
+// featureFlagClient identifies the client making a request, by its API token if it has one, otherwise by its
+// address.
+func featureFlagClient(req *http.Request) string {
+	if token := BearerToken(req); token != "" {
+		return HashAPIToken(token)
+	}
+	if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+		client, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(client)
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
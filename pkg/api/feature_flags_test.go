@@ -0,0 +1,104 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeatureFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:   "percentage and everyone",
+			values: []string{"release-scorecard=25", "promotion-recommendation"},
+			want:   map[string]int{FeatureReleaseScorecard: 25, FeaturePromotionRecommendation: 100},
+		},
+		{
+			name:   "none",
+			values: nil,
+			want:   map[string]int{},
+		},
+		{
+			name:    "unknown feature",
+			values:  []string{"time-travel"},
+			wantErr: true,
+		},
+		{
+			name:    "out of range",
+			values:  []string{"release-scorecard=150"},
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			values:  []string{"release-scorecard=half"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFeatureFlags(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFeatureFlagSetList(t *testing.T) {
+	flags, err := NewFeatureFlagSet(nil, map[string]int{FeatureReleaseScorecard: 100})
+	assert.NoError(t, err)
+
+	got, err := flags.List(httptest.NewRequest(http.MethodGet, "/api/flags", nil))
+	assert.NoError(t, err)
+	assert.Len(t, got, len(FeatureFlags))
+	for _, flag := range got {
+		switch flag.Name {
+		case FeatureReleaseScorecard:
+			assert.Equal(t, 100, flag.RolloutPercentage)
+			assert.Equal(t, featureFlagSourceFlag, flag.Source)
+			assert.True(t, flag.Enabled)
+		default:
+			assert.Equal(t, 0, flag.RolloutPercentage)
+			assert.Equal(t, featureFlagSourceDefault, flag.Source)
+			assert.False(t, flag.Enabled, "features should be dark by default")
+		}
+	}
+}
+
+func TestFeatureEnabledFor(t *testing.T) {
+	request := func(addr string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/releases/4.16/scorecard", nil)
+		req.RemoteAddr = addr + ":51234"
+		return req
+	}
+
+	enabled := 0
+	for i := 0; i < 1000; i++ {
+		req := request(fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+		if featureEnabledFor(FeatureReleaseScorecard, 30, req) {
+			enabled++
+			assert.True(t, featureEnabledFor(FeatureReleaseScorecard, 60, req), "growing the rollout should keep enabled clients enabled")
+		}
+		assert.Equal(t, featureEnabledFor(FeatureReleaseScorecard, 30, req), featureEnabledFor(FeatureReleaseScorecard, 30, req),
+			"clients should stay in the same bucket")
+	}
+	assert.InDelta(t, 300, enabled, 60, "about 30%% of clients should be enabled")
+
+	assert.False(t, featureEnabledFor(FeatureReleaseScorecard, 0, request("10.0.0.1")))
+	assert.True(t, featureEnabledFor(FeatureReleaseScorecard, 100, request("10.0.0.1")))
+
+	optIn := request("10.0.0.1")
+	optIn.Header.Set(FeatureFlagsHeader, "promotion-recommendation, release-scorecard")
+	assert.True(t, featureEnabledFor(FeatureReleaseScorecard, 0, optIn), "clients should be able to opt in to dark features")
+}
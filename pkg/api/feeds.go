@@ -0,0 +1,260 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/openshift/sippy/pkg/alerts"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// feedWindow is how far back feeds list events, long enough for a reader polling daily to miss nothing.
+	feedWindow = 14 * 24 * time.Hour
+	// maxFeedEvents caps how many events a feed lists.
+	maxFeedEvents = 100
+)
+
+// Kinds of notable events listed by feeds.
+const (
+	FeedEventAlertFired           = "alert_fired"
+	FeedEventPayloadRejected      = "payload_rejected"
+	FeedEventDisruptionRegression = "disruption_regression"
+)
+
+// Feed formats.
+const (
+	FeedFormatAtom = "atom"
+	FeedFormatRSS  = "rss"
+)
+
+// FeedEvent is a notable event listed by a feed.
+type FeedEvent struct {
+	// ID is stable across requests, so feed readers only announce an event once.
+	ID      string
+	Kind    string
+	Title   string
+	Summary string
+	Link    string
+	Time    time.Time
+}
+
+// GetFeedEventsFromDB lists the notable events of the last two weeks, newest first, optionally limited to a release
+// or a kind of event. Relative links are resolved against baseURL, the address sippy is served from.
+func GetFeedEventsFromDB(dbc *db.DB, release, kind string, baseURL *url.URL, reportEnd time.Time) ([]FeedEvent, error) {
+	since := reportEnd.Add(-feedWindow)
+	events := []FeedEvent{}
+	link := func(path string, params url.Values) string {
+		u := baseURL.JoinPath(path)
+		u.RawQuery = params.Encode()
+		return u.String()
+	}
+
+	if kind == "" || kind == FeedEventAlertFired {
+		fired, err := query.FiredAlerts(dbc, release, since)
+		if err != nil {
+			return nil, fmt.Errorf("error querying fired alerts: %w", err)
+		}
+		for _, a := range fired {
+			events = append(events, FeedEvent{
+				ID:      fmt.Sprintf("%s/%d", FeedEventAlertFired, a.ID),
+				Kind:    FeedEventAlertFired,
+				Title:   fmt.Sprintf("Alert %s fired", a.Rule),
+				Summary: alerts.Message(a),
+				Link:    link("/api/alerts", nil),
+				Time:    a.EvaluatedAt,
+			})
+		}
+	}
+
+	if kind == "" || kind == FeedEventPayloadRejected {
+		rejected, err := query.RejectedPayloads(dbc, release, since)
+		if err != nil {
+			return nil, fmt.Errorf("error querying rejected payloads: %w", err)
+		}
+		for _, p := range rejected {
+			events = append(events, FeedEvent{
+				ID:      fmt.Sprintf("%s/%s", FeedEventPayloadRejected, p.ReleaseTag),
+				Kind:    FeedEventPayloadRejected,
+				Title:   fmt.Sprintf("Payload %s rejected", p.ReleaseTag),
+				Summary: fmt.Sprintf("The %s %s %s payload %s was rejected", p.Release, p.Architecture, p.Stream, p.ReleaseTag),
+				Link:    fmt.Sprintf("https://%s.ocp.releases.ci.openshift.org/releasetag/%s", p.Architecture, p.ReleaseTag),
+				Time:    p.ReleaseTime,
+			})
+		}
+	}
+
+	if kind == "" || kind == FeedEventDisruptionRegression {
+		minDays := DefaultDisruptionRegressionMinDays
+		opened, err := query.OpenedDisruptionRegressions(dbc, release, minDays, since)
+		if err != nil {
+			return nil, fmt.Errorf("error querying disruption regressions: %w", err)
+		}
+		for _, r := range opened {
+			nurp := fmt.Sprintf("%s %s %s %s %s %s", r.Platform, r.Architecture, r.Network, r.Topology, r.UpgradeType, r.MasterNodesUpdated)
+			events = append(events, FeedEvent{
+				ID:    fmt.Sprintf("%s/%d/%s", FeedEventDisruptionRegression, r.ID, r.FirstBadDate.Format("2006-01-02")),
+				Kind:  FeedEventDisruptionRegression,
+				Title: fmt.Sprintf("%s disruption regressed in %s", r.BackendName, r.Release),
+				Summary: fmt.Sprintf("%s disruption on %s has been regressed for %d days, P95 is up %.2fs",
+					r.BackendName, nurp, r.ConsecutiveBadDays, r.P95Delta),
+				Link: link("/api/disruption/regressions", url.Values{"release": {r.Release}}),
+				Time: r.FirstBadDate.AddDate(0, 0, minDays-1),
+			})
+		}
+	}
+
+	return newestFeedEvents(events), nil
+}
+
+// newestFeedEvents sorts events newest first and keeps at most maxFeedEvents of them.
+func newestFeedEvents(events []FeedEvent) []FeedEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.After(events[j].Time)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events[:min(len(events), maxFeedEvents)]
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Link     atomLink     `xml:"link"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// RespondWithFeed writes the events as an Atom or RSS feed, titled and linking to the given address of the feed.
+func RespondWithFeed(w http.ResponseWriter, format, title, link string, events []FeedEvent, now time.Time) {
+	var feed interface{}
+	var contentType string
+	switch format {
+	case FeedFormatAtom:
+		contentType = "application/atom+xml; charset=utf-8"
+		feed = newAtomFeed(title, link, events, now)
+	case FeedFormatRSS:
+		contentType = "application/rss+xml; charset=utf-8"
+		feed = newRSSFeed(title, link, events, now)
+	default:
+		RespondWithError(w, http.StatusBadRequest, "unknown feed format "+format)
+		return
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "could not marshal feed: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, xml.Header)
+	_, _ = w.Write(body)
+}
+
+// feedUpdated is when the newest event happened, or now if there are none.
+func feedUpdated(events []FeedEvent, now time.Time) time.Time {
+	if len(events) == 0 {
+		return now
+	}
+	return events[0].Time
+}
+
+func newAtomFeed(title, link string, events []FeedEvent, now time.Time) atomFeed {
+	feed := atomFeed{
+		ID:      link,
+		Title:   title,
+		Updated: feedUpdated(events, now).UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: link, Rel: "self"},
+		Entries: make([]atomEntry, 0, len(events)),
+	}
+	for _, e := range events {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:       "tag:sippy," + e.ID,
+			Title:    e.Title,
+			Updated:  e.Time.UTC().Format(time.RFC3339),
+			Link:     atomLink{Href: e.Link},
+			Category: atomCategory{Term: e.Kind},
+			Summary:  e.Summary,
+		})
+	}
+	return feed
+}
+
+func newRSSFeed(title, link string, events []FeedEvent, now time.Time) rssFeed {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          link,
+			Description:   title,
+			LastBuildDate: feedUpdated(events, now).UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(events)),
+		},
+	}
+	for _, e := range events {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			Description: e.Summary,
+			Category:    e.Kind,
+			GUID:        rssGUID{Value: "sippy:" + e.ID},
+			PubDate:     e.Time.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return feed
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewestFeedEvents(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []FeedEvent{
+		{ID: "alert_fired/2", Time: now.Add(-2 * time.Hour)},
+		{ID: "payload_rejected/b", Time: now},
+		{ID: "payload_rejected/a", Time: now},
+		{ID: "alert_fired/1", Time: now.Add(-time.Hour)},
+	}
+	var ids []string
+	for _, e := range newestFeedEvents(events) {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []string{"payload_rejected/a", "payload_rejected/b", "alert_fired/1", "alert_fired/2"}, ids)
+
+	many := make([]FeedEvent, maxFeedEvents+10)
+	for i := range many {
+		many[i] = FeedEvent{ID: fmt.Sprint(i), Time: now.Add(time.Duration(i) * time.Minute)}
+	}
+	newest := newestFeedEvents(many)
+	require.Len(t, newest, maxFeedEvents)
+	assert.Equal(t, fmt.Sprint(maxFeedEvents+9), newest[0].ID)
+}
+
+func TestRespondWithFeed(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []FeedEvent{
+		{
+			ID:      "payload_rejected/4.16.0-0.nightly-2024-03-01-100000",
+			Kind:    FeedEventPayloadRejected,
+			Title:   "Payload 4.16.0-0.nightly-2024-03-01-100000 rejected",
+			Summary: "The 4.16 amd64 nightly payload 4.16.0-0.nightly-2024-03-01-100000 was rejected",
+			Link:    "https://amd64.ocp.releases.ci.openshift.org/releasetag/4.16.0-0.nightly-2024-03-01-100000",
+			Time:    now.Add(-2 * time.Hour),
+		},
+	}
+	link := "https://sippy.example.com/api/feeds/atom?release=4.16"
+
+	tests := []struct {
+		name            string
+		format          string
+		events          []FeedEvent
+		wantStatus      int
+		wantContentType string
+		check           func(t *testing.T, body []byte)
+	}{
+		{
+			name:            "atom",
+			format:          FeedFormatAtom,
+			events:          events,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/atom+xml; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				feed := atomFeed{}
+				require.NoError(t, xml.Unmarshal(body, &feed))
+				assert.Equal(t, "2024-03-01T10:00:00Z", feed.Updated)
+				assert.Equal(t, link, feed.Link.Href)
+				require.Len(t, feed.Entries, 1)
+				assert.Equal(t, "tag:sippy,"+events[0].ID, feed.Entries[0].ID)
+				assert.Equal(t, events[0].Link, feed.Entries[0].Link.Href)
+				assert.Equal(t, FeedEventPayloadRejected, feed.Entries[0].Category.Term)
+			},
+		},
+		{
+			name:            "empty atom feed is updated now",
+			format:          FeedFormatAtom,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/atom+xml; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				feed := atomFeed{}
+				require.NoError(t, xml.Unmarshal(body, &feed))
+				assert.Equal(t, "2024-03-01T12:00:00Z", feed.Updated)
+				assert.Empty(t, feed.Entries)
+			},
+		},
+		{
+			name:            "rss",
+			format:          FeedFormatRSS,
+			events:          events,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/rss+xml; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				feed := rssFeed{}
+				require.NoError(t, xml.Unmarshal(body, &feed))
+				assert.Equal(t, "2.0", feed.Version)
+				require.Len(t, feed.Channel.Items, 1)
+				item := feed.Channel.Items[0]
+				assert.Equal(t, events[0].Title, item.Title)
+				assert.Equal(t, "sippy:"+events[0].ID, item.GUID.Value)
+				assert.False(t, item.GUID.IsPermaLink)
+				assert.Equal(t, "Fri, 01 Mar 2024 10:00:00 +0000", item.PubDate)
+			},
+		},
+		{
+			name:       "unknown format",
+			format:     "json",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			RespondWithFeed(w, tt.format, "Sippy 4.16 events", link, tt.events, now)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.check == nil {
+				return
+			}
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			tt.check(t, w.Body.Bytes())
+		})
+	}
+}
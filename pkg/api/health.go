@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
 	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/query"
@@ -42,85 +43,85 @@ func useNewInstallTest(release string) bool {
 	return true
 }
 
-// PrintOverallReleaseHealthFromDB gives a summarized status of the overall health, including
-// infrastructure, install, upgrade, and variant success rates.
-func PrintOverallReleaseHealthFromDB(w http.ResponseWriter, dbc *db.DB, release string, reportEnd time.Time) {
+// OpenShiftIndicators are the top level indicators reported for OpenShift releases when none are configured.
+func OpenShiftIndicators(release string) []v1config.IndicatorConfig {
 	excludedVariants := testidentification.DefaultExcludedVariants
 	// Minor upgrades install a previous version and should not be counted against the current version's install stat.
-	excludedInstallVariants := testidentification.DefaultExcludedVariants
+	excludedInstallVariants := append([]string{}, testidentification.DefaultExcludedVariants...)
 	excludedInstallVariants = append(excludedInstallVariants, "upgrade-minor")
 
-	indicators := make(map[string]apitype.Test)
-
 	infraTestName := testidentification.InfrastructureTestName
 	installTestName := testidentification.InstallTestName
 	if useNewInstallTest(release) {
 		infraTestName = testidentification.NewInfrastructureTestName
 		installTestName = testidentification.NewInstallTestName
 	}
-	// Infrastructure
-	infraIndicator, err := query.TestReportExcludeVariants(dbc, release, infraTestName, excludedVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying infrastructure test report")
-		return
-	}
-	indicators["infrastructure"] = infraIndicator
 
-	// Install Configuration
-	installConfigIndicator, err := query.TestReportExcludeVariants(dbc, release, testidentification.InstallConfigTestName, excludedInstallVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying install test report")
-		return
+	indicator := func(name, testName string, excluded []string) v1config.IndicatorConfig {
+		return v1config.IndicatorConfig{
+			Name:            name,
+			TestNames:       []string{testName},
+			ExcludeVariants: excluded,
+		}
 	}
-	indicators["installConfig"] = installConfigIndicator
-
-	// Bootstrap
-	bootstrapIndicator, err := query.TestReportExcludeVariants(dbc, release, testidentification.InstallBootstrapTestName, excludedInstallVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying bootstrap test report")
-		return
+	return []v1config.IndicatorConfig{
+		indicator("infrastructure", infraTestName, excludedVariants),
+		indicator("installConfig", testidentification.InstallConfigTestName, excludedInstallVariants),
+		indicator("bootstrap", testidentification.InstallBootstrapTestName, excludedInstallVariants),
+		indicator("installOther", testidentification.InstallOtherTestName, excludedInstallVariants),
+		indicator("install", installTestName, excludedInstallVariants),
+		indicator("upgrade", testidentification.UpgradeTestName, excludedVariants),
+		// NOTE: this is not actually representing the percentage of tests that passed, it's representing
+		// the percentage of time that all tests passed. We should probably fix that.
+		indicator("tests", testidentification.OpenShiftTestsName, excludedVariants),
 	}
-	indicators["bootstrap"] = bootstrapIndicator
+}
 
-	// Install Other
-	installOtherIndicator, err := query.TestReportExcludeVariants(dbc, release, testidentification.InstallOtherTestName, excludedInstallVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying install (other) test report")
-		return
+// ReleaseIndicators returns the configured indicators, or the OpenShift indicators for the release if none are
+// configured and openshift is true.
+func ReleaseIndicators(configured []v1config.IndicatorConfig, openshift bool, release string) []v1config.IndicatorConfig {
+	if len(configured) > 0 {
+		return configured
 	}
-	indicators["installOther"] = installOtherIndicator
-
-	// Install
-	installIndicator, err := query.TestReportExcludeVariants(dbc, release, installTestName, excludedInstallVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying install test report")
-		return
+	if openshift {
+		return OpenShiftIndicators(release)
 	}
-	indicators["install"] = installIndicator
+	return nil
+}
 
-	// Upgrade
-	upgradeIndicator, err := query.TestReportExcludeVariants(dbc, release, testidentification.UpgradeTestName, excludedVariants)
+// PrintOverallReleaseHealthFromDB gives a summarized status of the overall health, including
+// infrastructure, install, upgrade, and variant success rates.
+func PrintOverallReleaseHealthFromDB(w http.ResponseWriter, dbc *db.DB, release, arch string, indicatorConfigs []v1config.IndicatorConfig, promotionWarningThreshold time.Duration, reportEnd time.Time) {
+	health, err := GetReleaseHealthFromDB(dbc, release, arch, indicatorConfigs, promotionWarningThreshold, reportEnd)
 	if err != nil {
-		log.WithError(err).Error("error querying upgrade test report")
+		RespondWithError(w, http.StatusInternalServerError, "Error building health report: "+err.Error())
 		return
 	}
-	indicators["upgrade"] = upgradeIndicator
+	RespondWithJSON(http.StatusOK, w, health)
+}
 
-	// Tests
-	// NOTE: this is not actually representing the percentage of tests that passed, it's representing
-	// the percentage of time that all tests passed. We should probably fix that.
-	testsIndicator, err := query.TestReportExcludeVariants(dbc, release, testidentification.OpenShiftTestsName, excludedVariants)
-	if err != nil {
-		log.WithError(err).Error("error querying test report")
-		return
+// GetReleaseHealthFromDB builds the release health summary shared by the HTTP and gRPC APIs. If arch is set, the
+// indicators and job statistics only count the jobs of that architecture, while promotions and warnings remain
+// those of the release.
+func GetReleaseHealthFromDB(dbc *db.DB, release, arch string, indicatorConfigs []v1config.IndicatorConfig, promotionWarningThreshold time.Duration, reportEnd time.Time) (apitype.Health, error) {
+	indicators := make(map[string]apitype.Test)
+	for _, ic := range indicatorConfigs {
+		indicator, err := query.TestReportMatching(dbc, release, arch, ic.TestNames, ic.TestRegexes, ic.ExcludeVariants)
+		if err != nil {
+			log.WithError(err).Errorf("error querying %s indicator test report", ic.Name)
+			return apitype.Health{}, err
+		}
+		if indicator.Name == "" {
+			indicator.Name = ic.Name
+		}
+		indicators[ic.Name] = indicator
 	}
-	indicators["tests"] = testsIndicator
 
 	var lastUpdated time.Time
 	r := dbc.DB.Raw("SELECT MAX(created_at) FROM prow_job_runs").Scan(&lastUpdated)
 	if r.Error != nil {
-		log.WithError(err).Error("error querying last update time")
-		return
+		log.WithError(r.Error).Error("error querying last update time")
+		return apitype.Health{}, r.Error
 	}
 	log.WithField("lastUpdated", lastUpdated).Info("ran the last update query")
 
@@ -131,25 +132,44 @@ func PrintOverallReleaseHealthFromDB(w http.ResponseWriter, dbc *db.DB, release
 		Sort:      apitype.SortDescending,
 		Limit:     0,
 	}
+	if arch != "" {
+		filterOpts.Filter.LinkOperator = filter.LinkOperatorAnd
+		filterOpts.Filter.Items = append(filterOpts.Filter.Items, filter.ArchitectureItem(arch))
+	}
 	start := reportEnd.Add(-14 * 24 * time.Hour)
 	boundary := reportEnd.Add(-7 * 24 * time.Hour)
 	end := reportEnd
 	jobReports, err := query.JobReports(dbc, filterOpts, release, start, boundary, end)
 	if err != nil {
 		log.WithError(err).Error("error querying job reports")
-		return
+		return apitype.Health{}, err
 	}
 	currStats, prevStats := calculateJobResultStatistics(jobReports)
 
-	warnings := ScanForReleaseWarnings(dbc, release, reportEnd)
+	flakyRuns, err := query.JobFlakyRuns(dbc, release, start, boundary, end)
+	if err != nil {
+		log.WithError(err).Error("error querying flaky job runs")
+		return apitype.Health{}, err
+	}
+	addJobFlakyRuns(jobReports, flakyRuns)
+
+	warnings := ScanForReleaseWarnings(dbc, release, promotionWarningThreshold, reportEnd)
+
+	promotions, err := GetReleasePromotionTimes(dbc, release, reportEnd)
+	if err != nil {
+		log.WithError(err).Error("error querying release promotions")
+		return apitype.Health{}, err
+	}
 
-	RespondWithJSON(http.StatusOK, w, apitype.Health{
+	return apitype.Health{
 		Indicators:  indicators,
 		LastUpdated: lastUpdated,
 		Current:     currStats,
 		Previous:    prevStats,
+		FlakyRuns:   summarizeFlakyRuns(jobReports),
+		Promotions:  promotions,
 		Warnings:    warnings,
-	})
+	}, nil
 }
 
 func calculateJobResultStatistics(results []apitype.Job) (currStats, prevStats sippyprocessingv1.Statistics) {
@@ -164,7 +184,7 @@ func calculateJobResultStatistics(results []apitype.Job) (currStats, prevStats s
 			continue
 		}
 
-		if util.IsNeverStable(result.Variants) {
+		if util.IsNeverStable(result.Variants) || util.StrSliceContains(result.Variants, testidentification.ChaosVariant) {
 			continue
 		}
 
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+func TestReleaseIndicators(t *testing.T) {
+	configured := []v1config.IndicatorConfig{
+		{Name: "conformance", TestRegexes: []string{`^\[sig-node\] .*`}},
+	}
+
+	tests := []struct {
+		name        string
+		configured  []v1config.IndicatorConfig
+		openshift   bool
+		release     string
+		wantNames   []string
+		wantInstall string
+	}{
+		{
+			name:        "openshift default before 4.11 uses old install test",
+			openshift:   true,
+			release:     "4.10",
+			wantNames:   []string{"infrastructure", "installConfig", "bootstrap", "installOther", "install", "upgrade", "tests"},
+			wantInstall: testidentification.InstallTestName,
+		},
+		{
+			name:        "openshift default from 4.11 uses new install test",
+			openshift:   true,
+			release:     "4.14",
+			wantNames:   []string{"infrastructure", "installConfig", "bootstrap", "installOther", "install", "upgrade", "tests"},
+			wantInstall: testidentification.NewInstallTestName,
+		},
+		{
+			name:       "configured indicators override openshift defaults",
+			configured: configured,
+			openshift:  true,
+			release:    "4.14",
+			wantNames:  []string{"conformance"},
+		},
+		{
+			name:    "no indicators outside openshift unless configured",
+			release: "1.30",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indicators := ReleaseIndicators(tt.configured, tt.openshift, tt.release)
+			names := []string{}
+			for _, ic := range indicators {
+				names = append(names, ic.Name)
+				if ic.Name == "install" {
+					assert.Equal(t, []string{tt.wantInstall}, ic.TestNames)
+					assert.Empty(t, ic.TestRegexes)
+					assert.Contains(t, ic.ExcludeVariants, "upgrade-minor")
+				}
+			}
+			if tt.wantNames == nil {
+				assert.Empty(t, names)
+			} else {
+				assert.Equal(t, tt.wantNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+// HostedControlPlaneIndicators are the top level indicators of hypershift jobs: whether their hosted clusters are
+// created and torn down, upgraded and pass the tests.
+func HostedControlPlaneIndicators() []v1config.IndicatorConfig {
+	indicator := func(name, testName string) v1config.IndicatorConfig {
+		return v1config.IndicatorConfig{
+			Name:            name,
+			TestNames:       []string{testName},
+			ExcludeVariants: testidentification.DefaultExcludedVariants,
+		}
+	}
+	return []v1config.IndicatorConfig{
+		indicator("hostedClusterCreate", testidentification.HostedClusterCreateTestName),
+		indicator("hostedClusterTeardown", testidentification.HostedClusterTeardownTestName),
+		indicator("upgrade", testidentification.UpgradeTestName),
+		indicator("tests", testidentification.OpenShiftTestsName),
+	}
+}
+
+// GetHostedControlPlaneHealthFromDB summarizes the health of the release's hypershift jobs apart from the
+// standalone ones: their indicators, job statistics, and pass rates by the platform of their hosted clusters.
+func GetHostedControlPlaneHealthFromDB(dbc *db.DB, release string, reportEnd time.Time) (apitype.HostedControlPlaneHealth, error) {
+	indicators := make(map[string]apitype.Test)
+	for _, ic := range HostedControlPlaneIndicators() {
+		indicator, err := query.TestReportMatchingVariant(dbc, release, testidentification.HostedControlPlaneVariant,
+			ic.TestNames, ic.TestRegexes, ic.ExcludeVariants)
+		if err != nil {
+			return apitype.HostedControlPlaneHealth{}, err
+		}
+		if indicator.Name == "" {
+			indicator.Name = ic.Name
+		}
+		indicators[ic.Name] = indicator
+	}
+
+	filterOpts := &filter.FilterOptions{
+		Filter: &filter.Filter{
+			LinkOperator: filter.LinkOperatorAnd,
+			Items: []filter.FilterItem{
+				{Field: "variants", Operator: filter.OperatorContains, Value: testidentification.HostedControlPlaneVariant},
+			},
+		},
+		SortField: "current_pass_percentage",
+		Sort:      apitype.SortAscending,
+	}
+	start := reportEnd.Add(-14 * 24 * time.Hour)
+	boundary := reportEnd.Add(-7 * 24 * time.Hour)
+	jobs, err := query.JobReports(dbc, filterOpts, release, start, boundary, reportEnd)
+	if err != nil {
+		return apitype.HostedControlPlaneHealth{}, err
+	}
+	flakyRuns, err := query.JobFlakyRuns(dbc, release, start, boundary, reportEnd)
+	if err != nil {
+		return apitype.HostedControlPlaneHealth{}, err
+	}
+	addJobFlakyRuns(jobs, flakyRuns)
+	currStats, prevStats := calculateJobResultStatistics(jobs)
+
+	return apitype.HostedControlPlaneHealth{
+		Indicators: indicators,
+		Current:    currStats,
+		Previous:   prevStats,
+		FlakyRuns:  summarizeFlakyRuns(jobs),
+		Platforms:  hostedControlPlanePlatforms(jobs),
+		Jobs:       jobs,
+	}, nil
+}
+
+// hostedControlPlanePlatforms totals the runs of the jobs by the platform of their hosted clusters, those passing
+// least first.
+func hostedControlPlanePlatforms(jobs []apitype.Job) []apitype.HostedControlPlanePlatform {
+	byPlatform := map[string]*apitype.HostedControlPlanePlatform{}
+	for _, job := range jobs {
+		platform := "unknown"
+		for _, v := range job.Variants {
+			if strings.HasPrefix(v, "Platform:") {
+				platform = strings.TrimPrefix(v, "Platform:")
+				break
+			}
+		}
+		p, ok := byPlatform[platform]
+		if !ok {
+			p = &apitype.HostedControlPlanePlatform{Platform: platform}
+			byPlatform[platform] = p
+		}
+		p.Jobs++
+		p.CurrentRuns += job.CurrentRuns
+		p.CurrentPasses += job.CurrentPasses
+		p.PreviousRuns += job.PreviousRuns
+		p.PreviousPasses += job.PreviousPasses
+	}
+
+	platforms := make([]apitype.HostedControlPlanePlatform, 0, len(byPlatform))
+	for _, p := range byPlatform {
+		p.CurrentPassPercentage = percentOf(p.CurrentPasses, p.CurrentRuns)
+		p.PreviousPassPercentage = percentOf(p.PreviousPasses, p.PreviousRuns)
+		platforms = append(platforms, *p)
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		if platforms[i].CurrentPassPercentage != platforms[j].CurrentPassPercentage {
+			return platforms[i].CurrentPassPercentage < platforms[j].CurrentPassPercentage
+		}
+		return platforms[i].Platform < platforms[j].Platform
+	})
+	return platforms
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestHostedControlPlanePlatforms(t *testing.T) {
+	jobs := []apitype.Job{
+		{Name: "e2e-aws-ovn", Variants: []string{"Platform:aws", "Topology:external"},
+			CurrentRuns: 10, CurrentPasses: 9, PreviousRuns: 10, PreviousPasses: 10},
+		{Name: "e2e-aws-ovn-conformance", Variants: []string{"Platform:aws", "Topology:external"},
+			CurrentRuns: 10, CurrentPasses: 7, PreviousRuns: 0},
+		{Name: "e2e-kubevirt-metal-ovn", Variants: []string{"Platform:kubevirt", "Topology:external"},
+			CurrentRuns: 4, CurrentPasses: 1, PreviousRuns: 4, PreviousPasses: 3},
+		{Name: "e2e-powervs", Variants: []string{"Topology:external"}},
+	}
+
+	platforms := hostedControlPlanePlatforms(jobs)
+	require.Len(t, platforms, 3)
+	assert.Equal(t, apitype.HostedControlPlanePlatform{Platform: "unknown", Jobs: 1}, platforms[0])
+	assert.Equal(t, apitype.HostedControlPlanePlatform{
+		Platform: "kubevirt", Jobs: 1, CurrentRuns: 4, CurrentPasses: 1, CurrentPassPercentage: 25,
+		PreviousRuns: 4, PreviousPasses: 3, PreviousPassPercentage: 75,
+	}, platforms[1])
+	assert.Equal(t, apitype.HostedControlPlanePlatform{
+		Platform: "aws", Jobs: 2, CurrentRuns: 20, CurrentPasses: 16, CurrentPassPercentage: 80,
+		PreviousRuns: 10, PreviousPasses: 10, PreviousPassPercentage: 100,
+	}, platforms[2])
+}
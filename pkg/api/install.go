@@ -17,21 +17,10 @@ import (
 
 // PrintInstallJSONReportFromDB renders a report showing the success/fail rates of operator installation.
 func PrintInstallJSONReportFromDB(w http.ResponseWriter, dbc *db.DB, release string) {
-	excludedVariants := testidentification.DefaultExcludedVariants
-	excludedVariants = append(excludedVariants, "upgrade-minor")
-	exactTestNames := sets.NewString()
-	testPrefixes := sets.NewString(testidentification.OperatorInstallPrefix)
-	if useNewInstallTest(release) {
-		testPrefixes.Insert(testidentification.InstallTestNamePrefix)
-	} else {
-		exactTestNames = exactTestNames.Insert(testidentification.InstallTestName)
-	}
-
-	variantColumns, tests, err := VariantTestsReport(dbc, release, v1.CurrentReport,
-		exactTestNames, testPrefixes, sets.NewString(), excludedVariants)
+	variantColumns, tests, err := InstallReport(dbc, release, v1.CurrentReport)
 	if err != nil {
 		log.WithError(err).Error("could not generate install report")
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Could not generate install report: " + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Could not generate install report: "+err.Error())
 		return
 	}
 
@@ -46,7 +35,7 @@ func PrintInstallJSONReportFromDB(w http.ResponseWriter, dbc *db.DB, release str
 	result, err := json.Marshal(summary)
 	if err != nil {
 		log.WithError(err).Error("could not generate install report")
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Could not generate install report: " + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Could not generate install report: "+err.Error())
 		return
 	}
 
@@ -54,6 +43,22 @@ func PrintInstallJSONReportFromDB(w http.ResponseWriter, dbc *db.DB, release str
 	RespondWithJSON(http.StatusOK, w, jsonStr)
 }
 
+// InstallReport returns the variant columns and the install rates by variant of each operator, and of the install
+// overall.
+func InstallReport(dbc *db.DB, release string, reportType v1.ReportType) (sets.String, map[string]map[string]apitype.Test, error) {
+	excludedVariants := append([]string{}, testidentification.DefaultExcludedVariants...)
+	excludedVariants = append(excludedVariants, "upgrade-minor")
+	exactTestNames := sets.NewString()
+	testPrefixes := sets.NewString(testidentification.OperatorInstallPrefix)
+	if useNewInstallTest(release) {
+		testPrefixes.Insert(testidentification.InstallTestNamePrefix)
+	} else {
+		exactTestNames = exactTestNames.Insert(testidentification.InstallTestName)
+	}
+
+	return VariantTestsReport(dbc, release, reportType, exactTestNames, testPrefixes, sets.NewString(), excludedVariants)
+}
+
 // VariantTestsReport returns a set of all variant columns plus "All", and a map of testName to variant column to test results for that variant.
 // Caller can provide exact test names to match, test name prefixes, or test substrings.
 func VariantTestsReport(dbc *db.DB, release string, reportType v1.ReportType,
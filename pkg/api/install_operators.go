@@ -0,0 +1,110 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/util/sets"
+)
+
+const unknownPlatform = "unknown"
+
+// GetInstallOperatorHealthFromDB reports which cluster operators most often fail to install on each platform
+// between start and end, and the degraded, unavailable or progressing conditions they reported when they did.
+// Platforms are identified by the job variants in platforms, and only platform is reported if it is set.
+func GetInstallOperatorHealthFromDB(dbc *db.DB, release string, platforms sets.String, platform string, start, end time.Time) ([]apitype.InstallOperatorHealth, error) {
+	failures, err := query.InstallOperatorFailures(dbc, release, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return groupInstallOperatorFailures(failures, platforms, platform), nil
+}
+
+// groupInstallOperatorFailures counts, per platform, the job runs in which each operator failed to install, and
+// how many of those runs reported each condition. Operators that fail most often are listed first.
+func groupInstallOperatorFailures(failures []query.InstallOperatorFailure, platforms sets.String, platform string) []apitype.InstallOperatorHealth {
+	type operatorKey struct{ platform, operator string }
+	type conditionKey struct{ condition, reason string }
+
+	platformRuns := map[string]map[uint]bool{}
+	operatorRuns := map[operatorKey]map[uint]bool{}
+	conditionRuns := map[operatorKey]map[conditionKey]map[uint]bool{}
+	for _, f := range failures {
+		p := jobPlatform(f.Variants, platforms)
+		if platform != "" && p != platform {
+			continue
+		}
+		key := operatorKey{p, f.Operator}
+		if platformRuns[p] == nil {
+			platformRuns[p] = map[uint]bool{}
+		}
+		if operatorRuns[key] == nil {
+			operatorRuns[key] = map[uint]bool{}
+			conditionRuns[key] = map[conditionKey]map[uint]bool{}
+		}
+		platformRuns[p][f.ProwJobRunID] = true
+		operatorRuns[key][f.ProwJobRunID] = true
+
+		if f.Condition == "" {
+			continue
+		}
+		cKey := conditionKey{f.Condition, f.Reason}
+		if conditionRuns[key][cKey] == nil {
+			conditionRuns[key][cKey] = map[uint]bool{}
+		}
+		conditionRuns[key][cKey][f.ProwJobRunID] = true
+	}
+
+	results := make([]apitype.InstallOperatorHealth, 0, len(operatorRuns))
+	for key, runs := range operatorRuns {
+		health := apitype.InstallOperatorHealth{
+			Platform:                   key.platform,
+			Operator:                   key.operator,
+			FailedInstalls:             len(runs),
+			PercentageOfFailedInstalls: float64(len(runs)) * 100 / float64(len(platformRuns[key.platform])),
+			Conditions:                 []apitype.InstallOperatorCondition{},
+		}
+		for cKey, cRuns := range conditionRuns[key] {
+			health.Conditions = append(health.Conditions, apitype.InstallOperatorCondition{
+				Condition: cKey.condition,
+				Reason:    cKey.reason,
+				Count:     len(cRuns),
+			})
+		}
+		sort.Slice(health.Conditions, func(i, j int) bool {
+			ci, cj := health.Conditions[i], health.Conditions[j]
+			if ci.Count != cj.Count {
+				return ci.Count > cj.Count
+			}
+			if ci.Condition != cj.Condition {
+				return ci.Condition < cj.Condition
+			}
+			return ci.Reason < cj.Reason
+		})
+		results = append(results, health)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].FailedInstalls != results[j].FailedInstalls {
+			return results[i].FailedInstalls > results[j].FailedInstalls
+		}
+		if results[i].Platform != results[j].Platform {
+			return results[i].Platform < results[j].Platform
+		}
+		return results[i].Operator < results[j].Operator
+	})
+	return results
+}
+
+// jobPlatform returns the first of a job's variants that is a platform.
+func jobPlatform(variants []string, platforms sets.String) string {
+	for _, v := range variants {
+		if platforms.Has(v) {
+			return v
+		}
+	}
+	return unknownPlatform
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/util/sets"
+)
+
+func TestGroupInstallOperatorFailures(t *testing.T) {
+	platforms := sets.NewString("aws", "gcp")
+	aws := []string{"aws", "ovn", "amd64"}
+	gcp := []string{"ovn", "gcp"}
+	failures := []query.InstallOperatorFailure{
+		{ProwJobRunID: 1, Operator: "kube-apiserver", Variants: aws, Condition: "degraded", Reason: "NodeInstaller_InstallerPodFailed"},
+		{ProwJobRunID: 1, Operator: "kube-apiserver", Variants: aws, Condition: "unavailable", Reason: "StaticPods_ZeroNodesActive"},
+		{ProwJobRunID: 1, Operator: "ingress", Variants: aws},
+		{ProwJobRunID: 2, Operator: "kube-apiserver", Variants: aws, Condition: "degraded", Reason: "NodeInstaller_InstallerPodFailed"},
+		{ProwJobRunID: 3, Operator: "ingress", Variants: aws, Condition: "degraded", Reason: "IngressDegraded"},
+		{ProwJobRunID: 4, Operator: "ingress", Variants: aws, Condition: "degraded", Reason: "IngressDegraded"},
+		{ProwJobRunID: 5, Operator: "ingress", Variants: aws, Condition: "degraded", Reason: "IngressDegraded"},
+		{ProwJobRunID: 6, Operator: "etcd", Variants: gcp, Condition: "progressing", Reason: "NodeInstaller"},
+		{ProwJobRunID: 7, Operator: "etcd", Variants: []string{"metal"}},
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		want     []apitype.InstallOperatorHealth
+	}{
+		{
+			name: "all platforms",
+			want: []apitype.InstallOperatorHealth{
+				{Platform: "aws", Operator: "ingress", FailedInstalls: 4, PercentageOfFailedInstalls: 80,
+					Conditions: []apitype.InstallOperatorCondition{
+						{Condition: "degraded", Reason: "IngressDegraded", Count: 3},
+					}},
+				{Platform: "aws", Operator: "kube-apiserver", FailedInstalls: 2, PercentageOfFailedInstalls: 40,
+					Conditions: []apitype.InstallOperatorCondition{
+						{Condition: "degraded", Reason: "NodeInstaller_InstallerPodFailed", Count: 2},
+						{Condition: "unavailable", Reason: "StaticPods_ZeroNodesActive", Count: 1},
+					}},
+				{Platform: "gcp", Operator: "etcd", FailedInstalls: 1, PercentageOfFailedInstalls: 100,
+					Conditions: []apitype.InstallOperatorCondition{
+						{Condition: "progressing", Reason: "NodeInstaller", Count: 1},
+					}},
+				{Platform: unknownPlatform, Operator: "etcd", FailedInstalls: 1, PercentageOfFailedInstalls: 100,
+					Conditions: []apitype.InstallOperatorCondition{}},
+			},
+		},
+		{
+			name:     "one platform",
+			platform: "gcp",
+			want: []apitype.InstallOperatorHealth{
+				{Platform: "gcp", Operator: "etcd", FailedInstalls: 1, PercentageOfFailedInstalls: 100,
+					Conditions: []apitype.InstallOperatorCondition{
+						{Condition: "progressing", Reason: "NodeInstaller", Count: 1},
+					}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, groupInstallOperatorFailures(failures, platforms, tt.platform))
+		})
+	}
+}
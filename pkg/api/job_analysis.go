@@ -38,6 +38,7 @@ func PrintJobAnalysisJSONFromDB(
 		TotalRuns      int
 		Aborted        int `gorm:"column:A"`
 		Success        int `gorm:"column:S"`
+		SuccessFlakes  int `gorm:"column:L"`
 		Running        int `gorm:"column:R"`
 		FailureE2E     int `gorm:"column:F"`
 		FailureOther   int `gorm:"column:f"`
@@ -52,6 +53,7 @@ func PrintJobAnalysisJSONFromDB(
 		Select(`date_trunc(?, timestamp)        AS period,
 	           count(*)                                              AS total_runs,
 	           sum(case when overall_result = 'S' then 1 else 0 end) AS "S",
+	           sum(case when overall_result = 'L' then 1 else 0 end) AS "L",
 	           sum(case when overall_result = 'F' then 1 else 0 end) AS "F",
 	           sum(case when overall_result = 'f' then 1 else 0 end) AS "f",
 	           sum(case when overall_result = 'U' then 1 else 0 end) AS "U",
@@ -88,6 +90,7 @@ func PrintJobAnalysisJSONFromDB(
 			TotalRuns: sum.TotalRuns,
 			ResultCount: map[v1sippyprocessing.JobOverallResult]int{
 				v1sippyprocessing.JobSucceeded:             sum.Success,
+				v1sippyprocessing.JobSucceededWithFlakes:   sum.SuccessFlakes,
 				v1sippyprocessing.JobRunning:               sum.Running,
 				v1sippyprocessing.JobTestFailure:           sum.FailureE2E,
 				v1sippyprocessing.JobInfrastructureFailure: sum.Infrastructure,
@@ -124,3 +127,42 @@ func PrintJobAnalysisJSONFromDB(
 	return results, nil
 
 }
+
+// GetJobResultsByPeriodFromDB returns the results of the jobs matching the filter summed by day or hour, using
+// the job results matviews.
+func GetJobResultsByPeriodFromDB(
+	dbc *db.DB,
+	release string,
+	jobFilter *filter.Filter,
+	start, boundary, end time.Time,
+	period string) ([]apitype.JobResultsByPeriod, error) {
+	results := make([]apitype.JobResultsByPeriod, 0)
+
+	jobs, err := query.ListFilteredJobIDs(dbc, release, jobFilter, start, boundary, end, 0, "name", apitype.SortAscending)
+	if err != nil {
+		return results, err
+	}
+	if len(jobs) == 0 {
+		return results, nil
+	}
+
+	table := "prow_job_results_by_day_matview"
+	if period == PeriodHour {
+		table = "prow_job_results_by_hour_matview"
+	}
+
+	res := dbc.DB.Table(table).
+		Select(`period,
+			sum(runs) AS runs,
+			sum(successes) AS successes,
+			sum(failures) AS failures,
+			sum(infrastructure_failures) AS infrastructure_failures,
+			sum(known_failures) AS known_failures,
+			sum(successes) * 100.0 / NULLIF(sum(runs), 0) AS pass_percentage`).
+		Where("prow_job_id IN ?", jobs).
+		Where("period BETWEEN ? AND ?", start, end).
+		Group("period").
+		Order("period").
+		Scan(&results)
+	return results, res.Error
+}
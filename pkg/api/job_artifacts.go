@@ -0,0 +1,43 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// ArtifactTrendDays is how many days of artifact sizes the trend API returns.
+const ArtifactTrendDays = 30
+
+// GetJobArtifactSizesFromDB returns the artifact sizes of the release's jobs, those whose artifacts grew the
+// most since the previous period first.
+func GetJobArtifactSizesFromDB(dbc *db.DB, release string, start, boundary, end time.Time) ([]apitype.JobArtifactSize, error) {
+	sizes, err := query.JobArtifactSizes(dbc, release, start, boundary, end)
+	if err != nil {
+		return nil, err
+	}
+	sortByArtifactGrowth(sizes)
+	return sizes, nil
+}
+
+// sortByArtifactGrowth sets the growth of each job's artifacts and sorts the fastest growing first, followed by
+// the largest jobs without a previous period to compare against.
+func sortByArtifactGrowth(sizes []apitype.JobArtifactSize) {
+	for i := range sizes {
+		if sizes[i].PreviousMeanBytes > 0 {
+			sizes[i].Growth = sizes[i].CurrentMeanBytes / sizes[i].PreviousMeanBytes
+		}
+	}
+	sort.SliceStable(sizes, func(i, j int) bool {
+		if sizes[i].Growth != sizes[j].Growth {
+			return sizes[i].Growth > sizes[j].Growth
+		}
+		if sizes[i].CurrentTotalBytes != sizes[j].CurrentTotalBytes {
+			return sizes[i].CurrentTotalBytes > sizes[j].CurrentTotalBytes
+		}
+		return sizes[i].ProwJobName < sizes[j].ProwJobName
+	})
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestSortByArtifactGrowth(t *testing.T) {
+	sizes := []apitype.JobArtifactSize{
+		{ProwJobName: "steady", CurrentMeanBytes: 100, CurrentTotalBytes: 1000, PreviousMeanBytes: 100},
+		{ProwJobName: "new-small", CurrentMeanBytes: 10, CurrentTotalBytes: 10},
+		{ProwJobName: "tenfold", CurrentMeanBytes: 1000, CurrentTotalBytes: 5000, PreviousMeanBytes: 100},
+		{ProwJobName: "new-large", CurrentMeanBytes: 500, CurrentTotalBytes: 5000},
+		{ProwJobName: "shrinking", CurrentMeanBytes: 50, CurrentTotalBytes: 500, PreviousMeanBytes: 100},
+	}
+	sortByArtifactGrowth(sizes)
+
+	names := []string{}
+	for _, s := range sizes {
+		names = append(names, s.ProwJobName)
+	}
+	assert.Equal(t, []string{"tenfold", "steady", "shrinking", "new-large", "new-small"}, names)
+	assert.Equal(t, 10.0, sizes[0].Growth)
+	assert.Equal(t, 0.5, sizes[2].Growth)
+	assert.Equal(t, 0.0, sizes[3].Growth)
+}
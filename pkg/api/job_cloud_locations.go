@@ -0,0 +1,49 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// GetCloudLocationHealthFromDB returns the failure rates of the release's job runs by the cloud region their
+// clusters were installed in, or zone if byZone, those failing most above the rest first.
+func GetCloudLocationHealthFromDB(dbc *db.DB, release string, byZone bool, start, boundary, end time.Time) ([]apitype.CloudLocationHealth, error) {
+	results, err := query.CloudLocationResults(dbc, release, byZone, start, boundary, end)
+	if err != nil {
+		return nil, err
+	}
+	sortByExcessFailures(results)
+	return results, nil
+}
+
+// sortByExcessFailures sets the failure percentages of each location and how far the current one is above that of
+// all locations together, and sorts the locations failing most above it first. A brownout in one region shows as
+// a large excess there, while a bad day across CI raises every location alike.
+func sortByExcessFailures(results []apitype.CloudLocationHealth) {
+	var runs, failures int
+	for i := range results {
+		results[i].CurrentFailurePercentage = percentOf(results[i].CurrentFailures, results[i].CurrentRuns)
+		results[i].PreviousFailurePercentage = percentOf(results[i].PreviousFailures, results[i].PreviousRuns)
+		runs += results[i].CurrentRuns
+		failures += results[i].CurrentFailures
+	}
+	overall := percentOf(failures, runs)
+	for i := range results {
+		if results[i].CurrentRuns > 0 {
+			results[i].ExcessFailurePercentage = results[i].CurrentFailurePercentage - overall
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].ExcessFailurePercentage != results[j].ExcessFailurePercentage {
+			return results[i].ExcessFailurePercentage > results[j].ExcessFailurePercentage
+		}
+		if results[i].Region != results[j].Region {
+			return results[i].Region < results[j].Region
+		}
+		return results[i].Zone < results[j].Zone
+	})
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestSortByExcessFailures(t *testing.T) {
+	results := []apitype.CloudLocationHealth{
+		{Region: "us-west-2", CurrentRuns: 100, CurrentFailures: 10},
+		{Region: "us-east-1", CurrentRuns: 100, CurrentFailures: 50, PreviousRuns: 100, PreviousFailures: 10},
+		{Region: "eu-west-1", PreviousRuns: 20, PreviousFailures: 20},
+		{Region: "us-east-2", CurrentRuns: 100, CurrentFailures: 0},
+	}
+	sortByExcessFailures(results)
+
+	regions := []string{}
+	for _, r := range results {
+		regions = append(regions, r.Region)
+	}
+	assert.Equal(t, []string{"us-east-1", "eu-west-1", "us-west-2", "us-east-2"}, regions)
+	assert.InDelta(t, 50.0, results[0].CurrentFailurePercentage, 0.001)
+	assert.InDelta(t, 10.0, results[0].PreviousFailurePercentage, 0.001)
+	assert.InDelta(t, 30.0, results[0].ExcessFailurePercentage, 0.001)
+	assert.Equal(t, 0.0, results[1].ExcessFailurePercentage, "a location without current runs has no excess")
+	assert.InDelta(t, -20.0, results[3].ExcessFailurePercentage, 0.001)
+}
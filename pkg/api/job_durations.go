@@ -0,0 +1,69 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// DefaultJobDurationIncreaseThreshold is the week-over-week growth in median runtime, as a percentage,
+	// at which a job is reported.
+	DefaultJobDurationIncreaseThreshold = 20.0
+
+	// minDurationRuns is the number of runs needed in each week for the medians to be meaningful.
+	minDurationRuns = 5
+)
+
+// addJobDurations copies the duration percentiles onto the matching job report rows.
+func addJobDurations(jobs []apitype.Job, durations []apitype.JobDurationStats) {
+	byName := make(map[string]apitype.JobDurationStats, len(durations))
+	for _, d := range durations {
+		byName[d.Name] = d
+	}
+	for i := range jobs {
+		d, ok := byName[jobs[i].Name]
+		if !ok {
+			continue
+		}
+		jobs[i].CurrentDurationP50 = d.CurrentDurationP50
+		jobs[i].CurrentDurationP90 = d.CurrentDurationP90
+		jobs[i].CurrentDurationP95 = d.CurrentDurationP95
+		jobs[i].PreviousDurationP50 = d.PreviousDurationP50
+	}
+}
+
+// GetJobDurationIncreasesFromDB reports jobs whose median runtime over the last week increased by at least
+// threshold percent over the week before.
+func GetJobDurationIncreasesFromDB(dbc *db.DB, release string, threshold float64, reportEnd time.Time) ([]apitype.JobDurationIncrease, error) {
+	start := reportEnd.Add(-14 * 24 * time.Hour)
+	boundary := reportEnd.Add(-7 * 24 * time.Hour)
+	durations, err := query.JobDurationStats(dbc, release, start, boundary, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	return jobDurationIncreases(durations, threshold), nil
+}
+
+func jobDurationIncreases(durations []apitype.JobDurationStats, threshold float64) []apitype.JobDurationIncrease {
+	increases := make([]apitype.JobDurationIncrease, 0)
+	for _, d := range durations {
+		if d.CurrentRuns < minDurationRuns || d.PreviousRuns < minDurationRuns || d.PreviousDurationP50 <= 0 {
+			continue
+		}
+		pct := (d.CurrentDurationP50 - d.PreviousDurationP50) * 100 / d.PreviousDurationP50
+		if pct >= threshold {
+			increases = append(increases, apitype.JobDurationIncrease{
+				JobDurationStats:   d,
+				IncreasePercentage: pct,
+			})
+		}
+	}
+	sort.Slice(increases, func(i, j int) bool {
+		return increases[i].IncreasePercentage > increases[j].IncreasePercentage
+	})
+	return increases
+}
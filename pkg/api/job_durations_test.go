@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestJobDurationIncreases(t *testing.T) {
+	durations := []apitype.JobDurationStats{
+		{Name: "steady", CurrentRuns: 10, CurrentDurationP50: 3700, PreviousRuns: 10, PreviousDurationP50: 3600},
+		{Name: "slower", CurrentRuns: 10, CurrentDurationP50: 4500, PreviousRuns: 10, PreviousDurationP50: 3600},
+		{Name: "much-slower", CurrentRuns: 10, CurrentDurationP50: 7200, PreviousRuns: 10, PreviousDurationP50: 3600},
+		{Name: "few-runs", CurrentRuns: 2, CurrentDurationP50: 7200, PreviousRuns: 10, PreviousDurationP50: 3600},
+		{Name: "new-job", CurrentRuns: 10, CurrentDurationP50: 7200},
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		expected  []string
+	}{
+		{
+			name:      "default threshold",
+			threshold: DefaultJobDurationIncreaseThreshold,
+			expected:  []string{"much-slower", "slower"},
+		},
+		{
+			name:      "high threshold",
+			threshold: 50,
+			expected:  []string{"much-slower"},
+		},
+		{
+			name:      "no increases",
+			threshold: 200,
+			expected:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{}
+			for _, inc := range jobDurationIncreases(durations, tt.threshold) {
+				names = append(names, inc.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestAddJobDurations(t *testing.T) {
+	jobs := []apitype.Job{{Name: "a"}, {Name: "b"}}
+	addJobDurations(jobs, []apitype.JobDurationStats{{Name: "b", CurrentDurationP50: 60, CurrentDurationP95: 90, PreviousDurationP50: 50}})
+	assert.Zero(t, jobs[0].CurrentDurationP50)
+	assert.Equal(t, 60.0, jobs[1].CurrentDurationP50)
+	assert.Equal(t, 90.0, jobs[1].CurrentDurationP95)
+	assert.Equal(t, 50.0, jobs[1].PreviousDurationP50)
+}
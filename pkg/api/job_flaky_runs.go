@@ -0,0 +1,44 @@
+package api
+
+import (
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+// addJobFlakyRuns copies the counts of runs that succeeded with test flakes onto the matching job report rows.
+func addJobFlakyRuns(jobs []apitype.Job, flakyRuns []apitype.JobFlakyRuns) {
+	byName := make(map[string]apitype.JobFlakyRuns, len(flakyRuns))
+	for _, f := range flakyRuns {
+		byName[f.Name] = f
+	}
+	for i := range jobs {
+		f, ok := byName[jobs[i].Name]
+		if !ok {
+			continue
+		}
+		jobs[i].CurrentFlakyRuns = f.CurrentFlakyRuns
+		jobs[i].CurrentFlakyRunPercentage = percentOf(f.CurrentFlakyRuns, jobs[i].CurrentRuns)
+		jobs[i].PreviousFlakyRuns = f.PreviousFlakyRuns
+		jobs[i].PreviousFlakyRunPercentage = percentOf(f.PreviousFlakyRuns, jobs[i].PreviousRuns)
+	}
+}
+
+// summarizeFlakyRuns totals the flaky runs across every job in a release.
+func summarizeFlakyRuns(jobs []apitype.Job) apitype.FlakyRunSummary {
+	summary := apitype.FlakyRunSummary{}
+	for _, j := range jobs {
+		summary.CurrentRuns += j.CurrentRuns
+		summary.CurrentFlakyRuns += j.CurrentFlakyRuns
+		summary.PreviousRuns += j.PreviousRuns
+		summary.PreviousFlakyRuns += j.PreviousFlakyRuns
+	}
+	summary.CurrentFlakyRunPercentage = percentOf(summary.CurrentFlakyRuns, summary.CurrentRuns)
+	summary.PreviousFlakyRunPercentage = percentOf(summary.PreviousFlakyRuns, summary.PreviousRuns)
+	return summary
+}
+
+func percentOf(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestAddJobFlakyRuns(t *testing.T) {
+	jobs := []apitype.Job{
+		{Name: "a", CurrentRuns: 10, PreviousRuns: 20},
+		{Name: "b", CurrentRuns: 4, PreviousRuns: 0},
+		{Name: "c", CurrentRuns: 6, PreviousRuns: 5},
+	}
+	addJobFlakyRuns(jobs, []apitype.JobFlakyRuns{
+		{Name: "a", CurrentFlakyRuns: 3, PreviousFlakyRuns: 2},
+		{Name: "b", CurrentFlakyRuns: 1},
+	})
+
+	assert.InDelta(t, 30, jobs[0].CurrentFlakyRunPercentage, 0.001)
+	assert.InDelta(t, 10, jobs[0].PreviousFlakyRunPercentage, 0.001)
+	assert.InDelta(t, 25, jobs[1].CurrentFlakyRunPercentage, 0.001)
+	assert.Zero(t, jobs[1].PreviousFlakyRunPercentage)
+	assert.Zero(t, jobs[2].CurrentFlakyRuns)
+
+	summary := summarizeFlakyRuns(jobs)
+	assert.Equal(t, 20, summary.CurrentRuns)
+	assert.Equal(t, 4, summary.CurrentFlakyRuns)
+	assert.InDelta(t, 20, summary.CurrentFlakyRunPercentage, 0.001)
+	assert.Equal(t, 25, summary.PreviousRuns)
+	assert.InDelta(t, 8, summary.PreviousFlakyRunPercentage, 0.001)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	// DefaultJobGridRuns is how many of a job's latest runs a grid shows by default.
+	DefaultJobGridRuns = 50
+	// MaxJobGridRuns caps how many runs a grid may show.
+	MaxJobGridRuns = 200
+)
+
+// ErrJobNotFound is returned for a job ID that doesn't exist.
+var ErrJobNotFound = errors.New("no job")
+
+// jobGridResult is a test's result in a run of the grid.
+type jobGridResult struct {
+	ProwJobRunID uint
+	Name         string
+	Status       int
+}
+
+// jobGridBugs is how many open bugs mention a test.
+type jobGridBugs struct {
+	Name     string
+	OpenBugs int
+}
+
+// GetJobGridFromDB returns a grid of the results of the tests in the latest runs of the job with id, only those
+// that failed or flaked in at least one of them when failing is true.
+func GetJobGridFromDB(dbc *db.DB, id uint, runs int, failing bool) (apitype.JobGrid, error) {
+	job := models.ProwJob{}
+	if res := dbc.DB.Limit(1).Find(&job, id); res.Error != nil {
+		return apitype.JobGrid{}, res.Error
+	} else if res.RowsAffected == 0 {
+		return apitype.JobGrid{}, fmt.Errorf("%w with id %d", ErrJobNotFound, id)
+	}
+
+	gridRuns := []apitype.JobGridRun{}
+	res := dbc.DB.Table("prow_job_runs").
+		Select("id, url, timestamp, overall_result, probable_cause, known_failure, infrastructure_failure").
+		Where("prow_job_id = ? AND deleted_at IS NULL", id).
+		Order("timestamp DESC").
+		Limit(runs).
+		Scan(&gridRuns)
+	if res.Error != nil {
+		return apitype.JobGrid{}, res.Error
+	}
+
+	results := []jobGridResult{}
+	bugs := []jobGridBugs{}
+	if len(gridRuns) > 0 {
+		runIDs := make([]uint, 0, len(gridRuns))
+		for _, r := range gridRuns {
+			runIDs = append(runIDs, r.ID)
+		}
+		// prow_job_run_tests is partitioned by created_at, which is never before the run started.
+		res = dbc.DB.Table("prow_job_run_tests").
+			Select("prow_job_run_tests.prow_job_run_id, tests.name, prow_job_run_tests.status").
+			Joins("JOIN tests ON tests.id = prow_job_run_tests.test_id").
+			Where("prow_job_run_tests.prow_job_run_id IN ? AND prow_job_run_tests.created_at >= ?",
+				runIDs, gridRuns[len(gridRuns)-1].Timestamp).
+			Where("prow_job_run_tests.deleted_at IS NULL").
+			Scan(&results)
+		if res.Error != nil {
+			return apitype.JobGrid{}, res.Error
+		}
+
+		res = dbc.DB.Table("bug_tests").
+			Select("tests.name, COUNT(DISTINCT bugs.id) AS open_bugs").
+			Joins("JOIN bugs ON bugs.id = bug_tests.bug_id").
+			Joins("JOIN tests ON tests.id = bug_tests.test_id").
+			Where("LOWER(bugs.status) <> 'closed'").
+			Where("bug_tests.test_id IN (?)", dbc.DB.Table("prow_job_run_tests").
+				Select("test_id").
+				Where("prow_job_run_id IN ? AND created_at >= ? AND status IN ?", runIDs,
+					gridRuns[len(gridRuns)-1].Timestamp,
+					[]v1.TestStatus{v1.TestStatusFailure, v1.TestStatusFlake, v1.TestStatusTimeout})).
+			Group("tests.name").
+			Scan(&bugs)
+		if res.Error != nil {
+			return apitype.JobGrid{}, res.Error
+		}
+	}
+
+	return apitype.JobGrid{
+		JobID:   job.ID,
+		JobName: job.Name,
+		Release: job.Release,
+		Runs:    gridRuns,
+		Tests:   jobGridTests(gridRuns, results, bugs, failing),
+	}, nil
+}
+
+// jobGridStatusCodes are the characters of each test status in a grid row.
+var jobGridStatusCodes = map[v1.TestStatus]byte{
+	v1.TestStatusSuccess: 'S',
+	v1.TestStatusFailure: 'F',
+	v1.TestStatusFlake:   'L',
+	v1.TestStatusTimeout: 'T',
+	v1.TestStatusRunning: 'R',
+}
+
+// jobGridStatusRank orders the statuses of a test that reported more than once in a run, the worst of which is
+// shown.
+var jobGridStatusRank = map[byte]int{'-': 0, 'R': 1, 'S': 2, 'L': 3, 'T': 4, 'F': 5}
+
+// jobGridTests builds the rows of a grid, the tests that failed most first and then by name.
+func jobGridTests(runs []apitype.JobGridRun, results []jobGridResult, bugs []jobGridBugs, failing bool) []apitype.JobGridTest {
+	column := make(map[uint]int, len(runs))
+	for i, r := range runs {
+		column[r.ID] = i
+	}
+	openBugs := make(map[string]int, len(bugs))
+	for _, b := range bugs {
+		openBugs[b.Name] = b.OpenBugs
+	}
+
+	rows := map[string][]byte{}
+	for _, r := range results {
+		i, ok := column[r.ProwJobRunID]
+		if !ok {
+			continue
+		}
+		code, ok := jobGridStatusCodes[v1.TestStatus(r.Status)]
+		if !ok {
+			continue
+		}
+		row, ok := rows[r.Name]
+		if !ok {
+			row = []byte(strings.Repeat("-", len(runs)))
+			rows[r.Name] = row
+		}
+		if jobGridStatusRank[code] > jobGridStatusRank[row[i]] {
+			row[i] = code
+		}
+	}
+
+	tests := make([]apitype.JobGridTest, 0, len(rows))
+	for name, row := range rows {
+		t := apitype.JobGridTest{
+			Name:     name,
+			Statuses: string(row),
+			Failures: strings.Count(string(row), "F") + strings.Count(string(row), "T"),
+			Flakes:   strings.Count(string(row), "L"),
+		}
+		if t.Failures+t.Flakes > 0 {
+			t.OpenBugs = openBugs[name]
+		} else if failing {
+			continue
+		}
+		tests = append(tests, t)
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Failures != tests[j].Failures {
+			return tests[i].Failures > tests[j].Failures
+		}
+		if tests[i].Flakes != tests[j].Flakes {
+			return tests[i].Flakes > tests[j].Flakes
+		}
+		return tests[i].Name < tests[j].Name
+	})
+	return tests
+}
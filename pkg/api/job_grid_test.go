@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+)
+
+func TestJobGridTests(t *testing.T) {
+	runs := []apitype.JobGridRun{{ID: 3}, {ID: 2}, {ID: 1}}
+	results := []jobGridResult{
+		{ProwJobRunID: 3, Name: "install", Status: int(v1.TestStatusSuccess)},
+		{ProwJobRunID: 2, Name: "install", Status: int(v1.TestStatusSuccess)},
+		{ProwJobRunID: 1, Name: "install", Status: int(v1.TestStatusSuccess)},
+		{ProwJobRunID: 3, Name: "network", Status: int(v1.TestStatusFailure)},
+		{ProwJobRunID: 1, Name: "network", Status: int(v1.TestStatusFlake)},
+		// a test reporting more than once in a run shows its worst result
+		{ProwJobRunID: 2, Name: "storage", Status: int(v1.TestStatusSuccess)},
+		{ProwJobRunID: 2, Name: "storage", Status: int(v1.TestStatusFlake)},
+		// runs outside the grid are ignored
+		{ProwJobRunID: 9, Name: "network", Status: int(v1.TestStatusFailure)},
+	}
+	bugs := []jobGridBugs{{Name: "network", OpenBugs: 2}, {Name: "install", OpenBugs: 1}}
+
+	assert.Equal(t, []apitype.JobGridTest{
+		{Name: "network", Statuses: "F-L", Failures: 1, Flakes: 1, OpenBugs: 2},
+		{Name: "storage", Statuses: "-L-", Flakes: 1},
+		{Name: "install", Statuses: "SSS"},
+	}, jobGridTests(runs, results, bugs, false))
+
+	failing := jobGridTests(runs, results, bugs, true)
+	assert.Len(t, failing, 2)
+	assert.Equal(t, "network", failing[0].Name)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// GetJobLineageFromDB returns the instances of a job across releases, newest release first.
+func GetJobLineageFromDB(dbc *db.DB, jobName string) ([]apitype.JobLineageEntry, error) {
+	entries, err := query.JobLineage(dbc, jobName)
+	if err != nil {
+		return nil, err
+	}
+	sortJobLineage(entries)
+	return entries, nil
+}
+
+// sortJobLineage orders entries by release version, newest first. Releases that are not versions, such as
+// Presubmits, sort last.
+func sortJobLineage(entries []apitype.JobLineageEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		vi, erri := version.NewVersion(entries[i].Release)
+		vj, errj := version.NewVersion(entries[j].Release)
+		switch {
+		case erri != nil && errj != nil:
+			return entries[i].Release < entries[j].Release
+		case erri != nil:
+			return false
+		case errj != nil:
+			return true
+		}
+		return vi.GreaterThan(vj)
+	})
+}
+
+// SetJobLineageOverride places a job in a lineage, replacing any previous override for the job. The job is
+// moved immediately if it has already been imported.
+func SetJobLineageOverride(dbc *db.DB, override models.JobLineageOverride) (models.JobLineageOverride, error) {
+	override.ID = 0
+	if override.JobName == "" || override.Lineage == "" {
+		return override, fmt.Errorf("job_name and lineage are required")
+	}
+
+	err := dbc.DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"lineage", "reason", "updated_at", "deleted_at"}),
+		}).Create(&override)
+		if res.Error != nil {
+			return res.Error
+		}
+		return tx.Model(&models.ProwJob{}).Where("name = ?", override.JobName).UpdateColumn("lineage", override.Lineage).Error
+	})
+	return override, err
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestSortJobLineage(t *testing.T) {
+	entries := []apitype.JobLineageEntry{
+		{Release: "4.9"},
+		{Release: "4.14"},
+		{Release: "Presubmits"},
+		{Release: "4.15"},
+	}
+	sortJobLineage(entries)
+
+	releases := []string{}
+	for _, e := range entries {
+		releases = append(releases, e.Release)
+	}
+	assert.Equal(t, []string{"4.15", "4.14", "4.9", "Presubmits"}, releases)
+}
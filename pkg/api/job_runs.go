@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/sippy/pkg/db/query"
 	"github.com/openshift/sippy/pkg/filter"
 	"github.com/openshift/sippy/pkg/testidentification"
+	"github.com/openshift/sippy/pkg/util/pagination"
 	"github.com/openshift/sippy/pkg/util/param"
 	log "github.com/sirupsen/logrus"
 )
@@ -62,8 +63,9 @@ func (runs apiRunResults) limit(req *http.Request) apiRunResults {
 
 type apiRunResults []apitype.JobRun
 
-// JobsRunsReportFromDB renders a filtered summary of matching jobs.
-func JobsRunsReportFromDB(dbc *db.DB, filterOpts *filter.FilterOptions, release string, pagination *apitype.Pagination, reportEnd time.Time) (*apitype.PaginationResult, error) {
+// JobsRunsReportFromDB renders a filtered summary of matching jobs, all of them if page is nil. The matching runs
+// are only counted for the first page, later pages report the total carried by their cursor.
+func JobsRunsReportFromDB(dbc *db.DB, filterOpts *filter.FilterOptions, release string, page *pagination.Request, reportEnd time.Time) (*apitype.PaginationResult, error) {
 	jobsResult := make([]apitype.JobRun, 0)
 	table := "prow_job_runs_report_matview"
 	q, err := filter.FilterableDBResult(dbc.DB.Table(table), filterOpts, apitype.JobRun{})
@@ -79,25 +81,60 @@ func JobsRunsReportFromDB(dbc *db.DB, filterOpts *filter.FilterOptions, release
 
 	// Get the row count before pagination
 	var rowCount int64
-	q.Count(&rowCount)
+	if page != nil && page.Total > 0 {
+		rowCount = page.Total
+	} else {
+		q.Count(&rowCount)
+	}
 
 	// Paginate the results:
-	if pagination == nil {
-		pagination = &apitype.Pagination{
-			PerPage: int(rowCount),
-			Page:    0,
-		}
-	} else {
-		q = q.Limit(pagination.PerPage).Offset(pagination.Page * pagination.PerPage)
+	if page != nil {
+		q = q.Limit(page.PageSize).Offset(page.Offset)
 	}
 
 	res := q.Scan(&jobsResult)
-	return &apitype.PaginationResult{
-		Rows:      jobsResult,
-		TotalRows: rowCount,
-		PageSize:  pagination.PerPage,
-		Page:      pagination.Page,
-	}, res.Error
+	if res.Error == nil {
+		if err := addDebugArtifacts(dbc, jobsResult); err != nil {
+			return nil, err
+		}
+	}
+	if page == nil {
+		return &apitype.PaginationResult{
+			Rows:      jobsResult,
+			TotalRows: rowCount,
+			PageSize:  int(rowCount),
+		}, res.Error
+	}
+	result := page.Result(jobsResult, len(jobsResult), rowCount)
+	return &result, res.Error
+}
+
+// addDebugArtifacts adds the links to debugging outputs for the failed runs.
+func addDebugArtifacts(dbc *db.DB, runs []apitype.JobRun) error {
+	byID := map[uint]*apitype.JobRun{}
+	for i := range runs {
+		if !runs[i].Succeeded {
+			byID[uint(runs[i].ID)] = &runs[i]
+		}
+	}
+	if len(byID) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	artifacts := []models.ProwJobRunDebugArtifact{}
+	res := dbc.DB.Where("prow_job_run_id IN ?", ids).Order("id").Find(&artifacts)
+	if res.Error != nil {
+		return res.Error
+	}
+	for _, a := range artifacts {
+		run := byID[a.ProwJobRunID]
+		run.DebugArtifacts = append(run.DebugArtifacts, apitype.DebugArtifact{Kind: a.Kind, URL: a.URL})
+	}
+	return nil
 }
 
 func FetchJobRun(dbc *db.DB, jobRunID int64, logger *log.Entry) (*models.ProwJobRun, int, error) {
@@ -370,8 +407,9 @@ func variantsTestResultFunc(dbc *db.DB) testResultsByVariantsFunc {
 			},
 			LinkOperator: "and",
 		}
+		// risk analysis is of job runs as they're loaded, against current results
 		testResults, overallTest, err := BuildTestsResults(dbc, release, "default", false, true,
-			fil)
+			fil, time.Now())
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+// MaxJobRunsBatchSize is the most job runs returned by a single batch request.
+const MaxJobRunsBatchSize = 500
+
+// ErrInvalidJobRunsBatchFilter is returned when a batch request's filter can't be applied, a client error.
+var ErrInvalidJobRunsBatchFilter = errors.New("invalid filter")
+
+// JobRunsBatchRequest selects job runs for the batch API, either by ID or with a filter over the same fields as
+// the job runs report.
+type JobRunsBatchRequest struct {
+	IDs     []int64        `json:"ids,omitempty"`
+	Release string         `json:"release,omitempty"`
+	Filter  *filter.Filter `json:"filter,omitempty"`
+}
+
+// Validate checks the request selects a bounded set of job runs.
+func (r JobRunsBatchRequest) Validate() error {
+	if len(r.IDs) == 0 && r.Filter == nil {
+		return fmt.Errorf("either ids or filter is required")
+	}
+	if len(r.IDs) > MaxJobRunsBatchSize {
+		return fmt.Errorf("at most %d job runs may be requested at once, got %d", MaxJobRunsBatchSize, len(r.IDs))
+	}
+	return nil
+}
+
+// StreamJobRunsBatchFromDB writes the selected job runs, including their failed and flaked test names, as a JSON
+// object with the runs under job_runs. Only the most recent MaxJobRunsBatchSize runs matching a filter are returned,
+// with truncated set if there were more. Rows are encoded as they are read from the database rather than buffered,
+// so large batches don't need to be held in memory.
+func StreamJobRunsBatchFromDB(w http.ResponseWriter, dbc *db.DB, batch JobRunsBatchRequest, reportEnd time.Time) error {
+	q := dbc.DB.Table("prow_job_runs_report_matview")
+	if batch.Filter != nil {
+		var err error
+		q, err = filter.FilterableDBResult(q, &filter.FilterOptions{
+			Filter:    batch.Filter,
+			SortField: "timestamp",
+			Sort:      apitype.SortDescending,
+		}, apitype.JobRun{})
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidJobRunsBatchFilter, err)
+		}
+	}
+	if len(batch.IDs) > 0 {
+		q = q.Where("id IN ?", batch.IDs)
+	}
+	if batch.Release != "" {
+		q = q.Where("release = ?", batch.Release)
+	}
+	// one more than we return, to tell if the results were truncated
+	q = q.Where("timestamp < ?", reportEnd.UnixMilli()).Limit(MaxJobRunsBatchSize + 1)
+
+	rows, err := q.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	// Once the header is written errors can no longer be reported to the client, so they are only logged
+	// and the response is left truncated.
+	enc := json.NewEncoder(w)
+	fmt.Fprint(w, `{"job_runs":[`)
+	count := 0
+	truncated := false
+	for rows.Next() {
+		if count == MaxJobRunsBatchSize {
+			truncated = true
+			break
+		}
+		run := apitype.JobRun{}
+		if err := dbc.DB.ScanRows(rows, &run); err != nil {
+			log.WithError(err).Error("error scanning job run")
+			return nil
+		}
+		if count > 0 {
+			fmt.Fprint(w, ",")
+		}
+		if err := enc.Encode(run); err != nil {
+			log.WithError(err).Error("error encoding job run")
+			return nil
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error reading job runs")
+		return nil
+	}
+	fmt.Fprintf(w, "],\"truncated\":%t}\n", truncated)
+	return nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+func TestJobRunsBatchRequestValidate(t *testing.T) {
+	tooMany := make([]int64, MaxJobRunsBatchSize+1)
+	tests := []struct {
+		name    string
+		request JobRunsBatchRequest
+		wantErr bool
+	}{
+		{
+			name:    "ids",
+			request: JobRunsBatchRequest{IDs: []int64{1, 2, 3}},
+		},
+		{
+			name:    "filter",
+			request: JobRunsBatchRequest{Filter: &filter.Filter{}},
+		},
+		{
+			name:    "empty",
+			request: JobRunsBatchRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "too many ids",
+			request: JobRunsBatchRequest{IDs: tooMany},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
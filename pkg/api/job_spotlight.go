@@ -0,0 +1,74 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+const (
+	// DefaultSpotlightMinRuns is how many runs a job needs in both periods to be spotlighted, so a couple of
+	// results don't swing its pass rate.
+	DefaultSpotlightMinRuns = 7
+	// DefaultSpotlightLimit is how many jobs are returned in each list.
+	DefaultSpotlightLimit = 10
+)
+
+// GetJobSpotlightFromDB returns the jobs whose pass rate improved, and degraded, the most between the previous
+// period start->boundary and the current period boundary->end, leaving out the variants excluded from reporting.
+func GetJobSpotlightFromDB(dbc *db.DB, release string, minRuns, limit int, start, boundary, end time.Time) (apitype.JobSpotlight, error) {
+	fil := &filter.Filter{LinkOperator: filter.LinkOperatorAnd}
+	for _, variant := range testidentification.DefaultExcludedVariants {
+		fil.Items = append(fil.Items, filter.FilterItem{
+			Field:    "variants",
+			Operator: filter.OperatorContains,
+			Value:    variant,
+			Not:      true,
+		})
+	}
+
+	jobs, err := query.JobReports(dbc, &filter.FilterOptions{Filter: fil}, release, start, boundary, end)
+	if err != nil {
+		return apitype.JobSpotlight{}, err
+	}
+	return spotlightJobs(jobs, minRuns, limit), nil
+}
+
+// spotlightJobs splits the jobs with at least minRuns in both periods into those that improved, most improved
+// first, and those that degraded, most degraded first, keeping up to limit of each.
+func spotlightJobs(jobs []apitype.Job, minRuns, limit int) apitype.JobSpotlight {
+	spotlight := apitype.JobSpotlight{
+		Improved: []apitype.Job{},
+		Degraded: []apitype.Job{},
+	}
+	for _, job := range jobs {
+		if job.CurrentRuns < minRuns || job.PreviousRuns < minRuns {
+			continue
+		}
+		switch {
+		case job.NetImprovement > 0:
+			spotlight.Improved = append(spotlight.Improved, job)
+		case job.NetImprovement < 0:
+			spotlight.Degraded = append(spotlight.Degraded, job)
+		}
+	}
+
+	sort.SliceStable(spotlight.Improved, func(i, j int) bool {
+		return spotlight.Improved[i].NetImprovement > spotlight.Improved[j].NetImprovement
+	})
+	sort.SliceStable(spotlight.Degraded, func(i, j int) bool {
+		return spotlight.Degraded[i].NetImprovement < spotlight.Degraded[j].NetImprovement
+	})
+	if len(spotlight.Improved) > limit {
+		spotlight.Improved = spotlight.Improved[:limit]
+	}
+	if len(spotlight.Degraded) > limit {
+		spotlight.Degraded = spotlight.Degraded[:limit]
+	}
+	return spotlight
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestSpotlightJobs(t *testing.T) {
+	jobs := []apitype.Job{
+		{Name: "slightly-better", CurrentRuns: 10, PreviousRuns: 10, NetImprovement: 5},
+		{Name: "much-worse", CurrentRuns: 10, PreviousRuns: 10, NetImprovement: -40},
+		{Name: "much-better", CurrentRuns: 10, PreviousRuns: 10, NetImprovement: 30},
+		{Name: "unchanged", CurrentRuns: 10, PreviousRuns: 10},
+		{Name: "too-few-current", CurrentRuns: 2, PreviousRuns: 10, NetImprovement: -100},
+		{Name: "too-few-previous", CurrentRuns: 10, PreviousRuns: 1, NetImprovement: 100},
+		{Name: "slightly-worse", CurrentRuns: 10, PreviousRuns: 10, NetImprovement: -5},
+		{Name: "worse", CurrentRuns: 10, PreviousRuns: 10, NetImprovement: -20},
+	}
+
+	names := func(jobs []apitype.Job) []string {
+		result := []string{}
+		for _, j := range jobs {
+			result = append(result, j.Name)
+		}
+		return result
+	}
+
+	spotlight := spotlightJobs(jobs, 7, 2)
+	assert.Equal(t, []string{"much-better", "slightly-better"}, names(spotlight.Improved))
+	assert.Equal(t, []string{"much-worse", "worse"}, names(spotlight.Degraded))
+
+	spotlight = spotlightJobs(jobs, 1, 10)
+	assert.Equal(t, []string{"too-few-previous", "much-better", "slightly-better"}, names(spotlight.Improved))
+	assert.Equal(t, []string{"too-few-current", "much-worse", "worse", "slightly-worse"}, names(spotlight.Degraded))
+
+	spotlight = spotlightJobs(nil, 7, 10)
+	assert.Empty(t, spotlight.Improved)
+	assert.NotNil(t, spotlight.Degraded)
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// GetStepJobsFromDB lists the jobs composed of a step, in release if given, as imported from the step registry.
+func GetStepJobsFromDB(dbc *db.DB, step, release string) ([]apitype.StepJob, error) {
+	jobs := []apitype.StepJob{}
+	q := dbc.DB.Table("job_steps").
+		Select("DISTINCT job_steps.job_name, prow_jobs.release, job_steps.phase").
+		Joins("JOIN prow_jobs ON prow_jobs.name = job_steps.job_name AND prow_jobs.deleted_at IS NULL").
+		Where("job_steps.step = ?", step)
+	if release != "" {
+		q = q.Where("prow_jobs.release = ?", release)
+	}
+	res := q.Order("job_steps.job_name").Scan(&jobs)
+	return jobs, res.Error
+}
+
+// GetJobRunStepsFromGCS reads the results of a job run's steps from its step graph, mapping each failed step to
+// the other jobs of the release that share it.
+func GetJobRunStepsFromGCS(ctx context.Context, gcsClient *storage.Client, dbc *db.DB, jobRunID int64, gcsBucket string,
+	logger *log.Entry) (apitype.JobRunSteps, error) {
+	jobRun, _, err := FetchJobRun(dbc, jobRunID, logger)
+	if err != nil {
+		return apitype.JobRunSteps{}, err
+	}
+	_, path, found := strings.Cut(jobRun.URL, "/"+gcsBucket+"/")
+	if !found {
+		return apitype.JobRunSteps{}, fmt.Errorf("job run %d is not stored in bucket %s", jobRunID, gcsBucket)
+	}
+
+	gcsJobRun := gcs.NewGCSJobRun(gcsClient.Bucket(gcsBucket), path)
+	matches := gcsJobRun.FindAllMatches([]*regexp.Regexp{gcs.GetStepGraphFile()})
+	results := []gcs.StepResult{}
+	if len(matches[0]) > 0 {
+		if results, err = gcsJobRun.GetStepResults(ctx, matches[0][0]); err != nil {
+			return apitype.JobRunSteps{}, err
+		}
+	}
+
+	var jobSteps []models.JobStep
+	if res := dbc.DB.Where("job_name = ?", jobRun.ProwJob.Name).Find(&jobSteps); res.Error != nil {
+		return apitype.JobRunSteps{}, res.Error
+	}
+	var shared []models.JobStep
+	if failed := failedSteps(results); len(failed) > 0 {
+		res := dbc.DB.Table("job_steps").
+			Select("job_steps.job_name, job_steps.step").
+			Joins("JOIN prow_jobs ON prow_jobs.name = job_steps.job_name AND prow_jobs.deleted_at IS NULL").
+			Where("job_steps.step IN ? AND prow_jobs.release = ? AND job_steps.job_name <> ?",
+				failed, jobRun.ProwJob.Release, jobRun.ProwJob.Name).
+			Order("job_steps.job_name").
+			Scan(&shared)
+		if res.Error != nil {
+			return apitype.JobRunSteps{}, res.Error
+		}
+	}
+
+	return apitype.JobRunSteps{
+		JobName: jobRun.ProwJob.Name,
+		RunID:   jobRunID,
+		Steps:   mapJobRunSteps(results, jobSteps, shared),
+	}, nil
+}
+
+func failedSteps(results []gcs.StepResult) []string {
+	var failed []string
+	for _, r := range results {
+		if r.Failed {
+			failed = append(failed, r.Step)
+		}
+	}
+	return failed
+}
+
+// mapJobRunSteps combines the step results of a run with the job's steps in the registry, and lists the other jobs
+// sharing each failed step.
+func mapJobRunSteps(results []gcs.StepResult, jobSteps, shared []models.JobStep) []apitype.JobRunStep {
+	phases := map[string]string{}
+	for _, s := range jobSteps {
+		phases[s.Step] = s.Phase
+	}
+	sharedWith := map[string][]string{}
+	for _, s := range shared {
+		sharedWith[s.Step] = append(sharedWith[s.Step], s.JobName)
+	}
+
+	steps := make([]apitype.JobRunStep, 0, len(results))
+	for _, r := range results {
+		step := apitype.JobRunStep{
+			Test:     r.Test,
+			Step:     r.Step,
+			Phase:    phases[r.Step],
+			Failed:   r.Failed,
+			Duration: r.Duration.Seconds(),
+		}
+		if r.Failed {
+			step.SharedWith = sharedWith[r.Step]
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
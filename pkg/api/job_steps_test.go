@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestMapJobRunSteps(t *testing.T) {
+	results := []gcs.StepResult{
+		{Test: "e2e-aws-ovn", Step: "ipi-install-install", Duration: 40 * time.Minute},
+		{Test: "e2e-aws-ovn", Step: "openshift-e2e-test", Failed: true, Duration: time.Hour},
+		{Test: "e2e-aws-ovn", Step: "gather-extra", Failed: true},
+	}
+	jobSteps := []models.JobStep{
+		{JobName: "periodic-e2e-aws-ovn", Step: "ipi-install-install", Phase: "pre"},
+		{JobName: "periodic-e2e-aws-ovn", Step: "openshift-e2e-test", Phase: "test"},
+	}
+	shared := []models.JobStep{
+		{JobName: "periodic-e2e-aws-ovn-serial", Step: "openshift-e2e-test"},
+		{JobName: "periodic-e2e-gcp-ovn", Step: "openshift-e2e-test"},
+		{JobName: "periodic-e2e-gcp-ovn", Step: "ipi-install-install"},
+	}
+
+	assert.Equal(t, []apitype.JobRunStep{
+		{Test: "e2e-aws-ovn", Step: "ipi-install-install", Phase: "pre", Duration: 2400},
+		{Test: "e2e-aws-ovn", Step: "openshift-e2e-test", Phase: "test", Failed: true, Duration: 3600,
+			SharedWith: []string{"periodic-e2e-aws-ovn-serial", "periodic-e2e-gcp-ovn"}},
+		{Test: "e2e-aws-ovn", Step: "gather-extra", Failed: true},
+	}, mapJobRunSteps(results, jobSteps, shared))
+}
@@ -0,0 +1,122 @@
+package jobrunintervals
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// volatileLocatorKeys differ between runs for the same event, so they're left out when matching intervals.
+var volatileLocatorKeys = map[string]bool{"node": true, "uid": true, "row": true}
+
+// generatedNameSuffix matches the random suffixes of pod names generated by deployments, replica sets and jobs.
+var generatedNameSuffix = regexp.MustCompile(`(-[0-9a-f]{8,10})?-[a-z0-9]{5}$`)
+
+// DiffJobRunIntervals compares the default intervals file of two runs of the same job, returning the intervals
+// only one of them has.
+func DiffJobRunIntervals(gcsClient *storage.Client, dbc *db.DB, baseRunID, runID int64, gcsBucket string,
+	logger *log.Entry) (*apitype.JobRunIntervalsDiff, error) {
+	baseRun, _, err := api.FetchJobRun(dbc, baseRunID, logger)
+	if err != nil {
+		return nil, err
+	}
+	run, _, err := api.FetchJobRun(dbc, runID, logger)
+	if err != nil {
+		return nil, err
+	}
+	if baseRun.ProwJobID != run.ProwJobID {
+		return nil, fmt.Errorf("job runs %d and %d are of different jobs", baseRunID, runID)
+	}
+
+	baseIntervals, err := JobRunIntervals(gcsClient, dbc, baseRunID, gcsBucket, "", "", logger.WithField("jobRunID", baseRunID))
+	if err != nil {
+		return nil, err
+	}
+	intervals, err := JobRunIntervals(gcsClient, dbc, runID, gcsBucket, "", "", logger.WithField("jobRunID", runID))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffIntervals(baseIntervals.Items, intervals.Items)
+	diff.JobName = run.ProwJob.Name
+	diff.BaseRunID = baseRunID
+	diff.RunID = runID
+	return &diff, nil
+}
+
+// diffIntervals matches the intervals of two runs by intervalKey, pairing each interval with one of the same key
+// in the other run. Unpaired intervals are returned aligned on the start of their run, in order.
+func diffIntervals(base, run []apitype.EventInterval) apitype.JobRunIntervalsDiff {
+	baseKeys := map[string]int{}
+	for _, i := range base {
+		baseKeys[intervalKey(i)]++
+	}
+	runKeys := map[string]int{}
+	for _, i := range run {
+		runKeys[intervalKey(i)]++
+	}
+
+	diff := apitype.JobRunIntervalsDiff{}
+	diff.OnlyInRun, diff.Common = unmatched(run, baseKeys)
+	diff.OnlyInBase, _ = unmatched(base, runKeys)
+	return diff
+}
+
+// unmatched returns the intervals without a counterpart left in other, which is consumed as intervals are paired,
+// and how many were paired.
+func unmatched(intervals []apitype.EventInterval, other map[string]int) ([]apitype.AlignedInterval, int) {
+	var start time.Time
+	for _, i := range intervals {
+		if i.From != nil && (start.IsZero() || i.From.Before(start)) {
+			start = *i.From
+		}
+	}
+
+	result := []apitype.AlignedInterval{}
+	matched := 0
+	for _, i := range intervals {
+		key := intervalKey(i)
+		if other[key] > 0 {
+			other[key]--
+			matched++
+			continue
+		}
+		aligned := apitype.AlignedInterval{EventInterval: i}
+		if i.From != nil {
+			aligned.Offset = i.From.Sub(start).Seconds()
+			if i.To != nil {
+				aligned.Duration = i.To.Sub(*i.From).Seconds()
+			}
+		}
+		result = append(result, aligned)
+	}
+	sort.SliceStable(result, func(a, b int) bool { return result[a].Offset < result[b].Offset })
+	return result, matched
+}
+
+// intervalKey identifies what an interval is about, its source, level, reason and locator, ignoring the parts of
+// the locator that differ from run to run such as node names and generated pod name suffixes.
+func intervalKey(i apitype.EventInterval) string {
+	keys := make([]string, 0, len(i.StructuredLocator.Keys))
+	for k, v := range i.StructuredLocator.Keys {
+		if volatileLocatorKeys[k] {
+			continue
+		}
+		if k == "pod" {
+			v = generatedNameSuffix.ReplaceAllString(v, "")
+		}
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	return strings.Join([]string{i.Source, i.Level, i.StructuredLocator.Type, i.StructuredMessage.Reason,
+		strings.Join(keys, ",")}, "|")
+}
@@ -0,0 +1,58 @@
+package jobrunintervals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestDiffIntervals(t *testing.T) {
+	interval := func(start time.Time, offset, duration time.Duration, source, reason string, keys map[string]string) apitype.EventInterval {
+		from, to := start.Add(offset), start.Add(offset+duration)
+		return apitype.EventInterval{
+			Level:             "Error",
+			Source:            source,
+			StructuredLocator: apitype.Locator{Type: "Pod", Keys: keys},
+			StructuredMessage: apitype.Message{Reason: reason},
+			From:              &from,
+			To:                &to,
+		}
+	}
+	baseStart := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	runStart := time.Date(2024, 3, 21, 8, 30, 0, 0, time.UTC)
+
+	base := []apitype.EventInterval{
+		interval(baseStart, 0, time.Minute, "PodLog", "Started",
+			map[string]string{"namespace": "openshift-etcd", "pod": "etcd-guard-7d9f8b6c4d-x2k9p", "node": "ip-10-0-1-2"}),
+		interval(baseStart, 10*time.Minute, time.Minute, "Alert", "Firing",
+			map[string]string{"namespace": "openshift-monitoring", "alertname": "Watchdog"}),
+	}
+	run := []apitype.EventInterval{
+		interval(runStart, 0, time.Minute, "PodLog", "Started",
+			map[string]string{"namespace": "openshift-etcd", "pod": "etcd-guard-5c8b7f9d6e-q7w4z", "node": "ip-10-0-3-4"}),
+		interval(runStart, 20*time.Minute, 5*time.Minute, "Disruption", "DisruptionBegan",
+			map[string]string{"backend-disruption-name": "kube-api-new-connections"}),
+		interval(runStart, 10*time.Minute, 30*time.Second, "Alert", "Firing",
+			map[string]string{"namespace": "openshift-monitoring", "alertname": "Watchdog"}),
+		interval(runStart, 15*time.Minute, 30*time.Second, "Alert", "Firing",
+			map[string]string{"namespace": "openshift-monitoring", "alertname": "Watchdog"}),
+	}
+
+	diff := diffIntervals(base, run)
+	assert.Equal(t, 2, diff.Common, "pods and the first alert match despite different nodes and generated names")
+	assert.Empty(t, diff.OnlyInBase)
+	if assert.Len(t, diff.OnlyInRun, 2) {
+		assert.Equal(t, "Alert", diff.OnlyInRun[0].Source, "the extra firing of the alert is unmatched")
+		assert.Equal(t, 900.0, diff.OnlyInRun[0].Offset)
+		assert.Equal(t, "Disruption", diff.OnlyInRun[1].Source)
+		assert.Equal(t, 1200.0, diff.OnlyInRun[1].Offset)
+		assert.Equal(t, 300.0, diff.OnlyInRun[1].Duration)
+	}
+
+	reversed := diffIntervals(run, base)
+	assert.Len(t, reversed.OnlyInBase, 2)
+	assert.Empty(t, reversed.OnlyInRun)
+}
@@ -89,13 +89,25 @@ func JobRunIntervals(gcsClient *storage.Client, dbc *db.DB, jobRunID int64, gcsB
 		logger.WithError(err).Errorf("error getting content for file: %s", fullGCSIntervalFile)
 		return nil, err
 	}
+	newIntervals, err := parseIntervals(content, baseFile)
+	if err != nil {
+		return nil, err
+	}
+	newIntervals.IntervalFilesAvailable = intervalFilesAvailable
+
+	return &newIntervals, nil
+}
+
+// parseIntervals reads an intervals file in either the current or the legacy schema, noting the file each
+// interval came from.
+func parseIntervals(content []byte, baseFile string) (apitype.EventIntervalList, error) {
 	var newIntervals apitype.EventIntervalList
 	var legacyIntervals apitype.LegacyEventIntervalList
 	if err := json.Unmarshal(content, &newIntervals); err != nil {
 		log.WithError(err).Error("error unmarshaling intervals file, attempting to parse legacy schema instead")
 		if err := json.Unmarshal(content, &legacyIntervals); err != nil {
 			log.WithError(err).Error("error unmarshaling legacy intervals file, giving up")
-			return nil, err
+			return apitype.EventIntervalList{}, err
 		}
 		log.Info("legacy interval files detected, successfully parsed")
 	}
@@ -122,8 +134,5 @@ func JobRunIntervals(gcsClient *storage.Client, dbc *db.DB, jobRunID int64, gcsB
 	for i := range newIntervals.Items {
 		newIntervals.Items[i].Filename = baseFile
 	}
-
-	newIntervals.IntervalFilesAvailable = intervalFilesAvailable
-
-	return &newIntervals, nil
+	return newIntervals, nil
 }
@@ -0,0 +1,130 @@
+package jobrunintervals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/apis/junit"
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+)
+
+// DefaultLivePollInterval is how often the artifacts of an in-progress job run are checked for new data.
+const DefaultLivePollInterval = 30 * time.Second
+
+// TailJobRunIntervals polls the artifacts of an in-progress job run every pollInterval, sending the intervals and
+// test failures that appeared since the previous poll, until the run's finished.json is uploaded or ctx is done.
+// An update is sent on every poll, even if empty, so clients and proxies can tell the stream is alive.
+func TailJobRunIntervals(ctx context.Context, gcsClient *storage.Client, gcsBucket, gcsPath string, pollInterval time.Duration,
+	send func(apitype.LiveJobRunUpdate) error) error {
+	bkt := gcsClient.Bucket(gcsBucket)
+	tail := newLiveTail()
+	logger := log.WithField("gcsPath", gcsPath)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		update := tail.poll(ctx, gcs.NewGCSJobRun(bkt, gcsPath), gcsPath, logger)
+		if err := send(update); err != nil {
+			return err
+		}
+		if update.Finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// liveTail remembers what has already been sent while tailing a job run.
+type liveTail struct {
+	seenIntervals map[string]bool
+	seenFailures  map[string]bool
+}
+
+func newLiveTail() *liveTail {
+	return &liveTail{
+		seenIntervals: map[string]bool{},
+		seenFailures:  map[string]bool{},
+	}
+}
+
+// poll reads the job run's interval and junit files as they are now. finished.json is checked first, so once it
+// is seen the artifacts read after it are complete.
+func (t *liveTail) poll(ctx context.Context, jobRun *gcs.GCSJobRun, gcsPath string, logger log.FieldLogger) apitype.LiveJobRunUpdate {
+	update := apitype.LiveJobRunUpdate{
+		Time:     time.Now(),
+		Finished: jobRun.ContentExists(ctx, fmt.Sprintf("%s/finished.json", gcsPath)),
+	}
+
+	matches := jobRun.FindAllMatches([]*regexp.Regexp{gcs.GetIntervalFile(), gcs.GetDefaultJunitFile()})
+	if len(matches) < 2 {
+		return update
+	}
+	for _, file := range matches[0] {
+		// Files may be read while still being uploaded, those are retried on the next poll.
+		content, err := jobRun.GetContent(ctx, file)
+		if err != nil {
+			logger.WithError(err).Debugf("could not read interval file %s", file)
+			continue
+		}
+		intervals, err := parseIntervals(content, path.Base(file))
+		if err != nil {
+			continue
+		}
+		update.Intervals = append(update.Intervals, t.newIntervals(intervals.Items)...)
+	}
+
+	jobRun.SetGCSJunitPaths(matches[1])
+	suites, err := jobRun.GetCombinedJUnitTestSuites(ctx)
+	if err != nil {
+		logger.WithError(err).Debug("could not read junit files")
+		return update
+	}
+	for _, suite := range suites.Suites {
+		update.FailedTests = append(update.FailedTests, t.newFailures(suite)...)
+	}
+	return update
+}
+
+// newIntervals returns the intervals not sent before. An interval that changed, such as one that has ended since,
+// is sent again.
+func (t *liveTail) newIntervals(intervals []apitype.EventInterval) []apitype.EventInterval {
+	var unseen []apitype.EventInterval
+	for _, interval := range intervals {
+		key, err := json.Marshal(interval)
+		if err != nil || t.seenIntervals[string(key)] {
+			continue
+		}
+		t.seenIntervals[string(key)] = true
+		unseen = append(unseen, interval)
+	}
+	return unseen
+}
+
+// newFailures returns the names of the failed tests in the suite and its children not sent before.
+func (t *liveTail) newFailures(suite *junit.TestSuite) []string {
+	var unseen []string
+	for _, tc := range suite.TestCases {
+		if tc.FailureOutput == nil || t.seenFailures[tc.Name] {
+			continue
+		}
+		t.seenFailures[tc.Name] = true
+		unseen = append(unseen, tc.Name)
+	}
+	for _, child := range suite.Children {
+		unseen = append(unseen, t.newFailures(child)...)
+	}
+	return unseen
+}
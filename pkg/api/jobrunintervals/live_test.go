@@ -0,0 +1,46 @@
+package jobrunintervals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/apis/junit"
+)
+
+func TestLiveTailNewIntervals(t *testing.T) {
+	from := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Minute)
+	disruption := apitype.EventInterval{Level: "Error", Source: "Disruption", From: &from, Filename: "e2e-timelines_spyglass_1.json"}
+	ended := disruption
+	ended.To = &to
+	alert := apitype.EventInterval{Level: "Warning", Source: "Alert", From: &from, To: &to, Filename: "e2e-timelines_spyglass_1.json"}
+
+	tail := newLiveTail()
+	assert.Equal(t, []apitype.EventInterval{disruption, alert}, tail.newIntervals([]apitype.EventInterval{disruption, alert}))
+	assert.Empty(t, tail.newIntervals([]apitype.EventInterval{disruption, alert}))
+	assert.Equal(t, []apitype.EventInterval{ended}, tail.newIntervals([]apitype.EventInterval{ended, alert}))
+}
+
+func TestLiveTailNewFailures(t *testing.T) {
+	failure := &junit.FailureOutput{Message: "failed"}
+	suite := &junit.TestSuite{
+		Name: "openshift-tests",
+		TestCases: []*junit.TestCase{
+			{Name: "passes"},
+			{Name: "fails", FailureOutput: failure},
+		},
+		Children: []*junit.TestSuite{
+			{TestCases: []*junit.TestCase{{Name: "child fails", FailureOutput: failure}}},
+		},
+	}
+
+	tail := newLiveTail()
+	assert.Equal(t, []string{"fails", "child fails"}, tail.newFailures(suite))
+	assert.Empty(t, tail.newFailures(suite))
+
+	suite.TestCases = append(suite.TestCases, &junit.TestCase{Name: "fails later", FailureOutput: failure})
+	assert.Equal(t, []string{"fails later"}, tail.newFailures(suite))
+}
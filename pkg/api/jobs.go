@@ -15,6 +15,8 @@ import (
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/db/query"
 	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/testidentification"
+	"github.com/openshift/sippy/pkg/util/pagination"
 	"github.com/openshift/sippy/pkg/util/param"
 
 	v1sippyprocessing "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
@@ -71,7 +73,7 @@ func PrintVariantReportFromDB(w http.ResponseWriter, req *http.Request,
 	case startParam != "":
 		start, err = time.Parse("2006-01-02", startParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding start param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding start param: %s", err.Error()))
 			return
 		}
 	case req.URL.Query().Get("period") == periodTwoDay:
@@ -89,7 +91,7 @@ func PrintVariantReportFromDB(w http.ResponseWriter, req *http.Request,
 	case boundaryParam != "":
 		boundary, err = time.Parse("2006-01-02", boundaryParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding boundary param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding boundary param: %s", err.Error()))
 			return
 		}
 	case req.URL.Query().Get("period") == periodTwoDay:
@@ -103,7 +105,7 @@ func PrintVariantReportFromDB(w http.ResponseWriter, req *http.Request,
 	if endParam != "" {
 		end, err = time.Parse("2006-01-02", endParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding end param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding end param: %s", err.Error()))
 			return
 		}
 	} else {
@@ -115,7 +117,7 @@ func PrintVariantReportFromDB(w http.ResponseWriter, req *http.Request,
 
 	variantsResult, err := query.VariantReports(dbc, release, start, boundary, end)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building variant report:" + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Error building variant report:"+err.Error())
 		return
 	}
 
@@ -132,7 +134,7 @@ func PrintJobsReportFromDB(w http.ResponseWriter, req *http.Request,
 	if queryFilter != "" {
 		fil = &filter.Filter{}
 		if err := json.Unmarshal([]byte(queryFilter), fil); err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": "Could not marshal query:" + err.Error()})
+			RespondWithError(w, http.StatusBadRequest, "Could not marshal query:"+err.Error())
 			return
 		}
 	}
@@ -148,7 +150,7 @@ func PrintJobsReportFromDB(w http.ResponseWriter, req *http.Request,
 	if startParam != "" {
 		start, err = time.Parse("2006-01-02", startParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding start param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding start param: %s", err.Error()))
 			return
 		}
 	}
@@ -159,7 +161,7 @@ func PrintJobsReportFromDB(w http.ResponseWriter, req *http.Request,
 	if boundaryParam != "" {
 		boundary, err = time.Parse("2006-01-02", boundaryParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding boundary param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding boundary param: %s", err.Error()))
 			return
 		}
 	}
@@ -168,7 +170,7 @@ func PrintJobsReportFromDB(w http.ResponseWriter, req *http.Request,
 	if endParam != "" {
 		end, err = time.Parse("2006-01-02", endParam)
 		if err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": fmt.Sprintf("Error decoding end param: %s", err.Error())})
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Error decoding end param: %s", err.Error()))
 			return
 		}
 	}
@@ -177,16 +179,50 @@ func PrintJobsReportFromDB(w http.ResponseWriter, req *http.Request,
 
 	filterOpts, err := filter.FilterOptionsFromRequest(req, currentPassPercentage, apitype.SortDescending)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building job report:" + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Error building job report:"+err.Error())
+		return
+	}
+
+	page, err := pagination.FromRequest(req)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
 		return
 	}
 
 	jobsResult, err := JobReportsFromDB(dbc, release, req.URL.Query().Get("period"), filterOpts, start, boundary, end, reportEnd)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building job report:" + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Error building job report:"+err.Error())
+		return
+	}
+
+	RespondWithJSON(http.StatusOK, w, pagination.Slice(page, jobsResult))
+}
+
+// PrintChaosJobsReportFromDB reports on the release's fault injection jobs, which are left out of the job health
+// statistics by default. The usual job report filters may narrow the results further.
+func PrintChaosJobsReportFromDB(w http.ResponseWriter, req *http.Request, dbc *db.DB, release string, start, boundary, end time.Time) {
+	filterOpts, err := filter.FilterOptionsFromRequest(req, currentPassPercentage, apitype.SortDescending)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Error building chaos job report:"+err.Error())
+		return
+	}
+	// The chaos variant must apply to every result, which can't be expressed by adding to an "or" filter.
+	if filterOpts.Filter.LinkOperator == filter.LinkOperatorOr && len(filterOpts.Filter.Items) > 0 {
+		RespondWithError(w, http.StatusBadRequest, "chaos job report cannot use an 'or' filter")
 		return
 	}
+	filterOpts.Filter.LinkOperator = filter.LinkOperatorAnd
+	filterOpts.Filter.Items = append(filterOpts.Filter.Items, filter.FilterItem{
+		Field:    "variants",
+		Operator: filter.OperatorContains,
+		Value:    testidentification.ChaosVariant,
+	})
 
+	jobsResult, err := query.JobReports(dbc, filterOpts, release, start, boundary, end)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Error building chaos job report:"+err.Error())
+		return
+	}
 	RespondWithJSON(http.StatusOK, w, jobsResult)
 }
 
@@ -228,6 +264,18 @@ func JobReportsFromDB(dbc *db.DB, release, period string, filterOpts *filter.Fil
 		return nil, err
 	}
 
+	durations, err := query.JobDurationStats(dbc, release, start, boundary, end)
+	if err != nil {
+		return nil, err
+	}
+	addJobDurations(jobsResult, durations)
+
+	flakyRuns, err := query.JobFlakyRuns(dbc, release, start, boundary, end)
+	if err != nil {
+		return nil, err
+	}
+	addJobFlakyRuns(jobsResult, flakyRuns)
+
 	return jobsResult, nil
 }
 
@@ -296,6 +344,7 @@ func PrintJobDetailsReportFromDB(w http.ResponseWriter, req *http.Request, dbc *
 			Failed:                pjr.Failed,
 			InfrastructureFailure: pjr.InfrastructureFailure,
 			KnownFailure:          pjr.KnownFailure,
+			ProbableCause:         pjr.ProbableCause,
 			Succeeded:             pjr.Succeeded,
 			Timestamp:             int(pjr.Timestamp.Unix() * 1000),
 			OverallResult:         pjr.OverallResult,
@@ -0,0 +1,70 @@
+package api
+
+import (
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// GetPayloadChangesFromDB reports what changed in a payload since the last accepted payload in its stream, and
+// which of the payloads in between first picked up each pull request.
+func GetPayloadChangesFromDB(dbc *db.DB, payloadTag string) (apitype.PayloadChanges, error) {
+	tag, err := query.GetPayloadWithChanges(dbc.DB, payloadTag)
+	if err != nil {
+		return apitype.PayloadChanges{}, err
+	}
+	intermediate, err := query.GetPayloadsSincePreviousAccepted(dbc.DB, tag)
+	if err != nil {
+		return apitype.PayloadChanges{}, err
+	}
+	return buildPayloadChanges(tag, intermediate), nil
+}
+
+// buildPayloadChanges assembles the changes for tag. Every changelog in a stream is computed against the last
+// accepted payload, so tag's changelog already holds everything since then; the intermediate payloads, oldest
+// first, are only used to attribute each pull request to the payload it first landed in.
+func buildPayloadChanges(tag *models.ReleaseTag, intermediate []models.ReleaseTag) apitype.PayloadChanges {
+	changes := apitype.PayloadChanges{
+		ReleaseTag:           tag.ReleaseTag,
+		Phase:                tag.Phase,
+		LastAcceptedTag:      tag.PreviousReleaseTag,
+		IntermediatePayloads: []string{},
+		Components:           []models.ReleaseComponent{},
+		Images:               tag.Repositories,
+		PullRequests:         []apitype.PayloadPullRequest{},
+	}
+	if changes.Images == nil {
+		changes.Images = []models.ReleaseRepository{}
+	}
+
+	for _, c := range tag.Components {
+		if c.PreviousVersion != "" && c.PreviousVersion != c.Version {
+			changes.Components = append(changes.Components, c)
+		}
+	}
+
+	type prKey struct{ name, url string }
+	firstSeen := map[prKey]string{}
+	for _, p := range intermediate {
+		changes.IntermediatePayloads = append(changes.IntermediatePayloads, p.ReleaseTag)
+		for _, pr := range p.PullRequests {
+			key := prKey{pr.Name, pr.URL}
+			if _, ok := firstSeen[key]; !ok {
+				firstSeen[key] = p.ReleaseTag
+			}
+		}
+	}
+
+	for _, pr := range tag.PullRequests {
+		first, ok := firstSeen[prKey{pr.Name, pr.URL}]
+		if !ok {
+			first = tag.ReleaseTag
+		}
+		changes.PullRequests = append(changes.PullRequests, apitype.PayloadPullRequest{
+			ReleasePullRequest: pr,
+			FirstReleaseTag:    first,
+		})
+	}
+	return changes
+}
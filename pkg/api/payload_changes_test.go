@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestBuildPayloadChanges(t *testing.T) {
+	prA := models.ReleasePullRequest{Name: "machine-config-operator", URL: "https://github.com/openshift/machine-config-operator/pull/1"}
+	prB := models.ReleasePullRequest{Name: "installer", URL: "https://github.com/openshift/installer/pull/2"}
+	prC := models.ReleasePullRequest{Name: "installer", URL: "https://github.com/openshift/installer/pull/3"}
+
+	tag := &models.ReleaseTag{
+		ReleaseTag:         "4.16.0-0.nightly-2024-03-03-000000",
+		Phase:              "Rejected",
+		PreviousReleaseTag: "4.16.0-0.nightly-2024-03-01-000000",
+		Components: []models.ReleaseComponent{
+			{Name: "Kubernetes", Version: "1.29.2", PreviousVersion: "1.29.1"},
+			{Name: "Red Hat Enterprise Linux CoreOS", Version: "416.94.1"},
+		},
+		PullRequests: []models.ReleasePullRequest{prA, prB, prC},
+	}
+	intermediate := []models.ReleaseTag{
+		{ReleaseTag: "4.16.0-0.nightly-2024-03-02-000000", PullRequests: []models.ReleasePullRequest{prA}},
+		{ReleaseTag: "4.16.0-0.nightly-2024-03-02-120000", PullRequests: []models.ReleasePullRequest{prA, prB}},
+	}
+
+	changes := buildPayloadChanges(tag, intermediate)
+	assert.Equal(t, "4.16.0-0.nightly-2024-03-01-000000", changes.LastAcceptedTag)
+	assert.Equal(t, []string{"4.16.0-0.nightly-2024-03-02-000000", "4.16.0-0.nightly-2024-03-02-120000"}, changes.IntermediatePayloads)
+	assert.Len(t, changes.Components, 1)
+	assert.Equal(t, "Kubernetes", changes.Components[0].Name)
+	assert.NotNil(t, changes.Images)
+
+	firstSeen := map[string]string{}
+	for _, pr := range changes.PullRequests {
+		firstSeen[pr.URL] = pr.FirstReleaseTag
+	}
+	assert.Equal(t, map[string]string{
+		prA.URL: "4.16.0-0.nightly-2024-03-02-000000",
+		prB.URL: "4.16.0-0.nightly-2024-03-02-120000",
+		prC.URL: "4.16.0-0.nightly-2024-03-03-000000",
+	}, firstSeen)
+}
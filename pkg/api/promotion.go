@@ -0,0 +1,168 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	PromotionAccept = "accept"
+	PromotionReject = "reject"
+
+	// DefaultPromotionMinPassPercentage is how often a blocking job must have passed recently for the stream to be
+	// considered healthy.
+	DefaultPromotionMinPassPercentage = 80.0
+
+	// promotionStatsWindow is how far back blocking job results are considered.
+	promotionStatsWindow = 7 * 24 * time.Hour
+	// promotionMinJobRuns is how many runs a blocking job needs before its pass percentage is held against a payload.
+	promotionMinJobRuns = 3
+	// promotionDisruptionMinDays is how many consecutive days disruption must be regressed to block a payload.
+	promotionDisruptionMinDays = 3
+)
+
+// GetPromotionRecommendation recommends whether to accept the latest payload of a stream, based on the payload's
+// blocking jobs, how those jobs did over the last week, and the release's open regressions. Component readiness
+// regressions are passed in, as they're tracked in BigQuery.
+func GetPromotionRecommendation(dbc *db.DB, release, stream, arch string, minPassPercentage float64,
+	regressions []*crtype.TestRegression, reportEnd time.Time) (*apitype.PromotionRecommendation, error) {
+	tag, err := query.GetLatestPayloadTag(dbc.DB, release, stream, arch, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, nil
+	}
+
+	payloadRuns := make([]models.ReleaseJobRun, 0)
+	res := dbc.DB.Joins("JOIN release_tags ON release_tags.id = release_job_runs.release_tag_id").
+		Where("release_tags.release_tag = ? AND release_job_runs.kind = ?", tag.ReleaseTag, "Blocking").
+		Find(&payloadRuns)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	jobCounts, err := query.GetBlockingJobRunCountsByJob(dbc.DB, release, stream, arch, reportEnd.Add(-promotionStatsWindow), reportEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	disruptions, err := GetDisruptionRegressionsFromDB(dbc, release, promotionDisruptionMinDays)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendation := buildPromotionRecommendation(*tag, payloadRuns, jobCounts, regressions, disruptions, minPassPercentage)
+	return &recommendation, nil
+}
+
+func buildPromotionRecommendation(tag models.ReleaseTag, payloadRuns []models.ReleaseJobRun, jobCounts []query.BlockingJobRunCounts,
+	regressions []*crtype.TestRegression, disruptions []models.DisruptionRegressionState, minPassPercentage float64) apitype.PromotionRecommendation {
+	rec := apitype.PromotionRecommendation{
+		ReleaseTag:   tag.ReleaseTag,
+		Release:      tag.Release,
+		Stream:       tag.Stream,
+		Architecture: tag.Architecture,
+		Phase:        tag.Phase,
+		ReleaseTime:  tag.ReleaseTime,
+		Reasons:      []apitype.PromotionReason{},
+		BlockingJobs: []apitype.BlockingJobStats{},
+	}
+
+	jobs := map[string]*apitype.BlockingJobStats{}
+	for _, c := range jobCounts {
+		jobs[c.JobName] = &apitype.BlockingJobStats{
+			JobName:        c.JobName,
+			Runs:           c.Runs,
+			Succeeded:      c.Succeeded,
+			PassPercentage: passPercentage(c.Succeeded, c.Runs),
+		}
+	}
+	for _, run := range payloadRuns {
+		job, ok := jobs[run.JobName]
+		if !ok {
+			job = &apitype.BlockingJobStats{JobName: run.JobName}
+			jobs[run.JobName] = job
+		}
+		job.PayloadState = run.State
+		job.PayloadURL = run.URL
+	}
+	for _, job := range jobs {
+		rec.BlockingJobs = append(rec.BlockingJobs, *job)
+	}
+	sort.Slice(rec.BlockingJobs, func(i, j int) bool {
+		return rec.BlockingJobs[i].JobName < rec.BlockingJobs[j].JobName
+	})
+
+	for _, job := range rec.BlockingJobs {
+		if job.PayloadState == "Failed" {
+			rec.Reasons = append(rec.Reasons, apitype.PromotionReason{
+				Kind:    "blocking_job_failed",
+				Message: fmt.Sprintf("blocking job %s failed for this payload", job.JobName),
+				URL:     job.PayloadURL,
+			})
+		}
+		if job.Runs >= promotionMinJobRuns && job.PassPercentage < minPassPercentage {
+			rec.Reasons = append(rec.Reasons, apitype.PromotionReason{
+				Kind: "blocking_job_below_threshold",
+				Message: fmt.Sprintf("blocking job %s passed %.0f%% of %d runs in the last week, below the %.0f%% threshold",
+					job.JobName, job.PassPercentage, job.Runs, minPassPercentage),
+			})
+		}
+	}
+
+	for _, r := range regressions {
+		if r.Release != tag.Release || r.Closed.Valid || !regressionAffectsArchitecture(r, tag.Architecture) {
+			continue
+		}
+		rec.Reasons = append(rec.Reasons, apitype.PromotionReason{
+			Kind: "regression",
+			Message: fmt.Sprintf("regression in %s (%s) open since %s", r.TestName, regressionVariants(r),
+				r.Opened.Format("2006-01-02")),
+		})
+	}
+
+	for _, d := range disruptions {
+		if d.Release != tag.Release || (d.Architecture != "" && d.Architecture != tag.Architecture) {
+			continue
+		}
+		rec.Reasons = append(rec.Reasons, apitype.PromotionReason{
+			Kind: "disruption_regression",
+			Message: fmt.Sprintf("%s disruption regressed by %.1fs P95 for %d days on %s %s %s",
+				d.BackendName, d.P95Delta, d.ConsecutiveBadDays, d.Platform, d.Network, d.UpgradeType),
+		})
+	}
+
+	rec.Recommendation = PromotionAccept
+	if len(rec.Reasons) > 0 {
+		rec.Recommendation = PromotionReject
+	}
+	return rec
+}
+
+// regressionAffectsArchitecture is true unless the regression is limited to another architecture.
+func regressionAffectsArchitecture(r *crtype.TestRegression, arch string) bool {
+	for _, v := range r.Variants {
+		if v.Key == "Architecture" {
+			return v.Value == arch
+		}
+	}
+	return true
+}
+
+func regressionVariants(r *crtype.TestRegression) string {
+	variants := make([]string, 0, len(r.Variants))
+	for _, v := range r.Variants {
+		variants = append(variants, v.Key+":"+v.Value)
+	}
+	sort.Strings(variants)
+	return strings.Join(variants, " ")
+}
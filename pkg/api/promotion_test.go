@@ -0,0 +1,83 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+func TestBuildPromotionRecommendation(t *testing.T) {
+	releaseTime := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	tag := models.ReleaseTag{
+		ReleaseTag:   "4.16.0-0.nightly-2024-03-20-000000",
+		Release:      "4.16",
+		Stream:       "nightly",
+		Architecture: "amd64",
+		Phase:        "Ready",
+		ReleaseTime:  releaseTime,
+	}
+	healthyJobs := []query.BlockingJobRunCounts{
+		{JobName: "e2e-aws", Runs: 10, Succeeded: 9},
+		{JobName: "e2e-gcp", Runs: 2, Succeeded: 0},
+	}
+
+	tests := []struct {
+		name        string
+		payloadRuns []models.ReleaseJobRun
+		jobCounts   []query.BlockingJobRunCounts
+		regressions []*crtype.TestRegression
+		disruptions []models.DisruptionRegressionState
+		wantRec     string
+		wantReasons []apitype.PromotionReason
+	}{
+		{
+			name:        "healthy payload is accepted",
+			payloadRuns: []models.ReleaseJobRun{{JobName: "e2e-aws", State: "Succeeded"}},
+			jobCounts:   healthyJobs,
+			regressions: []*crtype.TestRegression{
+				{Release: "4.15", TestName: "old release"},
+				{Release: "4.16", TestName: "closed", Closed: bigquery.NullTimestamp{Timestamp: releaseTime, Valid: true}},
+				{Release: "4.16", TestName: "other arch", Variants: []crtype.Variant{{Key: "Architecture", Value: "arm64"}}},
+			},
+			disruptions: []models.DisruptionRegressionState{{Release: "4.16", Architecture: "arm64", BackendName: "kube-api"}},
+			wantRec:     PromotionAccept,
+			wantReasons: []apitype.PromotionReason{},
+		},
+		{
+			name:        "failed blocking jobs and open regressions reject",
+			payloadRuns: []models.ReleaseJobRun{{JobName: "e2e-aws", State: "Failed", URL: "https://prow/1"}},
+			jobCounts: []query.BlockingJobRunCounts{
+				{JobName: "e2e-aws", Runs: 10, Succeeded: 5},
+			},
+			regressions: []*crtype.TestRegression{
+				{Release: "4.16", TestName: "test", Opened: releaseTime.Add(-48 * time.Hour),
+					Variants: []crtype.Variant{{Key: "Platform", Value: "aws"}, {Key: "Architecture", Value: "amd64"}}},
+			},
+			disruptions: []models.DisruptionRegressionState{
+				{Release: "4.16", BackendName: "kube-api", P95Delta: 2.5, ConsecutiveBadDays: 4, Platform: "aws", Network: "ovn", UpgradeType: "minor"},
+			},
+			wantRec: PromotionReject,
+			wantReasons: []apitype.PromotionReason{
+				{Kind: "blocking_job_failed", Message: "blocking job e2e-aws failed for this payload", URL: "https://prow/1"},
+				{Kind: "blocking_job_below_threshold", Message: "blocking job e2e-aws passed 50% of 10 runs in the last week, below the 80% threshold"},
+				{Kind: "regression", Message: "regression in test (Architecture:amd64 Platform:aws) open since 2024-03-18"},
+				{Kind: "disruption_regression", Message: "kube-api disruption regressed by 2.5s P95 for 4 days on aws ovn minor"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := buildPromotionRecommendation(tag, tt.payloadRuns, tt.jobCounts, tt.regressions, tt.disruptions, DefaultPromotionMinPassPercentage)
+			assert.Equal(t, tt.wantRec, rec.Recommendation)
+			assert.Equal(t, tt.wantReasons, rec.Reasons)
+			assert.Equal(t, tag.ReleaseTag, rec.ReleaseTag)
+		})
+	}
+}
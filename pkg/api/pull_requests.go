@@ -1,6 +1,9 @@
 package api
 
 import (
+	"sort"
+	"time"
+
 	apitype "github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
@@ -15,3 +18,67 @@ func GetPullRequestsReportFromDB(dbc *db.DB, release string, filterOpts *filter.
 func GetPayloadDiffPullRequests(dbc *db.DB, fromPayload, toPayload string) ([]models.ReleasePullRequest, error) {
 	return query.GetPayloadDiff(dbc.DB, fromPayload, toPayload)
 }
+
+// PullRequestFailureRateWeeks is how many weeks of presubmit history the failure rate report covers.
+const PullRequestFailureRateWeeks = 8
+
+// GetPullRequestFailureRatesFromDB reports weekly presubmit failure rates for pull requests by repository, or by
+// author and repository if byAuthor is set, alongside the release-wide failure rate for the same week.
+func GetPullRequestFailureRatesFromDB(dbc *db.DB, release string, byAuthor bool, org, repo string, reportEnd time.Time) ([]apitype.PullRequestFailureRate, error) {
+	start := reportEnd.Add(-PullRequestFailureRateWeeks * 7 * 24 * time.Hour)
+	rates, err := query.PullRequestFailureRates(dbc, release, true, byAuthor, org, repo, start, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	baselines, err := query.PullRequestFailureRates(dbc, release, false, false, "", "", start, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	applyPullRequestFailureBaselines(rates, baselines)
+	return rates, nil
+}
+
+func applyPullRequestFailureBaselines(rates, baselines []apitype.PullRequestFailureRate) {
+	baselineByWeek := map[time.Time]float64{}
+	for _, b := range baselines {
+		baselineByWeek[b.Week.UTC()] = percentOf(b.Failures, b.Runs)
+	}
+	for i := range rates {
+		rates[i].FailurePercentage = percentOf(rates[i].Failures, rates[i].Runs)
+		rates[i].BaselineFailurePercentage = baselineByWeek[rates[i].Week.UTC()]
+		rates[i].FailurePercentageDelta = rates[i].FailurePercentage - rates[i].BaselineFailurePercentage
+	}
+}
+
+// PullRequestRetestWeeks is how many weeks of presubmit history the retest report covers.
+const PullRequestRetestWeeks = 4
+
+// GetJobRetestStatsFromDB reports how often each presubmit job in a release is rerun on the same pull request commit,
+// and how often those reruns pass, optionally only for pull requests to one org or repo.
+func GetJobRetestStatsFromDB(dbc *db.DB, release, org, repo string, reportEnd time.Time) ([]apitype.JobRetestStats, error) {
+	start := reportEnd.Add(-PullRequestRetestWeeks * 7 * 24 * time.Hour)
+	stats, err := query.JobRetestStats(dbc, release, org, repo, start, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	summarizeJobRetests(stats)
+	return stats, nil
+}
+
+// summarizeJobRetests sets the retest percentages, and orders the jobs by how many failures a retest passed, so jobs
+// whose flakiness is hidden by retesting come first.
+func summarizeJobRetests(stats []apitype.JobRetestStats) {
+	for i := range stats {
+		stats[i].RetestPercentage = percentOf(stats[i].Retests, stats[i].Runs)
+		stats[i].RetestPassPercentage = percentOf(stats[i].RetestPasses, stats[i].RetestsAfterFailure)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].RetestPasses != stats[j].RetestPasses {
+			return stats[i].RetestPasses > stats[j].RetestPasses
+		}
+		if stats[i].RetriedFailureHours != stats[j].RetriedFailureHours {
+			return stats[i].RetriedFailureHours > stats[j].RetriedFailureHours
+		}
+		return stats[i].Job < stats[j].Job
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestApplyPullRequestFailureBaselines(t *testing.T) {
+	week1 := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	week2 := week1.Add(7 * 24 * time.Hour)
+	baselines := []apitype.PullRequestFailureRate{
+		{Week: week1, Runs: 100, Failures: 20},
+		{Week: week2, Runs: 50, Failures: 5},
+	}
+
+	tests := []struct {
+		name         string
+		rate         apitype.PullRequestFailureRate
+		wantPercent  float64
+		wantBaseline float64
+		wantDelta    float64
+	}{
+		{
+			name:         "flakier than baseline",
+			rate:         apitype.PullRequestFailureRate{Repo: "installer", Week: week1, Runs: 10, Failures: 5},
+			wantPercent:  50,
+			wantBaseline: 20,
+			wantDelta:    30,
+		},
+		{
+			name:         "better than baseline",
+			rate:         apitype.PullRequestFailureRate{Repo: "origin", Week: week2, Runs: 20, Failures: 0},
+			wantPercent:  0,
+			wantBaseline: 10,
+			wantDelta:    -10,
+		},
+		{
+			name:         "no baseline for week",
+			rate:         apitype.PullRequestFailureRate{Repo: "origin", Week: week2.Add(7 * 24 * time.Hour), Runs: 4, Failures: 1},
+			wantPercent:  25,
+			wantBaseline: 0,
+			wantDelta:    25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates := []apitype.PullRequestFailureRate{tt.rate}
+			applyPullRequestFailureBaselines(rates, baselines)
+			assert.InDelta(t, tt.wantPercent, rates[0].FailurePercentage, 0.001)
+			assert.InDelta(t, tt.wantBaseline, rates[0].BaselineFailurePercentage, 0.001)
+			assert.InDelta(t, tt.wantDelta, rates[0].FailurePercentageDelta, 0.001)
+		})
+	}
+}
+
+func TestSummarizeJobRetests(t *testing.T) {
+	stats := []apitype.JobRetestStats{
+		{Job: "pull-ci-unit", Runs: 10, Retests: 1, RetestsAfterFailure: 1, RetestPasses: 1, RetriedFailureHours: 0.5},
+		{Job: "pull-ci-e2e-aws", Runs: 20, Retests: 10, RetestsAfterFailure: 8, RetestPasses: 6, RetriedFailureHours: 12},
+		{Job: "pull-ci-images", Runs: 10, Retests: 2, RetestsAfterFailure: 1, RetestPasses: 1, RetriedFailureHours: 2},
+		{Job: "pull-ci-lint", Runs: 5},
+	}
+	summarizeJobRetests(stats)
+
+	jobs := make([]string, 0, len(stats))
+	for _, s := range stats {
+		jobs = append(jobs, s.Job)
+	}
+	assert.Equal(t, []string{"pull-ci-e2e-aws", "pull-ci-images", "pull-ci-unit", "pull-ci-lint"}, jobs)
+	assert.Equal(t, 50.0, stats[0].RetestPercentage)
+	assert.Equal(t, 75.0, stats[0].RetestPassPercentage)
+	assert.Equal(t, 0.0, stats[3].RetestPassPercentage, "jobs that were never retested should not divide by zero")
+}
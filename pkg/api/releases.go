@@ -34,15 +34,12 @@ func PrintPullRequestsReport(w http.ResponseWriter, req *http.Request, dbClient
 	q = q.Joins(`INNER JOIN release_tag_pull_requests ON release_tag_pull_requests.release_pull_request_id = release_pull_requests.id JOIN release_tags on release_tags.id = release_tag_pull_requests.release_tag_id`)
 	filterOpts, err := filter.FilterOptionsFromRequest(req, "id", apitype.SortDescending)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	q, err = filter.FilterableDBResult(q, filterOpts, nil)
 	if err != nil {
-		RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{
-			"code":    http.StatusBadRequest,
-			"message": err.Error(),
-		})
+		RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -321,15 +318,12 @@ func PrintReleasesReport(w http.ResponseWriter, req *http.Request, dbClient *db.
 
 	filterOpts, err := filter.FilterOptionsFromRequest(req, "release_tag", apitype.SortDescending)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building job run report:" + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Error building job run report:"+err.Error())
 		return
 	}
 	q, err := filter.FilterableDBResult(releaseFilter(req, dbClient.DB), filterOpts, nil)
 	if err != nil {
-		RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{
-			"code":    http.StatusBadRequest,
-			"message": err.Error(),
-		})
+		RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -436,15 +430,101 @@ func dbPayloadPhaseCountToAPI(dbpc []models.PayloadPhaseCount) apitype.PayloadPh
 	return apipc
 }
 
-// ScanForReleaseWarnings looks for problems in current release health and returns them to the user.
-func ScanForReleaseWarnings(dbClient *db.DB, release string, reportEnd time.Time) []string {
+// DefaultPromotionWarningThreshold is how long a payload stream can go without an accepted payload before we warn
+// about it, unless the server is configured with another threshold.
+const DefaultPromotionWarningThreshold = 72 * time.Hour
+
+// streamKey is the key used for a payload stream in maps returned to the UI, e.g. nightly-amd64.
+func streamKey(stream, architecture string) string {
+	return fmt.Sprintf("%s-%s", stream, architecture)
+}
+
+// GetReleasePromotions returns the last accepted payload for each stream and architecture in the release,
+// along with how long it has been since the stream promoted. Streams that have not promoted for longer than
+// warningThreshold are stale.
+func GetReleasePromotions(dbClient *db.DB, release string, warningThreshold time.Duration, reportEnd time.Time) ([]apitype.ReleasePromotion, error) {
+	promotions := make([]apitype.ReleasePromotion, 0)
+	if dbClient == nil || dbClient.DB == nil {
+		return promotions, fmt.Errorf("no db client configured")
+	}
+
+	lastAccepted, err := query.GetLastAcceptedByArchitectureAndStream(dbClient.DB, release, reportEnd)
+	if err != nil {
+		return promotions, err
+	}
+
+	for _, tag := range lastAccepted {
+		stats, err := query.GetPayloadAcceptanceStatistics(dbClient.DB, release, tag.Architecture, tag.Stream, nil, reportEnd)
+		if err != nil {
+			return promotions, errors.Wrapf(err, "error finding %s payload acceptance statistics for %s %s",
+				release, tag.Architecture, tag.Stream)
+		}
+		promotions = append(promotions, buildReleasePromotion(tag, stats, warningThreshold, reportEnd))
+	}
+
+	sort.Slice(promotions, func(i, j int) bool {
+		return streamKey(promotions[i].Stream, promotions[i].Architecture) < streamKey(promotions[j].Stream, promotions[j].Architecture)
+	})
+
+	return promotions, nil
+}
+
+func buildReleasePromotion(tag models.ReleaseTag, stats models.PayloadStatistics, warningThreshold time.Duration, reportEnd time.Time) apitype.ReleasePromotion {
+	sincePromotion := reportEnd.Sub(tag.ReleaseTime)
+	return apitype.ReleasePromotion{
+		Release:                   tag.Release,
+		Stream:                    tag.Stream,
+		Architecture:              tag.Architecture,
+		LastAcceptedTag:           tag.ReleaseTag,
+		LastAcceptedTime:          tag.ReleaseTime,
+		HoursSincePromotion:       math.Round(sincePromotion.Hours()*10) / 10,
+		MaxHoursBetweenPromotions: math.Round(float64(stats.MaxSecondsBetween)/360) / 10,
+		Stale:                     sincePromotion > warningThreshold,
+	}
+}
+
+// GetReleasePromotionTimes returns a map of payload stream to the time of its last accepted payload,
+// suitable for the promotions section of the health report.
+func GetReleasePromotionTimes(dbClient *db.DB, release string, reportEnd time.Time) (map[string]time.Time, error) {
+	promotionTimes := make(map[string]time.Time)
+	lastAccepted, err := query.GetLastAcceptedByArchitectureAndStream(dbClient.DB, release, reportEnd)
+	if err != nil {
+		return promotionTimes, err
+	}
+	for _, tag := range lastAccepted {
+		promotionTimes[streamKey(tag.Stream, tag.Architecture)] = tag.ReleaseTime
+	}
+	return promotionTimes, nil
+}
+
+// ScanForReleaseWarnings looks for problems in current release health and returns them to the user, including
+// payload streams that have not promoted for longer than promotionWarningThreshold.
+func ScanForReleaseWarnings(dbClient *db.DB, release string, promotionWarningThreshold time.Duration, reportEnd time.Time) []string {
 	payloadHealth, err := ReleaseHealthReports(dbClient, release, reportEnd)
 	if err != nil {
 		// treat the error as a warning itself
 		return []string{fmt.Sprintf("error checking release health, see logs: %v", err)}
 	}
-	// May add more release health checks in future
-	return ScanReleaseHealthForRHCOSVersionMisMatches(payloadHealth)
+	warnings := ScanReleaseHealthForRHCOSVersionMisMatches(payloadHealth)
+	return append(warnings, ScanReleaseHealthForPromotionGaps(payloadHealth, reportEnd, promotionWarningThreshold)...)
+}
+
+// ScanReleaseHealthForPromotionGaps warns about any payload stream whose last accepted payload is older than
+// the given threshold.
+func ScanReleaseHealthForPromotionGaps(payloadHealth []apitype.ReleaseHealthReport, reportEnd time.Time, threshold time.Duration) []string {
+	warnings := make([]string, 0)
+	for _, streamHealth := range payloadHealth {
+		if streamHealth.ReleaseTime.IsZero() {
+			continue
+		}
+		sincePromotion := reportEnd.Sub(streamHealth.ReleaseTime)
+		if sincePromotion > threshold {
+			warnings = append(warnings, fmt.Sprintf("%s %s %s has not promoted in %d hours, last accepted payload was %s",
+				streamHealth.Release, streamHealth.Stream, streamHealth.Architecture,
+				int(sincePromotion.Hours()), streamHealth.ReleaseTag.ReleaseTag))
+		}
+	}
+	return warnings
 }
 
 func ScanReleaseHealthForRHCOSVersionMisMatches(payloadHealth []apitype.ReleaseHealthReport) []string {
@@ -487,7 +567,7 @@ func GetReleasesFromBigQuery(ctx context.Context, client *bqcachedclient.Client)
 	queryString := "SELECT * FROM openshift-ci-data-analysis.ci_data.Releases ORDER BY DevelStartDate DESC"
 
 	q := client.BQ.Query(queryString)
-	it, err := q.Read(ctx)
+	it, err := client.Read(ctx, q, "releases")
 	if err != nil {
 		log.WithError(err).Error("error querying releases data from bigquery")
 		return releases, err
@@ -512,3 +592,32 @@ func GetReleasesFromBigQuery(ctx context.Context, client *bqcachedclient.Client)
 	}
 	return releases, nil
 }
+
+// ErrPayloadNotFound is returned for a payload that hasn't been loaded from the release controller.
+var ErrPayloadNotFound = errors.New("payload not found")
+
+// GetPayloadRejection explains a payload's rejection with the blocking jobs the release controller recorded as
+// failed, and the tests that failed in their runs.
+func GetPayloadRejection(dbc *db.DB, payloadTag string) (apitype.PayloadRejection, error) {
+	payload := models.ReleaseTag{}
+	res := dbc.DB.Where("release_tag = ?", payloadTag).Limit(1).Find(&payload)
+	if res.Error != nil {
+		return apitype.PayloadRejection{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apitype.PayloadRejection{}, errors.Wrap(ErrPayloadNotFound, payloadTag)
+	}
+
+	failedJobs, err := query.GetPayloadRejectedJobs(dbc.DB, payloadTag)
+	if err != nil {
+		return apitype.PayloadRejection{}, err
+	}
+	return apitype.PayloadRejection{
+		ReleaseTag:    payload.ReleaseTag,
+		Phase:         payload.Phase,
+		Forced:        payload.Forced,
+		RejectReasons: append([]string{}, payload.RejectReasons...),
+		RejectedBy:    append([]string{}, payload.RejectedBy...),
+		FailedJobs:    failedJobs,
+	}, nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -70,3 +71,70 @@ func buildFakeReleaseHealthReport(osVersion string) apitype.ReleaseHealthReport
 		},
 	}
 }
+
+func TestScanReleaseHealthForPromotionGaps(t *testing.T) {
+	reportEnd := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		releaseHealth    []apitype.ReleaseHealthReport
+		threshold        time.Duration
+		expectedWarnings []string
+	}{
+		{
+			name: "recently promoted",
+			releaseHealth: []apitype.ReleaseHealthReport{
+				buildFakePromotionReport("4.16.0-0.nightly-2024-05-10-010203", reportEnd.Add(-11*time.Hour)),
+			},
+			expectedWarnings: []string{},
+		},
+		{
+			name: "stale stream",
+			releaseHealth: []apitype.ReleaseHealthReport{
+				buildFakePromotionReport("4.16.0-0.nightly-2024-05-10-010203", reportEnd.Add(-11*time.Hour)),
+				buildFakePromotionReport("4.16.0-0.nightly-2024-05-05-010203", reportEnd.Add(-100*time.Hour)),
+			},
+			expectedWarnings: []string{
+				"4.16 nightly amd64 has not promoted in 100 hours, last accepted payload was 4.16.0-0.nightly-2024-05-05-010203",
+			},
+		},
+		{
+			name: "configured threshold",
+			releaseHealth: []apitype.ReleaseHealthReport{
+				buildFakePromotionReport("4.16.0-0.nightly-2024-05-09-010203", reportEnd.Add(-30*time.Hour)),
+			},
+			threshold: 24 * time.Hour,
+			expectedWarnings: []string{
+				"4.16 nightly amd64 has not promoted in 30 hours, last accepted payload was 4.16.0-0.nightly-2024-05-09-010203",
+			},
+		},
+		{
+			name: "no accepted payloads",
+			releaseHealth: []apitype.ReleaseHealthReport{
+				buildFakePromotionReport("", time.Time{}),
+			},
+			expectedWarnings: []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold := tc.threshold
+			if threshold == 0 {
+				threshold = DefaultPromotionWarningThreshold
+			}
+			warnings := ScanReleaseHealthForPromotionGaps(tc.releaseHealth, reportEnd, threshold)
+			assert.ElementsMatch(t, tc.expectedWarnings, warnings, "unexpected warnings")
+		})
+	}
+}
+
+func buildFakePromotionReport(tag string, releaseTime time.Time) apitype.ReleaseHealthReport {
+	return apitype.ReleaseHealthReport{
+		ReleaseTag: models.ReleaseTag{
+			Release:      "4.16",
+			Stream:       "nightly",
+			Architecture: "amd64",
+			ReleaseTag:   tag,
+			ReleaseTime:  releaseTime,
+		},
+	}
+}
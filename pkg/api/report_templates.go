@@ -0,0 +1,429 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+const (
+	ReportParamString = "string"
+	ReportParamInt    = "int"
+	ReportParamFloat  = "float"
+	ReportParamBool   = "bool"
+	ReportParamDate   = "date"
+
+	// reportTemplateMaxRows caps the rows a template returns, the result notes when it was truncated.
+	reportTemplateMaxRows = 10000
+	// reportTemplateTimeout is the statement timeout templates run with.
+	reportTemplateTimeout = time.Minute
+)
+
+// ErrReportTemplateNotAllowed is returned when running a template that reads more than the materialized views.
+var ErrReportTemplateNotAllowed = errors.New("report templates may only read materialized views")
+
+var (
+	reportTemplateNameRegexp = regexp.MustCompile(`^[\w-]+$`)
+	reportParamNameRegexp    = regexp.MustCompile(`^[a-zA-Z]\w*$`)
+	reportSQLParamRegexp     = regexp.MustCompile(`@([a-zA-Z]\w*)`)
+	reportRoleRegexp         = regexp.MustCompile(`^[a-zA-Z_][\w$]*$`)
+	// reportSQLStringRegexp matches string literals, group 1 is the prefix of escape and unicode strings.
+	reportSQLStringRegexp = regexp.MustCompile(`(?i)(\b(?:e|u&))?'(?:[^']|'')*'`)
+	// reportSQLCallRegexp matches what may be a function call, group 1 is a schema qualifier's dot.
+	reportSQLCallRegexp = regexp.MustCompile(`(\.\s*)?("(?:[^"]|"")*"|[a-zA-Z_][\w$]*)\s*\(`)
+)
+
+// reportSQLKeywords are the keywords that may be followed by a parenthesis without being a function call.
+var reportSQLKeywords = sets.NewString(
+	"all", "and", "any", "array", "as", "between", "by", "case", "distinct", "else", "exists", "filter", "from",
+	"group", "in", "is", "join", "like", "ilike", "not", "on", "or", "over", "partition", "select", "some", "then",
+	"union", "using", "values", "when", "where", "with", "within",
+)
+
+// reportSQLFunctions are the functions templates may call, builtins that only compute over their arguments. Anything
+// else, such as query_to_xml, pg_read_file or sippy's own SQL functions, could read beyond the materialized views.
+var reportSQLFunctions = sets.NewString(
+	// aggregates and window functions
+	"array_agg", "avg", "bool_and", "bool_or", "count", "dense_rank", "first_value", "lag", "last_value", "lead",
+	"max", "min", "ntile", "percent_rank", "percentile_cont", "percentile_disc", "rank", "row_number", "stddev",
+	"string_agg", "sum", "variance",
+	// conditionals and math
+	"abs", "ceil", "coalesce", "floor", "greatest", "least", "nullif", "power", "round", "sqrt", "trunc",
+	// strings
+	"concat", "left", "length", "lower", "position", "regexp_replace", "replace", "right", "split_part", "strpos",
+	"substr", "substring", "trim", "upper",
+	// dates
+	"age", "date_part", "date_trunc", "extract", "make_interval", "now", "to_char", "to_timestamp",
+	// arrays, unnest and generate_series also appear as function scans in plans
+	"array_length", "array_to_string", "cardinality", "generate_series", "unnest",
+	// type names taking a modifier in casts
+	"cast", "char", "decimal", "numeric", "timestamp", "varchar",
+)
+
+// ReportTemplates are the configured report templates. Templates are checked to be a single SELECT declaring every
+// parameter it uses when configured, and to only read from sippy's materialized views from their query plan each
+// time they run, as the plan is the only reliable account of what a query reads.
+type ReportTemplates struct {
+	// role, if set, is the role templates run as, which should be granted SELECT on the materialized views only.
+	role      string
+	matviews  map[string]bool
+	templates map[string]reportTemplate
+	names     []string
+}
+
+type reportTemplate struct {
+	v1config.ReportTemplate
+	patterns map[string]*regexp.Regexp
+}
+
+// NewReportTemplates validates the configured templates. If role is set templates run as that role, which is the
+// only safeguard that holds regardless of what a template's SQL does, the checks here are a second line of defense.
+func NewReportTemplates(templates []v1config.ReportTemplate, role string) (*ReportTemplates, error) {
+	if role != "" && !reportRoleRegexp.MatchString(role) {
+		return nil, fmt.Errorf("invalid report template role %q", role)
+	}
+	rt := &ReportTemplates{role: role, matviews: map[string]bool{}, templates: map[string]reportTemplate{}}
+	for _, v := range db.PostgresMatViews {
+		rt.matviews[v.Name] = true
+	}
+	for _, t := range templates {
+		if !reportTemplateNameRegexp.MatchString(t.Name) {
+			return nil, fmt.Errorf("invalid report template name %q", t.Name)
+		}
+		if _, ok := rt.templates[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate report template %q", t.Name)
+		}
+		t.SQL = strings.TrimSuffix(strings.TrimSpace(t.SQL), ";")
+		if err := validateReportSQL(t.SQL); err != nil {
+			return nil, fmt.Errorf("report template %q: %w", t.Name, err)
+		}
+		patterns, err := validateReportParams(t)
+		if err != nil {
+			return nil, fmt.Errorf("report template %q: %w", t.Name, err)
+		}
+		rt.templates[t.Name] = reportTemplate{ReportTemplate: t, patterns: patterns}
+		rt.names = append(rt.names, t.Name)
+	}
+	return rt, nil
+}
+
+// validateReportSQL checks a query is a single SELECT that only calls allowed functions. Writes need no check,
+// templates run in a read only transaction.
+func validateReportSQL(sql string) error {
+	upper := strings.ToUpper(sql)
+	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
+		return fmt.Errorf("sql must be a SELECT")
+	}
+
+	// Drop string literals so their contents aren't mistaken for SQL.
+	var literalErr error
+	code := reportSQLStringRegexp.ReplaceAllStringFunc(sql, func(literal string) string {
+		if !strings.HasPrefix(literal, "'") {
+			literalErr = fmt.Errorf("sql must not use escape or unicode strings")
+		}
+		return "NULL"
+	})
+	if literalErr != nil {
+		return literalErr
+	}
+	switch {
+	case strings.Contains(code, "'"):
+		return fmt.Errorf("sql has an unterminated string")
+	case strings.Contains(code, ";"):
+		return fmt.Errorf("sql must be a single statement")
+	case strings.Contains(code, "--"), strings.Contains(code, "/*"):
+		return fmt.Errorf("sql must not contain comments")
+	case strings.Contains(code, "$"):
+		return fmt.Errorf("sql must not use dollar quoting")
+	}
+
+	for _, m := range reportSQLCallRegexp.FindAllStringSubmatch(code, -1) {
+		name := m[2]
+		if strings.HasPrefix(name, `"`) {
+			name = strings.ReplaceAll(strings.Trim(name, `"`), `""`, `"`)
+		} else {
+			name = strings.ToLower(name)
+			if m[1] == "" && reportSQLKeywords.Has(name) {
+				continue
+			}
+		}
+		if m[1] != "" || !reportSQLFunctions.Has(name) {
+			return fmt.Errorf("sql must not call %s", strings.TrimSpace(m[1]+m[2]))
+		}
+	}
+	return nil
+}
+
+// planReads adds the tables and materialized views read by a JSON query plan, or part of one, to relations, and the
+// functions it scans to functions. Views do not appear, the plan reads the tables behind them.
+func planReads(plan interface{}, relations, functions map[string]bool) {
+	switch v := plan.(type) {
+	case []interface{}:
+		for _, item := range v {
+			planReads(item, relations, functions)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			if name, ok := value.(string); ok {
+				switch key {
+				case "Relation Name":
+					relations[name] = true
+				case "Function Name":
+					functions[name] = true
+				}
+				continue
+			}
+			planReads(value, relations, functions)
+		}
+	}
+}
+
+// validateReportParams checks the template's parameters are well-formed and match those its SQL uses, and
+// compiles their patterns.
+func validateReportParams(t v1config.ReportTemplate) (map[string]*regexp.Regexp, error) {
+	patterns := map[string]*regexp.Regexp{}
+	declared := map[string]bool{}
+	for _, p := range t.Params {
+		if !reportParamNameRegexp.MatchString(p.Name) || p.Name == "template" {
+			return nil, fmt.Errorf("invalid param name %q", p.Name)
+		}
+		if declared[p.Name] {
+			return nil, fmt.Errorf("duplicate param %q", p.Name)
+		}
+		declared[p.Name] = true
+		if p.Pattern != "" {
+			re, err := regexp.Compile(p.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for param %q: %w", p.Name, err)
+			}
+			patterns[p.Name] = re
+		}
+		switch p.Type {
+		case "", ReportParamString, ReportParamInt, ReportParamFloat, ReportParamBool, ReportParamDate:
+		default:
+			return nil, fmt.Errorf("param %q has unknown type %q", p.Name, p.Type)
+		}
+		if p.Default != "" {
+			if _, err := parseReportParam(p, patterns[p.Name], p.Default); err != nil {
+				return nil, fmt.Errorf("invalid default: %w", err)
+			}
+		}
+	}
+
+	used := map[string]bool{}
+	for _, m := range reportSQLParamRegexp.FindAllStringSubmatch(t.SQL, -1) {
+		if !declared[m[1]] {
+			return nil, fmt.Errorf("sql uses undeclared param %q", m[1])
+		}
+		used[m[1]] = true
+	}
+	for name := range declared {
+		if !used[name] {
+			return nil, fmt.Errorf("param %q is not used by the sql", name)
+		}
+	}
+	return patterns, nil
+}
+
+// parseReportParam converts a parameter value to the type it is bound as.
+func parseReportParam(p v1config.ReportTemplateParam, pattern *regexp.Regexp, value string) (interface{}, error) {
+	switch p.Type {
+	case "", ReportParamString:
+		if pattern != nil && !pattern.MatchString(value) {
+			return nil, fmt.Errorf("param %q must match %s", p.Name, pattern)
+		}
+		return value, nil
+	case ReportParamInt:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("param %q must be an integer", p.Name)
+		}
+		return v, nil
+	case ReportParamFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("param %q must be a number", p.Name)
+		}
+		return v, nil
+	case ReportParamBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("param %q must be true or false", p.Name)
+		}
+		return v, nil
+	case ReportParamDate:
+		v, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("param %q must be a date, YYYY-MM-DD", p.Name)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("param %q has unknown type %q", p.Name, p.Type)
+}
+
+// List describes the templates in the order they were configured.
+func (rt *ReportTemplates) List() []apitype.ReportTemplate {
+	results := make([]apitype.ReportTemplate, 0, len(rt.names))
+	for _, name := range rt.names {
+		t := rt.templates[name]
+		result := apitype.ReportTemplate{Name: t.Name, Description: t.Description, Params: []apitype.ReportTemplateParam{}}
+		for _, p := range t.Params {
+			typ := p.Type
+			if typ == "" {
+				typ = ReportParamString
+			}
+			result.Params = append(result.Params, apitype.ReportTemplateParam{
+				Name:     p.Name,
+				Type:     typ,
+				Pattern:  p.Pattern,
+				Required: p.Required,
+				Default:  p.Default,
+			})
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Has returns whether a template is configured.
+func (rt *ReportTemplates) Has(name string) bool {
+	_, ok := rt.templates[name]
+	return ok
+}
+
+// Bind validates the values given for a template's parameters, returning them typed and keyed by name.
+func (rt *ReportTemplates) Bind(name string, values url.Values) (map[string]interface{}, error) {
+	t, ok := rt.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown report template %q", name)
+	}
+	args := map[string]interface{}{}
+	for _, p := range t.Params {
+		value := values.Get(p.Name)
+		if value == "" {
+			if p.Required {
+				return nil, fmt.Errorf("param %q is required", p.Name)
+			}
+			if p.Default == "" {
+				args[p.Name] = nil
+				continue
+			}
+			value = p.Default
+		}
+		v, err := parseReportParam(p, t.patterns[p.Name], value)
+		if err != nil {
+			return nil, err
+		}
+		args[p.Name] = v
+	}
+	return args, nil
+}
+
+// checkPlan returns an error if a query plan reads anything other than the materialized views, or scans a function
+// that isn't allowed.
+func (rt *ReportTemplates) checkPlan(plan string) error {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(plan), &parsed); err != nil {
+		return fmt.Errorf("error parsing query plan: %w", err)
+	}
+	relations, functions := map[string]bool{}, map[string]bool{}
+	planReads(parsed, relations, functions)
+	for name := range relations {
+		if !rt.matviews[name] {
+			return fmt.Errorf("%w: reads %s", ErrReportTemplateNotAllowed, name)
+		}
+	}
+	for name := range functions {
+		if !reportSQLFunctions.Has(name) {
+			return fmt.Errorf("%w: calls %s", ErrReportTemplateNotAllowed, name)
+		}
+	}
+	return nil
+}
+
+// Run executes a template with parameters from Bind, in a read only transaction with a statement timeout, as the
+// configured role if any.
+func (rt *ReportTemplates) Run(ctx context.Context, dbc *db.DB, name string, args map[string]interface{}) (apitype.ReportTemplateResult, error) {
+	t, ok := rt.templates[name]
+	if !ok {
+		return apitype.ReportTemplateResult{}, fmt.Errorf("unknown report template %q", name)
+	}
+
+	result := apitype.ReportTemplateResult{Template: name, Rows: []map[string]interface{}{}}
+	err := dbc.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", reportTemplateTimeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+		if rt.role != "" {
+			if err := tx.Exec(fmt.Sprintf(`SET LOCAL ROLE "%s"`, rt.role)).Error; err != nil {
+				return err
+			}
+		}
+
+		sql := fmt.Sprintf("SELECT * FROM (%s) AS report LIMIT %d", t.SQL, reportTemplateMaxRows+1)
+		raw := func(sql string) *gorm.DB {
+			if len(args) > 0 {
+				return tx.Raw(sql, args)
+			}
+			return tx.Raw(sql)
+		}
+
+		var plan string
+		if err := raw("EXPLAIN (FORMAT JSON) " + sql).Row().Scan(&plan); err != nil {
+			return err
+		}
+		if err := rt.checkPlan(plan); err != nil {
+			return err
+		}
+
+		rows, err := raw(sql).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if result.Columns, err = rows.Columns(); err != nil {
+			return err
+		}
+		for rows.Next() {
+			if len(result.Rows) == reportTemplateMaxRows {
+				result.Truncated = true
+				break
+			}
+			values := make([]interface{}, len(result.Columns))
+			pointers := make([]interface{}, len(values))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := rows.Scan(pointers...); err != nil {
+				return err
+			}
+			row := make(map[string]interface{}, len(values))
+			for i, column := range result.Columns {
+				if b, ok := values[i].([]byte); ok {
+					values[i] = string(b)
+				}
+				row[column] = values[i]
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		return rows.Err()
+	})
+	return result, err
+}
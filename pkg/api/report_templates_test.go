@@ -0,0 +1,225 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+var flakiestTests = v1config.ReportTemplate{
+	Name: "flakiest-tests",
+	SQL: `SELECT name, SUM(current_flakes) AS flakes
+		FROM prow_test_report_7d_matview
+		WHERE release = @release AND current_runs >= @minRuns
+		GROUP BY name ORDER BY flakes DESC;`,
+	Params: []v1config.ReportTemplateParam{
+		{Name: "release", Pattern: `^\d+\.\d+$`, Required: true},
+		{Name: "minRuns", Type: ReportParamInt, Default: "10"},
+	},
+}
+
+func TestNewReportTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template v1config.ReportTemplate
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			template: flakiestTests,
+		},
+		{
+			name:     "not a select",
+			template: v1config.ReportTemplate{Name: "drop", SQL: "DROP MATERIALIZED VIEW prow_test_report_7d_matview"},
+			wantErr:  "sql must be a SELECT",
+		},
+		{
+			name:     "several statements",
+			template: v1config.ReportTemplate{Name: "two", SQL: "SELECT 1 FROM prow_test_report_7d_matview; SELECT 2"},
+			wantErr:  "single statement",
+		},
+		{
+			name: "keywords in literals",
+			template: v1config.ReportTemplate{Name: "literal",
+				SQL: "SELECT name, COUNT(*) FROM prow_test_report_7d_matview WHERE name LIKE '%lock; drop(%' GROUP BY name"},
+		},
+		{
+			name:     "disallowed function",
+			template: v1config.ReportTemplate{Name: "xml", SQL: "SELECT query_to_xml('select * from api_tokens', true, true, '')"},
+			wantErr:  "must not call query_to_xml",
+		},
+		{
+			name:     "sippy function",
+			template: v1config.ReportTemplate{Name: "results", SQL: "SELECT * FROM test_results('4.16')"},
+			wantErr:  "must not call test_results",
+		},
+		{
+			name:     "qualified function",
+			template: v1config.ReportTemplate{Name: "qualified", SQL: "SELECT pg_catalog.count(*) FROM prow_test_report_7d_matview"},
+			wantErr:  "must not call .count",
+		},
+		{
+			name:     "quoted function",
+			template: v1config.ReportTemplate{Name: "quoted", SQL: `SELECT "pg_read_file"('/etc/passwd')`},
+			wantErr:  `must not call "pg_read_file"`,
+		},
+		{
+			name:     "escape string",
+			template: v1config.ReportTemplate{Name: "escape", SQL: `SELECT E'\\'', pg_read_file('x') FROM prow_test_report_7d_matview`},
+			wantErr:  "escape or unicode strings",
+		},
+		{
+			name:     "comment",
+			template: v1config.ReportTemplate{Name: "comment", SQL: "SELECT pg_read_file/**/('x')"},
+			wantErr:  "comments",
+		},
+		{
+			name:     "undeclared param",
+			template: v1config.ReportTemplate{Name: "undeclared", SQL: "SELECT * FROM prow_test_report_7d_matview WHERE release = @release"},
+			wantErr:  `undeclared param "release"`,
+		},
+		{
+			name: "unused param",
+			template: v1config.ReportTemplate{Name: "unused", SQL: "SELECT * FROM prow_test_report_7d_matview",
+				Params: []v1config.ReportTemplateParam{{Name: "release"}}},
+			wantErr: `param "release" is not used`,
+		},
+		{
+			name: "unknown type",
+			template: v1config.ReportTemplate{Name: "type", SQL: "SELECT * FROM prow_test_report_7d_matview WHERE release = @release",
+				Params: []v1config.ReportTemplateParam{{Name: "release", Type: "version"}}},
+			wantErr: `unknown type "version"`,
+		},
+		{
+			name: "invalid default",
+			template: v1config.ReportTemplate{Name: "default", SQL: "SELECT * FROM prow_test_report_7d_matview WHERE current_runs > @runs",
+				Params: []v1config.ReportTemplateParam{{Name: "runs", Type: ReportParamInt, Default: "many"}}},
+			wantErr: "invalid default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReportTemplates([]v1config.ReportTemplate{tt.template}, "")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err := NewReportTemplates([]v1config.ReportTemplate{flakiestTests, flakiestTests}, "")
+	assert.ErrorContains(t, err, "duplicate report template")
+
+	_, err = NewReportTemplates(nil, `sippy"; RESET ROLE`)
+	assert.ErrorContains(t, err, "invalid report template role")
+}
+
+func TestReportTemplatesBind(t *testing.T) {
+	rt, err := NewReportTemplates([]v1config.ReportTemplate{flakiestTests, {
+		Name:   "since",
+		SQL:    "SELECT * FROM prow_job_runs_report_matview WHERE timestamp >= @since",
+		Params: []v1config.ReportTemplateParam{{Name: "since", Type: ReportParamDate}},
+	}}, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		template string
+		query    string
+		want     map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "default",
+			template: "flakiest-tests",
+			query:    "release=4.16",
+			want:     map[string]interface{}{"release": "4.16", "minRuns": int64(10)},
+		},
+		{
+			name:     "given",
+			template: "flakiest-tests",
+			query:    "release=4.16&minRuns=5",
+			want:     map[string]interface{}{"release": "4.16", "minRuns": int64(5)},
+		},
+		{
+			name:     "missing required",
+			template: "flakiest-tests",
+			wantErr:  `param "release" is required`,
+		},
+		{
+			name:     "pattern mismatch",
+			template: "flakiest-tests",
+			query:    "release=4.16' OR 1=1",
+			wantErr:  `param "release" must match`,
+		},
+		{
+			name:     "wrong type",
+			template: "flakiest-tests",
+			query:    "release=4.16&minRuns=ten",
+			wantErr:  `param "minRuns" must be an integer`,
+		},
+		{
+			name:     "date",
+			template: "since",
+			query:    "since=2024-03-20",
+			want:     map[string]interface{}{"since": time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "optional without default",
+			template: "since",
+			want:     map[string]interface{}{"since": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			got, err := rt.Bind(tt.template, values)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReportTemplatesCheckPlan(t *testing.T) {
+	rt, err := NewReportTemplates(nil, "")
+	require.NoError(t, err)
+
+	matviewPlan := `[{"Plan": {"Node Type": "Aggregate", "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "prow_test_report_7d_matview", "Alias": "prow_test_report_7d_matview"},
+		{"Node Type": "CTE Scan", "CTE Name": "recent"},
+		{"Node Type": "Function Scan", "Function Name": "unnest"}
+	]}}]`
+	assert.NoError(t, rt.checkPlan(matviewPlan))
+
+	tablePlan := `[{"Plan": {"Node Type": "Hash Join", "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "prow_test_report_7d_matview"},
+		{"Node Type": "Hash", "Plans": [{"Node Type": "Seq Scan", "Relation Name": "api_tokens"}]}
+	]}}]`
+	assert.ErrorIs(t, rt.checkPlan(tablePlan), ErrReportTemplateNotAllowed)
+
+	functionPlan := `[{"Plan": {"Node Type": "Function Scan", "Function Name": "test_results", "Alias": "test_results"}}]`
+	assert.ErrorIs(t, rt.checkPlan(functionPlan), ErrReportTemplateNotAllowed)
+}
+
+func TestReportTemplatesList(t *testing.T) {
+	rt, err := NewReportTemplates([]v1config.ReportTemplate{flakiestTests}, "")
+	require.NoError(t, err)
+
+	list := rt.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, "flakiest-tests", list[0].Name)
+	assert.Equal(t, ReportParamString, list[0].Params[0].Type)
+	assert.True(t, list[0].Params[0].Required)
+	assert.Equal(t, "10", list[0].Params[1].Default)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/openshift/sippy/pkg/util/param"
+)
+
+// SampleHeader echoes the percentage of job runs an approximate response was computed from, so clients can
+// tell sampled results apart from exact ones.
+const SampleHeader = "X-Sippy-Sample-Percent"
+
+// SamplePercent reads the optional sample param, the percentage of job runs to aggregate over for a fast but
+// approximate result. It returns 0 when every run should be included.
+func SamplePercent(req *http.Request) (int, error) {
+	value := param.SafeRead(req, "sample")
+	if value == "" {
+		if req.URL.Query().Get("sample") != "" {
+			return 0, fmt.Errorf("sample must be a percentage between 1 and 100")
+		}
+		return 0, nil
+	}
+	sample, err := strconv.Atoi(value)
+	if err != nil || sample < 1 || sample > 100 {
+		return 0, fmt.Errorf("sample must be a percentage between 1 and 100")
+	}
+	if sample == 100 {
+		return 0, nil
+	}
+	return sample, nil
+}
+
+// SetSampleHeader marks a response as computed from a sample of the job runs, if it was.
+func SetSampleHeader(w http.ResponseWriter, samplePercent int) {
+	if samplePercent > 0 {
+		w.Header().Set(SampleHeader, strconv.Itoa(samplePercent))
+	}
+}
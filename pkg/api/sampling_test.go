@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplePercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{name: "not sampled", query: "", want: 0},
+		{name: "sampled", query: "sample=10", want: 10},
+		{name: "everything", query: "sample=100", want: 0},
+		{name: "zero", query: "sample=0", wantErr: true},
+		{name: "too large", query: "sample=101", wantErr: true},
+		{name: "not a number", query: "sample=half", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tests/durations?"+tt.query, nil)
+			got, err := SamplePercent(req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			w := httptest.NewRecorder()
+			SetSampleHeader(w, got)
+			if tt.want > 0 {
+				assert.Equal(t, tt.query[len("sample="):], w.Header().Get(SampleHeader))
+			} else {
+				assert.Empty(t, w.Header().Get(SampleHeader))
+			}
+		})
+	}
+}
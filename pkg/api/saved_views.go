@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jackc/pgtype"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+var (
+	// ErrSavedViewNotFound is returned for a view ID that doesn't exist.
+	ErrSavedViewNotFound = errors.New("no saved view")
+	// ErrSavedViewNotOwner is returned when changing or deleting a view as someone other than its owner.
+	ErrSavedViewNotOwner = errors.New("saved views can only be changed by their owner")
+)
+
+var savedViewPageRegexp = regexp.MustCompile(`^\w+$`)
+
+// ValidateSavedView checks a view is well-formed before we store it.
+func ValidateSavedView(view models.SavedView) error {
+	if view.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if view.Owner == "" {
+		return fmt.Errorf("owner is required")
+	}
+	if !savedViewPageRegexp.MatchString(view.Page) {
+		return fmt.Errorf("page is required and must be a word, i.e. tests")
+	}
+	if view.Sort != "" && view.Sort != "asc" && view.Sort != "desc" {
+		return fmt.Errorf("sort must be asc or desc")
+	}
+	if view.Filter.Status == pgtype.Present {
+		f := filter.Filter{}
+		if err := json.Unmarshal(view.Filter.Bytes, &f); err != nil {
+			return fmt.Errorf("invalid filter: %v", err)
+		}
+	}
+	return nil
+}
+
+// CreateSavedView validates and stores a view. Names are unique among an owner's views of a page.
+func CreateSavedView(dbc *db.DB, view models.SavedView) (models.SavedView, error) {
+	view.ID = 0
+	if err := prepareSavedView(dbc, &view); err != nil {
+		return view, err
+	}
+	res := dbc.DB.Create(&view)
+	return view, res.Error
+}
+
+// UpdateSavedView replaces the view with id, which must belong to the same owner.
+func UpdateSavedView(dbc *db.DB, id uint, view models.SavedView) (models.SavedView, error) {
+	existing, err := GetSavedView(dbc, id)
+	if err != nil {
+		return view, err
+	}
+	if existing.Owner != view.Owner {
+		return view, ErrSavedViewNotOwner
+	}
+	view.Model = existing.Model
+	if err := prepareSavedView(dbc, &view); err != nil {
+		return view, err
+	}
+	res := dbc.DB.Save(&view)
+	return view, res.Error
+}
+
+// DeleteSavedView removes the view with id, which must belong to owner.
+func DeleteSavedView(dbc *db.DB, id uint, owner string) error {
+	view, err := GetSavedView(dbc, id)
+	if err != nil {
+		return err
+	}
+	if view.Owner != owner {
+		return ErrSavedViewNotOwner
+	}
+	return dbc.DB.Delete(&view).Error
+}
+
+// GetSavedView returns the view with id, shared or not.
+func GetSavedView(dbc *db.DB, id uint) (models.SavedView, error) {
+	view := models.SavedView{}
+	if res := dbc.DB.Limit(1).Find(&view, id); res.Error != nil {
+		return view, res.Error
+	} else if res.RowsAffected == 0 {
+		return view, fmt.Errorf("%w with id %d", ErrSavedViewNotFound, id)
+	}
+	return view, nil
+}
+
+// ListSavedViews lists the shared views and those of owner, optionally only those for page, by name.
+func ListSavedViews(dbc *db.DB, owner, page string) ([]models.SavedView, error) {
+	views := make([]models.SavedView, 0)
+	q := dbc.DB.Where("shared OR owner = ?", owner)
+	if page != "" {
+		q = q.Where("page = ?", page)
+	}
+	res := q.Order("name").Order("id").Find(&views)
+	return views, res.Error
+}
+
+// prepareSavedView validates a view about to be stored and checks its owner has no other view of the page with
+// the same name.
+func prepareSavedView(dbc *db.DB, view *models.SavedView) error {
+	if view.Filter.Status != pgtype.Present {
+		view.Filter = pgtype.JSONB{Status: pgtype.Null}
+	}
+	if err := ValidateSavedView(*view); err != nil {
+		return err
+	}
+	var duplicates int64
+	res := dbc.DB.Model(&models.SavedView{}).
+		Where("owner = ? AND page = ? AND name = ? AND id <> ?", view.Owner, view.Page, view.Name, view.ID).
+		Count(&duplicates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if duplicates > 0 {
+		return fmt.Errorf("%s already has a %s view named %q", view.Owner, view.Page, view.Name)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestValidateSavedView(t *testing.T) {
+	valid := models.SavedView{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "flaky network tests",
+		"page": "tests",
+		"owner": "jdoe",
+		"shared": true,
+		"filter": {"items": [{"columnField": "name", "operatorValue": "contains", "value": "[sig-network]"}], "linkOperator": "and"},
+		"columns": ["name", "current_flake_percentage"],
+		"sort_field": "current_flake_percentage",
+		"sort": "desc"
+	}`), &valid))
+
+	tests := []struct {
+		name        string
+		mutate      func(v *models.SavedView)
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(v *models.SavedView) {},
+		},
+		{
+			name:   "no filter",
+			mutate: func(v *models.SavedView) { v.Filter = pgtype.JSONB{Status: pgtype.Null} },
+		},
+		{
+			name:        "missing name",
+			mutate:      func(v *models.SavedView) { v.Name = "" },
+			expectError: true,
+		},
+		{
+			name:        "missing owner",
+			mutate:      func(v *models.SavedView) { v.Owner = "" },
+			expectError: true,
+		},
+		{
+			name:        "invalid page",
+			mutate:      func(v *models.SavedView) { v.Page = "/tests/4.16" },
+			expectError: true,
+		},
+		{
+			name:        "invalid sort",
+			mutate:      func(v *models.SavedView) { v.Sort = "up" },
+			expectError: true,
+		},
+		{
+			name:        "filter is not a filter",
+			mutate:      func(v *models.SavedView) { v.Filter = pgtype.JSONB{Bytes: []byte(`["name"]`), Status: pgtype.Present} },
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := valid
+			tt.mutate(&view)
+			err := ValidateSavedView(view)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	ScorecardIndicators        = "indicators"
+	ScorecardBlockingJobs      = "blocking_jobs"
+	ScorecardRegressions       = "regressions"
+	ScorecardPayloadAcceptance = "payload_acceptance"
+
+	// scorecardDays is the period blocking job pass rates are measured over.
+	scorecardDays = 7
+	// scorecardRegressionPenalty is the points lost for each open disruption regression.
+	scorecardRegressionPenalty = 10
+	// scorecardRejectionPenalty is the points lost for each consecutive rejected payload in a stream.
+	scorecardRejectionPenalty = 25
+)
+
+// scorecardWeights are the default share of the overall score given to each component.
+var scorecardWeights = map[string]float64{
+	ScorecardIndicators:        0.3,
+	ScorecardBlockingJobs:      0.3,
+	ScorecardRegressions:       0.2,
+	ScorecardPayloadAcceptance: 0.2,
+}
+
+// GetReleaseScorecardFromDB scores the readiness of a release from its top level indicators, blocking job
+// pass rates, open disruption regressions and the acceptance streaks of its payload streams.
+func GetReleaseScorecardFromDB(dbc *db.DB, release string, indicatorConfigs []v1config.IndicatorConfig, reportEnd time.Time) (apitype.ReleaseScorecard, error) {
+	components := []apitype.ScorecardComponent{}
+
+	indicators := make([]apitype.Test, 0, len(indicatorConfigs))
+	for _, ic := range indicatorConfigs {
+		indicator, err := query.TestReportMatching(dbc, release, "", ic.TestNames, ic.TestRegexes, ic.ExcludeVariants)
+		if err != nil {
+			return apitype.ReleaseScorecard{}, errors.Wrapf(err, "error querying %s indicator", ic.Name)
+		}
+		indicators = append(indicators, indicator)
+	}
+	score, ok := indicatorScore(indicators)
+	components = append(components, apitype.ScorecardComponent{
+		Name:        ScorecardIndicators,
+		Description: "Mean current pass rate of the top level indicators",
+		Score:       score,
+		NoData:      !ok,
+	})
+
+	succeeded, failed, err := query.GetBlockingJobRunCounts(dbc.DB, release, reportEnd.Add(-scorecardDays*24*time.Hour), reportEnd)
+	if err != nil {
+		return apitype.ReleaseScorecard{}, errors.Wrap(err, "error querying blocking job runs")
+	}
+	components = append(components, apitype.ScorecardComponent{
+		Name:        ScorecardBlockingJobs,
+		Description: fmt.Sprintf("%d of %d blocking job runs passed in the last %d days", succeeded, succeeded+failed, scorecardDays),
+		Score:       passPercentage(succeeded, succeeded+failed),
+		NoData:      succeeded+failed == 0,
+	})
+
+	regressions, err := GetDisruptionRegressionsFromDB(dbc, release, DefaultDisruptionRegressionMinDays)
+	if err != nil {
+		return apitype.ReleaseScorecard{}, errors.Wrap(err, "error querying disruption regressions")
+	}
+	components = append(components, apitype.ScorecardComponent{
+		Name:        ScorecardRegressions,
+		Description: fmt.Sprintf("%d open disruption regressions", len(regressions)),
+		Score:       regressionScore(len(regressions)),
+	})
+
+	streams, err := query.GetLastAcceptedByArchitectureAndStream(dbc.DB, release, reportEnd)
+	if err != nil {
+		return apitype.ReleaseScorecard{}, errors.Wrap(err, "error querying payload streams")
+	}
+	streamScores := make([]float64, 0, len(streams))
+	for _, stream := range streams {
+		phase, count, err := query.GetLastPayloadStatus(dbc.DB, stream.Architecture, stream.Stream, release, reportEnd)
+		if err != nil {
+			return apitype.ReleaseScorecard{}, errors.Wrapf(err, "error querying %s %s payload status", stream.Architecture, stream.Stream)
+		}
+		streamScores = append(streamScores, payloadStreakScore(phase, count))
+	}
+	components = append(components, apitype.ScorecardComponent{
+		Name:        ScorecardPayloadAcceptance,
+		Description: "Mean score of the current payload acceptance streak in each stream",
+		Score:       mean(streamScores),
+		NoData:      len(streamScores) == 0,
+	})
+
+	return buildScorecard(release, components), nil
+}
+
+// buildScorecard weighs the components into an overall score. Components without data are given no weight,
+// with their share spread proportionally over the others.
+func buildScorecard(release string, components []apitype.ScorecardComponent) apitype.ReleaseScorecard {
+	total := 0.0
+	for _, c := range components {
+		if !c.NoData {
+			total += scorecardWeights[c.Name]
+		}
+	}
+
+	scorecard := apitype.ReleaseScorecard{Release: release, Components: components}
+	for i := range components {
+		c := &components[i]
+		if c.NoData || total == 0 {
+			c.Weight, c.Score, c.Contribution = 0, 0, 0
+			continue
+		}
+		c.Weight = scorecardWeights[c.Name] / total
+		c.Contribution = c.Score * c.Weight
+		scorecard.Score += c.Contribution
+	}
+	return scorecard
+}
+
+// indicatorScore is the mean current pass percentage of the indicators that have runs.
+func indicatorScore(indicators []apitype.Test) (float64, bool) {
+	percentages := []float64{}
+	for _, i := range indicators {
+		if i.CurrentRuns > 0 {
+			percentages = append(percentages, i.CurrentPassPercentage)
+		}
+	}
+	return mean(percentages), len(percentages) > 0
+}
+
+func regressionScore(open int) float64 {
+	return math.Max(0, 100-float64(open*scorecardRegressionPenalty))
+}
+
+// payloadStreakScore gives full marks to a stream whose latest payloads were accepted, and takes points off
+// for each consecutive rejected payload otherwise.
+func payloadStreakScore(phase string, count int) float64 {
+	if phase != apitype.PayloadRejected {
+		return 100
+	}
+	return math.Max(0, 100-float64(count*scorecardRejectionPenalty))
+}
+
+func passPercentage(passed, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(passed) / float64(total) * 100
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
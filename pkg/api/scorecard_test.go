@@ -0,0 +1,88 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestBuildScorecard(t *testing.T) {
+	tests := []struct {
+		name              string
+		components        []apitype.ScorecardComponent
+		wantScore         float64
+		wantContributions map[string]float64
+	}{
+		{
+			name: "all components weighted",
+			components: []apitype.ScorecardComponent{
+				{Name: ScorecardIndicators, Score: 90},
+				{Name: ScorecardBlockingJobs, Score: 80},
+				{Name: ScorecardRegressions, Score: 100},
+				{Name: ScorecardPayloadAcceptance, Score: 50},
+			},
+			wantScore: 81,
+			wantContributions: map[string]float64{
+				ScorecardIndicators:        27,
+				ScorecardBlockingJobs:      24,
+				ScorecardRegressions:       20,
+				ScorecardPayloadAcceptance: 10,
+			},
+		},
+		{
+			name: "weight of components without data is redistributed",
+			components: []apitype.ScorecardComponent{
+				{Name: ScorecardIndicators, Score: 80},
+				{Name: ScorecardBlockingJobs, Score: 55, NoData: true},
+				{Name: ScorecardRegressions, Score: 80},
+				{Name: ScorecardPayloadAcceptance, NoData: true},
+			},
+			wantScore: 80,
+			wantContributions: map[string]float64{
+				ScorecardIndicators:        48,
+				ScorecardBlockingJobs:      0,
+				ScorecardRegressions:       32,
+				ScorecardPayloadAcceptance: 0,
+			},
+		},
+		{
+			name: "no data",
+			components: []apitype.ScorecardComponent{
+				{Name: ScorecardIndicators, NoData: true},
+			},
+			wantContributions: map[string]float64{ScorecardIndicators: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorecard := buildScorecard("4.16", tt.components)
+			assert.Equal(t, "4.16", scorecard.Release)
+			assert.InDelta(t, tt.wantScore, scorecard.Score, 0.001)
+			for _, c := range scorecard.Components {
+				assert.InDelta(t, tt.wantContributions[c.Name], c.Contribution, 0.001, c.Name)
+			}
+		})
+	}
+}
+
+func TestScorecardComponentScores(t *testing.T) {
+	score, ok := indicatorScore([]apitype.Test{
+		{CurrentRuns: 10, CurrentPassPercentage: 90},
+		{CurrentRuns: 10, CurrentPassPercentage: 70},
+		{CurrentRuns: 0},
+	})
+	assert.True(t, ok)
+	assert.InDelta(t, 80, score, 0.001)
+	_, ok = indicatorScore([]apitype.Test{{CurrentRuns: 0}})
+	assert.False(t, ok)
+
+	assert.Equal(t, 100.0, regressionScore(0))
+	assert.Equal(t, 70.0, regressionScore(3))
+	assert.Equal(t, 0.0, regressionScore(20))
+
+	assert.Equal(t, 100.0, payloadStreakScore(apitype.PayloadAccepted, 4))
+	assert.Equal(t, 75.0, payloadStreakScore(apitype.PayloadRejected, 1))
+	assert.Equal(t, 0.0, payloadStreakScore(apitype.PayloadRejected, 5))
+}
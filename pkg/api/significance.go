@@ -0,0 +1,52 @@
+package api
+
+import (
+	fischer "github.com/glycerine/golang-fisher-exact"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+)
+
+// ExtremeRegressionThreshold is the drop in pass rate, as a fraction, beyond which a significant regression is
+// extreme.
+const ExtremeRegressionThreshold = 0.15
+
+// FisherExactTest returns whether the sample's failures differ from the base's at the required confidence, a
+// percentage, along with the p value. Flakes count as successes.
+func FisherExactTest(confidenceRequired, sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake int) (bool, float64) {
+	_, _, r, _ := fischer.FisherExactTest(sampleTotal-sampleSuccess-sampleFlake,
+		sampleSuccess+sampleFlake,
+		baseTotal-baseSuccess-baseFlake,
+		baseSuccess+baseFlake)
+	return r < 1-float64(confidenceRequired)/100, r
+}
+
+// AssessSignificance compares a sample's pass rate with its basis the way component readiness does, returning
+// NotSignificant, SignificantImprovement, SignificantRegression or ExtremeRegression and the Fisher's exact p value,
+// zero when no test was needed. Improvements are tested with sample and base flipped. A regression is only tested
+// for when the pass rate dropped by more than pityFactor percent, and is extreme if it dropped by more than
+// ExtremeRegressionThreshold. Both totals must be non-zero.
+func AssessSignificance(confidenceRequired, pityFactor, sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake int) (crtype.Status, float64) {
+	basisPassPercentage := float64(baseSuccess+baseFlake) / float64(baseTotal)
+	samplePassPercentage := float64(sampleSuccess+sampleFlake) / float64(sampleTotal)
+
+	if samplePassPercentage >= basisPassPercentage {
+		significant, fisherExact := FisherExactTest(confidenceRequired, baseTotal, baseSuccess, baseFlake, sampleTotal, sampleSuccess, sampleFlake)
+		if significant {
+			return crtype.SignificantImprovement, fisherExact
+		}
+		return crtype.NotSignificant, fisherExact
+	}
+
+	if basisPassPercentage-samplePassPercentage <= float64(pityFactor)/100 {
+		return crtype.NotSignificant, 0
+	}
+	significant, fisherExact := FisherExactTest(confidenceRequired, sampleTotal, sampleSuccess, sampleFlake, baseTotal, baseSuccess, baseFlake)
+	switch {
+	case !significant:
+		return crtype.NotSignificant, fisherExact
+	case basisPassPercentage-samplePassPercentage > ExtremeRegressionThreshold:
+		return crtype.ExtremeRegression, fisherExact
+	default:
+		return crtype.SignificantRegression, fisherExact
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+)
+
+func TestAssessSignificance(t *testing.T) {
+	tests := []struct {
+		name                                               string
+		pityFactor                                         int
+		sampleTotal, sampleSuccess, baseTotal, baseSuccess int
+		want                                               crtype.Status
+	}{
+		{name: "unchanged", sampleTotal: 100, sampleSuccess: 95, baseTotal: 1000, baseSuccess: 950, want: crtype.NotSignificant},
+		{name: "improved", sampleTotal: 1000, sampleSuccess: 1000, baseTotal: 1000, baseSuccess: 900, want: crtype.SignificantImprovement},
+		{name: "regressed", sampleTotal: 1000, sampleSuccess: 850, baseTotal: 1000, baseSuccess: 950, want: crtype.SignificantRegression},
+		{name: "extreme", sampleTotal: 1000, sampleSuccess: 700, baseTotal: 1000, baseSuccess: 950, want: crtype.ExtremeRegression},
+		{name: "within pity", pityFactor: 15, sampleTotal: 1000, sampleSuccess: 850, baseTotal: 1000, baseSuccess: 950, want: crtype.NotSignificant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := AssessSignificance(95, tt.pityFactor, tt.sampleTotal, tt.sampleSuccess, 0, tt.baseTotal, tt.baseSuccess, 0)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
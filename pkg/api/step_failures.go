@@ -0,0 +1,63 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// stepFailuresWindow is how far back step failures are counted.
+const stepFailuresWindow = 7 * 24 * time.Hour
+
+// GetStepFailuresFromDB lists the steps failing in the runs of a job, or of every job if job is empty, in release
+// over the last week, most failures first.
+func GetStepFailuresFromDB(dbc *db.DB, release, job string, reportEnd time.Time) ([]apitype.StepFailures, error) {
+	counts, err := query.StepFailuresByJob(dbc, release, job, reportEnd.Add(-stepFailuresWindow), reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	return rankStepFailures(counts), nil
+}
+
+// GetPlatformStepFailuresFromDB lists the steps failing in the runs of the jobs on each platform in release over the
+// last week, most failures first.
+func GetPlatformStepFailuresFromDB(dbc *db.DB, release string, reportEnd time.Time) ([]apitype.StepFailures, error) {
+	counts, err := query.StepFailuresByPlatform(dbc, release, reportEnd.Add(-stepFailuresWindow), reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	return rankStepFailures(counts), nil
+}
+
+// rankStepFailures orders the step failure counts by failures, then failure percentage, so steps that fail often
+// in many runs come before those that always fail in a job that rarely runs.
+func rankStepFailures(counts []query.StepFailureCount) []apitype.StepFailures {
+	results := make([]apitype.StepFailures, 0, len(counts))
+	for _, c := range counts {
+		results = append(results, apitype.StepFailures{
+			JobName:           c.JobName,
+			Platform:          c.Platform,
+			Step:              c.Step,
+			Runs:              c.Runs,
+			Failures:          c.Failures,
+			FailurePercentage: percentOf(c.Failures, c.Runs),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		if a.FailurePercentage != b.FailurePercentage {
+			return a.FailurePercentage > b.FailurePercentage
+		}
+		if a.Step != b.Step {
+			return a.Step < b.Step
+		}
+		return a.JobName+a.Platform < b.JobName+b.Platform
+	})
+	return results
+}
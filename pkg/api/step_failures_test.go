@@ -0,0 +1,32 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+func TestRankStepFailures(t *testing.T) {
+	got := rankStepFailures([]query.StepFailureCount{
+		{Platform: "aws", Step: "openshift-e2e-test", Runs: 100, Failures: 10},
+		{Platform: "gcp", Step: "ipi-install-install", Runs: 10, Failures: 10},
+		{Platform: "aws", Step: "ipi-install-install", Runs: 200, Failures: 30},
+		{Platform: "azure", Step: "ipi-install-install", Runs: 40, Failures: 0},
+	})
+
+	var order []string
+	for _, r := range got {
+		order = append(order, r.Platform+"/"+r.Step)
+	}
+	assert.Equal(t, []string{
+		"aws/ipi-install-install",
+		"gcp/ipi-install-install",
+		"aws/openshift-e2e-test",
+		"azure/ipi-install-install",
+	}, order)
+	assert.Equal(t, 15.0, got[0].FailurePercentage)
+	assert.Equal(t, 100.0, got[1].FailurePercentage)
+	assert.Equal(t, 0.0, got[3].FailurePercentage)
+}
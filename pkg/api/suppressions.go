@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/util"
+)
+
+// testReportWindow is how far back the test report matviews look, suppressions ending before this
+// cannot affect them.
+const testReportWindow = 14 * 24 * time.Hour
+
+// ValidateTestSuppression checks a suppression is well-formed before we store it.
+func ValidateTestSuppression(suppression models.TestSuppression) error {
+	if suppression.TestPattern == "" {
+		return fmt.Errorf("test_pattern is required")
+	}
+	if _, err := regexp.Compile(suppression.TestPattern); err != nil {
+		return fmt.Errorf("invalid test_pattern: %v", err)
+	}
+	if suppression.StartDate.IsZero() || suppression.EndDate.IsZero() {
+		return fmt.Errorf("start_date and end_date are required")
+	}
+	if !suppression.EndDate.After(suppression.StartDate) {
+		return fmt.Errorf("end_date must be after start_date")
+	}
+	if suppression.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// CreateTestSuppression validates and stores a suppression. Suppressed results are excluded from test pass
+// rates from the next materialized view refresh.
+func CreateTestSuppression(dbc *db.DB, suppression models.TestSuppression) (models.TestSuppression, error) {
+	suppression.ID = 0
+	if err := ValidateTestSuppression(suppression); err != nil {
+		return suppression, err
+	}
+	res := dbc.DB.Create(&suppression)
+	return suppression, res.Error
+}
+
+// DeleteTestSuppression removes a suppression, returning it.
+func DeleteTestSuppression(dbc *db.DB, id uint) (models.TestSuppression, error) {
+	suppression := models.TestSuppression{}
+	if res := dbc.DB.Limit(1).Find(&suppression, id); res.Error != nil {
+		return suppression, res.Error
+	} else if res.RowsAffected == 0 {
+		return suppression, fmt.Errorf("no test suppression with id %d", id)
+	}
+	return suppression, dbc.DB.Delete(&suppression).Error
+}
+
+// SuppressionAffectsTestReports returns true if a suppression covers any of the results in the test report
+// window as of now, i.e. adding or removing it changes the test reports.
+func SuppressionAffectsTestReports(suppression models.TestSuppression, now time.Time) bool {
+	return suppression.EndDate.After(now.Add(-testReportWindow)) && suppression.StartDate.Before(now)
+}
+
+// markSuppressedTests flags tests which had results excluded by a suppression during the test report window ending
+// at reportEnd, so pinned reports show the suppressions that applied then.
+func markSuppressedTests(dbc *db.DB, tests []apitype.Test, reportEnd time.Time) {
+	since := reportEnd.Add(-testReportWindow)
+	suppressions, err := query.ListTestSuppressions(dbc, &since)
+	if err != nil {
+		log.WithError(err).Warn("error listing test suppressions, tests will not be marked as suppressed")
+		return
+	}
+	active := suppressions[:0]
+	for _, s := range suppressions {
+		if SuppressionAffectsTestReports(s, reportEnd) {
+			active = append(active, s)
+		}
+	}
+	suppressions = active
+	if len(suppressions) == 0 {
+		return
+	}
+
+	patterns := make([]*regexp.Regexp, len(suppressions))
+	for i, s := range suppressions {
+		// invalid patterns are rejected on creation, but if one slipped in just skip it
+		patterns[i], _ = regexp.Compile(s.TestPattern)
+	}
+
+	for i := range tests {
+		for j, s := range suppressions {
+			if patterns[j] == nil || !patterns[j].MatchString(tests[i].Name) {
+				continue
+			}
+			// Collapsed results have no variants, so any variant suppression applies.
+			if s.Variant != "" && len(tests[i].Variants) > 0 && !util.StrSliceContains(tests[i].Variants, s.Variant) {
+				continue
+			}
+			tests[i].Suppressed = true
+			break
+		}
+	}
+}
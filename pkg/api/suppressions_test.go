@@ -0,0 +1,86 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestValidateTestSuppression(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	valid := models.TestSuppression{
+		TestPattern: `^\[sig-network\] .*ingress`,
+		Variant:     "metal",
+		StartDate:   start,
+		EndDate:     start.Add(72 * time.Hour),
+		Reason:      "metal lab network outage",
+		BugURL:      "https://issues.redhat.com/browse/OCPBUGS-1",
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(s *models.TestSuppression)
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(s *models.TestSuppression) {},
+		},
+		{
+			name:        "invalid pattern",
+			mutate:      func(s *models.TestSuppression) { s.TestPattern = "[unclosed" },
+			expectError: true,
+		},
+		{
+			name:        "missing pattern",
+			mutate:      func(s *models.TestSuppression) { s.TestPattern = "" },
+			expectError: true,
+		},
+		{
+			name:        "end before start",
+			mutate:      func(s *models.TestSuppression) { s.EndDate = start.Add(-time.Hour) },
+			expectError: true,
+		},
+		{
+			name:        "missing reason",
+			mutate:      func(s *models.TestSuppression) { s.Reason = "" },
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			suppression := valid
+			tc.mutate(&suppression)
+			err := ValidateTestSuppression(suppression)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSuppressionAffectsTestReports(t *testing.T) {
+	now := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+	}{
+		{name: "within the report window", start: now.Add(-3 * day), end: now.Add(-2 * day), want: true},
+		{name: "ongoing", start: now.Add(-day), end: now.Add(day), want: true},
+		{name: "ending at the start of the window", start: now.Add(-30 * day), end: now.Add(-13 * day), want: true},
+		{name: "before the report window", start: now.Add(-30 * day), end: now.Add(-20 * day), want: false},
+		{name: "in the future", start: now.Add(day), end: now.Add(2 * day), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SuppressionAffectsTestReports(models.TestSuppression{StartDate: tt.start, EndDate: tt.end}, now))
+		})
+	}
+}
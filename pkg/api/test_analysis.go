@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -41,25 +42,8 @@ func GetTestAnalysisOverallFromDB(dbc *db.DB, filters *filter.Filter, release, t
 		Order("date ASC").
 		Group("date, test_id, test_name, prow_test_analysis_by_job_14d_matview.release")
 
-	var allowedVariants, blockedVariants []string
-	if filters != nil {
-		for _, f := range filters.Items {
-			if f.Field == "variants" {
-				if f.Not {
-					blockedVariants = append(blockedVariants, f.Value)
-				} else {
-					allowedVariants = append(allowedVariants, f.Value)
-				}
-			}
-		}
-	}
-
-	for _, bv := range blockedVariants {
-		jq = jq.Where("? != ANY(prow_jobs.variants)", bv)
-	}
-
-	for _, av := range allowedVariants {
-		jq = jq.Where("? = ANY(prow_jobs.variants)", av)
+	for _, c := range variantConditions(filters, "prow_jobs.variants") {
+		jq = jq.Where(c.query, c.variant)
 	}
 
 	r := jq.Scan(&rows)
@@ -73,6 +57,31 @@ func GetTestAnalysisOverallFromDB(dbc *db.DB, filters *filter.Filter, release, t
 	return result, nil
 }
 
+type variantCondition struct {
+	query   string
+	variant string
+}
+
+// variantConditions returns the conditions on a variants array column for the filter's variants items. A job must
+// have every allowed variant, and none of the blocked ones.
+func variantConditions(filters *filter.Filter, column string) []variantCondition {
+	conditions := []variantCondition{}
+	if filters == nil {
+		return conditions
+	}
+	for _, f := range filters.Items {
+		if f.Field != "variants" {
+			continue
+		}
+		if f.Not {
+			conditions = append(conditions, variantCondition{query: fmt.Sprintf("? != ALL(%s)", column), variant: f.Value})
+		} else {
+			conditions = append(conditions, variantCondition{query: fmt.Sprintf("? = ANY(%s)", column), variant: f.Value})
+		}
+	}
+	return conditions
+}
+
 func GetTestAnalysisByJobFromDB(dbc *db.DB, filters *filter.Filter, release, testName string, reportEnd time.Time) (map[string][]CountByDate, error) {
 	var rows []CountByDate
 	results := make(map[string][]CountByDate)
@@ -105,25 +114,8 @@ func GetTestAnalysisByJobFromDB(dbc *db.DB, filters *filter.Filter, release, tes
 		Where("date <= ?", reportEnd).
 		Order("date ASC")
 
-	var allowedVariants, blockedVariants []string
-	if filters != nil {
-		for _, f := range filters.Items {
-			if f.Field == "variants" {
-				if f.Not {
-					blockedVariants = append(blockedVariants, f.Value)
-				} else {
-					allowedVariants = append(allowedVariants, f.Value)
-				}
-			}
-		}
-	}
-
-	for _, bv := range blockedVariants {
-		jq = jq.Where("? != ANY(variants)", bv)
-	}
-
-	for _, av := range allowedVariants {
-		jq = jq.Where("? = ANY(variants)", av)
+	for _, c := range variantConditions(filters, "variants") {
+		jq = jq.Where(c.query, c.variant)
 	}
 
 	r := jq.Scan(&rows)
@@ -199,3 +191,59 @@ func GetTestAnalysisByVariantFromDB(dbc *db.DB, filters *filter.Filter, release,
 
 	return results, nil
 }
+
+// GetTestAnalysisByVariantCombinationFromDB is like GetTestAnalysisByVariantFromDB, but groups by each job's full
+// set of variants, so a job in aws, ovn and upgrade is reported under "aws+ovn+upgrade" rather than once for each.
+// Variant filters match combinations that include, or with not, exclude the variant.
+func GetTestAnalysisByVariantCombinationFromDB(dbc *db.DB, filters *filter.Filter, release, testName string, reportEnd time.Time) (map[string][]CountByDate, error) {
+	var rows []CountByDate
+	results := make(map[string][]CountByDate)
+
+	overallResult, err := GetTestAnalysisOverallFromDB(dbc, filters, release, testName, reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	if overall, ok := overallResult["overall"]; ok {
+		results["overall"] = overall
+	}
+
+	vq := dbc.DB.Table("prow_test_analysis_by_variant_combination_14d_matview").
+		Where("release = ?", release).
+		Where("test_name = ?", testName).
+		Where("date <= ?", reportEnd).
+		Select(`to_date((date at time zone 'UTC')::text, 'YYYY-MM-DD'::text)::text as date,
+			variant_combination as group,
+			runs,
+			passes,
+			flakes,
+			failures,
+			passes * 100.0 / NULLIF(runs, 0) AS pass_percentage,
+			flakes * 100.0 / NULLIF(runs, 0) AS flake_percentage,
+			failures * 100.0 / NULLIF(runs, 0) AS fail_percentage`).
+		Order("date ASC")
+
+	if filters != nil {
+		for _, f := range filters.Items {
+			if f.Field != "variants" {
+				continue
+			}
+			if f.Not {
+				vq = vq.Where("NOT (? = ANY(string_to_array(variant_combination, '+')))", f.Value)
+			} else {
+				vq = vq.Where("? = ANY(string_to_array(variant_combination, '+'))", f.Value)
+			}
+		}
+	}
+
+	r := vq.Scan(&rows)
+	if r.Error != nil {
+		log.WithError(r.Error).Error("error querying test analysis by variant combination")
+		return nil, r.Error
+	}
+
+	for _, row := range rows {
+		results[row.Group] = append(results[row.Group], row)
+	}
+
+	return results, nil
+}
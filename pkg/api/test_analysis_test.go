@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+func TestVariantConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters *filter.Filter
+		want    []variantCondition
+	}{
+		{
+			name: "no filter",
+			want: []variantCondition{},
+		},
+		{
+			name: "blocked variants must be absent from every element, allowed present in one",
+			filters: &filter.Filter{Items: []filter.FilterItem{
+				{Field: "variants", Not: true, Value: "upgrade-minor"},
+				{Field: "variants", Value: "aws"},
+				{Field: "name", Value: "e2e"},
+			}},
+			want: []variantCondition{
+				{query: "? != ALL(prow_jobs.variants)", variant: "upgrade-minor"},
+				{query: "? = ANY(prow_jobs.variants)", variant: "aws"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, variantConditions(tt.filters, "prow_jobs.variants"))
+		})
+	}
+}
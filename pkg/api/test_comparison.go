@@ -0,0 +1,104 @@
+package api
+
+import (
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+const (
+	// testComparisonSampleWindow is how far back the sample looks from the report end.
+	testComparisonSampleWindow = 7 * 24 * time.Hour
+	// testComparisonBaseWindow is how far back the basis looks from its end, wider than the sample so the
+	// basis is stable.
+	testComparisonBaseWindow = 28 * 24 * time.Hour
+)
+
+// TestComparisonOptions are the thresholds used to decide if a difference is significant. They have the same
+// meaning and defaults as the component readiness advanced options.
+type TestComparisonOptions struct {
+	Confidence     int
+	PityFactor     int
+	MinimumFailure int
+}
+
+// CompareTestFromDB compares a test's results over the last week in release with its results over the four
+// weeks up to baseEnd in baseRelease, restricted to jobs matching the variant filters in fil.
+func CompareTestFromDB(dbc *db.DB, test, release, baseRelease string, fil *filter.Filter, opts TestComparisonOptions, baseEnd, reportEnd time.Time) (apitype.TestComparison, error) {
+	include, exclude := variantFilters(fil)
+	sample, err := query.TestStatusCounts(dbc, release, test, include, exclude, reportEnd.Add(-testComparisonSampleWindow), reportEnd)
+	if err != nil {
+		return apitype.TestComparison{}, err
+	}
+	base, err := query.TestStatusCounts(dbc, baseRelease, test, include, exclude, baseEnd.Add(-testComparisonBaseWindow), baseEnd)
+	if err != nil {
+		return apitype.TestComparison{}, err
+	}
+
+	comparison := compareTestResults(sample, base, opts)
+	comparison.TestName = test
+	return comparison, nil
+}
+
+// variantFilters returns the variants a filter requires jobs to have, and those it requires them not to have.
+// Other filter items are ignored.
+func variantFilters(fil *filter.Filter) (include, exclude []string) {
+	if fil == nil {
+		return nil, nil
+	}
+	for _, f := range fil.Items {
+		if f.Field != "variants" {
+			continue
+		}
+		if f.Not {
+			exclude = append(exclude, f.Value)
+		} else {
+			include = append(include, f.Value)
+		}
+	}
+	return include, exclude
+}
+
+// compareTestResults assesses significance the same way component readiness does: a sample is only considered
+// regressed if its pass rate has dropped by more than the pity factor, it has at least the minimum number of
+// failures, and the difference is significant at the required confidence.
+func compareTestResults(sample, base apitype.TestComparisonStats, opts TestComparisonOptions) apitype.TestComparison {
+	sample.PassPercentage = passRate(sample) * 100
+	base.PassPercentage = passRate(base) * 100
+	comparison := apitype.TestComparison{
+		Sample:     sample,
+		Base:       base,
+		Confidence: opts.Confidence,
+		PityFactor: opts.PityFactor,
+		Status:     crtype.NotSignificant,
+	}
+
+	switch {
+	case base.Runs == 0:
+		comparison.Status = crtype.MissingBasis
+		return comparison
+	case sample.Runs == 0:
+		comparison.Status = crtype.MissingSample
+		return comparison
+	}
+
+	if passRate(sample) < passRate(base) && sample.Failures < opts.MinimumFailure {
+		return comparison
+	}
+	comparison.Status, comparison.FisherExact = AssessSignificance(opts.Confidence, opts.PityFactor,
+		sample.Runs, sample.Successes, sample.Flakes, base.Runs, base.Successes, base.Flakes)
+	comparison.Regressed = comparison.Status == crtype.SignificantRegression || comparison.Status == crtype.ExtremeRegression
+	return comparison
+}
+
+// passRate counts flakes as passes, as component readiness does.
+func passRate(stats apitype.TestComparisonStats) float64 {
+	if stats.Runs == 0 {
+		return 0
+	}
+	return float64(stats.Successes+stats.Flakes) / float64(stats.Runs)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+)
+
+func TestCompareTestResults(t *testing.T) {
+	opts := TestComparisonOptions{Confidence: 95, PityFactor: 5, MinimumFailure: 3}
+	stats := func(runs, successes, flakes int) apitype.TestComparisonStats {
+		return apitype.TestComparisonStats{Runs: runs, Successes: successes, Flakes: flakes, Failures: runs - successes - flakes}
+	}
+
+	tests := []struct {
+		name          string
+		sample        apitype.TestComparisonStats
+		base          apitype.TestComparisonStats
+		wantStatus    crtype.Status
+		wantRegressed bool
+	}{
+		{
+			name:       "missing basis",
+			sample:     stats(100, 90, 0),
+			base:       stats(0, 0, 0),
+			wantStatus: crtype.MissingBasis,
+		},
+		{
+			name:       "missing sample",
+			sample:     stats(0, 0, 0),
+			base:       stats(100, 99, 0),
+			wantStatus: crtype.MissingSample,
+		},
+		{
+			name:       "within pity factor",
+			sample:     stats(100, 96, 0),
+			base:       stats(1000, 990, 0),
+			wantStatus: crtype.NotSignificant,
+		},
+		{
+			name:          "significant regression",
+			sample:        stats(100, 85, 5),
+			base:          stats(1000, 990, 0),
+			wantStatus:    crtype.SignificantRegression,
+			wantRegressed: true,
+		},
+		{
+			name:          "extreme regression",
+			sample:        stats(100, 60, 0),
+			base:          stats(1000, 990, 0),
+			wantStatus:    crtype.ExtremeRegression,
+			wantRegressed: true,
+		},
+		{
+			name:       "too few failures",
+			sample:     stats(4, 2, 0),
+			base:       stats(1000, 1000, 0),
+			wantStatus: crtype.NotSignificant,
+		},
+		{
+			name:       "significant improvement",
+			sample:     stats(1000, 995, 0),
+			base:       stats(1000, 900, 0),
+			wantStatus: crtype.SignificantImprovement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compareTestResults(tt.sample, tt.base, opts)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantRegressed, result.Regressed)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/util"
+)
+
+const (
+	windowCurrent  = "current"
+	windowPrevious = "previous"
+)
+
+// ErrUnknownExplainWindow is returned when explaining a window other than current or previous, a client error.
+var ErrUnknownExplainWindow = errors.New("unknown window, must be current or previous")
+
+// ExplainTestReport lists the job runs counted in a test's results for the current or previous window of the test
+// report. The report itself is computed when the matviews refresh, so counts may briefly differ from it for
+// runs imported since.
+func ExplainTestReport(dbc *db.DB, release, test, period, window string, filters *filter.Filter, reportEnd time.Time) (apitype.TestReportExplanation, error) {
+	if period == "" {
+		period = "default"
+	}
+	if window == "" {
+		window = windowCurrent
+	}
+
+	start, boundary, end := util.PeriodToDates(period, reportEnd)
+	switch window {
+	case windowCurrent:
+		start = boundary
+	case windowPrevious:
+		end = boundary
+	default:
+		return apitype.TestReportExplanation{}, fmt.Errorf("%w: %q", ErrUnknownExplainWindow, window)
+	}
+
+	contributions, err := query.TestReportContributions(dbc, release, test, jobRunScopeFromFilter(filters), start, end)
+	if err != nil {
+		return apitype.TestReportExplanation{}, err
+	}
+
+	explanation := apitype.TestReportExplanation{
+		Name:    test,
+		Release: release,
+		Period:  period,
+		Window:  window,
+		Start:   start,
+		End:     end,
+		JobRuns: contributions,
+	}
+	tallyTestReportContributions(&explanation)
+	return explanation, nil
+}
+
+// tallyTestReportContributions computes the counts and percentages the same way as the test report.
+func tallyTestReportContributions(explanation *apitype.TestReportExplanation) {
+	for _, c := range explanation.JobRuns {
+		if c.Suppressed {
+			continue
+		}
+		if sippyprocessingv1.TestStatus(c.Status) == sippyprocessingv1.TestStatusTimeout {
+			explanation.Timeouts++
+			continue
+		}
+		explanation.Runs++
+		switch sippyprocessingv1.TestStatus(c.Status) {
+		case sippyprocessingv1.TestStatusSuccess:
+			explanation.Successes++
+		case sippyprocessingv1.TestStatusFailure:
+			explanation.Failures++
+		case sippyprocessingv1.TestStatusFlake:
+			explanation.Flakes++
+		}
+	}
+	if explanation.Runs > 0 {
+		explanation.PassPercentage = float64(explanation.Successes) * 100 / float64(explanation.Runs)
+		explanation.WorkingPercentage = float64(explanation.Successes+explanation.Flakes) * 100 / float64(explanation.Runs)
+	}
+}
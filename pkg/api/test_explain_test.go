@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestTallyTestReportContributions(t *testing.T) {
+	explanation := apitype.TestReportExplanation{
+		JobRuns: []apitype.TestReportContribution{
+			{ProwJobRunID: 1, Status: 1},
+			{ProwJobRunID: 2, Status: 1},
+			{ProwJobRunID: 3, Status: 13},
+			{ProwJobRunID: 4, Status: 12},
+			{ProwJobRunID: 5, Status: 12, Suppressed: true},
+			{ProwJobRunID: 6, Status: 14},
+		},
+	}
+	tallyTestReportContributions(&explanation)
+
+	assert.Equal(t, 4, explanation.Runs)
+	assert.Equal(t, 2, explanation.Successes)
+	assert.Equal(t, 1, explanation.Flakes)
+	assert.Equal(t, 1, explanation.Failures)
+	assert.Equal(t, 1, explanation.Timeouts)
+	assert.Equal(t, 50.0, explanation.PassPercentage)
+	assert.Equal(t, 75.0, explanation.WorkingPercentage)
+}
+
+func TestTallyTestReportContributionsNoRuns(t *testing.T) {
+	explanation := apitype.TestReportExplanation{}
+	tallyTestReportContributions(&explanation)
+	assert.Zero(t, explanation.Runs)
+	assert.Zero(t, explanation.PassPercentage)
+}
+
+func TestExplainTestReportUnknownWindow(t *testing.T) {
+	_, err := ExplainTestReport(nil, "4.16", "test", "default", "next", nil, time.Now())
+	assert.ErrorIs(t, err, ErrUnknownExplainWindow)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+const (
+	// failureGroupOutputs is how many of the most recent failures are grouped.
+	failureGroupOutputs = 1000
+	// failureGroupExamples is how many example failures are returned for each group.
+	failureGroupExamples = 3
+	// maxSignatureLength caps the signature, long messages rarely differ usefully past this point.
+	maxSignatureLength = 300
+)
+
+// failureSignatureReplacements strip details that vary from run to run out of failure messages, most
+// specific first so for example a UUID is not mangled by the number replacement.
+var failureSignatureReplacements = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`(?i)\b(0x[0-9a-f]+|[0-9a-f]{7,})\b`), "<hex>"},
+	// Random suffixes kubernetes adds to generated names, such as the pod in e2e-test-7d9c4fb5d8-x7k2p. The
+	// alphabet used has no vowels, so ordinary words are left alone.
+	{regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{9,10}-`), "-<id>-"},
+	{regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}\b`), "-<id>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<n>"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+// FailureSignature normalizes a test failure into a signature that is shared by failures with the same cause.
+// The junit message is used if present, otherwise the first line of output.
+func FailureSignature(message, output string) string {
+	text := strings.TrimSpace(message)
+	if text == "" {
+		text = strings.TrimSpace(output)
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[:i]
+		}
+	}
+	for _, r := range failureSignatureReplacements {
+		text = r.re.ReplaceAllString(text, r.replacement)
+	}
+	text = strings.TrimSpace(text)
+	if len(text) > maxSignatureLength {
+		text = strings.ToValidUTF8(text[:maxSignatureLength], "")
+	}
+	return text
+}
+
+// GetTestFailureGroupsFromDB groups the recent failures of a test by their failure signature.
+func GetTestFailureGroupsFromDB(dbc *db.DB, release, test, search string, filters *filter.Filter) ([]apitype.TestFailureGroup, error) {
+	outputs, err := query.TestFailureOutputs(dbc, release, test, search, jobRunScopeFromFilter(filters), failureGroupOutputs)
+	if err != nil {
+		return nil, err
+	}
+	return groupTestFailures(outputs), nil
+}
+
+// groupTestFailures groups failures, which must be ordered newest first, with the largest groups first.
+func groupTestFailures(outputs []apitype.TestFailureOutput) []apitype.TestFailureGroup {
+	groups := []*apitype.TestFailureGroup{}
+	bySignature := map[string]*apitype.TestFailureGroup{}
+	jobs := map[string]map[string]bool{}
+
+	for _, o := range outputs {
+		signature := FailureSignature(o.Message, o.Output)
+		group, ok := bySignature[signature]
+		if !ok {
+			group = &apitype.TestFailureGroup{
+				Signature: signature,
+				Jobs:      []string{},
+				LastSeen:  o.Timestamp,
+				FirstSeen: o.Timestamp,
+			}
+			bySignature[signature] = group
+			jobs[signature] = map[string]bool{}
+			groups = append(groups, group)
+		}
+
+		group.Count++
+		if o.Timestamp.Before(group.FirstSeen) {
+			group.FirstSeen = o.Timestamp
+		}
+		if o.Timestamp.After(group.LastSeen) {
+			group.LastSeen = o.Timestamp
+		}
+		if !jobs[signature][o.ProwJobName] {
+			jobs[signature][o.ProwJobName] = true
+			group.Jobs = append(group.Jobs, o.ProwJobName)
+		}
+		if len(group.Examples) < failureGroupExamples {
+			group.Examples = append(group.Examples, o)
+		}
+	}
+
+	results := make([]apitype.TestFailureGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Jobs)
+		results = append(results, *g)
+	}
+	// Stable so groups of the same size keep the order of their most recent failure
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Count > results[j].Count
+	})
+	return results
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestFailureSignature(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		output   string
+		expected string
+	}{
+		{
+			name:     "numbers and durations",
+			message:  "timed out after 300.5s waiting for 3 nodes",
+			expected: "timed out after <n>s waiting for <n> nodes",
+		},
+		{
+			name:     "generated names",
+			message:  `pod "e2e-test-7d9c4fb5d8-x7k2p" in namespace e2e-ns-hq2xz failed`,
+			expected: `pod "e<n>e-test-<hex>-<id>" in namespace e<n>e-ns-<id> failed`,
+		},
+		{
+			name:     "addresses, uuids and times",
+			message:  "dial tcp 10.0.12.4:6443 at 2024-03-01T10:11:12Z for cluster 123e4567-e89b-12d3-a456-426614174000",
+			expected: "dial tcp <ip> at <time> for cluster <uuid>",
+		},
+		{
+			name:     "ordinary words are kept",
+			message:  "worker-nodes not ready",
+			expected: "worker-nodes not ready",
+		},
+		{
+			name:     "first line of output without a message",
+			output:   "  fail [foo.go:12]: expected 1 got 2\nstack trace\n",
+			expected: "fail [foo.go:<n>]: expected <n> got <n>",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FailureSignature(tc.message, tc.output))
+		})
+	}
+}
+
+func TestGroupTestFailures(t *testing.T) {
+	now := time.Now()
+	failure := func(job, message string, age time.Duration) apitype.TestFailureOutput {
+		return apitype.TestFailureOutput{
+			TestOutput:  apitype.TestOutput{URL: "https://prow/" + job, Message: message},
+			ProwJobName: job,
+			Timestamp:   now.Add(-age),
+		}
+	}
+
+	groups := groupTestFailures([]apitype.TestFailureOutput{
+		failure("job-a", "connection refused to 10.0.0.1:443", time.Hour),
+		failure("job-b", "image pull failed", 2*time.Hour),
+		failure("job-b", "connection refused to 10.0.0.2:443", 3*time.Hour),
+		failure("job-a", "connection refused to 10.0.0.3:443", 4*time.Hour),
+		failure("job-c", "connection refused to 10.0.0.4:443", 5*time.Hour),
+		failure("job-c", "connection refused to 10.0.0.5:443", 6*time.Hour),
+	})
+
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "connection refused to <ip>", groups[0].Signature)
+	assert.Equal(t, 5, groups[0].Count)
+	assert.Equal(t, []string{"job-a", "job-b", "job-c"}, groups[0].Jobs)
+	assert.Equal(t, now.Add(-6*time.Hour), groups[0].FirstSeen)
+	assert.Equal(t, now.Add(-time.Hour), groups[0].LastSeen)
+	assert.Len(t, groups[0].Examples, failureGroupExamples)
+	assert.Equal(t, "connection refused to 10.0.0.1:443", groups[0].Examples[0].Message)
+
+	assert.Equal(t, "image pull failed", groups[1].Signature)
+	assert.Equal(t, 1, groups[1].Count)
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+// PrintTestGroupsJSONFromDB responds with the pass rates of the release's tests combined by sig or feature, so a
+// sig's health can be seen without filtering test names.
+func PrintTestGroupsJSONFromDB(w http.ResponseWriter, dbc *db.DB, release, period, groupBy string) {
+	reports, err := GetTestGroupReportsFromDB(dbc, release, period, groupBy)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Error building test group report: "+err.Error())
+		return
+	}
+	RespondWithJSON(http.StatusOK, w, reports)
+}
+
+// GetTestGroupReportsFromDB combines the release's test results by query.TestGroupSig or query.TestGroupFeature.
+func GetTestGroupReportsFromDB(dbc *db.DB, release, period, groupBy string) ([]apitype.TestGroupReport, error) {
+	table := testReport7dMatView
+	if period == periodTwoDay {
+		table = testReport2dMatView
+	}
+	return query.TestGroupReports(dbc, table, release, groupBy, testidentification.DefaultExcludedVariants)
+}
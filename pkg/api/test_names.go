@@ -0,0 +1,28 @@
+package api
+
+import (
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// collapsedTestExamples is how many of the names collapsed into a test are listed.
+const collapsedTestExamples = 5
+
+// GetCollapsedTestsFromDB lists the tests the test name rules collapsed other names into, most collapsed first.
+func GetCollapsedTestsFromDB(dbc *db.DB, limit int) ([]apitype.CollapsedTest, error) {
+	rows, err := query.CollapsedTests(dbc, collapsedTestExamples, limit)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]apitype.CollapsedTest, 0, len(rows))
+	for _, r := range rows {
+		results = append(results, apitype.CollapsedTest{
+			Name:       r.Name,
+			Duplicates: r.Duplicates,
+			Rules:      r.Rules,
+			Examples:   r.Examples,
+		})
+	}
+	return results, nil
+}
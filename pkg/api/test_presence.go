@@ -0,0 +1,63 @@
+package api
+
+import (
+	"sort"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// GetTestPresenceDiffFromDB lists the tests that ran in baseRelease but not in release over the last two weeks, and
+// those new in release, with counts for each sig.
+func GetTestPresenceDiffFromDB(dbc *db.DB, release, baseRelease string) (apitype.TestPresenceDiff, error) {
+	tests, err := query.TestsInOneRelease(dbc, testReport7dMatView, release, baseRelease)
+	if err != nil {
+		return apitype.TestPresenceDiff{}, err
+	}
+	return diffTestPresence(release, baseRelease, tests), nil
+}
+
+// diffTestPresence splits the tests that ran in only one release into those removed from and added to release.
+// Tests are sorted by name, and sigs by most removed tests first.
+func diffTestPresence(release, baseRelease string, tests []apitype.TestPresence) apitype.TestPresenceDiff {
+	diff := apitype.TestPresenceDiff{
+		Release:     release,
+		BaseRelease: baseRelease,
+		Removed:     []apitype.TestPresence{},
+		Added:       []apitype.TestPresence{},
+		Sigs:        []apitype.TestPresenceSig{},
+	}
+	sigs := map[string]*apitype.TestPresenceSig{}
+	for _, t := range tests {
+		sig, ok := sigs[t.Sig]
+		if !ok {
+			sig = &apitype.TestPresenceSig{Sig: t.Sig}
+			sigs[t.Sig] = sig
+		}
+		switch t.Release {
+		case baseRelease:
+			diff.Removed = append(diff.Removed, t)
+			sig.Removed++
+		case release:
+			diff.Added = append(diff.Added, t)
+			sig.Added++
+		}
+	}
+
+	byName := func(tests []apitype.TestPresence) {
+		sort.Slice(tests, func(i, j int) bool { return tests[i].Name < tests[j].Name })
+	}
+	byName(diff.Removed)
+	byName(diff.Added)
+	for _, sig := range sigs {
+		diff.Sigs = append(diff.Sigs, *sig)
+	}
+	sort.Slice(diff.Sigs, func(i, j int) bool {
+		if diff.Sigs[i].Removed != diff.Sigs[j].Removed {
+			return diff.Sigs[i].Removed > diff.Sigs[j].Removed
+		}
+		return diff.Sigs[i].Sig < diff.Sigs[j].Sig
+	})
+	return diff
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestDiffTestPresence(t *testing.T) {
+	tests := []apitype.TestPresence{
+		{Name: "[sig-network] services should work", Sig: "sig-network", Release: "4.15", Runs: 120},
+		{Name: "[sig-network] pods should be reachable", Sig: "sig-network", Release: "4.15", Runs: 80},
+		{Name: "[sig-storage] volumes should mount", Sig: "sig-storage", Release: "4.15", Runs: 40},
+		{Name: "[sig-storage] snapshots should restore", Sig: "sig-storage", Release: "4.16", Runs: 30},
+		{Name: "install should succeed", Release: "4.16", Runs: 300},
+	}
+
+	diff := diffTestPresence("4.16", "4.15", tests)
+	assert.Equal(t, "4.16", diff.Release)
+	assert.Equal(t, "4.15", diff.BaseRelease)
+	assert.Equal(t, []string{
+		"[sig-network] pods should be reachable",
+		"[sig-network] services should work",
+		"[sig-storage] volumes should mount",
+	}, presenceNames(diff.Removed))
+	assert.Equal(t, []string{"[sig-storage] snapshots should restore", "install should succeed"}, presenceNames(diff.Added))
+	assert.Equal(t, []apitype.TestPresenceSig{
+		{Sig: "sig-network", Removed: 2},
+		{Sig: "sig-storage", Removed: 1, Added: 1},
+		{Sig: "", Added: 1},
+	}, diff.Sigs)
+
+	empty := diffTestPresence("4.16", "4.15", nil)
+	assert.Empty(t, empty.Removed)
+	assert.NotNil(t, empty.Removed, "empty lists are returned rather than null")
+	assert.NotNil(t, empty.Sigs)
+}
+
+func presenceNames(tests []apitype.TestPresence) []string {
+	names := []string{}
+	for _, t := range tests {
+		names = append(names, t.Name)
+	}
+	return names
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+// GetTestReleaseMatrixFromDB returns a test's current period results in every release, overall and for each
+// variant of the jobs matching the variant filters in fil, to show whether a problem is new in the latest release.
+func GetTestReleaseMatrixFromDB(dbc *db.DB, test, period string, fil *filter.Filter) (apitype.TestReleaseMatrix, error) {
+	if period == "" {
+		period = "default"
+	}
+	table := testReport7dMatView
+	if period == periodTwoDay {
+		table = testReport2dMatView
+	}
+
+	include, exclude := variantFilters(fil)
+	overall, err := query.TestResultsAcrossReleases(dbc, table, test, include, exclude, false)
+	if err != nil {
+		return apitype.TestReleaseMatrix{}, err
+	}
+	byVariant, err := query.TestResultsAcrossReleases(dbc, table, test, include, exclude, true)
+	if err != nil {
+		return apitype.TestReleaseMatrix{}, err
+	}
+
+	matrix := buildTestReleaseMatrix(overall, byVariant)
+	matrix.TestName = test
+	matrix.Period = period
+	return matrix, nil
+}
+
+// buildTestReleaseMatrix lays out the results in rows with a cell for every release the test ran in, filling the
+// gaps with empty results.
+func buildTestReleaseMatrix(overall, byVariant []apitype.TestReleaseResult) apitype.TestReleaseMatrix {
+	seen := map[string]bool{}
+	releases := []string{}
+	for _, r := range overall {
+		if !seen[r.Release] {
+			seen[r.Release] = true
+			releases = append(releases, r.Release)
+		}
+	}
+	sortReleasesNewestFirst(releases)
+
+	row := func(results []apitype.TestReleaseResult) []apitype.TestReleaseResult {
+		byRelease := map[string]apitype.TestReleaseResult{}
+		for _, r := range results {
+			byRelease[r.Release] = r
+		}
+		cells := make([]apitype.TestReleaseResult, 0, len(releases))
+		for _, release := range releases {
+			cell := byRelease[release]
+			cell.Release = release
+			cell.PassPercentage = percentOf(cell.Successes, cell.Runs)
+			cell.FlakePercentage = percentOf(cell.Flakes, cell.Runs)
+			cells = append(cells, cell)
+		}
+		return cells
+	}
+
+	variants := map[string][]apitype.TestReleaseResult{}
+	for _, r := range byVariant {
+		variants[r.Variant] = append(variants[r.Variant], r)
+	}
+	matrix := apitype.TestReleaseMatrix{
+		Releases: releases,
+		Overall:  row(overall),
+		Variants: make([]apitype.TestReleaseMatrixRow, 0, len(variants)),
+	}
+	for variant, results := range variants {
+		matrix.Variants = append(matrix.Variants, apitype.TestReleaseMatrixRow{Variant: variant, Results: row(results)})
+	}
+	sort.Slice(matrix.Variants, func(i, j int) bool {
+		return matrix.Variants[i].Variant < matrix.Variants[j].Variant
+	})
+	return matrix
+}
+
+// sortReleasesNewestFirst sorts X.Y releases in descending version order, followed by any others, such as
+// Presubmits, by name.
+func sortReleasesNewestFirst(releases []string) {
+	version := func(release string) (int, int, bool) {
+		m := minorReleaseRegexp.FindStringSubmatch(release)
+		if m == nil {
+			return 0, 0, false
+		}
+		major, _ := strconv.Atoi(m[1])
+		minor, _ := strconv.Atoi(m[2])
+		return major, minor, true
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		iMajor, iMinor, iOK := version(releases[i])
+		jMajor, jMinor, jOK := version(releases[j])
+		switch {
+		case iOK != jOK:
+			return iOK
+		case !iOK:
+			return releases[i] < releases[j]
+		case iMajor != jMajor:
+			return iMajor > jMajor
+		}
+		return iMinor > jMinor
+	})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestBuildTestReleaseMatrix(t *testing.T) {
+	overall := []apitype.TestReleaseResult{
+		{Release: "4.15", Runs: 10, Successes: 10},
+		{Release: "4.16", Runs: 20, Successes: 10, Flakes: 5, Failures: 5},
+		{Release: "4.9", Runs: 4, Successes: 4},
+	}
+	byVariant := []apitype.TestReleaseResult{
+		{Release: "4.16", Variant: "ovn", Runs: 20, Successes: 10, Flakes: 5, Failures: 5},
+		{Release: "4.15", Variant: "ovn", Runs: 10, Successes: 10},
+		{Release: "4.16", Variant: "aws", Runs: 10, Successes: 5, Failures: 5},
+		{Release: "4.9", Variant: "aws", Runs: 4, Successes: 4},
+	}
+
+	matrix := buildTestReleaseMatrix(overall, byVariant)
+
+	assert.Equal(t, []string{"4.16", "4.15", "4.9"}, matrix.Releases)
+	assert.Equal(t, []apitype.TestReleaseResult{
+		{Release: "4.16", Runs: 20, Successes: 10, Flakes: 5, Failures: 5, PassPercentage: 50, FlakePercentage: 25},
+		{Release: "4.15", Runs: 10, Successes: 10, PassPercentage: 100},
+		{Release: "4.9", Runs: 4, Successes: 4, PassPercentage: 100},
+	}, matrix.Overall)
+	assert.Equal(t, []apitype.TestReleaseMatrixRow{
+		{Variant: "aws", Results: []apitype.TestReleaseResult{
+			{Release: "4.16", Variant: "aws", Runs: 10, Successes: 5, Failures: 5, PassPercentage: 50},
+			{Release: "4.15"},
+			{Release: "4.9", Variant: "aws", Runs: 4, Successes: 4, PassPercentage: 100},
+		}},
+		{Variant: "ovn", Results: []apitype.TestReleaseResult{
+			{Release: "4.16", Variant: "ovn", Runs: 20, Successes: 10, Flakes: 5, Failures: 5, PassPercentage: 50, FlakePercentage: 25},
+			{Release: "4.15", Variant: "ovn", Runs: 10, Successes: 10, PassPercentage: 100},
+			{Release: "4.9"},
+		}},
+	}, matrix.Variants)
+}
+
+func TestSortReleasesNewestFirst(t *testing.T) {
+	releases := []string{"Presubmits", "4.9", "4.16", "5.0", "4.15", "OKD"}
+	sortReleasesNewestFirst(releases)
+	assert.Equal(t, []string{"5.0", "4.16", "4.15", "4.9", "OKD", "Presubmits"}, releases)
+}
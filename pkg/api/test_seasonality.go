@@ -0,0 +1,128 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	// seasonalityWindow is how far back failures are analyzed, four weeks gives four samples of each weekday.
+	seasonalityWindow = 28 * 24 * time.Hour
+	// seasonalityPeakHours is the width of the window of consecutive hours failures are checked to concentrate in.
+	seasonalityPeakHours = 3
+	// seasonalityPeakRatio is how many times the overall failure rate the peak must reach to be seasonal.
+	seasonalityPeakRatio = 2.0
+	// seasonalityMinPeakFailures and seasonalityMinPeakRuns keep a handful of failures from looking seasonal.
+	seasonalityMinPeakFailures = 5
+	seasonalityMinPeakRuns     = 20
+	// seasonalityMinFailures is how often a test must have failed to be considered when listing seasonal tests.
+	seasonalityMinFailures = 20
+)
+
+// GetTestSeasonalityFromDB breaks down a test's failure rate in release over the last four weeks by hour of day
+// and day of the week.
+func GetTestSeasonalityFromDB(dbc *db.DB, release, test string, reportEnd time.Time) (apitype.TestSeasonality, error) {
+	cells, err := query.TestFailuresByHour(dbc, release, test, 0, reportEnd.Add(-seasonalityWindow), reportEnd)
+	if err != nil {
+		return apitype.TestSeasonality{}, err
+	}
+	seasonality := analyzeSeasonality(cells)
+	seasonality.TestName = test
+	return seasonality, nil
+}
+
+// GetSeasonalTestsFromDB lists the tests in release whose failures over the last four weeks concentrate in a few
+// hours of the day, most concentrated first.
+func GetSeasonalTestsFromDB(dbc *db.DB, release string, reportEnd time.Time) ([]apitype.TestSeasonality, error) {
+	cells, err := query.TestFailuresByHour(dbc, release, "", seasonalityMinFailures, reportEnd.Add(-seasonalityWindow), reportEnd)
+	if err != nil {
+		return nil, err
+	}
+	byTest := map[string][]query.TestFailureCell{}
+	for _, c := range cells {
+		byTest[c.TestName] = append(byTest[c.TestName], c)
+	}
+
+	results := []apitype.TestSeasonality{}
+	for name, testCells := range byTest {
+		seasonality := analyzeSeasonality(testCells)
+		if !seasonality.Seasonal {
+			continue
+		}
+		seasonality.TestName = name
+		results = append(results, seasonality)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].PeakRatio != results[j].PeakRatio {
+			return results[i].PeakRatio > results[j].PeakRatio
+		}
+		return results[i].TestName < results[j].TestName
+	})
+	return results, nil
+}
+
+// analyzeSeasonality totals the cells by hour and by day of the week, and finds the consecutive hours, wrapping
+// around midnight, with the highest failure rate.
+func analyzeSeasonality(cells []query.TestFailureCell) apitype.TestSeasonality {
+	result := apitype.TestSeasonality{
+		ByHour:      make([]apitype.SeasonalityBucket, 24),
+		ByDayOfWeek: make([]apitype.SeasonalityBucket, 7),
+		PeakHours:   []int{},
+	}
+	for i := range result.ByHour {
+		result.ByHour[i].Bucket = i
+	}
+	for i := range result.ByDayOfWeek {
+		result.ByDayOfWeek[i].Bucket = i
+	}
+	for _, c := range cells {
+		if c.Hour < 0 || c.Hour > 23 || c.DayOfWeek < 0 || c.DayOfWeek > 6 {
+			continue
+		}
+		result.Runs += c.Runs
+		result.Failures += c.Failures
+		result.ByHour[c.Hour].Runs += c.Runs
+		result.ByHour[c.Hour].Failures += c.Failures
+		result.ByDayOfWeek[c.DayOfWeek].Runs += c.Runs
+		result.ByDayOfWeek[c.DayOfWeek].Failures += c.Failures
+	}
+	result.FailurePercentage = percentOf(result.Failures, result.Runs)
+	for i := range result.ByHour {
+		result.ByHour[i].FailurePercentage = percentOf(result.ByHour[i].Failures, result.ByHour[i].Runs)
+	}
+	for i := range result.ByDayOfWeek {
+		result.ByDayOfWeek[i].FailurePercentage = percentOf(result.ByDayOfWeek[i].Failures, result.ByDayOfWeek[i].Runs)
+	}
+	if result.Failures == 0 {
+		return result
+	}
+
+	var peakStart, peakRuns, peakFailures int
+	peakRate := -1.0
+	for start := 0; start < 24; start++ {
+		runs, failures := 0, 0
+		for h := start; h < start+seasonalityPeakHours; h++ {
+			runs += result.ByHour[h%24].Runs
+			failures += result.ByHour[h%24].Failures
+		}
+		if runs == 0 {
+			continue
+		}
+		if rate := float64(failures) / float64(runs); rate > peakRate {
+			peakStart, peakRuns, peakFailures, peakRate = start, runs, failures, rate
+		}
+	}
+	overallRate := float64(result.Failures) / float64(result.Runs)
+	result.PeakRatio = peakRate / overallRate
+	if result.PeakRatio >= seasonalityPeakRatio && peakFailures >= seasonalityMinPeakFailures && peakRuns >= seasonalityMinPeakRuns {
+		result.Seasonal = true
+		for h := peakStart; h < peakStart+seasonalityPeakHours; h++ {
+			result.PeakHours = append(result.PeakHours, h%24)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// uniformCells returns cells with runs and failures in every hour of each day.
+func uniformCells(runs, failures int) []query.TestFailureCell {
+	var cells []query.TestFailureCell
+	for day := 0; day < 7; day++ {
+		for hour := 0; hour < 24; hour++ {
+			cells = append(cells, query.TestFailureCell{DayOfWeek: day, Hour: hour, Runs: runs, Failures: failures})
+		}
+	}
+	return cells
+}
+
+func TestAnalyzeSeasonality(t *testing.T) {
+	nightly := uniformCells(10, 0)
+	for i := range nightly {
+		if nightly[i].Hour == 23 || nightly[i].Hour <= 1 {
+			nightly[i].Failures = 4
+		} else if nightly[i].Hour == 12 {
+			nightly[i].Failures = 1
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cells         []query.TestFailureCell
+		wantSeasonal  bool
+		wantPeakHours []int
+		wantFailures  int
+	}{
+		{
+			name:          "no failures",
+			cells:         uniformCells(10, 0),
+			wantPeakHours: []int{},
+		},
+		{
+			name:          "failures spread evenly",
+			cells:         uniformCells(10, 1),
+			wantPeakHours: []int{},
+			wantFailures:  168,
+		},
+		{
+			name:          "failures around midnight",
+			cells:         nightly,
+			wantSeasonal:  true,
+			wantPeakHours: []int{23, 0, 1},
+			wantFailures:  91,
+		},
+		{
+			name:          "too few failures",
+			cells:         []query.TestFailureCell{{Hour: 3, Runs: 5, Failures: 2}, {Hour: 12, Runs: 50}},
+			wantPeakHours: []int{},
+			wantFailures:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzeSeasonality(tt.cells)
+			assert.Equal(t, tt.wantSeasonal, got.Seasonal)
+			assert.Equal(t, tt.wantPeakHours, got.PeakHours)
+			assert.Equal(t, tt.wantFailures, got.Failures)
+			assert.Len(t, got.ByHour, 24)
+			assert.Len(t, got.ByDayOfWeek, 7)
+		})
+	}
+}
@@ -1,12 +1,13 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
 	"net/http"
+	"regexp"
 	gosort "sort"
 	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -16,6 +17,9 @@ import (
 	"github.com/openshift/sippy/pkg/db/query"
 	"github.com/openshift/sippy/pkg/filter"
 	"github.com/openshift/sippy/pkg/html/installhtml"
+	"github.com/openshift/sippy/pkg/testidentification"
+	"github.com/openshift/sippy/pkg/util"
+	"github.com/openshift/sippy/pkg/util/pagination"
 	"github.com/openshift/sippy/pkg/util/param"
 )
 
@@ -28,44 +32,55 @@ const (
 func PrintTestsDetailsJSONFromDB(w http.ResponseWriter, release string, testSubstrings []string, dbc *db.DB) {
 	responseStr, err := installhtml.TestDetailTestsFromDB(dbc, release, testSubstrings)
 	if err != nil {
-		RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": err.Error()})
+		RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	RespondWithJSON(http.StatusOK, w, responseStr)
 }
 
 func GetTestOutputsFromDB(dbc *db.DB, release, test string, filters *filter.Filter, quantity int) ([]apitype.TestOutput, error) {
-	var includedVariants, excludedVariants []string
-	if filters != nil {
-		for _, f := range filters.Items {
-			if f.Field == "variants" {
-				if f.Not {
-					excludedVariants = append(excludedVariants, f.Value)
-				} else {
-					includedVariants = append(includedVariants, f.Value)
-				}
-			}
-		}
-	}
+	return query.TestOutputs(dbc, release, test, jobRunScopeFromFilter(filters), quantity)
+}
+
+// GetTestDurationsFromDB returns a test's average duration by day, optionally from a sample of the job runs.
+func GetTestDurationsFromDB(dbc *db.DB, release, test string, filters *filter.Filter, samplePercent int) (map[string]float64, error) {
+	scope := jobRunScopeFromFilter(filters)
+	scope.SamplePercent = samplePercent
+	return query.TestDurations(dbc, release, test, scope)
+}
 
-	return query.TestOutputs(dbc, release, test, includedVariants, excludedVariants, quantity)
+// GetTestBuildClustersFromDB returns the results of a test broken down by build cluster, so failures specific to
+// one cluster's infrastructure stand out. Like durations, it may be approximated from a sample of the job runs.
+func GetTestBuildClustersFromDB(dbc *db.DB, release, test string, filters *filter.Filter, samplePercent int) ([]apitype.TestBuildClusterResult, error) {
+	scope := jobRunScopeFromFilter(filters)
+	scope.SamplePercent = samplePercent
+	return query.TestResultsByBuildCluster(dbc, release, test, scope)
 }
 
-func GetTestDurationsFromDB(dbc *db.DB, release, test string, filters *filter.Filter) (map[string]float64, error) {
-	var includedVariants, excludedVariants []string
-	if filters != nil {
-		for _, f := range filters.Items {
-			if f.Field == "variants" {
-				if f.Not {
-					excludedVariants = append(excludedVariants, f.Value)
-				} else {
-					includedVariants = append(includedVariants, f.Value)
-				}
+// jobRunScopeFromFilter extracts the variants and cluster filter items, the only ones supported when querying
+// individual test results.
+func jobRunScopeFromFilter(filters *filter.Filter) query.JobRunScope {
+	scope := query.JobRunScope{}
+	if filters == nil {
+		return scope
+	}
+	for _, f := range filters.Items {
+		switch f.Field {
+		case "variants":
+			if f.Not {
+				scope.ExcludedVariants = append(scope.ExcludedVariants, f.Value)
+			} else {
+				scope.IncludedVariants = append(scope.IncludedVariants, f.Value)
+			}
+		case "cluster":
+			if f.Not {
+				scope.ExcludedClusters = append(scope.ExcludedClusters, f.Value)
+			} else {
+				scope.IncludedClusters = append(scope.IncludedClusters, f.Value)
 			}
 		}
 	}
-
-	return query.TestDurations(dbc, release, test, includedVariants, excludedVariants)
+	return scope
 }
 
 type testsAPIResult []apitype.Test
@@ -101,9 +116,51 @@ func (tests testsAPIResult) limit(req *http.Request) testsAPIResult {
 	return tests[:limit]
 }
 
-func PrintTestsJSONFromDB(release string, w http.ResponseWriter, req *http.Request, dbc *db.DB) {
-	var fil *filter.Filter
+// smoothingPriorRuns is how many runs the prior is worth when smoothing pass percentages.
+const smoothingPriorRuns = 10
+
+// smooth sets smoothed pass percentages, either shrinking each test towards the pass percentage of all the tests
+// with a beta prior ("bayes"), or setting the Wilson confidence interval around the raw percentage ("wilson").
+func (tests testsAPIResult) smooth(method string) testsAPIResult {
+	switch method {
+	case "bayes":
+		var currentSuccesses, currentRuns, previousSuccesses, previousRuns int
+		for _, t := range tests {
+			currentSuccesses += t.CurrentSuccesses
+			currentRuns += t.CurrentRuns
+			previousSuccesses += t.PreviousSuccesses
+			previousRuns += t.PreviousRuns
+		}
+		currentPrior := util.BetaSmoothedPercentage(currentSuccesses, currentRuns, 0, 0)
+		previousPrior := util.BetaSmoothedPercentage(previousSuccesses, previousRuns, 0, 0)
+		for i := range tests {
+			tests[i].CurrentPassPercentageSmoothed = util.BetaSmoothedPercentage(tests[i].CurrentSuccesses, tests[i].CurrentRuns, currentPrior, smoothingPriorRuns)
+			tests[i].PreviousPassPercentageSmoothed = util.BetaSmoothedPercentage(tests[i].PreviousSuccesses, tests[i].PreviousRuns, previousPrior, smoothingPriorRuns)
+		}
+	case "wilson":
+		for i := range tests {
+			tests[i].CurrentPassPercentageLower, tests[i].CurrentPassPercentageUpper = util.WilsonInterval(tests[i].CurrentSuccesses, tests[i].CurrentRuns, util.Z95)
+			tests[i].PreviousPassPercentageLower, tests[i].PreviousPassPercentageUpper = util.WilsonInterval(tests[i].PreviousSuccesses, tests[i].PreviousRuns, util.Z95)
+		}
+	}
+	return tests
+}
+
+// confident drops tests whose current pass percentage is too uncertain, i.e. its 95% confidence interval is wider
+// than maxInterval percentage points. Unlike a minimum number of runs this accounts for how consistent the results
+// are: a test that always passes needs fewer runs to be trusted than one that passes half the time.
+func (tests testsAPIResult) confident(maxInterval float64) testsAPIResult {
+	confident := make(testsAPIResult, 0, len(tests))
+	for _, t := range tests {
+		lower, upper := util.WilsonInterval(t.CurrentSuccesses, t.CurrentRuns, util.Z95)
+		if upper-lower <= maxInterval {
+			confident = append(confident, t)
+		}
+	}
+	return confident
+}
 
+func PrintTestsJSONFromDB(release string, w http.ResponseWriter, req *http.Request, dbc *db.DB, reportEnd time.Time) {
 	// Collapse means to produce an aggregated test result of all variant (NURP+ - network, upgrade, release, platform)
 	// combos. Uncollapsed results shows you the per-NURP+ result for each test (currently approx. 50,000 rows: filtering
 	// is advised)
@@ -119,38 +176,232 @@ func PrintTestsJSONFromDB(release string, w http.ResponseWriter, req *http.Reque
 		includeOverall, _ = strconv.ParseBool(overallStr)
 	}
 
-	queryFilter := req.URL.Query().Get("filter")
-	if queryFilter != "" {
-		fil = &filter.Filter{}
-		if err := json.Unmarshal([]byte(queryFilter), fil); err != nil {
-			RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": "Could not marshal query:" + err.Error()})
-			return
-		}
+	fil, err := filter.ExtractFilters(req)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return
 	}
 
 	// If requesting a two day report, we make the comparison between the last
 	// period (typically 7 days) and the last two days.
 	period := req.URL.Query().Get("period")
 	if period != "" && period != "default" && period != "current" && period != "twoDay" {
-		RespondWithJSON(http.StatusBadRequest, w, map[string]interface{}{"code": http.StatusBadRequest, "message": "Unknown period"})
+		RespondWithError(w, http.StatusBadRequest, "Unknown period")
 		return
 	}
 
-	testsResult, overall, err := BuildTestsResults(dbc, release, period, collapse, includeOverall, fil)
+	page, err := pagination.FromRequest(req)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building job report:" + err.Error()})
+		RespondWithError(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
 		return
 	}
 
-	testsResult = testsResult.sort(req).limit(req)
+	var maxInterval float64
+	if maxIntervalStr := param.SafeRead(req, "maxInterval"); maxIntervalStr != "" {
+		var err error
+		if maxInterval, err = strconv.ParseFloat(maxIntervalStr, 64); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid maxInterval: "+err.Error())
+			return
+		}
+	}
+
+	testsResult, overall, err := BuildTestsResults(dbc, release, period, collapse, includeOverall, fil, reportEnd)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Error building job report:"+err.Error())
+		return
+	}
+
+	if compareRelease := param.SafeRead(req, "compareRelease"); compareRelease != "" {
+		if compareRelease == "previous" {
+			if compareRelease = PreviousMinorRelease(release); compareRelease == "" {
+				RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("release %s has no previous release to compare with", release))
+				return
+			}
+		}
+		if testsResult, err = withCompareRelease(dbc, testsResult, compareRelease, period, collapse); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Error comparing with release "+compareRelease+": "+err.Error())
+			return
+		}
+	}
+
+	if req.URL.Query().Get("rollup") == "true" {
+		testsResult = testsResult.rollUp(!collapse)
+	}
+
+	if maxInterval > 0 {
+		testsResult = testsResult.confident(maxInterval)
+	}
+	testsResult = testsResult.smooth(param.SafeRead(req, "smoothing")).sort(req).limit(req)
 	if overall != nil {
 		testsResult = append([]apitype.Test{*overall}, testsResult...)
 	}
 
-	RespondWithJSON(http.StatusOK, w, testsResult)
+	RespondWithJSON(http.StatusOK, w, pagination.Slice(page, testsResult))
+}
+
+// PreviousMinorRelease returns the release before an X.Y release, or an empty string if there isn't one.
+func PreviousMinorRelease(release string) string {
+	m := minorReleaseRegexp.FindStringSubmatch(release)
+	if m == nil {
+		return ""
+	}
+	minor, _ := strconv.Atoi(m[2])
+	if minor == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s.%d", m[1], minor-1)
+}
+
+var minorReleaseRegexp = regexp.MustCompile(`^(\d+)\.(\d+)$`)
+
+// withCompareRelease adds each test's results over the same period in compareRelease, matching tests by name, or
+// when not collapsed by name, suite and variants.
+func withCompareRelease(dbc *db.DB, tests testsAPIResult, compareRelease, period string, collapse bool) (testsAPIResult, error) {
+	if len(tests) == 0 {
+		return tests, nil
+	}
+	seen := map[string]bool{}
+	names := make([]string, 0, len(tests))
+	for _, t := range tests {
+		if !seen[t.Name] {
+			seen[t.Name] = true
+			names = append(names, t.Name)
+		}
+	}
+	table := testReport7dMatView
+	if period == "twoDay" {
+		table = testReport2dMatView
+	}
+	others, err := query.TestReportsForRelease(dbc, table, compareRelease, names, !collapse)
+	if err != nil {
+		return nil, err
+	}
+	return tests.compareWith(compareRelease, others, !collapse), nil
+}
+
+// testKey identifies a test in the results, by name when collapsed or by name, suite and variants when not.
+func testKey(name string, t apitype.Test, byVariants bool) string {
+	if !byVariants {
+		return name
+	}
+	variants := append([]string{}, t.Variants...)
+	gosort.Strings(variants)
+	return strings.Join([]string{name, t.SuiteName, strings.Join(variants, ",")}, "|")
+}
+
+func (tests testsAPIResult) compareWith(compareRelease string, others []apitype.Test, byVariants bool) testsAPIResult {
+	byKey := make(map[string]apitype.Test, len(others))
+	for _, o := range others {
+		byKey[testKey(o.Name, o, byVariants)] = o
+	}
+	for i := range tests {
+		tests[i].CompareRelease = compareRelease
+		other, ok := byKey[testKey(tests[i].Name, tests[i], byVariants)]
+		if !ok || other.CurrentRuns == 0 {
+			continue
+		}
+		tests[i].CompareReleaseRuns = other.CurrentRuns
+		tests[i].CompareReleasePassPercentage = other.CurrentPassPercentage
+		tests[i].CompareReleaseFlakePercentage = other.CurrentFlakePercentage
+		if tests[i].CurrentRuns > 0 {
+			tests[i].NetImprovementFromCompareRelease = tests[i].CurrentPassPercentage - other.CurrentPassPercentage
+		}
+	}
+	return tests
+}
+
+// rollUp replaces subtests, such as the per-operator install tests, with a single result for their parent test
+// summing their results. A parent test that is reported itself is combined with its subtests.
+func (tests testsAPIResult) rollUp(byVariants bool) testsAPIResult {
+	rolledUp := make(testsAPIResult, 0, len(tests))
+	parents := map[string]int{}
+	for _, t := range tests {
+		name := t.Name
+		parent, isSubTest := testidentification.ParentTestName(t.Name)
+		if isSubTest {
+			name = parent
+		}
+		key := testKey(name, t, byVariants)
+		i, ok := parents[key]
+		if !ok {
+			if !isSubTest {
+				parents[key] = len(rolledUp)
+				rolledUp = append(rolledUp, t)
+				continue
+			}
+			parents[key] = len(rolledUp)
+			t.Name = parent
+			t.Hash = ""
+			t.SubTests = 1
+			rolledUp = append(rolledUp, t)
+			continue
+		}
+
+		p := &rolledUp[i]
+		if !isSubTest {
+			// The parent test's own identity replaces the placeholder made from its first subtest.
+			placeholder := *p
+			*p = t
+			p.SubTests = placeholder.SubTests
+			t = placeholder
+		} else {
+			p.SubTests++
+		}
+		compareRuns := p.CompareReleaseRuns + t.CompareReleaseRuns
+		if compareRuns > 0 {
+			p.CompareReleasePassPercentage = (p.CompareReleasePassPercentage*float64(p.CompareReleaseRuns) +
+				t.CompareReleasePassPercentage*float64(t.CompareReleaseRuns)) / float64(compareRuns)
+			p.CompareReleaseFlakePercentage = (p.CompareReleaseFlakePercentage*float64(p.CompareReleaseRuns) +
+				t.CompareReleaseFlakePercentage*float64(t.CompareReleaseRuns)) / float64(compareRuns)
+		}
+		p.CompareReleaseRuns = compareRuns
+		p.CurrentRuns += t.CurrentRuns
+		p.CurrentSuccesses += t.CurrentSuccesses
+		p.CurrentFailures += t.CurrentFailures
+		p.CurrentFlakes += t.CurrentFlakes
+		p.PreviousRuns += t.PreviousRuns
+		p.PreviousSuccesses += t.PreviousSuccesses
+		p.PreviousFailures += t.PreviousFailures
+		p.PreviousFlakes += t.PreviousFlakes
+		if t.OpenBugs > p.OpenBugs {
+			p.OpenBugs = t.OpenBugs
+		}
+		p.Suppressed = p.Suppressed || t.Suppressed
+	}
+
+	for i := range rolledUp {
+		if rolledUp[i].SubTests > 0 {
+			summarizeTest(&rolledUp[i])
+		}
+	}
+	return rolledUp
+}
+
+// summarizeTest recomputes a test's percentages from its counts, as the test report queries do.
+func summarizeTest(t *apitype.Test) {
+	t.CurrentPassPercentage = passPercentage(t.CurrentSuccesses, t.CurrentRuns)
+	t.CurrentFailurePercentage = passPercentage(t.CurrentFailures, t.CurrentRuns)
+	t.CurrentFlakePercentage = passPercentage(t.CurrentFlakes, t.CurrentRuns)
+	t.CurrentWorkingPercentage = passPercentage(t.CurrentSuccesses+t.CurrentFlakes, t.CurrentRuns)
+	t.PreviousPassPercentage = passPercentage(t.PreviousSuccesses, t.PreviousRuns)
+	t.PreviousFailurePercentage = passPercentage(t.PreviousFailures, t.PreviousRuns)
+	t.PreviousFlakePercentage = passPercentage(t.PreviousFlakes, t.PreviousRuns)
+	t.PreviousWorkingPercentage = passPercentage(t.PreviousSuccesses+t.PreviousFlakes, t.PreviousRuns)
+
+	t.NetFailureImprovement, t.NetFlakeImprovement, t.NetWorkingImprovement, t.NetImprovement = 0, 0, 0, 0
+	if t.CurrentRuns > 0 && t.PreviousRuns > 0 {
+		t.NetFailureImprovement = t.PreviousFailurePercentage - t.CurrentFailurePercentage
+		t.NetFlakeImprovement = t.PreviousFlakePercentage - t.CurrentFlakePercentage
+		t.NetWorkingImprovement = t.CurrentWorkingPercentage - t.PreviousWorkingPercentage
+		t.NetImprovement = t.CurrentPassPercentage - t.PreviousPassPercentage
+	}
+	t.NetImprovementFromCompareRelease = 0
+	if t.CurrentRuns > 0 && t.CompareReleaseRuns > 0 {
+		t.NetImprovementFromCompareRelease = t.CurrentPassPercentage - t.CompareReleasePassPercentage
+	}
 }
 
-func PrintCanaryTestsFromDB(release string, w http.ResponseWriter, dbc *db.DB) {
+func PrintCanaryTestsFromDB(release string, w http.ResponseWriter, dbc *db.DB, reportEnd time.Time) {
 	f := filter.Filter{
 		Items: []filter.FilterItem{
 			{
@@ -161,9 +412,9 @@ func PrintCanaryTestsFromDB(release string, w http.ResponseWriter, dbc *db.DB) {
 		},
 	}
 
-	results, _, err := BuildTestsResults(dbc, release, "default", true, false, &f)
+	results, _, err := BuildTestsResults(dbc, release, "default", true, false, &f, reportEnd)
 	if err != nil {
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Error building test report:" + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Error building test report:"+err.Error())
 		return
 	}
 
@@ -173,7 +424,7 @@ func PrintCanaryTestsFromDB(release string, w http.ResponseWriter, dbc *db.DB) {
 	}
 }
 
-func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOverall bool, fil *filter.Filter) (testsAPIResult, *apitype.Test, error) { //lint:ignore
+func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOverall bool, fil *filter.Filter, reportEnd time.Time) (testsAPIResult, *apitype.Test, error) { //lint:ignore
 	now := time.Now()
 
 	// Test results are generated by using two subqueries, which need to be filtered separately. Once during
@@ -181,7 +432,7 @@ func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOver
 	// assembled our final temporary table.
 	var rawFilter, processedFilter *filter.Filter
 	if fil != nil {
-		rawFilter, processedFilter = fil.Split([]string{"name", "variants"})
+		rawFilter, processedFilter = fil.Split([]string{"name", "hash", "variants"})
 	}
 
 	table := testReport7dMatView
@@ -196,7 +447,7 @@ func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOver
 	// Collapse groups the test results together -- otherwise we return the test results per-variant combo (NURP+)
 	variantSelect := ""
 	if collapse {
-		rawQuery = rawQuery.Select(`name,watchlist,jira_component,jira_component_id,` + query.QueryTestSummer).Group("name,watchlist,jira_component,jira_component_id")
+		rawQuery = rawQuery.Select(`name,hash,watchlist,jira_component,jira_component_id,` + query.QueryTestSummer).Group("name,hash,watchlist,jira_component,jira_component_id")
 	} else {
 		rawQuery = query.TestsByNURPAndStandardDeviation(dbc, release, table)
 		variantSelect = "suite_name, variants," +
@@ -213,7 +464,7 @@ func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOver
 	testReports := make([]apitype.Test, 0)
 	// FIXME: Add test id to matview, for now generate with ROW_NUMBER OVER
 	processedResults := dbc.DB.Table("(?) as results", rawQuery).
-		Select(`ROW_NUMBER() OVER() as id, watchlist, name, jira_component, jira_component_id,` + variantSelect + query.QueryTestSummarizer).
+		Select(`ROW_NUMBER() OVER() as id, watchlist, name, hash, jira_component, jira_component_id,` + variantSelect + query.QueryTestSummarizer).
 		Where("current_runs > 0 or previous_runs > 0")
 
 	finalResults := dbc.DB.Table("(?) as final_results", processedResults)
@@ -226,6 +477,7 @@ func BuildTestsResults(dbc *db.DB, release, period string, collapse, includeOver
 		log.WithError(finalResults.Error).Error("error querying test reports")
 		return []apitype.Test{}, nil, frr.Error
 	}
+	markSuppressedTests(dbc, testReports, reportEnd)
 
 	// Produce a special "overall" test that has a summary of all the selected tests.
 	var overallTest *apitype.Test
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+func TestJobRunScopeFromFilter(t *testing.T) {
+	filters := &filter.Filter{
+		Items: []filter.FilterItem{
+			{Field: "variants", Value: "aws"},
+			{Field: "variants", Not: true, Value: "upgrade"},
+			{Field: "cluster", Value: "build01"},
+			{Field: "cluster", Not: true, Value: "build02"},
+			{Field: "name", Value: "ignored"},
+		},
+	}
+
+	assert.Equal(t, query.JobRunScope{
+		IncludedVariants: []string{"aws"},
+		ExcludedVariants: []string{"upgrade"},
+		IncludedClusters: []string{"build01"},
+		ExcludedClusters: []string{"build02"},
+	}, jobRunScopeFromFilter(filters))
+	assert.Equal(t, query.JobRunScope{}, jobRunScopeFromFilter(nil))
+}
+
+func TestTestsSmoothing(t *testing.T) {
+	tests := testsAPIResult{
+		{Name: "few runs", CurrentSuccesses: 0, CurrentRuns: 2},
+		{Name: "many runs", CurrentSuccesses: 90, CurrentRuns: 98},
+	}
+
+	smoothed := append(testsAPIResult{}, tests...).smooth("bayes")
+	// The prior is the pass rate of all tests, 90 of 100 runs, so the test that failed twice stays close to it.
+	assert.InDelta(t, 75, smoothed[0].CurrentPassPercentageSmoothed, 0.01)
+	assert.InDelta(t, 91.67, smoothed[1].CurrentPassPercentageSmoothed, 0.01)
+	assert.Zero(t, smoothed[0].CurrentPassPercentageUpper)
+
+	wilson := append(testsAPIResult{}, tests...).smooth("wilson")
+	assert.Zero(t, wilson[0].CurrentPassPercentageLower)
+	assert.Greater(t, wilson[0].CurrentPassPercentageUpper, 50.0)
+	assert.Zero(t, wilson[0].CurrentPassPercentageSmoothed)
+
+	unsmoothed := append(testsAPIResult{}, tests...).smooth("")
+	assert.Zero(t, unsmoothed[1].CurrentPassPercentageSmoothed)
+	assert.Zero(t, unsmoothed[1].CurrentPassPercentageUpper)
+
+	confident := tests.confident(20)
+	assert.Len(t, confident, 1)
+	assert.Equal(t, "many runs", confident[0].Name)
+}
+
+func TestPreviousMinorRelease(t *testing.T) {
+	assert.Equal(t, "4.15", PreviousMinorRelease("4.16"))
+	assert.Equal(t, "4.9", PreviousMinorRelease("4.10"))
+	assert.Equal(t, "", PreviousMinorRelease("4.0"))
+	assert.Equal(t, "", PreviousMinorRelease("Presubmits"))
+}
+
+func TestTestsCompareWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		tests      testsAPIResult
+		others     []apitype.Test
+		byVariants bool
+		want       testsAPIResult
+	}{
+		{
+			name: "collapsed tests match by name",
+			tests: testsAPIResult{
+				{Name: "worse", CurrentRuns: 10, CurrentPassPercentage: 80},
+				{Name: "new", CurrentRuns: 10, CurrentPassPercentage: 100},
+			},
+			others: []apitype.Test{
+				{Name: "worse", CurrentRuns: 20, CurrentPassPercentage: 95, CurrentFlakePercentage: 5},
+			},
+			want: testsAPIResult{
+				{Name: "worse", CurrentRuns: 10, CurrentPassPercentage: 80, CompareRelease: "4.15", CompareReleaseRuns: 20,
+					CompareReleasePassPercentage: 95, CompareReleaseFlakePercentage: 5, NetImprovementFromCompareRelease: -15},
+				{Name: "new", CurrentRuns: 10, CurrentPassPercentage: 100, CompareRelease: "4.15"},
+			},
+		},
+		{
+			name:       "variants match regardless of order",
+			byVariants: true,
+			tests: testsAPIResult{
+				{Name: "test", SuiteName: "e2e", Variants: []string{"aws", "ovn"}, CurrentRuns: 10, CurrentPassPercentage: 90},
+				{Name: "test", SuiteName: "e2e", Variants: []string{"gcp", "ovn"}, CurrentRuns: 10, CurrentPassPercentage: 90},
+			},
+			others: []apitype.Test{
+				{Name: "test", SuiteName: "e2e", Variants: []string{"ovn", "aws"}, CurrentRuns: 4, CurrentPassPercentage: 50},
+				{Name: "test", SuiteName: "e2e", Variants: []string{"gcp", "sdn"}, CurrentRuns: 4, CurrentPassPercentage: 50},
+			},
+			want: testsAPIResult{
+				{Name: "test", SuiteName: "e2e", Variants: []string{"aws", "ovn"}, CurrentRuns: 10, CurrentPassPercentage: 90,
+					CompareRelease: "4.15", CompareReleaseRuns: 4, CompareReleasePassPercentage: 50, NetImprovementFromCompareRelease: 40},
+				{Name: "test", SuiteName: "e2e", Variants: []string{"gcp", "ovn"}, CurrentRuns: 10, CurrentPassPercentage: 90,
+					CompareRelease: "4.15"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tests.compareWith("4.15", tt.others, tt.byVariants))
+		})
+	}
+}
+
+func TestTestsRollUp(t *testing.T) {
+	tests := []struct {
+		name       string
+		tests      testsAPIResult
+		byVariants bool
+		want       testsAPIResult
+	}{
+		{
+			name: "subtests are summed into their parent",
+			tests: testsAPIResult{
+				{ID: 1, Name: "operator install etcd", Hash: "a", CurrentRuns: 10, CurrentSuccesses: 10, PreviousRuns: 10, PreviousSuccesses: 10},
+				{ID: 2, Name: "operator install dns", Hash: "b", CurrentRuns: 10, CurrentSuccesses: 5, CurrentFailures: 5, PreviousRuns: 10, PreviousSuccesses: 10},
+				{ID: 3, Name: "standalone", Hash: "c", CurrentRuns: 4, CurrentSuccesses: 4, CurrentPassPercentage: 100},
+			},
+			want: testsAPIResult{
+				{ID: 1, Name: "operator install", SubTests: 2,
+					CurrentRuns: 20, CurrentSuccesses: 15, CurrentFailures: 5, CurrentPassPercentage: 75, CurrentFailurePercentage: 25, CurrentWorkingPercentage: 75,
+					PreviousRuns: 20, PreviousSuccesses: 20, PreviousPassPercentage: 100, PreviousWorkingPercentage: 100,
+					NetFailureImprovement: -25, NetWorkingImprovement: -25, NetImprovement: -25},
+				{ID: 3, Name: "standalone", Hash: "c", CurrentRuns: 4, CurrentSuccesses: 4, CurrentPassPercentage: 100},
+			},
+		},
+		{
+			name: "a reported parent keeps its identity",
+			tests: testsAPIResult{
+				{ID: 1, Name: "TestRouter/reload", CurrentRuns: 2, CurrentSuccesses: 1, CurrentFlakes: 1},
+				{ID: 2, Name: "TestRouter", Hash: "p", JiraComponent: "Routing", CurrentRuns: 2, CurrentSuccesses: 2},
+			},
+			want: testsAPIResult{
+				{ID: 2, Name: "TestRouter", Hash: "p", JiraComponent: "Routing", SubTests: 1,
+					CurrentRuns: 4, CurrentSuccesses: 3, CurrentFlakes: 1, CurrentPassPercentage: 75, CurrentFlakePercentage: 25, CurrentWorkingPercentage: 100},
+			},
+		},
+		{
+			name:       "uncollapsed subtests roll up per variant combination",
+			byVariants: true,
+			tests: testsAPIResult{
+				{Name: "operator install etcd", Variants: []string{"aws"}, CurrentRuns: 1, CurrentSuccesses: 1},
+				{Name: "operator install dns", Variants: []string{"gcp"}, CurrentRuns: 1},
+			},
+			want: testsAPIResult{
+				{Name: "operator install", Variants: []string{"aws"}, SubTests: 1, CurrentRuns: 1, CurrentSuccesses: 1,
+					CurrentPassPercentage: 100, CurrentWorkingPercentage: 100},
+				{Name: "operator install", Variants: []string{"gcp"}, SubTests: 1, CurrentRuns: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tests.rollUp(tt.byVariants))
+		})
+	}
+}
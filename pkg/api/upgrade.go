@@ -17,6 +17,7 @@ func PrintUpgradeJSONReportFromDB(w http.ResponseWriter, req *http.Request, dbc
 
 	exactTestNames := sets.NewString(
 		testidentification.UpgradeTestName,
+		testidentification.UpgradeRollbackTestName,
 	)
 	testPrefixes := sets.NewString(
 		testidentification.OperatorUpgradePrefix, // "old" upgrade test
@@ -33,7 +34,7 @@ func PrintUpgradeJSONReportFromDB(w http.ResponseWriter, req *http.Request, dbc
 		exactTestNames, testPrefixes, testSubStrings, testidentification.DefaultExcludedVariants)
 	if err != nil {
 		log.WithError(err).Error("could not generate upgrade report")
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Could not generate install report: " + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Could not generate install report: "+err.Error())
 		return
 	}
 
@@ -48,7 +49,7 @@ func PrintUpgradeJSONReportFromDB(w http.ResponseWriter, req *http.Request, dbc
 	result, err := json.Marshal(summary)
 	if err != nil {
 		log.WithError(err).Error("could not generate install report")
-		RespondWithJSON(http.StatusInternalServerError, w, map[string]interface{}{"code": http.StatusInternalServerError, "message": "Could not generate install report: " + err.Error()})
+		RespondWithError(w, http.StatusInternalServerError, "Could not generate install report: "+err.Error())
 		return
 	}
 
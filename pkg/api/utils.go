@@ -78,41 +78,64 @@ func GetDataFromCacheOrGenerate[T any](
 			cacheDuration = now.Truncate(cacheOptions.CRTimeRoundingFactor).Add(cacheOptions.CRTimeRoundingFactor).Sub(now)
 		}
 
-		if !cacheOptions.ForceRefresh {
-			if res, err := c.Get(ctx, string(cacheKey), cacheDuration); err == nil {
-				log.WithFields(log.Fields{
-					"key":  string(cacheKey),
-					"type": reflect.TypeOf(defaultVal).String(),
-				}).Infof("cache hit")
-				var cr T
-				if err := json.Unmarshal(res, &cr); err != nil {
-					return defaultVal, []error{errors.WithMessagef(err, "failed to unmarshal cached item.  cacheKey=%+v", cacheKey)}
-				}
-				return cr, nil
+		// concurrent callers of the same key share one generation, which only one of them runs
+		var result T
+		var errs []error
+		generated := false
+		generate := func(ctx context.Context) ([]byte, error) {
+			result, errs = generateFn(ctx)
+			generated = true
+			if len(errs) > 0 {
+				return nil, generateErrors(errs)
 			}
-			log.WithFields(log.Fields{
-				"key": string(cacheKey),
-			}).Infof("cache miss")
-		}
-		result, errs := generateFn(ctx)
-		if len(errs) == 0 {
-			cr, err := json.Marshal(result)
-			if err == nil {
-				if err := c.Set(ctx, string(cacheKey), cr, cacheDuration); err != nil {
-					log.WithError(err).Warningf("couldn't persist new item to cache")
-				} else {
-					log.Debugf("cache set for cache key: %s", string(cacheKey))
-				}
-			} else {
+			return json.Marshal(result)
+		}
+
+		var content []byte
+		if cacheOptions.ForceRefresh {
+			content, err = cache.Refresh(ctx, c, string(cacheKey), cacheDuration, generate)
+		} else {
+			content, err = cache.GetOrSet(ctx, c, string(cacheKey), cacheDuration, generate)
+		}
+		if generated {
+			if err != nil && len(errs) == 0 {
 				log.WithError(err).Errorf("Failed to marshall cache item: %v", result)
 			}
+			return result, errs
+		}
+
+		var genErrs generateErrors
+		if errors.As(err, &genErrs) {
+			return defaultVal, genErrs
+		} else if err != nil {
+			return defaultVal, []error{err}
 		}
-		return result, errs
+		log.WithFields(log.Fields{
+			"key":  string(cacheKey),
+			"type": reflect.TypeOf(defaultVal).String(),
+		}).Infof("cache hit")
+
+		var cr T
+		if err := json.Unmarshal(content, &cr); err != nil {
+			return defaultVal, []error{errors.WithMessagef(err, "failed to unmarshal cached item.  cacheKey=%+v", cacheKey)}
+		}
+		return cr, nil
 	}
 
 	return generateFn(ctx)
 }
 
+// generateErrors carries the errors of a cached item's generation to every caller waiting on it.
+type generateErrors []error
+
+func (e generateErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
 // isStructWithNoPublicFields checks if the given interface is a struct with no public fields.
 func isStructWithNoPublicFields(v interface{}) bool {
 	val := reflect.ValueOf(v)
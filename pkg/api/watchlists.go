@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+	"github.com/openshift/sippy/pkg/notify"
+)
+
+const (
+	// maxWatchedTests caps how many tests a watchlist may have.
+	maxWatchedTests = 500
+	// watchlistRegressionPoints is how many percentage points a test's pass percentage must drop from the previous
+	// period to be reported as regressed.
+	watchlistRegressionPoints = 10.0
+	// watchlistRegressionMinRuns is how many runs a test needs in both periods to be reported as regressed.
+	watchlistRegressionMinRuns = 10
+	// maxDigestRegressions caps how many regressed tests a digest names.
+	maxDigestRegressions = 10
+)
+
+var (
+	// ErrWatchlistNotFound is returned for a watchlist ID that doesn't exist.
+	ErrWatchlistNotFound = errors.New("no watchlist")
+	// ErrWatchlistNotOwner is returned when changing or deleting a watchlist as someone other than its owner.
+	ErrWatchlistNotOwner = errors.New("watchlists can only be changed by their owner")
+)
+
+// digestIntervals are how often each kind of digest is sent. They are slightly short of a day or week so a digest
+// sent by a periodic load doesn't drift later each time.
+var digestIntervals = map[string]time.Duration{
+	models.WatchlistDigestDaily:  23 * time.Hour,
+	models.WatchlistDigestWeekly: 7*24*time.Hour - time.Hour,
+}
+
+// ValidateWatchlist checks a watchlist is well-formed before we store it.
+func ValidateWatchlist(w models.Watchlist) error {
+	if w.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if w.Owner == "" {
+		return fmt.Errorf("owner is required")
+	}
+	if w.Release == "" {
+		return fmt.Errorf("release is required")
+	}
+	if len(w.Tests) == 0 {
+		return fmt.Errorf("at least one test is required")
+	}
+	if len(w.Tests) > maxWatchedTests {
+		return fmt.Errorf("a watchlist may have at most %d tests", maxWatchedTests)
+	}
+	for _, t := range w.Tests {
+		if strings.TrimSpace(t) == "" {
+			return fmt.Errorf("test names can't be empty")
+		}
+	}
+	if _, ok := digestIntervals[w.Digest]; w.Digest != "" && !ok {
+		return fmt.Errorf("digest must be %s or %s", models.WatchlistDigestDaily, models.WatchlistDigestWeekly)
+	}
+	return nil
+}
+
+// CreateWatchlist validates and stores a watchlist.
+func CreateWatchlist(dbc *db.DB, w models.Watchlist) (models.Watchlist, error) {
+	w.ID = 0
+	w.LastDigestAt = nil
+	if err := ValidateWatchlist(w); err != nil {
+		return w, err
+	}
+	res := dbc.DB.Create(&w)
+	return w, res.Error
+}
+
+// UpdateWatchlist replaces the watchlist with id, which must belong to the same owner.
+func UpdateWatchlist(dbc *db.DB, id uint, w models.Watchlist) (models.Watchlist, error) {
+	existing, err := GetWatchlist(dbc, id)
+	if err != nil {
+		return w, err
+	}
+	if existing.Owner != w.Owner {
+		return w, ErrWatchlistNotOwner
+	}
+	w.Model = existing.Model
+	w.LastDigestAt = existing.LastDigestAt
+	if err := ValidateWatchlist(w); err != nil {
+		return w, err
+	}
+	res := dbc.DB.Save(&w)
+	return w, res.Error
+}
+
+// DeleteWatchlist removes the watchlist with id, which must belong to owner.
+func DeleteWatchlist(dbc *db.DB, id uint, owner string) error {
+	w, err := GetWatchlist(dbc, id)
+	if err != nil {
+		return err
+	}
+	if w.Owner != owner {
+		return ErrWatchlistNotOwner
+	}
+	return dbc.DB.Delete(&w).Error
+}
+
+// GetWatchlist returns the watchlist with id.
+func GetWatchlist(dbc *db.DB, id uint) (models.Watchlist, error) {
+	w := models.Watchlist{}
+	if res := dbc.DB.Limit(1).Find(&w, id); res.Error != nil {
+		return w, res.Error
+	} else if res.RowsAffected == 0 {
+		return w, fmt.Errorf("%w with id %d", ErrWatchlistNotFound, id)
+	}
+	return w, nil
+}
+
+// ListWatchlists lists the watchlists, optionally only those of owner, by name.
+func ListWatchlists(dbc *db.DB, owner string) ([]models.Watchlist, error) {
+	watchlists := make([]models.Watchlist, 0)
+	q := dbc.DB.Order("name")
+	if owner != "" {
+		q = q.Where("owner = ?", owner)
+	}
+	res := q.Find(&watchlists)
+	return watchlists, res.Error
+}
+
+// GetWatchlistReportFromDB returns the current results of the tests on the watchlist.
+func GetWatchlistReportFromDB(dbc *db.DB, w models.Watchlist) (apitype.WatchlistReport, error) {
+	results, err := query.TestReportsForRelease(dbc, testReport7dMatView, w.Release, w.Tests, false)
+	if err != nil {
+		return apitype.WatchlistReport{}, err
+	}
+	return watchlistReport(w, results), nil
+}
+
+// watchlistReport combines the results of the watched tests, listing those without results with no runs, regressed
+// tests first and then by name.
+func watchlistReport(w models.Watchlist, results []apitype.Test) apitype.WatchlistReport {
+	byName := map[string]apitype.Test{}
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	report := apitype.WatchlistReport{ID: w.ID, Name: w.Name, Release: w.Release, Tests: make([]apitype.WatchedTest, 0, len(w.Tests))}
+	seen := map[string]bool{}
+	for _, name := range w.Tests {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		t, ok := byName[name]
+		if !ok {
+			t = apitype.Test{Name: name}
+		}
+		regressed := t.CurrentRuns >= watchlistRegressionMinRuns && t.PreviousRuns >= watchlistRegressionMinRuns &&
+			t.PreviousPassPercentage-t.CurrentPassPercentage >= watchlistRegressionPoints
+		if regressed {
+			report.Regressions++
+		}
+		report.Tests = append(report.Tests, apitype.WatchedTest{Test: t, Regressed: regressed})
+	}
+	sort.SliceStable(report.Tests, func(i, j int) bool {
+		if report.Tests[i].Regressed != report.Tests[j].Regressed {
+			return report.Tests[i].Regressed
+		}
+		return report.Tests[i].Name < report.Tests[j].Name
+	})
+	return report
+}
+
+// watchlistDigest summarizes a watchlist report in a few lines of text.
+func watchlistDigest(report apitype.WatchlistReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Sippy watchlist %s: %d of %d tests regressed in %s", report.Name, report.Regressions,
+		len(report.Tests), report.Release)
+	for _, t := range report.Tests[:min(report.Regressions, maxDigestRegressions)] {
+		fmt.Fprintf(&sb, "\n- %s: %.1f%% passing, down from %.1f%%", t.Name, t.CurrentPassPercentage, t.PreviousPassPercentage)
+	}
+	if report.Regressions > maxDigestRegressions {
+		fmt.Fprintf(&sb, "\n- and %d more", report.Regressions-maxDigestRegressions)
+	}
+	return sb.String()
+}
+
+// digestDue returns true if a digest of the watchlist should be sent at now.
+func digestDue(w models.Watchlist, now time.Time) bool {
+	interval, ok := digestIntervals[w.Digest]
+	if !ok {
+		return false
+	}
+	return w.LastDigestAt == nil || now.Sub(*w.LastDigestAt) >= interval
+}
+
+// SendWatchlistDigests sends the digests due at now, each as a notification named after its watchlist so routes
+// can deliver each team's digest to their own channel.
+func SendWatchlistDigests(ctx context.Context, dbc *db.DB, notifier *notify.Notifier, now time.Time) error {
+	watchlists, err := ListWatchlists(dbc, "")
+	if err != nil {
+		return fmt.Errorf("error listing watchlists: %w", err)
+	}
+
+	var errs []error
+	for _, w := range watchlists {
+		if !digestDue(w, now) {
+			continue
+		}
+		wLog := log.WithField("watchlist", w.Name)
+		report, err := GetWatchlistReportFromDB(dbc, w)
+		if err != nil {
+			wLog.WithError(err).Error("error building watchlist digest")
+			errs = append(errs, err)
+			continue
+		}
+		severity := notify.SeverityInfo
+		if report.Regressions > 0 {
+			severity = notify.SeverityWarning
+		}
+		err = notifier.Notify(ctx, notify.Message{
+			Source:   notify.SourceWatchlists,
+			Name:     w.Name,
+			Severity: severity,
+			Summary:  watchlistDigest(report),
+			Details:  report,
+		})
+		if err != nil {
+			wLog.WithError(err).Error("error sending watchlist digest")
+			errs = append(errs, err)
+			continue
+		}
+		if res := dbc.DB.Model(&w).Update("last_digest_at", now); res.Error != nil {
+			errs = append(errs, res.Error)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d errors sending watchlist digests, see logs for details", len(errs))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestValidateWatchlist(t *testing.T) {
+	valid := models.Watchlist{
+		Name:    "sig-network-ovn",
+		Owner:   "network-team",
+		Release: "4.14",
+		Tests:   []string{"[sig-network] pods should have network connectivity"},
+		Digest:  models.WatchlistDigestDaily,
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(w *models.Watchlist)
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(w *models.Watchlist) {},
+		},
+		{
+			name:   "no digest",
+			mutate: func(w *models.Watchlist) { w.Digest = "" },
+		},
+		{
+			name:        "missing owner",
+			mutate:      func(w *models.Watchlist) { w.Owner = "" },
+			expectError: true,
+		},
+		{
+			name:        "no tests",
+			mutate:      func(w *models.Watchlist) { w.Tests = nil },
+			expectError: true,
+		},
+		{
+			name:        "empty test name",
+			mutate:      func(w *models.Watchlist) { w.Tests = append(w.Tests, " ") },
+			expectError: true,
+		},
+		{
+			name:        "unknown digest",
+			mutate:      func(w *models.Watchlist) { w.Digest = "hourly" },
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := valid
+			w.Tests = append([]string{}, valid.Tests...)
+			tc.mutate(&w)
+			err := ValidateWatchlist(w)
+			assert.Equal(t, tc.expectError, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestWatchlistReport(t *testing.T) {
+	w := models.Watchlist{Name: "sig-network-ovn", Release: "4.14", Tests: []string{"b", "a", "c", "d", "a"}}
+	results := []apitype.Test{
+		{Name: "a", CurrentRuns: 20, CurrentPassPercentage: 99, PreviousRuns: 20, PreviousPassPercentage: 100},
+		{Name: "b", CurrentRuns: 20, CurrentPassPercentage: 70, PreviousRuns: 20, PreviousPassPercentage: 95},
+		// too few runs to call it a regression
+		{Name: "c", CurrentRuns: 2, CurrentPassPercentage: 50, PreviousRuns: 20, PreviousPassPercentage: 100},
+	}
+
+	report := watchlistReport(w, results)
+	assert.Equal(t, 1, report.Regressions)
+	names := []string{}
+	for _, test := range report.Tests {
+		names = append(names, test.Name)
+	}
+	assert.Equal(t, []string{"b", "a", "c", "d"}, names)
+	assert.True(t, report.Tests[0].Regressed)
+	assert.Equal(t, 0, report.Tests[3].CurrentRuns)
+
+	assert.Equal(t, "Sippy watchlist sig-network-ovn: 1 of 4 tests regressed in 4.14\n- b: 70.0% passing, down from 95.0%",
+		watchlistDigest(report))
+}
+
+func TestDigestDue(t *testing.T) {
+	now := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	anHourAgo := now.Add(-time.Hour)
+
+	assert.False(t, digestDue(models.Watchlist{}, now), "no digest")
+	assert.True(t, digestDue(models.Watchlist{Digest: models.WatchlistDigestDaily}, now), "never sent")
+	assert.True(t, digestDue(models.Watchlist{Digest: models.WatchlistDigestDaily, LastDigestAt: &yesterday}, now))
+	assert.False(t, digestDue(models.Watchlist{Digest: models.WatchlistDigestDaily, LastDigestAt: &anHourAgo}, now))
+	assert.False(t, digestDue(models.Watchlist{Digest: models.WatchlistDigestWeekly, LastDigestAt: &yesterday}, now))
+}
@@ -102,6 +102,20 @@ type ViewMetrics struct {
 
 type ViewRegressionTracking struct {
 	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Detector decides which of the tests regressed in the view's report are tracked as regressions, by default
+	// all of them.
+	Detector RegressionDetectorConfig `json:"detector" yaml:"detector,omitempty"`
+	// ShadowDetectors are run alongside Detector and only log how their results differ, so a detector can be
+	// compared against the one in use before switching to it.
+	ShadowDetectors []RegressionDetectorConfig `json:"shadow_detectors,omitempty" yaml:"shadow_detectors,omitempty"`
+}
+
+type RegressionDetectorConfig struct {
+	// Name is fisher_exact, the default, or pass_rate_delta.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// MinPassRateDelta is how many percentage points below the basis the sample pass rate must be for
+	// pass_rate_delta to track a test.
+	MinPassRateDelta float64 `json:"min_pass_rate_delta,omitempty" yaml:"min_pass_rate_delta,omitempty"`
 }
 
 type RequestAdvancedOptions struct {
@@ -34,6 +34,36 @@ type EventIntervalList struct {
 	IntervalFilesAvailable []string        `json:"intervalFilesAvailable"`
 }
 
+// JobRunIntervalsDiff compares the intervals of two runs of a job, usually a passing BaseRunID and a failing RunID.
+// Each run's intervals are aligned on the run's first interval, and matched by what they're about rather than
+// their exact timing, so the intervals listed are those with no counterpart in the other run.
+type JobRunIntervalsDiff struct {
+	JobName    string            `json:"job_name"`
+	BaseRunID  int64             `json:"base_run_id"`
+	RunID      int64             `json:"run_id"`
+	OnlyInRun  []AlignedInterval `json:"only_in_run"`
+	OnlyInBase []AlignedInterval `json:"only_in_base"`
+	// Common is how many of the run's intervals have a counterpart in the base run.
+	Common int `json:"common"`
+}
+
+// AlignedInterval is an interval of a JobRunIntervalsDiff, with its start and duration in seconds relative to the
+// first interval of its run.
+type AlignedInterval struct {
+	EventInterval
+	Offset   float64 `json:"offset"`
+	Duration float64 `json:"duration"`
+}
+
+// LiveJobRunUpdate is sent while tailing an in-progress job run, with the intervals and test failures that
+// appeared in its artifacts since the previous update. The last update of a run has Finished set.
+type LiveJobRunUpdate struct {
+	Time        time.Time       `json:"time"`
+	Intervals   []EventInterval `json:"intervals"`
+	FailedTests []string        `json:"failed_tests"`
+	Finished    bool            `json:"finished"`
+}
+
 // LegacyEventInterval is the previous temporary schema we used before we completed the port to the new API.
 // We fall back to using this if we cannot parse the new schema (because locator/message are still strings in that file),
 // then convert to the new format and return from the API.
@@ -41,13 +41,10 @@ type PaginationResult struct {
 	PageSize  int         `json:"page_size"`
 	Page      int         `json:"page"`
 	TotalRows int64       `json:"total_rows"`
-}
-
-// Pagination is a type used to request specific per-page and offset values
-// in an API request.
-type Pagination struct {
-	PerPage int `json:"per_page"`
-	Page    int `json:"page"`
+	// TotalEstimated is true when TotalRows was counted for the first page and not again, so may be out of date.
+	TotalEstimated bool `json:"total_estimated,omitempty"`
+	// NextCursor is passed as the cursor param to get the next page, empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type Repository struct {
@@ -131,6 +128,84 @@ type PullRequest struct {
 	FirstNightlyPayloadRelease string `json:"first_nightly_payload_release"`
 }
 
+// PullRequestFailureRate is the presubmit failure rate for a repository's pull requests, or for one author's
+// pull requests to it, during a week.
+type PullRequestFailureRate struct {
+	Org    string    `json:"org,omitempty"`
+	Repo   string    `json:"repo,omitempty"`
+	Author string    `json:"author,omitempty"`
+	Week   time.Time `json:"week"`
+	Runs   int       `json:"runs"`
+	// Failures is the number of runs that failed. Aborted runs are not counted.
+	Failures          int     `json:"failures"`
+	FailurePercentage float64 `json:"failure_percentage" gorm:"-"`
+	// BaselineFailurePercentage is the failure rate across all pull requests in the release that week.
+	BaselineFailurePercentage float64 `json:"baseline_failure_percentage" gorm:"-"`
+	// FailurePercentageDelta is FailurePercentage minus BaselineFailurePercentage; large positive values point
+	// to presubmits that are flakier than the org as a whole.
+	FailurePercentageDelta float64 `json:"failure_percentage_delta" gorm:"-"`
+}
+
+// ReportTemplate describes a configured report template and the parameters it takes.
+type ReportTemplate struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Params      []ReportTemplateParam `json:"params"`
+}
+
+// ReportTemplateParam is a parameter of a report template.
+type ReportTemplateParam struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Pattern  string `json:"pattern,omitempty"`
+	Required bool   `json:"required"`
+	Default  string `json:"default,omitempty"`
+}
+
+// ReportTemplateResult is the output of running a report template. Columns are in the order the query returned
+// them, and Truncated is set if there were more rows than the limit.
+type ReportTemplateResult struct {
+	Template  string                   `json:"template"`
+	Columns   []string                 `json:"columns"`
+	Rows      []map[string]interface{} `json:"rows"`
+	Truncated bool                     `json:"truncated"`
+}
+
+// FeatureFlag is an experimental feature and how widely it is rolled out.
+type FeatureFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// RolloutPercentage is the percentage of clients the feature is enabled for.
+	RolloutPercentage int `json:"rollout_percentage"`
+	// Source is where the rollout was configured: "default", "flag" for the server's command line, or "database".
+	Source string `json:"source"`
+	// Enabled is whether the feature is enabled for the client asking.
+	Enabled bool `json:"enabled"`
+}
+
+// JobRetestStats summarizes a presubmit job's reruns on the same pull request commit, the runs triggered by
+// /retest without any code change.
+type JobRetestStats struct {
+	Job string `json:"job"`
+	// Runs is how many times the job ran on pull requests. Aborted runs are not counted.
+	Runs int `json:"runs"`
+	// Commits is how many distinct pull request commits the job ran on.
+	Commits int `json:"commits"`
+	// Retests is how many runs were on a commit the job had already run on.
+	Retests          int     `json:"retests"`
+	RetestPercentage float64 `json:"retest_percentage" gorm:"-"`
+	// RetestsAfterFailure is how many retests followed a failed run, and RetestPasses how many of those passed.
+	RetestsAfterFailure  int     `json:"retests_after_failure"`
+	RetestPasses         int     `json:"retest_passes"`
+	RetestPassPercentage float64 `json:"retest_pass_percentage" gorm:"-"`
+	// MaskedFailureCommits is how many commits failed the job and then passed it on a retest; a high count points to
+	// flakiness that retesting hides.
+	MaskedFailureCommits int `json:"masked_failure_commits"`
+	// RetriedFailureHours is the total duration of the failed runs that were retested, compute spent only to be
+	// run again.
+	RetriedFailureHours float64 `json:"retried_failure_hours"`
+}
+
 func (pr PullRequest) GetFieldType(param string) ColumnType {
 	switch param {
 	case "id":
@@ -225,6 +300,8 @@ type Job struct {
 	BriefName string         `json:"brief_name"`
 	Variants  pq.StringArray `json:"variants" gorm:"type:text[]"`
 	LastPass  *time.Time     `json:"last_pass,omitempty"`
+	// Kind is periodic, presubmit or postsubmit.
+	Kind string `json:"kind"`
 
 	AverageRetestsToMerge          float64 `json:"average_retests_to_merge"`
 	CurrentPassPercentage          float64 `json:"current_pass_percentage"`
@@ -244,6 +321,95 @@ type Job struct {
 
 	TestGridURL string `json:"test_grid_url"`
 	OpenBugs    int    `json:"open_bugs"`
+
+	// Run duration percentiles in seconds for the current and previous periods.
+	CurrentDurationP50  float64 `json:"current_duration_p50,omitempty" gorm:"-"`
+	CurrentDurationP90  float64 `json:"current_duration_p90,omitempty" gorm:"-"`
+	CurrentDurationP95  float64 `json:"current_duration_p95,omitempty" gorm:"-"`
+	PreviousDurationP50 float64 `json:"previous_duration_p50,omitempty" gorm:"-"`
+
+	// Runs that succeeded only after one or more tests flaked, these are included in the passes above.
+	CurrentFlakyRuns           int     `json:"current_flaky_runs,omitempty" gorm:"-"`
+	CurrentFlakyRunPercentage  float64 `json:"current_flaky_run_percentage" gorm:"-"`
+	PreviousFlakyRuns          int     `json:"previous_flaky_runs,omitempty" gorm:"-"`
+	PreviousFlakyRunPercentage float64 `json:"previous_flaky_run_percentage" gorm:"-"`
+}
+
+// InstallOperatorHealth counts the job runs on a platform in which a cluster operator failed to install, and the
+// conditions it reported in them.
+type InstallOperatorHealth struct {
+	Platform       string `json:"platform"`
+	Operator       string `json:"operator"`
+	FailedInstalls int    `json:"failed_installs"`
+	// PercentageOfFailedInstalls is the share of the platform's runs with an operator install failure in which
+	// this operator failed.
+	PercentageOfFailedInstalls float64                    `json:"percentage_of_failed_installs"`
+	Conditions                 []InstallOperatorCondition `json:"conditions"`
+}
+
+// InstallOperatorCondition is a degraded, unavailable or progressing condition reported by an operator that failed
+// to install, with the number of job runs it was reported in.
+type InstallOperatorCondition struct {
+	Condition string `json:"condition"`
+	Reason    string `json:"reason"`
+	Count     int    `json:"count"`
+}
+
+// JobSpotlight lists the jobs whose pass rate improved, and degraded, the most between the previous and current
+// periods.
+type JobSpotlight struct {
+	Improved []Job `json:"improved"`
+	Degraded []Job `json:"degraded"`
+}
+
+// JobFlakyRuns counts a job's runs that succeeded with test flakes in the current and previous periods.
+type JobFlakyRuns struct {
+	Name              string `json:"name"`
+	CurrentFlakyRuns  int    `json:"current_flaky_runs"`
+	PreviousFlakyRuns int    `json:"previous_flaky_runs"`
+}
+
+// FlakyRunSummary is the share of a release's job runs that succeeded only after test flakes.
+type FlakyRunSummary struct {
+	CurrentRuns                int     `json:"current_runs"`
+	CurrentFlakyRuns           int     `json:"current_flaky_runs"`
+	CurrentFlakyRunPercentage  float64 `json:"current_flaky_run_percentage"`
+	PreviousRuns               int     `json:"previous_runs"`
+	PreviousFlakyRuns          int     `json:"previous_flaky_runs"`
+	PreviousFlakyRunPercentage float64 `json:"previous_flaky_run_percentage"`
+}
+
+// JobLineageEntry is one release's instance of a job that is renamed each release, with its all time results.
+type JobLineageEntry struct {
+	ID             uint       `json:"id"`
+	Name           string     `json:"name"`
+	Release        string     `json:"release"`
+	Lineage        string     `json:"lineage"`
+	Runs           int        `json:"runs"`
+	Passes         int        `json:"passes"`
+	PassPercentage float64    `json:"pass_percentage"`
+	FirstRun       *time.Time `json:"first_run,omitempty"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+}
+
+// JobDurationStats summarizes the run durations of a job, in seconds, split into the current and previous
+// periods at the report boundary.
+type JobDurationStats struct {
+	Name                string  `json:"name"`
+	CurrentRuns         int     `json:"current_runs"`
+	CurrentDurationP50  float64 `json:"current_duration_p50"`
+	CurrentDurationP90  float64 `json:"current_duration_p90"`
+	CurrentDurationP95  float64 `json:"current_duration_p95"`
+	PreviousRuns        int     `json:"previous_runs"`
+	PreviousDurationP50 float64 `json:"previous_duration_p50"`
+	PreviousDurationP90 float64 `json:"previous_duration_p90"`
+}
+
+// JobDurationIncrease is a job whose median runtime grew significantly week-over-week. Jobs trending longer
+// frequently start failing on timeouts.
+type JobDurationIncrease struct {
+	JobDurationStats
+	IncreasePercentage float64 `json:"increase_percentage"`
 }
 
 func (job Job) GetFieldType(param string) ColumnType {
@@ -268,6 +434,8 @@ func (job Job) GetFieldType(param string) ColumnType {
 	//nolint:goconst
 	case "test_grid_url":
 		return ColumnTypeString
+	case "kind":
+		return ColumnTypeString
 	default:
 		return ColumnTypeNumerical
 	}
@@ -287,6 +455,8 @@ func (job Job) GetStringValue(param string) (string, error) {
 	//nolint:goconst
 	case "repo":
 		return job.Repo, nil
+	case "kind":
+		return job.Kind, nil
 	default:
 		return "", fmt.Errorf("unknown string field %s", param)
 	}
@@ -348,6 +518,7 @@ type JobRun struct {
 	Failed                bool                `json:"failed"`
 	InfrastructureFailure bool                `json:"infrastructure_failure"`
 	KnownFailure          bool                `json:"known_failure"`
+	ProbableCause         string              `json:"probable_cause"`
 	Succeeded             bool                `json:"succeeded"`
 	Timestamp             int                 `json:"timestamp"`
 	OverallResult         v1.JobOverallResult `json:"overall_result"`
@@ -356,6 +527,18 @@ type JobRun struct {
 	PullRequestLink       string              `json:"pull_request_link"`
 	PullRequestSHA        string              `json:"pull_request_sha"`
 	PullRequestAuthor     string              `json:"pull_request_author"`
+	PullRequestNumber     int                 `json:"pull_request_number,omitempty"`
+	// Kind is periodic, presubmit or postsubmit.
+	Kind string `json:"kind"`
+	// DebugArtifacts link to the must-gather and other debugging outputs of failed runs.
+	DebugArtifacts []DebugArtifact `json:"debug_artifacts,omitempty" gorm:"-"`
+}
+
+// DebugArtifact is a link to a well known debugging output in a job run's artifacts.
+type DebugArtifact struct {
+	// Kind is must-gather, gather-extra or screenshot.
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
 }
 
 func (run JobRun) GetFieldType(param string) ColumnType {
@@ -390,6 +573,10 @@ func (run JobRun) GetFieldType(param string) ColumnType {
 		return ColumnTypeString
 	case "pull_request_link":
 		return ColumnTypeString
+	case "kind":
+		return ColumnTypeString
+	case "probable_cause":
+		return ColumnTypeString
 	default:
 		return ColumnTypeNumerical
 	}
@@ -415,6 +602,10 @@ func (run JobRun) GetStringValue(param string) (string, error) {
 		return run.PullRequestSHA, nil
 	case "pull_request_link":
 		return run.PullRequestLink, nil
+	case "kind":
+		return run.Kind, nil
+	case "probable_cause":
+		return run.ProbableCause, nil
 	default:
 		return "", fmt.Errorf("unknown string field %s", param)
 	}
@@ -428,6 +619,8 @@ func (run JobRun) GetNumericalValue(param string) (float64, error) {
 		return float64(run.TestFailures), nil
 	case "timestamp":
 		return float64(run.Timestamp), nil
+	case "pull_request_number":
+		return float64(run.PullRequestNumber), nil
 	default:
 		return 0, fmt.Errorf("unknown numerical field %s", param)
 	}
@@ -451,8 +644,10 @@ func (run JobRun) GetArrayValue(param string) ([]string, error) {
 // Test contains the full accounting of a test's history, with a synthetic ID. The format
 // of this struct is suitable for use in a data table.
 type Test struct {
-	ID        int            `json:"id,omitempty"`
-	Name      string         `json:"name"`
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Hash is a stable identifier for the test name that can be used in place of the name in test APIs.
+	Hash      string         `json:"hash,omitempty"`
 	SuiteName string         `json:"suite_name"`
 	Variant   string         `json:"variant,omitempty"`
 	Variants  pq.StringArray `json:"variants" gorm:"type:text[]"`
@@ -496,12 +691,305 @@ type Test struct {
 
 	Tags     []string `json:"tags" gorm:"type:text[]"`
 	OpenBugs int      `json:"open_bugs"`
+
+	// Suppressed is true if some results for this test were excluded by a known issue suppression.
+	Suppressed bool `json:"suppressed" gorm:"-"`
+
+	// Smoothed pass percentages and their 95% confidence intervals are only set when requested with the
+	// smoothing parameter, they are less noisy than the raw rates for tests with few runs.
+	CurrentPassPercentageSmoothed  float64 `json:"current_pass_percentage_smoothed,omitempty" gorm:"-"`
+	CurrentPassPercentageLower     float64 `json:"current_pass_percentage_lower,omitempty" gorm:"-"`
+	CurrentPassPercentageUpper     float64 `json:"current_pass_percentage_upper,omitempty" gorm:"-"`
+	PreviousPassPercentageSmoothed float64 `json:"previous_pass_percentage_smoothed,omitempty" gorm:"-"`
+	PreviousPassPercentageLower    float64 `json:"previous_pass_percentage_lower,omitempty" gorm:"-"`
+	PreviousPassPercentageUpper    float64 `json:"previous_pass_percentage_upper,omitempty" gorm:"-"`
+
+	// The same test's current period results in another release, usually the previous one, are only set when
+	// requested with the compareRelease parameter.
+	CompareRelease                   string  `json:"compare_release,omitempty" gorm:"-"`
+	CompareReleaseRuns               int     `json:"compare_release_runs,omitempty" gorm:"-"`
+	CompareReleasePassPercentage     float64 `json:"compare_release_pass_percentage,omitempty" gorm:"-"`
+	CompareReleaseFlakePercentage    float64 `json:"compare_release_flake_percentage,omitempty" gorm:"-"`
+	NetImprovementFromCompareRelease float64 `json:"net_improvement_from_compare_release,omitempty" gorm:"-"`
+
+	// SubTests is how many subtests, such as the per-operator install tests, were rolled up into this parent test
+	// when requested with the rollup parameter.
+	SubTests int `json:"sub_tests,omitempty" gorm:"-"`
+}
+
+// TestBuildClusterResult summarizes a test's results on a single build cluster. Timeouts, where the test failed
+// only because its suite timed out, are reported separately and not counted in Runs.
+type TestBuildClusterResult struct {
+	Cluster        string  `json:"cluster"`
+	Runs           int     `json:"runs"`
+	Successes      int     `json:"successes"`
+	Failures       int     `json:"failures"`
+	Flakes         int     `json:"flakes"`
+	Timeouts       int     `json:"timeouts"`
+	PassPercentage float64 `json:"pass_percentage"`
+}
+
+// TestComparison is a statistical comparison of a test's recent results against a basis, such as the previous
+// release, using Fisher's exact test.
+type TestComparison struct {
+	TestName    string              `json:"test_name"`
+	Sample      TestComparisonStats `json:"sample"`
+	Base        TestComparisonStats `json:"base"`
+	Confidence  int                 `json:"confidence"`
+	PityFactor  int                 `json:"pity_factor"`
+	FisherExact float64             `json:"fisher_exact"`
+	// Status uses the component readiness statuses, e.g. -4 for a significant regression.
+	Status crtype.Status `json:"status"`
+	// Regressed is true if the sample is significantly worse than the basis.
+	Regressed bool `json:"regressed"`
+}
+
+// TestComparisonStats are a test's results in one release over a time window.
+type TestComparisonStats struct {
+	Release        string    `json:"release"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	Runs           int       `json:"runs"`
+	Successes      int       `json:"successes"`
+	Flakes         int       `json:"flakes"`
+	Failures       int       `json:"failures"`
+	PassPercentage float64   `json:"pass_percentage" gorm:"-"`
+}
+
+// TestReleaseMatrix is a test's current results in every release, overall and for each variant of the jobs
+// matching the requested variant filters. Releases are newest first, and each row has a cell per release.
+type TestReleaseMatrix struct {
+	TestName string                 `json:"test_name"`
+	Period   string                 `json:"period"`
+	Releases []string               `json:"releases"`
+	Overall  []TestReleaseResult    `json:"overall"`
+	Variants []TestReleaseMatrixRow `json:"variants"`
+}
+
+// TestReleaseMatrixRow is a test's results in each release of a TestReleaseMatrix, for jobs with one variant.
+type TestReleaseMatrixRow struct {
+	Variant string              `json:"variant"`
+	Results []TestReleaseResult `json:"results"`
+}
+
+// TestReleaseResult is one cell of a TestReleaseMatrix. Releases where the test did not run have no runs.
+type TestReleaseResult struct {
+	Release         string  `json:"release"`
+	Variant         string  `json:"-"`
+	Runs            int     `json:"runs"`
+	Successes       int     `json:"successes"`
+	Flakes          int     `json:"flakes"`
+	Failures        int     `json:"failures"`
+	PassPercentage  float64 `json:"pass_percentage" gorm:"-"`
+	FlakePercentage float64 `json:"flake_percentage" gorm:"-"`
+}
+
+// JobRunSteps are the steps a job run's multi-stage tests ran, read from its ci-operator step graph.
+type JobRunSteps struct {
+	JobName string       `json:"job_name"`
+	RunID   int64        `json:"run_id"`
+	Steps   []JobRunStep `json:"steps"`
+}
+
+// JobRunStep is a step of a job run. Phase is the step's phase in the job as listed in the step registry, empty if
+// the registry doesn't list the step for the job. Failed steps are mapped to the other jobs of the release composed
+// of the same step, to see whether a failure may be shared rather than specific to the job.
+type JobRunStep struct {
+	Test       string   `json:"test"`
+	Step       string   `json:"step"`
+	Phase      string   `json:"phase,omitempty"`
+	Failed     bool     `json:"failed"`
+	Duration   float64  `json:"duration"`
+	SharedWith []string `json:"shared_with,omitempty"`
+}
+
+// StepJob is a job composed of a step in the step registry.
+type StepJob struct {
+	JobName string `json:"job_name"`
+	Release string `json:"release"`
+	Phase   string `json:"phase"`
+}
+
+// StepFailures is how often a step failed in the runs of a job, or of the jobs on a platform, that ran it.
+type StepFailures struct {
+	JobName           string  `json:"job_name,omitempty"`
+	Platform          string  `json:"platform,omitempty"`
+	Step              string  `json:"step"`
+	Runs              int     `json:"runs"`
+	Failures          int     `json:"failures"`
+	FailurePercentage float64 `json:"failure_percentage"`
+}
+
+// SLOStatus is how much of an SLO's error budget, the failures its target allows over its window, remains. The burn
+// rates are how fast the budget is being spent relative to spending it exactly over the window, over the whole window
+// and the last day: above 1 the budget runs out early.
+type SLOStatus struct {
+	Name            string  `json:"name"`
+	Release         string  `json:"release"`
+	Target          float64 `json:"target"`
+	WindowDays      int     `json:"window_days"`
+	Jobs            int     `json:"jobs"`
+	Runs            int     `json:"runs"`
+	Failures        int     `json:"failures"`
+	PassPercentage  float64 `json:"pass_percentage"`
+	ErrorBudget     float64 `json:"error_budget"`
+	BudgetRemaining float64 `json:"budget_remaining"`
+	BurnRate        float64 `json:"burn_rate"`
+	DayBurnRate     float64 `json:"day_burn_rate"`
+	// Status is ok, at_risk with less than a quarter of the budget left, exhausted, or no_data without runs.
+	Status string `json:"status"`
+}
+
+// CollapsedTest is a test the configured test name rules rewrote other test names into as they were loaded, with how
+// many names it collapsed, the rules that rewrote them, and the most recently seen of them.
+type CollapsedTest struct {
+	Name       string   `json:"name"`
+	Duplicates int      `json:"duplicates"`
+	Rules      []string `json:"rules"`
+	Examples   []string `json:"examples"`
+}
+
+// TestPresenceDiff lists the tests that ran in only one of two releases, to catch coverage lost by accident when
+// suites are migrated. Removed tests ran in BaseRelease but not in Release, added tests the other way round.
+type TestPresenceDiff struct {
+	Release     string            `json:"release"`
+	BaseRelease string            `json:"base_release"`
+	Removed     []TestPresence    `json:"removed"`
+	Added       []TestPresence    `json:"added"`
+	Sigs        []TestPresenceSig `json:"sigs"`
+}
+
+// TestPresence is a test of a TestPresenceDiff, with its runs in the release it ran in.
+type TestPresence struct {
+	Name    string `json:"name"`
+	Sig     string `json:"sig"`
+	Release string `json:"-"`
+	Runs    int    `json:"runs"`
+}
+
+// TestPresenceSig counts a sig's removed and added tests. Sig is empty for tests without a sig tag.
+type TestPresenceSig struct {
+	Sig     string `json:"sig"`
+	Removed int    `json:"removed"`
+	Added   int    `json:"added"`
+}
+
+// TestSeasonality breaks a test's failure rate down by the hour of day and day of the week (UTC) its job runs
+// started. A test is Seasonal when its failures concentrate in a few consecutive hours, PeakHours, where it
+// fails PeakRatio times as often as overall, such as during nightly batch load on the build clusters.
+type TestSeasonality struct {
+	TestName          string              `json:"test_name"`
+	Runs              int                 `json:"runs"`
+	Failures          int                 `json:"failures"`
+	FailurePercentage float64             `json:"failure_percentage"`
+	ByHour            []SeasonalityBucket `json:"by_hour"`
+	ByDayOfWeek       []SeasonalityBucket `json:"by_day_of_week"`
+	PeakHours         []int               `json:"peak_hours"`
+	PeakRatio         float64             `json:"peak_ratio"`
+	Seasonal          bool                `json:"seasonal"`
+}
+
+// SeasonalityBucket is a test's failure rate in one hour of the day (0-23), or one day of the week (0 is Sunday).
+type SeasonalityBucket struct {
+	Bucket            int     `json:"bucket"`
+	Runs              int     `json:"runs"`
+	Failures          int     `json:"failures"`
+	FailurePercentage float64 `json:"failure_percentage"`
+}
+
+// TestReportExplanation lists the job runs behind a test's pass percentage in one window of the test report, so
+// the numbers can be audited. Timeouts, where the test failed only because its suite timed out, are not counted
+// in Runs.
+type TestReportExplanation struct {
+	Name              string                   `json:"name"`
+	Release           string                   `json:"release"`
+	Period            string                   `json:"period"`
+	Window            string                   `json:"window"`
+	Start             time.Time                `json:"start"`
+	End               time.Time                `json:"end"`
+	Runs              int                      `json:"runs"`
+	Successes         int                      `json:"successes"`
+	Failures          int                      `json:"failures"`
+	Flakes            int                      `json:"flakes"`
+	Timeouts          int                      `json:"timeouts"`
+	PassPercentage    float64                  `json:"pass_percentage"`
+	WorkingPercentage float64                  `json:"working_percentage"`
+	JobRuns           []TestReportContribution `json:"job_runs"`
+}
+
+// BugImpact lists the job runs between Start and End that failed a test linked to a bug, grouped by job. Runs are
+// counted separately either side of Boundary, so a fix can be verified by comparing the two.
+type BugImpact struct {
+	Bug          string           `json:"bug"`
+	Start        time.Time        `json:"start"`
+	Boundary     time.Time        `json:"boundary"`
+	End          time.Time        `json:"end"`
+	CurrentRuns  int              `json:"current_runs"`
+	PreviousRuns int              `json:"previous_runs"`
+	Jobs         []BugImpactGroup `json:"jobs"`
+}
+
+// BugImpactGroup is the impacted runs of a single job.
+type BugImpactGroup struct {
+	Job      string   `json:"job"`
+	Release  string   `json:"release"`
+	Variants []string `json:"variants"`
+	// CurrentRuns are impacted runs after the boundary, PreviousRuns before it.
+	CurrentRuns  int               `json:"current_runs"`
+	PreviousRuns int               `json:"previous_runs"`
+	JobRuns      []BugImpactJobRun `json:"job_runs"`
+}
+
+// BugImpactJobRun is a job run that failed one or more tests linked to a bug.
+type BugImpactJobRun struct {
+	ProwJobRunID uint           `json:"prow_job_run_id"`
+	ProwJobName  string         `json:"prow_job_name"`
+	Release      string         `json:"release"`
+	Variants     pq.StringArray `json:"variants" gorm:"type:text[]"`
+	URL          string         `json:"url"`
+	Timestamp    time.Time      `json:"timestamp"`
+	FailedTests  pq.StringArray `json:"failed_tests" gorm:"type:text[]"`
+}
+
+// BugResolutionStats summarizes how long the CI impacting bugs of a component or release stay open.
+type BugResolutionStats struct {
+	Name         string `json:"name"`
+	ResolvedBugs int    `json:"resolved_bugs"`
+	// MeanHoursToResolve, the MTTR, and MedianHoursToResolve are the time from opening to resolution of the
+	// resolved bugs.
+	MeanHoursToResolve   float64 `json:"mean_hours_to_resolve"`
+	MedianHoursToResolve float64 `json:"median_hours_to_resolve"`
+	OpenBugs             int     `json:"open_bugs"`
+	// MeanOpenHours is the mean age of the bugs that are still open.
+	MeanOpenHours float64 `json:"mean_open_hours"`
+}
+
+// TestReportContribution is a single result of a test in a job run. Suppressed results are listed for
+// transparency but are not counted.
+type TestReportContribution struct {
+	ProwJobRunID uint           `json:"prow_job_run_id"`
+	ProwJobName  string         `json:"prow_job_name"`
+	URL          string         `json:"url"`
+	Timestamp    time.Time      `json:"timestamp"`
+	Status       int            `json:"status"`
+	Variants     pq.StringArray `json:"variants" gorm:"type:text[]"`
+	Suppressed   bool           `json:"suppressed"`
+}
+
+// TestSearchResult is a test matching a search query, ranked by how similar its name is to the query.
+type TestSearchResult struct {
+	ID         uint    `json:"id"`
+	Name       string  `json:"name"`
+	Hash       string  `json:"hash"`
+	Similarity float64 `json:"similarity"`
 }
 
 func (test Test) GetFieldType(param string) ColumnType {
 	switch param {
 	case "name":
 		return ColumnTypeString
+	case "hash":
+		return ColumnTypeString
 	case "tags":
 		return ColumnTypeArray
 	case "variant":
@@ -519,6 +1007,8 @@ func (test Test) GetStringValue(param string) (string, error) {
 	switch param {
 	case "name":
 		return test.Name, nil
+	case "hash":
+		return test.Hash, nil
 	case "variant":
 		return test.Variant, nil
 	case "watchlist":
@@ -593,6 +1083,18 @@ func (test Test) GetNumericalValue(param string) (float64, error) {
 		return test.FlakeAverage, nil
 	case "flake_standard_deviation":
 		return test.FlakeStandardDeviation, nil
+	case "current_pass_percentage_smoothed":
+		return test.CurrentPassPercentageSmoothed, nil
+	case "current_pass_percentage_lower":
+		return test.CurrentPassPercentageLower, nil
+	case "current_pass_percentage_upper":
+		return test.CurrentPassPercentageUpper, nil
+	case "previous_pass_percentage_smoothed":
+		return test.PreviousPassPercentageSmoothed, nil
+	case "previous_pass_percentage_lower":
+		return test.PreviousPassPercentageLower, nil
+	case "previous_pass_percentage_upper":
+		return test.PreviousPassPercentageUpper, nil
 	default:
 		return 0, fmt.Errorf("unknown numerical field %s", param)
 	}
@@ -626,6 +1128,135 @@ type ReleaseHealthReport struct {
 	PayloadStatistics PayloadStatistics `json:"acceptance_statistics"`
 }
 
+// PayloadChanges describes what changed in a payload since the last accepted payload in its stream, which is
+// the base the release controller's changelog is computed from.
+type PayloadChanges struct {
+	ReleaseTag      string `json:"release_tag"`
+	Phase           string `json:"phase"`
+	LastAcceptedTag string `json:"last_accepted_tag"`
+	// IntermediatePayloads are the payloads built between LastAcceptedTag and ReleaseTag, oldest first.
+	IntermediatePayloads []string `json:"intermediate_payloads"`
+	// Components are the payload components, such as Kubernetes or the machine OS, whose version changed.
+	Components []models.ReleaseComponent `json:"components"`
+	// Images are the images that were rebuilt.
+	Images       []models.ReleaseRepository `json:"images"`
+	PullRequests []PayloadPullRequest       `json:"pull_requests"`
+}
+
+// PayloadPullRequest is a pull request included in a payload since the last accepted payload.
+type PayloadPullRequest struct {
+	models.ReleasePullRequest
+	// FirstReleaseTag is the earliest payload since the last accepted payload to include the pull request.
+	FirstReleaseTag string `json:"first_release_tag"`
+}
+
+// PayloadRejection explains a payload's rejection: the blocking jobs the release controller recorded as failed,
+// the prow job runs behind them, and the tests that failed in those runs.
+type PayloadRejection struct {
+	ReleaseTag string `json:"release_tag"`
+	Phase      string `json:"phase"`
+	Forced     bool   `json:"forced"`
+	// RejectReasons are the categories TRT assigned to the rejection, if any.
+	RejectReasons []string             `json:"reject_reasons"`
+	RejectedBy    []string             `json:"rejected_by"`
+	FailedJobs    []PayloadRejectedJob `json:"failed_jobs"`
+}
+
+// PayloadRejectedJob is a failed blocking job of a payload.
+type PayloadRejectedJob struct {
+	// Name is the job's verification name in the release controller, e.g. aws-serial.
+	Name         string `json:"name"`
+	ProwJobRunID uint   `json:"prow_job_run_id"`
+	ProwJobName  string `json:"prow_job_name"`
+	URL          string `json:"url"`
+	Retries      int    `json:"retries"`
+	// FailedTests are the tests that failed in the run, empty until the run is imported from prow.
+	FailedTests pq.StringArray `json:"failed_tests" gorm:"type:text[]"`
+}
+
+// ReleasePromotion describes the most recent accepted payload for a release stream and architecture,
+// and how long it has been since that stream last promoted.
+type ReleasePromotion struct {
+	Release      string `json:"release"`
+	Stream       string `json:"stream"`
+	Architecture string `json:"architecture"`
+	// LastAcceptedTag is the most recently accepted payload in this stream.
+	LastAcceptedTag string `json:"last_accepted_tag"`
+	// LastAcceptedTime is the release time of LastAcceptedTag.
+	LastAcceptedTime time.Time `json:"last_accepted_time"`
+	// HoursSincePromotion is the number of hours between LastAcceptedTime and the report end.
+	HoursSincePromotion float64 `json:"hours_since_promotion"`
+	// MaxHoursBetweenPromotions is the longest gap between accepted payloads in this stream over the release.
+	MaxHoursBetweenPromotions float64 `json:"max_hours_between_promotions"`
+	// Stale is true if the stream has not promoted within the promotion warning threshold.
+	Stale bool `json:"stale"`
+}
+
+// ExternalJobRun is a job run from a CI system other than prow, such as jenkins, reported to sippy directly.
+type ExternalJobRun struct {
+	// Source names the CI system, e.g. jenkins. It can be anything but prow.
+	Source  string `json:"source"`
+	Job     string `json:"job"`
+	Release string `json:"release"`
+	// BuildID identifies the run within the job, it must be unique for the source and job.
+	BuildID         string    `json:"build_id"`
+	URL             string    `json:"url"`
+	Timestamp       time.Time `json:"timestamp"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	// Succeeded defaults to whether none of the tests failed.
+	Succeeded *bool                `json:"succeeded,omitempty"`
+	Tests     []ExternalTestResult `json:"tests"`
+	// JUnit is junit XML with the run's results, an alternative to listing the tests.
+	JUnit string `json:"junit,omitempty"`
+}
+
+// ExternalTestResult is the result of a test in an ExternalJobRun.
+type ExternalTestResult struct {
+	Name  string `json:"name"`
+	Suite string `json:"suite,omitempty"`
+	// Status is one of passed, failed or flaked.
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Output is the failure output of failed and flaked tests.
+	Output string `json:"output,omitempty"`
+}
+
+// PromotionRecommendation recommends whether to accept the latest payload of a stream, to aid payload reviews.
+type PromotionRecommendation struct {
+	ReleaseTag   string    `json:"release_tag"`
+	Release      string    `json:"release"`
+	Stream       string    `json:"stream"`
+	Architecture string    `json:"architecture"`
+	Phase        string    `json:"phase"`
+	ReleaseTime  time.Time `json:"release_time"`
+	// Recommendation is "accept" if nothing was found to block the payload, otherwise "reject".
+	Recommendation string `json:"recommendation"`
+	// Reasons explain a rejection, one per blocking job or regression.
+	Reasons []PromotionReason `json:"reasons"`
+	// BlockingJobs are the recent results of the stream's blocking jobs.
+	BlockingJobs []BlockingJobStats `json:"blocking_jobs"`
+}
+
+// PromotionReason is one problem counting against promoting a payload.
+type PromotionReason struct {
+	// Kind is one of blocking_job_failed, blocking_job_below_threshold, regression or disruption_regression.
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// BlockingJobStats summarizes a blocking job's runs for recent payloads of a stream, and its state for the payload
+// under review.
+type BlockingJobStats struct {
+	JobName        string  `json:"job_name"`
+	Runs           int     `json:"runs"`
+	Succeeded      int     `json:"succeeded"`
+	PassPercentage float64 `json:"pass_percentage"`
+	// PayloadState is the job's state for the payload under review, empty if it has not run for it.
+	PayloadState string `json:"payload_state,omitempty"`
+	PayloadURL   string `json:"payload_url,omitempty"`
+}
+
 type PayloadPhaseCounts struct {
 	// CurrentWeek contains payload phase counts over the past week.
 	CurrentWeek PayloadPhaseCount `json:"current_week"`
@@ -704,6 +1335,17 @@ type BuildClusterHealthAnalysis struct {
 
 type BuildClusterHealth = models.BuildClusterHealthReport
 
+// JobResultsByPeriod sums the results of all matching job runs that started in a day or hour.
+type JobResultsByPeriod struct {
+	Period                 time.Time `json:"period"`
+	Runs                   int       `json:"runs"`
+	Successes              int       `json:"successes"`
+	Failures               int       `json:"failures"`
+	InfrastructureFailures int       `json:"infrastructure_failures"`
+	KnownFailures          int       `json:"known_failures"`
+	PassPercentage         float64   `json:"pass_percentage"`
+}
+
 type AnalysisResult struct {
 	TotalRuns        int                         `json:"total_runs"`
 	ResultCount      map[v1.JobOverallResult]int `json:"result_count"`
@@ -715,8 +1357,28 @@ type JobAnalysisResult struct {
 }
 
 type TestOutput struct {
-	URL    string `json:"url"`
-	Output string `json:"output"`
+	URL     string `json:"url"`
+	Message string `json:"message,omitempty"`
+	Output  string `json:"output"`
+}
+
+// TestFailureOutput is the failure output of one test in a job run.
+type TestFailureOutput struct {
+	TestOutput
+	ProwJobName string    `json:"prow_job_name"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// TestFailureGroup is a set of failures of a test whose error messages are the same once run specific details,
+// such as names, addresses and durations, are removed. Distinct groups usually point to distinct root causes.
+type TestFailureGroup struct {
+	Signature string    `json:"signature"`
+	Count     int       `json:"count"`
+	Jobs      []string  `json:"jobs"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// Examples are the most recent failures in the group.
+	Examples []TestFailureOutput `json:"examples"`
 }
 
 type Releases struct {
@@ -743,6 +1405,7 @@ type Health struct {
 	Warnings    []string             `json:"warnings"`
 	Current     v1.Statistics        `json:"current_statistics"`
 	Previous    v1.Statistics        `json:"previous_statistics"`
+	FlakyRuns   FlakyRunSummary      `json:"flaky_runs"`
 }
 
 type ProwJobRunRiskAnalysis struct {
@@ -824,6 +1487,47 @@ type DisruptionReportRow struct {
 	Relevance                int     `json:"relevance"`
 }
 
+// TestGroupReport is the combined results of every test in a group, such as the tests of a sig or feature.
+type TestGroupReport struct {
+	Name  string `json:"name"`
+	Tests int    `json:"tests"`
+
+	CurrentSuccesses         int     `json:"current_successes"`
+	CurrentFailures          int     `json:"current_failures"`
+	CurrentFlakes            int     `json:"current_flakes"`
+	CurrentPassPercentage    float64 `json:"current_pass_percentage"`
+	CurrentFailurePercentage float64 `json:"current_failure_percentage"`
+	CurrentFlakePercentage   float64 `json:"current_flake_percentage"`
+	CurrentWorkingPercentage float64 `json:"current_working_percentage"`
+	CurrentRuns              int     `json:"current_runs"`
+
+	PreviousSuccesses         int     `json:"previous_successes"`
+	PreviousFailures          int     `json:"previous_failures"`
+	PreviousFlakes            int     `json:"previous_flakes"`
+	PreviousPassPercentage    float64 `json:"previous_pass_percentage"`
+	PreviousFailurePercentage float64 `json:"previous_failure_percentage"`
+	PreviousFlakePercentage   float64 `json:"previous_flake_percentage"`
+	PreviousWorkingPercentage float64 `json:"previous_working_percentage"`
+	PreviousRuns              int     `json:"previous_runs"`
+
+	NetFailureImprovement float64 `json:"net_failure_improvement"`
+	NetFlakeImprovement   float64 `json:"net_flake_improvement"`
+	NetWorkingImprovement float64 `json:"net_working_improvement"`
+	NetImprovement        float64 `json:"net_improvement"`
+}
+
+// DisruptionJobRun is the time a backend was disrupted during a single job run.
+type DisruptionJobRun struct {
+	BackendName       string         `json:"backend_name"`
+	ProwJobRunID      uint           `json:"prow_job_run_id"`
+	ProwJobName       string         `json:"prow_job_name"`
+	Release           string         `json:"release"`
+	Variants          pq.StringArray `json:"variants" gorm:"type:text[]"`
+	URL               string         `json:"url"`
+	Timestamp         time.Time      `json:"timestamp"`
+	DisruptionSeconds float64        `json:"disruption_seconds"`
+}
+
 type ReleaseRow struct {
 	// Release contains the X.Y version of the payload, e.g. 4.8
 	Release string `bigquery:"release"`
@@ -861,3 +1565,181 @@ type FeatureGate struct {
 	Release         string `json:"release"`
 	UniqueTestCount int64  `json:"unique_test_count"`
 }
+
+// ReleaseScorecard combines several measures of a release's health into a single readiness score out of 100.
+type ReleaseScorecard struct {
+	Release    string               `json:"release"`
+	Score      float64              `json:"score"`
+	Components []ScorecardComponent `json:"components"`
+}
+
+// ScorecardComponent is one measure contributing to a release scorecard.
+type ScorecardComponent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Score is the component's own score out of 100.
+	Score float64 `json:"score"`
+	// Weight is the share of the overall score given to this component, weights of components without data
+	// are redistributed to the others.
+	Weight float64 `json:"weight"`
+	// Contribution is the number of points this component adds to the overall score.
+	Contribution float64 `json:"contribution"`
+	// NoData is set when there was nothing to score the component on.
+	NoData bool `json:"no_data"`
+}
+
+// JobArtifactSize compares the size of the artifacts uploaded by a job's runs in the current and previous periods.
+type JobArtifactSize struct {
+	ProwJobName       string  `json:"prow_job_name"`
+	Release           string  `json:"release"`
+	CurrentRuns       int     `json:"current_runs"`
+	CurrentTotalBytes int64   `json:"current_total_bytes"`
+	CurrentMeanBytes  float64 `json:"current_mean_bytes"`
+	PreviousRuns      int     `json:"previous_runs"`
+	PreviousMeanBytes float64 `json:"previous_mean_bytes"`
+	// Growth is the current mean size as a multiple of the previous, 0 if there were no previous runs.
+	Growth float64 `json:"growth"`
+}
+
+// CloudLocationHealth compares the failure rate of the job runs whose clusters were installed in a cloud region, or
+// zone of one, in the current and previous periods.
+type CloudLocationHealth struct {
+	Region string `json:"region"`
+	// Zone is empty when results are grouped by region.
+	Zone                      string  `json:"zone,omitempty"`
+	CurrentRuns               int     `json:"current_runs"`
+	CurrentFailures           int     `json:"current_failures"`
+	CurrentFailurePercentage  float64 `json:"current_failure_percentage"`
+	PreviousRuns              int     `json:"previous_runs"`
+	PreviousFailures          int     `json:"previous_failures"`
+	PreviousFailurePercentage float64 `json:"previous_failure_percentage"`
+	// ExcessFailurePercentage is how much higher the current failure percentage is than that of all regions.
+	ExcessFailurePercentage float64 `json:"excess_failure_percentage"`
+}
+
+// ArtifactSizeDay is the size of the artifacts uploaded by the job runs of a day.
+type ArtifactSizeDay struct {
+	Date       time.Time `json:"date"`
+	Runs       int       `json:"runs"`
+	TotalBytes int64     `json:"total_bytes"`
+	MeanBytes  float64   `json:"mean_bytes"`
+}
+
+// WatchlistReport is the current results of the tests on a watchlist, regressed tests first.
+type WatchlistReport struct {
+	ID      uint          `json:"id"`
+	Name    string        `json:"name"`
+	Release string        `json:"release"`
+	Tests   []WatchedTest `json:"tests"`
+	// Regressions is how many of the tests regressed.
+	Regressions int `json:"regressions"`
+}
+
+// WatchedTest is the results of a test on a watchlist, with no runs if it didn't run in the release.
+type WatchedTest struct {
+	Test
+	// Regressed is true if the test's pass percentage dropped significantly from the previous period.
+	Regressed bool `json:"regressed"`
+}
+
+// JobGrid is a testgrid-like view of a job's latest runs, newest first, with a row per test that ran in any of them.
+type JobGrid struct {
+	JobID   uint          `json:"job_id"`
+	JobName string        `json:"job_name"`
+	Release string        `json:"release"`
+	Runs    []JobGridRun  `json:"runs"`
+	Tests   []JobGridTest `json:"tests"`
+}
+
+// JobGridRun is a column of a job grid, with what sippy knows about the run.
+type JobGridRun struct {
+	ID                    uint                `json:"id"`
+	URL                   string              `json:"url"`
+	Timestamp             time.Time           `json:"timestamp"`
+	OverallResult         v1.JobOverallResult `json:"overall_result"`
+	ProbableCause         string              `json:"probable_cause,omitempty"`
+	KnownFailure          bool                `json:"known_failure"`
+	InfrastructureFailure bool                `json:"infrastructure_failure"`
+}
+
+// JobGridTest is a row of a job grid. Statuses has a character per run, in the order of the grid's runs: S for
+// success, F for failure, L for flake, T for timeout, R for running and - if the test didn't run.
+type JobGridTest struct {
+	Name     string `json:"name"`
+	Statuses string `json:"statuses"`
+	Failures int    `json:"failures"`
+	Flakes   int    `json:"flakes"`
+	// OpenBugs is how many open bugs mention the test, for tests that failed or flaked.
+	OpenBugs int `json:"open_bugs,omitempty"`
+}
+
+// EnvironmentDrift is a change of an environment fingerprint in a job's runs, i.e. a new installer version, after
+// which the job failed more often, pointing triage at the changed dependency.
+type EnvironmentDrift struct {
+	JobName       string    `json:"job_name"`
+	Fingerprint   string    `json:"fingerprint"`
+	PreviousValue string    `json:"previous_value"`
+	Value         string    `json:"value"`
+	ChangedAt     time.Time `json:"changed_at"`
+
+	PreviousRuns              int     `json:"previous_runs"`
+	PreviousFailurePercentage float64 `json:"previous_failure_percentage"`
+	CurrentRuns               int     `json:"current_runs"`
+	CurrentFailurePercentage  float64 `json:"current_failure_percentage"`
+	// FailureIncrease is how many percentage points the failure percentage rose after the change.
+	FailureIncrease float64 `json:"failure_increase"`
+}
+
+// DatabaseUsage is the disk usage of sippy's database, with warnings when its growth is projected to exceed the
+// configured disk budget.
+type DatabaseUsage struct {
+	DatabaseBytes int64 `json:"database_bytes"`
+	DiskBudget    int64 `json:"disk_budget,omitempty"`
+	// GrowthBytesPerDay is how fast the tables and materialized views have grown over the last week.
+	GrowthBytesPerDay float64 `json:"growth_bytes_per_day"`
+	// DaysUntilFull is when the database is projected to exceed its disk budget at its current growth, omitted
+	// without a budget or growth.
+	DaysUntilFull *float64     `json:"days_until_full,omitempty"`
+	Warnings      []string     `json:"warnings"`
+	Tables        []TableUsage `json:"tables"`
+}
+
+// TableUsage is the disk usage of a table, including all its partitions, or of a materialized view.
+type TableUsage struct {
+	Name string `json:"name"`
+	// Kind is table or matview.
+	Kind       string `json:"kind"`
+	TotalBytes int64  `json:"total_bytes"`
+	TableBytes int64  `json:"table_bytes"`
+	IndexBytes int64  `json:"index_bytes"`
+	LiveRows   int64  `json:"live_rows"`
+	DeadRows   int64  `json:"dead_rows"`
+	// BloatBytes estimates the space taken by dead rows not yet vacuumed.
+	BloatBytes int64 `json:"bloat_bytes"`
+	// GrowthBytesPerDay is how fast it has grown over the last week, 0 until it has a day's history.
+	GrowthBytesPerDay float64 `json:"growth_bytes_per_day"`
+}
+
+// HostedControlPlaneHealth summarizes the health of a release's hypershift jobs, whose clusters have their control
+// plane hosted on a management cluster, apart from the standalone ones.
+type HostedControlPlaneHealth struct {
+	Indicators map[string]Test              `json:"indicators"`
+	Current    v1.Statistics                `json:"current_statistics"`
+	Previous   v1.Statistics                `json:"previous_statistics"`
+	FlakyRuns  FlakyRunSummary              `json:"flaky_runs"`
+	Platforms  []HostedControlPlanePlatform `json:"platforms"`
+	// Jobs are the hypershift jobs of the release, those passing least first.
+	Jobs []Job `json:"jobs"`
+}
+
+// HostedControlPlanePlatform totals the runs of the hypershift jobs whose hosted clusters run on a platform.
+type HostedControlPlanePlatform struct {
+	Platform               string  `json:"platform"`
+	Jobs                   int     `json:"jobs"`
+	CurrentRuns            int     `json:"current_runs"`
+	CurrentPasses          int     `json:"current_passes"`
+	CurrentPassPercentage  float64 `json:"current_pass_percentage"`
+	PreviousRuns           int     `json:"previous_runs"`
+	PreviousPasses         int     `json:"previous_passes"`
+	PreviousPassPercentage float64 `json:"previous_pass_percentage"`
+}
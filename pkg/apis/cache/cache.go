@@ -11,6 +11,12 @@ type Cache interface {
 	Set(ctx context.Context, key string, content []byte, duration time.Duration) error
 }
 
+// Purger is implemented by caches that can remove entries before they expire.
+type Purger interface {
+	// Purge removes all entries whose key starts with prefix, returning how many were removed.
+	Purge(ctx context.Context, prefix string) (int, error)
+}
+
 type APIResponse struct {
 	Headers  http.Header
 	Response []byte
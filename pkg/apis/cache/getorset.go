@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// generation is a computation of one cache key's content, which concurrent callers wait on.
+type generation struct {
+	done    chan struct{}
+	content []byte
+	err     error
+}
+
+var (
+	inflightLock sync.Mutex
+	inflight     = map[string]*generation{}
+)
+
+// GetOrSet returns the content cached under key, or generates and caches it when missing. Only one generation runs
+// at a time for each key in this process, with concurrent callers waiting for and sharing its result, so an expired
+// hot key doesn't have every request recompute the same expensive report. Errors from generate are returned to every
+// waiting caller and are not cached.
+func GetOrSet(ctx context.Context, c Cache, key string, duration time.Duration, generate func(context.Context) ([]byte, error)) ([]byte, error) {
+	if content, err := c.Get(ctx, key, duration); err == nil && content != nil {
+		return content, nil
+	}
+	return Refresh(ctx, c, key, duration, generate)
+}
+
+// Refresh generates and caches the content for key, ignoring anything already cached, sharing one generation
+// between concurrent callers like GetOrSet. The generation isn't canceled with the caller that started it, as
+// others may be waiting on it, but a waiting caller stops waiting when its own context is done.
+func Refresh(ctx context.Context, c Cache, key string, duration time.Duration, generate func(context.Context) ([]byte, error)) ([]byte, error) {
+	inflightLock.Lock()
+	g, waiting := inflight[key]
+	if !waiting {
+		g = &generation{done: make(chan struct{})}
+		inflight[key] = g
+	}
+	inflightLock.Unlock()
+
+	if waiting {
+		log.WithField("key", key).Debug("waiting for cache generation in progress")
+		select {
+		case <-g.done:
+			return g.content, g.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	defer func() {
+		inflightLock.Lock()
+		delete(inflight, key)
+		inflightLock.Unlock()
+		close(g.done)
+	}()
+
+	// reported to waiting callers if generate panics
+	g.err = fmt.Errorf("generating %q did not complete", key)
+	g.content, g.err = generate(context.WithoutCancel(ctx))
+	if g.err != nil {
+		return nil, g.err
+	}
+	if err := c.Set(ctx, key, g.content, duration); err != nil {
+		log.WithError(err).Warningf("couldn't persist new item to cache")
+	}
+	return g.content, nil
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryCache struct {
+	lock    sync.Mutex
+	entries map[string][]byte
+}
+
+func (c *memoryCache) Get(_ context.Context, key string, _ time.Duration) ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	content, ok := c.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return content, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, content []byte, _ time.Duration) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[key] = content
+	return nil
+}
+
+func TestGetOrSet(t *testing.T) {
+	c := &memoryCache{entries: map[string][]byte{}}
+	var calls int32
+	release := make(chan struct{})
+	generate := func(context.Context) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("report"), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			content, err := GetOrSet(context.Background(), c, "report", time.Hour, generate)
+			assert.NoError(t, err)
+			results[i] = content
+		}(i)
+	}
+	// let the callers pile up on the generation before it finishes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "concurrent callers should share one generation")
+	for _, content := range results {
+		assert.Equal(t, []byte("report"), content)
+	}
+
+	content, err := GetOrSet(context.Background(), c, "report", time.Hour, generate)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("report"), content)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "cached content should not be generated again")
+
+	content, err = Refresh(context.Background(), c, "report", time.Hour, generate)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("report"), content)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "refreshing should ignore the cache")
+}
+
+func TestGetOrSetError(t *testing.T) {
+	c := &memoryCache{entries: map[string][]byte{}}
+	calls := 0
+	generate := func(context.Context) ([]byte, error) {
+		calls++
+		return nil, fmt.Errorf("bigquery unavailable")
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := GetOrSet(context.Background(), c, "report", time.Hour, generate)
+		assert.EqualError(t, err, "bigquery unavailable")
+	}
+	assert.Equal(t, 2, calls, "errors should not be cached")
+	assert.Empty(t, c.entries)
+}
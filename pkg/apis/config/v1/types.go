@@ -3,12 +3,137 @@ package v1
 type SippyConfig struct {
 	Prow     ProwConfig               `yaml:"prow"`
 	Releases map[string]ReleaseConfig `yaml:"releases"`
+	Alerting AlertingConfig           `yaml:"alerting,omitempty"`
+
+	// Notifications routes messages from sippy, such as firing alerts or failed loads, to chat and paging services.
+	Notifications NotificationConfig `yaml:"notifications,omitempty"`
+
+	// Indicators are the top level health indicators reported for each release. If empty, OpenShift modes use
+	// the OpenShift install, upgrade and infrastructure indicators, and other modes report none.
+	Indicators []IndicatorConfig `yaml:"indicators,omitempty"`
+
+	// ReportTemplates are parameterized queries against the materialized views that can be run through the API,
+	// for one-off aggregations not worth an endpoint of their own.
+	ReportTemplates []ReportTemplate `yaml:"reportTemplates,omitempty"`
+
+	// SLOs are pass rate objectives for jobs, whose error budgets are tracked through the API and metrics.
+	SLOs []SLOConfig `yaml:"slos,omitempty"`
+
+	// TestNameRules rewrite test names as they are loaded, collapsing names that differ only by generated parts
+	// such as timestamps or resource names into one test.
+	TestNameRules []TestNameRule `yaml:"testNameRules,omitempty"`
+
+	// EnvironmentFingerprints are read from the artifacts of each job run as it is loaded, to flag failure spikes
+	// that started when a dependency changed. If empty, the OpenShift installer's version and commit are read.
+	EnvironmentFingerprints []EnvironmentFingerprint `yaml:"environmentFingerprints,omitempty"`
+}
+
+// EnvironmentFingerprint captures a value identifying a dependency of a job run, such as the installer version or
+// a base image digest, from the first match of Pattern in the first artifact whose path matches File.
+type EnvironmentFingerprint struct {
+	// Name identifies the fingerprint in the drift report, i.e. installer-version.
+	Name string `yaml:"name"`
+	// File is a regular expression matched against the paths of the run's artifacts.
+	File string `yaml:"file"`
+	// Pattern is a regular expression whose first capture group is the value, searched for in the first 256KiB of
+	// the file.
+	Pattern string `yaml:"pattern"`
+}
+
+// TestNameRule replaces every match of Pattern in a test name with Replacement, which may reference the pattern's
+// capture groups as $1 or ${name}.
+type TestNameRule struct {
+	// Name identifies the rule in the report of collapsed tests.
+	Name        string `yaml:"name"`
+	Pattern     string `yaml:"pattern"`
+	Replacement string `yaml:"replacement"`
+}
+
+// SLOConfig is a service level objective for the combined runs of a release's jobs, e.g. blocking jobs pass at
+// least 92% of the time over 28 days. A job is covered if it is listed in Jobs or matches any of JobRegexes, and has
+// all of Variants. With only Variants, every job with them is covered.
+type SLOConfig struct {
+	// Name uniquely identifies the SLO.
+	Name    string `yaml:"name"`
+	Release string `yaml:"release"`
+
+	Jobs       []string `yaml:"jobs,omitempty"`
+	JobRegexes []string `yaml:"jobRegexes,omitempty"`
+	Variants   []string `yaml:"variants,omitempty"`
+
+	// Target is the objective's pass percentage, e.g. 92.
+	Target float64 `yaml:"target"`
+	// WindowDays is how many days the objective is measured over, 28 if unset.
+	WindowDays int `yaml:"windowDays,omitempty"`
+}
+
+// ReportTemplate is a named SELECT against sippy's materialized views. Parameters are referenced in the SQL as
+// @name and are always bound, never interpolated.
+type ReportTemplate struct {
+	// Name identifies the template in the API, i.e. flakiest-tests.
+	Name        string                `yaml:"name"`
+	Description string                `yaml:"description,omitempty"`
+	SQL         string                `yaml:"sql"`
+	Params      []ReportTemplateParam `yaml:"params,omitempty"`
+}
+
+// ReportTemplateParam is a parameter of a report template, passed as the query parameter of the same name.
+type ReportTemplateParam struct {
+	Name string `yaml:"name"`
+	// Type is string, int, float, bool or date (YYYY-MM-DD), string if empty.
+	Type string `yaml:"type,omitempty"`
+	// Pattern is a regular expression string values must match.
+	Pattern  string `yaml:"pattern,omitempty"`
+	Required bool   `yaml:"required,omitempty"`
+	// Default is used when an optional parameter is not passed.
+	Default string `yaml:"default,omitempty"`
+}
+
+// IndicatorConfig defines a top level health indicator, the combined results of all tests named any of TestNames
+// or whose name matches any of TestRegexes.
+type IndicatorConfig struct {
+	// Name is the key of the indicator in the health API, i.e. install or conformance.
+	Name string `yaml:"name"`
+	// TestNames are exact test names, which are much cheaper to look up than regexes.
+	TestNames   []string `yaml:"testNames,omitempty"`
+	TestRegexes []string `yaml:"testRegexes,omitempty"`
+	// ExcludeVariants are variants whose jobs don't count towards the indicator.
+	ExcludeVariants []string `yaml:"excludeVariants,omitempty"`
 }
 
 type ProwConfig struct {
 	// URL to the prowjob.js endpoint of the prow instance. This endpoint contains
 	// a JSON file with all the ProwJob resources from the prow cluster.
 	URL string `yaml:"url"`
+
+	// Deployments are other prow instances to import jobs from, in addition to the one above.
+	Deployments []ProwDeployment `yaml:"deployments,omitempty"`
+}
+
+// ProwDeployment is a prow instance whose job artifacts may be laid out differently than OpenShift CI's. Job names
+// must be unique across all deployments.
+type ProwDeployment struct {
+	Name string `yaml:"name"`
+
+	// URL to the prowjobs.js endpoint of the prow instance.
+	URL string `yaml:"url"`
+
+	// GCSBucket holds the job artifacts, the --google-storage-bucket is used if empty.
+	GCSBucket string `yaml:"gcsBucket,omitempty"`
+
+	// PathTemplate is a Go template executed against each ProwJob to produce the path to the job run's artifacts
+	// in GCSBucket, i.e. "logs/{{.Spec.Job}}/{{.Status.BuildID}}". If empty, the path is taken from the job's URL
+	// as it is for OpenShift CI, which works for any prow deck serving artifacts under /view/gs/<bucket>/.
+	PathTemplate string `yaml:"pathTemplate,omitempty"`
+
+	// ArtifactURL is the base URL used to link to objects in GCSBucket, OpenShift CI's gcsweb if empty. Links are
+	// formed as <ArtifactURL>/<bucket>/<path>.
+	ArtifactURL string `yaml:"artifactURL,omitempty"`
+
+	// Mode selects how the deployment's jobs are classified into variants and which synthetic tests are created
+	// for them: ocp, okd or none. The --mode flag is used if empty, set this when mixing OpenShift and Kubernetes
+	// jobs in one instance.
+	Mode string `yaml:"mode,omitempty"`
 }
 
 type ReleaseConfig struct {
@@ -23,4 +148,91 @@ type ReleaseConfig struct {
 
 	// InformingJobs is the list of informing payload jobs
 	InformingJobs []string `yaml:"informingJobs,omitempty"`
+
+	// TestGridDashboards are the dashboards jobs outside the OpenShift blocking and informing payload dashboards
+	// are shown on, such as Kubernetes' presubmits-kubernetes-blocking, used to link jobs to testgrid.
+	TestGridDashboards []TestGridDashboard `yaml:"testGridDashboards,omitempty"`
+}
+
+// TestGridDashboard is a testgrid dashboard and the jobs with a tab on it. Tabs are assumed to be named after
+// their jobs, as prow's testgrid config generation does.
+type TestGridDashboard struct {
+	Name string `yaml:"name"`
+	// Jobs are the names of the jobs on the dashboard.
+	Jobs []string `yaml:"jobs,omitempty"`
+	// Regexp is a list of regular expressions matching jobs on the dashboard, i.e. ^pull-kubernetes-.
+	Regexp []string `yaml:"regexp,omitempty"`
+}
+
+// AlertingConfig defines alert rules evaluated after each refresh, and where to send notifications
+// when they begin firing.
+type AlertingConfig struct {
+	Rules []AlertRule `yaml:"rules,omitempty"`
+	// Notifiers receive every alert. Use notification routes to send only some alerts to a destination.
+	Notifiers []NotifierConfig `yaml:"notifiers,omitempty"`
+}
+
+// AlertRule is a condition on a variant's health, e.g. metal install success below 80% over the
+// last 7 days.
+type AlertRule struct {
+	// Name uniquely identifies the rule.
+	Name string `yaml:"name"`
+
+	Release string `yaml:"release"`
+	Variant string `yaml:"variant"`
+
+	// Metric is job_pass_percentage, the pass rate of all jobs with the variant, or test_pass_percentage,
+	// the pass rate (including flakes) of Test in jobs with the variant.
+	Metric string `yaml:"metric"`
+
+	// Test is the test name used by the test_pass_percentage metric.
+	Test string `yaml:"test,omitempty"`
+
+	// Period is default (last 7 days) or twoDay (last 2 days).
+	Period string `yaml:"period,omitempty"`
+
+	// Operator is one of <, <=, >, >= and is used to compare the metric to Threshold.
+	Operator  string  `yaml:"operator"`
+	Threshold float64 `yaml:"threshold"`
+
+	// MinRuns is the minimum number of runs required for the rule to fire, avoiding noise from
+	// variants with little data.
+	MinRuns int `yaml:"minRuns,omitempty"`
+}
+
+// NotificationConfig defines the senders notifications can be delivered with, and which notifications each
+// receives.
+type NotificationConfig struct {
+	Senders []NotifierConfig    `yaml:"senders,omitempty"`
+	Routes  []NotificationRoute `yaml:"routes,omitempty"`
+}
+
+// NotificationRoute sends the notifications matching all of its conditions to Senders.
+type NotificationRoute struct {
+	// Sources are the subsystems whose notifications match, i.e. alerts, load or watchlists. Empty matches all.
+	Sources []string `yaml:"sources,omitempty"`
+	// Names are regular expressions matched against what the notification is about, such as the alert rule
+	// name. Empty matches all.
+	Names []string `yaml:"names,omitempty"`
+	// MinSeverity is info, warning or critical. Empty matches all.
+	MinSeverity string `yaml:"minSeverity,omitempty"`
+	// Senders are the names of the senders to deliver to.
+	Senders []string `yaml:"senders"`
+}
+
+// NotifierConfig describes a destination for notifications.
+type NotifierConfig struct {
+	// Name identifies the sender in notification routes. Notifiers listed under alerting don't need one.
+	Name string `yaml:"name,omitempty"`
+	// Type is slack, googlechat, pagerduty or webhook. Slack and Google Chat URLs are incoming webhooks, generic
+	// webhooks receive the notification as JSON.
+	Type string `yaml:"type"`
+	// URL is required except for pagerduty, where it defaults to the Events API v2.
+	URL string `yaml:"url,omitempty"`
+	// RoutingKey is the integration key of the PagerDuty service to page.
+	RoutingKey string `yaml:"routingKey,omitempty"`
+	// PayloadVersion is the JSON body posted to generic webhooks. v1, the default, is the notification's details
+	// with a message field, which for alerts is the alert result and message webhooks have always received. v2 is
+	// the full notification: source, name, severity, summary and details.
+	PayloadVersion string `yaml:"payloadVersion,omitempty"`
 }
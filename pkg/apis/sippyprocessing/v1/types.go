@@ -69,7 +69,9 @@ type TestResult struct {
 type JobOverallResult string
 
 const (
-	JobSucceeded             JobOverallResult = "S"
+	JobSucceeded JobOverallResult = "S"
+	// JobSucceededWithFlakes is a run that succeeded, but only after one or more tests flaked.
+	JobSucceededWithFlakes   JobOverallResult = "L"
 	JobRunning               JobOverallResult = "R"
 	JobInfrastructureFailure JobOverallResult = "N"
 	JobInstallFailure        JobOverallResult = "I"
@@ -80,6 +82,11 @@ const (
 	JobUnknown               JobOverallResult = "f"
 )
 
+// IsSuccess is true for runs that succeeded, with or without test flakes.
+func (r JobOverallResult) IsSuccess() bool {
+	return r == JobSucceeded || r == JobSucceededWithFlakes
+}
+
 // JobRunResult represents a single invocation of a prow job and it's status, as well as any failed tests.
 type JobRunResult struct {
 	ProwID          uint     `json:"prowID"`
@@ -92,7 +99,9 @@ type JobRunResult struct {
 	InfrastructureFailure bool `json:"infrastructureFailure"`
 	// KnownFailure is true if the job run failed, but we found a bug that is likely related already filed.
 	KnownFailure bool `json:"knownFailure"`
-	Succeeded    bool `json:"succeeded"`
+	// ProbableCause is the category the job run classifier assigned a failed run, e.g. install or known_bug.
+	ProbableCause string `json:"probableCause,omitempty"`
+	Succeeded     bool   `json:"succeeded"`
 	// Timestamp is milliseconds since epoch when this job was run.
 	Timestamp     int              `json:"timestamp"`
 	OverallResult JobOverallResult `json:"result"`
@@ -139,9 +148,11 @@ type RawJobRunTestResult struct {
 // RawJobRunResult is an intermediate datatype that may not have complete or consistent data when interrogated.
 // It holds data for an individual run of a given job.
 type RawJobRunResult struct {
-	Job             string
-	JobRunURL       string
-	TestFailures    int
+	Job          string
+	JobRunURL    string
+	TestFailures int
+	// TestFlakes is the number of tests that failed at least once before passing.
+	TestFlakes      int
 	FailedTestNames []string // TODO: drop this and favor TestResults going forward, it has caused bugs.
 	TestResults     []RawJobRunTestResult
 	Failed          bool
@@ -160,6 +171,13 @@ type RawJobRunResult struct {
 	UpgradeForOperatorsStatus string
 	// Success, Failure, or ""
 	UpgradeForMachineConfigPoolsStatus string
+	// UpgradeRolledBack is true if the job run's artifacts show its upgrade was aborted or rolled back
+	UpgradeRolledBack bool
+
+	// HostedClusterCreateStatus and HostedClusterTeardownStatus can be "", "Success", "Failure", from the steps
+	// creating and tearing down a hypershift hosted cluster
+	HostedClusterCreateStatus   string
+	HostedClusterTeardownStatus string
 
 	// OpenShiftTestsStatus can be "", "Success", "Failure"
 	OpenShiftTestsStatus string
@@ -186,4 +204,7 @@ const (
 	TestStatusRunning TestStatus = 4
 	TestStatusFailure TestStatus = 12
 	TestStatusFlake   TestStatus = 13
+	// TestStatusTimeout is a failure caused by the suite running out of time rather than by the test's own
+	// assertions. It is not a TestGrid value, and is left out of pass rates.
+	TestStatusTimeout TestStatus = 14
 )
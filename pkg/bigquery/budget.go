@@ -0,0 +1,285 @@
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+)
+
+var (
+	bytesProcessedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sippy_bigquery_bytes_processed",
+		Help: "Bytes scanned by BigQuery queries, by the feature that ran them.",
+	}, []string{"source"})
+	queriesMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sippy_bigquery_queries",
+		Help: "BigQuery queries run, by the feature that ran them and whether they succeeded, failed, or were rejected by the daily budget.",
+	}, []string{"source", "result"})
+	queueWaitMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sippy_bigquery_queue_wait_seconds",
+		Help:    "Time BigQuery queries waited for another to finish before running.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 4, 8),
+	}, []string{"source"})
+	budgetRemainingMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sippy_bigquery_budget_remaining_bytes",
+		Help: "Bytes left in today's BigQuery budget.",
+	})
+)
+
+// ErrBudgetExceeded is returned for a query that would scan more bytes than are left in the day's budget.
+var ErrBudgetExceeded = errors.New("BigQuery daily byte budget exceeded")
+
+// Budget limits the bytes scanned by BigQuery queries each UTC day, and how many queries run at once, queueing the
+// rest. Queries are estimated with a dry run before they run, and refused if the estimate doesn't fit in what's
+// left of the day's budget.
+type Budget struct {
+	// DailyBytes is how many bytes may be scanned each day, 0 for no limit.
+	DailyBytes int64
+
+	queue     chan struct{}
+	lock      sync.Mutex
+	day       time.Time
+	used      int64
+	estimates map[string]int64
+}
+
+// NewBudget returns a budget of dailyBytes a day, 0 for no limit, running up to maxConcurrent queries at once, 0 for
+// no limit.
+func NewBudget(dailyBytes int64, maxConcurrent int) *Budget {
+	b := &Budget{DailyBytes: dailyBytes, estimates: map[string]int64{}}
+	if maxConcurrent > 0 {
+		b.queue = make(chan struct{}, maxConcurrent)
+	}
+	return b
+}
+
+// Used returns the bytes scanned, or reserved by running queries, on the day of now, and how many are left.
+// Remaining is -1 when there is no limit.
+func (b *Budget) Used(now time.Time) (used, remaining int64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rollover(now)
+	return b.used, b.remaining()
+}
+
+// rollover starts a new day's budget, and forgets the previous day's estimates as the tables will have grown.
+// The lock must be held.
+func (b *Budget) rollover(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if day.Equal(b.day) {
+		return
+	}
+	b.day = day
+	b.used = 0
+	b.estimates = map[string]int64{}
+	if b.DailyBytes > 0 {
+		budgetRemainingMetric.Set(float64(b.DailyBytes))
+	}
+}
+
+// remaining returns the bytes left today, or -1 when there is no limit. The lock must be held.
+func (b *Budget) remaining() int64 {
+	if b.DailyBytes <= 0 {
+		return -1
+	}
+	return max(b.DailyBytes-b.used, 0)
+}
+
+// reserve counts estimate bytes against the budget of the day of now, if they fit.
+func (b *Budget) reserve(estimate int64, now time.Time) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rollover(now)
+	if left := b.remaining(); left >= 0 && estimate > left {
+		return fmt.Errorf("%w: query would scan %d bytes with %d of %d left today", ErrBudgetExceeded, estimate, left,
+			b.DailyBytes)
+	}
+	b.used += estimate
+	b.updateMetric()
+	return nil
+}
+
+// settle replaces a reservation with the bytes the query actually scanned, which is 0 if it failed.
+func (b *Budget) settle(estimate, actual int64, reservedAt, now time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.rollover(now)
+	if b.day.Equal(reservedAt.UTC().Truncate(24 * time.Hour)) {
+		b.used -= estimate
+	}
+	b.used += actual
+	b.updateMetric()
+}
+
+// updateMetric must be called with the lock held.
+func (b *Budget) updateMetric() {
+	if left := b.remaining(); left >= 0 {
+		budgetRemainingMetric.Set(float64(left))
+	}
+}
+
+func (b *Budget) cachedEstimate(key string) (int64, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	estimate, ok := b.estimates[key]
+	return estimate, ok
+}
+
+func (b *Budget) cacheEstimate(key string, estimate int64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.estimates[key] = estimate
+}
+
+// wait blocks until fewer than the maximum queries are running, returning a func to call when the query is done.
+func (b *Budget) wait(ctx context.Context, source string) (func(), error) {
+	if b.queue == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	select {
+	case b.queue <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	queueWaitMetric.WithLabelValues(source).Observe(time.Since(start).Seconds())
+	return func() { <-b.queue }, nil
+}
+
+// queryKey identifies a query by its SQL and parameters.
+func queryKey(q *bigquery.Query) string {
+	return fmt.Sprintf("%s\n%+v", q.Q, q.Parameters)
+}
+
+// EstimateBytes returns how many bytes q would scan, from a dry run. Estimates are cached for the rest of the day.
+func (c *Client) EstimateBytes(ctx context.Context, q *bigquery.Query) (int64, error) {
+	key := queryKey(q)
+	if c.Budget != nil {
+		if estimate, ok := c.Budget.cachedEstimate(key); ok {
+			return estimate, nil
+		}
+	}
+	dryRun := *q
+	dryRun.DryRun = true
+	job, err := dryRun.Run(ctx)
+	if err != nil {
+		return 0, err
+	}
+	status := job.LastStatus()
+	if status == nil || status.Statistics == nil {
+		return 0, fmt.Errorf("dry run returned no statistics")
+	}
+	if c.Budget != nil {
+		c.Budget.cacheEstimate(key, status.Statistics.TotalBytesProcessed)
+	}
+	return status.Statistics.TotalBytesProcessed, nil
+}
+
+// Read runs q and returns its rows, counting the bytes it scanned against the client's budget under source, which
+// names the feature running the query in metrics and logs. When the budget is limited, the query is first estimated
+// with a dry run and refused with ErrBudgetExceeded if it doesn't fit. It waits its turn when the budget's maximum
+// queries are already running.
+//
+// Every sippy query should be read this way. The only exceptions are the persistent cache's lookups of cached
+// results, which are small and made on behalf of a query that was already budgeted, and the variant registry, which
+// runs from its own command with a plain BigQuery client.
+func (c *Client) Read(ctx context.Context, q *bigquery.Query, source string) (*bigquery.RowIterator, error) {
+	var estimate int64
+	reservedAt := time.Now()
+	if c.Budget != nil && c.Budget.DailyBytes > 0 {
+		var err error
+		estimate, err = c.EstimateBytes(ctx, q)
+		if err != nil {
+			queriesMetric.WithLabelValues(source, "error").Inc()
+			return nil, err
+		}
+		if err := c.Budget.reserve(estimate, reservedAt); err != nil {
+			log.WithField("source", source).WithError(err).Warning("refusing BigQuery query")
+			queriesMetric.WithLabelValues(source, "rejected").Inc()
+			return nil, err
+		}
+	}
+
+	var actual int64
+	if c.Budget != nil {
+		done, err := c.Budget.wait(ctx, source)
+		if err != nil {
+			c.Budget.settle(estimate, 0, reservedAt, time.Now())
+			return nil, err
+		}
+		defer func() {
+			done()
+			c.Budget.settle(estimate, actual, reservedAt, time.Now())
+		}()
+	}
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		queriesMetric.WithLabelValues(source, "error").Inc()
+		return nil, err
+	}
+	status, err := job.Wait(ctx)
+	if err == nil {
+		err = status.Err()
+	}
+	if status != nil && status.Statistics != nil {
+		actual = status.Statistics.TotalBytesProcessed
+		bytesProcessedMetric.WithLabelValues(source).Add(float64(actual))
+	}
+	if err != nil {
+		queriesMetric.WithLabelValues(source, "error").Inc()
+		return nil, err
+	}
+	queriesMetric.WithLabelValues(source, "success").Inc()
+	return job.Read(ctx)
+}
+
+// ReadAll runs q with Read and returns all its rows as T. When cacheDuration is set and the client has a cache, the
+// rows are cached for that long, and identical queries made at the same time share a single run.
+func ReadAll[T any](ctx context.Context, c *Client, q *bigquery.Query, source string, cacheDuration time.Duration) ([]T, error) {
+	read := func(ctx context.Context) ([]T, error) {
+		it, err := c.Read(ctx, q, source)
+		if err != nil {
+			return nil, err
+		}
+		rows := []T{}
+		for {
+			var row T
+			err := it.Next(&row)
+			if err == iterator.Done {
+				return rows, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+	if c.Cache == nil || cacheDuration == 0 {
+		return read(ctx)
+	}
+
+	content, err := cache.GetOrSet(ctx, c.Cache, "bigquery~"+queryKey(q), cacheDuration, func(ctx context.Context) ([]byte, error) {
+		rows, err := read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows := []T{}
+	return rows, json.Unmarshal(content, &rows)
+}
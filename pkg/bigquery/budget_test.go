@@ -0,0 +1,64 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBudget(1000, 0)
+
+	assert.NoError(t, b.reserve(600, now))
+	used, remaining := b.Used(now)
+	assert.Equal(t, int64(600), used)
+	assert.Equal(t, int64(400), remaining)
+
+	// a second query that would exceed the budget while the first is still reserved is refused
+	err := b.reserve(500, now)
+	assert.True(t, errors.Is(err, ErrBudgetExceeded), "unexpected error: %v", err)
+
+	// the first query scanned less than estimated, leaving room for the second
+	b.settle(600, 300, now, now)
+	assert.NoError(t, b.reserve(500, now))
+	b.settle(500, 0, now, now)
+	used, _ = b.Used(now)
+	assert.Equal(t, int64(300), used)
+
+	// the next day starts a fresh budget, and a query reserved yesterday only counts what it scanned
+	tomorrow := now.Add(24 * time.Hour)
+	assert.NoError(t, b.reserve(100, now))
+	b.settle(100, 200, now, tomorrow)
+	used, remaining = b.Used(tomorrow)
+	assert.Equal(t, int64(200), used)
+	assert.Equal(t, int64(800), remaining)
+}
+
+func TestBudgetUnlimited(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBudget(0, 0)
+	assert.NoError(t, b.reserve(1<<50, now))
+	_, remaining := b.Used(now)
+	assert.Equal(t, int64(-1), remaining)
+}
+
+func TestBudgetQueue(t *testing.T) {
+	b := NewBudget(0, 1)
+	done, err := b.wait(context.Background(), "test")
+	assert.NoError(t, err)
+
+	// a second query waits until the first is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = b.wait(ctx, "test")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done()
+	done, err = b.wait(context.Background(), "test")
+	assert.NoError(t, err)
+	done()
+}
@@ -14,6 +14,8 @@ type Client struct {
 	BQ      *bigquery.Client
 	Cache   cache.Cache
 	Dataset string
+	// Budget limits the bytes queries run with Read scan each day and how many run at once, nil for no limits.
+	Budget *Budget
 }
 
 func New(ctx context.Context, credentialFile, project, dataset string, c cache.Cache) (*Client, error) {
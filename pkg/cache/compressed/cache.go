@@ -72,6 +72,15 @@ func (c Cache) Set(ctx context.Context, key string, content []byte, duration tim
 	return c.Cache.Set(ctx, cachePrefix+key, data, duration)
 }
 
+// Purge removes entries starting with prefix if the underlying cache supports it.
+func (c Cache) Purge(ctx context.Context, prefix string) (int, error) {
+	purger, ok := c.Cache.(cache.Purger)
+	if !ok {
+		return 0, fmt.Errorf("cache does not support purging")
+	}
+	return purger.Purge(ctx, cachePrefix+prefix)
+}
+
 func compress(value []byte) ([]byte, [16]byte, error) {
 	var buf bytes.Buffer
 	sum := md5.Sum(value) // nolint:gosec
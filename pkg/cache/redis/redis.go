@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -43,3 +44,29 @@ func (c Cache) Set(_ context.Context, key string, content []byte, duration time.
 	}(key, before)
 	return c.client.Set(prefix+key, content, duration).Err()
 }
+
+// Purge removes all keys starting with keyPrefix. Keys are found with SCAN so redis is not blocked.
+func (c Cache) Purge(_ context.Context, keyPrefix string) (int, error) {
+	match := globEscaper.Replace(prefix+keyPrefix) + "*"
+	var cursor uint64
+	purged := 0
+	for {
+		keys, next, err := c.client.Scan(cursor, match, 1000).Result()
+		if err != nil {
+			return purged, err
+		}
+		if len(keys) > 0 {
+			n, err := c.client.Del(keys...).Result()
+			if err != nil {
+				return purged, err
+			}
+			purged += int(n)
+		}
+		if next == 0 {
+			return purged, nil
+		}
+		cursor = next
+	}
+}
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
@@ -0,0 +1,111 @@
+// Package autoloader periodically runs the sippy data load from within a long-running process, such as the
+// server, so a separate cron job is not needed.
+package autoloader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	runsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sippy_auto_load_runs_total",
+		Help: "Scheduled data loads by result: success, failure, skipped when the previous load was still running, or not_leader when another replica loads",
+	}, []string{"result"})
+	durationMetric = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sippy_auto_load_millis",
+		Help:    "Milliseconds taken by a scheduled data load",
+		Buckets: []float64{60000, 300000, 600000, 1200000, 1800000, 3600000, 7200000, 14400000},
+	})
+	runningMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sippy_auto_load_running",
+		Help: "1 while a scheduled data load is running",
+	})
+	lastSuccessMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sippy_auto_load_last_success_timestamp_seconds",
+		Help: "Unix time the last scheduled data load completed successfully",
+	})
+)
+
+const (
+	resultSuccess   = "success"
+	resultFailure   = "failure"
+	resultSkipped   = "skipped"
+	resultNotLeader = "not_leader"
+)
+
+// AutoLoader runs a load function on an interval. Only one load runs at a time; a tick that arrives while the
+// previous load is still in progress is skipped rather than queued.
+type AutoLoader struct {
+	interval time.Duration
+	load     func(context.Context) error
+	running  sync.Mutex
+	// isLeader, if set, must return true for this replica to load.
+	isLeader func() bool
+}
+
+func New(interval time.Duration, load func(context.Context) error) *AutoLoader {
+	return &AutoLoader{
+		interval: interval,
+		load:     load,
+	}
+}
+
+// SetLeaderCheck makes loads run only while isLeader returns true, so that when several replicas share a
+// database only one of them loads.
+func (a *AutoLoader) SetLeaderCheck(isLeader func() bool) {
+	a.isLeader = isLeader
+}
+
+// Run loads immediately, then on every interval until the context is canceled.
+func (a *AutoLoader) Run(ctx context.Context) {
+	log.WithField("interval", a.interval).Info("starting scheduled data loads")
+	go a.trigger(ctx)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			go a.trigger(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// trigger runs a load unless one is already in progress, and reports whether it ran.
+func (a *AutoLoader) trigger(ctx context.Context) bool {
+	if a.isLeader != nil && !a.isLeader() {
+		log.Debug("not the leader, skipping scheduled data load")
+		runsMetric.WithLabelValues(resultNotLeader).Inc()
+		return false
+	}
+	if !a.running.TryLock() {
+		log.Warning("previous scheduled data load is still running, skipping")
+		runsMetric.WithLabelValues(resultSkipped).Inc()
+		return false
+	}
+	defer a.running.Unlock()
+
+	runningMetric.Set(1)
+	defer runningMetric.Set(0)
+
+	start := time.Now()
+	err := a.load(ctx)
+	durationMetric.Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		log.WithError(err).Error("scheduled data load failed")
+		runsMetric.WithLabelValues(resultFailure).Inc()
+		return true
+	}
+	log.WithField("duration", time.Since(start)).Info("scheduled data load complete")
+	runsMetric.WithLabelValues(resultSuccess).Inc()
+	lastSuccessMetric.SetToCurrentTime()
+	return true
+}
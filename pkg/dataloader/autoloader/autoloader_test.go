@@ -0,0 +1,58 @@
+package autoloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerSkipsOverlappingLoads(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	a := New(time.Hour, func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	done := make(chan bool)
+	go func() {
+		done <- a.trigger(context.Background())
+	}()
+	<-started
+
+	assert.False(t, a.trigger(context.Background()), "a load started while another is running should be skipped")
+
+	close(release)
+	assert.True(t, <-done)
+}
+
+func TestTriggerRunsAfterFailure(t *testing.T) {
+	calls := 0
+	a := New(time.Hour, func(context.Context) error {
+		calls++
+		return errors.New("load failed")
+	})
+
+	assert.True(t, a.trigger(context.Background()))
+	assert.True(t, a.trigger(context.Background()), "a failed load should not block the next one")
+	assert.Equal(t, 2, calls)
+}
+
+func TestTriggerOnlyLoadsOnLeader(t *testing.T) {
+	calls := 0
+	a := New(time.Hour, func(context.Context) error {
+		calls++
+		return nil
+	})
+	leader := false
+	a.SetLeaderCheck(func() bool { return leader })
+
+	assert.False(t, a.trigger(context.Background()), "a replica that isn't the leader should not load")
+	leader = true
+	assert.True(t, a.trigger(context.Background()))
+	assert.Equal(t, 1, calls)
+}
@@ -33,7 +33,7 @@ const (
   FROM
     openshift-ci-data-analysis.jira_data.tickets_dedup t
   LEFT JOIN UNNEST(t.comments) AS c
-  WHERE t.summary IS NOT NULL AND last_changed_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 14 DAY)
+  WHERE t.summary IS NOT NULL AND last_changed_time >= @since
 )
 SELECT
   t.issue.key as key,
@@ -41,6 +41,8 @@ SELECT
   t.summary as summary,
   j.name AS link_name,
   t.last_changed_time as last_changed_time,
+  t.created_time as created_time,
+  t.resolution_date as resolution_date,
   t.status.name as status,
   ARRAY(SELECT name FROM UNNEST(affects_versions)) as affects_versions,
   ARRAY(SELECT name FROM UNNEST(fix_versions)) as fix_versions,
@@ -48,6 +50,12 @@ SELECT
   t.labels as labels
 FROM
   TicketData t`
+
+	// bugLookback is how far back a full sync looks for changed bugs, bugs unchanged for longer are removed.
+	bugLookback = 14 * 24 * time.Hour
+	// syncOverlap is subtracted from the last change time we have when syncing incrementally, to allow for lag in
+	// the jira data in bigquery.
+	syncOverlap = 2 * time.Hour
 )
 
 type BugLoader struct {
@@ -61,6 +69,8 @@ type bigQueryBug struct {
 	Key             string             `json:"key" bigquery:"key"`
 	Status          string             `json:"status" bigquery:"status"`
 	LastChangedTime bqgo.NullTimestamp `json:"last_changed_time" bigquery:"last_changed_time"`
+	CreatedTime     bqgo.NullTimestamp `json:"created_time" bigquery:"created_time"`
+	ResolutionDate  bqgo.NullTimestamp `json:"resolution_date" bigquery:"resolution_date"`
 	Summary         string             `json:"summary" bigquery:"summary"`
 	AffectsVersions []string           `json:"affects_versions" bigquery:"affects_versions"`
 	FixVersions     []string           `json:"fix_versions" bigquery:"fix_versions"`
@@ -87,6 +97,14 @@ func (bl *BugLoader) Errors() []error {
 
 func (bl *BugLoader) Load() {
 	dbExpectedBugs := make([]*models.Bug, 0)
+	syncTime := time.Now()
+
+	since, full, err := bl.syncSince(syncTime)
+	if err != nil {
+		bl.errors = append(bl.errors, err)
+		return
+	}
+	log.WithFields(log.Fields{"since": since, "full": full}).Info("syncing bugs changed since last sync")
 
 	// Fetch bugs<->test mapping from bigquery
 	testCache, err := loadTestCache(bl.dbc, []string{})
@@ -94,7 +112,7 @@ func (bl *BugLoader) Load() {
 		bl.errors = append(bl.errors, err)
 		return
 	}
-	testBugs, err := bl.getTestBugMappings(context.TODO(), testCache)
+	testBugs, err := bl.getTestBugMappings(context.TODO(), testCache, since)
 	if err != nil {
 		panic(err)
 	}
@@ -105,7 +123,7 @@ func (bl *BugLoader) Load() {
 		bl.errors = append(bl.errors, err)
 		return
 	}
-	jobBugs, err := bl.getJobBugMappings(context.TODO(), jobCache)
+	jobBugs, err := bl.getJobBugMappings(context.TODO(), jobCache, since)
 	if err != nil {
 		panic(err)
 	}
@@ -123,10 +141,23 @@ func (bl *BugLoader) Load() {
 		dbExpectedBugs = append(dbExpectedBugs, b)
 	}
 
-	// Find or create new bugs and mappings
+	existingBugs, err := bl.loadExistingBugs()
+	if err != nil {
+		bl.errors = append(bl.errors, err)
+		return
+	}
+
+	// Find or create new bugs and mappings, bugs that haven't changed since the last sync only have their sync
+	// time updated.
 	expectedBugIDs := make([]uint, 0, len(dbExpectedBugs))
+	unchangedBugIDs := make([]uint, 0)
 	for _, bug := range dbExpectedBugs {
 		expectedBugIDs = append(expectedBugIDs, bug.ID)
+		if bugUnchanged(existingBugs[bug.ID], bug) {
+			unchangedBugIDs = append(unchangedBugIDs, bug.ID)
+			continue
+		}
+		bug.LastSyncTime = syncTime
 		res := bl.dbc.DB.Clauses(clause.OnConflict{
 			UpdateAll: true,
 		}).Create(bug)
@@ -152,10 +183,22 @@ func (bl *BugLoader) Load() {
 			continue
 		}
 	}
-	log.Infof("created or updated %d bugs", len(expectedBugIDs))
-
-	// Remove old unseen bugs
-	res := bl.dbc.DB.Where("id not in ?", expectedBugIDs).Unscoped().Delete(&models.Bug{})
+	if len(unchangedBugIDs) > 0 {
+		res := bl.dbc.DB.Model(&models.Bug{}).Where("id IN ?", unchangedBugIDs).UpdateColumn("last_sync_time", syncTime)
+		if res.Error != nil {
+			bl.errors = append(bl.errors, errors.Wrap(res.Error, "error updating bug sync time"))
+		}
+	}
+	log.Infof("created or updated %d bugs, skipped %d unchanged bugs", len(expectedBugIDs)-len(unchangedBugIDs), len(unchangedBugIDs))
+
+	// Remove old unseen bugs. An incremental sync only sees recently changed bugs, so there we remove the bugs
+	// a full sync would no longer have found.
+	var res *gorm.DB
+	if full {
+		res = bl.dbc.DB.Where("id not in ?", expectedBugIDs).Unscoped().Delete(&models.Bug{})
+	} else {
+		res = bl.dbc.DB.Where("last_change_time < ?", syncTime.Add(-bugLookback)).Unscoped().Delete(&models.Bug{})
+	}
 	if res.Error != nil {
 		err := errors.Wrap(res.Error, "error deleting stale bugs")
 		bl.errors = append(bl.errors, err)
@@ -168,9 +211,93 @@ func (bl *BugLoader) Load() {
 	}
 }
 
+// syncSince returns the last change time to sync bugs from. If we have bugs this is shortly before the most
+// recent change we know of, otherwise full is true and we sync everything in the lookback window.
+func (bl *BugLoader) syncSince(now time.Time) (since time.Time, full bool, err error) {
+	var latest *time.Time
+	res := bl.dbc.DB.Model(&models.Bug{}).Select("MAX(last_change_time)").Scan(&latest)
+	if res.Error != nil {
+		return time.Time{}, false, errors.Wrap(res.Error, "error querying most recent bug change")
+	}
+	since, full = syncSinceTime(latest, now)
+	return since, full, nil
+}
+
+func syncSinceTime(latest *time.Time, now time.Time) (time.Time, bool) {
+	oldest := now.Add(-bugLookback)
+	if latest == nil || latest.Add(-syncOverlap).Before(oldest) {
+		return oldest, true
+	}
+	return latest.Add(-syncOverlap), false
+}
+
+// loadExistingBugs returns the bugs in the database with their test and job associations, keyed by ID.
+func (bl *BugLoader) loadExistingBugs() (map[uint]*models.Bug, error) {
+	bugs := []*models.Bug{}
+	res := bl.dbc.DB.Preload("Tests").Preload("Jobs").Find(&bugs)
+	if res.Error != nil {
+		return nil, errors.Wrap(res.Error, "error loading existing bugs")
+	}
+	existing := make(map[uint]*models.Bug, len(bugs))
+	for _, b := range bugs {
+		existing[b.ID] = b
+	}
+	return existing, nil
+}
+
+// bugUnchanged returns true if the bug has not changed in jira since it was stored, and is still associated
+// with the same tests and jobs.
+func bugUnchanged(existing, bug *models.Bug) bool {
+	if existing == nil || !existing.LastChangeTime.Equal(bug.LastChangeTime) {
+		return false
+	}
+	// Bugs stored before we recorded when they were opened need updating.
+	if existing.OpenedTime == nil && bug.OpenedTime != nil {
+		return false
+	}
+	return sameIDs(testIDs(existing.Tests), testIDs(bug.Tests)) && sameIDs(jobIDs(existing.Jobs), jobIDs(bug.Jobs))
+}
+
+func testIDs(tests []models.Test) []uint {
+	ids := make([]uint, 0, len(tests))
+	for _, t := range tests {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func jobIDs(jobs []models.ProwJob) []uint {
+	ids := make([]uint, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID)
+	}
+	return ids
+}
+
+// sameIDs compares the IDs as sets, the bigquery results contain an association once per matching comment.
+func sameIDs(a, b []uint) bool {
+	toSet := func(ids []uint) map[uint]bool {
+		set := make(map[uint]bool, len(ids))
+		for _, id := range ids {
+			set[id] = true
+		}
+		return set
+	}
+	setA, setB := toSet(a), toSet(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for id := range setA {
+		if !setB[id] {
+			return false
+		}
+	}
+	return true
+}
+
 // getTestBugMappings looks for jira cards that contain a test name from the ci-test-mapping database in bigquery.  We
 // search the Jira comments, description and summary for the test name.
-func (bl *BugLoader) getTestBugMappings(ctx context.Context, testCache map[string]*models.Test) (map[uint]*models.Bug, error) {
+func (bl *BugLoader) getTestBugMappings(ctx context.Context, testCache map[string]*models.Test, since time.Time) (map[uint]*models.Bug, error) {
 	bugs := make(map[uint]*models.Bug)
 
 	// `WHERE j.name != upgrade` is because there's a test named just `upgrade` in some junits, which querying
@@ -180,8 +307,14 @@ func (bl *BugLoader) getTestBugMappings(ctx context.Context, testCache map[strin
 		TicketDataQuery, ComponentMappingProject, ComponentMappingDataset, ComponentMappingTable)
 	log.Debugf(querySQL)
 	query := bl.bqc.BQ.Query(querySQL)
+	query.Parameters = []bqgo.QueryParameter{
+		{
+			Name:  "since",
+			Value: since,
+		},
+	}
 
-	it, err := query.Read(ctx)
+	it, err := bl.bqc.Read(ctx, query, "bug_loader")
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to execute query")
 	}
@@ -226,7 +359,7 @@ func (bl *BugLoader) getTestBugMappings(ctx context.Context, testCache map[strin
 
 // getJobBugMappings looks for jira cards that contain a job name from the jobs table in bigquery.  We
 // search the Jira comments, description and summary for the job name.
-func (bl *BugLoader) getJobBugMappings(ctx context.Context, jobCache map[string]*models.ProwJob) (map[uint]*models.Bug, error) {
+func (bl *BugLoader) getJobBugMappings(ctx context.Context, jobCache map[string]*models.ProwJob, since time.Time) (map[uint]*models.Bug, error) {
 	bugs := make(map[uint]*models.Bug)
 
 	querySQL := fmt.Sprintf(
@@ -234,8 +367,14 @@ func (bl *BugLoader) getJobBugMappings(ctx context.Context, jobCache map[string]
 		TicketDataQuery)
 	log.Debugf(querySQL)
 	query := bl.bqc.BQ.Query(querySQL)
+	query.Parameters = []bqgo.QueryParameter{
+		{
+			Name:  "since",
+			Value: since,
+		},
+	}
 
-	it, err := query.Read(ctx)
+	it, err := bl.bqc.Read(ctx, query, "bug_loader")
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to execute query")
 	}
@@ -356,6 +495,8 @@ func bigQueryBugToModel(bqBug bigQueryBug) *models.Bug {
 		Key:             bqBug.Key,
 		Status:          bqBug.Status,
 		LastChangeTime:  lastChange,
+		OpenedTime:      nullTimestampToTime(bqBug.CreatedTime),
+		ResolvedTime:    nullTimestampToTime(bqBug.ResolutionDate),
 		Summary:         bqBug.Summary,
 		AffectsVersions: pq.StringArray(bqBug.AffectsVersions),
 		FixVersions:     pq.StringArray(bqBug.FixVersions),
@@ -364,3 +505,10 @@ func bigQueryBugToModel(bqBug bigQueryBug) *models.Bug {
 		URL:             fmt.Sprintf("https://issues.redhat.com/browse/%s", bqBug.Key),
 	}
 }
+
+func nullTimestampToTime(ts bqgo.NullTimestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Timestamp
+}
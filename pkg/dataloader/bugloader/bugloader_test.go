@@ -0,0 +1,107 @@
+package bugloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestSyncSinceTime(t *testing.T) {
+	now := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-6 * time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		latest    *time.Time
+		wantSince time.Time
+		wantFull  bool
+	}{
+		{
+			name:      "no bugs does a full sync",
+			wantSince: now.Add(-bugLookback),
+			wantFull:  true,
+		},
+		{
+			name:      "recent change syncs incrementally with overlap",
+			latest:    &recent,
+			wantSince: recent.Add(-syncOverlap),
+		},
+		{
+			name:      "change older than the lookback does a full sync",
+			latest:    &old,
+			wantSince: now.Add(-bugLookback),
+			wantFull:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, full := syncSinceTime(tt.latest, now)
+			assert.Equal(t, tt.wantSince, since)
+			assert.Equal(t, tt.wantFull, full)
+		})
+	}
+}
+
+func TestBugUnchanged(t *testing.T) {
+	changed := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	existing := &models.Bug{
+		ID:             1,
+		LastChangeTime: changed,
+		Tests:          []models.Test{{Model: gorm.Model{ID: 10}}, {Model: gorm.Model{ID: 11}}},
+		Jobs:           []models.ProwJob{{Model: gorm.Model{ID: 20}}},
+	}
+
+	tests := []struct {
+		name     string
+		existing *models.Bug
+		bug      *models.Bug
+		want     bool
+	}{
+		{
+			name: "new bug",
+			bug:  &models.Bug{ID: 1, LastChangeTime: changed},
+		},
+		{
+			name:     "same change time and associations",
+			existing: existing,
+			bug: &models.Bug{
+				ID:             1,
+				LastChangeTime: changed,
+				// bigquery returns an association once per matching comment
+				Tests: []models.Test{{Model: gorm.Model{ID: 11}}, {Model: gorm.Model{ID: 10}}, {Model: gorm.Model{ID: 10}}},
+				Jobs:  []models.ProwJob{{Model: gorm.Model{ID: 20}}},
+			},
+			want: true,
+		},
+		{
+			name:     "changed in jira",
+			existing: existing,
+			bug: &models.Bug{
+				ID:             1,
+				LastChangeTime: changed.Add(time.Hour),
+				Tests:          existing.Tests,
+				Jobs:           existing.Jobs,
+			},
+		},
+		{
+			name:     "newly associated job",
+			existing: existing,
+			bug: &models.Bug{
+				ID:             1,
+				LastChangeTime: changed,
+				Tests:          existing.Tests,
+				Jobs:           []models.ProwJob{{Model: gorm.Model{ID: 20}}, {Model: gorm.Model{ID: 21}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bugUnchanged(tt.existing, tt.bug))
+		})
+	}
+}
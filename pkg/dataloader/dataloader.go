@@ -10,3 +10,26 @@ type DataLoader interface {
 	// Errors returns a slice of errors that occurred during the data loading process.
 	Errors() []error
 }
+
+// WarningLoader is a DataLoader that also reports problems that don't fail the load.
+type WarningLoader interface {
+	DataLoader
+
+	// Warnings returns the problems found during the data loading process.
+	Warnings() []string
+}
+
+// Counts are the rows a loader inserted.
+type Counts struct {
+	JobRuns     int64
+	TestResults int64
+}
+
+// CountingLoader is a DataLoader that reports how many rows it inserted, so a load can be summarized without
+// querying for rows created during it.
+type CountingLoader interface {
+	DataLoader
+
+	// Counts returns the rows inserted by the data loading process.
+	Counts() Counts
+}
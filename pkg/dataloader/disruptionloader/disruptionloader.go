@@ -0,0 +1,174 @@
+// Package disruptionloader copies backend disruption percentiles from BigQuery into postgres, so disruption
+// regression detection and the disruption APIs can work without BigQuery.
+package disruptionloader
+
+import (
+	"context"
+	"time"
+
+	bqgo "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	// initialLoadDays is how far back we load when the table is empty.
+	initialLoadDays = 60
+	// reloadDays are reloaded on each run, the most recent days of the view may still be changing as job runs
+	// are imported into BigQuery.
+	reloadDays = 2
+
+	percentilesQuery = `
+SELECT
+  ReportDate, LookbackDays, Release, IFNULL(FromRelease, '') AS FromRelease, BackendName, Platform,
+  UpgradeType, MasterNodesUpdated, Network, Topology, Architecture, JobRuns, P50, P75, P95, P99,
+  PercentageAboveZero
+FROM openshift-ci-data-analysis.ci_data.BackendDisruptionPercentilesByDate
+WHERE ReportDate >= @Since`
+)
+
+type DisruptionLoader struct {
+	dbc    *db.DB
+	bqc    *bigquery.Client
+	errors []error
+}
+
+type bigQueryPercentile struct {
+	ReportDate          bqgo.NullDate `bigquery:"ReportDate"`
+	LookbackDays        int           `bigquery:"LookbackDays"`
+	Release             string        `bigquery:"Release"`
+	FromRelease         string        `bigquery:"FromRelease"`
+	BackendName         string        `bigquery:"BackendName"`
+	Platform            string        `bigquery:"Platform"`
+	UpgradeType         string        `bigquery:"UpgradeType"`
+	MasterNodesUpdated  string        `bigquery:"MasterNodesUpdated"`
+	Network             string        `bigquery:"Network"`
+	Topology            string        `bigquery:"Topology"`
+	Architecture        string        `bigquery:"Architecture"`
+	JobRuns             int           `bigquery:"JobRuns"`
+	P50                 float64       `bigquery:"P50"`
+	P75                 float64       `bigquery:"P75"`
+	P95                 float64       `bigquery:"P95"`
+	P99                 float64       `bigquery:"P99"`
+	PercentageAboveZero float64       `bigquery:"PercentageAboveZero"`
+}
+
+func New(dbc *db.DB, bqc *bigquery.Client) *DisruptionLoader {
+	return &DisruptionLoader{
+		dbc: dbc,
+		bqc: bqc,
+	}
+}
+
+func (dl *DisruptionLoader) Name() string {
+	return "disruption"
+}
+
+func (dl *DisruptionLoader) Errors() []error {
+	return dl.errors
+}
+
+func (dl *DisruptionLoader) Load() {
+	since, err := dl.loadSince(time.Now())
+	if err != nil {
+		dl.errors = append(dl.errors, err)
+		return
+	}
+	log.WithField("since", since.Format("2006-01-02")).Info("loading disruption percentiles from bigquery")
+
+	rows, err := dl.fetchPercentiles(context.TODO(), since)
+	if err != nil {
+		dl.errors = append(dl.errors, err)
+		return
+	}
+
+	if len(rows) > 0 {
+		res := dl.dbc.DB.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "date"}, {Name: "lookback_days"}, {Name: "release"}, {Name: "from_release"},
+				{Name: "backend_name"}, {Name: "platform"}, {Name: "upgrade_type"}, {Name: "master_nodes_updated"},
+				{Name: "network"}, {Name: "topology"}, {Name: "architecture"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "job_runs", "p50", "p75", "p95", "p99", "percentage_above_zero"}),
+		}).CreateInBatches(rows, 1000)
+		if res.Error != nil {
+			dl.errors = append(dl.errors, errors.Wrap(res.Error, "error saving disruption percentiles"))
+			return
+		}
+	}
+	log.Infof("loaded %d disruption percentile rows", len(rows))
+}
+
+// loadSince returns the first day to load: a few days before the most recent day we have, so late arriving job
+// runs are picked up, or initialLoadDays ago if we have nothing.
+func (dl *DisruptionLoader) loadSince(now time.Time) (time.Time, error) {
+	var latest *time.Time
+	res := dl.dbc.DB.Model(&models.DisruptionPercentile{}).Select("MAX(date)").Scan(&latest)
+	if res.Error != nil {
+		return time.Time{}, errors.Wrap(res.Error, "error querying most recent disruption percentiles")
+	}
+	return loadSinceDate(latest, now), nil
+}
+
+func loadSinceDate(latest *time.Time, now time.Time) time.Time {
+	if latest == nil || latest.IsZero() {
+		return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -initialLoadDays)
+	}
+	return latest.UTC().Truncate(24*time.Hour).AddDate(0, 0, -reloadDays)
+}
+
+func (dl *DisruptionLoader) fetchPercentiles(ctx context.Context, since time.Time) ([]models.DisruptionPercentile, error) {
+	q := dl.bqc.BQ.Query(percentilesQuery)
+	q.Parameters = []bqgo.QueryParameter{
+		{
+			Name:  "Since",
+			Value: civil.DateOf(since),
+		},
+	}
+	it, err := dl.bqc.Read(ctx, q, "disruption_loader")
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying disruption percentiles from bigquery")
+	}
+
+	rows := []models.DisruptionPercentile{}
+	for {
+		r := bigQueryPercentile{}
+		err := it.Next(&r)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing disruption percentile row from bigquery")
+		}
+		if !r.ReportDate.Valid {
+			continue
+		}
+		rows = append(rows, models.DisruptionPercentile{
+			Date:                r.ReportDate.Date.In(time.UTC),
+			LookbackDays:        r.LookbackDays,
+			Release:             r.Release,
+			FromRelease:         r.FromRelease,
+			BackendName:         r.BackendName,
+			Platform:            r.Platform,
+			UpgradeType:         r.UpgradeType,
+			MasterNodesUpdated:  r.MasterNodesUpdated,
+			Network:             r.Network,
+			Topology:            r.Topology,
+			Architecture:        r.Architecture,
+			JobRuns:             r.JobRuns,
+			P50:                 r.P50,
+			P75:                 r.P75,
+			P95:                 r.P95,
+			P99:                 r.P99,
+			PercentageAboveZero: r.PercentageAboveZero,
+		})
+	}
+	return rows, nil
+}
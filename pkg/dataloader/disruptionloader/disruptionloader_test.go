@@ -0,0 +1,41 @@
+package disruptionloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSinceDate(t *testing.T) {
+	now := time.Date(2024, 5, 20, 15, 30, 0, 0, time.UTC)
+	latest := time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)
+	zero := time.Time{}
+
+	tests := []struct {
+		name     string
+		latest   *time.Time
+		expected time.Time
+	}{
+		{
+			name:     "empty table",
+			latest:   nil,
+			expected: time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "zero date",
+			latest:   &zero,
+			expected: time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "reloads recent days",
+			latest:   &latest,
+			expected: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, loadSinceDate(tc.latest, now))
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Package jobrunclassifier assigns each failed job run a probable cause from the signals loaded with it, such as
+// failed synthetic tests, steps, disruption and tests linked to open bugs, to give triagers a head start.
+package jobrunclassifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+// Probable causes of a failed job run, from the most to the least specific.
+const (
+	// CauseInfrastructure is a failure to provision the cluster's infrastructure.
+	CauseInfrastructure = "infrastructure"
+	CauseInstall        = "install"
+	CauseUpgrade        = "upgrade"
+	// CauseKnownBug is a run whose every failed test is linked to an open bug.
+	CauseKnownBug = "known_bug"
+	// CauseDisruption is a run where a backend was disrupted for at least disruptionSeconds.
+	CauseDisruption = "disruption"
+	CauseTests      = "tests"
+	// CauseStep is a run where only steps outside any test failed, such as setup or teardown.
+	CauseStep    = "step"
+	CauseUnknown = "unknown"
+)
+
+const (
+	// window is how far back failed runs are classified on every load. Runs are classified again until they fall
+	// out of it, so bugs filed for their failures after they were loaded are taken into account.
+	window = 7 * 24 * time.Hour
+	// disruptionSeconds is how long a backend must be unavailable for disruption to be the probable cause.
+	disruptionSeconds = 60
+	// updateBatchSize caps how many runs are updated by a single statement.
+	updateBatchSize = 5000
+)
+
+var (
+	infrastructureTests = []string{testidentification.InfrastructureTestName, testidentification.NewInfrastructureTestName}
+	installTests        = []string{testidentification.InstallTestName, testidentification.NewInstallTestName, testidentification.InstallTimeoutTestName, testidentification.HostedClusterCreateTestName}
+	upgradeTests        = []string{testidentification.UpgradeTestName, testidentification.UpgradeRollbackTestName}
+)
+
+// JobRunClassifier sets the probable cause of the failed job runs of the last week.
+type JobRunClassifier struct {
+	dbc    *db.DB
+	now    time.Time
+	errors []error
+}
+
+func New(dbc *db.DB, now time.Time) *JobRunClassifier {
+	return &JobRunClassifier{dbc: dbc, now: now}
+}
+
+func (c *JobRunClassifier) Name() string {
+	return "probable-cause"
+}
+
+func (c *JobRunClassifier) Errors() []error {
+	return c.errors
+}
+
+// runSignals are the signals of a failed job run the probable cause is decided from.
+type runSignals struct {
+	ID                   uint
+	InfrastructureFailed bool
+	InstallFailed        bool
+	UpgradeFailed        bool
+	// FailedTests and KnownBugTests are the failed tests of the run other than the synthetic ones, and how many of
+	// them are linked to an open bug.
+	FailedTests          int
+	KnownBugTests        int
+	FailedSteps          int
+	MaxDisruptionSeconds float64
+}
+
+func (c *JobRunClassifier) Load() {
+	since := c.now.Add(-window)
+	var signals []runSignals
+	res := c.dbc.DB.Raw(`
+		WITH runs AS (
+			SELECT id FROM prow_job_runs
+			WHERE timestamp >= @since AND NOT succeeded AND deleted_at IS NULL
+		), failures AS (
+			SELECT prow_job_run_tests.prow_job_run_id AS id, tests.id AS test_id, tests.name,
+				COALESCE(suites.name = @sippySuite, false) OR tests.name LIKE @installPrefix || '%' AS synthetic
+			FROM prow_job_run_tests
+			JOIN tests ON tests.id = prow_job_run_tests.test_id
+			LEFT JOIN suites ON suites.id = prow_job_run_tests.suite_id
+			WHERE prow_job_run_tests.created_at >= @since AND prow_job_run_tests.status = 12
+				AND prow_job_run_tests.prow_job_run_id IN (SELECT id FROM runs)
+		), failed_tests AS (
+			SELECT id,
+				bool_or(name = ANY(@infrastructureTests)) AS infrastructure_failed,
+				bool_or(name = ANY(@installTests)) AS install_failed,
+				bool_or(name = ANY(@upgradeTests)) AS upgrade_failed,
+				COUNT(*) FILTER (WHERE NOT synthetic) AS failed_tests,
+				COUNT(*) FILTER (WHERE NOT synthetic AND EXISTS (
+					SELECT 1 FROM bug_tests JOIN bugs ON bugs.id = bug_tests.bug_id
+					WHERE bug_tests.test_id = failures.test_id AND LOWER(bugs.status) <> 'closed'
+				)) AS known_bug_tests
+			FROM failures
+			GROUP BY id
+		), failed_steps AS (
+			SELECT prow_job_run_id AS id, COUNT(*) AS failed_steps
+			FROM prow_job_run_steps
+			WHERE failed AND prow_job_run_id IN (SELECT id FROM runs)
+			GROUP BY prow_job_run_id
+		), disruptions AS (
+			SELECT prow_job_run_id AS id, MAX(disruption_seconds) AS max_disruption_seconds
+			FROM prow_job_run_disruptions
+			WHERE prow_job_run_id IN (SELECT id FROM runs)
+			GROUP BY prow_job_run_id
+		)
+		SELECT runs.id,
+			COALESCE(failed_tests.infrastructure_failed, false) AS infrastructure_failed,
+			COALESCE(failed_tests.install_failed, false) AS install_failed,
+			COALESCE(failed_tests.upgrade_failed, false) AS upgrade_failed,
+			COALESCE(failed_tests.failed_tests, 0) AS failed_tests,
+			COALESCE(failed_tests.known_bug_tests, 0) AS known_bug_tests,
+			COALESCE(failed_steps.failed_steps, 0) AS failed_steps,
+			COALESCE(disruptions.max_disruption_seconds, 0) AS max_disruption_seconds
+		FROM runs
+		LEFT JOIN failed_tests ON failed_tests.id = runs.id
+		LEFT JOIN failed_steps ON failed_steps.id = runs.id
+		LEFT JOIN disruptions ON disruptions.id = runs.id`,
+		map[string]interface{}{
+			"since":               since,
+			"infrastructureTests": pq.Array(infrastructureTests),
+			"installTests":        pq.Array(installTests),
+			"upgradeTests":        pq.Array(upgradeTests),
+			"sippySuite":          testidentification.SippySuiteName,
+			"installPrefix":       testidentification.InstallTestNamePrefix,
+		}).Scan(&signals)
+	if res.Error != nil {
+		c.errors = append(c.errors, fmt.Errorf("error querying failed job run signals: %w", res.Error))
+		return
+	}
+
+	byCause := map[string][]uint{}
+	for _, s := range signals {
+		cause := classify(s)
+		byCause[cause] = append(byCause[cause], s.ID)
+	}
+	for cause, ids := range byCause {
+		for start := 0; start < len(ids); start += updateBatchSize {
+			batch := ids[start:min(start+updateBatchSize, len(ids))]
+			res := c.dbc.DB.Exec(`
+				UPDATE prow_job_runs
+				SET probable_cause = @cause, infrastructure_failure = @infrastructure, known_failure = @known
+				WHERE timestamp >= @since AND id IN @ids`,
+				map[string]interface{}{
+					"cause":          cause,
+					"infrastructure": cause == CauseInfrastructure,
+					"known":          cause == CauseKnownBug,
+					"since":          since,
+					"ids":            batch,
+				})
+			if res.Error != nil {
+				c.errors = append(c.errors, fmt.Errorf("error setting probable cause %s of job runs: %w", cause, res.Error))
+				break
+			}
+		}
+		log.WithFields(log.Fields{"cause": cause, "runs": len(ids)}).Info("classified failed job runs")
+	}
+}
+
+// classify returns the probable cause of a failed job run from its signals. Earlier failures explain later ones: an
+// install failure fails the tests that would have run on the cluster, and heavy disruption fails the tests that
+// needed the disrupted backend.
+func classify(s runSignals) string {
+	switch {
+	case s.InfrastructureFailed:
+		return CauseInfrastructure
+	case s.InstallFailed:
+		return CauseInstall
+	case s.UpgradeFailed:
+		return CauseUpgrade
+	case s.FailedTests > 0 && s.KnownBugTests == s.FailedTests:
+		return CauseKnownBug
+	case s.MaxDisruptionSeconds >= disruptionSeconds:
+		return CauseDisruption
+	case s.FailedTests > 0:
+		return CauseTests
+	case s.FailedSteps > 0:
+		return CauseStep
+	default:
+		return CauseUnknown
+	}
+}
@@ -0,0 +1,60 @@
+package jobrunclassifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals runSignals
+		want    string
+	}{
+		{
+			name:    "infrastructure failure explains the failed install",
+			signals: runSignals{InfrastructureFailed: true, InstallFailed: true, FailedSteps: 1},
+			want:    CauseInfrastructure,
+		},
+		{
+			name:    "install failure explains the failed tests",
+			signals: runSignals{InstallFailed: true, FailedTests: 30},
+			want:    CauseInstall,
+		},
+		{
+			name:    "upgrade failure",
+			signals: runSignals{UpgradeFailed: true, FailedTests: 2, MaxDisruptionSeconds: 120},
+			want:    CauseUpgrade,
+		},
+		{
+			name:    "every failed test has an open bug",
+			signals: runSignals{FailedTests: 2, KnownBugTests: 2, MaxDisruptionSeconds: 120},
+			want:    CauseKnownBug,
+		},
+		{
+			name:    "heavy disruption explains the failed tests",
+			signals: runSignals{FailedTests: 3, KnownBugTests: 1, MaxDisruptionSeconds: 90},
+			want:    CauseDisruption,
+		},
+		{
+			name:    "failed tests with brief disruption",
+			signals: runSignals{FailedTests: 3, KnownBugTests: 1, MaxDisruptionSeconds: 5},
+			want:    CauseTests,
+		},
+		{
+			name:    "only a step failed",
+			signals: runSignals{FailedSteps: 1},
+			want:    CauseStep,
+		},
+		{
+			name: "no signals",
+			want: CauseUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classify(tt.signals))
+		})
+	}
+}
@@ -26,6 +26,12 @@ var errorMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Buckets: []float64{0, 1, 10, 100, 1000},
 }, []string{"loader"})
 
+var warningMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sippy_data_load_warnings",
+	Help:    "Warnings raised while loading data into the DB, such as anomalies found verifying the load",
+	Buckets: []float64{0, 1, 10, 100, 1000},
+}, []string{"loader"})
+
 type LoaderWithMetrics struct {
 	loaders    []dataloader.DataLoader
 	promPusher *push.Pusher
@@ -40,6 +46,7 @@ func New(wrappedLoaders []dataloader.DataLoader) *LoaderWithMetrics {
 		loader.promPusher = push.New(pushgateway, "sippy-prow-job-loader")
 		loader.promPusher.Collector(errorMetric)
 		loader.promPusher.Collector(loadMetric)
+		loader.promPusher.Collector(warningMetric)
 	}
 
 	return loader
@@ -57,6 +64,9 @@ func (l *LoaderWithMetrics) Load() {
 
 		loadMetric.WithLabelValues(loader.Name()).Observe(float64(totalTime.Milliseconds()))
 		errorMetric.WithLabelValues(loader.Name()).Observe(float64(len(loader.Errors())))
+		if wl, ok := loader.(dataloader.WarningLoader); ok {
+			warningMetric.WithLabelValues(loader.Name()).Observe(float64(len(wl.Warnings())))
+		}
 	}
 	overallDuration := time.Since(overallStart)
 	log.Infof("%d loaders finished in %+v...", len(l.loaders), overallDuration)
@@ -81,3 +91,29 @@ func (l *LoaderWithMetrics) Errors() []error {
 	}
 	return errs
 }
+
+// Warnings returns the warnings of the loaders that report them, prefixed by the loader's name.
+func (l *LoaderWithMetrics) Warnings() []string {
+	var warnings []string
+	for _, loader := range l.loaders {
+		if wl, ok := loader.(dataloader.WarningLoader); ok {
+			for _, warning := range wl.Warnings() {
+				warnings = append(warnings, fmt.Sprintf("loader %q: %s", loader.Name(), warning))
+			}
+		}
+	}
+	return warnings
+}
+
+// Counts returns the total rows inserted by the loaders that count them.
+func (l *LoaderWithMetrics) Counts() dataloader.Counts {
+	var counts dataloader.Counts
+	for _, loader := range l.loaders {
+		if cl, ok := loader.(dataloader.CountingLoader); ok {
+			c := cl.Counts()
+			counts.JobRuns += c.JobRuns
+			counts.TestResults += c.TestResults
+		}
+	}
+	return counts
+}
@@ -0,0 +1,35 @@
+package loaderwithmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/dataloader"
+)
+
+type fakeLoader struct {
+	name string
+}
+
+func (f *fakeLoader) Name() string    { return f.name }
+func (f *fakeLoader) Load()           {}
+func (f *fakeLoader) Errors() []error { return nil }
+
+type fakeCountingLoader struct {
+	fakeLoader
+	counts dataloader.Counts
+}
+
+func (f *fakeCountingLoader) Counts() dataloader.Counts { return f.counts }
+
+func TestCounts(t *testing.T) {
+	l := New([]dataloader.DataLoader{
+		&fakeCountingLoader{fakeLoader: fakeLoader{name: "prow"}, counts: dataloader.Counts{JobRuns: 10, TestResults: 2000}},
+		&fakeLoader{name: "releases"},
+		&fakeCountingLoader{fakeLoader: fakeLoader{name: "backfill"}, counts: dataloader.Counts{JobRuns: 2, TestResults: 300}},
+	})
+	l.Load()
+
+	assert.Equal(t, dataloader.Counts{JobRuns: 12, TestResults: 2300}, l.Counts())
+}
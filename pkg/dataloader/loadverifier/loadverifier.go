@@ -0,0 +1,203 @@
+// Package loadverifier sanity checks the job runs a load imported, so a silently partial load is noticed the day it
+// happens rather than days later when reports look wrong.
+package loadverifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/db"
+)
+
+const (
+	// baselineDays is how many days before the last one a release's daily run count is averaged over.
+	baselineDays = 7
+	// minBaselineRuns is the average daily runs a release needs before a drop is reported, quiet releases vary
+	// too much from day to day.
+	minBaselineRuns = 20
+	// DefaultMinRunRatio is how many of its usual daily runs a release must have had in the last day.
+	DefaultMinRunRatio = 0.5
+	// maxListed caps how many jobs or runs a warning names.
+	maxListed = 10
+)
+
+// LoadVerifier checks the job runs imported since a load started:
+//
+//   - each release had at least minRunRatio of its average daily runs in the last day,
+//   - no imported run has zero test results, which happens when its results failed to save,
+//   - no run ID was imported twice, which the partitioned job runs table can't prevent.
+//
+// Anomalies are warnings, or also errors failing the load if failOnAnomaly is set.
+type LoadVerifier struct {
+	dbc           *db.DB
+	since         time.Time
+	releases      []string
+	minRunRatio   float64
+	failOnAnomaly bool
+	warnings      []string
+	errors        []error
+}
+
+// New returns a verifier for the runs of releases, or of every release if empty, imported since the load started.
+func New(dbc *db.DB, since time.Time, releases []string, minRunRatio float64, failOnAnomaly bool) *LoadVerifier {
+	return &LoadVerifier{
+		dbc:           dbc,
+		since:         since,
+		releases:      releases,
+		minRunRatio:   minRunRatio,
+		failOnAnomaly: failOnAnomaly,
+	}
+}
+
+func (lv *LoadVerifier) Name() string {
+	return "verify"
+}
+
+func (lv *LoadVerifier) Errors() []error {
+	return lv.errors
+}
+
+// Warnings returns the anomalies found, whether or not they failed the load.
+func (lv *LoadVerifier) Warnings() []string {
+	return lv.warnings
+}
+
+func (lv *LoadVerifier) Load() {
+	checks := []func() ([]string, error){lv.checkRunCounts, lv.checkRunsWithoutTests, lv.checkDuplicateRuns}
+	for _, check := range checks {
+		warnings, err := check()
+		if err != nil {
+			lv.errors = append(lv.errors, err)
+			continue
+		}
+		for _, warning := range warnings {
+			log.Warningf("load verification: %s", warning)
+			lv.warnings = append(lv.warnings, warning)
+			if lv.failOnAnomaly {
+				lv.errors = append(lv.errors, fmt.Errorf("load verification: %s", warning))
+			}
+		}
+	}
+}
+
+// releaseRunCounts is a release's runs in the last day and average daily runs in the days before.
+type releaseRunCounts struct {
+	Release      string
+	LastDayRuns  int
+	BaselineRuns float64
+}
+
+func (lv *LoadVerifier) checkRunCounts() ([]string, error) {
+	var counts []releaseRunCounts
+	end := time.Now()
+	res := lv.dbc.DB.Raw(`
+		SELECT
+			prow_jobs.release,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp >= @boundary) AS last_day_runs,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp < @boundary)::float / @baselineDays AS baseline_runs
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND (cardinality(@releases::text[]) = 0 OR prow_jobs.release = ANY(@releases))
+		GROUP BY prow_jobs.release`,
+		map[string]interface{}{
+			"start":        end.Add(-(baselineDays + 1) * 24 * time.Hour),
+			"boundary":     end.Add(-24 * time.Hour),
+			"end":          end,
+			"baselineDays": baselineDays,
+			"releases":     pq.Array(lv.releases),
+		}).Scan(&counts)
+	if res.Error != nil {
+		return nil, fmt.Errorf("error counting job runs by release: %w", res.Error)
+	}
+	return runCountDrops(counts, lv.minRunRatio), nil
+}
+
+// runCountDrops warns of the releases whose runs in the last day fell below minRatio of their baseline.
+func runCountDrops(counts []releaseRunCounts, minRatio float64) []string {
+	var warnings []string
+	for _, c := range counts {
+		if c.BaselineRuns < minBaselineRuns || float64(c.LastDayRuns) >= minRatio*c.BaselineRuns {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("release %s had %d job runs in the last day, usually %.0f",
+			c.Release, c.LastDayRuns, c.BaselineRuns))
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// jobRunCount is the number of a job's runs matching a check.
+type jobRunCount struct {
+	Name string
+	Runs int
+}
+
+func (lv *LoadVerifier) checkRunsWithoutTests() ([]string, error) {
+	var counts []jobRunCount
+	res := lv.dbc.DB.Raw(`
+		SELECT prow_jobs.name, COUNT(*) AS runs
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_runs.created_at >= @since AND prow_job_runs.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM prow_job_run_tests WHERE prow_job_run_tests.prow_job_run_id = prow_job_runs.id)
+		GROUP BY prow_jobs.name
+		ORDER BY runs DESC, prow_jobs.name`,
+		map[string]interface{}{"since": lv.since}).Scan(&counts)
+	if res.Error != nil {
+		return nil, fmt.Errorf("error finding job runs without tests: %w", res.Error)
+	}
+	return runsWithoutTests(counts), nil
+}
+
+// runsWithoutTests warns of the jobs with imported runs that have no test results at all. Every imported run has
+// at least the synthetic tests, so these lost their results.
+func runsWithoutTests(counts []jobRunCount) []string {
+	if len(counts) == 0 {
+		return nil
+	}
+	total := 0
+	names := make([]string, 0, maxListed)
+	for _, c := range counts {
+		total += c.Runs
+		if len(names) < maxListed {
+			names = append(names, fmt.Sprintf("%s (%d)", c.Name, c.Runs))
+		}
+	}
+	return []string{fmt.Sprintf("%d job runs of %d jobs were imported without any test results: %s",
+		total, len(counts), strings.Join(names, ", "))}
+}
+
+func (lv *LoadVerifier) checkDuplicateRuns() ([]string, error) {
+	var ids []uint
+	res := lv.dbc.DB.Raw(`
+		SELECT id
+		FROM prow_job_runs
+		WHERE id IN (SELECT id FROM prow_job_runs WHERE created_at >= @since)
+		GROUP BY id
+		HAVING COUNT(*) > 1
+		ORDER BY id`,
+		map[string]interface{}{"since": lv.since}).Scan(&ids)
+	if res.Error != nil {
+		return nil, fmt.Errorf("error finding duplicate job runs: %w", res.Error)
+	}
+	return duplicateRuns(ids), nil
+}
+
+// duplicateRuns warns of the run IDs imported more than once.
+func duplicateRuns(ids []uint) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	listed := make([]string, 0, maxListed)
+	for _, id := range ids[:min(len(ids), maxListed)] {
+		listed = append(listed, fmt.Sprint(id))
+	}
+	return []string{fmt.Sprintf("%d job run IDs were imported more than once: %s", len(ids), strings.Join(listed, ", "))}
+}
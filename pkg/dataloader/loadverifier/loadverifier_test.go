@@ -0,0 +1,41 @@
+package loadverifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCountDrops(t *testing.T) {
+	counts := []releaseRunCounts{
+		{Release: "4.16", LastDayRuns: 900, BaselineRuns: 1000},
+		{Release: "4.15", LastDayRuns: 120, BaselineRuns: 400},
+		{Release: "4.10", LastDayRuns: 0, BaselineRuns: 5},
+		{Release: "Presubmits", LastDayRuns: 0, BaselineRuns: 3000},
+	}
+	assert.Equal(t, []string{
+		"release 4.15 had 120 job runs in the last day, usually 400",
+		"release Presubmits had 0 job runs in the last day, usually 3000",
+	}, runCountDrops(counts, DefaultMinRunRatio))
+	assert.Equal(t, []string{"release Presubmits had 0 job runs in the last day, usually 3000"}, runCountDrops(counts, 0.2),
+		"a lower ratio tolerates smaller days")
+}
+
+func TestRunsWithoutTests(t *testing.T) {
+	assert.Empty(t, runsWithoutTests(nil))
+	assert.Equal(t, []string{
+		"3 job runs of 2 jobs were imported without any test results: periodic-e2e-aws (2), periodic-e2e-gcp (1)",
+	}, runsWithoutTests([]jobRunCount{{Name: "periodic-e2e-aws", Runs: 2}, {Name: "periodic-e2e-gcp", Runs: 1}}))
+}
+
+func TestDuplicateRuns(t *testing.T) {
+	assert.Empty(t, duplicateRuns(nil))
+	assert.Equal(t, []string{"2 job run IDs were imported more than once: 1771000000000001, 1771000000000002"},
+		duplicateRuns([]uint{1771000000000001, 1771000000000002}))
+
+	ids := make([]uint, 25)
+	for i := range ids {
+		ids[i] = uint(i + 1)
+	}
+	assert.Equal(t, []string{"25 job run IDs were imported more than once: 1, 2, 3, 4, 5, 6, 7, 8, 9, 10"}, duplicateRuns(ids))
+}
@@ -0,0 +1,147 @@
+package prowloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+)
+
+// snapshotDateRegexp matches the date in the name of an archived prowjobs.json snapshot, i.e.
+// prowjobs-2024-03-01.json or 20240301/prowjobs.json.
+var snapshotDateRegexp = regexp.MustCompile(`(\d{4})-?(\d{2})-?(\d{2})`)
+
+// snapshot is an archived copy of prow's job list taken on Date.
+type snapshot struct {
+	Path string
+	Date time.Time
+}
+
+// SetBackfillSource makes Load import job runs from the archived job list snapshots in source instead of from
+// the live prow deployments. The source is a local directory or a gs://bucket/prefix of JSON files in the
+// prowjobs.js format, each named with the date it was taken. This recovers the early history of a release added
+// mid-cycle, as prow only lists recent jobs.
+func (pl *ProwLoader) SetBackfillSource(source string) {
+	pl.backfillSource = source
+}
+
+// loadBackfill imports the job runs from each snapshot, oldest first. Runs keep their original start time as
+// the creation time of their rows, so they land in the right partitions and matview periods.
+func (pl *ProwLoader) loadBackfill() {
+	snapshots, err := pl.listSnapshots(pl.ctx, pl.backfillSource)
+	if err != nil {
+		pl.errors = append(pl.errors, errors.Wrapf(err, "error listing snapshots in %s", pl.backfillSource))
+		return
+	}
+	log.Infof("backfilling job runs from %d snapshots in %s", len(snapshots), pl.backfillSource)
+
+	for _, s := range snapshots {
+		data, err := pl.readSnapshot(pl.ctx, s.Path)
+		if err != nil {
+			pl.errors = append(pl.errors, errors.Wrapf(err, "error reading snapshot %s", s.Path))
+			continue
+		}
+		prowJobs, err := jobsJSONToProwJobs(data)
+		if err != nil {
+			pl.errors = append(pl.errors, errors.Wrapf(err, "error decoding snapshot %s", s.Path))
+			continue
+		}
+		log.WithField("snapshot", s.Path).Infof("backfilling %d prow jobs from %s", len(prowJobs), s.Date.Format("2006-01-02"))
+		pl.loadProwJobs(pl.deployments[0], prowJobs)
+	}
+}
+
+func (pl *ProwLoader) listSnapshots(ctx context.Context, source string) ([]snapshot, error) {
+	names := []string{}
+	if bucket, prefix, ok := parseGCSPath(source); ok {
+		it := pl.gcsClient.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+		for {
+			attrs, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, "gs://"+bucket+"/"+attrs.Name)
+		}
+	} else {
+		err := filepath.WalkDir(source, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			names = append(names, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return datedSnapshots(names), nil
+}
+
+func (pl *ProwLoader) readSnapshot(ctx context.Context, path string) ([]byte, error) {
+	bucket, object, ok := parseGCSPath(path)
+	if !ok {
+		return os.ReadFile(path)
+	}
+	r, err := pl.gcsClient.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// datedSnapshots returns the JSON files among paths that are named with a date, sorted oldest first.
+func datedSnapshots(paths []string) []snapshot {
+	snapshots := []snapshot{}
+	for _, p := range paths {
+		if !strings.HasSuffix(p, ".json") {
+			continue
+		}
+		date, err := snapshotDate(p)
+		if err != nil {
+			log.WithError(err).Warningf("skipping snapshot %s", p)
+			continue
+		}
+		snapshots = append(snapshots, snapshot{Path: p, Date: date})
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].Date.Equal(snapshots[j].Date) {
+			return snapshots[i].Path < snapshots[j].Path
+		}
+		return snapshots[i].Date.Before(snapshots[j].Date)
+	})
+	return snapshots
+}
+
+// snapshotDate returns the last date in the path, so a dated file within a dated directory uses the file's.
+func snapshotDate(path string) (time.Time, error) {
+	matches := snapshotDateRegexp.FindAllStringSubmatch(path, -1)
+	if len(matches) == 0 {
+		return time.Time{}, fmt.Errorf("no date in snapshot name")
+	}
+	m := matches[len(matches)-1]
+	return time.Parse("20060102", m[1]+m[2]+m[3])
+}
+
+// parseGCSPath splits a gs://bucket/object path, ok is false for anything else.
+func parseGCSPath(path string) (bucket, object string, ok bool) {
+	rest, found := strings.CutPrefix(path, "gs://")
+	if !found {
+		return "", "", false
+	}
+	bucket, object, _ = strings.Cut(rest, "/")
+	return bucket, object, bucket != ""
+}
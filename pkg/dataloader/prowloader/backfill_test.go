@@ -0,0 +1,51 @@
+package prowloader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatedSnapshots(t *testing.T) {
+	snapshots := datedSnapshots([]string{
+		"/archive/prowjobs-2024-03-02.json",
+		"/archive/README.md",
+		"/archive/prowjobs-latest.json",
+		"/archive/20240301/prowjobs.json",
+		"gs://archive/2023/prowjobs-20240215.json",
+	})
+
+	paths := []string{}
+	for _, s := range snapshots {
+		paths = append(paths, s.Path)
+	}
+	assert.Equal(t, []string{
+		"gs://archive/2023/prowjobs-20240215.json",
+		"/archive/20240301/prowjobs.json",
+		"/archive/prowjobs-2024-03-02.json",
+	}, paths)
+	assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), snapshots[0].Date)
+}
+
+func TestParseGCSPath(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantBucket string
+		wantObject string
+		wantOK     bool
+	}{
+		{path: "gs://bucket/prefix/prowjobs.json", wantBucket: "bucket", wantObject: "prefix/prowjobs.json", wantOK: true},
+		{path: "gs://bucket", wantBucket: "bucket", wantOK: true},
+		{path: "/local/prowjobs.json"},
+		{path: "gs://"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			bucket, object, ok := parseGCSPath(tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantBucket, bucket)
+			assert.Equal(t, tt.wantObject, object)
+		})
+	}
+}
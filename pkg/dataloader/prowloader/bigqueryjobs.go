@@ -35,7 +35,7 @@ func (pl *ProwLoader) fetchProwJobsFromOpenShiftBigQuery() ([]prow.ProwJob, []er
 	// NOTE: casting a couple datetime columns to timestamps, it does appear they go in as UTC, and thus come out
 	// as the default UTC correctly.
 	// Annotations and labels can be queried here if we need them.
-	query := pl.bigQueryClient.Query(`SELECT
+	query := pl.bigQueryClient.BQ.Query(`SELECT
 			prowjob_job_name,
 			prowjob_state,
 			prowjob_build_id,
@@ -59,7 +59,7 @@ func (pl *ProwLoader) fetchProwJobsFromOpenShiftBigQuery() ([]prow.ProwJob, []er
 			Value: lastProwJobRun,
 		},
 	}
-	it, err := query.Read(context.TODO())
+	it, err := pl.bigQueryClient.Read(context.TODO(), query, "prow_loader")
 	if err != nil {
 		errs = append(errs, err)
 		log.WithError(err).Error("error querying jobs from bigquery")
@@ -0,0 +1,132 @@
+package prowloader
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/apis/prow"
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+	"github.com/openshift/sippy/pkg/synthetictests"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+// defaultDeploymentName is the OpenShift CI prow configured by prow.url in the sippy config.
+const defaultDeploymentName = "default"
+
+// deployment is a prow instance we import job runs from, along with where it stores their artifacts.
+type deployment struct {
+	name string
+	// url is the prowjobs.js endpoint, it is unused when jobs come from OpenShift CI's BigQuery.
+	url          string
+	bucket       string
+	bkt          *storage.BucketHandle
+	pathTemplate *template.Template
+	// artifactURL is the base URL for links to objects in the bucket.
+	artifactURL string
+	// mode overrides the loader's mode for this deployment's jobs, if set.
+	mode                 string
+	variantManager       testidentification.VariantManager
+	syntheticTestManager synthetictests.SyntheticTestManager
+}
+
+// ModeManagers returns the variant and synthetic test managers for a mode, for deployments that are configured
+// with a mode other than the loader's.
+type ModeManagers func(mode string) (testidentification.VariantManager, synthetictests.SyntheticTestManager, error)
+
+// setManagers gives each deployment the managers for its mode, or the defaults if it has none.
+func setManagers(deployments []*deployment, variantManager testidentification.VariantManager,
+	syntheticTestManager synthetictests.SyntheticTestManager, modeManagers ModeManagers) error {
+	for _, d := range deployments {
+		d.variantManager = variantManager
+		d.syntheticTestManager = syntheticTestManager
+		if d.mode == "" {
+			continue
+		}
+		if modeManagers == nil {
+			return fmt.Errorf("prow deployment %s sets a mode, which is not supported here", d.name)
+		}
+		var err error
+		d.variantManager, d.syntheticTestManager, err = modeManagers(d.mode)
+		if err != nil {
+			return fmt.Errorf("invalid mode for prow deployment %s: %w", d.name, err)
+		}
+	}
+	return nil
+}
+
+// newDeployments returns the default OpenShift CI deployment followed by any additional deployments from the
+// sippy config.
+func newDeployments(gcsClient *storage.Client, defaultBucket string, config v1config.ProwConfig) ([]*deployment, error) {
+	deployments := []*deployment{
+		{
+			name:        defaultDeploymentName,
+			url:         config.URL,
+			bucket:      defaultBucket,
+			bkt:         gcsClient.Bucket(defaultBucket),
+			artifactURL: gcs.DefaultArtifactURL,
+		},
+	}
+
+	for _, d := range config.Deployments {
+		if d.Name == "" || d.URL == "" {
+			return nil, fmt.Errorf("prow deployments require a name and url")
+		}
+		if d.Name == defaultDeploymentName {
+			return nil, fmt.Errorf("prow deployment name %q is reserved", defaultDeploymentName)
+		}
+		bucket := d.GCSBucket
+		if bucket == "" {
+			bucket = defaultBucket
+		}
+		artifactURL := strings.TrimSuffix(d.ArtifactURL, "/")
+		if artifactURL == "" {
+			artifactURL = gcs.DefaultArtifactURL
+		}
+		dep := &deployment{
+			name:        d.Name,
+			url:         d.URL,
+			bucket:      bucket,
+			bkt:         gcsClient.Bucket(bucket),
+			artifactURL: artifactURL,
+			mode:        d.Mode,
+		}
+		if d.PathTemplate != "" {
+			tmpl, err := template.New(d.Name).Option("missingkey=error").Parse(d.PathTemplate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path template for prow deployment %s: %w", d.Name, err)
+			}
+			dep.pathTemplate = tmpl
+		}
+		deployments = append(deployments, dep)
+	}
+	return deployments, nil
+}
+
+// jobRunPath returns the path to the job run's artifacts in the deployment's bucket.
+func (d *deployment) jobRunPath(pjLog log.FieldLogger, pj *prow.ProwJob) (string, error) {
+	if d.pathTemplate == nil {
+		return GetGCSPathForProwJobURL(pjLog, pj.Status.URL)
+	}
+
+	var buf bytes.Buffer
+	if err := d.pathTemplate.Execute(&buf, pj); err != nil {
+		return "", fmt.Errorf("error executing path template for prow deployment %s: %w", d.name, err)
+	}
+	path := strings.Trim(buf.String(), "/")
+	if path == "" {
+		return "", fmt.Errorf("path template for prow deployment %s produced an empty path", d.name)
+	}
+	pjLog.Debugf("gcs bucket path: %+v", path)
+	return path, nil
+}
+
+// artifactLink returns a link to an object in the deployment's bucket.
+func (d *deployment) artifactLink(path string) string {
+	return fmt.Sprintf("%s/%s/%s", d.artifactURL, d.bucket, path)
+}
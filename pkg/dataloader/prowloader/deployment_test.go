@@ -0,0 +1,209 @@
+package prowloader
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/apis/prow"
+	"github.com/openshift/sippy/pkg/synthetictests"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+func TestNewDeployments(t *testing.T) {
+	gcsClient, err := storage.NewClient(context.Background(), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		config        v1config.ProwConfig
+		expectedNames []string
+		expectedErr   bool
+	}{
+		{
+			name:          "default only",
+			config:        v1config.ProwConfig{URL: "https://prow.ci.openshift.org/prowjobs.js"},
+			expectedNames: []string{defaultDeploymentName},
+		},
+		{
+			name: "additional deployment",
+			config: v1config.ProwConfig{
+				URL: "https://prow.ci.openshift.org/prowjobs.js",
+				Deployments: []v1config.ProwDeployment{
+					{Name: "k8s", URL: "https://prow.k8s.io/prowjobs.js", GCSBucket: "kubernetes-jenkins"},
+				},
+			},
+			expectedNames: []string{defaultDeploymentName, "k8s"},
+		},
+		{
+			name: "missing url",
+			config: v1config.ProwConfig{
+				Deployments: []v1config.ProwDeployment{{Name: "k8s"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "reserved name",
+			config: v1config.ProwConfig{
+				Deployments: []v1config.ProwDeployment{{Name: defaultDeploymentName, URL: "https://prow.k8s.io/prowjobs.js"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid template",
+			config: v1config.ProwConfig{
+				Deployments: []v1config.ProwDeployment{{Name: "k8s", URL: "https://prow.k8s.io/prowjobs.js", PathTemplate: "logs/{{.Spec.Job"}},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deployments, err := newDeployments(gcsClient, "origin-ci-test", tc.config)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, d := range deployments {
+				names = append(names, d.name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestDeploymentJobRunPath(t *testing.T) {
+	gcsClient, err := storage.NewClient(context.Background(), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	deployments, err := newDeployments(gcsClient, "origin-ci-test", v1config.ProwConfig{
+		Deployments: []v1config.ProwDeployment{
+			{
+				Name: "templated",
+				URL:  "https://prow.example.com/prowjobs.js",
+				PathTemplate: `{{if eq .Spec.Type "presubmit"}}pr-logs/pull/{{.Spec.Refs.Org}}_{{.Spec.Refs.Repo}}/` +
+					`{{(index .Spec.Refs.Pulls 0).Number}}{{else}}logs{{end}}/{{.Spec.Job}}/{{.Status.BuildID}}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	periodic := &prow.ProwJob{
+		Spec: prow.ProwJobSpec{Type: "periodic", Job: "periodic-e2e"},
+		Status: prow.ProwJobStatus{
+			BuildID: "1234",
+			URL:     "https://prow.ci.openshift.org/view/gs/origin-ci-test/logs/periodic-e2e/1234",
+		},
+	}
+	presubmit := &prow.ProwJob{
+		Spec: prow.ProwJobSpec{
+			Type: "presubmit",
+			Job:  "pull-e2e",
+			Refs: &prow.Refs{Org: "example", Repo: "project", Pulls: []prow.Pull{{Number: 42}}},
+		},
+		Status: prow.ProwJobStatus{BuildID: "5678", URL: "https://deck.example.com/run/5678"},
+	}
+
+	tests := []struct {
+		name       string
+		deployment *deployment
+		job        *prow.ProwJob
+		expected   string
+	}{
+		{
+			name:       "path from url",
+			deployment: deployments[0],
+			job:        periodic,
+			expected:   "logs/periodic-e2e/1234",
+		},
+		{
+			name:       "template periodic",
+			deployment: deployments[1],
+			job:        periodic,
+			expected:   "logs/periodic-e2e/1234",
+		},
+		{
+			name:       "template presubmit",
+			deployment: deployments[1],
+			job:        presubmit,
+			expected:   "pr-logs/pull/example_project/42/pull-e2e/5678",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := tc.deployment.jobRunPath(log.WithField("test", tc.name), tc.job)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, path)
+		})
+	}
+}
+
+func TestSetManagers(t *testing.T) {
+	defaultVariants := testidentification.NewOKDVariantManager()
+	defaultSynthetic := synthetictests.NewOKDSyntheticTestManager()
+	kubeVariants := testidentification.NewEmptyVariantManager()
+	kubeSynthetic := synthetictests.NewEmptySyntheticTestManager()
+	modeManagers := func(mode string) (testidentification.VariantManager, synthetictests.SyntheticTestManager, error) {
+		if mode != "none" {
+			return nil, nil, fmt.Errorf("unknown mode %q", mode)
+		}
+		return kubeVariants, kubeSynthetic, nil
+	}
+
+	tests := []struct {
+		name         string
+		deployments  []*deployment
+		modeManagers ModeManagers
+		expectedErr  bool
+	}{
+		{
+			name:         "deployments without a mode use the defaults",
+			deployments:  []*deployment{{name: defaultDeploymentName}},
+			modeManagers: modeManagers,
+		},
+		{
+			name:         "deployment with its own mode",
+			deployments:  []*deployment{{name: defaultDeploymentName}, {name: "k8s", mode: "none"}},
+			modeManagers: modeManagers,
+		},
+		{
+			name:         "unknown mode",
+			deployments:  []*deployment{{name: "k8s", mode: "bogus"}},
+			modeManagers: modeManagers,
+			expectedErr:  true,
+		},
+		{
+			name:        "mode without mode managers",
+			deployments: []*deployment{{name: "k8s", mode: "none"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := setManagers(tc.deployments, defaultVariants, defaultSynthetic, tc.modeManagers)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, d := range tc.deployments {
+				if d.mode == "" {
+					assert.Equal(t, defaultVariants, d.variantManager, d.name)
+					assert.Equal(t, defaultSynthetic, d.syntheticTestManager, d.name)
+				} else {
+					assert.Equal(t, kubeVariants, d.variantManager, d.name)
+					assert.Equal(t, kubeSynthetic, d.syntheticTestManager, d.name)
+				}
+			}
+		})
+	}
+}
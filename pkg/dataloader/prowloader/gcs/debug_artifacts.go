@@ -0,0 +1,70 @@
+package gcs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kinds of debugging output we link to from failed job runs.
+const (
+	DebugArtifactMustGather  = "must-gather"
+	DebugArtifactGatherExtra = "gather-extra"
+	DebugArtifactScreenshot  = "screenshot"
+)
+
+// DefaultArtifactURL is OpenShift CI's gcsweb, which can browse directories as well as serve files.
+const DefaultArtifactURL = "https://gcsweb-ci.apps.ci.l2s4.p1.openshiftapps.com/gcs"
+
+// maxScreenshots limits how many screenshots are linked, UI test suites can produce hundreds.
+const maxScreenshots = 10
+
+const gatherExtraDir = "/gather-extra/"
+
+var (
+	mustGatherFileRegex = regexp.MustCompile(`/must-gather[^/]*\.tar(\.gz)?$`)
+	screenshotFileRegex = regexp.MustCompile(`(?i)(/screenshots?/[^/]+|screenshot[^/]*)\.(png|jpe?g)$`)
+	debugArtifactRegex  = regexp.MustCompile(mustGatherFileRegex.String() + "|" + regexp.QuoteMeta(gatherExtraDir) + "|" + screenshotFileRegex.String())
+)
+
+// GetDebugArtifactFile matches any object that is, or is part of, a debugging output listed by DebugArtifacts.
+func GetDebugArtifactFile() *regexp.Regexp {
+	return debugArtifactRegex
+}
+
+// DebugArtifact is the path to a debugging output within a job run's artifacts.
+type DebugArtifact struct {
+	Kind string
+	Path string
+}
+
+// DebugArtifacts picks out the well known debugging outputs from the object paths of a job run. gather-extra
+// produces many files, so we return its directory rather than each file.
+func DebugArtifacts(paths []string) []DebugArtifact {
+	artifacts := []DebugArtifact{}
+	seen := map[string]bool{}
+	screenshots := 0
+	for _, p := range paths {
+		var artifact DebugArtifact
+		switch {
+		case mustGatherFileRegex.MatchString(p):
+			artifact = DebugArtifact{Kind: DebugArtifactMustGather, Path: p}
+		case strings.Contains(p, gatherExtraDir):
+			dir := p[:strings.Index(p, gatherExtraDir)+len(gatherExtraDir)]
+			artifact = DebugArtifact{Kind: DebugArtifactGatherExtra, Path: dir}
+		case screenshotFileRegex.MatchString(p):
+			if screenshots >= maxScreenshots {
+				continue
+			}
+			screenshots++
+			artifact = DebugArtifact{Kind: DebugArtifactScreenshot, Path: p}
+		default:
+			continue
+		}
+		if seen[artifact.Path] {
+			continue
+		}
+		seen[artifact.Path] = true
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts
+}
@@ -0,0 +1,73 @@
+package gcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugArtifacts(t *testing.T) {
+	run := "logs/periodic-ci-openshift-release-master-ci-4.16-e2e-aws-ovn/1780000000000000000/artifacts/e2e-aws-ovn"
+
+	manyScreenshots := []string{}
+	for i := 0; i < 15; i++ {
+		manyScreenshots = append(manyScreenshots, fmt.Sprintf("%s/test/artifacts/gui_test_screenshots/screenshots/%d.png", run, i))
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []DebugArtifact
+	}{
+		{
+			name: "must-gather and gather-extra",
+			paths: []string{
+				run + "/gather-extra/artifacts/nodes.json",
+				run + "/gather-extra/artifacts/pods.json",
+				run + "/gather-must-gather/artifacts/must-gather.tar",
+				run + "/gather-must-gather/build-log.txt",
+			},
+			want: []DebugArtifact{
+				{Kind: DebugArtifactGatherExtra, Path: run + "/gather-extra/"},
+				{Kind: DebugArtifactMustGather, Path: run + "/gather-must-gather/artifacts/must-gather.tar"},
+			},
+		},
+		{
+			name: "console screenshot",
+			paths: []string{
+				run + "/test/artifacts/console-screenshot-login.png",
+				run + "/test/artifacts/junit_e2e.xml",
+			},
+			want: []DebugArtifact{
+				{Kind: DebugArtifactScreenshot, Path: run + "/test/artifacts/console-screenshot-login.png"},
+			},
+		},
+		{
+			name:  "screenshots are limited",
+			paths: manyScreenshots,
+			want: func() []DebugArtifact {
+				want := []DebugArtifact{}
+				for _, p := range manyScreenshots[:maxScreenshots] {
+					want = append(want, DebugArtifact{Kind: DebugArtifactScreenshot, Path: p})
+				}
+				return want
+			}(),
+		},
+		{
+			name:  "nothing of interest",
+			paths: []string{run + "/build-log.txt"},
+			want:  []DebugArtifact{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DebugArtifacts(tt.paths))
+			for _, p := range tt.paths {
+				if len(DebugArtifacts([]string{p})) > 0 {
+					assert.True(t, GetDebugArtifactFile().MatchString(p), "%s should be listed", p)
+				}
+			}
+		})
+	}
+}
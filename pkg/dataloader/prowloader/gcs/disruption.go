@@ -0,0 +1,64 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+const (
+	disruptionSource       = "Disruption"
+	disruptionBeganReason  = "DisruptionBegan"
+	disruptionBackendKey   = "backend-disruption-name"
+	disruptionFileRegExStr = `/e2e-timelines_spyglass_[^/]*\.json$`
+)
+
+// The spyglass timelines are the smallest interval files that still hold every disruption interval. A run
+// has one for each phase it tests, e.g. upgrade and conformance.
+var disruptionFileRegex = regexp.MustCompile(disruptionFileRegExStr)
+
+// GetDisruptionIntervalFile matches the interval files BackendDisruptionSeconds are calculated from.
+func GetDisruptionIntervalFile() *regexp.Regexp {
+	return disruptionFileRegex
+}
+
+// BackendDisruptionSeconds sums the time each backend was disrupted over the intervals.
+func BackendDisruptionSeconds(intervals []apitype.EventInterval) map[string]float64 {
+	seconds := map[string]float64{}
+	for _, i := range intervals {
+		if i.Source != disruptionSource || i.StructuredMessage.Reason != disruptionBeganReason || i.From == nil || i.To == nil {
+			continue
+		}
+		backend := i.StructuredLocator.Keys[disruptionBackendKey]
+		if backend == "" {
+			continue
+		}
+		seconds[backend] += i.To.Sub(*i.From).Seconds()
+	}
+	return seconds
+}
+
+// GetBackendDisruptionSeconds reads the interval files at paths and sums the time each backend was disrupted.
+// Files in the legacy interval schema are skipped.
+func (j *GCSJobRun) GetBackendDisruptionSeconds(ctx context.Context, paths []string) (map[string]float64, error) {
+	seconds := map[string]float64{}
+	for _, path := range paths {
+		content, err := j.GetContent(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		intervals := apitype.EventIntervalList{}
+		if err := json.Unmarshal(content, &intervals); err != nil {
+			log.WithError(err).Warningf("error parsing intervals file %s, skipping", path)
+			continue
+		}
+		for backend, s := range BackendDisruptionSeconds(intervals.Items) {
+			seconds[backend] += s
+		}
+	}
+	return seconds, nil
+}
@@ -0,0 +1,61 @@
+package gcs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestBackendDisruptionSeconds(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	interval := func(source, reason, backend string, from time.Duration, d time.Duration) apitype.EventInterval {
+		f, to := start.Add(from), start.Add(from+d)
+		return apitype.EventInterval{
+			Source:            source,
+			StructuredLocator: apitype.Locator{Keys: map[string]string{disruptionBackendKey: backend}},
+			StructuredMessage: apitype.Message{Reason: reason},
+			From:              &f,
+			To:                &to,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		intervals []apitype.EventInterval
+		want      map[string]float64
+	}{
+		{
+			name: "sums sub-second disruption per backend",
+			intervals: []apitype.EventInterval{
+				interval(disruptionSource, disruptionBeganReason, "kube-api-new-connections", 0, 1500*time.Millisecond),
+				interval(disruptionSource, disruptionBeganReason, "kube-api-new-connections", time.Minute, 250*time.Millisecond),
+				interval(disruptionSource, disruptionBeganReason, "ingress-to-console", 0, 3*time.Second),
+			},
+			want: map[string]float64{"kube-api-new-connections": 1.75, "ingress-to-console": 3},
+		},
+		{
+			name: "ignores availability and non disruption intervals",
+			intervals: []apitype.EventInterval{
+				interval(disruptionSource, "DisruptionEnded", "kube-api-new-connections", 0, time.Hour),
+				interval("E2ETest", disruptionBeganReason, "kube-api-new-connections", 0, time.Hour),
+				interval(disruptionSource, disruptionBeganReason, "", 0, time.Hour),
+			},
+			want: map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BackendDisruptionSeconds(tt.intervals))
+		})
+	}
+}
+
+func TestGetDisruptionIntervalFile(t *testing.T) {
+	re := GetDisruptionIntervalFile()
+	assert.True(t, re.MatchString("logs/job/123/artifacts/e2e/openshift-e2e-test/artifacts/junit/e2e-timelines_spyglass_20240301-100000.json"))
+	assert.False(t, re.MatchString("logs/job/123/artifacts/e2e/openshift-e2e-test/artifacts/junit/e2e-events_20240301-100000.json"))
+	assert.False(t, re.MatchString("logs/job/123/artifacts/e2e/openshift-e2e-test/artifacts/junit/e2e-timelines_everything_20240301-100000.json"))
+}
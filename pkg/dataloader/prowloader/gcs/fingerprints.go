@@ -0,0 +1,100 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+// fingerprintReadBytes is how much of an artifact is searched for a fingerprint, enough for the start of an
+// installer log without downloading all of it.
+const fingerprintReadBytes = 256 * 1024
+
+// defaultFingerprints read the OpenShift installer's version and commit from the start of its log.
+var defaultFingerprints = []v1.EnvironmentFingerprint{
+	{Name: "installer-version", File: `/\.openshift_install\.log$`, Pattern: `OpenShift Installer ([^\s"]+)`},
+	{Name: "installer-commit", File: `/\.openshift_install\.log$`, Pattern: `Built from commit ([0-9a-f]+)`},
+}
+
+// Fingerprint reads a value identifying a dependency of a job run from the first artifact whose path matches File.
+type Fingerprint struct {
+	Name    string
+	File    *regexp.Regexp
+	Pattern *regexp.Regexp
+}
+
+// NewFingerprints compiles the configured environment fingerprints, or the defaults if none are configured.
+func NewFingerprints(config []v1.EnvironmentFingerprint) ([]Fingerprint, error) {
+	if len(config) == 0 {
+		config = defaultFingerprints
+	}
+	fingerprints := make([]Fingerprint, 0, len(config))
+	for _, c := range config {
+		if c.Name == "" {
+			return nil, fmt.Errorf("environment fingerprint is missing a name")
+		}
+		file, err := regexp.Compile(c.File)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file of environment fingerprint %s: %w", c.Name, err)
+		}
+		pattern, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of environment fingerprint %s: %w", c.Name, err)
+		}
+		if pattern.NumSubexp() < 1 {
+			return nil, fmt.Errorf("pattern of environment fingerprint %s has no capture group", c.Name)
+		}
+		fingerprints = append(fingerprints, Fingerprint{Name: c.Name, File: file, Pattern: pattern})
+	}
+	return fingerprints, nil
+}
+
+// FingerprintFiles returns the file regexes of the fingerprints, to find their artifacts with FindAllMatches.
+func FingerprintFiles(fingerprints []Fingerprint) []*regexp.Regexp {
+	files := make([]*regexp.Regexp, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		files = append(files, f.File)
+	}
+	return files
+}
+
+// GetFingerprints reads the value of each fingerprint from the first of its matches, as found by FindAllMatches for
+// FingerprintFiles. Fingerprints with no artifact, or whose pattern isn't found, are left out.
+func (j *GCSJobRun) GetFingerprints(ctx context.Context, fingerprints []Fingerprint, matches [][]string) (map[string]string, error) {
+	values := map[string]string{}
+	for i, f := range fingerprints {
+		if i >= len(matches) || len(matches[i]) == 0 {
+			continue
+		}
+		content, err := j.getContentPrefix(ctx, matches[i][0], fingerprintReadBytes)
+		if err != nil {
+			return values, fmt.Errorf("error reading %s for environment fingerprint %s: %w", matches[i][0], f.Name, err)
+		}
+		if value, ok := fingerprintValue(f.Pattern, content); ok {
+			values[f.Name] = value
+		}
+	}
+	return values, nil
+}
+
+// fingerprintValue returns the first capture group of the first match of pattern in content.
+func fingerprintValue(pattern *regexp.Regexp, content []byte) (string, bool) {
+	match := pattern.FindSubmatch(content)
+	if len(match) < 2 || len(match[1]) == 0 {
+		return "", false
+	}
+	return string(match[1]), true
+}
+
+// getContentPrefix reads up to n bytes from the start of the artifact at path.
+func (j *GCSJobRun) getContentPrefix(ctx context.Context, path string, n int64) ([]byte, error) {
+	reader, err := j.bkt.Object(path).NewRangeReader(ctx, 0, n)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
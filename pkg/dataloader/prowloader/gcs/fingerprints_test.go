@@ -0,0 +1,42 @@
+package gcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+func TestDefaultFingerprints(t *testing.T) {
+	fingerprints, err := NewFingerprints(nil)
+	require.NoError(t, err)
+	require.Len(t, fingerprints, 2)
+
+	log := []byte(`time="2024-04-30T06:12:00Z" level=debug msg="OpenShift Installer 4.16.0-0.nightly-2024-04-30-052730"
+time="2024-04-30T06:12:00Z" level=debug msg="Built from commit 8a5f1c2e9d"
+`)
+	assert.True(t, fingerprints[0].File.MatchString("artifacts/e2e-aws-ovn/ipi-install-install/artifacts/.openshift_install.log"))
+	value, ok := fingerprintValue(fingerprints[0].Pattern, log)
+	assert.True(t, ok)
+	assert.Equal(t, "4.16.0-0.nightly-2024-04-30-052730", value)
+	value, ok = fingerprintValue(fingerprints[1].Pattern, log)
+	assert.True(t, ok)
+	assert.Equal(t, "8a5f1c2e9d", value)
+
+	_, ok = fingerprintValue(fingerprints[0].Pattern, []byte("no installer here"))
+	assert.False(t, ok)
+}
+
+func TestNewFingerprintsValidation(t *testing.T) {
+	_, err := NewFingerprints([]v1.EnvironmentFingerprint{{Name: "sdk", File: `/versions\.txt$`, Pattern: `aws-sdk-go`}})
+	assert.Error(t, err, "a pattern without a capture group can't capture a value")
+
+	_, err = NewFingerprints([]v1.EnvironmentFingerprint{{File: `/versions\.txt$`, Pattern: `aws-sdk-go (\S+)`}})
+	assert.Error(t, err, "fingerprints need a name")
+
+	fingerprints, err := NewFingerprints([]v1.EnvironmentFingerprint{{Name: "sdk", File: `/versions\.txt$`, Pattern: `aws-sdk-go (\S+)`}})
+	assert.NoError(t, err)
+	assert.Len(t, FingerprintFiles(fingerprints), 1)
+}
@@ -62,6 +62,9 @@ type GCSJobRun struct {
 	gcsJunitPaths  []string
 
 	pathToContent map[string][]byte
+
+	// artifactBytes is the total size of the objects seen by the last FindAllMatches listing.
+	artifactBytes int64
 }
 
 func NewGCSJobRun(bkt *storage.BucketHandle, path string) *GCSJobRun {
@@ -193,6 +196,7 @@ func (j *GCSJobRun) FindAllMatches(filenames []*regexp.Regexp) [][]string {
 		return nil
 	}
 	matches := make([][]string, len(filenames))
+	j.artifactBytes = 0
 
 	it := j.bkt.Objects(context.Background(), &storage.Query{
 		Prefix: j.gcsProwJobPath,
@@ -202,6 +206,7 @@ func (j *GCSJobRun) FindAllMatches(filenames []*regexp.Regexp) [][]string {
 		if err == iterator.Done {
 			break
 		}
+		j.artifactBytes += attrs.Size
 
 		for i, filename := range filenames {
 			if matches[i] == nil {
@@ -215,3 +220,8 @@ func (j *GCSJobRun) FindAllMatches(filenames []*regexp.Regexp) [][]string {
 
 	return matches
 }
+
+// ArtifactBytes returns the total size of the job run's artifacts, as found by the last call to FindAllMatches.
+func (j *GCSJobRun) ArtifactBytes() int64 {
+	return j.artifactBytes
+}
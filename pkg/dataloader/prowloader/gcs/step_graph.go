@@ -0,0 +1,75 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var stepGraphFileRegex = regexp.MustCompile(`/ci-operator-step-graph\.json$`)
+
+// GetStepGraphFile matches the step graph ci-operator uploads, describing each step it ran and whether it failed.
+func GetStepGraphFile() *regexp.Regexp {
+	return stepGraphFileRegex
+}
+
+// stepGraphNode is a step in ci-operator-step-graph.json. Multi-stage tests list the steps they ran as substeps,
+// named after the test followed by the step.
+type stepGraphNode struct {
+	Name       string          `json:"name"`
+	StartedAt  *time.Time      `json:"started_at"`
+	FinishedAt *time.Time      `json:"finished_at"`
+	Failed     *bool           `json:"failed"`
+	Substeps   []stepGraphNode `json:"substeps"`
+}
+
+// StepResult is the outcome of a step of a multi-stage test, i.e. the ipi-install-install step of e2e-aws-ovn.
+type StepResult struct {
+	// Test is the multi-stage test the step ran in, i.e. e2e-aws-ovn.
+	Test string
+	// Step is the step's name in the step registry, i.e. ipi-install-install.
+	Step      string
+	Failed    bool
+	StartedAt *time.Time
+	Duration  time.Duration
+}
+
+// ParseStepGraph returns the results of the steps of the multi-stage tests in a step graph, in the order they are
+// listed. Steps that never started are left out.
+func ParseStepGraph(content []byte) ([]StepResult, error) {
+	var nodes []stepGraphNode
+	if err := json.Unmarshal(content, &nodes); err != nil {
+		return nil, err
+	}
+
+	results := []StepResult{}
+	for _, node := range nodes {
+		for _, sub := range node.Substeps {
+			if sub.StartedAt == nil {
+				continue
+			}
+			result := StepResult{
+				Test:      node.Name,
+				Step:      strings.TrimPrefix(sub.Name, node.Name+"-"),
+				Failed:    sub.Failed != nil && *sub.Failed,
+				StartedAt: sub.StartedAt,
+			}
+			if sub.FinishedAt != nil {
+				result.Duration = sub.FinishedAt.Sub(*sub.StartedAt)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// GetStepResults reads the step results from the step graph at path.
+func (j *GCSJobRun) GetStepResults(ctx context.Context, path string) ([]StepResult, error) {
+	content, err := j.GetContent(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStepGraph(content)
+}
@@ -0,0 +1,40 @@
+package gcs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStepGraph(t *testing.T) {
+	content := []byte(`[
+		{"name": "src", "started_at": "2024-03-20T12:00:00Z", "finished_at": "2024-03-20T12:01:00Z"},
+		{
+			"name": "e2e-aws-ovn",
+			"started_at": "2024-03-20T12:05:00Z",
+			"failed": true,
+			"substeps": [
+				{"name": "e2e-aws-ovn-ipi-install-install", "started_at": "2024-03-20T12:05:00Z", "finished_at": "2024-03-20T12:45:00Z", "failed": false},
+				{"name": "e2e-aws-ovn-openshift-e2e-test", "started_at": "2024-03-20T12:45:00Z", "finished_at": "2024-03-20T14:00:00Z", "failed": true},
+				{"name": "e2e-aws-ovn-ipi-deprovision-deprovision"}
+			]
+		}
+	]`)
+
+	results, err := ParseStepGraph(content)
+	require.NoError(t, err)
+	require.Len(t, results, 2, "only multi-stage test steps that started are returned")
+	assert.Equal(t, "e2e-aws-ovn", results[0].Test)
+	assert.Equal(t, "ipi-install-install", results[0].Step)
+	assert.False(t, results[0].Failed)
+	assert.Equal(t, 40*time.Minute, results[0].Duration)
+	assert.Equal(t, "openshift-e2e-test", results[1].Step)
+	assert.True(t, results[1].Failed)
+
+	assert.True(t, GetStepGraphFile().MatchString("logs/periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn/1770069582231949312/artifacts/ci-operator-step-graph.json"))
+
+	_, err = ParseStepGraph([]byte(`{"not": "a list"}`))
+	assert.Error(t, err)
+}
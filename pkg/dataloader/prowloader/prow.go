@@ -13,8 +13,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
-	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/storage"
 	"github.com/jackc/pgtype"
 	"github.com/pkg/errors"
@@ -26,6 +26,8 @@ import (
 	"github.com/openshift/sippy/pkg/apis/junit"
 	"github.com/openshift/sippy/pkg/apis/prow"
 	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	bqcachedclient "github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/dataloader"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/github"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/testconversion"
@@ -45,11 +47,11 @@ var gcsPathStrip = regexp.MustCompile(`.*/gs/[^/]+/`)
 type ProwLoader struct {
 	ctx                     context.Context
 	dbc                     *db.DB
-	bkt                     *storage.BucketHandle
-	bktName                 string
+	deployments             []*deployment
 	errors                  []error
 	githubClient            *github.Client
-	bigQueryClient          *bigquery.Client
+	bigQueryClient          *bqcachedclient.Client
+	gcsClient               *storage.Client
 	maxConcurrency          int
 	prowJobCache            map[string]*models.ProwJob
 	prowJobCacheLock        sync.RWMutex
@@ -57,49 +59,82 @@ type ProwLoader struct {
 	prowJobRunCacheLock     sync.RWMutex
 	prowJobRunTestCache     map[string]uint
 	prowJobRunTestCacheLock sync.RWMutex
-	variantManager          testidentification.VariantManager
 	suiteCache              map[string]*uint
 	suiteCacheLock          sync.RWMutex
-	syntheticTestManager    synthetictests.SyntheticTestManager
 	releases                []string
 	config                  *v1config.SippyConfig
 	ghCommenter             *commenter.GitHubCommenter
 	jobsImportedCount       atomic.Int32
+	jobRunsInserted         atomic.Int64
+	testResultsInserted     atomic.Int64
+	releaseErrorCounts      map[string]int
+	releaseErrorCountsLock  sync.Mutex
+	// backfillSource, if set, holds archived job list snapshots to import instead of the live job lists.
+	backfillSource string
+	// testNameNormalizer rewrites test names with the configured rules, testNameAliasCache holds the rewritten
+	// names already recorded.
+	testNameNormalizer     *testidentification.TestNameNormalizer
+	testNameAliasCache     map[string]bool
+	testNameAliasCacheLock sync.Mutex
+	// fingerprints are read from each run's artifacts to identify the versions of its dependencies.
+	fingerprints []gcs.Fingerprint
 }
 
 func New(
 	ctx context.Context,
 	dbc *db.DB,
 	gcsClient *storage.Client,
-	bigQueryClient *bigquery.Client,
+	bigQueryClient *bqcachedclient.Client,
 	gcsBucket string,
 	githubClient *github.Client,
 	variantManager testidentification.VariantManager,
 	syntheticTestManager synthetictests.SyntheticTestManager,
+	modeManagers ModeManagers,
 	releases []string,
 	config *v1config.SippyConfig,
-	ghCommenter *commenter.GitHubCommenter) *ProwLoader {
+	ghCommenter *commenter.GitHubCommenter,
+	maxConcurrency int) (*ProwLoader, error) {
 
-	bkt := gcsClient.Bucket(gcsBucket)
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
 
-	return &ProwLoader{
-		ctx:                  ctx,
-		dbc:                  dbc,
-		bkt:                  bkt,
-		bktName:              gcsBucket,
-		githubClient:         githubClient,
-		bigQueryClient:       bigQueryClient,
-		maxConcurrency:       10,
-		prowJobRunCache:      loadProwJobRunCache(dbc),
-		prowJobCache:         loadProwJobCache(dbc),
-		prowJobRunTestCache:  make(map[string]uint),
-		suiteCache:           make(map[string]*uint),
-		syntheticTestManager: syntheticTestManager,
-		variantManager:       variantManager,
-		releases:             releases,
-		config:               config,
-		ghCommenter:          ghCommenter,
+	deployments, err := newDeployments(gcsClient, gcsBucket, config.Prow)
+	if err != nil {
+		return nil, err
+	}
+	if err := setManagers(deployments, variantManager, syntheticTestManager, modeManagers); err != nil {
+		return nil, err
+	}
+	testNameNormalizer, err := testidentification.NewTestNameNormalizer(config.TestNameRules)
+	if err != nil {
+		return nil, err
 	}
+	fingerprints, err := gcs.NewFingerprints(config.EnvironmentFingerprints)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProwLoader{
+		ctx:                 ctx,
+		dbc:                 dbc,
+		deployments:         deployments,
+		githubClient:        githubClient,
+		bigQueryClient:      bigQueryClient,
+		gcsClient:           gcsClient,
+		maxConcurrency:      maxConcurrency,
+		prowJobRunCache:     loadProwJobRunCache(dbc),
+		prowJobCache:        loadProwJobCache(dbc),
+		prowJobRunTestCache: make(map[string]uint),
+		suiteCache:          make(map[string]*uint),
+		releases:            releases,
+		config:              config,
+		ghCommenter:         ghCommenter,
+		releaseErrorCounts:  make(map[string]int),
+		testNameNormalizer:  testNameNormalizer,
+		testNameAliasCache:  make(map[string]bool),
+		fingerprints:        fingerprints,
+	}, nil
 }
 
 var clusterDataDateTimeName = regexp.MustCompile(`cluster-data_(?P<DATE>.*)-(?P<TIME>.*).json`)
@@ -146,6 +181,14 @@ func (pl *ProwLoader) Errors() []error {
 	return pl.errors
 }
 
+// Counts returns the job runs and test results inserted by the load.
+func (pl *ProwLoader) Counts() dataloader.Counts {
+	return dataloader.Counts{
+		JobRuns:     pl.jobRunsInserted.Load(),
+		TestResults: pl.testResultsInserted.Load(),
+	}
+}
+
 func (pl *ProwLoader) Load() {
 	start := time.Now()
 	log.Infof("started loading prow jobs to DB...")
@@ -155,32 +198,61 @@ func (pl *ProwLoader) Load() {
 		pl.errors = append(pl.errors, errors.Wrap(err, "error in syncPRStatus"))
 	}
 
-	// Grab the ProwJob definitions from prow or CI bigquery. Note that these are the Kube
-	// ProwJob CRDs, not our sippy db model ProwJob.
-	var prowJobs []prow.ProwJob
-	// Fetch/update job data
-	if pl.bigQueryClient != nil {
-		var bqErrs []error
-		prowJobs, bqErrs = pl.fetchProwJobsFromOpenShiftBigQuery()
-		if len(bqErrs) > 0 {
-			pl.errors = append(pl.errors, bqErrs...)
-		}
+	if pl.backfillSource != "" {
+		pl.loadBackfill()
 	} else {
-		jobsJSON, err := fetchJobsJSON(pl.config.Prow.URL)
-		if err != nil {
-			pl.errors = append(pl.errors, errors.Wrap(err, "error fetching job JSON data from prow"))
-			return
+		pl.loadDeployments()
+	}
+
+	if len(pl.errors) > 0 {
+		log.Warningf("encountered %d errors while importing job runs", len(pl.errors))
+		for release, count := range pl.releaseErrorCounts {
+			log.WithField("release", release).Warningf("%d job runs failed to import", count)
 		}
-		prowJobs, err = jobsJSONToProwJobs(jobsJSON)
-		if err != nil {
-			pl.errors = append(pl.errors, errors.Wrap(err, "error decoding job JSON data from prow"))
-			return
+	}
+	log.Infof("finished importing new job runs in %+v", time.Since(start))
+}
+
+// loadDeployments imports the job runs currently listed by each prow deployment.
+func (pl *ProwLoader) loadDeployments() {
+	for _, d := range pl.deployments {
+		// Grab the ProwJob definitions from prow or CI bigquery. Note that these are the Kube
+		// ProwJob CRDs, not our sippy db model ProwJob.
+		var prowJobs []prow.ProwJob
+		// Fetch/update job data
+		switch {
+		case d.name == defaultDeploymentName && pl.bigQueryClient != nil:
+			var bqErrs []error
+			prowJobs, bqErrs = pl.fetchProwJobsFromOpenShiftBigQuery()
+			if len(bqErrs) > 0 {
+				pl.errors = append(pl.errors, bqErrs...)
+			}
+		case d.url == "" && len(pl.deployments) > 1:
+			// Only additional deployments are configured
+			continue
+		default:
+			jobsJSON, err := fetchJobsJSON(d.url)
+			if err != nil {
+				pl.errors = append(pl.errors, errors.Wrapf(err, "error fetching job JSON data from prow deployment %s", d.name))
+				continue
+			}
+			prowJobs, err = jobsJSONToProwJobs(jobsJSON)
+			if err != nil {
+				pl.errors = append(pl.errors, errors.Wrapf(err, "error decoding job JSON data from prow deployment %s", d.name))
+				continue
+			}
 		}
+
+		pl.loadProwJobs(d, prowJobs)
 	}
+}
 
+// loadProwJobs imports the runs of prowJobs from deployment d, pl.maxConcurrency at a time.
+func (pl *ProwLoader) loadProwJobs(d *deployment, prowJobs []prow.ProwJob) {
 	queue := make(chan *prow.ProwJob)
 	errsCh := make(chan error, len(prowJobs))
 	total := len(prowJobs)
+	pl.jobsImportedCount.Store(0)
 
 	// Producer to keep feeding the queue
 	go prowJobsProducer(pl.ctx, queue, prowJobs)
@@ -197,12 +269,12 @@ func (pl *ProwLoader) Load() {
 					log.WithError(err).Warningf("consumer exiting, got error")
 					break
 				}
-				if err := pl.processProwJob(ctx, job); err != nil {
+				if err := pl.processProwJob(ctx, d, job); err != nil {
 					errsCh <- err
 					log.WithError(err).Warningf("couldn't import job %s/%s, continuing", job.Spec.Job, job.Status.BuildID)
 				}
 				pl.jobsImportedCount.Add(1)
-				log.Infof("%d of %d job runs processed", pl.jobsImportedCount.Load(), total)
+				log.Infof("%d of %d job runs processed from prow deployment %s", pl.jobsImportedCount.Load(), total, d.name)
 			}
 		}(pl.ctx)
 	}
@@ -212,11 +284,6 @@ func (pl *ProwLoader) Load() {
 	for err := range errsCh {
 		pl.errors = append(pl.errors, err)
 	}
-
-	if len(pl.errors) > 0 {
-		log.Warningf("encountered %d errors while importing job runs", len(pl.errors))
-	}
-	log.Infof("finished importing new job runs in %+v", time.Since(start))
 }
 
 func prowJobsProducer(ctx context.Context, queue chan *prow.ProwJob, jobs []prow.ProwJob) {
@@ -230,7 +297,7 @@ func prowJobsProducer(ctx context.Context, queue chan *prow.ProwJob, jobs []prow
 	}
 }
 
-func (pl *ProwLoader) processProwJob(ctx context.Context, pj *prow.ProwJob) error {
+func (pl *ProwLoader) processProwJob(ctx context.Context, d *deployment, pj *prow.ProwJob) error {
 	pjLog := log.WithFields(log.Fields{
 		"job":     pj.Spec.Job,
 		"buildID": pj.Status.BuildID,
@@ -244,9 +311,10 @@ func (pl *ProwLoader) processProwJob(ctx context.Context, pj *prow.ProwJob) erro
 		}
 
 		if val, ok := cfg.Jobs[pj.Spec.Job]; val && ok {
-			if err := pl.prowJobToJobRun(ctx, pj, release); err != nil {
+			if err := pl.prowJobToJobRun(ctx, d, pj, release); err != nil {
 				err = errors.Wrapf(err, "error converting prow job to job run: %s", pj.Spec.Job)
 				pjLog.WithError(err).Warning("prow import error")
+				pl.recordReleaseError(release)
 				return err
 			}
 			return nil
@@ -261,9 +329,10 @@ func (pl *ProwLoader) processProwJob(ctx context.Context, pj *prow.ProwJob) erro
 			}
 
 			if re.MatchString(pj.Spec.Job) {
-				if err := pl.prowJobToJobRun(ctx, pj, release); err != nil {
+				if err := pl.prowJobToJobRun(ctx, d, pj, release); err != nil {
 					err = errors.Wrapf(err, "error converting prow job to job run: %s", pj.Spec.Job)
 					pjLog.WithError(err).Warning("prow import error")
+					pl.recordReleaseError(release)
 					return err
 				}
 				return nil
@@ -275,6 +344,14 @@ func (pl *ProwLoader) processProwJob(ctx context.Context, pj *prow.ProwJob) erro
 	return nil
 }
 
+// recordReleaseError tracks import failures per release, so a single broken release does not
+// get lost in the noise of a large load.
+func (pl *ProwLoader) recordReleaseError(release string) {
+	pl.releaseErrorCountsLock.Lock()
+	defer pl.releaseErrorCountsLock.Unlock()
+	pl.releaseErrorCounts[release]++
+}
+
 func (pl *ProwLoader) syncPRStatus() error {
 	if pl.githubClient == nil {
 		log.Infof("No GitHub client, skipping PR sync")
@@ -359,23 +436,43 @@ func jobsJSONToProwJobs(jobJSON []byte) ([]prow.ProwJob, error) {
 
 func (pl *ProwLoader) generateTestGridURL(release, jobName string) *url.URL {
 	if releaseConfig, ok := pl.config.Releases[release]; ok {
-		dashboard := "redhat-openshift-ocp-release-" + release
-		blockingJobs := sets.NewString(releaseConfig.BlockingJobs...)
-		informingJobs := sets.NewString(releaseConfig.InformingJobs...)
-		jobType := ""
-		if blockingJobs.Has(jobName) {
-			jobType = "blocking"
-		} else if informingJobs.Has(jobName) {
-			jobType = "informing"
-		}
-		if len(jobType) != 0 {
-			dashboard = dashboard + "-" + jobType
+		if dashboard := testGridDashboard(release, releaseConfig, jobName); dashboard != "" {
 			return util.URLForJob(dashboard, jobName)
 		}
 	}
 	return &url.URL{}
 }
 
+// testGridDashboard returns the testgrid dashboard a job is shown on: the OpenShift release's blocking or
+// informing dashboard for payload jobs, otherwise the first configured dashboard listing it, such as a Kubernetes
+// presubmit dashboard. It returns "" for jobs on no known dashboard.
+func testGridDashboard(release string, releaseConfig v1config.ReleaseConfig, jobName string) string {
+	dashboard := "redhat-openshift-ocp-release-" + release
+	if sets.NewString(releaseConfig.BlockingJobs...).Has(jobName) {
+		return dashboard + "-blocking"
+	}
+	if sets.NewString(releaseConfig.InformingJobs...).Has(jobName) {
+		return dashboard + "-informing"
+	}
+
+	for _, d := range releaseConfig.TestGridDashboards {
+		if sets.NewString(d.Jobs...).Has(jobName) {
+			return d.Name
+		}
+		for _, expr := range d.Regexp {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				log.WithError(err).Errorf("invalid regex in testgrid dashboard %q configuration", d.Name)
+				continue
+			}
+			if re.MatchString(jobName) {
+				return d.Name
+			}
+		}
+	}
+	return ""
+}
+
 func GetClusterDataBytes(ctx context.Context, bkt *storage.BucketHandle, path string, matches []string) ([]byte, error) {
 	// get the variant cluster data for this job run
 	gcsJobRun := gcs.NewGCSJobRun(bkt, path)
@@ -525,7 +622,7 @@ func mostRecentDateTimeName(one, two DateTimeName) DateTimeName {
 	return two
 }
 
-func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, release string) error {
+func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, d *deployment, pj *prow.ProwJob, release string) error {
 	pjLog := log.WithFields(log.Fields{
 		"job":     pj.Spec.Job,
 		"buildID": pj.Status.BuildID,
@@ -549,16 +646,23 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 	// and prowJobRunTestsFromGCS
 	// add more regexes if we require more
 	// results from scanning for file names
-	path, err := GetGCSPathForProwJobURL(pjLog, pj.Status.URL)
+	path, err := d.jobRunPath(pjLog, pj)
 	if err != nil {
 		pjLog.WithError(err).WithField("prowJobURL", pj.Status.URL).Error("error getting GCS path for prow job URL")
 		return err
 	}
-	gcsJobRun := gcs.NewGCSJobRun(pl.bkt, path)
-	allMatches := gcsJobRun.FindAllMatches([]*regexp.Regexp{gcs.GetDefaultJunitFile()})
-	var junitMatches []string
-	if len(allMatches) > 0 {
+	gcsJobRun := gcs.NewGCSJobRun(d.bkt, path)
+	allMatches := gcsJobRun.FindAllMatches(append([]*regexp.Regexp{gcs.GetDefaultJunitFile(), gcs.GetDebugArtifactFile(), gcs.GetDisruptionIntervalFile(),
+		gcs.GetDefaultClusterDataFile(), gcs.GetStepGraphFile()}, gcs.FingerprintFiles(pl.fingerprints)...))
+	var junitMatches, debugMatches, disruptionMatches, clusterDataMatches, stepGraphMatches []string
+	var fingerprintMatches [][]string
+	if len(allMatches) > 4 {
 		junitMatches = allMatches[0]
+		debugMatches = allMatches[1]
+		disruptionMatches = allMatches[2]
+		clusterDataMatches = allMatches[3]
+		stepGraphMatches = allMatches[4]
+		fingerprintMatches = allMatches[5:]
 	}
 
 	// Lock the whole prow job block to avoid trying to create the pj multiple times concurrently\
@@ -571,7 +675,7 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 			Name:        pj.Spec.Job,
 			Kind:        models.ProwKind(pj.Spec.Type),
 			Release:     release,
-			Variants:    pl.variantManager.IdentifyVariants(pj.Spec.Job),
+			Variants:    d.variantManager.IdentifyVariants(pj.Spec.Job),
 			TestGridURL: pl.generateTestGridURL(release, pj.Spec.Job).String(),
 		}
 		err := pl.dbc.DB.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(dbProwJob).Error
@@ -581,7 +685,7 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 		pl.prowJobCache[pj.Spec.Job] = dbProwJob
 	} else {
 		saveDB := false
-		newVariants := pl.variantManager.IdentifyVariants(pj.Spec.Job)
+		newVariants := d.variantManager.IdentifyVariants(pj.Spec.Job)
 		if !reflect.DeepEqual(newVariants, []string(dbProwJob.Variants)) || dbProwJob.Kind != models.ProwKind(pj.Spec.Type) {
 			dbProwJob.Kind = models.ProwKind(pj.Spec.Type)
 			dbProwJob.Variants = newVariants
@@ -594,7 +698,8 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 			}
 		}
 		if saveDB {
-			if res := pl.dbc.DB.WithContext(ctx).Save(&dbProwJob); res.Error != nil {
+			// The cached lineage may predate an override, which the loader must not undo.
+			if res := pl.dbc.DB.WithContext(ctx).Omit("Lineage").Save(&dbProwJob); res.Error != nil {
 				return res.Error
 			}
 		}
@@ -609,36 +714,103 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 	} else {
 		pjLog.Info("processing GCS bucket")
 
-		tests, failures, overallResult, err := pl.prowJobRunTestsFromGCS(ctx, pj, uint(id), path, junitMatches)
+		// Steps are read first as the synthetic tests of hypershift jobs are made from them.
+		var steps []models.ProwJobRunStep
+		for _, stepGraph := range stepGraphMatches {
+			results, err := gcsJobRun.GetStepResults(ctx, stepGraph)
+			if err != nil {
+				// Steps are supplementary, don't lose the run over them.
+				pjLog.WithError(err).WithField("stepGraph", stepGraph).Warning("error reading step graph")
+				continue
+			}
+			steps = append(steps, jobRunSteps(results)...)
+		}
+
+		tests, failures, overallResult, err := pl.prowJobRunTestsFromGCS(ctx, d, pj, uint(id), path, junitMatches, steps)
 		if err != nil {
 			return err
 		}
 
 		pulls := pl.findOrAddPullRequests(pj.Spec.Refs, path)
 
+		// Link the debugging outputs of failed runs, successful runs have no use for them.
+		var debugArtifacts []models.ProwJobRunDebugArtifact
+		if !overallResult.IsSuccess() {
+			for _, a := range gcs.DebugArtifacts(debugMatches) {
+				debugArtifacts = append(debugArtifacts, models.ProwJobRunDebugArtifact{Kind: a.Kind, URL: d.artifactLink(a.Path)})
+			}
+		}
+
+		var disruptions []models.ProwJobRunDisruption
+		if len(disruptionMatches) > 0 {
+			backendSeconds, err := gcsJobRun.GetBackendDisruptionSeconds(ctx, disruptionMatches)
+			if err != nil {
+				// Disruption is supplementary, don't lose the run over it.
+				pjLog.WithError(err).Warning("error reading disruption intervals")
+			}
+			for backend, seconds := range backendSeconds {
+				disruptions = append(disruptions, models.ProwJobRunDisruption{BackendName: backend, DisruptionSeconds: seconds})
+			}
+		}
+
+		values, err := gcsJobRun.GetFingerprints(ctx, pl.fingerprints, fingerprintMatches)
+		if err != nil {
+			// Fingerprints are supplementary as well, keep whatever was read.
+			pjLog.WithError(err).Warning("error reading environment fingerprints")
+		}
+		fingerprints := make([]models.ProwJobRunFingerprint, 0, len(values))
+		for name, value := range values {
+			fingerprints = append(fingerprints, models.ProwJobRunFingerprint{Name: name, Value: value})
+		}
+
+		// The cloud region and zone the cluster ran in, for correlating failures with cloud provider brownouts.
+		var clusterData models.ClusterData
+		if len(clusterDataMatches) > 0 {
+			clusterData = GetClusterData(ctx, d.bkt, path, clusterDataMatches)
+		}
+
 		var duration time.Duration
 		if pj.Status.CompletionTime != nil {
 			duration = pj.Status.CompletionTime.Sub(pj.Status.StartTime)
 		}
 
+		// Backfilled runs are created long after they ran, date their rows by the run so they are partitioned
+		// and reported with the period they belong to.
+		var createdAt time.Time
+		if pl.backfillSource != "" {
+			createdAt = pj.Status.StartTime
+			for _, t := range tests {
+				t.CreatedAt = createdAt
+			}
+		}
+
 		err = pl.dbc.DB.WithContext(ctx).Create(&models.ProwJobRun{
 			Model: gorm.Model{
-				ID: uint(id),
+				ID:        uint(id),
+				CreatedAt: createdAt,
 			},
-			Cluster:       pj.Spec.Cluster,
-			Duration:      duration,
-			ProwJob:       *dbProwJob,
-			ProwJobID:     dbProwJob.ID,
-			URL:           pj.Status.URL,
-			Timestamp:     pj.Status.StartTime,
-			OverallResult: overallResult,
-			PullRequests:  pulls,
-			TestFailures:  failures,
-			Succeeded:     overallResult == sippyprocessingv1.JobSucceeded,
+			Cluster:        pj.Spec.Cluster,
+			Duration:       duration,
+			ProwJob:        *dbProwJob,
+			ProwJobID:      dbProwJob.ID,
+			URL:            pj.Status.URL,
+			ArtifactBytes:  gcsJobRun.ArtifactBytes(),
+			Timestamp:      pj.Status.StartTime,
+			OverallResult:  overallResult,
+			PullRequests:   pulls,
+			DebugArtifacts: debugArtifacts,
+			Disruptions:    disruptions,
+			Steps:          steps,
+			Fingerprints:   fingerprints,
+			TestFailures:   failures,
+			Succeeded:      overallResult.IsSuccess(),
+			CloudRegion:    clusterData.CloudRegion,
+			CloudZone:      clusterData.CloudZone,
 		}).Error
 		if err != nil {
 			return err
 		}
+		pl.jobRunsInserted.Add(1)
 		// Looks like sometimes, we might be getting duplicate entries from bigquery:
 		pl.prowJobRunCacheLock.Lock()
 		pl.prowJobRunCache[uint(id)] = true
@@ -648,12 +820,22 @@ func (pl *ProwLoader) prowJobToJobRun(ctx context.Context, pj *prow.ProwJob, rel
 		if err != nil {
 			return err
 		}
+		pl.testResultsInserted.Add(int64(len(tests)))
 	}
 
 	pjLog.Infof("processing complete")
 	return nil
 }
 
+// jobRunSteps converts the step results of a step graph to the rows stored for the run.
+func jobRunSteps(results []gcs.StepResult) []models.ProwJobRunStep {
+	steps := make([]models.ProwJobRunStep, 0, len(results))
+	for _, r := range results {
+		steps = append(steps, models.ProwJobRunStep{Test: r.Test, Step: r.Step, Failed: r.Failed, Duration: r.Duration})
+	}
+	return steps
+}
+
 func GetGCSPathForProwJobURL(pjLog log.FieldLogger, prowJobURL string) (string, error) {
 	// this err validation has moved up
 	// and will exit before we save / update the ProwJob
@@ -786,6 +968,22 @@ func (pl *ProwLoader) findOrAddTest(name string) (uint, error) {
 	return test.ID, nil
 }
 
+// recordTestNameAlias notes that a test name was rewritten into the test, for the report of collapsed tests.
+func (pl *ProwLoader) recordTestNameAlias(rawName string, testID uint, rules []string) {
+	pl.testNameAliasCacheLock.Lock()
+	defer pl.testNameAliasCacheLock.Unlock()
+	if pl.testNameAliasCache[rawName] {
+		return
+	}
+	alias := models.TestNameAlias{RawName: rawName, TestID: testID, Rules: rules}
+	res := pl.dbc.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "raw_name"}}, DoNothing: true}).Create(&alias)
+	if res.Error != nil {
+		log.WithError(res.Error).Warningf("failed to record test name alias %q", rawName)
+		return
+	}
+	pl.testNameAliasCache[rawName] = true
+}
+
 func (pl *ProwLoader) findSuite(name string) *uint {
 	if name == "" {
 		return nil
@@ -811,16 +1009,30 @@ func (pl *ProwLoader) findSuite(name string) *uint {
 	return pl.suiteCache[name]
 }
 
-func (pl *ProwLoader) prowJobRunTestsFromGCS(ctx context.Context, pj *prow.ProwJob, id uint, path string, junitPaths []string) ([]*models.ProwJobRunTest, int, sippyprocessingv1.JobOverallResult, error) {
+func (pl *ProwLoader) prowJobRunTestsFromGCS(ctx context.Context, d *deployment, pj *prow.ProwJob, id uint, path string, junitPaths []string,
+	steps []models.ProwJobRunStep) ([]*models.ProwJobRunTest, int, sippyprocessingv1.JobOverallResult, error) {
 	failures := 0
 
-	gcsJobRun := gcs.NewGCSJobRun(pl.bkt, path)
+	gcsJobRun := gcs.NewGCSJobRun(d.bkt, path)
 	gcsJobRun.SetGCSJunitPaths(junitPaths)
 	suites, err := gcsJobRun.GetCombinedJUnitTestSuites(ctx)
 	if err != nil {
 		log.Warningf("failed to get junit test suites: %s", err.Error())
 		return []*models.ProwJobRunTest{}, 0, "", err
 	}
+
+	// The build log is only needed to explain failures and find upgrade rollbacks, so skip fetching it for
+	// successful runs that can't have upgraded.
+	stepTimedOut, upgradeRolledBack := false, false
+	if pj.Status.State != prow.SuccessState || isUpgradeJob(pj.Spec.Job) {
+		buildLog, err := gcsJobRun.GetContent(ctx, fmt.Sprintf("%s/%s", path, buildLogName))
+		if err != nil {
+			log.WithError(err).Debug("could not read build log")
+		}
+		stepTimedOut = pj.Status.State != prow.SuccessState && buildLogTimedOut(buildLog)
+		upgradeRolledBack = !isRollbackJob(pj.Spec.Job) && buildLogRolledBack(buildLog)
+	}
+
 	testCases := make(map[string]*models.ProwJobRunTest)
 	for _, suite := range suites.Suites {
 		suiteID := pl.findSuite(suite.Name)
@@ -829,17 +1041,17 @@ func (pl *ProwLoader) prowJobRunTestsFromGCS(ctx context.Context, pj *prow.ProwJ
 			continue
 		}
 
-		pl.extractTestCases(suite, suiteID, testCases)
+		pl.extractTestCases(suite, suiteID, testCases, stepTimedOut)
 	}
 
-	syntheticSuite, jobResult := testconversion.ConvertProwJobRunToSyntheticTests(*pj, testCases, pl.syntheticTestManager)
+	syntheticSuite, jobResult := testconversion.ConvertProwJobRunToSyntheticTests(*pj, testCases, steps, upgradeRolledBack, d.syntheticTestManager)
 
 	suiteID := pl.findSuite(syntheticSuite.Name)
 	if suiteID == nil {
 		// this shouldn't happen but if it does we want to know
 		panic("synthetic suite is missing from the database")
 	}
-	pl.extractTestCases(syntheticSuite, suiteID, testCases)
+	pl.extractTestCases(syntheticSuite, suiteID, testCases, false)
 	log.Infof("synthetic suite had %d tests", syntheticSuite.NumTests)
 
 	results := make([]*models.ProwJobRunTest, 0)
@@ -858,7 +1070,30 @@ func (pl *ProwLoader) prowJobRunTestsFromGCS(ctx context.Context, pj *prow.ProwJ
 	return results, failures, jobResult, nil
 }
 
-func (pl *ProwLoader) extractTestCases(suite *junit.TestSuite, suiteID *uint, testCases map[string]*models.ProwJobRunTest) {
+const (
+	// maxTestMessageLength and maxTestOutputLength cap how much of a failed test's junit message and output
+	// we store, some tests dump entire logs into their failure output.
+	maxTestMessageLength = 4 * 1024
+	maxTestOutputLength  = 64 * 1024
+
+	truncatedSuffix = "\n... (truncated)"
+)
+
+// truncateTestOutput shortens s to at most limit bytes without splitting a multibyte character.
+func truncateTestOutput(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit - len(truncatedSuffix)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedSuffix
+}
+
+// extractTestCases adds the results of the suite's test cases, and those of its children, to testCases. Failures
+// caused by the suite timing out are recorded as timeouts, see isSuiteTimeout.
+func (pl *ProwLoader) extractTestCases(suite *junit.TestSuite, suiteID *uint, testCases map[string]*models.ProwJobRunTest, stepTimedOut bool) {
 	testOutputMetadataExtractor := TestFailureMetadataExtractor{}
 
 	for _, tc := range suite.TestCases {
@@ -870,13 +1105,18 @@ func (pl *ProwLoader) extractTestCases(suite *junit.TestSuite, suiteID *uint, te
 			status = sippyprocessingv1.TestStatusSuccess
 		} else {
 			failureOutput = &models.ProwJobRunTestOutput{
-				Output: tc.FailureOutput.Output,
+				Message: tc.FailureOutput.Message,
+				Output:  tc.FailureOutput.Output,
+			}
+			if isSuiteTimeout(failureOutput, stepTimedOut) {
+				status = sippyprocessingv1.TestStatusTimeout
 			}
 		}
 
 		// Cache key should always have the suite name, so we don't combine
 		// a pass and a fail from two different suites to generate a flake.
-		testCacheKey := fmt.Sprintf("%s.%s", suite.Name, tc.Name)
+		name, rules := pl.testNameNormalizer.Normalize(tc.Name)
+		testCacheKey := fmt.Sprintf("%s.%s", suite.Name, name)
 
 		if failureOutput != nil {
 			// Check if this test is configured to extract metadata from it's output, and if so, create it
@@ -894,14 +1134,21 @@ func (pl *ProwLoader) extractTestCases(suite *junit.TestSuite, suiteID *uint, te
 					})
 				}
 			}
+
+			// Metadata is extracted from the full output above, but only a capped amount is stored.
+			failureOutput.Message = truncateTestOutput(failureOutput.Message, maxTestMessageLength)
+			failureOutput.Output = truncateTestOutput(failureOutput.Output, maxTestOutputLength)
 		}
 
 		if existing, ok := testCases[testCacheKey]; !ok {
-			testID, err := pl.findOrAddTest(tc.Name)
+			testID, err := pl.findOrAddTest(name)
 			if err != nil {
-				log.WithError(err).Warningf("could not find or create test %q", tc.Name)
+				log.WithError(err).Warningf("could not find or create test %q", name)
 				continue
 			}
+			if len(rules) > 0 {
+				pl.recordTestNameAlias(tc.Name, testID, rules)
+			}
 
 			testCases[testCacheKey] = &models.ProwJobRunTest{
 				TestID:               testID,
@@ -910,17 +1157,25 @@ func (pl *ProwLoader) extractTestCases(suite *junit.TestSuite, suiteID *uint, te
 				Duration:             tc.Duration,
 				ProwJobRunTestOutput: failureOutput,
 			}
-		} else if (existing.Status == int(sippyprocessingv1.TestStatusFailure) && status == sippyprocessingv1.TestStatusSuccess) ||
-			(existing.Status == int(sippyprocessingv1.TestStatusSuccess) && status == sippyprocessingv1.TestStatusFailure) {
-			// One pass among failures makes this a flake
-			existing.Status = int(sippyprocessingv1.TestStatusFlake)
-			if existing.ProwJobRunTestOutput == nil {
+		} else if merged := mergeTestStatus(sippyprocessingv1.TestStatus(existing.Status), status); merged != sippyprocessingv1.TestStatus(existing.Status) {
+			switch merged {
+			case sippyprocessingv1.TestStatusSuccess:
+				// A pass after a timeout, the timeout's output is of no interest
+				existing.ProwJobRunTestOutput = nil
+			case sippyprocessingv1.TestStatusFailure:
+				// A genuine failure after a timeout, keep the output explaining it
 				existing.ProwJobRunTestOutput = failureOutput
+			case sippyprocessingv1.TestStatusFlake:
+				// One pass among failures makes this a flake
+				if existing.ProwJobRunTestOutput == nil {
+					existing.ProwJobRunTestOutput = failureOutput
+				}
 			}
+			existing.Status = int(merged)
 		}
 	}
 
 	for _, c := range suite.Children {
-		pl.extractTestCases(c, suiteID, testCases)
+		pl.extractTestCases(c, suiteID, testCases, stepTimedOut)
 	}
 }
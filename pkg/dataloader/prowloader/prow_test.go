@@ -1,9 +1,12 @@
 package prowloader
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
 )
 
 func TestDateTimeNameComparisons(t *testing.T) {
@@ -86,3 +89,86 @@ func TestParseVariantDataFile(t *testing.T) {
 	assert.Equal(t, "IPv4", clusterData["NetworkStack"])
 	assert.Equal(t, "foo", clusterData["AddonProp1"])
 }
+
+func TestTruncateTestOutput(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	tests := []struct {
+		name     string
+		input    string
+		limit    int
+		expected string
+	}{
+		{
+			name:     "under limit",
+			input:    "short",
+			limit:    100,
+			expected: "short",
+		},
+		{
+			name:     "over limit",
+			input:    long,
+			limit:    50,
+			expected: long[:50-len(truncatedSuffix)] + truncatedSuffix,
+		},
+		{
+			name:     "does not split multibyte characters",
+			input:    strings.Repeat("é", 50),
+			limit:    51,
+			expected: strings.Repeat("é", 17) + truncatedSuffix,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := truncateTestOutput(tc.input, tc.limit)
+			assert.Equal(t, tc.expected, result)
+			assert.LessOrEqual(t, len(result), tc.limit)
+		})
+	}
+}
+
+func TestTestGridDashboard(t *testing.T) {
+	releaseConfig := v1config.ReleaseConfig{
+		BlockingJobs:  []string{"periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn"},
+		InformingJobs: []string{"periodic-ci-openshift-release-master-nightly-4.16-e2e-metal-ipi"},
+		TestGridDashboards: []v1config.TestGridDashboard{
+			{Name: "sig-release-master-blocking", Jobs: []string{"ci-kubernetes-e2e-gci-gce"}},
+			{Name: "presubmits-kubernetes-blocking", Regexp: []string{"(", "^pull-kubernetes-"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		job  string
+		want string
+	}{
+		{
+			name: "blocking payload job",
+			job:  "periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn",
+			want: "redhat-openshift-ocp-release-4.16-blocking",
+		},
+		{
+			name: "informing payload job",
+			job:  "periodic-ci-openshift-release-master-nightly-4.16-e2e-metal-ipi",
+			want: "redhat-openshift-ocp-release-4.16-informing",
+		},
+		{
+			name: "listed periodic",
+			job:  "ci-kubernetes-e2e-gci-gce",
+			want: "sig-release-master-blocking",
+		},
+		{
+			name: "presubmit matching regexp, skipping the invalid one",
+			job:  "pull-kubernetes-e2e-gce",
+			want: "presubmits-kubernetes-blocking",
+		},
+		{
+			name: "unknown job",
+			job:  "pull-ci-openshift-origin-master-e2e-aws",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, testGridDashboard("4.16", releaseConfig, tt.job))
+		})
+	}
+}
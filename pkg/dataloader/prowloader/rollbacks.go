@@ -0,0 +1,36 @@
+package prowloader
+
+import (
+	"regexp"
+	"strings"
+)
+
+// upgradeRollbackMarkers match the ci-operator build log of a job run whose upgrade was aborted or rolled back to
+// the version it started from.
+var upgradeRollbackMarkers = []*regexp.Regexp{
+	// openshift-tests, when the upgrade test gives up and reverts the cluster
+	regexp.MustCompile(`(?i)\brolling back (the )?(cluster|upgrade)\b`),
+	regexp.MustCompile(`(?i)\bupgrade (was )?(aborted|rolled back)\b`),
+	// the cluster version operator, reporting a rollback in the cluster version history
+	regexp.MustCompile(`(?i)\bcluster version (is )?rolling back to \S+`),
+}
+
+// buildLogRolledBack returns whether the build log shows the job run's upgrade was aborted or rolled back.
+func buildLogRolledBack(buildLog []byte) bool {
+	for _, marker := range upgradeRollbackMarkers {
+		if marker.Match(buildLog) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUpgradeJob returns whether a job may run an upgrade, so its build log is worth checking even when it passed.
+func isUpgradeJob(jobName string) bool {
+	return strings.Contains(jobName, "upgrade")
+}
+
+// isRollbackJob returns whether a job rolls back on purpose, so a rollback there is expected rather than a failure.
+func isRollbackJob(jobName string) bool {
+	return strings.Contains(jobName, "rollback")
+}
@@ -0,0 +1,44 @@
+package prowloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildLogRolledBack(t *testing.T) {
+	tests := []struct {
+		name     string
+		buildLog string
+		want     bool
+	}{
+		{
+			name:     "upgrade completed",
+			buildLog: "INFO[2024-03-20T12:00:00Z] Step e2e-aws-upgrade-openshift-e2e-test succeeded after 1h32m0s.",
+		},
+		{
+			name:     "rollback job step name",
+			buildLog: "INFO[2024-03-20T12:00:00Z] Running step e2e-aws-upgrade-rollback-ipi-install.",
+		},
+		{
+			name:     "openshift-tests rolling back",
+			buildLog: "Mar 20 12:00:00.000: INFO: Upgrade failed, rolling back the cluster to 4.15.3",
+			want:     true,
+		},
+		{
+			name:     "upgrade aborted",
+			buildLog: "error: upgrade was aborted: cluster operator machine-config is degraded",
+			want:     true,
+		},
+		{
+			name:     "cluster version rolling back",
+			buildLog: "Cluster version is rolling back to 4.15.3",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildLogRolledBack([]byte(tt.buildLog)))
+		})
+	}
+}
@@ -9,23 +9,50 @@ import (
 	"github.com/openshift/sippy/pkg/testidentification"
 )
 
-func ConvertProwJobRunToSyntheticTests(pj prow.ProwJob, tests map[string]*models.ProwJobRunTest, manager synthetictests.SyntheticTestManager) (*junit.TestSuite, v1.JobOverallResult) {
+func ConvertProwJobRunToSyntheticTests(pj prow.ProwJob, tests map[string]*models.ProwJobRunTest, steps []models.ProwJobRunStep,
+	upgradeRolledBack bool, manager synthetictests.SyntheticTestManager) (*junit.TestSuite, v1.JobOverallResult) {
 	jrr := v1.RawJobRunResult{
-		Job:       pj.Spec.Job,
-		Errored:   pj.Status.State == prow.ErrorState,
-		Failed:    pj.Status.State == prow.FailureState,
-		Succeeded: pj.Status.State == prow.SuccessState,
-		Aborted:   pj.Status.State == prow.AbortedState,
+		Job:               pj.Spec.Job,
+		Errored:           pj.Status.State == prow.ErrorState,
+		Failed:            pj.Status.State == prow.FailureState,
+		Succeeded:         pj.Status.State == prow.SuccessState,
+		Aborted:           pj.Status.State == prow.AbortedState,
+		UpgradeRolledBack: upgradeRolledBack,
 	}
 	testsToRawJobRunResult(&jrr, tests)
+	stepsToRawJobRunResult(&jrr, steps)
 	syntheticTests := manager.CreateSyntheticTests(&jrr)
 	return syntheticTests, jrr.OverallResult
 }
 
+// stepsToRawJobRunResult sets the status of the hosted cluster's creation and teardown from the steps doing them, a
+// failure of any of them failing it.
+func stepsToRawJobRunResult(jrr *v1.RawJobRunResult, steps []models.ProwJobRunStep) {
+	for _, step := range steps {
+		var status *string
+		switch {
+		case testidentification.IsHostedClusterTeardownStep(step.Step):
+			status = &jrr.HostedClusterTeardownStatus
+		case testidentification.IsHostedClusterCreateStep(step.Step):
+			status = &jrr.HostedClusterCreateStatus
+		default:
+			continue
+		}
+		if step.Failed {
+			*status = testidentification.Failure
+		} else if *status == "" {
+			*status = testidentification.Success
+		}
+	}
+}
+
 func testsToRawJobRunResult(jrr *v1.RawJobRunResult, tests map[string]*models.ProwJobRunTest) {
 	for name, test := range tests {
 		switch v1.TestStatus(test.Status) {
 		case v1.TestStatusSuccess, v1.TestStatusFlake: // success, flake(failed one or more times but ultimately succeeded)
+			if v1.TestStatus(test.Status) == v1.TestStatusFlake && !testidentification.IsOverallTest(name) {
+				jrr.TestFlakes++
+			}
 			switch {
 			case testidentification.IsOverallTest(name):
 				jrr.Succeeded = true
@@ -51,10 +78,11 @@ func testsToRawJobRunResult(jrr *v1.RawJobRunResult, tests map[string]*models.Pr
 					jrr.OpenShiftTestsStatus = testidentification.Success
 				}
 			}
-		case v1.TestStatusFailure:
+		case v1.TestStatusFailure, v1.TestStatusTimeout:
 			// only add the failing test and name if it has predictive value.  We excluded all the non-predictive ones above except for these
-			// which we use to set various JobRunResult markers
-			if !testidentification.IsOverallTest(name) {
+			// which we use to set various JobRunResult markers. A timeout still fails the markers below, but is not
+			// counted as a test failure.
+			if !testidentification.IsOverallTest(name) && v1.TestStatus(test.Status) == v1.TestStatusFailure {
 				jrr.FailedTestNames = append(jrr.FailedTestNames, name)
 				jrr.TestFailures++
 			}
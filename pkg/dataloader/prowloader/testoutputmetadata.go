@@ -15,6 +15,10 @@ var (
 	pathologicalEventsRE = regexp.MustCompile(`reason\/(?P<reason>[a-zA-Z0-9]+)`)
 
 	watchRequestsRE = regexp.MustCompile(`Operator \\"(?P<operator>[a-zA-Z0-9-]+)\\" produces more watch requests than expected`)
+
+	// operatorConditionsRE matches the conditions reported when a cluster operator is unhealthy at the end of an
+	// install, i.e. "Operator degraded (NodeInstaller_InstallerPodFailed): ..."
+	operatorConditionsRE = regexp.MustCompile(`Operator (?P<condition>degraded|unavailable|progressing) \((?P<reason>[^)]*)\)`)
 )
 
 func GetTestOutputMetadataExtractors() map[string]TestOutputMetadataExtractorFunc {
@@ -27,6 +31,8 @@ func GetTestOutputMetadataExtractors() map[string]TestOutputMetadataExtractorFun
 
 var pathologicalTestNameMatch = regexp.MustCompile(`events should not repeat pathologically`)
 
+var operatorConditionsTestNameMatch = regexp.MustCompile(`operator conditions [a-z0-9-]+$`)
+
 // testNameToMetadataExtractor takes a test name and returns an TestOutputMetadataExtractorFunc
 // via looking for the testname in the map or using a regex to match on the testName.
 func testNameToMetadataExtractor(testName string) (TestOutputMetadataExtractorFunc, error) {
@@ -38,6 +44,9 @@ func testNameToMetadataExtractor(testName string) (TestOutputMetadataExtractorFu
 	if pathologicalTestNameMatch.MatchString(testName) {
 		return pathologicalEventsMetadataExtractor, nil
 	}
+	if operatorConditionsTestNameMatch.MatchString(testName) {
+		return operatorConditionsMetadataExtractor, nil
+	}
 	return nil, fmt.Errorf("extractor function not found for %s", testName)
 }
 
@@ -59,6 +68,10 @@ func pathologicalEventsMetadataExtractor(testOutput string) []map[string]string
 	)
 }
 
+func operatorConditionsMetadataExtractor(testOutput string) []map[string]string {
+	return scanTestOutput(operatorConditionsRE, []string{}, testOutput)
+}
+
 type TestFailureMetadataExtractor struct {
 }
 
@@ -266,6 +266,28 @@ Ginkgo exit error 1: exit with code 1}`,
 				},
 			},
 		},
+		{
+			name:     "operator conditions",
+			testName: "operator conditions kube-apiserver",
+			testOutput: `Operator degraded (NodeInstaller_InstallerPodFailed): NodeInstallerDegraded: 1 nodes are failing on revision 7
+Operator unavailable (StaticPods_ZeroNodesActive): StaticPodsAvailable: 0 nodes are active; 3 nodes are at revision 0`,
+			expectedTags: []map[string]string{
+				{
+					"condition": "degraded",
+					"reason":    "NodeInstaller_InstallerPodFailed",
+				},
+				{
+					"condition": "unavailable",
+					"reason":    "StaticPods_ZeroNodesActive",
+				},
+			},
+		},
+		{
+			name:         "operator conditions without a reason we recognize",
+			testName:     "Operator results.operator conditions etcd",
+			testOutput:   `Operator is not reporting conditions`,
+			expectedTags: []map[string]string{},
+		},
 	}
 
 	for _, tc := range tests {
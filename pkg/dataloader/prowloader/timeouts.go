@@ -0,0 +1,73 @@
+package prowloader
+
+import (
+	"regexp"
+	"strings"
+
+	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// buildLogName is the ci-operator log at the root of every job run's artifacts.
+const buildLogName = "build-log.txt"
+
+// suiteTimeoutMarkers match the junit output of a test that failed because the suite running it hit its
+// deadline, rather than on an assertion of its own.
+var suiteTimeoutMarkers = []*regexp.Regexp{
+	// ginkgo v2, for specs still running when the suite timeout fires
+	regexp.MustCompile(`Interrupted by Timeout`),
+	// go test -timeout
+	regexp.MustCompile(`(?m)^panic: test timed out after \S+`),
+	// ci-operator, for a step that ran past its timeout
+	regexp.MustCompile(`Process did not finish before \S+ timeout`),
+}
+
+// buildLogTimeoutMarkers match the ci-operator build log of a job run where a step was killed at its timeout.
+var buildLogTimeoutMarkers = []*regexp.Regexp{
+	regexp.MustCompile(`Process did not finish before \S+ timeout`),
+	regexp.MustCompile(`Job execution exceeded the timeout`),
+}
+
+// buildLogTimedOut returns whether the build log shows a step of the job run hit its timeout.
+func buildLogTimedOut(buildLog []byte) bool {
+	for _, marker := range buildLogTimeoutMarkers {
+		if marker.Match(buildLog) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuiteTimeout returns whether a failed test's output shows it failed only because its suite timed out. When
+// the build log showed a step timed out, failures junit recorded with no output at all are assumed to be tests
+// interrupted by it.
+func isSuiteTimeout(output *models.ProwJobRunTestOutput, stepTimedOut bool) bool {
+	if output == nil {
+		return false
+	}
+	if stepTimedOut && strings.TrimSpace(output.Message) == "" && strings.TrimSpace(output.Output) == "" {
+		return true
+	}
+	for _, marker := range suiteTimeoutMarkers {
+		if marker.MatchString(output.Message) || marker.MatchString(output.Output) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeTestStatus combines the results of a test reported more than once in a job run. A pass and a failure make
+// a flake, while a timeout gives way to any other result: a test that passed or genuinely failed elsewhere in the
+// run tells us more than one that was cut short.
+func mergeTestStatus(existing, status sippyprocessingv1.TestStatus) sippyprocessingv1.TestStatus {
+	switch {
+	case existing == sippyprocessingv1.TestStatusTimeout:
+		return status
+	case status == sippyprocessingv1.TestStatusTimeout:
+		return existing
+	case existing == sippyprocessingv1.TestStatusFailure && status == sippyprocessingv1.TestStatusSuccess,
+		existing == sippyprocessingv1.TestStatusSuccess && status == sippyprocessingv1.TestStatusFailure:
+		return sippyprocessingv1.TestStatusFlake
+	}
+	return existing
+}
@@ -0,0 +1,131 @@
+package prowloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/apis/junit"
+	sippyprocessingv1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestIsSuiteTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       *models.ProwJobRunTestOutput
+		stepTimedOut bool
+		want         bool
+	}{
+		{
+			name: "assertion",
+			output: &models.ProwJobRunTestOutput{
+				Message: "fail [github.com/openshift/origin/test/extended/router/router.go:52]: Expected 200, got 503",
+			},
+		},
+		{
+			name: "ginkgo interrupted by timeout",
+			output: &models.ProwJobRunTestOutput{
+				Output: "[INTERRUPTED] Interrupted by Timeout\nIn [It] at: test/e2e/storage/volumes.go:88",
+			},
+			want: true,
+		},
+		{
+			name: "go test timeout",
+			output: &models.ProwJobRunTestOutput{
+				Output: "=== RUN   TestUpgrade\npanic: test timed out after 2h0m0s\nrunning tests:\n\tTestUpgrade (2h0m0s)",
+			},
+			want: true,
+		},
+		{
+			name: "ci-operator step timeout",
+			output: &models.ProwJobRunTestOutput{
+				Message: `step e2e-aws failed: "e2e-aws" pod "e2e-aws-openshift-e2e-test" failed: Process did not finish before 4h0m0s timeout`,
+			},
+			want: true,
+		},
+		{
+			name:   "empty output without a step timeout",
+			output: &models.ProwJobRunTestOutput{},
+		},
+		{
+			name:         "empty output after a step timeout",
+			output:       &models.ProwJobRunTestOutput{Output: "\n"},
+			stepTimedOut: true,
+			want:         true,
+		},
+		{
+			name:         "assertion after a step timeout",
+			output:       &models.ProwJobRunTestOutput{Message: "Expected 200, got 503"},
+			stepTimedOut: true,
+		},
+		{
+			name:         "success",
+			stepTimedOut: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSuiteTimeout(tt.output, tt.stepTimedOut))
+		})
+	}
+}
+
+func TestBuildLogTimedOut(t *testing.T) {
+	assert.True(t, buildLogTimedOut([]byte(`INFO[2024-03-20T12:00:00Z] Running step e2e-aws-openshift-e2e-test.
+ERRO[2024-03-20T16:00:00Z] Step e2e-aws-openshift-e2e-test failed after 4h0m0s.
+error: some steps failed: Process did not finish before 4h0m0s timeout`)))
+	assert.False(t, buildLogTimedOut([]byte(`ERRO[2024-03-20T13:00:00Z] Step e2e-aws-openshift-e2e-test failed after 1h0m0s.`)))
+	assert.False(t, buildLogTimedOut(nil))
+}
+
+func TestMergeTestStatus(t *testing.T) {
+	const (
+		success = sippyprocessingv1.TestStatusSuccess
+		failure = sippyprocessingv1.TestStatusFailure
+		flake   = sippyprocessingv1.TestStatusFlake
+		timeout = sippyprocessingv1.TestStatusTimeout
+	)
+	tests := []struct {
+		existing, status, want sippyprocessingv1.TestStatus
+	}{
+		{existing: success, status: success, want: success},
+		{existing: failure, status: failure, want: failure},
+		{existing: failure, status: success, want: flake},
+		{existing: success, status: failure, want: flake},
+		{existing: flake, status: failure, want: flake},
+		{existing: timeout, status: timeout, want: timeout},
+		{existing: timeout, status: success, want: success},
+		{existing: success, status: timeout, want: success},
+		{existing: timeout, status: failure, want: failure},
+		{existing: failure, status: timeout, want: failure},
+		{existing: flake, status: timeout, want: flake},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, mergeTestStatus(tt.existing, tt.status), "merging %d into %d", tt.status, tt.existing)
+	}
+}
+
+func TestExtractTestCasesTimeouts(t *testing.T) {
+	pl := &ProwLoader{prowJobRunTestCache: map[string]uint{"a": 1, "b": 2, "c": 3}}
+	suiteID := uint(1)
+	suite := &junit.TestSuite{
+		Name: "openshift-tests",
+		TestCases: []*junit.TestCase{
+			{Name: "a", FailureOutput: &junit.FailureOutput{Output: "Interrupted by Timeout"}},
+			{Name: "b", FailureOutput: &junit.FailureOutput{Output: "Interrupted by Timeout"}},
+			{Name: "b"},
+			{Name: "c", FailureOutput: &junit.FailureOutput{Output: "Interrupted by Timeout"}},
+			{Name: "c", FailureOutput: &junit.FailureOutput{Message: "Expected 200, got 503"}},
+		},
+	}
+
+	testCases := map[string]*models.ProwJobRunTest{}
+	pl.extractTestCases(suite, &suiteID, testCases, false)
+
+	assert.Equal(t, int(sippyprocessingv1.TestStatusTimeout), testCases["openshift-tests.a"].Status)
+	assert.Equal(t, int(sippyprocessingv1.TestStatusSuccess), testCases["openshift-tests.b"].Status)
+	assert.Nil(t, testCases["openshift-tests.b"].ProwJobRunTestOutput)
+	assert.Equal(t, int(sippyprocessingv1.TestStatusFailure), testCases["openshift-tests.c"].Status)
+	assert.Equal(t, "Expected 200, got 503", testCases["openshift-tests.c"].ProwJobRunTestOutput.Message)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/db"
@@ -73,6 +74,9 @@ func (r *ReleaseLoader) Load() {
 						log.Warningf("Phase change detected (%q to %q) -- updating tag %s...", mReleaseTag.Phase, tag.Phase, tag.Name)
 						mReleaseTag.Phase = tag.Phase
 						mReleaseTag.Forced = true
+						if tag.Phase != api.PayloadRejected {
+							mReleaseTag.RejectedBy = nil
+						}
 						if err := r.db.DB.Clauses(clause.OnConflict{UpdateAll: true}).Table(releaseTagsTable).Save(mReleaseTag).Error; err != nil {
 							log.WithError(err).Errorf("error updating release tag")
 							r.errors = append(r.errors, errors.Wrapf(err, "error updating release tag %s for new phase: %s -> %s", tag.Name, mReleaseTag.Phase, tag.Phase))
@@ -215,6 +219,7 @@ func releaseDetailsToDB(architecture string, tag ReleaseTag, details ReleaseDeta
 		release.PreviousReleaseTag = jsonChangeLog.PreviousReleaseTag
 		release.Repositories = jsonChangeLog.Repositories
 		release.PullRequests = jsonChangeLog.PullRequests
+		release.Components = jsonChangeLog.Components
 
 	} else {
 		changelog := NewChangelog(tag.Name, string(details.ChangeLog))
@@ -242,17 +247,41 @@ func releaseDetailsToDB(architecture string, tag ReleaseTag, details ReleaseDeta
 		release.Forced = failedBlocking
 	} else if release.Phase == "Rejected" {
 		release.Forced = !failedBlocking
+		release.RejectedBy = failedBlockingJobs(release.JobRuns)
 	}
 
 	return &release
 }
 
+// failedBlockingJobs returns the verification names of the failed blocking jobs, the reasons the release
+// controller rejected a payload.
+func failedBlockingJobs(jobRuns []models.ReleaseJobRun) []string {
+	names := sets.NewString()
+	for _, jRun := range jobRuns {
+		if jRun.Kind == "Blocking" && jRun.State == failed {
+			names.Insert(jRun.JobName)
+		}
+	}
+	if names.Len() == 0 {
+		return nil
+	}
+	return names.List()
+}
+
 func parseChangeLogJSON(releaseTag string, changeLogJSON ChangeLog) models.ReleaseTag {
 	releaseChangeLogJSON := models.ReleaseTag{}
 
 	releaseChangeLogJSON.PreviousReleaseTag = changeLogJSON.From.Name
 
 	for _, c := range changeLogJSON.Components {
+		releaseChangeLogJSON.Components = append(releaseChangeLogJSON.Components, models.ReleaseComponent{
+			Name:               c.Name,
+			Version:            c.Version,
+			VersionURL:         c.VersionURL,
+			PreviousVersion:    c.From,
+			PreviousVersionURL: c.FromURL,
+			DiffURL:            c.DiffURL,
+		})
 		if c.Name == "Kubernetes" {
 			releaseChangeLogJSON.KubernetesVersion = c.Version
 		} else if strings.Contains(c.Name, "CoreOS") {
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/openshift/sippy/pkg/db/models"
 )
 
@@ -19,6 +21,7 @@ func TestReleaseTagForcedFlag(t *testing.T) {
 		releaseTagDownloadURL string
 		architecture          string
 		wantForced            bool
+		wantRejectedBy        []string
 	}{
 		{
 
@@ -30,6 +33,7 @@ func TestReleaseTagForcedFlag(t *testing.T) {
 			releaseTagDownloadURL: "https://openshift-release-artifacts.apps.ci.l2s4.p1.openshiftapps.com/4.7.0-0.ci-2022-06-24-181413",
 			architecture:          "amd64",
 			wantForced:            false,
+			wantRejectedBy:        []string{"gcp"},
 		},
 		{
 
@@ -80,6 +84,7 @@ func TestReleaseTagForcedFlag(t *testing.T) {
 			if mReleaseTag.Forced != tt.wantForced {
 				t.Errorf("Invalid forced flag for %s", tt.name)
 			}
+			assert.Equal(t, tt.wantRejectedBy, []string(mReleaseTag.RejectedBy))
 
 		})
 	}
@@ -227,6 +232,10 @@ func TestChangeLog(t *testing.T) {
 		t.Fatalf("ReleaseChangeLog PreviousReleaseTag versions don't match.  ChangeLog: %s, ChangeLogJson: %s", releaseChangeLog.PreviousReleaseTag, releaseChangeLogJSON.PreviousReleaseTag)
 	}
 
+	if len(releaseChangeLogJSON.Components) != len(releaseDetails.ChangeLogJSON.Components) {
+		t.Fatalf("ReleaseChangeLog Components don't match.  ChangeLogJson: %v, Components: %v", releaseDetails.ChangeLogJSON.Components, releaseChangeLogJSON.Components)
+	}
+
 	if len(releaseChangeLogJSON.Repositories) != len(releaseChangeLog.Repositories) {
 		t.Fatalf("ReleaseChangeLog Repositories versions don't match.  ChangeLog: %v, ChangeLogJson: %v", releaseChangeLog.Repositories, releaseChangeLogJSON.Repositories)
 	}
@@ -0,0 +1,123 @@
+// Package stepregistryloader imports which steps compose each job's multi-stage test, from metadata generated from
+// the OpenShift CI step registry.
+package stepregistryloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// Metadata lists the steps of each job's multi-stage test, as resolved from its workflow and chains:
+//
+//	{"jobs": [{"name": "periodic-ci-...-e2e-aws-ovn", "steps": [{"name": "ipi-install-install", "phase": "pre"}]}]}
+type Metadata struct {
+	Jobs []JobMetadata `json:"jobs"`
+}
+
+type JobMetadata struct {
+	Name  string         `json:"name"`
+	Steps []StepMetadata `json:"steps"`
+}
+
+type StepMetadata struct {
+	Name string `json:"name"`
+	// Phase is pre, test or post.
+	Phase string `json:"phase"`
+}
+
+// StepRegistryLoader replaces the job steps with those in the metadata read from a URL or file.
+type StepRegistryLoader struct {
+	dbc    *db.DB
+	source string
+	errors []error
+}
+
+func New(dbc *db.DB, source string) *StepRegistryLoader {
+	return &StepRegistryLoader{dbc: dbc, source: source}
+}
+
+func (l *StepRegistryLoader) Name() string {
+	return "step-registry"
+}
+
+func (l *StepRegistryLoader) Errors() []error {
+	return l.errors
+}
+
+func (l *StepRegistryLoader) Load() {
+	content, err := l.read()
+	if err != nil {
+		l.errors = append(l.errors, fmt.Errorf("error reading step registry metadata: %w", err))
+		return
+	}
+	steps, err := parseMetadata(content)
+	if err != nil {
+		l.errors = append(l.errors, fmt.Errorf("error parsing step registry metadata: %w", err))
+		return
+	}
+
+	err = l.dbc.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.JobStep{}).Error; err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(steps, l.dbc.BatchSize).Error
+	})
+	if err != nil {
+		l.errors = append(l.errors, fmt.Errorf("error saving job steps: %w", err))
+		return
+	}
+	log.WithField("steps", len(steps)).Info("loaded step registry metadata")
+}
+
+func (l *StepRegistryLoader) read() ([]byte, error) {
+	if !strings.HasPrefix(l.source, "http://") && !strings.HasPrefix(l.source, "https://") {
+		return os.ReadFile(l.source)
+	}
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Get(l.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", l.source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseMetadata returns the steps of every job in the metadata, numbered in the order they're listed.
+func parseMetadata(content []byte) ([]models.JobStep, error) {
+	var metadata Metadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, err
+	}
+	steps := []models.JobStep{}
+	for _, job := range metadata.Jobs {
+		if job.Name == "" {
+			return nil, fmt.Errorf("job without a name")
+		}
+		for i, step := range job.Steps {
+			switch step.Phase {
+			case "pre", "test", "post":
+			default:
+				return nil, fmt.Errorf("step %s of job %s has unknown phase %q", step.Name, job.Name, step.Phase)
+			}
+			steps = append(steps, models.JobStep{JobName: job.Name, Step: step.Name, Phase: step.Phase, Position: i})
+		}
+	}
+	return steps, nil
+}
@@ -0,0 +1,34 @@
+package stepregistryloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestParseMetadata(t *testing.T) {
+	steps, err := parseMetadata([]byte(`{"jobs": [
+		{"name": "periodic-e2e-aws-ovn", "steps": [
+			{"name": "ipi-install-install", "phase": "pre"},
+			{"name": "openshift-e2e-test", "phase": "test"},
+			{"name": "ipi-deprovision-deprovision", "phase": "post"}
+		]},
+		{"name": "periodic-e2e-gcp", "steps": [{"name": "ipi-install-install", "phase": "pre"}]}
+	]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []models.JobStep{
+		{JobName: "periodic-e2e-aws-ovn", Step: "ipi-install-install", Phase: "pre", Position: 0},
+		{JobName: "periodic-e2e-aws-ovn", Step: "openshift-e2e-test", Phase: "test", Position: 1},
+		{JobName: "periodic-e2e-aws-ovn", Step: "ipi-deprovision-deprovision", Phase: "post", Position: 2},
+		{JobName: "periodic-e2e-gcp", Step: "ipi-install-install", Phase: "pre", Position: 0},
+	}, steps)
+
+	_, err = parseMetadata([]byte(`{"jobs": [{"name": "periodic-e2e-aws", "steps": [{"name": "install", "phase": "setup"}]}]}`))
+	assert.ErrorContains(t, err, "unknown phase")
+
+	_, err = parseMetadata([]byte(`{"jobs": [{"steps": []}]}`))
+	assert.ErrorContains(t, err, "without a name")
+}
@@ -2,9 +2,10 @@ package variantsyncer
 
 import (
 	"context"
-	"reflect"
+	"sort"
 	"strings"
 
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 
 	bqcached "github.com/openshift/sippy/pkg/bigquery"
@@ -19,16 +20,29 @@ type VariantSyncer struct {
 	errors []error
 }
 
+// VariantChange is a job whose stored variants differ from those the variant manager now identifies.
+type VariantChange struct {
+	Job      string   `json:"job"`
+	Release  string   `json:"release"`
+	Original []string `json:"original"`
+	Updated  []string `json:"updated"`
+}
+
 func New(dbc *db.DB, bqc *bqcached.Client) (*VariantSyncer, error) {
 	mgr, err := testidentification.NewOpenshiftVariantManager(context.TODO(), bqc)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewWithManager(dbc, mgr), nil
+}
+
+// NewWithManager returns a syncer using the given variant manager, such as the one for the server's mode.
+func NewWithManager(dbc *db.DB, mgr testidentification.VariantManager) *VariantSyncer {
 	return &VariantSyncer{
 		dbc: dbc,
 		mgr: mgr,
-	}, nil
+	}
 }
 
 func (vl *VariantSyncer) Name() string {
@@ -40,22 +54,67 @@ func (vl *VariantSyncer) Errors() []error {
 }
 
 func (vl *VariantSyncer) Load() {
-	allJobs := loadAllProwJobs(vl.dbc)
-	for _, j := range allJobs {
+	changes := vl.Diff()
+	if err := vl.Apply(context.TODO(), changes); err != nil {
+		vl.errors = append(vl.errors, err)
+	}
+}
+
+// Diff returns the jobs whose variants would change, without updating them.
+func (vl *VariantSyncer) Diff() []VariantChange {
+	return variantChanges(loadAllProwJobs(vl.dbc), vl.mgr)
+}
+
+// Apply stores the updated variants of each changed job.
+func (vl *VariantSyncer) Apply(ctx context.Context, changes []VariantChange) error {
+	for _, c := range changes {
+		log.WithFields(log.Fields{
+			"job":      c.Job,
+			"original": strings.Join(c.Original, ", "),
+			"updated":  strings.Join(c.Updated, ", "),
+		}).Debugf("mismatched; updating database")
+		res := vl.dbc.DB.WithContext(ctx).Model(&models.ProwJob{}).
+			Where("name = ?", c.Job).
+			Update("variants", pq.StringArray(c.Updated))
+		if res.Error != nil {
+			return res.Error
+		}
+	}
+	log.Infof("updated variants for %d jobs", len(changes))
+	return nil
+}
+
+func variantChanges(jobs map[string]*models.ProwJob, mgr testidentification.VariantManager) []VariantChange {
+	changes := []VariantChange{}
+	for _, j := range jobs {
 		log.Debugf("syncing variants for %s", j.Name)
-		newVariants := vl.mgr.IdentifyVariants(j.Name)
-		if !reflect.DeepEqual(newVariants, []string(j.Variants)) {
-			log.WithFields(log.Fields{
-				"job":      j.Name,
-				"original": strings.Join(j.Variants, ", "),
-				"updated":  strings.Join(newVariants, ", "),
-			}).Debugf("mismatched; updating database")
-			j.Variants = newVariants
-			if res := vl.dbc.DB.WithContext(context.TODO()).Save(j); res.Error != nil {
-				vl.errors = append(vl.errors, res.Error)
-			}
+		newVariants := mgr.IdentifyVariants(j.Name)
+		if !sameVariants(j.Variants, newVariants) {
+			changes = append(changes, VariantChange{
+				Job:      j.Name,
+				Release:  j.Release,
+				Original: j.Variants,
+				Updated:  newVariants,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Job < changes[j].Job
+	})
+	return changes
+}
+
+// sameVariants compares variants in order, treating a missing list like an empty one.
+func sameVariants(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
 		}
 	}
+	return true
 }
 
 func loadAllProwJobs(dbc *db.DB) map[string]*models.ProwJob {
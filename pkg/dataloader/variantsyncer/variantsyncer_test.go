@@ -0,0 +1,49 @@
+package variantsyncer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/testidentification"
+	"github.com/openshift/sippy/pkg/util/sets"
+)
+
+// fakeVariants identifies the platform and upgrade variants from the job name.
+type fakeVariants struct{}
+
+func (fakeVariants) AllPlatforms() sets.String {
+	return sets.NewString("aws", "gcp")
+}
+
+func (fakeVariants) IdentifyVariants(jobName string) []string {
+	variants := []string{}
+	for _, v := range []string{"aws", "gcp", "upgrade"} {
+		if strings.Contains(jobName, v) {
+			variants = append(variants, v)
+		}
+	}
+	return variants
+}
+
+func (fakeVariants) IsJobNeverStable(string) bool {
+	return false
+}
+
+var _ testidentification.VariantManager = fakeVariants{}
+
+func TestVariantChanges(t *testing.T) {
+	jobs := map[string]*models.ProwJob{
+		"periodic-aws-upgrade": {Name: "periodic-aws-upgrade", Release: "4.16", Variants: []string{"aws", "upgrade"}},
+		"periodic-gcp-upgrade": {Name: "periodic-gcp-upgrade", Release: "4.16", Variants: []string{"gcp"}},
+		"periodic-aws":         {Name: "periodic-aws", Release: "4.15", Variants: []string{"gcp"}},
+		"periodic-metal":       {Name: "periodic-metal", Release: "4.16"},
+	}
+
+	assert.Equal(t, []VariantChange{
+		{Job: "periodic-aws", Release: "4.15", Original: []string{"gcp"}, Updated: []string{"aws"}},
+		{Job: "periodic-gcp-upgrade", Release: "4.16", Original: []string{"gcp"}, Updated: []string{"gcp", "upgrade"}},
+	}, variantChanges(jobs, fakeVariants{}))
+}
@@ -21,6 +21,7 @@ const (
 	hashTypeView         SchemaHashType = "view"
 	hashTypeMatViewIndex SchemaHashType = "matview_index"
 	hashTypeFunction     SchemaHashType = "function"
+	hashTypeIndex        SchemaHashType = "index"
 )
 
 type DB struct {
@@ -29,6 +30,20 @@ type DB struct {
 	// BatchSize is used for how many insertions we should do at once. Postgres supports
 	// a maximum of 2^16 records per insert.
 	BatchSize int
+
+	// PartitionRetention is how long data is kept in partitioned tables before its partitions are dropped, zero
+	// keeps it forever.
+	PartitionRetention time.Duration
+
+	// SlowQueries tracks queries slower than the configured threshold, nil when disabled.
+	SlowQueries *SlowQueryTracker
+
+	// DiskBudget is how many bytes the database is expected to fit in, warned about when its growth is projected
+	// to exceed it, zero for no budget.
+	DiskBudget int64
+
+	// Schema is the postgres schema sippy's objects live in, empty for public.
+	Schema string
 }
 
 // log2LogrusWriter bridges gorm logging to logrus logging.
@@ -42,6 +57,10 @@ func (w log2LogrusWriter) Printf(msg string, args ...interface{}) {
 }
 
 func New(dsn string, logLevel gormlogger.LogLevel) (*DB, error) {
+	return open(postgres.Open(dsn), logLevel)
+}
+
+func open(dialector gorm.Dialector, logLevel gormlogger.LogLevel) (*DB, error) {
 	gormLogger := gormlogger.New(
 		log2LogrusWriter{entry: log.WithField("source", "gorm")},
 		gormlogger.Config{
@@ -52,7 +71,7 @@ func New(dsn string, logLevel gormlogger.LogLevel) (*DB, error) {
 		},
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: gormLogger,
 	})
 	if err != nil {
@@ -64,6 +83,15 @@ func New(dsn string, logLevel gormlogger.LogLevel) (*DB, error) {
 	}, nil
 }
 
+// Close closes the connection pool, for clients opened for a single run in a long-running process.
+func (d *DB) Close() error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 
 	if err := d.DB.AutoMigrate(&models.ReleaseTag{}); err != nil {
@@ -78,6 +106,10 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.ReleaseComponent{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.ReleaseJobRun{}); err != nil {
 		return err
 	}
@@ -86,6 +118,10 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.JobLineageOverride{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.ProwJobRun{}); err != nil {
 		return err
 	}
@@ -94,6 +130,10 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.TestNameAlias{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.Suite{}); err != nil {
 		return err
 	}
@@ -106,6 +146,50 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.ProwJobRunDebugArtifact{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.ProwJobRunDisruption{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.ProwJobRunFingerprint{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.ProwJobRunStep{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.TestSuppression{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.Acknowledgement{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.APIToken{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.FeatureFlagRollout{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.SavedView{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.Watchlist{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.TableSizeSnapshot{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.ProwJobRunTestOutputMetadata{}); err != nil {
 		return err
 	}
@@ -126,6 +210,26 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.MatViewRefresh{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.AlertResult{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.DisruptionRegressionState{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.DisruptionPercentile{}); err != nil {
+		return err
+	}
+
+	if err := d.DB.AutoMigrate(&models.LoadEvent{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.PullRequestComment{}); err != nil {
 		return err
 	}
@@ -138,14 +242,48 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := d.DB.AutoMigrate(&models.JobStep{}); err != nil {
+		return err
+	}
+
 	if err := d.DB.AutoMigrate(&models.TestOwnership{}); err != nil {
 		return err
 	}
 
+	// AutoMigrate above owns tables and columns, the sync functions below own views, functions and indexes, and
+	// versioned migrations own everything else. See migrations.go.
+	if err := d.MigrateUp(); err != nil {
+		return err
+	}
+
+	if err := d.partitionTables(time.Now()); err != nil {
+		return err
+	}
+
+	if err := d.SyncPartitions(time.Now()); err != nil {
+		return err
+	}
+
 	if err := populateTestSuitesInDB(d.DB); err != nil {
 		return err
 	}
 
+	if err := populateTestHashes(d.DB); err != nil {
+		return err
+	}
+
+	if err := populateTestSigs(d.DB); err != nil {
+		return err
+	}
+
+	if err := populateJobLineage(d.DB); err != nil {
+		return err
+	}
+
+	if err := syncPostgresIndexes(d.DB); err != nil {
+		return err
+	}
+
 	if err := syncPostgresMaterializedViews(d.DB, reportEnd); err != nil {
 		return err
 	}
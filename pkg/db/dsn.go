@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// ConnectionOptions adjust the DSN used to connect to postgres, so TLS and credentials can be configured without
+// embedding them in it.
+type ConnectionOptions struct {
+	// SSLMode, SSLRootCert, SSLCert and SSLKey override the DSN's sslmode, sslrootcert (the CA bundle the server's
+	// certificate is verified with), sslcert and sslkey settings when not empty.
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+	// Password, when set, provides the password for each new connection in place of any in the DSN, such as a
+	// short-lived IAM token.
+	Password PasswordSource
+	// Schema, when set, is the schema sippy's tables, views and functions live in instead of public, so several
+	// sippy environments can share one database. public stays on the search path for extensions such as pg_trgm.
+	Schema string
+}
+
+// schemaRegexp restricts schema names to unquoted lowercase identifiers, the name goes into the search path and
+// DDL as is.
+var schemaRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// PasswordSource returns the password to open a new connection with, given the connection's DSN settings.
+type PasswordSource func(ctx context.Context, settings map[string]string) (string, error)
+
+// NewWithOptions connects to postgres like New, applying the connection options to the DSN. The schema, if one is
+// set, is created when missing.
+func NewWithOptions(dsn string, opts ConnectionOptions, logLevel gormlogger.LogLevel) (*DB, error) {
+	if opts.SSLMode == "" && opts.SSLRootCert == "" && opts.SSLCert == "" && opts.SSLKey == "" && opts.Password == nil &&
+		opts.Schema == "" {
+		return New(dsn, logLevel)
+	}
+
+	settings, err := connectionSettings(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbc *DB
+	if opts.Password == nil {
+		dbc, err = New(formatDSN(settings), logLevel)
+	} else {
+		// the pgx driver is registered by the gorm postgres driver
+		pgx, openErr := sql.Open("pgx", "")
+		if openErr != nil {
+			return nil, openErr
+		}
+		connector := &passwordConnector{driver: pgx.Driver(), settings: settings, password: opts.Password}
+		_ = pgx.Close()
+		dbc, err = open(postgres.New(postgres.Config{Conn: sql.OpenDB(connector)}), logLevel)
+	}
+	if err != nil || opts.Schema == "" {
+		return dbc, err
+	}
+
+	dbc.Schema = opts.Schema
+	if err := dbc.createSchema(); err != nil {
+		return nil, fmt.Errorf("could not create database schema %s: %w", opts.Schema, err)
+	}
+	return dbc, nil
+}
+
+// connectionSettings parses the DSN and applies the connection options to its settings.
+func connectionSettings(dsn string, opts ConnectionOptions) (map[string]string, error) {
+	settings, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range map[string]string{
+		"sslmode":     opts.SSLMode,
+		"sslrootcert": opts.SSLRootCert,
+		"sslcert":     opts.SSLCert,
+		"sslkey":      opts.SSLKey,
+	} {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	if opts.Schema != "" {
+		if !schemaRegexp.MatchString(opts.Schema) {
+			return nil, fmt.Errorf("invalid database schema %q, must be a lowercase identifier", opts.Schema)
+		}
+		settings["search_path"] = opts.Schema + ",public"
+	}
+	return settings, nil
+}
+
+// createSchema creates the configured schema if it doesn't exist yet. Until it does, postgres creates and finds
+// tables in public, the next schema on the search path.
+func (d *DB) createSchema() error {
+	var exists bool
+	if res := d.DB.Raw("SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = ?)", d.Schema).Scan(&exists); res.Error != nil {
+		return res.Error
+	}
+	if exists {
+		return nil
+	}
+	log.Infof("creating database schema %s", d.Schema)
+	return d.DB.Exec("CREATE SCHEMA IF NOT EXISTS " + d.Schema).Error
+}
+
+// passwordConnector opens each connection with a password fetched for it, so expiring tokens are refreshed as
+// the pool opens new connections.
+type passwordConnector struct {
+	driver   driver.Driver
+	settings map[string]string
+	password PasswordSource
+}
+
+func (c *passwordConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.password(ctx, c.settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not get database password: %w", err)
+	}
+	settings := make(map[string]string, len(c.settings)+1)
+	for k, v := range c.settings {
+		settings[k] = v
+	}
+	settings["password"] = password
+
+	dsn := formatDSN(settings)
+	if dc, ok := c.driver.(driver.DriverContext); ok {
+		connector, err := dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return connector.Connect(ctx)
+	}
+	return c.driver.Open(dsn)
+}
+
+func (c *passwordConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// parseDSN splits a postgres connection string, either a postgres:// URL or space separated key=value pairs, into
+// its settings.
+func parseDSN(dsn string) (map[string]string, error) {
+	settings := map[string]string{}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid database DSN: %w", err)
+		}
+		if u.User != nil {
+			settings["user"] = u.User.Username()
+			if password, ok := u.User.Password(); ok {
+				settings["password"] = password
+			}
+		}
+		if u.Hostname() != "" {
+			settings["host"] = u.Hostname()
+		}
+		if u.Port() != "" {
+			settings["port"] = u.Port()
+		}
+		if dbname := strings.TrimPrefix(u.Path, "/"); dbname != "" {
+			settings["dbname"] = dbname
+		}
+		for key, values := range u.Query() {
+			settings[key] = values[0]
+		}
+		return settings, nil
+	}
+
+	s := strings.TrimSpace(dsn)
+	for len(s) > 0 {
+		eq := strings.IndexRune(s, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid database DSN: missing = after %q", s)
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " \t\n")
+
+		var value strings.Builder
+		quoted := strings.HasPrefix(s, "'")
+		if quoted {
+			s = s[1:]
+		}
+		i := 0
+		for ; i < len(s); i++ {
+			ch := s[i]
+			if ch == '\\' && i+1 < len(s) {
+				i++
+				value.WriteByte(s[i])
+				continue
+			}
+			if (quoted && ch == '\'') || (!quoted && (ch == ' ' || ch == '\t' || ch == '\n')) {
+				break
+			}
+			value.WriteByte(ch)
+		}
+		if quoted {
+			if i == len(s) {
+				return nil, fmt.Errorf("invalid database DSN: unterminated quoted value for %s", key)
+			}
+			i++
+		}
+		settings[key] = value.String()
+		s = strings.TrimLeft(s[i:], " \t\n")
+	}
+	return settings, nil
+}
+
+// formatDSN writes settings as key=value pairs, in key order with every value quoted.
+func formatDSN(settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"='"+escaper.Replace(settings[k])+"'")
+	}
+	return strings.Join(pairs, " ")
+}
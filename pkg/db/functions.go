@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
 )
 
 type PostgresFunction struct {
@@ -33,7 +35,7 @@ func syncPostgresFunctions(db *gorm.DB) error {
 }
 
 const testResultFunction = `
-CREATE FUNCTION public.test_results(start timestamp without time zone, boundary timestamp without time zone, endstamp timestamp without time zone) RETURNS TABLE(id bigint, name text, previous_successes bigint, previous_flakes bigint, previous_failures bigint, previous_runs bigint, current_successes bigint, current_flakes bigint, current_failures bigint, current_runs bigint, current_pass_percentage double precision, current_failure_percentage double precision, previous_pass_percentage double precision, previous_failure_percentage double precision, net_improvement double precision, release text)
+CREATE FUNCTION test_results(start timestamp without time zone, boundary timestamp without time zone, endstamp timestamp without time zone) RETURNS TABLE(id bigint, name text, previous_successes bigint, previous_flakes bigint, previous_failures bigint, previous_runs bigint, current_successes bigint, current_flakes bigint, current_failures bigint, current_runs bigint, current_pass_percentage double precision, current_failure_percentage double precision, previous_pass_percentage double precision, previous_failure_percentage double precision, net_improvement double precision, release text)
     LANGUAGE sql
     AS $_$
 WITH results AS (
@@ -52,6 +54,7 @@ FROM prow_job_run_tests
     JOIN tests ON tests.id = prow_job_run_tests.test_id
     JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_tests.prow_job_run_id
     JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id
+WHERE prow_job_run_tests.status <> 14
 GROUP BY tests.id, prow_jobs.release
 )
 SELECT tests.id,
@@ -76,7 +79,7 @@ $_$;
 `
 
 const jobResultFunction = `
-CREATE FUNCTION public.job_results(release text, start timestamp without time zone, boundary timestamp without time zone, endstamp timestamp without time zone) RETURNS TABLE(pj_name text, pj_variants text[], org text, repo text, average_retests_to_merge double precision, previous_passes bigint, previous_failures bigint, previous_runs bigint, previous_infra_fails bigint, current_passes bigint, current_fails bigint, current_runs bigint, current_infra_fails bigint, id bigint, created_at timestamp without time zone, updated_at timestamp without time zone, deleted_at timestamp without time zone, name text, release text, variants text[], test_grid_url text, kind text, brief_name text, current_pass_percentage real, current_projected_pass_percentage real, current_failure_percentage real, previous_pass_percentage real, previous_projected_pass_percentage real, previous_failure_percentage real, net_improvement real, open_bugs int, last_pass timestamp)
+CREATE FUNCTION job_results(release text, start timestamp without time zone, boundary timestamp without time zone, endstamp timestamp without time zone) RETURNS TABLE(pj_name text, pj_variants text[], org text, repo text, average_retests_to_merge double precision, previous_passes bigint, previous_failures bigint, previous_runs bigint, previous_infra_fails bigint, current_passes bigint, current_fails bigint, current_runs bigint, current_infra_fails bigint, id bigint, created_at timestamp without time zone, updated_at timestamp without time zone, deleted_at timestamp without time zone, name text, release text, variants text[], test_grid_url text, kind text, brief_name text, current_pass_percentage real, current_projected_pass_percentage real, current_failure_percentage real, previous_pass_percentage real, previous_projected_pass_percentage real, previous_failure_percentage real, net_improvement real, open_bugs int, last_pass timestamp)
     LANGUAGE sql
     AS $_$
 WITH repo_org_jobs AS (
@@ -94,8 +97,7 @@ merged_prs AS
          INNER JOIN prow_pull_requests on prow_pull_requests.id = prow_job_run_prow_pull_requests.prow_pull_request_id
          INNER JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id
 	WHERE prow_pull_requests.merged_at BETWEEN $2::timestamp AND $4::timestamp
-	AND prow_job_runs.overall_result != 'S'
-	AND prow_job_runs.overall_result != 'A'
+	AND prow_job_runs.overall_result NOT IN ('S', 'L', 'A')
     GROUP BY prow_jobs.id, prow_pull_requests.id, prow_pull_requests.link),
 retests AS
     (SELECT prow_job_id, AVG(total_runs) as average_retests_to_merge FROM merged_prs GROUP BY prow_job_id),
@@ -120,7 +122,7 @@ results AS (
         group by prow_jobs.name, prow_jobs.variants
 ),
 last_pass AS (
-	SELECT prow_job_id, max(timestamp) as last_pass from prow_job_runs where overall_result = 'S' group by prow_job_id
+	SELECT prow_job_id, max(timestamp) as last_pass from prow_job_runs where overall_result IN ('S', 'L') group by prow_job_id
 )
 SELECT pj_name,
        pj_variants,
@@ -144,7 +146,7 @@ SELECT pj_name,
        variants,
        test_grid_url,
        kind,
-       REGEXP_REPLACE(results.pj_name, 'periodic-ci-openshift-(multiarch|release)-master-(ci|nightly)-[0-9]+.[0-9]+-', '') as brief_name,
+       REGEXP_REPLACE(results.pj_name, '` + models.JobBriefNamePattern + `', '') as brief_name,
        current_passes * 100.0 / NULLIF(current_runs, 0) AS current_pass_percentage,
        (current_passes + current_infra_fails) * 100.0 / NULLIF(current_runs, 0) AS current_projected_pass_percentage,
        current_fails * 100.0 / NULLIF(current_runs, 0) AS current_failure_percentage,
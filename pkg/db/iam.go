@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	rdsauth "github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// IAMAuthGCP authenticates to Cloud SQL with OAuth2 access tokens.
+	IAMAuthGCP = "gcp"
+	// IAMAuthAWS authenticates to RDS with IAM authentication tokens.
+	IAMAuthAWS = "aws"
+
+	cloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+)
+
+// GCPIAMPassword returns a PasswordSource of access tokens for Cloud SQL IAM database authentication, from the
+// application default credentials. The DSN's user must be the IAM principal, for a service account its email
+// without the .gserviceaccount.com suffix. Tokens are reused until they are about to expire.
+func GCPIAMPassword(ctx context.Context) (PasswordSource, error) {
+	ts, err := google.DefaultTokenSource(ctx, cloudSQLLoginScope)
+	if err != nil {
+		return nil, fmt.Errorf("could not find google credentials for database IAM authentication: %w", err)
+	}
+	ts = oauth2.ReuseTokenSource(nil, ts)
+	return func(_ context.Context, _ map[string]string) (string, error) {
+		token, err := ts.Token()
+		if err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}, nil
+}
+
+// AWSIAMPassword returns a PasswordSource of RDS IAM authentication tokens for the connection's host, port and user.
+// Tokens are signed with credentials from the AWS SDK's default chain: environment variables, shared config and
+// credentials files, web identity (IRSA) and the container or instance role. Temporary credentials are refreshed
+// before they expire. Tokens are valid for 15 minutes, so a new one is generated for each connection. RDS requires
+// TLS for IAM authentication.
+func AWSIAMPassword(ctx context.Context, region string) (PasswordSource, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS configuration for database IAM authentication: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("an AWS region is required for database IAM authentication")
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, fmt.Errorf("could not find AWS credentials for database IAM authentication: %w", err)
+	}
+	return func(ctx context.Context, settings map[string]string) (string, error) {
+		port := settings["port"]
+		if port == "" {
+			port = "5432"
+		}
+		if settings["host"] == "" || settings["user"] == "" {
+			return "", fmt.Errorf("the database DSN must include the host and user for IAM authentication")
+		}
+		return rdsauth.BuildAuthToken(ctx, settings["host"]+":"+port, cfg.Region, settings["user"], cfg.Credentials)
+	}, nil
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSIAMPassword(t *testing.T) {
+	// keep the SDK's credential chain to the environment
+	missing := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("AWS_CONFIG_FILE", missing)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", missing)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session token")
+
+	password, err := AWSIAMPassword(context.Background(), "us-east-1")
+	require.NoError(t, err)
+
+	token, err := password(context.Background(), map[string]string{
+		"host": "sippy.abc123.us-east-1.rds.amazonaws.com",
+		"user": "sippy",
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "sippy.abc123.us-east-1.rds.amazonaws.com:5432?"), token)
+	assert.Contains(t, token, "Action=connect")
+	assert.Contains(t, token, "DBUser=sippy")
+	assert.Contains(t, token, "X-Amz-Credential=AKIDEXAMPLE%2F")
+	assert.Contains(t, token, "%2Fus-east-1%2Frds-db%2Faws4_request")
+	assert.Contains(t, token, "X-Amz-Expires=900")
+	assert.Contains(t, token, "X-Amz-Security-Token=session%20token")
+	assert.Contains(t, token, "X-Amz-Signature=")
+
+	_, err = password(context.Background(), map[string]string{"user": "sippy"})
+	assert.ErrorContains(t, err, "must include the host and user")
+}
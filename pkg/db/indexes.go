@@ -0,0 +1,36 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PostgresIndex is an index on a regular table which cannot be expressed through gorm struct tags,
+// such as those using a non-default operator class.
+type PostgresIndex struct {
+	Name       string
+	Definition string
+}
+
+var PostgresIndexes = []PostgresIndex{
+	{
+		// Trigram index used for substring and fuzzy test name search.
+		Name:       "idx_tests_name_trgm",
+		Definition: "CREATE INDEX idx_tests_name_trgm ON tests USING gin (name gin_trgm_ops)",
+	},
+}
+
+func syncPostgresIndexes(db *gorm.DB) error {
+	// The extension is database wide, keep it in public where every sippy schema's search path finds it.
+	if res := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public"); res.Error != nil {
+		return res.Error
+	}
+	for _, idx := range PostgresIndexes {
+		dropSQL := fmt.Sprintf("DROP INDEX IF EXISTS %s", idx.Name)
+		if _, err := syncSchema(db, hashTypeIndex, idx.Name, idx.Definition, dropSQL, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// populateJobLineage backfills the lineage for jobs created before the column existed, and applies manual
+// lineage overrides. New jobs get their automatic lineage set on save.
+func populateJobLineage(db *gorm.DB) error {
+	jobs := make([]models.ProwJob, 0)
+	var updated int
+	res := db.Where("lineage IS NULL OR lineage = ''").FindInBatches(&jobs, 1000, func(tx *gorm.DB, batch int) error {
+		for i := range jobs {
+			res := tx.Model(&jobs[i]).UpdateColumn("lineage", models.JobLineageName(jobs[i].Name))
+			if res.Error != nil {
+				return res.Error
+			}
+			updated++
+		}
+		return nil
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if updated > 0 {
+		log.WithField("jobs", updated).Info("populated missing job lineage")
+	}
+
+	return db.Exec(`UPDATE prow_jobs SET lineage = job_lineage_overrides.lineage
+		FROM job_lineage_overrides
+		WHERE prow_jobs.name = job_lineage_overrides.job_name
+		  AND job_lineage_overrides.deleted_at IS NULL
+		  AND prow_jobs.lineage <> job_lineage_overrides.lineage`).Error
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/util"
+)
+
+// StartLoadEvent records the start of a data load.
+func (d *DB) StartLoadEvent(loaders, releases, architectures []string) (*models.LoadEvent, error) {
+	event := &models.LoadEvent{
+		Loaders:       loaders,
+		Releases:      releases,
+		Architectures: architectures,
+		SippyVersion:  util.SippyVersion(),
+		StartedAt:     time.Now(),
+		Status:        models.LoadEventRunning,
+	}
+	if err := d.DB.Create(event).Error; err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// FinishLoadEvent records the outcome of a data load with the job runs and test results its loaders inserted.
+func (d *DB) FinishLoadEvent(event *models.LoadEvent, jobRunsInserted, testResultsInserted int64, errs []error, warnings []string) error {
+	finishLoadEvent(event, time.Now(), jobRunsInserted, testResultsInserted, errs, warnings)
+	return d.DB.Save(event).Error
+}
+
+func finishLoadEvent(event *models.LoadEvent, ended time.Time, jobRunsInserted, testResultsInserted int64, errs []error, warnings []string) {
+	event.EndedAt = &ended
+	event.DurationMillis = ended.Sub(event.StartedAt).Milliseconds()
+	event.JobRunsInserted = jobRunsInserted
+	event.TestResultsInserted = testResultsInserted
+
+	event.Status = models.LoadEventSucceeded
+	event.Errors = make([]string, 0, len(errs))
+	for _, err := range errs {
+		event.Errors = append(event.Errors, err.Error())
+	}
+	if len(errs) > 0 {
+		event.Status = models.LoadEventFailed
+	}
+	event.Warnings = append(make([]string, 0, len(warnings)), warnings...)
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestFinishLoadEvent(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ended := started.Add(90 * time.Second)
+
+	tests := []struct {
+		name             string
+		errs             []error
+		warnings         []string
+		expectedStatus   string
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			name:             "succeeded",
+			expectedStatus:   models.LoadEventSucceeded,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{},
+		},
+		{
+			name:             "succeeded with warnings",
+			warnings:         []string{`loader "verify": 4.16 had 10 job runs`},
+			expectedStatus:   models.LoadEventSucceeded,
+			expectedErrors:   []string{},
+			expectedWarnings: []string{`loader "verify": 4.16 had 10 job runs`},
+		},
+		{
+			name:             "failed",
+			errs:             []error{errors.New("could not list prow jobs")},
+			expectedStatus:   models.LoadEventFailed,
+			expectedErrors:   []string{"could not list prow jobs"},
+			expectedWarnings: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &models.LoadEvent{StartedAt: started, Status: models.LoadEventRunning}
+			finishLoadEvent(event, ended, 12, 3400, tt.errs, tt.warnings)
+
+			assert.Equal(t, tt.expectedStatus, event.Status)
+			assert.Equal(t, ended, *event.EndedAt)
+			assert.Equal(t, int64(90000), event.DurationMillis)
+			assert.Equal(t, int64(12), event.JobRunsInserted)
+			assert.Equal(t, int64(3400), event.TestResultsInserted)
+			assert.Equal(t, tt.expectedErrors, []string(event.Errors))
+			assert.Equal(t, tt.expectedWarnings, []string(event.Warnings))
+		})
+	}
+}
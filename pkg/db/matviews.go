@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
 )
 
 const replaceTimeNow = "|||TIMENOW|||"
@@ -38,6 +40,11 @@ var PostgresMatViews = []PostgresView{
 		Definition:   testAnalysisByJobMatView,
 		IndexColumns: []string{"test_id", "test_name", "date", "job_name"},
 	},
+	{
+		Name:         "prow_test_analysis_by_variant_combination_14d_matview",
+		Definition:   testAnalysisByVariantCombinationMatView,
+		IndexColumns: []string{"test_id", "date", "release", "variant_combination"},
+	},
 	{
 		Name:         "prow_job_runs_report_matview",
 		Definition:   jobRunsReportMatView,
@@ -59,6 +66,22 @@ var PostgresMatViews = []PostgresView{
 			"|||BY|||": "hour",
 		},
 	},
+	{
+		Name:         "prow_job_results_by_day_matview",
+		Definition:   prowJobResultsMatView,
+		IndexColumns: []string{"period", "prow_job_id"},
+		ReplaceStrings: map[string]string{
+			"|||BY|||": "day",
+		},
+	},
+	{
+		Name:         "prow_job_results_by_hour_matview",
+		Definition:   prowJobResultsMatView,
+		IndexColumns: []string{"period", "prow_job_id"},
+		ReplaceStrings: map[string]string{
+			"|||BY|||": "hour",
+		},
+	},
 	{
 		// TODO: this probably doesn't need to be a matview anymore since we only keep 3 months of data,
 		// metrics show this refreshing in .6s a lot of the time, occasionally up to 5s.
@@ -186,27 +209,30 @@ pull_requests AS (
 		prow_pull_requests.sha,
 		prow_pull_requests.org,
 		prow_pull_requests.author,
-		prow_pull_requests.repo
+		prow_pull_requests.repo,
+		prow_pull_requests.number
         FROM
                 prow_pull_requests
         INNER JOIN
                 prow_job_run_prow_pull_requests ON prow_job_run_prow_pull_requests.prow_pull_request_id = prow_pull_requests.id
         INNER JOIN
                 prow_job_runs ON prow_job_run_prow_pull_requests.prow_job_run_id = prow_job_runs.id
-        GROUP BY prow_job_runs.id, prow_pull_requests.link, prow_pull_requests.sha, prow_pull_requests.org, prow_pull_requests.repo, prow_pull_requests.author
+        GROUP BY prow_job_runs.id, prow_pull_requests.link, prow_pull_requests.sha, prow_pull_requests.org, prow_pull_requests.repo, prow_pull_requests.author, prow_pull_requests.number
 )
 SELECT prow_job_runs.id,
    prow_jobs.release,
    prow_jobs.name,
    prow_jobs.name AS job,
    prow_jobs.variants,
-   regexp_replace(prow_jobs.name, 'periodic-ci-openshift-(multiarch|release)-master-(ci|nightly)-[0-9]+.[0-9]+-'::text, ''::text) AS brief_name,
+   prow_jobs.kind,
+   regexp_replace(prow_jobs.name, '` + models.JobBriefNamePattern + `'::text, ''::text) AS brief_name,
    prow_job_runs.overall_result,
    prow_job_runs.url AS test_grid_url,
    prow_job_runs.url,
    prow_job_runs.succeeded,
    prow_job_runs.infrastructure_failure,
    prow_job_runs.known_failure,
+   prow_job_runs.probable_cause,
    (EXTRACT(epoch FROM (prow_job_runs."timestamp" AT TIME ZONE 'utc'::text)) * 1000::numeric)::bigint AS "timestamp",
    prow_job_runs.id AS prow_id,
    prow_job_runs.cluster AS cluster,
@@ -218,7 +244,8 @@ SELECT prow_job_runs.id,
    pull_requests.sha as pull_request_sha,
    pull_requests.org as pull_request_org,
    pull_requests.repo as pull_request_repo,
-   pull_requests.author as pull_request_author
+   pull_requests.author as pull_request_author,
+   pull_requests.number as pull_request_number
 FROM prow_job_runs
    LEFT JOIN failed_test_results ON failed_test_results.prow_job_run_id = prow_job_runs.id
    LEFT JOIN flaked_test_results ON flaked_test_results.prow_job_run_id = prow_job_runs.id
@@ -242,6 +269,7 @@ WITH open_bugs AS (
 SELECT
     tests.id,
     tests.name,
+    tests.hash,
     tests.watchlist, 
     suites.name AS suite_name,
     jira_components.name AS jira_component,
@@ -268,8 +296,16 @@ FROM
     JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id
 WHERE
     prow_job_run_tests.created_at >= |||START||| AND prow_job_runs.timestamp >= |||START|||
+    AND prow_job_run_tests.status <> 14
+    AND NOT EXISTS (
+        SELECT 1 FROM test_suppressions
+        WHERE test_suppressions.deleted_at IS NULL
+          AND prow_job_runs.timestamp BETWEEN test_suppressions.start_date AND test_suppressions.end_date
+          AND tests.name ~ test_suppressions.test_pattern
+          AND (test_suppressions.variant = '' OR test_suppressions.variant = ANY(prow_jobs.variants))
+    )
 GROUP BY
-    tests.id, tests.name, jira_components.name, jira_components.id, suites.name, open_bugs.open_bugs, prow_jobs.variants, prow_jobs.release
+    tests.id, tests.name, tests.hash, jira_components.name, jira_components.id, suites.name, open_bugs.open_bugs, prow_jobs.variants, prow_jobs.release
 `
 
 const testAnalysisByVariantView = `
@@ -293,6 +329,36 @@ GROUP BY
 	tests.name, tests.id, byjob.test_id, byjob.test_name, date, unnest(prow_jobs.variants), prow_jobs.release
 `
 
+// testAnalysisByVariantCombinationMatView groups by a job's full, sorted set of variants, e.g. "aws+ovn+upgrade",
+// rather than counting each variant independently, so pass rates for a specific combination can be reported.
+const testAnalysisByVariantCombinationMatView = `
+SELECT
+    tests.id AS test_id,
+    tests.name AS test_name,
+    date(prow_job_runs."timestamp") AS date,
+    jobs.release,
+    jobs.variant_combination,
+    COUNT(*) AS runs,
+    COUNT(*) FILTER (WHERE prow_job_run_tests.status = 1) AS passes,
+    COUNT(*) FILTER (WHERE prow_job_run_tests.status = 13) AS flakes,
+    COUNT(*) FILTER (WHERE prow_job_run_tests.status = 12) AS failures
+FROM
+    prow_job_run_tests
+    JOIN tests ON tests.id = prow_job_run_tests.test_id
+    JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_tests.prow_job_run_id
+    JOIN (
+        SELECT id, release, array_to_string(ARRAY(SELECT v FROM unnest(variants) v ORDER BY v), '+') AS variant_combination
+        FROM prow_jobs
+    ) jobs ON jobs.id = prow_job_runs.prow_job_id
+WHERE
+    prow_job_run_tests.created_at > (|||TIMENOW||| - '14 days'::interval)
+    AND prow_job_runs."timestamp" > (|||TIMENOW||| - '14 days'::interval)
+    AND prow_job_runs."timestamp" <= |||TIMENOW|||
+    AND prow_job_run_tests.status <> 14
+GROUP BY
+    tests.id, tests.name, date(prow_job_runs."timestamp"), jobs.release, jobs.variant_combination
+`
+
 const testAnalysisByJobMatView = `
 SELECT
     tests.id AS test_id,
@@ -312,6 +378,7 @@ FROM
     JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
 WHERE
     prow_job_run_tests.created_at > (|||TIMENOW||| - '14 days'::interval) AND prow_job_runs."timestamp" > (|||TIMENOW||| - '14 days'::interval)
+    AND prow_job_run_tests.status <> 14
 GROUP BY
     tests.name, tests.id, date(prow_job_runs."timestamp"), prow_jobs.release, prow_jobs.name
 `
@@ -328,6 +395,19 @@ WHERE pjrt.status = 12
 GROUP BY tests.name, (date_trunc('|||BY|||'::text, prow_job_runs."timestamp")), prow_job_runs.prow_job_id
 `
 
+const prowJobResultsMatView = `
+SELECT date_trunc('|||BY|||'::text, prow_job_runs."timestamp") AS period,
+   prow_job_runs.prow_job_id,
+   count(*) AS runs,
+   count(*) FILTER (WHERE prow_job_runs.succeeded) AS successes,
+   count(*) FILTER (WHERE prow_job_runs.failed) AS failures,
+   count(*) FILTER (WHERE prow_job_runs.infrastructure_failure) AS infrastructure_failures,
+   count(*) FILTER (WHERE prow_job_runs.known_failure) AS known_failures
+FROM prow_job_runs
+WHERE prow_job_runs.deleted_at IS NULL
+GROUP BY (date_trunc('|||BY|||'::text, prow_job_runs."timestamp")), prow_job_runs.prow_job_id
+`
+
 // TODO: remove distinct once bug fixed re dupes in release_job_runs
 const payloadTestFailuresMatView = `
 SELECT DISTINCT
@@ -0,0 +1,234 @@
+package db
+
+import (
+	"crypto/sha256"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// UpdateSchema changes the schema in three ways, and each kind of object has exactly one owner:
+//
+//   - gorm's AutoMigrate owns tables and columns: creating a table or adding a column is done by adding it to a
+//     model in pkg/db/models.
+//   - syncSchema owns views, materialized views, functions and indexes: they are recreated whenever their
+//     definition's hash changes.
+//   - Versioned migrations own everything else, the changes AutoMigrate can't make safely such as renames, drops,
+//     constraints and data fixes. They run after AutoMigrate, so they can rely on the tables and columns of the
+//     current models existing.
+//
+// Each versioned migration is a pair of files in the migrations directory named <version>_<name>.up.sql and
+// <version>_<name>.down.sql; the down file is what makes a change reversible. Migrations that create objects owned
+// by AutoMigrate or syncSchema are rejected when they are loaded.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationOwnedElsewhereRegexp matches up migration statements that create objects AutoMigrate or syncSchema own.
+var migrationOwnedElsewhereRegexp = regexp.MustCompile(
+	`(?i)\b(CREATE\s+(TABLE|(OR\s+REPLACE\s+)?(MATERIALIZED\s+)?VIEW|(OR\s+REPLACE\s+)?FUNCTION|(UNIQUE\s+)?INDEX)|ADD\s+COLUMN)\b`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum is the hash recorded when the migration is applied.
+func (m Migration) Checksum() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(m.Up)))
+}
+
+// Migrations returns the versioned migrations built into sippy, ordered by version.
+func Migrations() ([]Migration, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return loadMigrations(sub)
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := migrationFileRegexp.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		content, err := fs.ReadFile(fsys, path.Clean(e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both an up and a down file", m.Version, m.Name)
+		}
+		if stmt := migrationOwnedElsewhereRegexp.FindString(m.Up); stmt != "" {
+			return nil, fmt.Errorf("migration %d_%s uses %s, tables and columns belong in the gorm models and "+
+				"views, functions and indexes in syncSchema", m.Version, m.Name, stmt)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// AppliedMigrations returns the migration history recorded in the database.
+func (d *DB) AppliedMigrations() ([]models.SchemaMigration, error) {
+	applied := []models.SchemaMigration{}
+	if !d.DB.Migrator().HasTable(&models.SchemaMigration{}) {
+		return applied, nil
+	}
+	res := d.DB.Order("version").Find(&applied)
+	return applied, res.Error
+}
+
+// PendingMigrations returns the migrations that have not yet been applied to the database. An error is returned
+// if an applied migration has since been modified.
+func (d *DB) PendingMigrations() ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := d.AppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return pendingMigrations(migrations, applied)
+}
+
+func pendingMigrations(migrations []Migration, applied []models.SchemaMigration) ([]Migration, error) {
+	appliedByVersion := map[int]models.SchemaMigration{}
+	for _, a := range applied {
+		appliedByVersion[a.Version] = a
+	}
+
+	pending := []Migration{}
+	for _, m := range migrations {
+		a, ok := appliedByVersion[m.Version]
+		if !ok {
+			pending = append(pending, m)
+			continue
+		}
+		if a.Checksum != m.Checksum() {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied", m.Version, m.Name)
+		}
+	}
+	return pending, nil
+}
+
+// CheckSchemaVersion returns an error if the database is missing migrations this version of sippy expects.
+func (d *DB) CheckSchemaVersion() error {
+	pending, err := d.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind, %d migrations are pending starting with %d_%s, run sippy migrate",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// MigrateUp applies all pending migrations, each in its own transaction.
+func (d *DB) MigrateUp() error {
+	if err := d.DB.AutoMigrate(&models.SchemaMigration{}); err != nil {
+		return err
+	}
+	pending, err := d.PendingMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		start := time.Now()
+		err := d.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.SchemaMigration{
+				Version:        m.Version,
+				Name:           m.Name,
+				Checksum:       m.Checksum(),
+				AppliedAt:      start,
+				DurationMillis: time.Since(start).Milliseconds(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error applying migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		log.WithField("duration", time.Since(start)).Infof("applied migration %d_%s", m.Version, m.Name)
+	}
+	return nil
+}
+
+// MigrateDown rolls back applied migrations newer than version, newest first.
+func (d *DB) MigrateDown(version int) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	byVersion := map[int]Migration{}
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+	applied, err := d.AppliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for i := len(applied) - 1; i >= 0 && applied[i].Version > version; i-- {
+		m, ok := byVersion[applied[i].Version]
+		if !ok {
+			return fmt.Errorf("migration %d_%s is applied but unknown to this version of sippy", applied[i].Version, applied[i].Name)
+		}
+		err := d.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&models.SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error rolling back migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		log.Infof("rolled back migration %d_%s", m.Version, m.Name)
+	}
+	return nil
+}
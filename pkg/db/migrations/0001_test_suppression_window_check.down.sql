@@ -0,0 +1,2 @@
+ALTER TABLE test_suppressions
+    DROP CONSTRAINT IF EXISTS test_suppressions_window_check;
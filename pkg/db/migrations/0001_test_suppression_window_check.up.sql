@@ -0,0 +1,2 @@
+ALTER TABLE test_suppressions
+    ADD CONSTRAINT test_suppressions_window_check CHECK (end_date > start_date);
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		expected []int
+		wantErr  bool
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"0002_second.up.sql":   {Data: []byte("up 2")},
+				"0002_second.down.sql": {Data: []byte("down 2")},
+				"0001_first.up.sql":    {Data: []byte("up 1")},
+				"0001_first.down.sql":  {Data: []byte("down 1")},
+			},
+			expected: []int{1, 2},
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"0001_first.up.sql": {Data: []byte("up 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("up 1")},
+				"0001_first.down.sql": {Data: []byte("down 1")},
+				"0001_other.up.sql":   {Data: []byte("up 1")},
+				"0001_other.down.sql": {Data: []byte("down 1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "creates a table",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("CREATE TABLE foo (id int)")},
+				"0001_first.down.sql": {Data: []byte("DROP TABLE foo")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adds a column",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("ALTER TABLE foo ADD COLUMN bar int")},
+				"0001_first.down.sql": {Data: []byte("ALTER TABLE foo DROP COLUMN bar")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "creates an index",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("create unique index foo_bar on foo(bar)")},
+				"0001_first.down.sql": {Data: []byte("DROP INDEX foo_bar")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "renames a column",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("ALTER TABLE foo RENAME COLUMN bar TO baz")},
+				"0001_first.down.sql": {Data: []byte("ALTER TABLE foo RENAME COLUMN baz TO bar")},
+			},
+			expected: []int{1},
+		},
+		{
+			name: "bad file name",
+			files: fstest.MapFS{
+				"first.sql": {Data: []byte("up 1")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tt.files)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			versions := []int{}
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.expected, versions)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	_, err := Migrations()
+	assert.NoError(t, err)
+}
+
+func TestPendingMigrations(t *testing.T) {
+	first := Migration{Version: 1, Name: "first", Up: "up 1", Down: "down 1"}
+	second := Migration{Version: 2, Name: "second", Up: "up 2", Down: "down 2"}
+	migrations := []Migration{first, second}
+
+	pending, err := pendingMigrations(migrations, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, migrations, pending)
+
+	pending, err = pendingMigrations(migrations, []models.SchemaMigration{{Version: 1, Name: "first", Checksum: first.Checksum()}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{second}, pending)
+
+	_, err = pendingMigrations(migrations, []models.SchemaMigration{{Version: 1, Name: "first", Checksum: "edited"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	// AcknowledgementKindAlert acknowledges a firing alert rule, the target is the rule's name.
+	AcknowledgementKindAlert = "alert"
+	// AcknowledgementKindDisruptionRegression acknowledges a disruption regression, the target is its ID.
+	AcknowledgementKindDisruptionRegression = "disruption_regression"
+)
+
+// Acknowledgement marks a detected regression or firing alert as known and being worked, so it can be told apart
+// from new, untriaged ones. It lasts until the regression or alert clears, or until ExpiresAt if set, snoozing it.
+type Acknowledgement struct {
+	Model
+
+	Kind   string `json:"kind" gorm:"index:idx_acknowledgement_target"`
+	Target string `json:"target" gorm:"index:idx_acknowledgement_target"`
+	User   string `json:"user"`
+	Reason string `json:"reason"`
+	// ExpiresAt is when a snooze ends, nil if acknowledged until cleared.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIToken authenticates automation calling sippy APIs that change state. Only a hash of the token is stored;
+// the token itself is shown once, when it is created.
+type APIToken struct {
+	Model
+
+	// Name describes what the token is used for, e.g. "release-controller".
+	Name      string `json:"name" gorm:"uniqueIndex"`
+	TokenHash string `json:"-" gorm:"uniqueIndex"`
+	// Scopes the token grants, see api.APITokenScopes.
+	Scopes     pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+)
+
+// DisruptionRegressionState tracks whether disruption for a backend in a given NURP (network, upgrade, release,
+// platform) combination is worse than it was two weeks ago, and for how many consecutive days that has been true.
+// It is updated each time disruption metrics are refreshed.
+type DisruptionRegressionState struct {
+	Model
+
+	Release            string `json:"release" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	BackendName        string `json:"backend_name" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	Platform           string `json:"platform" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	UpgradeType        string `json:"upgrade_type" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	MasterNodesUpdated string `json:"master_nodes_updated" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	Network            string `json:"network" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	Topology           string `json:"topology" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+	Architecture       string `json:"architecture" gorm:"uniqueIndex:idx_disruption_regression_nurp"`
+
+	// P95Delta is the most recently observed change in P95 disruption seconds vs two weeks ago.
+	P95Delta float64 `json:"p95_delta"`
+	// Regressed is true if the most recent evaluation found disruption worse than two weeks ago.
+	Regressed bool `json:"regressed"`
+	// ConsecutiveBadDays is the number of days in a row disruption has been regressed.
+	ConsecutiveBadDays int `json:"consecutive_bad_days"`
+	// FirstBadDate is the first day of the current run of regressed days.
+	FirstBadDate *time.Time `json:"first_bad_date"`
+	// LastEvaluatedDate is the day (UTC, truncated) of the most recent evaluation.
+	LastEvaluatedDate time.Time `json:"last_evaluated_date"`
+	// Acknowledgement is set when the regression is acknowledged or snoozed.
+	Acknowledgement *Acknowledgement `json:"acknowledgement,omitempty" gorm:"-"`
+}
+
+// DisruptionPercentile is a copy of a row from the BigQuery BackendDisruptionPercentilesByDate view, the disruption
+// percentiles for a backend in a NURP over the days leading up to Date. Keeping these in postgres allows disruption
+// to be analyzed without BigQuery credentials.
+type DisruptionPercentile struct {
+	Model
+
+	Date               time.Time `json:"date" gorm:"type:date;uniqueIndex:idx_disruption_percentile_nurp"`
+	LookbackDays       int       `json:"lookback_days" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	Release            string    `json:"release" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	FromRelease        string    `json:"from_release" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	BackendName        string    `json:"backend_name" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	Platform           string    `json:"platform" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	UpgradeType        string    `json:"upgrade_type" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	MasterNodesUpdated string    `json:"master_nodes_updated" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	Network            string    `json:"network" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	Topology           string    `json:"topology" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+	Architecture       string    `json:"architecture" gorm:"uniqueIndex:idx_disruption_percentile_nurp"`
+
+	JobRuns int     `json:"job_runs"`
+	P50     float64 `json:"p50"`
+	P75     float64 `json:"p75"`
+	P95     float64 `json:"p95"`
+	P99     float64 `json:"p99"`
+	// PercentageAboveZero is the percentage of job runs that saw any disruption.
+	PercentageAboveZero float64 `json:"percentage_above_zero"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// FeatureFlagRollout overrides the rollout of an experimental feature, so it can be changed without restarting
+// sippy. It takes precedence over the --feature-flag server flag.
+type FeatureFlagRollout struct {
+	Model
+
+	Name string `json:"name" gorm:"uniqueIndex"`
+	// RolloutPercentage is the percentage of clients the feature is enabled for, from 0 to 100.
+	RolloutPercentage int `json:"rollout_percentage"`
+}
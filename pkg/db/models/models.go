@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -30,6 +31,60 @@ type SchemaHash struct {
 	Hash string `json:"hash"`
 }
 
+// SchemaMigration records a versioned migration applied to the database.
+type SchemaMigration struct {
+	Version int    `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	Name    string `json:"name"`
+	// Checksum is the SHA256 of the up migration, used to detect migrations edited after they were applied.
+	Checksum       string    `json:"checksum"`
+	AppliedAt      time.Time `json:"applied_at"`
+	DurationMillis int64     `json:"duration_millis"`
+}
+
+// MatViewRefresh records a single refresh of a materialized view, so we can see how long refreshes take and
+// which are still running.
+type MatViewRefresh struct {
+	Model
+	// Name of the materialized view.
+	Name string `json:"name" gorm:"index"`
+	// Status is running, succeeded, failed, or canceled.
+	Status    string     `json:"status"`
+	StartedAt time.Time  `json:"started_at" gorm:"index"`
+	EndedAt   *time.Time `json:"ended_at"`
+	// DurationMillis is the time taken by the refresh, set once it has ended.
+	DurationMillis int64 `json:"duration_millis"`
+	// Rows is the number of rows in the view after a successful refresh.
+	Rows int64 `json:"rows"`
+	// Concurrent indicates if the view was refreshed without blocking reads.
+	Concurrent bool   `json:"concurrent"`
+	Error      string `json:"error,omitempty"`
+}
+
+const (
+	MatViewRefreshRunning   = "running"
+	MatViewRefreshSucceeded = "succeeded"
+	MatViewRefreshFailed    = "failed"
+	MatViewRefreshCanceled  = "canceled"
+)
+
+// AlertResult is the outcome of evaluating an alert rule from the sippy config.
+type AlertResult struct {
+	Model
+	Rule      string  `json:"rule" gorm:"index"`
+	Release   string  `json:"release"`
+	Variant   string  `json:"variant"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	Value     float64 `json:"value"`
+	Runs      int     `json:"runs"`
+	// Firing is true if the rule's condition was met.
+	Firing      bool      `json:"firing"`
+	EvaluatedAt time.Time `json:"evaluated_at" gorm:"index"`
+	// Acknowledgement is set when a firing rule is acknowledged or snoozed.
+	Acknowledgement *Acknowledgement `json:"acknowledgement,omitempty" gorm:"-"`
+}
+
 // APISnapshot is a minimal implementation of historical data tracking. On GA or other dates of interest, we use the snapshot CLI command
 // to query some of the main API endpoints, and store the resulting json with an type (indicating the API) into our database.
 type APISnapshot struct {
@@ -74,3 +129,33 @@ type JiraIncident struct {
 	// ResolutionTime is the time the issue was resolved
 	ResolutionTime *time.Time `json:"resolution_time" gorm:"index"`
 }
+
+// LoadEvent records a run of sippy load, so we can tell when data was last updated and by what.
+type LoadEvent struct {
+	Model
+	// Loaders are the data sources that were loaded, i.e. prow, releases, bugs.
+	Loaders pq.StringArray `json:"loaders" gorm:"type:text[]"`
+	// Releases and Architectures that were loaded, empty means all of them.
+	Releases      pq.StringArray `json:"releases" gorm:"type:text[]"`
+	Architectures pq.StringArray `json:"architectures" gorm:"type:text[]"`
+	// SippyVersion is the git commit of the sippy that ran the load.
+	SippyVersion string     `json:"sippy_version"`
+	StartedAt    time.Time  `json:"started_at" gorm:"index"`
+	EndedAt      *time.Time `json:"ended_at"`
+	// DurationMillis is the time taken by the load, set once it has ended.
+	DurationMillis int64 `json:"duration_millis"`
+	// JobRunsInserted and TestResultsInserted count the rows created during the load.
+	JobRunsInserted     int64          `json:"job_runs_inserted"`
+	TestResultsInserted int64          `json:"test_results_inserted"`
+	Errors              pq.StringArray `json:"errors" gorm:"type:text[]"`
+	// Warnings are anomalies found verifying the loaded data that did not fail the load.
+	Warnings pq.StringArray `json:"warnings" gorm:"type:text[]"`
+	// Status is running, succeeded or failed.
+	Status string `json:"status"`
+}
+
+const (
+	LoadEventRunning   = "running"
+	LoadEventSucceeded = "succeeded"
+	LoadEventFailed    = "failed"
+)
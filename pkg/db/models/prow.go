@@ -1,6 +1,10 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgtype"
@@ -15,6 +19,10 @@ type ProwKind string
 const ProwPeriodic ProwKind = "periodic"
 const ProwPresubmit ProwKind = "presubmit"
 
+// JobSourceProw is the source of jobs loaded from prow, any other source is a CI system reporting its runs to
+// sippy directly.
+const JobSourceProw = "prow"
+
 // ProwJob represents a prow job with various fields inferred from it's name. (release, variants, etc)
 type ProwJob struct {
 	gorm.Model
@@ -26,6 +34,79 @@ type ProwJob struct {
 	TestGridURL string
 	Bugs        []Bug        `gorm:"many2many:bug_jobs;"`
 	JobRuns     []ProwJobRun `gorm:"constraint:OnDelete:CASCADE;"`
+	// Lineage links the same job across releases, as jobs are renamed each release. It defaults to
+	// JobLineageName, and can be set by a JobLineageOverride when the automatic match is wrong.
+	Lineage string `gorm:"index"`
+	// Source is the CI system the job runs in, prow unless its runs were reported from elsewhere, such as jenkins.
+	Source string `gorm:"default:prow;index"`
+}
+
+// BeforeSave ensures every job belongs to a lineage. A job first saved after an override for it was set gets the
+// override's lineage.
+func (pj *ProwJob) BeforeSave(tx *gorm.DB) error {
+	if pj.Lineage != "" || pj.Name == "" {
+		return nil
+	}
+	override := JobLineageOverride{}
+	res := tx.Session(&gorm.Session{NewDB: true}).Where("job_name = ?", pj.Name).Limit(1).Find(&override)
+	if res.Error != nil {
+		return res.Error
+	}
+	if override.ID != 0 {
+		pj.Lineage = override.Lineage
+	} else {
+		pj.Lineage = JobLineageName(pj.Name)
+	}
+	return nil
+}
+
+// JobBriefNamePattern matches the prefix of the OpenShift release jobs' names up to and including their release,
+// which the job reports drop to show the job's brief name. The brief_name SQL and JobLineageName share it so the
+// two stay consistent.
+const JobBriefNamePattern = `periodic-ci-openshift-(multiarch|release)-master-(ci|nightly)-[0-9]+.[0-9]+-`
+
+var (
+	jobBriefNameRegexp      = regexp.MustCompile(JobBriefNamePattern)
+	jobLineageReleaseRegexp = regexp.MustCompile(`-[0-9]+\.[0-9]+(-|$)`)
+)
+
+// JobBriefName is the name of a job without its release prefix, as shown in the job reports. Like the brief_name
+// SQL, only the first match is removed.
+func JobBriefName(name string) string {
+	loc := jobBriefNameRegexp.FindStringIndex(name)
+	if loc == nil {
+		return name
+	}
+	return name[:loc[0]] + name[loc[1]:]
+}
+
+// JobLineageName is the automatic lineage of a job, its name with release versions removed. For example both
+// periodic-ci-openshift-release-master-nightly-4.14-e2e-aws-ovn and its 4.15 equivalent have the lineage
+// periodic-ci-openshift-release-master-nightly-e2e-aws-ovn.
+func JobLineageName(name string) string {
+	// The brief name drops the release prefix, keep the prefix without its release so jobs of different streams
+	// stay in different lineages.
+	if m := jobBriefNameRegexp.FindStringSubmatchIndex(name); m != nil {
+		name = name[:m[0]] + "periodic-ci-openshift-" + name[m[2]:m[3]] + "-master-" + name[m[4]:m[5]] + "-" +
+			JobBriefName(name[m[0]:])
+	}
+	// Remove the remaining versions, such as upgrade-from-4.14. ReplaceAll does not find overlapping matches, so
+	// repeat until names like upgrade-from-4.13-4.14 are done.
+	for {
+		lineage := jobLineageReleaseRegexp.ReplaceAllString(name, "$1")
+		if lineage == name {
+			return lineage
+		}
+		name = lineage
+	}
+}
+
+// JobLineageOverride manually places a job in a lineage, for renames beyond a change of release.
+type JobLineageOverride struct {
+	Model
+	JobName string `json:"job_name" gorm:"uniqueIndex"`
+	Lineage string `json:"lineage"`
+	Reason  string `json:"reason"`
 }
 
 // IDName is a partial struct to query limited fields we need for caching. Can be used
@@ -48,14 +129,34 @@ type ProwJobRun struct {
 
 	URL          string
 	TestFailures int
-	Tests        []ProwJobRunTest  `gorm:"constraint:OnDelete:CASCADE;"`
-	PullRequests []ProwPullRequest `gorm:"many2many:prow_job_run_prow_pull_requests;constraint:OnDelete:CASCADE;"`
+	// prow_job_runs is partitioned by timestamp, so rows referencing a run can't have a foreign key to it. They are
+	// listed as dependents in db.PartitionedTables, and deleted when the run's partition is dropped.
+	Tests        []ProwJobRunTest  `gorm:"constraint:-"`
+	PullRequests []ProwPullRequest `gorm:"many2many:prow_job_run_prow_pull_requests;constraint:-"`
+	// DebugArtifacts link to the must-gather and other debugging outputs of failed runs.
+	DebugArtifacts []ProwJobRunDebugArtifact `gorm:"constraint:-"`
+	// Disruptions is the time each backend was unavailable during the run.
+	Disruptions []ProwJobRunDisruption `gorm:"constraint:-"`
+	// Steps are the steps of the run's multi-stage tests, from its step graph.
+	Steps []ProwJobRunStep `gorm:"constraint:-"`
+	// Fingerprints identify the versions of the dependencies the run used, such as its installer, from its
+	// artifacts.
+	Fingerprints []ProwJobRunFingerprint `gorm:"constraint:-"`
 	Failed       bool
 	// InfrastructureFailure is true if the job run failed, for reasons which appear to be related to test/CI infra.
 	InfrastructureFailure bool
 	// KnownFailure is true if the job run failed, but we found a bug that is likely related already filed.
-	KnownFailure  bool
+	KnownFailure bool
+	// ProbableCause is the category the job run classifier assigned a failed run, e.g. install or known_bug, to
+	// jump-start triage.
+	ProbableCause string
 	Succeeded     bool
+	// ArtifactBytes is the total size of the run's artifacts in GCS, 0 if they were not measured.
+	ArtifactBytes int64
+	// CloudRegion and CloudZone are where the run's cluster was installed, from its cluster-data file, empty if
+	// it had none.
+	CloudRegion   string
+	CloudZone     string
 	Timestamp     time.Time `gorm:"index;index:idx_prow_job_runs_timestamp_date,expression:DATE(timestamp AT TIME ZONE 'UTC')"`
 	Duration      time.Duration
 	OverallResult v1.JobOverallResult `gorm:"index"`
@@ -64,12 +165,101 @@ type ProwJobRun struct {
 	ClusterData ClusterData `gorm:"-"`
 }
 
+// ProwJobRunDebugArtifact links a failed job run to a well known debugging output in its artifacts, so triagers
+// don't have to hunt through the artifacts tree.
+type ProwJobRunDebugArtifact struct {
+	gorm.Model
+	ProwJobRunID uint `gorm:"index"`
+	// Kind is must-gather, gather-extra or screenshot.
+	Kind string
+	URL  string
+}
+
+// ProwJobRunDisruption is the total time a backend was disrupted during a job run, summed from the disruption
+// intervals the run published.
+type ProwJobRunDisruption struct {
+	gorm.Model
+	ProwJobRunID      uint   `gorm:"index"`
+	BackendName       string `gorm:"index"`
+	DisruptionSeconds float64
+}
+
+// ProwJobRunFingerprint is the value of a configured environment fingerprint found in a job run's artifacts, i.e.
+// the installer version, so failures can be correlated with a dependency changing.
+type ProwJobRunFingerprint struct {
+	gorm.Model
+	ProwJobRunID uint   `gorm:"index"`
+	Name         string `gorm:"index"`
+	Value        string
+}
+
 type Test struct {
 	gorm.Model
 	Name string `gorm:"uniqueIndex"`
 	Bugs []Bug  `gorm:"many2many:bug_tests;"`
 	// Watchlist are tests TRT is interested in keeping an eye on.
 	Watchlist bool
+	// Hash is a stable identifier derived from the test name. Unlike ID it is the same across sippy
+	// instances and survives a reload of the database, so it is safe to use in links.
+	Hash string `gorm:"index"`
+	// Sig is the sig tag in the test name, i.e. sig-network, or empty if it has none.
+	Sig *string `gorm:"index"`
+	// Features are the feature and feature gate tags in the test name.
+	Features pq.StringArray `gorm:"type:text[]"`
+}
+
+// TestNameAlias is a test name the configured test name rules rewrote, loaded as the results of another test.
+type TestNameAlias struct {
+	gorm.Model
+	RawName string `gorm:"uniqueIndex"`
+	TestID  uint   `gorm:"index"`
+	// Rules are the names of the rules that rewrote it.
+	Rules pq.StringArray `gorm:"type:text[]"`
+}
+
+// BeforeSave ensures every test has its name hash, sig and features populated.
+func (t *Test) BeforeSave(_ *gorm.DB) error {
+	if t.Name != "" {
+		t.Hash = TestNameHash(t.Name)
+		sig := TestSig(t.Name)
+		t.Sig = &sig
+		t.Features = TestFeatures(t.Name)
+	}
+	return nil
+}
+
+var (
+	testSigRegexp     = regexp.MustCompile(`\[(sig-[^\]]+)\]`)
+	testFeatureRegexp = regexp.MustCompile(`\[(?:Feature|FeatureGate|OCPFeatureGate):([^\]]+)\]`)
+)
+
+// TestSig returns the first sig tag in a test name, i.e. sig-network for "[sig-network] pods should be reachable".
+func TestSig(name string) string {
+	if m := testSigRegexp.FindStringSubmatch(name); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// TestFeatures returns the distinct feature and feature gate tags in a test name, i.e. Builds for
+// "[sig-builds][Feature:Builds] result image should have proper labels set".
+func TestFeatures(name string) []string {
+	features := []string{}
+	seen := map[string]bool{}
+	for _, m := range testFeatureRegexp.FindAllStringSubmatch(name, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			features = append(features, m[1])
+		}
+	}
+	return features
+}
+
+// TestNameHash returns the stable identifier for a test name: the hex encoded sha256 of the name with
+// surrounding whitespace removed.
+func TestNameHash(name string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(name)))
+	return hex.EncodeToString(sum[:])
 }
 
 // ProwJobRunTest defines a join table linking tests to the job runs they execute in, along with the status for
@@ -90,12 +280,16 @@ type ProwJobRunTest struct {
 
 	// ProwJobRunTestOutput collect the output of a failed test run. This is stored as a separate object in the DB, so
 	// we can keep the test result for a longer period of time than we keep the full failure output.
-	ProwJobRunTestOutput *ProwJobRunTestOutput `gorm:"constraint:OnDelete:CASCADE;"`
+	// prow_job_run_tests is partitioned by created_at, so the output is deleted when its partition is dropped
+	// rather than by a foreign key.
+	ProwJobRunTestOutput *ProwJobRunTestOutput `gorm:"constraint:-"`
 }
 
 type ProwJobRunTestOutput struct {
 	gorm.Model
 	ProwJobRunTestID uint `gorm:"index"`
+	// Message stores the junit failure message of a ProwJobRunTest, usually a one line summary of the error.
+	Message string
 	// Output stores the output of a ProwJobRunTest.
 	Output string
 
@@ -148,8 +342,14 @@ type Bug struct {
 	Components      pq.StringArray `json:"components" gorm:"type:text[]"`
 	Labels          pq.StringArray `json:"labels" gorm:"type:text[]"`
 	URL             string         `json:"url"`
-	Tests           []Test         `json:"-" gorm:"many2many:bug_tests;constraint:OnDelete:CASCADE;"`
-	Jobs            []ProwJob      `json:"-" gorm:"many2many:bug_jobs;constraint:OnDelete:CASCADE;"`
+	// LastSyncTime is when the bug was last seen by the bug loader, whether or not it had changed.
+	LastSyncTime time.Time `json:"last_sync_time" gorm:"index"`
+	// OpenedTime and ResolvedTime are when the bug was created and resolved in jira, ResolvedTime is nil while
+	// the bug is unresolved.
+	OpenedTime   *time.Time `json:"opened_time"`
+	ResolvedTime *time.Time `json:"resolved_time"`
+	Tests        []Test     `json:"-" gorm:"many2many:bug_tests;constraint:OnDelete:CASCADE;"`
+	Jobs         []ProwJob  `json:"-" gorm:"many2many:bug_jobs;constraint:OnDelete:CASCADE;"`
 }
 
 // ProwPullRequest represents a GitHub pull request, there can be multiple entries
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestNameHash(t *testing.T) {
+	name := "[sig-network] pods should be reachable"
+	hash := TestNameHash(name)
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, TestNameHash(name), "hash should be deterministic")
+	assert.Equal(t, hash, TestNameHash(" "+name+"\n"), "surrounding whitespace should not change the hash")
+	assert.NotEqual(t, hash, TestNameHash(name+"s"), "different names should hash differently")
+}
+
+func TestTestSigAndFeatures(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantSig      string
+		wantFeatures []string
+	}{
+		{
+			name:         "[sig-network] pods should be reachable",
+			wantSig:      "sig-network",
+			wantFeatures: []string{},
+		},
+		{
+			name:         "[sig-builds][Feature:Builds] result image should have proper labels set [apigroup:build.openshift.io]",
+			wantSig:      "sig-builds",
+			wantFeatures: []string{"Builds"},
+		},
+		{
+			name:         "[sig-node][Feature:Gate][OCPFeatureGate:DynamicResourceAllocation][Feature:Gate] should allocate",
+			wantSig:      "sig-node",
+			wantFeatures: []string{"Gate", "DynamicResourceAllocation"},
+		},
+		{
+			name:         "install should succeed: overall",
+			wantFeatures: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSig, TestSig(tt.name))
+			assert.Equal(t, tt.wantFeatures, TestFeatures(tt.name))
+		})
+	}
+}
+
+func TestJobLineageName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "periodic-ci-openshift-release-master-nightly-4.14-e2e-aws-ovn",
+			expected: "periodic-ci-openshift-release-master-nightly-e2e-aws-ovn",
+		},
+		{
+			name:     "periodic-ci-openshift-release-master-ci-4.15-upgrade-from-stable-4.14-e2e-gcp-ovn-upgrade",
+			expected: "periodic-ci-openshift-release-master-ci-upgrade-from-stable-e2e-gcp-ovn-upgrade",
+		},
+		{
+			name:     "periodic-ci-openshift-release-master-nightly-4.15-upgrade-from-4.13-4.14",
+			expected: "periodic-ci-openshift-release-master-nightly-upgrade-from",
+		},
+		{
+			name:     "periodic-ci-openshift-multiarch-master-nightly-4.16-ocp-e2e-aws-ovn-arm64",
+			expected: "periodic-ci-openshift-multiarch-master-nightly-ocp-e2e-aws-ovn-arm64",
+		},
+		{
+			name:     "pull-ci-openshift-origin-master-e2e-aws-ovn",
+			expected: "pull-ci-openshift-origin-master-e2e-aws-ovn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JobLineageName(tt.name))
+		})
+	}
+}
+
+func TestJobBriefName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "periodic-ci-openshift-release-master-nightly-4.14-e2e-aws-ovn",
+			expected: "e2e-aws-ovn",
+		},
+		{
+			name:     "periodic-ci-openshift-release-master-ci-4.15-upgrade-from-stable-4.14-e2e-gcp-ovn-upgrade",
+			expected: "upgrade-from-stable-4.14-e2e-gcp-ovn-upgrade",
+		},
+		{
+			name:     "pull-ci-openshift-origin-master-e2e-aws-ovn",
+			expected: "pull-ci-openshift-origin-master-e2e-aws-ovn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JobBriefName(tt.name))
+			// The lineage keeps the brief name, without its versions.
+			assert.True(t, strings.HasSuffix(JobLineageName(tt.name), JobLineageName(tt.expected)))
+		})
+	}
+}
@@ -59,6 +59,8 @@ type ReleaseTag struct {
 
 	Repositories []ReleaseRepository `json:"-" gorm:"foreignKey:release_tag_id;constraint:OnDelete:CASCADE;"`
 
+	Components []ReleaseComponent `json:"-" gorm:"foreignKey:release_tag_id;constraint:OnDelete:CASCADE;"`
+
 	JobRuns []ReleaseJobRun `json:"-" gorm:"foreignKey:release_tag_id;constraint:OnDelete:CASCADE;"`
 
 	// RejectReason is category of failure for why the payload was rejected. Today this is manually assigned
@@ -70,6 +72,10 @@ type ReleaseTag struct {
 
 	// RejectReasons represents multiple RejectReasons; the value of RejectReason is the first array element.
 	RejectReasons pq.StringArray `json:"reject_reasons" gorm:"type:text[]"`
+
+	// RejectedBy are the blocking jobs the release controller recorded as failed for a rejected payload, by
+	// their verification name, e.g. aws-serial. It's empty for payloads rejected by hand.
+	RejectedBy pq.StringArray `json:"rejected_by" gorm:"type:text[]"`
 }
 
 // ReleasePullRequest represents a pull request that was included for the first time
@@ -112,6 +118,29 @@ type ReleaseRepository struct {
 	DiffURL string `json:"url" gorm:"column:diff_url"`
 }
 
+// ReleaseComponent is a versioned component of a release payload, e.g. Kubernetes or the machine OS, as
+// reported by the release controller's changelog.
+type ReleaseComponent struct {
+	Model
+
+	// ReleaseTagID foreign key.
+	ReleaseTagID string `json:"release_tag" gorm:"column:release_tag_id;index"`
+
+	// Name of the component, e.g. Kubernetes.
+	Name string `json:"name" gorm:"column:name"`
+
+	// Version of the component in this payload.
+	Version    string `json:"version" gorm:"column:version"`
+	VersionURL string `json:"version_url" gorm:"column:version_url"`
+
+	// PreviousVersion is set if the component changed since the previous accepted payload.
+	PreviousVersion    string `json:"previous_version" gorm:"column:previous_version"`
+	PreviousVersionURL string `json:"previous_version_url" gorm:"column:previous_version_url"`
+
+	// DiffURL is a link to the changes between the two versions.
+	DiffURL string `json:"diff_url" gorm:"column:diff_url"`
+}
+
 type ReleaseJobRun struct {
 	Model
 
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/jackc/pgtype"
+	"github.com/lib/pq"
+)
+
+// SavedView is a named filter, column selection and sort for a page of the UI, so links to it can carry the
+// view's ID rather than the whole filter.
+type SavedView struct {
+	Model
+
+	Name string `json:"name"`
+	// Page is the UI page the view applies to, i.e. tests or jobs.
+	Page string `json:"page" gorm:"index"`
+	// Owner identifies who created the view, only they may change or delete it.
+	Owner string `json:"owner" gorm:"index"`
+	// Shared views are listed for everyone, others only for their owner. Any view can be loaded by ID.
+	Shared bool `json:"shared"`
+
+	// Filter is a filter as accepted by the filter param of list endpoints.
+	Filter    pgtype.JSONB   `json:"filter" gorm:"type:jsonb"`
+	Columns   pq.StringArray `json:"columns" gorm:"type:text[]"`
+	SortField string         `json:"sort_field"`
+	Sort      string         `json:"sort"`
+}
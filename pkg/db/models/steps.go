@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JobStep is a step of a job's multi-stage test as composed in the OpenShift CI step registry, so failures can be
+// aggregated by the steps jobs share rather than by whole job.
+type JobStep struct {
+	ID      uint   `json:"-" gorm:"primaryKey"`
+	JobName string `json:"job_name" gorm:"index"`
+	Step    string `json:"step" gorm:"index"`
+	// Phase is pre, test or post.
+	Phase string `json:"phase"`
+	// Position orders the steps of a job.
+	Position int `json:"position"`
+}
+
+// ProwJobRunStep is the outcome of a step of a job run's multi-stage test, read from the run's step graph.
+type ProwJobRunStep struct {
+	gorm.Model
+	ProwJobRunID uint `gorm:"index"`
+	// Test is the multi-stage test the step ran in, i.e. e2e-aws-ovn.
+	Test string
+	// Step is the step's name in the step registry, i.e. ipi-install-install.
+	Step     string `gorm:"index"`
+	Failed   bool
+	Duration time.Duration
+}
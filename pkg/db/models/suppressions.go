@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// TestSuppression marks a period where a test is known to be broken, for example during an infrastructure
+// outage. Results for matching tests in that period are excluded from test pass rates, so the outage does not
+// skew baselines.
+type TestSuppression struct {
+	Model
+
+	// TestPattern is a regular expression matched against test names.
+	TestPattern string `json:"test_pattern"`
+	// Variant optionally limits the suppression to jobs with this variant.
+	Variant   string    `json:"variant"`
+	StartDate time.Time `json:"start_date" gorm:"index"`
+	EndDate   time.Time `json:"end_date" gorm:"index"`
+	Reason    string    `json:"reason"`
+	BugURL    string    `json:"bug_url"`
+}
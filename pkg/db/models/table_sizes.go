@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TableSizeSnapshot is the size of a table or materialized view on a day, kept to measure how fast it grows.
+type TableSizeSnapshot struct {
+	ID         uint   `gorm:"primaryKey"`
+	Name       string `gorm:"index"`
+	Kind       string
+	TotalBytes int64
+	RecordedAt time.Time `gorm:"index"`
+}
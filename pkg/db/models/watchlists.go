@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	WatchlistDigestDaily  = "daily"
+	WatchlistDigestWeekly = "weekly"
+)
+
+// Watchlist is a list of tests a user or team follows in a release, with their current pass rates and regressions
+// reported on demand and optionally in a scheduled digest.
+type Watchlist struct {
+	Model
+
+	// Name is unique, and is the notification name digests are routed by.
+	Name string `json:"name" gorm:"uniqueIndex"`
+	// Owner identifies who created the watchlist, only they may change or delete it.
+	Owner   string         `json:"owner" gorm:"index"`
+	Release string         `json:"release"`
+	Tests   pq.StringArray `json:"tests" gorm:"type:text[]"`
+	// Digest is daily or weekly to be sent a digest, empty for none.
+	Digest string `json:"digest"`
+	// LastDigestAt is when the last digest was sent.
+	LastDigestAt *time.Time `json:"last_digest_at"`
+}
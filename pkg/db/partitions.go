@@ -0,0 +1,324 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// partitionMonthsAhead is how many months of empty partitions are kept ready beyond the current one.
+const partitionMonthsAhead = 2
+
+// PartitionedTable is a table range partitioned by month on a timestamp column, so aged data can be dropped a
+// partition at a time instead of deleted and vacuumed row by row.
+type PartitionedTable struct {
+	Name   string
+	Column string
+	// Model is used to recreate the gorm managed indexes once an existing table has been partitioned.
+	Model interface{}
+	// Dependents reference rows of the table by ID alone. Postgres can't enforce a foreign key against a
+	// partitioned table without the partition column, so these rows are deleted when a partition is pruned.
+	Dependents []PartitionDependent
+}
+
+type PartitionDependent struct {
+	Table  string
+	Column string
+	// Dependents reference rows of this dependent, and are deleted with it.
+	Dependents []PartitionDependent
+}
+
+var PartitionedTables = []PartitionedTable{
+	{
+		Name:   "prow_job_runs",
+		Column: "timestamp",
+		Model:  &models.ProwJobRun{},
+		Dependents: []PartitionDependent{
+			{Table: "prow_job_run_debug_artifacts", Column: "prow_job_run_id"},
+			{Table: "prow_job_run_disruptions", Column: "prow_job_run_id"},
+			{Table: "prow_job_run_fingerprints", Column: "prow_job_run_id"},
+			{Table: "prow_job_run_prow_pull_requests", Column: "prow_job_run_id"},
+			{Table: "prow_job_run_steps", Column: "prow_job_run_id"},
+			// Test results are partitioned by when they were loaded, not when their run ran, so a backfilled run's
+			// results are in a newer partition than the run and must be deleted with it.
+			{
+				Table:      "prow_job_run_tests",
+				Column:     "prow_job_run_id",
+				Dependents: prowJobRunTestDependents,
+			},
+		},
+	},
+	{
+		Name:       "prow_job_run_tests",
+		Column:     "created_at",
+		Model:      &models.ProwJobRunTest{},
+		Dependents: prowJobRunTestDependents,
+	},
+}
+
+var prowJobRunTestDependents = []PartitionDependent{
+	{Table: "prow_job_run_test_outputs", Column: "prow_job_run_test_id"},
+}
+
+var partitionNameRegexp = regexp.MustCompile(`_p(\d{6})$`)
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func partitionName(table string, month time.Time) string {
+	return fmt.Sprintf("%s_p%s", table, month.Format("200601"))
+}
+
+func defaultPartitionName(table string) string {
+	return table + "_default"
+}
+
+// partitionMonth returns the month held by a partition of the table, false if it is not a monthly partition.
+func partitionMonth(table, name string) (time.Time, bool) {
+	m := partitionNameRegexp.FindStringSubmatch(name)
+	if m == nil || name != table+m[0] {
+		return time.Time{}, false
+	}
+	month, err := time.Parse("200601", m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// partitionMonths returns the start of each month from the one containing from through the one containing to.
+func partitionMonths(from, to time.Time) []time.Time {
+	months := []time.Time{}
+	for m := monthStart(from); !m.After(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, m)
+	}
+	return months
+}
+
+// expiredPartitions returns the months whose partitions hold only data older than the retention. A zero
+// retention keeps everything.
+func expiredPartitions(months []time.Time, now time.Time, retention time.Duration) []time.Time {
+	expired := []time.Time{}
+	if retention <= 0 {
+		return expired
+	}
+	cutoff := now.Add(-retention)
+	for _, m := range months {
+		if !m.AddDate(0, 1, 0).After(cutoff) {
+			expired = append(expired, m)
+		}
+	}
+	return expired
+}
+
+func partitionBound(t time.Time) string {
+	return "'" + t.Format("2006-01-02 15:04:05Z07:00") + "'"
+}
+
+func (d *DB) isPartitioned(table string) (bool, error) {
+	var relkind string
+	res := d.DB.Raw("SELECT relkind FROM pg_class WHERE oid = to_regclass(?)", table).Scan(&relkind)
+	return relkind == "p", res.Error
+}
+
+// existingPartitionMonths returns the months that already have a partition of the table.
+func (d *DB) existingPartitionMonths(table string) ([]time.Time, error) {
+	names := []string{}
+	res := d.DB.Raw(`SELECT child.relname FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.oid = to_regclass(?)`, table).Scan(&names)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	months := []time.Time{}
+	for _, name := range names {
+		if month, ok := partitionMonth(table, name); ok {
+			months = append(months, month)
+		}
+	}
+	return months, nil
+}
+
+// createPartition adds the month's partition to the table, moving over any of its rows that had landed in the
+// default partition.
+func createPartition(tx *gorm.DB, pt PartitionedTable, month time.Time) error {
+	name := partitionName(pt.Name, month)
+	from, to := partitionBound(month), partitionBound(month.AddDate(0, 1, 0))
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS)", name, pt.Name),
+		fmt.Sprintf(`WITH moved AS (DELETE FROM %s WHERE %q >= %s AND %q < %s RETURNING *) INSERT INTO %s SELECT * FROM moved`,
+			defaultPartitionName(pt.Name), pt.Column, from, pt.Column, to, name),
+		fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (%s) TO (%s)", pt.Name, name, from, to),
+	}
+	for _, stmt := range stmts {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropPartition removes the month's partition from the table along with the rows of its dependents.
+func dropPartition(tx *gorm.DB, pt PartitionedTable, month time.Time) error {
+	name := partitionName(pt.Name, month)
+	for _, stmt := range dependentDeletes(pt.Dependents, fmt.Sprintf("SELECT id FROM %s", name)) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Exec(fmt.Sprintf("DROP TABLE %s", name)).Error
+}
+
+// dependentDeletes returns the statements deleting the dependents of the rows whose IDs are selected by ids,
+// their own dependents first.
+func dependentDeletes(deps []PartitionDependent, ids string) []string {
+	stmts := []string{}
+	for _, dep := range deps {
+		stmts = append(stmts, dependentDeletes(dep.Dependents,
+			fmt.Sprintf("SELECT id FROM %s WHERE %s IN (%s)", dep.Table, dep.Column, ids))...)
+		stmts = append(stmts, fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", dep.Table, dep.Column, ids))
+	}
+	return stmts
+}
+
+// SyncPartitions creates the partitions needed for the current and upcoming months, and drops those older than
+// the DB's PartitionRetention. Tables that have not yet been partitioned by UpdateSchema are skipped.
+func (d *DB) SyncPartitions(now time.Time) error {
+	for _, pt := range PartitionedTables {
+		tlog := log.WithField("table", pt.Name)
+		partitioned, err := d.isPartitioned(pt.Name)
+		if err != nil {
+			return err
+		}
+		if !partitioned {
+			tlog.Warning("table is not partitioned, run sippy load with --init-database to partition it")
+			continue
+		}
+
+		existing, err := d.existingPartitionMonths(pt.Name)
+		if err != nil {
+			return err
+		}
+		have := map[string]bool{}
+		for _, m := range existing {
+			have[partitionName(pt.Name, m)] = true
+		}
+
+		for _, month := range partitionMonths(now, monthStart(now).AddDate(0, partitionMonthsAhead, 0)) {
+			if have[partitionName(pt.Name, month)] {
+				continue
+			}
+			if err := d.DB.Transaction(func(tx *gorm.DB) error { return createPartition(tx, pt, month) }); err != nil {
+				return fmt.Errorf("error creating partition %s: %w", partitionName(pt.Name, month), err)
+			}
+			tlog.Infof("created partition %s", partitionName(pt.Name, month))
+		}
+
+		for _, month := range expiredPartitions(existing, now, d.PartitionRetention) {
+			if err := d.DB.Transaction(func(tx *gorm.DB) error { return dropPartition(tx, pt, month) }); err != nil {
+				return fmt.Errorf("error dropping partition %s: %w", partitionName(pt.Name, month), err)
+			}
+			tlog.Infof("dropped expired partition %s", partitionName(pt.Name, month))
+		}
+	}
+	return nil
+}
+
+// partitionTables converts any of the PartitionedTables that are still regular tables, copying their rows into
+// monthly partitions. This rewrites the whole table and may take a long time on a large database.
+func (d *DB) partitionTables(now time.Time) error {
+	converted := false
+	for _, pt := range PartitionedTables {
+		partitioned, err := d.isPartitioned(pt.Name)
+		if err != nil {
+			return err
+		}
+		if partitioned {
+			continue
+		}
+
+		start := time.Now()
+		log.WithField("table", pt.Name).Info("partitioning table, this may take some time")
+		if err := d.DB.Transaction(func(tx *gorm.DB) error { return partitionTable(tx, pt, now) }); err != nil {
+			return fmt.Errorf("error partitioning %s: %w", pt.Name, err)
+		}
+		// Indexes are not copied to the partitioned table, let gorm recreate them.
+		if err := d.DB.AutoMigrate(pt.Model); err != nil {
+			return err
+		}
+		log.WithField("table", pt.Name).WithField("duration", time.Since(start)).Info("partitioned table")
+		converted = true
+	}
+
+	if converted {
+		// Views built on the old tables were dropped with them, clear their hashes so they are recreated.
+		res := d.DB.Where("type IN ?", []SchemaHashType{hashTypeMatView, hashTypeMatViewIndex, hashTypeView}).
+			Delete(&models.SchemaHash{})
+		return res.Error
+	}
+	return nil
+}
+
+func partitionTable(tx *gorm.DB, pt PartitionedTable, now time.Time) error {
+	old := pt.Name + "_unpartitioned"
+
+	columns := []string{}
+	if err := tx.Raw(`SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position`, pt.Name).
+		Scan(&columns).Error; err != nil {
+		return err
+	}
+	// The partition column becomes part of the primary key, rows without a value go to the default partition.
+	selects := make([]string, len(columns))
+	for i, c := range columns {
+		columns[i] = fmt.Sprintf("%q", c)
+		selects[i] = columns[i]
+		if c == pt.Column {
+			selects[i] = fmt.Sprintf("COALESCE(%q, 'epoch')", c)
+		}
+	}
+
+	var first sql.NullTime
+	if err := tx.Raw(fmt.Sprintf("SELECT MIN(%q) FROM %s", pt.Column, pt.Name)).Scan(&first).Error; err != nil {
+		return err
+	}
+	if !first.Valid {
+		first.Time = now
+	}
+
+	stmts := []string{
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", pt.Name, old),
+		fmt.Sprintf("ALTER INDEX IF EXISTS %s_pkey RENAME TO %s_pkey", pt.Name, old),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS) PARTITION BY RANGE (%q)", pt.Name, old, pt.Column),
+		fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (id, %q)", pt.Name, pt.Column),
+		fmt.Sprintf("ALTER SEQUENCE IF EXISTS %s_id_seq OWNED BY %s.id", pt.Name, pt.Name),
+		fmt.Sprintf("CREATE TABLE %s PARTITION OF %s DEFAULT", defaultPartitionName(pt.Name), pt.Name),
+	}
+	for _, month := range partitionMonths(first.Time, monthStart(now).AddDate(0, partitionMonthsAhead, 0)) {
+		stmts = append(stmts, fmt.Sprintf("CREATE TABLE %s PARTITION OF %s FOR VALUES FROM (%s) TO (%s)",
+			partitionName(pt.Name, month), pt.Name, partitionBound(month), partitionBound(month.AddDate(0, 1, 0))))
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+			pt.Name, strings.Join(columns, ", "), strings.Join(selects, ", "), old),
+		// Drops the foreign keys referencing the old table, and any views built on it.
+		fmt.Sprintf("DROP TABLE %s CASCADE", old),
+	)
+
+	for _, stmt := range stmts {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
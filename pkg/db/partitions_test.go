@@ -0,0 +1,126 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionMonths(t *testing.T) {
+	from := time.Date(2023, 11, 17, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	names := []string{}
+	for _, m := range partitionMonths(from, to) {
+		names = append(names, partitionName("prow_job_run_tests", m))
+	}
+	assert.Equal(t, []string{
+		"prow_job_run_tests_p202311",
+		"prow_job_run_tests_p202312",
+		"prow_job_run_tests_p202401",
+		"prow_job_run_tests_p202402",
+	}, names)
+}
+
+func TestPartitionMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		wantMonth time.Time
+		wantOK    bool
+	}{
+		{
+			name:      "monthly partition",
+			partition: "prow_job_runs_p202403",
+			wantMonth: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantOK:    true,
+		},
+		{
+			name:      "default partition",
+			partition: "prow_job_runs_default",
+		},
+		{
+			name:      "partition of another table",
+			partition: "prow_job_run_tests_p202403",
+		},
+		{
+			name:      "invalid month",
+			partition: "prow_job_runs_p202413",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			month, ok := partitionMonth("prow_job_runs", tt.partition)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.wantMonth.Equal(month), "got month %s", month)
+		})
+	}
+}
+
+func TestExpiredPartitions(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	months := partitionMonths(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), now)
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      []string
+	}{
+		{
+			name: "zero retention keeps everything",
+			want: []string{},
+		},
+		{
+			name:      "partitions entirely before the cutoff are expired",
+			retention: 90 * 24 * time.Hour,
+			want:      []string{"t_p202401", "t_p202402"},
+		},
+		{
+			name:      "partition ending exactly at the cutoff is expired",
+			retention: now.Sub(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			want:      []string{"t_p202401"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{}
+			for _, m := range expiredPartitions(months, now, tt.retention) {
+				names = append(names, partitionName("t", m))
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestDependentDeletes(t *testing.T) {
+	deps := []PartitionDependent{
+		{Table: "prow_job_run_steps", Column: "prow_job_run_id"},
+		{
+			Table:      "prow_job_run_tests",
+			Column:     "prow_job_run_id",
+			Dependents: []PartitionDependent{{Table: "prow_job_run_test_outputs", Column: "prow_job_run_test_id"}},
+		},
+	}
+	assert.Equal(t, []string{
+		"DELETE FROM prow_job_run_steps WHERE prow_job_run_id IN (SELECT id FROM prow_job_runs_p202401)",
+		"DELETE FROM prow_job_run_test_outputs WHERE prow_job_run_test_id IN " +
+			"(SELECT id FROM prow_job_run_tests WHERE prow_job_run_id IN (SELECT id FROM prow_job_runs_p202401))",
+		"DELETE FROM prow_job_run_tests WHERE prow_job_run_id IN (SELECT id FROM prow_job_runs_p202401)",
+	}, dependentDeletes(deps, "SELECT id FROM prow_job_runs_p202401"))
+}
+
+func TestProwJobRunPartitionDeletesTests(t *testing.T) {
+	for _, pt := range PartitionedTables {
+		if pt.Name != "prow_job_runs" {
+			continue
+		}
+		for _, dep := range pt.Dependents {
+			if dep.Table == "prow_job_run_tests" {
+				assert.Equal(t, "prow_job_run_id", dep.Column)
+				return
+			}
+		}
+	}
+	t.Fatal("prow_job_run_tests rows are not deleted with their run's partition")
+}
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// JobArtifactSizes returns the artifact sizes of each job in the release in the current period, between boundary
+// and end, and the previous period, between start and boundary. Runs whose artifacts were not measured are
+// ignored.
+func JobArtifactSizes(dbc *db.DB, release string, start, boundary, end time.Time) ([]api.JobArtifactSize, error) {
+	results := []api.JobArtifactSize{}
+	res := dbc.DB.Raw(`
+		SELECT
+			prow_jobs.name AS prow_job_name,
+			prow_jobs.release,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp >= @boundary) AS current_runs,
+			COALESCE(SUM(prow_job_runs.artifact_bytes) FILTER (WHERE prow_job_runs.timestamp >= @boundary), 0) AS current_total_bytes,
+			COALESCE(AVG(prow_job_runs.artifact_bytes) FILTER (WHERE prow_job_runs.timestamp >= @boundary), 0) AS current_mean_bytes,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp < @boundary) AS previous_runs,
+			COALESCE(AVG(prow_job_runs.artifact_bytes) FILTER (WHERE prow_job_runs.timestamp < @boundary), 0) AS previous_mean_bytes
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.artifact_bytes > 0
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+		GROUP BY prow_jobs.name, prow_jobs.release`,
+		map[string]interface{}{
+			"start":    start,
+			"boundary": boundary,
+			"end":      end,
+			"release":  release,
+		}).Scan(&results)
+	return results, res.Error
+}
+
+// ArtifactSizeTrend returns the daily artifact sizes of the release's job runs between start and end, optionally
+// for only one job.
+func ArtifactSizeTrend(dbc *db.DB, release, job string, start, end time.Time) ([]api.ArtifactSizeDay, error) {
+	results := []api.ArtifactSizeDay{}
+	res := dbc.DB.Raw(`
+		SELECT
+			DATE(prow_job_runs.timestamp AT TIME ZONE 'UTC') AS date,
+			COUNT(*) AS runs,
+			SUM(prow_job_runs.artifact_bytes) AS total_bytes,
+			AVG(prow_job_runs.artifact_bytes) AS mean_bytes
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.artifact_bytes > 0
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+			AND (@job = '' OR prow_jobs.name = @job)
+		GROUP BY 1
+		ORDER BY 1`,
+		map[string]interface{}{
+			"start":   start,
+			"end":     end,
+			"release": release,
+			"job":     job,
+		}).Scan(&results)
+	return results, res.Error
+}
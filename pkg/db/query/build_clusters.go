@@ -53,9 +53,9 @@ SELECT
     cluster,
     date_trunc('%s', timestamp) as period,
     count(*) AS total_runs,
-    sum(case when overall_result = 'S' then 1 else 0 end) AS passes,
-    sum(case when overall_result != 'S' then 1 else 0 end) AS failures,
-    sum(case when overall_result = 'S' then 1 else 0 end) * 100.0 / count(*) AS pass_percentage
+    sum(case when overall_result IN ('S', 'L') then 1 else 0 end) AS passes,
+    sum(case when overall_result NOT IN ('S', 'L') then 1 else 0 end) AS failures,
+    sum(case when overall_result IN ('S', 'L') then 1 else 0 end) * 100.0 / count(*) AS pass_percentage
 FROM
     prow_job_runs
 JOIN
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// DisruptionPercentileLookbackDays is the lookback window of the disruption percentiles we compare, matching the
+// BigQuery delta views.
+const DisruptionPercentileLookbackDays = 3
+
+// DisruptionVsTwoWeeksAgo compares the most recent disruption percentiles loaded for each backend and NURP with
+// those from two weeks earlier. The percentiles in the returned rows are the deltas. Relevance scores how many job
+// runs back the comparison, on the same scale as the BigQuery delta views, so alerts can ignore rarely run NURPs.
+func DisruptionVsTwoWeeksAgo(dbc *db.DB) ([]api.DisruptionReportRow, error) {
+	rows := []api.DisruptionReportRow{}
+	res := dbc.DB.Raw(`
+		WITH latest AS (
+			SELECT MAX(date) AS date FROM disruption_percentiles WHERE lookback_days = @lookback AND deleted_at IS NULL
+		)
+		SELECT
+			cur.release, cur.backend_name, cur.platform, cur.upgrade_type, cur.master_nodes_updated, cur.network,
+			cur.topology, cur.architecture,
+			cur.p50 - prev.p50 AS p50,
+			cur.p75 - prev.p75 AS p75,
+			cur.p95 - prev.p95 AS p95,
+			cur.percentage_above_zero - prev.percentage_above_zero AS percentage_above_zero_delta,
+			CASE
+				WHEN LEAST(cur.job_runs, prev.job_runs) > 100 THEN 10
+				WHEN LEAST(cur.job_runs, prev.job_runs) > 50 THEN 5
+				WHEN LEAST(cur.job_runs, prev.job_runs) > 10 THEN 1
+				ELSE 0
+			END AS relevance
+		FROM disruption_percentiles cur
+		JOIN latest ON cur.date = latest.date
+		JOIN disruption_percentiles prev ON prev.date = cur.date - 14
+			AND prev.lookback_days = cur.lookback_days
+			AND prev.release = cur.release
+			AND prev.from_release = cur.from_release
+			AND prev.backend_name = cur.backend_name
+			AND prev.platform = cur.platform
+			AND prev.upgrade_type = cur.upgrade_type
+			AND prev.master_nodes_updated = cur.master_nodes_updated
+			AND prev.network = cur.network
+			AND prev.topology = cur.topology
+			AND prev.architecture = cur.architecture
+			AND prev.deleted_at IS NULL
+		WHERE cur.lookback_days = @lookback AND cur.deleted_at IS NULL`,
+		map[string]interface{}{"lookback": DisruptionPercentileLookbackDays}).Scan(&rows)
+	return rows, res.Error
+}
+
+// DisruptionPercentiles returns the disruption percentiles loaded for a release since start, optionally only for
+// one backend, ordered by date.
+func DisruptionPercentiles(dbc *db.DB, release, backend string, start time.Time) ([]models.DisruptionPercentile, error) {
+	results := []models.DisruptionPercentile{}
+	q := dbc.DB.Where("release = ?", release).
+		Where("lookback_days = ?", DisruptionPercentileLookbackDays).
+		Where("date >= ?", start)
+	if backend != "" {
+		q = q.Where("backend_name = ?", backend)
+	}
+	res := q.Order("date, backend_name, platform, network, topology, architecture").Find(&results)
+	return results, res.Error
+}
+
+// WorstDisruptionJobRuns returns, for each backend, the limit job runs since start that were disrupted the longest,
+// optionally only for one release or backend.
+func WorstDisruptionJobRuns(dbc *db.DB, release, backend string, start, end time.Time, limit int) ([]api.DisruptionJobRun, error) {
+	runs := []api.DisruptionJobRun{}
+	res := dbc.DB.Raw(`
+		SELECT * FROM (
+			SELECT
+				prow_job_run_disruptions.backend_name,
+				prow_job_runs.id AS prow_job_run_id,
+				prow_jobs.name AS prow_job_name,
+				prow_jobs.release,
+				prow_jobs.variants,
+				prow_job_runs.url,
+				prow_job_runs.timestamp,
+				prow_job_run_disruptions.disruption_seconds,
+				ROW_NUMBER() OVER (
+					PARTITION BY prow_job_run_disruptions.backend_name
+					ORDER BY prow_job_run_disruptions.disruption_seconds DESC
+				) AS rank
+			FROM prow_job_run_disruptions
+			JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_disruptions.prow_job_run_id
+			JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+			WHERE prow_job_run_disruptions.deleted_at IS NULL
+				AND prow_job_runs.timestamp BETWEEN @start AND @end
+				AND (@release = '' OR prow_jobs.release = @release)
+				AND (@backend = '' OR prow_job_run_disruptions.backend_name = @backend)
+		) ranked
+		WHERE rank <= @limit
+		ORDER BY backend_name, disruption_seconds DESC`,
+		map[string]interface{}{
+			"start":   start,
+			"end":     end,
+			"release": release,
+			"backend": backend,
+			"limit":   limit,
+		}).Scan(&runs)
+	return runs, res.Error
+}
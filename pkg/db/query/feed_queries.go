@@ -0,0 +1,53 @@
+package query
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// FiredAlerts returns the evaluations since the given time that found an alert rule firing when it was not on the
+// previous evaluation, newest first, optionally limited to a release.
+func FiredAlerts(dbc *db.DB, release string, since time.Time) ([]models.AlertResult, error) {
+	results := make([]models.AlertResult, 0)
+	res := dbc.DB.Raw(`
+		SELECT id, created_at, updated_at, rule, release, variant, metric, operator, threshold, value, runs, firing, evaluated_at
+		FROM (
+			SELECT *, LAG(firing) OVER (PARTITION BY rule ORDER BY evaluated_at) AS previously_firing
+			FROM alert_results
+			WHERE deleted_at IS NULL
+		) results
+		WHERE firing AND NOT COALESCE(previously_firing, false)
+			AND evaluated_at >= @since
+			AND (@release = '' OR release = @release)
+		ORDER BY evaluated_at DESC`,
+		map[string]interface{}{"since": since, "release": release}).Scan(&results)
+	return results, res.Error
+}
+
+// RejectedPayloads returns the payloads rejected since the given time, newest first, optionally limited to a release.
+func RejectedPayloads(dbc *db.DB, release string, since time.Time) ([]models.ReleaseTag, error) {
+	results := make([]models.ReleaseTag, 0)
+	q := dbc.DB.Where("phase = ? AND release_time >= ?", api.PayloadRejected, since)
+	if release != "" {
+		q = q.Where("release = ?", release)
+	}
+	res := q.Order("release_time DESC").Find(&results)
+	return results, res.Error
+}
+
+// OpenedDisruptionRegressions returns the disruption regressions that reached minDays consecutive regressed days
+// since the given time and are still regressed, optionally limited to a release. Regressions that have cleared since
+// are not returned, their state only records the current run of regressed days.
+func OpenedDisruptionRegressions(dbc *db.DB, release string, minDays int, since time.Time) ([]models.DisruptionRegressionState, error) {
+	results := make([]models.DisruptionRegressionState, 0)
+	q := dbc.DB.Where("regressed = ? AND consecutive_bad_days >= ?", true, minDays).
+		Where("first_bad_date + make_interval(days => ?) >= ?", minDays-1, since)
+	if release != "" {
+		q = q.Where("release = ?", release)
+	}
+	res := q.Order("first_bad_date DESC").Find(&results)
+	return results, res.Error
+}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// FingerprintValueCount is how often the runs of a job with a value of an environment fingerprint ran and failed.
+type FingerprintValueCount struct {
+	JobName     string
+	Fingerprint string
+	Value       string
+	FirstSeen   time.Time
+	Runs        int
+	Failures    int
+}
+
+// FingerprintValuesByJob counts the runs and failures of each job in release between start and end by the value
+// of each of their environment fingerprints.
+func FingerprintValuesByJob(dbc *db.DB, release string, start, end time.Time) ([]FingerprintValueCount, error) {
+	results := make([]FingerprintValueCount, 0)
+	res := dbc.DB.Raw(`
+		SELECT
+			prow_jobs.name AS job_name,
+			prow_job_run_fingerprints.name AS fingerprint,
+			prow_job_run_fingerprints.value,
+			MIN(prow_job_runs.timestamp) AS first_seen,
+			COUNT(*) AS runs,
+			COUNT(*) FILTER (WHERE NOT prow_job_runs.succeeded) AS failures
+		FROM prow_job_run_fingerprints
+		JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_fingerprints.prow_job_run_id
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_run_fingerprints.deleted_at IS NULL AND prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+		GROUP BY prow_jobs.name, prow_job_run_fingerprints.name, prow_job_run_fingerprints.value`,
+		map[string]interface{}{"release": release, "start": start, "end": end}).Scan(&results)
+	return results, res.Error
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/testidentification"
+)
+
+// InstallOperatorFailure is a cluster operator that failed to install in a job run, with one of the conditions
+// extracted from its operator conditions test output. Condition and Reason are empty if none were extracted.
+type InstallOperatorFailure struct {
+	ProwJobRunID uint
+	Operator     string
+	Variants     pq.StringArray `gorm:"type:text[]"`
+	Condition    string
+	Reason       string
+}
+
+// InstallOperatorFailures returns the failed operator install synthetic tests of the release's job runs between
+// start and end, one row per condition reported by the operator in that run.
+func InstallOperatorFailures(dbc *db.DB, release string, start, end time.Time) ([]InstallOperatorFailure, error) {
+	results := make([]InstallOperatorFailure, 0)
+	res := dbc.DB.Raw(`
+		SELECT prow_job_runs.id AS prow_job_run_id,
+			substring(tests.name FROM char_length(@prefix) + 1) AS operator,
+			prow_jobs.variants,
+			COALESCE(conditions.metadata->>'condition', '') AS condition,
+			COALESCE(conditions.metadata->>'reason', '') AS reason
+		FROM prow_job_run_tests
+		JOIN tests ON tests.id = prow_job_run_tests.test_id
+		JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_tests.prow_job_run_id
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		LEFT JOIN LATERAL (
+			SELECT prow_job_run_test_output_metadata.metadata
+			FROM prow_job_run_tests condition_tests
+			JOIN tests condition_test_names ON condition_test_names.id = condition_tests.test_id
+			JOIN prow_job_run_test_outputs ON prow_job_run_test_outputs.prow_job_run_test_id = condition_tests.id
+			JOIN prow_job_run_test_output_metadata ON prow_job_run_test_output_metadata.prow_job_run_test_output_id = prow_job_run_test_outputs.id
+			WHERE condition_tests.prow_job_run_id = prow_job_run_tests.prow_job_run_id
+				AND condition_test_names.name LIKE '%operator conditions ' || substring(tests.name FROM char_length(@prefix) + 1)
+		) conditions ON true
+		WHERE tests.name LIKE @prefix || '%'
+			AND prow_job_run_tests.status = 12
+			AND prow_jobs.release = @release
+			AND prow_job_runs.timestamp BETWEEN @start AND @end`,
+		map[string]interface{}{
+			"prefix":  testidentification.OperatorInstallPrefix,
+			"release": release,
+			"start":   start,
+			"end":     end,
+		}).Scan(&results)
+	return results, res.Error
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/filter"
@@ -96,6 +97,73 @@ func JobReports(dbc *db.DB, filterOpts *filter.FilterOptions, release string, st
 	return jobReports, nil
 }
 
+// JobDurationStats returns run duration percentiles for each job in the release, split into the periods
+// start->boundary and boundary->end. Runs without a recorded duration are ignored.
+func JobDurationStats(dbc *db.DB, release string, start, boundary, end time.Time) ([]apitype.JobDurationStats, error) {
+	stats := make([]apitype.JobDurationStats, 0)
+	// durations are stored as nanoseconds
+	res := dbc.DB.Raw(`
+		SELECT prow_jobs.name,
+			COUNT(*) FILTER (WHERE timestamp >= @boundary) AS current_runs,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY duration) FILTER (WHERE timestamp >= @boundary), 0) / 1e9 AS current_duration_p50,
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY duration) FILTER (WHERE timestamp >= @boundary), 0) / 1e9 AS current_duration_p90,
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY duration) FILTER (WHERE timestamp >= @boundary), 0) / 1e9 AS current_duration_p95,
+			COUNT(*) FILTER (WHERE timestamp < @boundary) AS previous_runs,
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY duration) FILTER (WHERE timestamp < @boundary), 0) / 1e9 AS previous_duration_p50,
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY duration) FILTER (WHERE timestamp < @boundary), 0) / 1e9 AS previous_duration_p90
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_jobs.release = @release
+			AND prow_job_runs.timestamp BETWEEN @start AND @end
+			AND prow_job_runs.duration > 0
+		GROUP BY prow_jobs.name`,
+		sql.Named("release", release),
+		sql.Named("start", start),
+		sql.Named("boundary", boundary),
+		sql.Named("end", end)).Scan(&stats)
+	return stats, res.Error
+}
+
+// JobFlakyRuns counts the runs of each job in the release that succeeded with test flakes, split into the
+// periods start->boundary and boundary->end.
+func JobFlakyRuns(dbc *db.DB, release string, start, boundary, end time.Time) ([]apitype.JobFlakyRuns, error) {
+	results := make([]apitype.JobFlakyRuns, 0)
+	res := dbc.DB.Raw(`
+		SELECT prow_jobs.name,
+			COUNT(*) FILTER (WHERE timestamp >= @boundary) AS current_flaky_runs,
+			COUNT(*) FILTER (WHERE timestamp < @boundary) AS previous_flaky_runs
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_jobs.release = @release
+			AND prow_job_runs.timestamp BETWEEN @start AND @end
+			AND prow_job_runs.overall_result = @result
+		GROUP BY prow_jobs.name`,
+		sql.Named("release", release),
+		sql.Named("start", start),
+		sql.Named("boundary", boundary),
+		sql.Named("end", end),
+		sql.Named("result", string(v1.JobSucceededWithFlakes))).Scan(&results)
+	return results, res.Error
+}
+
+// JobLineage returns every job in the same lineage as the named job, across all releases.
+func JobLineage(dbc *db.DB, jobName string) ([]apitype.JobLineageEntry, error) {
+	results := make([]apitype.JobLineageEntry, 0)
+	lineage := dbc.DB.Table("prow_jobs").Select("lineage").Where("name = ?", jobName)
+	res := dbc.DB.Table("prow_jobs").
+		Joins("LEFT JOIN prow_job_runs ON prow_job_runs.prow_job_id = prow_jobs.id").
+		Where("prow_jobs.lineage = (?)", lineage).
+		Select(`prow_jobs.id, prow_jobs.name, prow_jobs.release, prow_jobs.lineage,
+			COUNT(prow_job_runs.id) AS runs,
+			COUNT(prow_job_runs.id) FILTER (WHERE prow_job_runs.succeeded) AS passes,
+			COALESCE(COUNT(prow_job_runs.id) FILTER (WHERE prow_job_runs.succeeded) * 100.0 / NULLIF(COUNT(prow_job_runs.id), 0), 0) AS pass_percentage,
+			MIN(prow_job_runs.timestamp) AS first_run,
+			MAX(prow_job_runs.timestamp) AS last_run`).
+		Group("prow_jobs.id, prow_jobs.name, prow_jobs.release, prow_jobs.lineage").
+		Scan(&results)
+	return results, res.Error
+}
+
 func VariantReports(dbc *db.DB, release string, start, boundary, end time.Time) ([]apitype.Variant, error) {
 	variantResults := make([]apitype.Variant, 0)
 	q := dbc.DB.Raw(`
@@ -170,3 +238,61 @@ func LoadBugsForJobs(dbc *db.DB,
 	log.Infof("found %d bugs for job", len(job.Bugs))
 	return job.Bugs, nil
 }
+
+// BugImpactJobRuns returns the job runs between start and end that failed a test linked to the bug, newest first.
+func BugImpactJobRuns(dbc *db.DB, bugID uint, release string, start, end time.Time) ([]apitype.BugImpactJobRun, error) {
+	results := make([]apitype.BugImpactJobRun, 0)
+	q := dbc.DB.Table("prow_job_run_tests").
+		Joins("JOIN bug_tests ON bug_tests.test_id = prow_job_run_tests.test_id").
+		Joins("JOIN tests ON tests.id = prow_job_run_tests.test_id").
+		Joins("JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_tests.prow_job_run_id").
+		Joins("JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id").
+		Where("bug_tests.bug_id = ?", bugID).
+		Where("prow_job_run_tests.status = ?", int(v1.TestStatusFailure)).
+		Where("prow_job_runs.timestamp BETWEEN ? AND ?", start, end)
+	if release != "" {
+		q = q.Where("prow_jobs.release = ?", release)
+	}
+	res := q.Select(`
+			prow_job_runs.id AS prow_job_run_id,
+			prow_jobs.name AS prow_job_name,
+			prow_jobs.release,
+			prow_jobs.variants,
+			prow_job_runs.url,
+			prow_job_runs.timestamp,
+			ARRAY_AGG(DISTINCT tests.name) AS failed_tests`).
+		Group("prow_job_runs.id, prow_jobs.name, prow_jobs.release, prow_jobs.variants").
+		Order("prow_job_runs.timestamp DESC").
+		Scan(&results)
+	return results, res.Error
+}
+
+// CloudLocationResults counts the runs and failures of the release's job runs by the cloud region their clusters
+// were installed in, and zone if byZone, in the current period, between boundary and end, and the previous period,
+// between start and boundary. Runs without a region are ignored.
+func CloudLocationResults(dbc *db.DB, release string, byZone bool, start, boundary, end time.Time) ([]apitype.CloudLocationHealth, error) {
+	results := []apitype.CloudLocationHealth{}
+	res := dbc.DB.Raw(`
+		SELECT
+			prow_job_runs.cloud_region AS region,
+			CASE WHEN @byZone THEN prow_job_runs.cloud_zone ELSE '' END AS zone,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp >= @boundary) AS current_runs,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp >= @boundary AND NOT prow_job_runs.succeeded) AS current_failures,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp < @boundary) AS previous_runs,
+			COUNT(*) FILTER (WHERE prow_job_runs.timestamp < @boundary AND NOT prow_job_runs.succeeded) AS previous_failures
+		FROM prow_job_runs
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.cloud_region IS NOT NULL AND prow_job_runs.cloud_region != ''
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+		GROUP BY 1, 2`,
+		map[string]interface{}{
+			"start":    start,
+			"boundary": boundary,
+			"end":      end,
+			"release":  release,
+			"byZone":   byZone,
+		}).Scan(&results)
+	return results, res.Error
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/testidentification"
 	"github.com/openshift/sippy/pkg/util/sets"
 )
@@ -53,3 +54,101 @@ func PlatformInfraSuccess(dbc *db.DB, platforms sets.String, period string) (map
 	}).Info("PlatformInfraSuccess completed")
 	return results, q.Error
 }
+
+// ListMatViewRefreshes returns the most recent materialized view refreshes, newest first, optionally
+// limited to a single view.
+func ListMatViewRefreshes(dbc *db.DB, name string, limit int) ([]models.MatViewRefresh, error) {
+	results := make([]models.MatViewRefresh, 0)
+	q := dbc.DB.Order("started_at DESC")
+	if name != "" {
+		q = q.Where("name = ?", name)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	res := q.Find(&results)
+	return results, res.Error
+}
+
+// LastMatViewRefresh returns when the most recent successful materialized view refresh ended, nil if none has.
+func LastMatViewRefresh(dbc *db.DB) (*time.Time, error) {
+	var last *time.Time
+	res := dbc.DB.Model(&models.MatViewRefresh{}).
+		Where("status = ?", models.MatViewRefreshSucceeded).
+		Select("MAX(ended_at)").Scan(&last)
+	return last, res.Error
+}
+
+// ListLoadEvents returns the most recent data loads, newest first, optionally limited to those that loaded
+// release. Loads of all releases are always included.
+func ListLoadEvents(dbc *db.DB, release string, limit int) ([]models.LoadEvent, error) {
+	results := make([]models.LoadEvent, 0)
+	q := dbc.DB.Order("started_at DESC")
+	if release != "" {
+		q = q.Where("(? = ANY(releases) OR cardinality(releases) = 0 OR releases IS NULL)", release)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	res := q.Find(&results)
+	return results, res.Error
+}
+
+// LatestAlertResults returns the most recent evaluation of each alert rule.
+func LatestAlertResults(dbc *db.DB) ([]models.AlertResult, error) {
+	results := make([]models.AlertResult, 0)
+	res := dbc.DB.Raw(`SELECT DISTINCT ON (rule) * FROM alert_results WHERE deleted_at IS NULL ORDER BY rule, evaluated_at DESC`).
+		Scan(&results)
+	return results, res.Error
+}
+
+// ListAcknowledgements returns the acknowledgements not expired as of now, optionally only those of a kind.
+func ListAcknowledgements(dbc *db.DB, kind string, now time.Time) ([]models.Acknowledgement, error) {
+	results := make([]models.Acknowledgement, 0)
+	q := dbc.DB.Where("expires_at IS NULL OR expires_at > ?", now).Order("created_at DESC")
+	if kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+	res := q.Find(&results)
+	return results, res.Error
+}
+
+// ClearAcknowledgements removes the acknowledgements of an alert or regression once it has cleared, so they don't
+// hide it if it comes back.
+func ClearAcknowledgements(dbc *db.DB, kind, target string) error {
+	return dbc.DB.Where("kind = ? AND target = ?", kind, target).Delete(&models.Acknowledgement{}).Error
+}
+
+// JobRunFailures is how many runs of a set of jobs there were, and how many failed.
+type JobRunFailures struct {
+	Runs     int
+	Failures int
+}
+
+// JobRunFailureCounts counts the runs of the jobs between start and end, and those that did not succeed.
+func JobRunFailureCounts(dbc *db.DB, jobIDs []uint, start, end time.Time) (JobRunFailures, error) {
+	var result JobRunFailures
+	if len(jobIDs) == 0 {
+		return result, nil
+	}
+	res := dbc.DB.Table("prow_job_runs").
+		Select("COUNT(*) AS runs, COUNT(*) FILTER (WHERE NOT succeeded) AS failures").
+		Where("prow_job_id IN ? AND timestamp >= ? AND timestamp < ? AND deleted_at IS NULL", jobIDs, start, end).
+		Scan(&result)
+	return result, res.Error
+}
+
+// VariantTestPassRate returns the runs and pass percentage, counting flakes as passes, for a test in jobs
+// with the given variant.
+func VariantTestPassRate(dbc *db.DB, table, release, variant, testName string) (int, float64, error) {
+	var result struct {
+		Runs           int
+		PassPercentage float64
+	}
+	res := dbc.DB.Table(table).
+		Select(`COALESCE(SUM(current_runs), 0) AS runs,
+			COALESCE(SUM(current_successes + current_flakes) * 100.0 / NULLIF(SUM(current_runs), 0), 0) AS pass_percentage`).
+		Where("release = ? AND name = ? AND ? = ANY(variants)", release, testName, variant).
+		Scan(&result)
+	return result.Runs, result.PassPercentage, res.Error
+}
@@ -5,6 +5,7 @@ import (
 
 	"gorm.io/gorm"
 
+	apitype "github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/db/models"
 )
 
@@ -211,3 +212,122 @@ func GetPayloadAcceptanceStatistics(db *gorm.DB, release, architecture, stream s
 
 	return results, q.Error
 }
+
+// GetPayloadWithChanges returns the payload with the given tag, with the repositories, pull requests and
+// components from its changelog.
+func GetPayloadWithChanges(db *gorm.DB, payloadTag string) (*models.ReleaseTag, error) {
+	tag := &models.ReleaseTag{}
+	res := db.Preload("Repositories").Preload("PullRequests").Preload("Components").
+		Where("release_tag = ?", payloadTag).First(tag)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return tag, nil
+}
+
+// GetPayloadsSincePreviousAccepted returns the payloads built on the same accepted payload as tag but
+// created before it, oldest first, with the pull requests from their changelogs.
+func GetPayloadsSincePreviousAccepted(db *gorm.DB, tag *models.ReleaseTag) ([]models.ReleaseTag, error) {
+	results := make([]models.ReleaseTag, 0)
+	res := db.Preload("PullRequests").
+		Where("previous_release_tag = ? AND architecture = ? AND stream = ? AND release_time < ?",
+			tag.PreviousReleaseTag, tag.Architecture, tag.Stream, tag.ReleaseTime).
+		Order("release_time").Find(&results)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return results, nil
+}
+
+// GetBlockingJobRunCounts returns how many blocking job runs succeeded and failed for payloads of the release
+// between start and end.
+func GetBlockingJobRunCounts(db *gorm.DB, release string, start, end time.Time) (succeeded, failed int, err error) {
+	var counts struct {
+		Succeeded int
+		Failed    int
+	}
+	result := db.Raw(`SELECT
+			COUNT(*) FILTER (WHERE rjr.state = 'Succeeded') AS succeeded,
+			COUNT(*) FILTER (WHERE rjr.state = 'Failed') AS failed
+		FROM release_job_runs rjr
+		JOIN release_tags rt ON rt.id = rjr.release_tag_id
+		WHERE rt.release = ? AND rjr.kind = 'Blocking' AND rt.release_time >= ? AND rt.release_time < ?`,
+		release, start, end).Scan(&counts)
+	return counts.Succeeded, counts.Failed, result.Error
+}
+
+// GetLatestPayloadTag returns the most recent payload of a stream and architecture released before reportEnd, or
+// nil if there is none.
+func GetLatestPayloadTag(db *gorm.DB, release, stream, arch string, reportEnd time.Time) (*models.ReleaseTag, error) {
+	results := []models.ReleaseTag{}
+	result := db.Where("release = ? AND stream = ? AND architecture = ? AND release_time < ?", release, stream, arch, reportEnd).
+		Order("release_time DESC").
+		Limit(1).
+		Find(&results)
+	if result.Error != nil || len(results) == 0 {
+		return nil, result.Error
+	}
+	return &results[0], nil
+}
+
+// BlockingJobRunCounts is how many runs of a blocking job succeeded for a stream's payloads.
+type BlockingJobRunCounts struct {
+	JobName   string
+	Runs      int
+	Succeeded int
+}
+
+// GetBlockingJobRunCountsByJob returns how many runs of each blocking job succeeded for payloads of a stream and
+// architecture released between start and end. Pending runs are not counted.
+func GetBlockingJobRunCountsByJob(db *gorm.DB, release, stream, arch string, start, end time.Time) ([]BlockingJobRunCounts, error) {
+	results := make([]BlockingJobRunCounts, 0)
+	result := db.Raw(`SELECT
+			rjr.job_name,
+			COUNT(*) FILTER (WHERE rjr.state IN ('Succeeded', 'Failed')) AS runs,
+			COUNT(*) FILTER (WHERE rjr.state = 'Succeeded') AS succeeded
+		FROM release_job_runs rjr
+		JOIN release_tags rt ON rt.id = rjr.release_tag_id
+		WHERE rt.release = @release AND rt.stream = @stream AND rt.architecture = @arch
+			AND rjr.kind = 'Blocking' AND rt.release_time >= @start AND rt.release_time < @end
+		GROUP BY rjr.job_name
+		ORDER BY rjr.job_name`,
+		map[string]interface{}{
+			"release": release,
+			"stream":  stream,
+			"arch":    arch,
+			"start":   start,
+			"end":     end,
+		}).Scan(&results)
+	return results, result.Error
+}
+
+// GetPayloadRejectedJobs returns the failed blocking jobs of a payload, with the tests that failed in each of their
+// runs that has been imported.
+func GetPayloadRejectedJobs(db *gorm.DB, payloadTag string) ([]apitype.PayloadRejectedJob, error) {
+	results := make([]apitype.PayloadRejectedJob, 0)
+	result := db.Raw(`SELECT
+		rjr.job_name AS name,
+		rjr.prow_job_run_id,
+		COALESCE(pj.name, '') AS prow_job_name,
+		rjr.url,
+		rjr.retries,
+		COALESCE(ARRAY_AGG(DISTINCT t.name) FILTER (WHERE t.name IS NOT NULL), '{}') AS failed_tests
+	FROM release_tags rt
+	JOIN release_job_runs rjr ON rjr.release_tag_id = rt.id
+	LEFT JOIN prow_job_runs pjr ON pjr.id = rjr.prow_job_run_id
+	LEFT JOIN prow_jobs pj ON pj.id = pjr.prow_job_id
+	LEFT JOIN prow_job_run_tests pjrt ON pjrt.prow_job_run_id = rjr.prow_job_run_id AND pjrt.status = 12
+	LEFT JOIN tests t ON t.id = pjrt.test_id
+	WHERE rt.release_tag = ?
+		AND rjr.kind = 'Blocking'
+		AND rjr.state = 'Failed'
+		AND rjr.deleted_at IS NULL
+	GROUP BY rjr.job_name, rjr.prow_job_run_id, pj.name, rjr.url, rjr.retries
+	ORDER BY rjr.job_name, rjr.prow_job_run_id`, payloadTag).Scan(&results)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return results, nil
+}
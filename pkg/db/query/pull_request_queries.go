@@ -1,6 +1,8 @@
 package query
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -45,8 +47,7 @@ func PullRequestAveragePremergeFailures(dbc *db.DB, start, end *time.Time) *gorm
 		Joins("INNER JOIN prow_job_run_prow_pull_requests on prow_job_run_prow_pull_requests.prow_job_run_id = prow_job_runs.id").
 		Joins("INNER JOIN prow_pull_requests on prow_pull_requests.id = prow_job_run_prow_pull_requests.prow_pull_request_id").
 		Joins("INNER JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id").
-		Where("prow_job_runs.overall_result != 'S'").
-		Where("prow_job_runs.overall_result != 'A'").
+		Where("prow_job_runs.overall_result NOT IN ('S', 'L', 'A')").
 		Where("prow_pull_requests.merged_at IS NOT NULL").
 		Group("prow_jobs.id, prow_jobs.name, prow_pull_requests.org, prow_pull_requests.repo, prow_pull_requests.id, prow_pull_requests.link")
 
@@ -62,3 +63,84 @@ func PullRequestAveragePremergeFailures(dbc *db.DB, start, end *time.Time) *gorm
 		Select("org, repo, prow_job_id, prow_job_name, AVG(total_runs) as average_premerge_job_failures").
 		Group("prow_job_id, prow_job_name, org, repo")
 }
+
+// JobRetestStats counts, for each presubmit job in a release, its runs on pull requests started between start and
+// end, and its reruns on the same pull request commit. A pull request is recorded once for each commit it was tested
+// at, so runs sharing a prow_pull_requests row are retests. Aborted runs are not counted.
+func JobRetestStats(dbc *db.DB, release, org, repo string, start, end time.Time) ([]api.JobRetestStats, error) {
+	// each job's runs on one pull request commit, in order
+	const commitRuns = "PARTITION BY prow_jobs.id, prow_pull_requests.id ORDER BY prow_job_runs.timestamp"
+	runs := dbc.DB.Table("prow_job_runs").
+		Select(`prow_jobs.name AS job,
+			prow_pull_requests.id AS commit_id,
+			prow_job_runs.succeeded,
+			prow_job_runs.duration,
+			LAG(prow_job_runs.succeeded) OVER (`+commitRuns+`) AS previous_succeeded,
+			LEAD(prow_job_runs.id) OVER (`+commitRuns+`) IS NOT NULL AS retested`).
+		Joins("INNER JOIN prow_job_run_prow_pull_requests ON prow_job_run_prow_pull_requests.prow_job_run_id = prow_job_runs.id").
+		Joins("INNER JOIN prow_pull_requests ON prow_pull_requests.id = prow_job_run_prow_pull_requests.prow_pull_request_id").
+		Joins("INNER JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id").
+		Where("prow_jobs.release = ?", release).
+		Where("prow_job_runs.timestamp >= ? AND prow_job_runs.timestamp < ?", start, end).
+		Where("prow_job_runs.overall_result != 'A'")
+	if org != "" {
+		runs = runs.Where("prow_pull_requests.org = ?", org)
+	}
+	if repo != "" {
+		runs = runs.Where("prow_pull_requests.repo = ?", repo)
+	}
+
+	results := make([]api.JobRetestStats, 0)
+	res := dbc.DB.Table("(?) AS runs", runs).
+		Select(`job,
+			COUNT(*) AS runs,
+			COUNT(DISTINCT commit_id) AS commits,
+			COUNT(*) FILTER (WHERE previous_succeeded IS NOT NULL) AS retests,
+			COUNT(*) FILTER (WHERE previous_succeeded = false) AS retests_after_failure,
+			COUNT(*) FILTER (WHERE previous_succeeded = false AND succeeded) AS retest_passes,
+			COUNT(DISTINCT commit_id) FILTER (WHERE previous_succeeded = false AND succeeded) AS masked_failure_commits,
+			COALESCE(SUM(duration) FILTER (WHERE retested AND NOT succeeded), 0) / 3600000000000.0 AS retried_failure_hours`).
+		Group("job").
+		Scan(&results)
+	return results, res.Error
+}
+
+// PullRequestFailureRates counts presubmit job runs and failures per week for pull requests in a release,
+// grouped by org and repo, and also by author if byAuthor is set. With groupBy false the counts are the
+// weekly totals across every pull request, which serve as the baseline. Aborted runs are not counted.
+func PullRequestFailureRates(dbc *db.DB, release string, groupBy, byAuthor bool, org, repo string, start, end time.Time) ([]api.PullRequestFailureRate, error) {
+	columns := []string{"DATE_TRUNC('week', prow_job_runs.timestamp) AS week"}
+	if groupBy {
+		columns = append(columns, "prow_pull_requests.org", "prow_pull_requests.repo")
+		if byAuthor {
+			columns = append(columns, "prow_pull_requests.author")
+		}
+	}
+	groups := make([]string, 0, len(columns))
+	for i := range columns {
+		groups = append(groups, strconv.Itoa(i+1))
+	}
+
+	q := dbc.DB.Table("prow_job_runs").
+		Select(strings.Join(columns, ", ")+
+			", COUNT(DISTINCT prow_job_runs.id) AS runs"+
+			", COUNT(DISTINCT prow_job_runs.id) FILTER (WHERE prow_job_runs.failed) AS failures").
+		Joins("INNER JOIN prow_job_run_prow_pull_requests ON prow_job_run_prow_pull_requests.prow_job_run_id = prow_job_runs.id").
+		Joins("INNER JOIN prow_pull_requests ON prow_pull_requests.id = prow_job_run_prow_pull_requests.prow_pull_request_id").
+		Joins("INNER JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id").
+		Where("prow_jobs.release = ?", release).
+		Where("prow_job_runs.timestamp >= ? AND prow_job_runs.timestamp < ?", start, end).
+		Where("prow_job_runs.overall_result != 'A'").
+		Group(strings.Join(groups, ", ")).
+		Order(strings.Join(groups, ", "))
+	if org != "" {
+		q = q.Where("prow_pull_requests.org = ?", org)
+	}
+	if repo != "" {
+		q = q.Where("prow_pull_requests.repo = ?", repo)
+	}
+
+	results := make([]api.PullRequestFailureRate, 0)
+	res := q.Scan(&results)
+	return results, res.Error
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// StepFailureCount is how often a step ran and failed in the runs of a job, or of the jobs on a platform.
+type StepFailureCount struct {
+	JobName  string
+	Platform string
+	Step     string
+	Runs     int
+	Failures int
+}
+
+// StepFailuresByJob counts the runs and failures of each step that failed at least once in the runs of a job, or
+// of every job if job is empty, in release between start and end.
+func StepFailuresByJob(dbc *db.DB, release, job string, start, end time.Time) ([]StepFailureCount, error) {
+	results := make([]StepFailureCount, 0)
+	res := dbc.DB.Raw(`
+		SELECT
+			prow_jobs.name AS job_name,
+			prow_job_run_steps.step,
+			COUNT(*) AS runs,
+			COUNT(*) FILTER (WHERE prow_job_run_steps.failed) AS failures
+		FROM prow_job_run_steps
+		JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_steps.prow_job_run_id
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		WHERE prow_job_run_steps.deleted_at IS NULL AND prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+			AND (@job = '' OR prow_jobs.name = @job)
+		GROUP BY prow_jobs.name, prow_job_run_steps.step
+		HAVING COUNT(*) FILTER (WHERE prow_job_run_steps.failed) > 0`,
+		map[string]interface{}{"release": release, "job": job, "start": start, "end": end}).Scan(&results)
+	return results, res.Error
+}
+
+// StepFailuresByPlatform counts the runs and failures of each step that failed at least once in the runs of the
+// jobs on each platform, taken from the jobs' Platform variant, in release between start and end.
+func StepFailuresByPlatform(dbc *db.DB, release string, start, end time.Time) ([]StepFailureCount, error) {
+	results := make([]StepFailureCount, 0)
+	res := dbc.DB.Raw(`
+		SELECT
+			substring(variant FROM length('Platform:') + 1) AS platform,
+			prow_job_run_steps.step,
+			COUNT(*) AS runs,
+			COUNT(*) FILTER (WHERE prow_job_run_steps.failed) AS failures
+		FROM prow_job_run_steps
+		JOIN prow_job_runs ON prow_job_runs.id = prow_job_run_steps.prow_job_run_id
+		JOIN prow_jobs ON prow_jobs.id = prow_job_runs.prow_job_id
+		CROSS JOIN LATERAL unnest(prow_jobs.variants) AS variant
+		WHERE prow_job_run_steps.deleted_at IS NULL AND prow_job_runs.deleted_at IS NULL
+			AND prow_job_runs.timestamp >= @start AND prow_job_runs.timestamp < @end
+			AND prow_jobs.release = @release
+			AND variant LIKE 'Platform:%'
+		GROUP BY variant, prow_job_run_steps.step
+		HAVING COUNT(*) FILTER (WHERE prow_job_run_steps.failed) > 0`,
+		map[string]interface{}{"release": release, "start": start, "end": end}).Scan(&results)
+	return results, res.Error
+}
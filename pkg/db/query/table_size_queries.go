@@ -0,0 +1,57 @@
+package query
+
+import (
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// TableSize is the disk usage of a table, including every partition of a partitioned table, or of a materialized
+// view.
+type TableSize struct {
+	Name string
+	// Kind is table or matview.
+	Kind       string
+	TotalBytes int64
+	TableBytes int64
+	IndexBytes int64
+	LiveRows   int64
+	DeadRows   int64
+}
+
+// TableSizes lists the disk usage of the tables and materialized views in sippy's schema, largest first.
+func TableSizes(dbc *db.DB) ([]TableSize, error) {
+	results := make([]TableSize, 0)
+	res := dbc.DB.Raw(`
+		WITH rels AS (
+			SELECT c.oid, c.relname, c.relkind
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p', 'm') AND NOT c.relispartition
+		), members AS (
+			SELECT rels.oid AS root, rels.oid AS relid FROM rels WHERE rels.relkind <> 'p'
+			UNION ALL
+			SELECT rels.oid AS root, tree.relid
+			FROM rels, pg_partition_tree(rels.oid) AS tree
+			WHERE rels.relkind = 'p' AND tree.isleaf
+		)
+		SELECT
+			rels.relname AS name,
+			CASE rels.relkind WHEN 'm' THEN 'matview' ELSE 'table' END AS kind,
+			COALESCE(SUM(pg_total_relation_size(members.relid)), 0) AS total_bytes,
+			COALESCE(SUM(pg_relation_size(members.relid)), 0) AS table_bytes,
+			COALESCE(SUM(pg_indexes_size(members.relid)), 0) AS index_bytes,
+			COALESCE(SUM(stats.n_live_tup), 0) AS live_rows,
+			COALESCE(SUM(stats.n_dead_tup), 0) AS dead_rows
+		FROM rels
+		LEFT JOIN members ON members.root = rels.oid
+		LEFT JOIN pg_stat_all_tables stats ON stats.relid = members.relid
+		GROUP BY rels.relname, rels.relkind
+		ORDER BY total_bytes DESC, name`).Scan(&results)
+	return results, res.Error
+}
+
+// DatabaseSize returns the disk usage of the whole database.
+func DatabaseSize(dbc *db.DB) (int64, error) {
+	var size int64
+	res := dbc.DB.Raw("SELECT pg_database_size(current_database())").Scan(&size)
+	return size, res.Error
+}
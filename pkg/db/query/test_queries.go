@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 
@@ -172,6 +173,133 @@ func TestReportExcludeVariants(
 	return testReport, nil
 }
 
+// TestReportMatching returns a single test report combining all tests in the db named any of testNames or whose
+// name matches any of the given POSIX regular expressions, all variants collapsed, optionally with some excluded or
+// limited to the jobs of one architecture. Names are looked up through the matview's index, so they should be
+// preferred over regexes of a literal name. The report is named after the test if only one matched, and is empty if
+// nothing matches.
+func TestReportMatching(dbc *db.DB, release, arch string, testNames, testRegexes, excludeVariants []string) (api.Test, error) {
+	variant := ""
+	if arch != "" {
+		variant = "Architecture:" + arch
+	}
+	return TestReportMatchingVariant(dbc, release, variant, testNames, testRegexes, excludeVariants)
+}
+
+// TestReportMatchingVariant is TestReportMatching limited to the jobs with variant, i.e. Topology:external, instead
+// of an architecture.
+func TestReportMatchingVariant(dbc *db.DB, release, variant string, testNames, testRegexes, excludeVariants []string) (api.Test, error) {
+	var testReport api.Test
+	// only include the conditions in use, a regex alongside the names would turn the index lookup into a scan
+	matches := []string{}
+	if len(testNames) > 0 {
+		matches = append(matches, "name = ANY(@names)")
+	}
+	if len(testRegexes) > 0 {
+		matches = append(matches, "name ~ ANY(@regexes)")
+	}
+	if len(matches) == 0 {
+		return testReport, nil
+	}
+	q := `WITH results AS (
+    SELECT CASE WHEN COUNT(DISTINCT name) = 1 THEN MIN(name) ELSE '' END AS name,
+           release,
+           sum(current_runs)       AS current_runs,
+           sum(current_successes)  AS current_successes,
+           sum(current_failures)   AS current_failures,
+           sum(current_flakes)     AS current_flakes,
+           sum(previous_runs)      AS previous_runs,
+           sum(previous_successes) AS previous_successes,
+           sum(previous_failures)  AS previous_failures,
+           sum(previous_flakes)    AS previous_flakes
+    FROM prow_test_report_7d_matview
+    WHERE release = @release AND (` + strings.Join(matches, " OR ") + `) AND NOT COALESCE(variants && @excluded, false)
+        AND (@variant = '' OR @variant = ANY(variants))
+    GROUP BY release
+) SELECT *, ` + QueryTestPercentages + ` FROM results;`
+
+	r := dbc.DB.Raw(q,
+		sql.Named("release", release),
+		sql.Named("variant", variant),
+		sql.Named("names", pq.Array(testNames)),
+		sql.Named("regexes", pq.Array(testRegexes)),
+		sql.Named("excluded", pq.Array(excludeVariants))).Scan(&testReport)
+	return testReport, r.Error
+}
+
+// Test groupings supported by TestGroupReports.
+const (
+	TestGroupSig     = "sig"
+	TestGroupFeature = "feature"
+)
+
+// TestGroupReports combines the results of the tests in the test report table by their sig or feature, all variants
+// collapsed, optionally with some excluded. A test with several features counts towards each of them, tests without
+// a sig or feature are left out.
+func TestGroupReports(dbc *db.DB, table, release, groupBy string, excludeVariants []string) ([]api.TestGroupReport, error) {
+	var group, join string
+	switch groupBy {
+	case TestGroupSig:
+		group = "tests.sig"
+	case TestGroupFeature:
+		group = "feature"
+		join = "CROSS JOIN LATERAL unnest(tests.features) AS feature"
+	default:
+		return nil, fmt.Errorf("unknown test grouping %q", groupBy)
+	}
+
+	reports := []api.TestGroupReport{}
+	q := fmt.Sprintf(`WITH results AS (
+    SELECT %[1]s AS name,
+           COUNT(DISTINCT tests.id) AS tests,
+           sum(current_runs)       AS current_runs,
+           sum(current_successes)  AS current_successes,
+           sum(current_failures)   AS current_failures,
+           sum(current_flakes)     AS current_flakes,
+           sum(previous_runs)      AS previous_runs,
+           sum(previous_successes) AS previous_successes,
+           sum(previous_failures)  AS previous_failures,
+           sum(previous_flakes)    AS previous_flakes
+    FROM %[2]s report
+    JOIN tests ON tests.id = report.id
+    %[3]s
+    WHERE report.release = @release AND NOT COALESCE(report.variants && @excluded, false) AND %[1]s <> ''
+    GROUP BY %[1]s
+) SELECT *, `+QueryTestPercentages+` FROM results ORDER BY name;`, group, table, join)
+
+	r := dbc.DB.Raw(q,
+		sql.Named("release", release),
+		sql.Named("excluded", pq.Array(excludeVariants))).Scan(&reports)
+	return reports, r.Error
+}
+
+// SearchTests returns tests whose name contains, or is similar to, the search string. Substring matches are
+// ranked first, and then by trigram similarity. Both are served by the trigram index on tests.name.
+func SearchTests(dbc *db.DB, search string, limit int) ([]api.TestSearchResult, error) {
+	results := make([]api.TestSearchResult, 0)
+	likeEscaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	res := dbc.DB.Raw(`
+SELECT id, name, hash, similarity(name, @search) AS similarity
+FROM tests
+WHERE deleted_at IS NULL AND (name ILIKE @like OR name % @search)
+ORDER BY name ILIKE @like DESC, similarity DESC, name
+LIMIT @limit`,
+		sql.Named("search", search),
+		sql.Named("like", "%"+likeEscaper.Replace(search)+"%"),
+		sql.Named("limit", limit)).Scan(&results)
+	return results, res.Error
+}
+
+// TestNameByHash returns the name of the test with the given stable name hash.
+func TestNameByHash(dbc *db.DB, hash string) (string, error) {
+	test := models.Test{}
+	res := dbc.DB.Where("hash = ?", hash).First(&test)
+	if res.Error != nil {
+		return "", res.Error
+	}
+	return test.Name, nil
+}
+
 // LoadBugsForTest returns all bugs in the database for the given test, across all releases.
 func LoadBugsForTest(dbc *db.DB, testName string, filterClosed bool) ([]models.Bug, error) {
 	results := []models.Bug{}
@@ -240,7 +368,93 @@ func TestsByNURPAndStandardDeviation(dbc *db.DB, release, table string) *gorm.DB
 		Where(fmt.Sprintf("NOT ('never-stable'=any(%s.variants))", table))
 }
 
-func TestOutputs(dbc *db.DB, release, test string, includedVariants, excludedVariants []string, quantity int) ([]api.TestOutput, error) {
+// TestReportsForRelease returns the current period results in the test report table of the named tests in
+// release, either per test or, with byVariants, per test, suite and variant combination.
+func TestReportsForRelease(dbc *db.DB, table, release string, names []string, byVariants bool) ([]api.Test, error) {
+	groupBy := "name"
+	if byVariants {
+		groupBy = "name, suite_name, variants"
+	}
+	summed := dbc.DB.Table(table).
+		Select(groupBy+", "+QueryTestSummer).
+		Where("release = ?", release).
+		Where("name IN ?", names).
+		Group(groupBy)
+
+	results := make([]api.Test, 0)
+	res := dbc.DB.Table("(?) AS summed", summed).
+		Select(groupBy + ", " + QueryTestSummarizer).
+		Scan(&results)
+	return results, res.Error
+}
+
+// TestResultsAcrossReleases totals a test's current period results in the test report table for every release,
+// from jobs having all of includeVariants and none of excludeVariants. With byVariant the results are also broken
+// down by each variant of those jobs, so a job counts towards every one of its variants.
+func TestResultsAcrossReleases(dbc *db.DB, table, test string, includeVariants, excludeVariants []string, byVariant bool) ([]api.TestReleaseResult, error) {
+	variant := "''"
+	if byVariant {
+		variant = "unnest(variants)"
+	}
+	q := dbc.DB.Table(table).
+		Select(fmt.Sprintf("release, %s AS variant, current_runs, current_successes, current_flakes, current_failures", variant)).
+		Where("name = ?", test)
+	for _, v := range includeVariants {
+		q = q.Where("? = ANY(variants)", v)
+	}
+	for _, v := range excludeVariants {
+		q = q.Where("NOT (? = ANY(variants))", v)
+	}
+
+	results := make([]api.TestReleaseResult, 0)
+	res := dbc.DB.Table("(?) AS results", q).
+		Select(`release, variant,
+			sum(current_runs) AS runs,
+			sum(current_successes) AS successes,
+			sum(current_flakes) AS flakes,
+			sum(current_failures) AS failures`).
+		Group("release, variant").
+		Scan(&results)
+	return results, res.Error
+}
+
+// JobRunScope restricts test queries to runs of jobs with, or without, the given variants, and to runs
+// on, or not on, the given build clusters.
+type JobRunScope struct {
+	IncludedVariants []string
+	ExcludedVariants []string
+	IncludedClusters []string
+	ExcludedClusters []string
+	// SamplePercent, when between 1 and 99, only includes that percentage of job runs, chosen by run ID so
+	// the same runs are sampled on every request.
+	SamplePercent int
+}
+
+// apply adds the scope to a query that joins prow_jobs and prow_job_runs.
+func (s JobRunScope) apply(q *gorm.DB) *gorm.DB {
+	for _, variant := range s.IncludedVariants {
+		q = q.Where("? = any(prow_jobs.variants)", variant)
+	}
+
+	for _, variant := range s.ExcludedVariants {
+		q = q.Where("NOT ? = any(prow_jobs.variants)", variant)
+	}
+
+	if len(s.IncludedClusters) > 0 {
+		q = q.Where("prow_job_runs.cluster IN ?", s.IncludedClusters)
+	}
+
+	if len(s.ExcludedClusters) > 0 {
+		q = q.Where("prow_job_runs.cluster NOT IN ?", s.ExcludedClusters)
+	}
+
+	if s.SamplePercent > 0 && s.SamplePercent < 100 {
+		q = q.Where("prow_job_runs.id % 100 < ?", s.SamplePercent)
+	}
+	return q
+}
+
+func TestOutputs(dbc *db.DB, release, test string, scope JobRunScope, quantity int) ([]api.TestOutput, error) {
 	results := make([]api.TestOutput, 0)
 
 	testQuery := dbc.DB.Table("tests").Where("name = ?", test).Select("id")
@@ -252,16 +466,40 @@ func TestOutputs(dbc *db.DB, release, test string, includedVariants, excludedVar
 		Where("prow_job_run_tests.test_id = (?)", testQuery).
 		Where("prow_jobs.release = ?", release)
 
-	for _, variant := range includedVariants {
-		q = q.Where("? = any(prow_jobs.variants)", variant)
-	}
+	q = scope.apply(q)
 
-	for _, variant := range excludedVariants {
-		q = q.Where("NOT ? = any(prow_jobs.variants)", variant)
+	res := q.
+		Select("prow_job_runs.url, message, output").
+		Order("prow_job_run_test_outputs.id DESC").
+		Limit(quantity).
+		Scan(&results)
+
+	return results, res.Error
+}
+
+// TestFailureOutputs returns the most recent failure outputs of a test over the last 14 days, optionally only
+// those whose message or output contains search.
+func TestFailureOutputs(dbc *db.DB, release, test, search string, scope JobRunScope, quantity int) ([]api.TestFailureOutput, error) {
+	results := make([]api.TestFailureOutput, 0)
+
+	testQuery := dbc.DB.Table("tests").Where("name = ?", test).Select("id")
+	q := dbc.DB.Table("prow_job_run_test_outputs").
+		Joins("JOIN prow_job_run_tests ON prow_job_run_test_outputs.prow_job_run_test_id = prow_job_run_tests.id").
+		Joins("JOIN prow_job_runs ON prow_job_run_tests.prow_job_run_id = prow_job_runs.id").
+		Joins("JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id").
+		Where("prow_job_runs.timestamp > current_date - interval '14' day").
+		Where("prow_job_run_tests.test_id = (?)", testQuery).
+		Where("prow_jobs.release = ?", release)
+
+	if search != "" {
+		pattern := "%" + search + "%"
+		q = q.Where("(prow_job_run_test_outputs.message ILIKE ? OR prow_job_run_test_outputs.output ILIKE ?)", pattern, pattern)
 	}
 
+	q = scope.apply(q)
+
 	res := q.
-		Select("prow_job_runs.url, output").
+		Select("prow_job_runs.url, prow_job_runs.timestamp, prow_jobs.name AS prow_job_name, message, output").
 		Order("prow_job_run_test_outputs.id DESC").
 		Limit(quantity).
 		Scan(&results)
@@ -269,7 +507,7 @@ func TestOutputs(dbc *db.DB, release, test string, includedVariants, excludedVar
 	return results, res.Error
 }
 
-func TestDurations(dbc *db.DB, release, test string, includedVariants, excludedVariants []string) (map[string]float64, error) {
+func TestDurations(dbc *db.DB, release, test string, scope JobRunScope) (map[string]float64, error) {
 	type testDuration struct {
 		Period          time.Time `json:"period"`
 		AverageDuration float64   `json:"average_duration"`
@@ -286,13 +524,7 @@ func TestDurations(dbc *db.DB, release, test string, includedVariants, excludedV
 		Where("prow_job_run_tests.test_id = (?)", testQuery).
 		Where("prow_jobs.release = ?", release)
 
-	for _, variant := range includedVariants {
-		q = q.Where("? = any(prow_jobs.variants)", variant)
-	}
-
-	for _, variant := range excludedVariants {
-		q = q.Where("NOT ? = any(prow_jobs.variants)", variant)
-	}
+	q = scope.apply(q)
 
 	res := q.
 		Select(`
@@ -308,3 +540,216 @@ func TestDurations(dbc *db.DB, release, test string, includedVariants, excludedV
 
 	return results, res.Error
 }
+
+// TestResultsByBuildCluster returns the results of a test over the last 14 days, broken down by the build
+// cluster the job runs executed on.
+func TestResultsByBuildCluster(dbc *db.DB, release, test string, scope JobRunScope) ([]api.TestBuildClusterResult, error) {
+	results := make([]api.TestBuildClusterResult, 0)
+
+	testQuery := dbc.DB.Table("tests").Where("name = ?", test).Select("id")
+	q := dbc.DB.Table("prow_job_run_tests").
+		Joins("JOIN prow_job_runs ON prow_job_run_tests.prow_job_run_id = prow_job_runs.id").
+		Joins("JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id").
+		Where("prow_job_runs.timestamp > current_date - interval '14' day").
+		Where("prow_job_run_tests.test_id = (?)", testQuery).
+		Where("prow_jobs.release = ?", release)
+
+	q = scope.apply(q)
+
+	res := q.
+		Select(`
+			prow_job_runs.cluster AS cluster,
+			count(*) FILTER (WHERE prow_job_run_tests.status <> 14) AS runs,
+			count(*) FILTER (WHERE prow_job_run_tests.status = 1) AS successes,
+			count(*) FILTER (WHERE prow_job_run_tests.status = 12) AS failures,
+			count(*) FILTER (WHERE prow_job_run_tests.status = 13) AS flakes,
+			count(*) FILTER (WHERE prow_job_run_tests.status = 14) AS timeouts,
+			count(*) FILTER (WHERE prow_job_run_tests.status IN (1, 13)) * 100.0 /
+				NULLIF(count(*) FILTER (WHERE prow_job_run_tests.status <> 14), 0) AS pass_percentage`).
+		Group("prow_job_runs.cluster").
+		Order("pass_percentage ASC").
+		Scan(&results)
+
+	return results, res.Error
+}
+
+// TestReportContributions returns every result of a test in job runs started between start and end, as counted by
+// the test report matviews.
+func TestReportContributions(dbc *db.DB, release, test string, scope JobRunScope, start, end time.Time) ([]api.TestReportContribution, error) {
+	results := make([]api.TestReportContribution, 0)
+
+	testQuery := dbc.DB.Table("tests").Where("name = ?", test).Select("id")
+	q := dbc.DB.Table("prow_job_run_tests").
+		Joins("JOIN tests ON prow_job_run_tests.test_id = tests.id").
+		Joins("JOIN prow_job_runs ON prow_job_run_tests.prow_job_run_id = prow_job_runs.id").
+		Joins("JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id").
+		Where("prow_job_runs.timestamp BETWEEN ? AND ?", start, end).
+		Where("prow_job_run_tests.test_id = (?)", testQuery).
+		Where("prow_jobs.release = ?", release)
+
+	q = scope.apply(q)
+
+	res := q.
+		Select(`
+			prow_job_runs.id AS prow_job_run_id,
+			prow_jobs.name AS prow_job_name,
+			prow_job_runs.url,
+			prow_job_runs.timestamp,
+			prow_job_run_tests.status,
+			prow_jobs.variants,
+			EXISTS (
+				SELECT 1 FROM test_suppressions
+				WHERE test_suppressions.deleted_at IS NULL
+				  AND prow_job_runs.timestamp BETWEEN test_suppressions.start_date AND test_suppressions.end_date
+				  AND tests.name ~ test_suppressions.test_pattern
+				  AND (test_suppressions.variant = '' OR test_suppressions.variant = ANY(prow_jobs.variants))
+			) AS suppressed`).
+		Order("prow_job_runs.timestamp DESC").
+		Scan(&results)
+
+	return results, res.Error
+}
+
+// TestStatusCounts totals a test's results in the release between start and end, from jobs having all of
+// includeVariants and none of excludeVariants.
+func TestStatusCounts(dbc *db.DB, release, test string, includeVariants, excludeVariants []string, start, end time.Time) (api.TestComparisonStats, error) {
+	stats := api.TestComparisonStats{Release: release, Start: start, End: end}
+
+	testQuery := dbc.DB.Table("tests").Where("name = ?", test).Select("id")
+	q := dbc.DB.Table("prow_job_run_tests").
+		Joins("JOIN prow_job_runs ON prow_job_run_tests.prow_job_run_id = prow_job_runs.id").
+		Joins("JOIN prow_jobs ON prow_job_runs.prow_job_id = prow_jobs.id").
+		Where("prow_job_run_tests.test_id = (?)", testQuery).
+		Where("prow_jobs.release = ?", release).
+		Where("prow_job_runs.timestamp BETWEEN ? AND ?", start, end).
+		Where("prow_job_run_tests.status <> ?", v1.TestStatusTimeout)
+	for _, v := range includeVariants {
+		q = q.Where("? = ANY(prow_jobs.variants)", v)
+	}
+	for _, v := range excludeVariants {
+		q = q.Where("NOT (? = ANY(prow_jobs.variants))", v)
+	}
+
+	res := q.Select(`
+			COUNT(*) AS runs,
+			COUNT(*) FILTER (WHERE prow_job_run_tests.status = 1) AS successes,
+			COUNT(*) FILTER (WHERE prow_job_run_tests.status = 13) AS flakes,
+			COUNT(*) FILTER (WHERE prow_job_run_tests.status = 12) AS failures`).
+		Scan(&stats)
+	return stats, res.Error
+}
+
+// ListTestSuppressions returns test suppressions, optionally only those still in effect at or after since.
+func ListTestSuppressions(dbc *db.DB, since *time.Time) ([]models.TestSuppression, error) {
+	results := make([]models.TestSuppression, 0)
+	q := dbc.DB.Order("start_date DESC")
+	if since != nil {
+		q = q.Where("end_date >= ?", *since)
+	}
+	res := q.Find(&results)
+	return results, res.Error
+}
+
+// TestFailureCell is how often a test failed in the runs of the jobs that failed it, for one hour of one day of
+// the week.
+type TestFailureCell struct {
+	TestName  string
+	DayOfWeek int
+	Hour      int
+	Runs      int
+	Failures  int
+}
+
+// TestsInOneRelease returns the tests in the test report table that ran in only one of release and baseRelease, with
+// their runs in it over both periods of the table.
+func TestsInOneRelease(dbc *db.DB, table, release, baseRelease string) ([]api.TestPresence, error) {
+	results := make([]api.TestPresence, 0)
+	res := dbc.DB.Raw(fmt.Sprintf(`
+		SELECT tests.name, COALESCE(tests.sig, '') AS sig, report.release,
+			SUM(report.current_runs + report.previous_runs) AS runs
+		FROM %[1]s report
+		JOIN tests ON tests.id = report.id
+		WHERE report.release IN (@release, @baseRelease)
+			AND report.id IN (
+				SELECT id FROM %[1]s
+				WHERE release IN (@release, @baseRelease)
+				GROUP BY id
+				HAVING COUNT(DISTINCT release) = 1)
+		GROUP BY tests.name, tests.sig, report.release`, table),
+		map[string]interface{}{"release": release, "baseRelease": baseRelease}).Scan(&results)
+	return results, res.Error
+}
+
+// TestFailuresByHour breaks down the failures of a test, or of every test failing at least minFailures times, in
+// release between start and end by the day of the week and hour (UTC) of the job run, using the hourly matviews.
+// Runs are those of the jobs that failed the test in the period, as the matviews don't record passes.
+func TestFailuresByHour(dbc *db.DB, release, test string, minFailures int, start, end time.Time) ([]TestFailureCell, error) {
+	results := make([]TestFailureCell, 0)
+	res := dbc.DB.Raw(`
+		WITH failures AS (
+			SELECT f.test_name, f.prow_job_id, f.period, f.count
+			FROM prow_job_failed_tests_by_hour_matview f
+			JOIN prow_jobs ON prow_jobs.id = f.prow_job_id
+			WHERE prow_jobs.release = @release AND f.period >= @start AND f.period < @end
+				AND (@test = '' OR f.test_name = @test)
+		), tests AS (
+			SELECT test_name FROM failures GROUP BY test_name HAVING SUM(count) >= @minFailures
+		), test_jobs AS (
+			SELECT DISTINCT failures.test_name, failures.prow_job_id
+			FROM failures JOIN tests ON tests.test_name = failures.test_name
+		)
+		SELECT
+			test_jobs.test_name,
+			EXTRACT(DOW FROM r.period)::int AS day_of_week,
+			EXTRACT(HOUR FROM r.period)::int AS hour,
+			SUM(r.runs) AS runs,
+			COALESCE(SUM(failures.count), 0) AS failures
+		FROM test_jobs
+		JOIN prow_job_results_by_hour_matview r ON r.prow_job_id = test_jobs.prow_job_id
+		LEFT JOIN failures ON failures.test_name = test_jobs.test_name
+			AND failures.prow_job_id = r.prow_job_id AND failures.period = r.period
+		WHERE r.period >= @start AND r.period < @end
+		GROUP BY test_jobs.test_name, day_of_week, hour`,
+		map[string]interface{}{
+			"release":     release,
+			"test":        test,
+			"minFailures": minFailures,
+			"start":       start,
+			"end":         end,
+		}).Scan(&results)
+	return results, res.Error
+}
+
+// CollapsedTest is a test the test name rules rewrote other names into, with how many names, the rules that
+// rewrote them, and some of the names.
+type CollapsedTest struct {
+	Name       string
+	Duplicates int
+	Rules      pq.StringArray `gorm:"type:text[]"`
+	Examples   pq.StringArray `gorm:"type:text[]"`
+}
+
+// CollapsedTests lists the tests other names were rewritten into, those collapsing the most names first, with up
+// to maxExamples of the names.
+func CollapsedTests(dbc *db.DB, maxExamples, limit int) ([]CollapsedTest, error) {
+	results := make([]CollapsedTest, 0)
+	q := dbc.DB.Raw(`
+		SELECT
+			tests.name,
+			COUNT(*) AS duplicates,
+			ARRAY(
+				SELECT DISTINCT rule
+				FROM test_name_aliases aliases, unnest(aliases.rules) AS rule
+				WHERE aliases.test_id = tests.id AND aliases.deleted_at IS NULL
+				ORDER BY rule) AS rules,
+			(array_agg(test_name_aliases.raw_name ORDER BY test_name_aliases.created_at DESC))[1:@maxExamples] AS examples
+		FROM test_name_aliases
+		JOIN tests ON tests.id = test_name_aliases.test_id
+		WHERE test_name_aliases.deleted_at IS NULL
+		GROUP BY tests.id, tests.name
+		ORDER BY duplicates DESC, tests.name
+		LIMIT NULLIF(@limit, 0)`,
+		map[string]interface{}{"maxExamples": maxExamples, "limit": limit})
+	res := q.Scan(&results)
+	return results, res.Error
+}
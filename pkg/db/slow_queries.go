@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	slowQueryStartKey       = "sippy:slow_query_start"
+	slowQuerySkipKey        = "sippy:slow_query_skip"
+	slowQueryMaxTracked     = 100
+	slowQueryExplainTimeout = 2 * time.Minute
+)
+
+// SlowQuery summarizes the executions of one statement that took longer than the slow query threshold.
+type SlowQuery struct {
+	// SQL is the statement with its parameter placeholders, used to group executions.
+	SQL string `json:"sql"`
+	// Example is the slowest execution, with its bound parameters.
+	Example         string    `json:"example"`
+	Count           int       `json:"count"`
+	MaxDurationMS   int64     `json:"max_duration_ms"`
+	TotalDurationMS int64     `json:"total_duration_ms"`
+	LastSeen        time.Time `json:"last_seen"`
+	// Plan is the most recently sampled EXPLAIN output, if any.
+	Plan string `json:"plan,omitempty"`
+}
+
+// SlowQueryTracker is a gorm plugin that logs queries slower than a threshold, along with their bound parameters,
+// and keeps the worst recent offenders to guide index work. A sample of the slow SELECTs are re-run with EXPLAIN
+// to capture their query plans.
+type SlowQueryTracker struct {
+	threshold     time.Duration
+	explainSample float64
+
+	lock    sync.Mutex
+	queries map[string]*SlowQuery
+}
+
+// NewSlowQueryTracker returns a tracker for queries slower than threshold, explaining the given fraction of them.
+func NewSlowQueryTracker(threshold time.Duration, explainSample float64) *SlowQueryTracker {
+	return &SlowQueryTracker{
+		threshold:     threshold,
+		explainSample: explainSample,
+		queries:       map[string]*SlowQuery{},
+	}
+}
+
+func (t *SlowQueryTracker) Name() string {
+	return "sippy:slow_queries"
+}
+
+func (t *SlowQueryTracker) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("sippy:slow_query_start", t.start),
+		cb.Create().After("gorm:create").Register("sippy:slow_query_end", t.end),
+		cb.Query().Before("gorm:query").Register("sippy:slow_query_start", t.start),
+		cb.Query().After("gorm:query").Register("sippy:slow_query_end", t.end),
+		cb.Update().Before("gorm:update").Register("sippy:slow_query_start", t.start),
+		cb.Update().After("gorm:update").Register("sippy:slow_query_end", t.end),
+		cb.Delete().Before("gorm:delete").Register("sippy:slow_query_start", t.start),
+		cb.Delete().After("gorm:delete").Register("sippy:slow_query_end", t.end),
+		cb.Row().Before("gorm:row").Register("sippy:slow_query_start", t.start),
+		cb.Row().After("gorm:row").Register("sippy:slow_query_end", t.end),
+		cb.Raw().Before("gorm:raw").Register("sippy:slow_query_start", t.start),
+		cb.Raw().After("gorm:raw").Register("sippy:slow_query_end", t.end),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SlowQueryTracker) start(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (t *SlowQueryTracker) end(db *gorm.DB) {
+	if _, skip := db.Get(slowQuerySkipKey); skip {
+		return
+	}
+	v, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(v.(time.Time))
+	if elapsed < t.threshold {
+		return
+	}
+
+	sql := db.Statement.SQL.String()
+	example := db.Dialector.Explain(sql, db.Statement.Vars...)
+	log.WithFields(log.Fields{
+		"elapsed": elapsed,
+		"rows":    db.RowsAffected,
+	}).Warnf("slow query: %s", example)
+	t.record(sql, example, elapsed, time.Now())
+
+	if t.explainSample > 0 && isSelect(sql) && rand.Float64() < t.explainSample { // nolint:gosec
+		go t.explain(db, sql, example)
+	}
+}
+
+// explain re-runs a slow query with EXPLAIN in a new session the tracker ignores, and keeps its plan.
+func (t *SlowQueryTracker) explain(db *gorm.DB, sql, example string) {
+	ctx, cancel := context.WithTimeout(context.Background(), slowQueryExplainTimeout)
+	defer cancel()
+
+	var lines []string
+	res := db.Session(&gorm.Session{NewDB: true, Context: ctx, Logger: gormlogger.Discard}).
+		Set(slowQuerySkipKey, true).
+		Raw("EXPLAIN " + example).
+		Scan(&lines)
+	if res.Error != nil {
+		log.WithError(res.Error).Debug("could not explain slow query")
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if q, ok := t.queries[sql]; ok {
+		q.Plan = strings.Join(lines, "\n")
+	}
+}
+
+// record adds an execution of a slow query, evicting the query seen least recently when too many are tracked.
+func (t *SlowQueryTracker) record(sql, example string, elapsed time.Duration, at time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	q, ok := t.queries[sql]
+	if !ok {
+		if len(t.queries) >= slowQueryMaxTracked {
+			var oldest *SlowQuery
+			for _, candidate := range t.queries {
+				if oldest == nil || candidate.LastSeen.Before(oldest.LastSeen) {
+					oldest = candidate
+				}
+			}
+			delete(t.queries, oldest.SQL)
+		}
+		q = &SlowQuery{SQL: sql}
+		t.queries[sql] = q
+	}
+
+	q.Count++
+	q.TotalDurationMS += elapsed.Milliseconds()
+	q.LastSeen = at
+	if elapsed.Milliseconds() >= q.MaxDurationMS {
+		q.MaxDurationMS = elapsed.Milliseconds()
+		q.Example = example
+	}
+}
+
+// Worst returns up to limit of the tracked slow queries, slowest first.
+func (t *SlowQueryTracker) Worst(limit int) []SlowQuery {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	queries := make([]SlowQuery, 0, len(t.queries))
+	for _, q := range t.queries {
+		queries = append(queries, *q)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].MaxDurationMS != queries[j].MaxDurationMS {
+			return queries[i].MaxDurationMS > queries[j].MaxDurationMS
+		}
+		return queries[i].TotalDurationMS > queries[j].TotalDurationMS
+	})
+	if limit > 0 && len(queries) > limit {
+		queries = queries[:limit]
+	}
+	return queries
+}
+
+func isSelect(sql string) bool {
+	sql = strings.ToUpper(strings.TrimSpace(sql))
+	return strings.HasPrefix(sql, "SELECT") || strings.HasPrefix(sql, "WITH")
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowQueryTracker(t *testing.T) {
+	now := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	tracker := NewSlowQueryTracker(time.Second, 0)
+
+	tracker.record("SELECT * FROM tests WHERE id = $1", "SELECT * FROM tests WHERE id = 1", 2*time.Second, now)
+	tracker.record("SELECT * FROM tests WHERE id = $1", "SELECT * FROM tests WHERE id = 2", 5*time.Second, now.Add(time.Minute))
+	tracker.record("SELECT * FROM tests WHERE id = $1", "SELECT * FROM tests WHERE id = 3", 3*time.Second, now.Add(2*time.Minute))
+	tracker.record("REFRESH MATERIALIZED VIEW prow_test_report_7d_matview", "REFRESH MATERIALIZED VIEW prow_test_report_7d_matview", 4*time.Second, now)
+
+	worst := tracker.Worst(0)
+	assert.Len(t, worst, 2)
+	assert.Equal(t, SlowQuery{
+		SQL:             "SELECT * FROM tests WHERE id = $1",
+		Example:         "SELECT * FROM tests WHERE id = 2",
+		Count:           3,
+		MaxDurationMS:   5000,
+		TotalDurationMS: 10000,
+		LastSeen:        now.Add(2 * time.Minute),
+	}, worst[0])
+	assert.Equal(t, "REFRESH MATERIALIZED VIEW prow_test_report_7d_matview", worst[1].SQL)
+	assert.Len(t, tracker.Worst(1), 1)
+
+	// Filling the tracker evicts the query seen least recently.
+	for i := 1; i < slowQueryMaxTracked; i++ {
+		sql := fmt.Sprintf("SELECT %d", i)
+		tracker.record(sql, sql, time.Second, now.Add(time.Hour))
+	}
+	worst = tracker.Worst(0)
+	assert.Len(t, worst, slowQueryMaxTracked)
+	for _, q := range worst {
+		assert.NotEqual(t, "REFRESH MATERIALIZED VIEW prow_test_report_7d_matview", q.SQL)
+	}
+	assert.Equal(t, "SELECT * FROM tests WHERE id = $1", worst[0].SQL)
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// populateTestHashes backfills the name hash for any tests created before the column existed. New tests
+// get their hash set on save.
+func populateTestHashes(db *gorm.DB) error {
+	tests := make([]models.Test, 0)
+	var updated int
+	res := db.Where("hash IS NULL OR hash = ''").FindInBatches(&tests, 1000, func(tx *gorm.DB, batch int) error {
+		for i := range tests {
+			res := tx.Model(&tests[i]).UpdateColumn("hash", models.TestNameHash(tests[i].Name))
+			if res.Error != nil {
+				return errors.Wrapf(res.Error, "error updating hash for test: %s", tests[i].Name)
+			}
+			updated++
+		}
+		return nil
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if updated > 0 {
+		log.WithField("tests", updated).Info("populated missing test name hashes")
+	}
+	return nil
+}
+
+// populateTestSigs backfills the sig and features for any tests created before the columns existed. New tests
+// get them set on save.
+func populateTestSigs(db *gorm.DB) error {
+	tests := make([]models.Test, 0)
+	var updated int
+	res := db.Where("sig IS NULL").FindInBatches(&tests, 1000, func(tx *gorm.DB, batch int) error {
+		for i := range tests {
+			res := tx.Model(&tests[i]).UpdateColumns(map[string]interface{}{
+				"sig":      models.TestSig(tests[i].Name),
+				"features": pq.StringArray(models.TestFeatures(tests[i].Name)),
+			})
+			if res.Error != nil {
+				return errors.Wrapf(res.Error, "error updating sig for test: %s", tests[i].Name)
+			}
+			updated++
+		}
+		return nil
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if updated > 0 {
+		log.WithField("tests", updated).Info("populated missing test sigs and features")
+	}
+	return nil
+}
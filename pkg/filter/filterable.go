@@ -249,6 +249,12 @@ func FilterOptionsFromRequest(req *http.Request, defaultSortField string, defaul
 			return filterOpts, fmt.Errorf("could not marshal filter: %w", err)
 		}
 	}
+	if err := addExcludedVariants(filter, req); err != nil {
+		return filterOpts, err
+	}
+	if err := addArchitecture(filter, req); err != nil {
+		return filterOpts, err
+	}
 	filterOpts.Filter = filter
 
 	limitParam := req.URL.Query().Get("limit")
@@ -284,10 +290,72 @@ func ExtractFilters(req *http.Request) (*Filter, error) {
 			return nil, fmt.Errorf("could not unmarshal filter: %w", err)
 		}
 	}
+	if err := addExcludedVariants(filter, req); err != nil {
+		return nil, err
+	}
+	if err := addArchitecture(filter, req); err != nil {
+		return nil, err
+	}
 
 	return filter, nil
 }
 
+// addExcludedVariants adds a "variants not contains" item to the filter for each variant in the excludeVariants
+// query parameter, which may be repeated or comma separated, i.e. excludeVariants=single-node,serial.
+func addExcludedVariants(filter *Filter, req *http.Request) error {
+	var variants []string
+	for _, param := range req.URL.Query()["excludeVariants"] {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				variants = append(variants, v)
+			}
+		}
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+	// Exclusions must apply to every result, which can't be expressed by adding items to an "or" filter.
+	if filter.LinkOperator == LinkOperatorOr && len(filter.Items) > 0 {
+		return fmt.Errorf("excludeVariants cannot be combined with an 'or' filter")
+	}
+
+	filter.LinkOperator = LinkOperatorAnd
+	for _, v := range variants {
+		filter.Items = append(filter.Items, FilterItem{
+			Field:    "variants",
+			Not:      true,
+			Operator: OperatorContains,
+			Value:    v,
+		})
+	}
+	return nil
+}
+
+// addArchitecture adds a "variants contains" item to the filter for the architecture in the arch query parameter,
+// i.e. arch=arm64, so one architecture's payload stream can be assessed independently of the others.
+func addArchitecture(filter *Filter, req *http.Request) error {
+	arch := apiparam.SafeRead(req, "arch")
+	if arch == "" {
+		return nil
+	}
+	if filter.LinkOperator == LinkOperatorOr && len(filter.Items) > 0 {
+		return fmt.Errorf("arch cannot be combined with an 'or' filter")
+	}
+
+	filter.LinkOperator = LinkOperatorAnd
+	filter.Items = append(filter.Items, ArchitectureItem(arch))
+	return nil
+}
+
+// ArchitectureItem is a filter item matching the results of jobs on the given architecture.
+func ArchitectureItem(arch string) FilterItem {
+	return FilterItem{
+		Field:    "variants",
+		Operator: OperatorContains,
+		Value:    "Architecture:" + arch,
+	}
+}
+
 func ApplyFilters(
 	filter *Filter,
 	sortField string,
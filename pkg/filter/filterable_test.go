@@ -1,8 +1,13 @@
 package filter
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	apitype "github.com/openshift/sippy/pkg/apis/api"
 )
 
@@ -382,3 +387,99 @@ func TestFilterableNumerical(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractFiltersExcludeVariants(t *testing.T) {
+	cases := []struct {
+		name          string
+		query         string
+		expectedItems []FilterItem
+		expectedErr   bool
+	}{
+		{
+			name:  "no exclusions",
+			query: "",
+		},
+		{
+			name:  "repeated and comma separated",
+			query: "excludeVariants=single-node,serial&excludeVariants=techpreview",
+			expectedItems: []FilterItem{
+				{Field: "variants", Not: true, Operator: OperatorContains, Value: "single-node"},
+				{Field: "variants", Not: true, Operator: OperatorContains, Value: "serial"},
+				{Field: "variants", Not: true, Operator: OperatorContains, Value: "techpreview"},
+			},
+		},
+		{
+			name:  "combined with an and filter",
+			query: `excludeVariants=serial&filter={"items":[{"columnField":"name","operatorValue":"contains","value":"aws"}],"linkOperator":"and"}`,
+			expectedItems: []FilterItem{
+				{Field: "name", Operator: OperatorContains, Value: "aws"},
+				{Field: "variants", Not: true, Operator: OperatorContains, Value: "serial"},
+			},
+		},
+		{
+			name:        "combined with an or filter",
+			query:       `excludeVariants=serial&filter={"items":[{"columnField":"name","operatorValue":"contains","value":"aws"}],"linkOperator":"or"}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tests?"+strings.ReplaceAll(tc.query, `"`, "%22"), nil)
+			result, err := ExtractFilters(req)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, result.Items)
+		})
+	}
+}
+
+func TestExtractFiltersArchitecture(t *testing.T) {
+	cases := []struct {
+		name          string
+		query         string
+		expectedItems []FilterItem
+		expectedErr   bool
+	}{
+		{
+			name:  "no architecture",
+			query: "",
+		},
+		{
+			name:  "architecture",
+			query: "arch=arm64",
+			expectedItems: []FilterItem{
+				{Field: "variants", Operator: OperatorContains, Value: "Architecture:arm64"},
+			},
+		},
+		{
+			name:  "combined with exclusions",
+			query: "arch=arm64&excludeVariants=serial",
+			expectedItems: []FilterItem{
+				{Field: "variants", Not: true, Operator: OperatorContains, Value: "serial"},
+				{Field: "variants", Operator: OperatorContains, Value: "Architecture:arm64"},
+			},
+		},
+		{
+			name:        "combined with an or filter",
+			query:       `arch=arm64&filter={"items":[{"columnField":"name","operatorValue":"contains","value":"aws"}],"linkOperator":"or"}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs?"+strings.ReplaceAll(tc.query, `"`, "%22"), nil)
+			result, err := ExtractFilters(req)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, result.Items)
+		})
+	}
+}
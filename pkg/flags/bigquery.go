@@ -14,6 +14,11 @@ import (
 type BigQueryFlags struct {
 	BigQueryProject string
 	BigQueryDataset string
+
+	DailyByteBudget      int64
+	MaxConcurrentQueries int
+
+	budget *bqcachedclient.Budget
 }
 
 func NewBigQueryFlags() *BigQueryFlags {
@@ -23,6 +28,16 @@ func NewBigQueryFlags() *BigQueryFlags {
 func (f *BigQueryFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.BigQueryProject, "bigquery-project", "openshift-gce-devel", "BigQuery project to use")
 	fs.StringVar(&f.BigQueryDataset, "bigquery-dataset", "ci_analysis_us", "Dataset to use")
+	fs.Int64Var(&f.DailyByteBudget, "bigquery-daily-byte-budget", 0, "Bytes BigQuery queries may scan each UTC day before further queries are refused, 0 for no limit")
+	fs.IntVar(&f.MaxConcurrentQueries, "bigquery-max-concurrent-queries", 0, "BigQuery queries to run at once, queueing the rest, 0 for no limit")
+}
+
+// GetBudget returns the budget shared by the BigQuery clients of this process.
+func (f *BigQueryFlags) GetBudget() *bqcachedclient.Budget {
+	if f.budget == nil {
+		f.budget = bqcachedclient.NewBudget(f.DailyByteBudget, f.MaxConcurrentQueries)
+	}
+	return f.budget
 }
 
 func (f *BigQueryFlags) GetBigQueryClient(ctx context.Context, cacheClient cache.Cache, googleServiceAccountCredentialFile string) (*bqcachedclient.Client, error) {
@@ -30,5 +45,10 @@ func (f *BigQueryFlags) GetBigQueryClient(ctx context.Context, cacheClient cache
 		return nil, fmt.Errorf("service account required")
 	}
 
-	return bqcachedclient.New(ctx, googleServiceAccountCredentialFile, f.BigQueryProject, f.BigQueryDataset, cacheClient)
+	client, err := bqcachedclient.New(ctx, googleServiceAccountCredentialFile, f.BigQueryProject, f.BigQueryDataset, cacheClient)
+	if err != nil {
+		return nil, err
+	}
+	client.Budget = f.GetBudget()
+	return client, nil
 }
@@ -5,9 +5,9 @@ import (
 	"os"
 	"time"
 
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
 	"github.com/openshift/sippy/pkg/apis/api"
 	"github.com/pkg/errors"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 )
@@ -49,7 +49,8 @@ func (f *ComponentReadinessFlags) ParseViewsFile() (*api.SippyViews, error) {
 
 		err = f.validateViews(vf)
 		if err != nil {
-			log.WithError(err).Fatal("invalid view definition found")
+			err = errors.Wrapf(err, "invalid view definition found in %s", f.ComponentReadinessViewsFile)
+			return vf, err
 		}
 	}
 	return vf, nil
@@ -71,6 +72,9 @@ func (f *ComponentReadinessFlags) validateViews(views *api.SippyViews) error {
 		}
 
 		if view.RegressionTracking.Enabled {
+			if err := componentreadiness.ValidateRegressionDetectors(view); err != nil {
+				return err
+			}
 
 			if _, ok := viewsWithRegressionTracking[view.SampleRelease.Release]; !ok {
 				viewsWithRegressionTracking[view.SampleRelease.Release] = []string{}
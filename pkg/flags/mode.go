@@ -2,6 +2,7 @@ package flags
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/pflag"
 
@@ -17,6 +18,7 @@ type ModeFlags struct {
 
 const (
 	ModeOpenshift = "ocp"
+	ModeOKD       = "okd"
 	ModeNone      = "none"
 )
 
@@ -27,15 +29,27 @@ func NewModeFlags() *ModeFlags {
 }
 
 func (f *ModeFlags) BindFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&f.Mode, "mode", f.Mode, "Mode to use: {ocp,none}")
+	fs.StringVar(&f.Mode, "mode", f.Mode, "Mode to use: {ocp,okd,none}")
+}
+
+func (f *ModeFlags) Validate() error {
+	switch f.Mode {
+	case ModeOpenshift, ModeOKD, ModeNone:
+		return nil
+	default:
+		return fmt.Errorf("unknown mode %q, must be one of ocp, okd or none", f.Mode)
+	}
 }
 
 func (f *ModeFlags) GetServerMode() sippyserver.Mode {
-	if f.Mode == ModeOpenshift {
+	switch f.Mode {
+	case ModeOpenshift:
 		return sippyserver.ModeOpenShift
+	case ModeOKD:
+		return sippyserver.ModeOKD
+	default:
+		return sippyserver.ModeKubernetes
 	}
-
-	return sippyserver.ModeKubernetes
 }
 
 func (f *ModeFlags) GetVariantManager(ctx context.Context, bqc *bqcachedclient.Client) testidentification.VariantManager {
@@ -46,17 +60,22 @@ func (f *ModeFlags) GetVariantManager(ctx context.Context, bqc *bqcachedclient.C
 			panic(err)
 		}
 		return mgr
+	case ModeOKD:
+		return testidentification.NewOKDVariantManager()
 	case ModeNone:
 		return testidentification.NewEmptyVariantManager()
 	default:
-		panic("only ocp or none is allowed")
+		panic("only ocp, okd or none is allowed")
 	}
 }
 
 func (f *ModeFlags) GetSyntheticTestManager() synthetictests.SyntheticTestManager {
-	if f.Mode == ModeOpenshift {
+	switch f.Mode {
+	case ModeOpenshift:
 		return synthetictests.NewOpenshiftSyntheticTestManager()
+	case ModeOKD:
+		return synthetictests.NewOKDSyntheticTestManager()
+	default:
+		return synthetictests.NewEmptySyntheticTestManager()
 	}
-
-	return synthetictests.NewEmptySyntheticTestManager()
 }
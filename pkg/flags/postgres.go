@@ -1,6 +1,7 @@
 package flags
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -97,6 +98,26 @@ type PostgresFlags struct {
 	LogLevel logLevel
 	DSN      string
 
+	// SSLMode, SSLRootCert, SSLCert and SSLKey override the TLS settings in the DSN.
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+	// IAMAuth authenticates with short-lived cloud IAM tokens instead of the DSN's password, "gcp" or "aws".
+	IAMAuth string
+	// AWSRegion is the region of the RDS instance, for aws IAM authentication.
+	AWSRegion string
+	// Schema is the postgres schema to use instead of public, so environments can share a database.
+	Schema string
+
+	// SlowQueryThreshold logs and tracks queries taking longer than this, zero disables tracking.
+	SlowQueryThreshold time.Duration
+	// SlowQueryExplainSample is the fraction of slow queries to capture the EXPLAIN plan of.
+	SlowQueryExplainSample float64
+
+	// DiskBudget is the bytes the database should fit in, for warnings about its projected growth.
+	DiskBudget int64
+
 	// pinnedTime should not be exported. Use GetPinnedTime() instead.
 	pinnedTime PinnedTime
 }
@@ -108,23 +129,85 @@ func NewPostgresDatabaseFlags() *PostgresFlags {
 	}
 
 	return &PostgresFlags{
-		LogLevel: logLevel(logger.Info),
-		DSN:      dsn,
+		LogLevel:    logLevel(logger.Info),
+		DSN:         dsn,
+		SSLMode:     os.Getenv("SIPPY_DATABASE_SSLMODE"),
+		SSLRootCert: os.Getenv("SIPPY_DATABASE_SSLROOTCERT"),
+		SSLCert:     os.Getenv("SIPPY_DATABASE_SSLCERT"),
+		SSLKey:      os.Getenv("SIPPY_DATABASE_SSLKEY"),
+		IAMAuth:     os.Getenv("SIPPY_DATABASE_IAM_AUTH"),
+		AWSRegion:   os.Getenv("AWS_REGION"),
+		Schema:      os.Getenv("SIPPY_DATABASE_SCHEMA"),
 	}
 }
 
 func (f *PostgresFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.Var(&f.LogLevel, "db-log-level", "GORM database log level")
 	fs.StringVar(&f.DSN, "database-dsn", f.DSN, "Database DSN for connecting to Postgres")
+	fs.StringVar(&f.SSLMode, "database-sslmode", f.SSLMode,
+		"Postgres sslmode, overriding the DSN's: disable, require, verify-ca or verify-full")
+	fs.StringVar(&f.SSLRootCert, "database-ssl-root-cert", f.SSLRootCert,
+		"Path to the CA bundle to verify the database server's certificate with")
+	fs.StringVar(&f.SSLCert, "database-ssl-cert", f.SSLCert, "Path to a client certificate for the database")
+	fs.StringVar(&f.SSLKey, "database-ssl-key", f.SSLKey, "Path to the key of the database client certificate")
+	fs.StringVar(&f.IAMAuth, "database-iam-auth", f.IAMAuth,
+		"Authenticate to the database with refreshed cloud IAM tokens instead of a password: gcp (Cloud SQL) or aws (RDS)")
+	fs.StringVar(&f.AWSRegion, "database-aws-region", f.AWSRegion, "Region of the RDS instance, for aws IAM authentication; defaults to the AWS SDK configuration")
+	fs.StringVar(&f.Schema, "database-schema", f.Schema,
+		"Postgres schema for sippy's tables, views and functions instead of public, created if missing")
 	fs.Var(&f.pinnedTime, "pinned-date-time", "Pin database results to a fixed end date/time")
+	fs.DurationVar(&f.SlowQueryThreshold, "db-slow-query-threshold", f.SlowQueryThreshold,
+		"Log and track database queries slower than this, for /api/admin/slow_queries (0 disables)")
+	fs.Float64Var(&f.SlowQueryExplainSample, "db-slow-query-explain-sample", f.SlowQueryExplainSample,
+		"Fraction of slow SELECT queries to capture the EXPLAIN output of, between 0 and 1")
+	fs.Int64Var(&f.DiskBudget, "db-disk-budget", f.DiskBudget,
+		"Bytes the database should fit in, warned about by /api/admin/database/usage when growth is projected to exceed it (0 disables)")
 }
 
 func (f *PostgresFlags) GetDBClient() (*db.DB, error) {
-	dbc, err := db.New(f.DSN, logger.LogLevel(f.LogLevel))
+	opts, err := f.connectionOptions()
+	if err != nil {
+		log.WithError(err).Error("invalid database connection options")
+		return nil, err
+	}
+
+	dbc, err := db.NewWithOptions(f.DSN, opts, logger.LogLevel(f.LogLevel))
 	if err != nil {
 		log.WithError(err).Error("could not connect to db")
 		return nil, err
 	}
 
+	dbc.DiskBudget = f.DiskBudget
+
+	if f.SlowQueryThreshold > 0 {
+		dbc.SlowQueries = db.NewSlowQueryTracker(f.SlowQueryThreshold, f.SlowQueryExplainSample)
+		if err := dbc.DB.Use(dbc.SlowQueries); err != nil {
+			log.WithError(err).Error("could not register slow query tracker")
+			return nil, err
+		}
+	}
+
 	return dbc, nil
 }
+
+func (f *PostgresFlags) connectionOptions() (db.ConnectionOptions, error) {
+	opts := db.ConnectionOptions{
+		SSLMode:     f.SSLMode,
+		SSLRootCert: f.SSLRootCert,
+		SSLCert:     f.SSLCert,
+		SSLKey:      f.SSLKey,
+		Schema:      f.Schema,
+	}
+
+	var err error
+	switch f.IAMAuth {
+	case "":
+	case db.IAMAuthGCP:
+		opts.Password, err = db.GCPIAMPassword(context.Background())
+	case db.IAMAuthAWS:
+		opts.Password, err = db.AWSIAMPassword(context.Background(), f.AWSRegion)
+	default:
+		err = fmt.Errorf("unknown database IAM authentication %q, must be %s or %s", f.IAMAuth, db.IAMAuthGCP, db.IAMAuthAWS)
+	}
+	return opts, err
+}
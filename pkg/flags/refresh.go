@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/sippyserver"
+)
+
+// MatViewRefreshFlags controls how materialized views are refreshed.
+type MatViewRefreshFlags struct {
+	Parallelism int
+	Timeout     time.Duration
+}
+
+func NewMatViewRefreshFlags() *MatViewRefreshFlags {
+	defaults := sippyserver.DefaultRefreshOptions()
+	return &MatViewRefreshFlags{
+		Parallelism: defaults.Parallelism,
+		Timeout:     defaults.Timeout,
+	}
+}
+
+func (f *MatViewRefreshFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&f.Parallelism, "matview-refresh-parallelism", f.Parallelism, "Number of materialized views to refresh at once")
+	fs.DurationVar(&f.Timeout, "matview-refresh-timeout", f.Timeout, "Maximum time to spend refreshing a single materialized view before canceling it (0 for no limit)")
+}
+
+func (f *MatViewRefreshFlags) GetRefreshOptions(onlyIfEmpty bool) sippyserver.RefreshOptions {
+	return sippyserver.RefreshOptions{
+		OnlyIfEmpty: onlyIfEmpty,
+		Parallelism: f.Parallelism,
+		Timeout:     f.Timeout,
+	}
+}
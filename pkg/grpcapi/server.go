@@ -0,0 +1,298 @@
+// Package grpcapi serves a subset of the sippy API over gRPC for high-volume consumers. It shares the query layer
+// with the REST handlers; the service definition lives in sippy.proto, and sippy.pb.go and sippy_grpc.pb.go are
+// generated from it.
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/openshift/sippy/pkg/api"
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/util"
+)
+
+const (
+	// defaultJobRunsLimit is how many job runs ListJobRuns streams when the request has no limit.
+	defaultJobRunsLimit = 1000
+	// maxJobRunsLimit is the most job runs ListJobRuns streams, larger limits are clamped to it.
+	maxJobRunsLimit = 10000
+)
+
+// Server implements the sippy.v1.Sippy service.
+type Server struct {
+	UnimplementedSippyServer
+
+	dbc            *db.DB
+	pinnedDateTime *time.Time
+	indicators     func() []v1config.IndicatorConfig
+	openshift      bool
+	// promotionWarningThreshold is how long a payload stream can go without promoting before Health warns.
+	promotionWarningThreshold time.Duration
+}
+
+// NewServer creates the gRPC server. Health reports the indicators currently returned by indicators, so reloaded
+// configuration is picked up, or the OpenShift indicators if none are configured and openshift is true.
+func NewServer(dbc *db.DB, pinnedDateTime *time.Time, indicators func() []v1config.IndicatorConfig, openshift bool, promotionWarningThreshold time.Duration) *Server {
+	return &Server{
+		dbc:                       dbc,
+		pinnedDateTime:            pinnedDateTime,
+		indicators:                indicators,
+		openshift:                 openshift,
+		promotionWarningThreshold: promotionWarningThreshold,
+	}
+}
+
+// Serve listens on addr and blocks until the gRPC server stops.
+func (s *Server) Serve(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	gs := grpc.NewServer()
+	RegisterSippyServer(gs, s)
+	log.Infof("Serving gRPC API on %s", addr)
+	return gs.Serve(lis)
+}
+
+func (s *Server) reportEnd() time.Time {
+	return util.GetReportEnd(s.pinnedDateTime)
+}
+
+// Health returns the release health indicators, equivalent to /api/health.
+func (s *Server) Health(_ context.Context, req *HealthRequest) (*HealthResponse, error) {
+	if req.GetRelease() == "" {
+		return nil, status.Error(codes.InvalidArgument, "release is required")
+	}
+
+	indicators := api.ReleaseIndicators(s.indicators(), s.openshift, req.GetRelease())
+	health, err := api.GetReleaseHealthFromDB(s.dbc, req.GetRelease(), "", indicators, s.promotionWarningThreshold, s.reportEnd())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return healthResponse(health), nil
+}
+
+// ListTests streams test results, equivalent to /api/tests.
+func (s *Server) ListTests(req *ListTestsRequest, stream Sippy_ListTestsServer) error {
+	if req.GetRelease() == "" {
+		return status.Error(codes.InvalidArgument, "release is required")
+	}
+	period := req.GetPeriod()
+	if period != "" && period != "default" && period != "current" && period != "twoDay" {
+		return status.Error(codes.InvalidArgument, "unknown period")
+	}
+	collapse := true
+	if req.Collapse != nil {
+		collapse = req.GetCollapse()
+	}
+
+	tests, _, err := api.BuildTestsResults(s.dbc, req.GetRelease(), period, collapse, false, toFilter(req.GetFilter()),
+		s.reportEnd())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	for i := range tests {
+		if err := stream.Send(testMessage(tests[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListJobRuns streams job runs, equivalent to /api/jobs/runs.
+func (s *Server) ListJobRuns(req *ListJobRunsRequest, stream Sippy_ListJobRunsServer) error {
+	fil := toFilter(req.GetFilter())
+	if fil == nil {
+		fil = &filter.Filter{}
+	}
+	filterOpts := &filter.FilterOptions{
+		Filter:    fil,
+		SortField: "timestamp",
+		Sort:      apitype.SortDescending,
+		Limit:     jobRunsLimit(req.GetLimit()),
+	}
+	if req.GetSortField() != "" {
+		filterOpts.SortField = req.GetSortField()
+	}
+	if req.GetSort() == string(apitype.SortAscending) {
+		filterOpts.Sort = apitype.SortAscending
+	}
+
+	result, err := api.JobsRunsReportFromDB(s.dbc, filterOpts, req.GetRelease(), nil, s.reportEnd())
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	runs, _ := result.Rows.([]apitype.JobRun)
+	for i := range runs {
+		if err := stream.Send(jobRunMessage(runs[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobRunsLimit returns how many job runs to stream for the requested limit, the default if none was given and at
+// most maxJobRunsLimit.
+func jobRunsLimit(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultJobRunsLimit
+	case requested > maxJobRunsLimit:
+		return maxJobRunsLimit
+	default:
+		return int(requested)
+	}
+}
+
+// toFilter converts the request filter to the filter used by the query layer, nil if none was given.
+func toFilter(f *Filter) *filter.Filter {
+	if f == nil {
+		return nil
+	}
+	fil := &filter.Filter{
+		LinkOperator: filter.LinkOperator(f.GetLinkOperator()),
+	}
+	for _, item := range f.GetItems() {
+		fil.Items = append(fil.Items, filter.FilterItem{
+			Field:    item.GetColumnField(),
+			Not:      item.GetNot(),
+			Operator: filter.Operator(item.GetOperatorValue()),
+			Value:    item.GetValue(),
+		})
+	}
+	return fil
+}
+
+func healthResponse(health apitype.Health) *HealthResponse {
+	resp := &HealthResponse{
+		Indicators:         map[string]*Test{},
+		CurrentVariants:    variantHealthMessage(health.Variants.Current),
+		PreviousVariants:   variantHealthMessage(health.Variants.Previous),
+		LastUpdated:        timestamppb.New(health.LastUpdated),
+		Promotions:         map[string]*timestamppb.Timestamp{},
+		Warnings:           health.Warnings,
+		CurrentStatistics:  statisticsMessage(health.Current),
+		PreviousStatistics: statisticsMessage(health.Previous),
+		FlakyRuns: &FlakyRunSummary{
+			CurrentRuns:                int32(health.FlakyRuns.CurrentRuns),
+			CurrentFlakyRuns:           int32(health.FlakyRuns.CurrentFlakyRuns),
+			CurrentFlakyRunPercentage:  health.FlakyRuns.CurrentFlakyRunPercentage,
+			PreviousRuns:               int32(health.FlakyRuns.PreviousRuns),
+			PreviousFlakyRuns:          int32(health.FlakyRuns.PreviousFlakyRuns),
+			PreviousFlakyRunPercentage: health.FlakyRuns.PreviousFlakyRunPercentage,
+		},
+	}
+	for name, test := range health.Indicators {
+		resp.Indicators[name] = testMessage(test)
+	}
+	for release, promoted := range health.Promotions {
+		resp.Promotions[release] = timestamppb.New(promoted)
+	}
+	return resp
+}
+
+func variantHealthMessage(vh v1.VariantHealth) *VariantHealth {
+	return &VariantHealth{
+		Success:  int32(vh.Success),
+		Unstable: int32(vh.Unstable),
+		Failed:   int32(vh.Failed),
+	}
+}
+
+func statisticsMessage(stats v1.Statistics) *Statistics {
+	msg := &Statistics{
+		Mean:              stats.Mean,
+		StandardDeviation: stats.StandardDeviation,
+		Quartiles:         stats.Quartiles,
+		P95:               stats.P95,
+	}
+	for _, count := range stats.Histogram {
+		msg.Histogram = append(msg.Histogram, int32(count))
+	}
+	return msg
+}
+
+func testMessage(t apitype.Test) *Test {
+	return &Test{
+		Id:                        int64(t.ID),
+		Name:                      t.Name,
+		Hash:                      t.Hash,
+		SuiteName:                 t.SuiteName,
+		Variant:                   t.Variant,
+		Variants:                  t.Variants,
+		JiraComponent:             t.JiraComponent,
+		JiraComponentId:           int64(t.JiraComponentID),
+		CurrentSuccesses:          int32(t.CurrentSuccesses),
+		CurrentFailures:           int32(t.CurrentFailures),
+		CurrentFlakes:             int32(t.CurrentFlakes),
+		CurrentPassPercentage:     t.CurrentPassPercentage,
+		CurrentFailurePercentage:  t.CurrentFailurePercentage,
+		CurrentFlakePercentage:    t.CurrentFlakePercentage,
+		CurrentWorkingPercentage:  t.CurrentWorkingPercentage,
+		CurrentRuns:               int32(t.CurrentRuns),
+		PreviousSuccesses:         int32(t.PreviousSuccesses),
+		PreviousFailures:          int32(t.PreviousFailures),
+		PreviousFlakes:            int32(t.PreviousFlakes),
+		PreviousPassPercentage:    t.PreviousPassPercentage,
+		PreviousFailurePercentage: t.PreviousFailurePercentage,
+		PreviousFlakePercentage:   t.PreviousFlakePercentage,
+		PreviousWorkingPercentage: t.PreviousWorkingPercentage,
+		PreviousRuns:              int32(t.PreviousRuns),
+		NetFailureImprovement:     t.NetFailureImprovement,
+		NetFlakeImprovement:       t.NetFlakeImprovement,
+		NetWorkingImprovement:     t.NetWorkingImprovement,
+		NetImprovement:            t.NetImprovement,
+		Watchlist:                 t.Watchlist,
+		Tags:                      t.Tags,
+		OpenBugs:                  int32(t.OpenBugs),
+		Suppressed:                t.Suppressed,
+	}
+}
+
+func jobRunMessage(run apitype.JobRun) *JobRun {
+	msg := &JobRun{
+		Id:                    int64(run.ID),
+		BriefName:             run.BriefName,
+		Variants:              run.Variants,
+		Tags:                  run.Tags,
+		TestGridUrl:           run.TestGridURL,
+		ProwId:                uint64(run.ProwID),
+		Job:                   run.Job,
+		Cluster:               run.Cluster,
+		Url:                   run.URL,
+		TestFlakes:            int32(run.TestFlakes),
+		FlakedTestNames:       run.FlakedTestNames,
+		TestFailures:          int32(run.TestFailures),
+		FailedTestNames:       run.FailedTestNames,
+		Failed:                run.Failed,
+		InfrastructureFailure: run.InfrastructureFailure,
+		KnownFailure:          run.KnownFailure,
+		ProbableCause:         run.ProbableCause,
+		Succeeded:             run.Succeeded,
+		Timestamp:             int64(run.Timestamp),
+		OverallResult:         string(run.OverallResult),
+		PullRequestOrg:        run.PullRequestOrg,
+		PullRequestRepo:       run.PullRequestRepo,
+		PullRequestLink:       run.PullRequestLink,
+		PullRequestSha:        run.PullRequestSHA,
+		PullRequestAuthor:     run.PullRequestAuthor,
+		PullRequestNumber:     int64(run.PullRequestNumber),
+		Kind:                  run.Kind,
+	}
+	for _, artifact := range run.DebugArtifacts {
+		msg.DebugArtifacts = append(msg.DebugArtifacts, &DebugArtifact{Kind: artifact.Kind, Url: artifact.URL})
+	}
+	return msg
+}
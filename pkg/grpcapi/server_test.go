@@ -0,0 +1,103 @@
+package grpcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+func TestToFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *Filter
+		expected *filter.Filter
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name: "filter items",
+			filter: &Filter{
+				Items: []*FilterItem{
+					{ColumnField: "name", OperatorValue: "contains", Value: "sig-network"},
+					{ColumnField: "variants", Not: true, OperatorValue: "contains", Value: "aws"},
+				},
+				LinkOperator: "or",
+			},
+			expected: &filter.Filter{
+				Items: []filter.FilterItem{
+					{Field: "name", Operator: filter.OperatorContains, Value: "sig-network"},
+					{Field: "variants", Not: true, Operator: filter.OperatorContains, Value: "aws"},
+				},
+				LinkOperator: filter.LinkOperatorOr,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toFilter(tt.filter))
+		})
+	}
+}
+
+func TestHealthResponse(t *testing.T) {
+	promoted := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	resp := healthResponse(apitype.Health{
+		Indicators: map[string]apitype.Test{
+			"install": {Name: "install should succeed", CurrentRuns: 3, CurrentPassPercentage: 66.6},
+		},
+		Variants: apitype.Variants{
+			Current: v1.VariantHealth{Success: 1, Unstable: 2, Failed: 3},
+		},
+		LastUpdated: promoted,
+		Promotions:  map[string]time.Time{"nightly": promoted},
+		Current:     v1.Statistics{Mean: 80, Histogram: []int{1, 2}},
+	})
+
+	assert.Equal(t, "install should succeed", resp.GetIndicators()["install"].GetName())
+	assert.Equal(t, int32(3), resp.GetIndicators()["install"].GetCurrentRuns())
+	assert.Equal(t, int32(2), resp.GetCurrentVariants().GetUnstable())
+	assert.Equal(t, promoted, resp.GetLastUpdated().AsTime())
+	assert.Equal(t, promoted, resp.GetPromotions()["nightly"].AsTime())
+	assert.Equal(t, []int32{1, 2}, resp.GetCurrentStatistics().GetHistogram())
+}
+
+func TestJobRunMessage(t *testing.T) {
+	msg := jobRunMessage(apitype.JobRun{
+		ID:             7,
+		Job:            "periodic-ci-openshift-release-master-ci-4.16-e2e-aws",
+		ProwID:         1234,
+		Timestamp:      1714564800000,
+		OverallResult:  v1.JobSucceeded,
+		DebugArtifacts: []apitype.DebugArtifact{{Kind: "must-gather", URL: "https://example.com/must-gather.tar"}},
+	})
+
+	assert.Equal(t, int64(7), msg.GetId())
+	assert.Equal(t, uint64(1234), msg.GetProwId())
+	assert.Equal(t, int64(1714564800000), msg.GetTimestamp())
+	assert.Equal(t, string(v1.JobSucceeded), msg.GetOverallResult())
+	assert.Equal(t, "must-gather", msg.GetDebugArtifacts()[0].GetKind())
+}
+
+func TestJobRunsLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int32
+		want      int
+	}{
+		{name: "no limit uses the default", requested: 0, want: defaultJobRunsLimit},
+		{name: "negative limit uses the default", requested: -1, want: defaultJobRunsLimit},
+		{name: "limit within the maximum is kept", requested: 50, want: 50},
+		{name: "limit above the maximum is clamped", requested: maxJobRunsLimit + 1, want: maxJobRunsLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jobRunsLimit(tt.requested))
+		})
+	}
+}
@@ -0,0 +1,1839 @@
+// The sippy gRPC API exposes the same reports as the REST API for high-volume consumers such as the release
+// controller. Messages mirror the JSON responses field for field, and large result sets are streamed one row per
+// message.
+//
+// Regenerate the Go stubs after changing this file with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative sippy.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: sippy.proto
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Filter is the same structure as the REST API's filter query parameter.
+type Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*FilterItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// link_operator is "and" or "or", defaulting to "and".
+	LinkOperator string `protobuf:"bytes,2,opt,name=link_operator,json=linkOperator,proto3" json:"link_operator,omitempty"`
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Filter) GetItems() []*FilterItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Filter) GetLinkOperator() string {
+	if x != nil {
+		return x.LinkOperator
+	}
+	return ""
+}
+
+type FilterItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ColumnField   string `protobuf:"bytes,1,opt,name=column_field,json=columnField,proto3" json:"column_field,omitempty"`
+	Not           bool   `protobuf:"varint,2,opt,name=not,proto3" json:"not,omitempty"`
+	OperatorValue string `protobuf:"bytes,3,opt,name=operator_value,json=operatorValue,proto3" json:"operator_value,omitempty"`
+	Value         string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *FilterItem) Reset() {
+	*x = FilterItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterItem) ProtoMessage() {}
+
+func (x *FilterItem) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterItem.ProtoReflect.Descriptor instead.
+func (*FilterItem) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterItem) GetColumnField() string {
+	if x != nil {
+		return x.ColumnField
+	}
+	return ""
+}
+
+func (x *FilterItem) GetNot() bool {
+	if x != nil {
+		return x.Not
+	}
+	return false
+}
+
+func (x *FilterItem) GetOperatorValue() string {
+	if x != nil {
+		return x.OperatorValue
+	}
+	return ""
+}
+
+func (x *FilterItem) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type HealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Release string `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HealthRequest) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+type ListTestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Release string `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	// period is default, current or twoDay.
+	Period string `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	// collapse rolls variants up into one result per test, defaulting to true.
+	Collapse *bool   `protobuf:"varint,3,opt,name=collapse,proto3,oneof" json:"collapse,omitempty"`
+	Filter   *Filter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *ListTestsRequest) Reset() {
+	*x = ListTestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTestsRequest) ProtoMessage() {}
+
+func (x *ListTestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTestsRequest.ProtoReflect.Descriptor instead.
+func (*ListTestsRequest) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTestsRequest) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+func (x *ListTestsRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *ListTestsRequest) GetCollapse() bool {
+	if x != nil && x.Collapse != nil {
+		return *x.Collapse
+	}
+	return false
+}
+
+func (x *ListTestsRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListJobRunsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Release string  `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	Filter  *Filter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// sort_field defaults to timestamp.
+	SortField string `protobuf:"bytes,3,opt,name=sort_field,json=sortField,proto3" json:"sort_field,omitempty"`
+	// sort is asc or desc, defaulting to desc.
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// limit is the most job runs streamed, defaulting to 1000 and at most 10000.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListJobRunsRequest) Reset() {
+	*x = ListJobRunsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRunsRequest) ProtoMessage() {}
+
+func (x *ListJobRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobRunsRequest) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListJobRunsRequest) GetRelease() string {
+	if x != nil {
+		return x.Release
+	}
+	return ""
+}
+
+func (x *ListJobRunsRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListJobRunsRequest) GetSortField() string {
+	if x != nil {
+		return x.SortField
+	}
+	return ""
+}
+
+func (x *ListJobRunsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListJobRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Indicators         map[string]*Test                  `protobuf:"bytes,1,rep,name=indicators,proto3" json:"indicators,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CurrentVariants    *VariantHealth                    `protobuf:"bytes,2,opt,name=current_variants,json=currentVariants,proto3" json:"current_variants,omitempty"`
+	PreviousVariants   *VariantHealth                    `protobuf:"bytes,3,opt,name=previous_variants,json=previousVariants,proto3" json:"previous_variants,omitempty"`
+	LastUpdated        *timestamppb.Timestamp            `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Promotions         map[string]*timestamppb.Timestamp `protobuf:"bytes,5,rep,name=promotions,proto3" json:"promotions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Warnings           []string                          `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	CurrentStatistics  *Statistics                       `protobuf:"bytes,7,opt,name=current_statistics,json=currentStatistics,proto3" json:"current_statistics,omitempty"`
+	PreviousStatistics *Statistics                       `protobuf:"bytes,8,opt,name=previous_statistics,json=previousStatistics,proto3" json:"previous_statistics,omitempty"`
+	FlakyRuns          *FlakyRunSummary                  `protobuf:"bytes,9,opt,name=flaky_runs,json=flakyRuns,proto3" json:"flaky_runs,omitempty"`
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HealthResponse) GetIndicators() map[string]*Test {
+	if x != nil {
+		return x.Indicators
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetCurrentVariants() *VariantHealth {
+	if x != nil {
+		return x.CurrentVariants
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetPreviousVariants() *VariantHealth {
+	if x != nil {
+		return x.PreviousVariants
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetPromotions() map[string]*timestamppb.Timestamp {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetCurrentStatistics() *Statistics {
+	if x != nil {
+		return x.CurrentStatistics
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetPreviousStatistics() *Statistics {
+	if x != nil {
+		return x.PreviousStatistics
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetFlakyRuns() *FlakyRunSummary {
+	if x != nil {
+		return x.FlakyRuns
+	}
+	return nil
+}
+
+type VariantHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success  int32 `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Unstable int32 `protobuf:"varint,2,opt,name=unstable,proto3" json:"unstable,omitempty"`
+	Failed   int32 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (x *VariantHealth) Reset() {
+	*x = VariantHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VariantHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantHealth) ProtoMessage() {}
+
+func (x *VariantHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantHealth.ProtoReflect.Descriptor instead.
+func (*VariantHealth) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VariantHealth) GetSuccess() int32 {
+	if x != nil {
+		return x.Success
+	}
+	return 0
+}
+
+func (x *VariantHealth) GetUnstable() int32 {
+	if x != nil {
+		return x.Unstable
+	}
+	return 0
+}
+
+func (x *VariantHealth) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type Statistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mean              float64   `protobuf:"fixed64,1,opt,name=mean,proto3" json:"mean,omitempty"`
+	StandardDeviation float64   `protobuf:"fixed64,2,opt,name=standard_deviation,json=standardDeviation,proto3" json:"standard_deviation,omitempty"`
+	Histogram         []int32   `protobuf:"varint,3,rep,packed,name=histogram,proto3" json:"histogram,omitempty"`
+	Quartiles         []float64 `protobuf:"fixed64,4,rep,packed,name=quartiles,proto3" json:"quartiles,omitempty"`
+	P95               float64   `protobuf:"fixed64,5,opt,name=p95,proto3" json:"p95,omitempty"`
+}
+
+func (x *Statistics) Reset() {
+	*x = Statistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Statistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistics) ProtoMessage() {}
+
+func (x *Statistics) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Statistics) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *Statistics) GetStandardDeviation() float64 {
+	if x != nil {
+		return x.StandardDeviation
+	}
+	return 0
+}
+
+func (x *Statistics) GetHistogram() []int32 {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *Statistics) GetQuartiles() []float64 {
+	if x != nil {
+		return x.Quartiles
+	}
+	return nil
+}
+
+func (x *Statistics) GetP95() float64 {
+	if x != nil {
+		return x.P95
+	}
+	return 0
+}
+
+type FlakyRunSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentRuns                int32   `protobuf:"varint,1,opt,name=current_runs,json=currentRuns,proto3" json:"current_runs,omitempty"`
+	CurrentFlakyRuns           int32   `protobuf:"varint,2,opt,name=current_flaky_runs,json=currentFlakyRuns,proto3" json:"current_flaky_runs,omitempty"`
+	CurrentFlakyRunPercentage  float64 `protobuf:"fixed64,3,opt,name=current_flaky_run_percentage,json=currentFlakyRunPercentage,proto3" json:"current_flaky_run_percentage,omitempty"`
+	PreviousRuns               int32   `protobuf:"varint,4,opt,name=previous_runs,json=previousRuns,proto3" json:"previous_runs,omitempty"`
+	PreviousFlakyRuns          int32   `protobuf:"varint,5,opt,name=previous_flaky_runs,json=previousFlakyRuns,proto3" json:"previous_flaky_runs,omitempty"`
+	PreviousFlakyRunPercentage float64 `protobuf:"fixed64,6,opt,name=previous_flaky_run_percentage,json=previousFlakyRunPercentage,proto3" json:"previous_flaky_run_percentage,omitempty"`
+}
+
+func (x *FlakyRunSummary) Reset() {
+	*x = FlakyRunSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlakyRunSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlakyRunSummary) ProtoMessage() {}
+
+func (x *FlakyRunSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlakyRunSummary.ProtoReflect.Descriptor instead.
+func (*FlakyRunSummary) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FlakyRunSummary) GetCurrentRuns() int32 {
+	if x != nil {
+		return x.CurrentRuns
+	}
+	return 0
+}
+
+func (x *FlakyRunSummary) GetCurrentFlakyRuns() int32 {
+	if x != nil {
+		return x.CurrentFlakyRuns
+	}
+	return 0
+}
+
+func (x *FlakyRunSummary) GetCurrentFlakyRunPercentage() float64 {
+	if x != nil {
+		return x.CurrentFlakyRunPercentage
+	}
+	return 0
+}
+
+func (x *FlakyRunSummary) GetPreviousRuns() int32 {
+	if x != nil {
+		return x.PreviousRuns
+	}
+	return 0
+}
+
+func (x *FlakyRunSummary) GetPreviousFlakyRuns() int32 {
+	if x != nil {
+		return x.PreviousFlakyRuns
+	}
+	return 0
+}
+
+func (x *FlakyRunSummary) GetPreviousFlakyRunPercentage() float64 {
+	if x != nil {
+		return x.PreviousFlakyRunPercentage
+	}
+	return 0
+}
+
+type Test struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                        int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                      string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Hash                      string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	SuiteName                 string   `protobuf:"bytes,4,opt,name=suite_name,json=suiteName,proto3" json:"suite_name,omitempty"`
+	Variant                   string   `protobuf:"bytes,5,opt,name=variant,proto3" json:"variant,omitempty"`
+	Variants                  []string `protobuf:"bytes,6,rep,name=variants,proto3" json:"variants,omitempty"`
+	JiraComponent             string   `protobuf:"bytes,7,opt,name=jira_component,json=jiraComponent,proto3" json:"jira_component,omitempty"`
+	JiraComponentId           int64    `protobuf:"varint,8,opt,name=jira_component_id,json=jiraComponentId,proto3" json:"jira_component_id,omitempty"`
+	CurrentSuccesses          int32    `protobuf:"varint,9,opt,name=current_successes,json=currentSuccesses,proto3" json:"current_successes,omitempty"`
+	CurrentFailures           int32    `protobuf:"varint,10,opt,name=current_failures,json=currentFailures,proto3" json:"current_failures,omitempty"`
+	CurrentFlakes             int32    `protobuf:"varint,11,opt,name=current_flakes,json=currentFlakes,proto3" json:"current_flakes,omitempty"`
+	CurrentPassPercentage     float64  `protobuf:"fixed64,12,opt,name=current_pass_percentage,json=currentPassPercentage,proto3" json:"current_pass_percentage,omitempty"`
+	CurrentFailurePercentage  float64  `protobuf:"fixed64,13,opt,name=current_failure_percentage,json=currentFailurePercentage,proto3" json:"current_failure_percentage,omitempty"`
+	CurrentFlakePercentage    float64  `protobuf:"fixed64,14,opt,name=current_flake_percentage,json=currentFlakePercentage,proto3" json:"current_flake_percentage,omitempty"`
+	CurrentWorkingPercentage  float64  `protobuf:"fixed64,15,opt,name=current_working_percentage,json=currentWorkingPercentage,proto3" json:"current_working_percentage,omitempty"`
+	CurrentRuns               int32    `protobuf:"varint,16,opt,name=current_runs,json=currentRuns,proto3" json:"current_runs,omitempty"`
+	PreviousSuccesses         int32    `protobuf:"varint,17,opt,name=previous_successes,json=previousSuccesses,proto3" json:"previous_successes,omitempty"`
+	PreviousFailures          int32    `protobuf:"varint,18,opt,name=previous_failures,json=previousFailures,proto3" json:"previous_failures,omitempty"`
+	PreviousFlakes            int32    `protobuf:"varint,19,opt,name=previous_flakes,json=previousFlakes,proto3" json:"previous_flakes,omitempty"`
+	PreviousPassPercentage    float64  `protobuf:"fixed64,20,opt,name=previous_pass_percentage,json=previousPassPercentage,proto3" json:"previous_pass_percentage,omitempty"`
+	PreviousFailurePercentage float64  `protobuf:"fixed64,21,opt,name=previous_failure_percentage,json=previousFailurePercentage,proto3" json:"previous_failure_percentage,omitempty"`
+	PreviousFlakePercentage   float64  `protobuf:"fixed64,22,opt,name=previous_flake_percentage,json=previousFlakePercentage,proto3" json:"previous_flake_percentage,omitempty"`
+	PreviousWorkingPercentage float64  `protobuf:"fixed64,23,opt,name=previous_working_percentage,json=previousWorkingPercentage,proto3" json:"previous_working_percentage,omitempty"`
+	PreviousRuns              int32    `protobuf:"varint,24,opt,name=previous_runs,json=previousRuns,proto3" json:"previous_runs,omitempty"`
+	NetFailureImprovement     float64  `protobuf:"fixed64,25,opt,name=net_failure_improvement,json=netFailureImprovement,proto3" json:"net_failure_improvement,omitempty"`
+	NetFlakeImprovement       float64  `protobuf:"fixed64,26,opt,name=net_flake_improvement,json=netFlakeImprovement,proto3" json:"net_flake_improvement,omitempty"`
+	NetWorkingImprovement     float64  `protobuf:"fixed64,27,opt,name=net_working_improvement,json=netWorkingImprovement,proto3" json:"net_working_improvement,omitempty"`
+	NetImprovement            float64  `protobuf:"fixed64,28,opt,name=net_improvement,json=netImprovement,proto3" json:"net_improvement,omitempty"`
+	Watchlist                 bool     `protobuf:"varint,29,opt,name=watchlist,proto3" json:"watchlist,omitempty"`
+	Tags                      []string `protobuf:"bytes,30,rep,name=tags,proto3" json:"tags,omitempty"`
+	OpenBugs                  int32    `protobuf:"varint,31,opt,name=open_bugs,json=openBugs,proto3" json:"open_bugs,omitempty"`
+	Suppressed                bool     `protobuf:"varint,32,opt,name=suppressed,proto3" json:"suppressed,omitempty"`
+}
+
+func (x *Test) Reset() {
+	*x = Test{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Test) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Test) ProtoMessage() {}
+
+func (x *Test) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Test.ProtoReflect.Descriptor instead.
+func (*Test) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Test) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Test) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Test) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Test) GetSuiteName() string {
+	if x != nil {
+		return x.SuiteName
+	}
+	return ""
+}
+
+func (x *Test) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Test) GetVariants() []string {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Test) GetJiraComponent() string {
+	if x != nil {
+		return x.JiraComponent
+	}
+	return ""
+}
+
+func (x *Test) GetJiraComponentId() int64 {
+	if x != nil {
+		return x.JiraComponentId
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentSuccesses() int32 {
+	if x != nil {
+		return x.CurrentSuccesses
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentFailures() int32 {
+	if x != nil {
+		return x.CurrentFailures
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentFlakes() int32 {
+	if x != nil {
+		return x.CurrentFlakes
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentPassPercentage() float64 {
+	if x != nil {
+		return x.CurrentPassPercentage
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentFailurePercentage() float64 {
+	if x != nil {
+		return x.CurrentFailurePercentage
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentFlakePercentage() float64 {
+	if x != nil {
+		return x.CurrentFlakePercentage
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentWorkingPercentage() float64 {
+	if x != nil {
+		return x.CurrentWorkingPercentage
+	}
+	return 0
+}
+
+func (x *Test) GetCurrentRuns() int32 {
+	if x != nil {
+		return x.CurrentRuns
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousSuccesses() int32 {
+	if x != nil {
+		return x.PreviousSuccesses
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousFailures() int32 {
+	if x != nil {
+		return x.PreviousFailures
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousFlakes() int32 {
+	if x != nil {
+		return x.PreviousFlakes
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousPassPercentage() float64 {
+	if x != nil {
+		return x.PreviousPassPercentage
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousFailurePercentage() float64 {
+	if x != nil {
+		return x.PreviousFailurePercentage
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousFlakePercentage() float64 {
+	if x != nil {
+		return x.PreviousFlakePercentage
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousWorkingPercentage() float64 {
+	if x != nil {
+		return x.PreviousWorkingPercentage
+	}
+	return 0
+}
+
+func (x *Test) GetPreviousRuns() int32 {
+	if x != nil {
+		return x.PreviousRuns
+	}
+	return 0
+}
+
+func (x *Test) GetNetFailureImprovement() float64 {
+	if x != nil {
+		return x.NetFailureImprovement
+	}
+	return 0
+}
+
+func (x *Test) GetNetFlakeImprovement() float64 {
+	if x != nil {
+		return x.NetFlakeImprovement
+	}
+	return 0
+}
+
+func (x *Test) GetNetWorkingImprovement() float64 {
+	if x != nil {
+		return x.NetWorkingImprovement
+	}
+	return 0
+}
+
+func (x *Test) GetNetImprovement() float64 {
+	if x != nil {
+		return x.NetImprovement
+	}
+	return 0
+}
+
+func (x *Test) GetWatchlist() bool {
+	if x != nil {
+		return x.Watchlist
+	}
+	return false
+}
+
+func (x *Test) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Test) GetOpenBugs() int32 {
+	if x != nil {
+		return x.OpenBugs
+	}
+	return 0
+}
+
+func (x *Test) GetSuppressed() bool {
+	if x != nil {
+		return x.Suppressed
+	}
+	return false
+}
+
+type JobRun struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                    int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BriefName             string   `protobuf:"bytes,2,opt,name=brief_name,json=briefName,proto3" json:"brief_name,omitempty"`
+	Variants              []string `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	Tags                  []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	TestGridUrl           string   `protobuf:"bytes,5,opt,name=test_grid_url,json=testGridUrl,proto3" json:"test_grid_url,omitempty"`
+	ProwId                uint64   `protobuf:"varint,6,opt,name=prow_id,json=prowId,proto3" json:"prow_id,omitempty"`
+	Job                   string   `protobuf:"bytes,7,opt,name=job,proto3" json:"job,omitempty"`
+	Cluster               string   `protobuf:"bytes,8,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Url                   string   `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	TestFlakes            int32    `protobuf:"varint,10,opt,name=test_flakes,json=testFlakes,proto3" json:"test_flakes,omitempty"`
+	FlakedTestNames       []string `protobuf:"bytes,11,rep,name=flaked_test_names,json=flakedTestNames,proto3" json:"flaked_test_names,omitempty"`
+	TestFailures          int32    `protobuf:"varint,12,opt,name=test_failures,json=testFailures,proto3" json:"test_failures,omitempty"`
+	FailedTestNames       []string `protobuf:"bytes,13,rep,name=failed_test_names,json=failedTestNames,proto3" json:"failed_test_names,omitempty"`
+	Failed                bool     `protobuf:"varint,14,opt,name=failed,proto3" json:"failed,omitempty"`
+	InfrastructureFailure bool     `protobuf:"varint,15,opt,name=infrastructure_failure,json=infrastructureFailure,proto3" json:"infrastructure_failure,omitempty"`
+	KnownFailure          bool     `protobuf:"varint,16,opt,name=known_failure,json=knownFailure,proto3" json:"known_failure,omitempty"`
+	ProbableCause         string   `protobuf:"bytes,17,opt,name=probable_cause,json=probableCause,proto3" json:"probable_cause,omitempty"`
+	Succeeded             bool     `protobuf:"varint,18,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// timestamp is in milliseconds since the epoch, as in the REST API.
+	Timestamp         int64            `protobuf:"varint,19,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OverallResult     string           `protobuf:"bytes,20,opt,name=overall_result,json=overallResult,proto3" json:"overall_result,omitempty"`
+	PullRequestOrg    string           `protobuf:"bytes,21,opt,name=pull_request_org,json=pullRequestOrg,proto3" json:"pull_request_org,omitempty"`
+	PullRequestRepo   string           `protobuf:"bytes,22,opt,name=pull_request_repo,json=pullRequestRepo,proto3" json:"pull_request_repo,omitempty"`
+	PullRequestLink   string           `protobuf:"bytes,23,opt,name=pull_request_link,json=pullRequestLink,proto3" json:"pull_request_link,omitempty"`
+	PullRequestSha    string           `protobuf:"bytes,24,opt,name=pull_request_sha,json=pullRequestSha,proto3" json:"pull_request_sha,omitempty"`
+	PullRequestAuthor string           `protobuf:"bytes,25,opt,name=pull_request_author,json=pullRequestAuthor,proto3" json:"pull_request_author,omitempty"`
+	PullRequestNumber int64            `protobuf:"varint,26,opt,name=pull_request_number,json=pullRequestNumber,proto3" json:"pull_request_number,omitempty"`
+	Kind              string           `protobuf:"bytes,27,opt,name=kind,proto3" json:"kind,omitempty"`
+	DebugArtifacts    []*DebugArtifact `protobuf:"bytes,28,rep,name=debug_artifacts,json=debugArtifacts,proto3" json:"debug_artifacts,omitempty"`
+}
+
+func (x *JobRun) Reset() {
+	*x = JobRun{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRun) ProtoMessage() {}
+
+func (x *JobRun) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRun.ProtoReflect.Descriptor instead.
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JobRun) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobRun) GetBriefName() string {
+	if x != nil {
+		return x.BriefName
+	}
+	return ""
+}
+
+func (x *JobRun) GetVariants() []string {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *JobRun) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *JobRun) GetTestGridUrl() string {
+	if x != nil {
+		return x.TestGridUrl
+	}
+	return ""
+}
+
+func (x *JobRun) GetProwId() uint64 {
+	if x != nil {
+		return x.ProwId
+	}
+	return 0
+}
+
+func (x *JobRun) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+func (x *JobRun) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *JobRun) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *JobRun) GetTestFlakes() int32 {
+	if x != nil {
+		return x.TestFlakes
+	}
+	return 0
+}
+
+func (x *JobRun) GetFlakedTestNames() []string {
+	if x != nil {
+		return x.FlakedTestNames
+	}
+	return nil
+}
+
+func (x *JobRun) GetTestFailures() int32 {
+	if x != nil {
+		return x.TestFailures
+	}
+	return 0
+}
+
+func (x *JobRun) GetFailedTestNames() []string {
+	if x != nil {
+		return x.FailedTestNames
+	}
+	return nil
+}
+
+func (x *JobRun) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *JobRun) GetInfrastructureFailure() bool {
+	if x != nil {
+		return x.InfrastructureFailure
+	}
+	return false
+}
+
+func (x *JobRun) GetKnownFailure() bool {
+	if x != nil {
+		return x.KnownFailure
+	}
+	return false
+}
+
+func (x *JobRun) GetProbableCause() string {
+	if x != nil {
+		return x.ProbableCause
+	}
+	return ""
+}
+
+func (x *JobRun) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *JobRun) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *JobRun) GetOverallResult() string {
+	if x != nil {
+		return x.OverallResult
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestOrg() string {
+	if x != nil {
+		return x.PullRequestOrg
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestRepo() string {
+	if x != nil {
+		return x.PullRequestRepo
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestLink() string {
+	if x != nil {
+		return x.PullRequestLink
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestSha() string {
+	if x != nil {
+		return x.PullRequestSha
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestAuthor() string {
+	if x != nil {
+		return x.PullRequestAuthor
+	}
+	return ""
+}
+
+func (x *JobRun) GetPullRequestNumber() int64 {
+	if x != nil {
+		return x.PullRequestNumber
+	}
+	return 0
+}
+
+func (x *JobRun) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *JobRun) GetDebugArtifacts() []*DebugArtifact {
+	if x != nil {
+		return x.DebugArtifacts
+	}
+	return nil
+}
+
+type DebugArtifact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Url  string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *DebugArtifact) Reset() {
+	*x = DebugArtifact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sippy_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugArtifact) ProtoMessage() {}
+
+func (x *DebugArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_sippy_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugArtifact.ProtoReflect.Descriptor instead.
+func (*DebugArtifact) Descriptor() ([]byte, []int) {
+	return file_sippy_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DebugArtifact) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DebugArtifact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_sippy_proto protoreflect.FileDescriptor
+
+var file_sippy_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73,
+	0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x59, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x69, 0x6e, 0x6b, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x22, 0x7e, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x0d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x9c,
+	0x01, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x08, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x6c, 0x6c, 0x61,
+	0x70, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x22, 0xa1, 0x01,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x75, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x28,
+	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f,
+	0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0xf9, 0x05, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x42,
+	0x0a, 0x10, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x74, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x73, 0x69,
+	0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x43, 0x0a,
+	0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x69, 0x70, 0x70,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52,
+	0x11, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x45, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x66, 0x6c, 0x61,
+	0x6b, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x75,
+	0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x09, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x52,
+	0x75, 0x6e, 0x73, 0x1a, 0x4d, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x59, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5d, 0x0a,
+	0x0d, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x73, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x75, 0x6e, 0x73, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x9d, 0x01, 0x0a,
+	0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x65, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x12,
+	0x2d, 0x0a, 0x12, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x61, 0x72, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73, 0x74, 0x61,
+	0x6e, 0x64, 0x61, 0x72, 0x64, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1c, 0x0a, 0x09,
+	0x71, 0x75, 0x61, 0x72, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x09, 0x71, 0x75, 0x61, 0x72, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x39,
+	0x35, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x70, 0x39, 0x35, 0x22, 0xbb, 0x02, 0x0a,
+	0x0f, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x75, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52,
+	0x75, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66,
+	0x6c, 0x61, 0x6b, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x10, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x75, 0x6e,
+	0x73, 0x12, 0x3f, 0x0a, 0x1c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x6c, 0x61,
+	0x6b, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x19, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x75, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x72,
+	0x75, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x52, 0x75, 0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x6c,
+	0x61, 0x6b, 0x79, 0x52, 0x75, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x1d, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x1a,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x75, 0x6e,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0xd2, 0x0a, 0x0a, 0x04, 0x54,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x75, 0x69, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x75, 0x69, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x61,
+	0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x6a, 0x69, 0x72, 0x61, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6a, 0x69, 0x72, 0x61, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6a, 0x69, 0x72, 0x61, 0x5f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x6a, 0x69, 0x72, 0x61, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x6c, 0x61, 0x6b,
+	0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61,
+	0x73, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x15, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x73, 0x73,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x61, 0x67, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x46, 0x6c, 0x61, 0x6b, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x77, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x57,
+	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x73,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52,
+	0x75, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66, 0x6c, 0x61, 0x6b,
+	0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x46, 0x6c, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x61, 0x67, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x50, 0x61, 0x73, 0x73, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x19, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66,
+	0x6c, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46,
+	0x6c, 0x61, 0x6b, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3e,
+	0x0a, 0x1b, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x69,
+	0x6e, 0x67, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x19, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x57, 0x6f, 0x72,
+	0x6b, 0x69, 0x6e, 0x67, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x52,
+	0x75, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6e, 0x65, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x5f, 0x69, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x6e, 0x65, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x49, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x6e,
+	0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x5f, 0x69, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x6e, 0x65, 0x74, 0x46,
+	0x6c, 0x61, 0x6b, 0x65, 0x49, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x36, 0x0a, 0x17, 0x6e, 0x65, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x69,
+	0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x15, 0x6e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x6d, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x5f, 0x69,
+	0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0e, 0x6e, 0x65, 0x74, 0x49, 0x6d, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x1d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x62, 0x75, 0x67, 0x73, 0x18,
+	0x1f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x42, 0x75, 0x67, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x22,
+	0xe0, 0x07, 0x0a, 0x06, 0x4a, 0x6f, 0x62, 0x52, 0x75, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x72,
+	0x69, 0x65, 0x66, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x62, 0x72, 0x69, 0x65, 0x66, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x65, 0x73,
+	0x74, 0x5f, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x47, 0x72, 0x69, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x17, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x70, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x6c, 0x61,
+	0x6b, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x46,
+	0x6c, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x64, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0f, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x16, 0x69, 0x6e,
+	0x66, 0x72, 0x61, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x69, 0x6e, 0x66, 0x72,
+	0x61, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x61, 0x75, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x76, 0x65,
+	0x72, 0x61, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x28, 0x0a, 0x10, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x6f, 0x72, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x75, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x72, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x75,
+	0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x70, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69,
+	0x6e, 0x6b, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x75,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x68, 0x61, 0x12, 0x2e, 0x0a, 0x13,
+	0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x75, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x13,
+	0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x70, 0x75, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x40, 0x0a, 0x0f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x69, 0x70, 0x70,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x52, 0x0e, 0x64, 0x65, 0x62, 0x75, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x22, 0x35, 0x0a, 0x0d, 0x44, 0x65, 0x62, 0x75, 0x67, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x32, 0xc0, 0x01, 0x0a, 0x05, 0x53, 0x69,
+	0x70, 0x70, 0x79, 0x12, 0x3b, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x17, 0x2e,
+	0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x2e,
+	0x73, 0x69, 0x70, 0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x65, 0x73,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x73, 0x69, 0x70, 0x70,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0b, 0x4c,
+	0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x75, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x73, 0x69, 0x70,
+	0x70, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x75, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x73, 0x69, 0x70, 0x70, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x75, 0x6e, 0x30, 0x01, 0x42, 0x28, 0x5a, 0x26,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x73,
+	0x68, 0x69, 0x66, 0x74, 0x2f, 0x73, 0x69, 0x70, 0x70, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sippy_proto_rawDescOnce sync.Once
+	file_sippy_proto_rawDescData = file_sippy_proto_rawDesc
+)
+
+func file_sippy_proto_rawDescGZIP() []byte {
+	file_sippy_proto_rawDescOnce.Do(func() {
+		file_sippy_proto_rawDescData = protoimpl.X.CompressGZIP(file_sippy_proto_rawDescData)
+	})
+	return file_sippy_proto_rawDescData
+}
+
+var file_sippy_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_sippy_proto_goTypes = []interface{}{
+	(*Filter)(nil),                // 0: sippy.v1.Filter
+	(*FilterItem)(nil),            // 1: sippy.v1.FilterItem
+	(*HealthRequest)(nil),         // 2: sippy.v1.HealthRequest
+	(*ListTestsRequest)(nil),      // 3: sippy.v1.ListTestsRequest
+	(*ListJobRunsRequest)(nil),    // 4: sippy.v1.ListJobRunsRequest
+	(*HealthResponse)(nil),        // 5: sippy.v1.HealthResponse
+	(*VariantHealth)(nil),         // 6: sippy.v1.VariantHealth
+	(*Statistics)(nil),            // 7: sippy.v1.Statistics
+	(*FlakyRunSummary)(nil),       // 8: sippy.v1.FlakyRunSummary
+	(*Test)(nil),                  // 9: sippy.v1.Test
+	(*JobRun)(nil),                // 10: sippy.v1.JobRun
+	(*DebugArtifact)(nil),         // 11: sippy.v1.DebugArtifact
+	nil,                           // 12: sippy.v1.HealthResponse.IndicatorsEntry
+	nil,                           // 13: sippy.v1.HealthResponse.PromotionsEntry
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+}
+var file_sippy_proto_depIdxs = []int32{
+	1,  // 0: sippy.v1.Filter.items:type_name -> sippy.v1.FilterItem
+	0,  // 1: sippy.v1.ListTestsRequest.filter:type_name -> sippy.v1.Filter
+	0,  // 2: sippy.v1.ListJobRunsRequest.filter:type_name -> sippy.v1.Filter
+	12, // 3: sippy.v1.HealthResponse.indicators:type_name -> sippy.v1.HealthResponse.IndicatorsEntry
+	6,  // 4: sippy.v1.HealthResponse.current_variants:type_name -> sippy.v1.VariantHealth
+	6,  // 5: sippy.v1.HealthResponse.previous_variants:type_name -> sippy.v1.VariantHealth
+	14, // 6: sippy.v1.HealthResponse.last_updated:type_name -> google.protobuf.Timestamp
+	13, // 7: sippy.v1.HealthResponse.promotions:type_name -> sippy.v1.HealthResponse.PromotionsEntry
+	7,  // 8: sippy.v1.HealthResponse.current_statistics:type_name -> sippy.v1.Statistics
+	7,  // 9: sippy.v1.HealthResponse.previous_statistics:type_name -> sippy.v1.Statistics
+	8,  // 10: sippy.v1.HealthResponse.flaky_runs:type_name -> sippy.v1.FlakyRunSummary
+	11, // 11: sippy.v1.JobRun.debug_artifacts:type_name -> sippy.v1.DebugArtifact
+	9,  // 12: sippy.v1.HealthResponse.IndicatorsEntry.value:type_name -> sippy.v1.Test
+	14, // 13: sippy.v1.HealthResponse.PromotionsEntry.value:type_name -> google.protobuf.Timestamp
+	2,  // 14: sippy.v1.Sippy.Health:input_type -> sippy.v1.HealthRequest
+	3,  // 15: sippy.v1.Sippy.ListTests:input_type -> sippy.v1.ListTestsRequest
+	4,  // 16: sippy.v1.Sippy.ListJobRuns:input_type -> sippy.v1.ListJobRunsRequest
+	5,  // 17: sippy.v1.Sippy.Health:output_type -> sippy.v1.HealthResponse
+	9,  // 18: sippy.v1.Sippy.ListTests:output_type -> sippy.v1.Test
+	10, // 19: sippy.v1.Sippy.ListJobRuns:output_type -> sippy.v1.JobRun
+	17, // [17:20] is the sub-list for method output_type
+	14, // [14:17] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_sippy_proto_init() }
+func file_sippy_proto_init() {
+	if File_sippy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sippy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobRunsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VariantHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Statistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlakyRunSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Test); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobRun); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sippy_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugArtifact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_sippy_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sippy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sippy_proto_goTypes,
+		DependencyIndexes: file_sippy_proto_depIdxs,
+		MessageInfos:      file_sippy_proto_msgTypes,
+	}.Build()
+	File_sippy_proto = out.File
+	file_sippy_proto_rawDesc = nil
+	file_sippy_proto_goTypes = nil
+	file_sippy_proto_depIdxs = nil
+}
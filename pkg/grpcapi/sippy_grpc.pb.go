@@ -0,0 +1,252 @@
+// The sippy gRPC API exposes the same reports as the REST API for high-volume consumers such as the release
+// controller. Messages mirror the JSON responses field for field, and large result sets are streamed one row per
+// message.
+//
+// Regenerate the Go stubs after changing this file with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative sippy.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: sippy.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Sippy_Health_FullMethodName      = "/sippy.v1.Sippy/Health"
+	Sippy_ListTests_FullMethodName   = "/sippy.v1.Sippy/ListTests"
+	Sippy_ListJobRuns_FullMethodName = "/sippy.v1.Sippy/ListJobRuns"
+)
+
+// SippyClient is the client API for Sippy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SippyClient interface {
+	// Health returns the release health indicators, equivalent to /api/health.
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// ListTests streams test results, equivalent to /api/tests.
+	ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (Sippy_ListTestsClient, error)
+	// ListJobRuns streams job runs, equivalent to /api/jobs/runs.
+	ListJobRuns(ctx context.Context, in *ListJobRunsRequest, opts ...grpc.CallOption) (Sippy_ListJobRunsClient, error)
+}
+
+type sippyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSippyClient(cc grpc.ClientConnInterface) SippyClient {
+	return &sippyClient{cc}
+}
+
+func (c *sippyClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, Sippy_Health_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sippyClient) ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (Sippy_ListTestsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Sippy_ServiceDesc.Streams[0], Sippy_ListTests_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sippyListTestsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sippy_ListTestsClient interface {
+	Recv() (*Test, error)
+	grpc.ClientStream
+}
+
+type sippyListTestsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sippyListTestsClient) Recv() (*Test, error) {
+	m := new(Test)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sippyClient) ListJobRuns(ctx context.Context, in *ListJobRunsRequest, opts ...grpc.CallOption) (Sippy_ListJobRunsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Sippy_ServiceDesc.Streams[1], Sippy_ListJobRuns_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sippyListJobRunsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sippy_ListJobRunsClient interface {
+	Recv() (*JobRun, error)
+	grpc.ClientStream
+}
+
+type sippyListJobRunsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sippyListJobRunsClient) Recv() (*JobRun, error) {
+	m := new(JobRun)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SippyServer is the server API for Sippy service.
+// All implementations must embed UnimplementedSippyServer
+// for forward compatibility
+type SippyServer interface {
+	// Health returns the release health indicators, equivalent to /api/health.
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// ListTests streams test results, equivalent to /api/tests.
+	ListTests(*ListTestsRequest, Sippy_ListTestsServer) error
+	// ListJobRuns streams job runs, equivalent to /api/jobs/runs.
+	ListJobRuns(*ListJobRunsRequest, Sippy_ListJobRunsServer) error
+	mustEmbedUnimplementedSippyServer()
+}
+
+// UnimplementedSippyServer must be embedded to have forward compatible implementations.
+type UnimplementedSippyServer struct {
+}
+
+func (UnimplementedSippyServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedSippyServer) ListTests(*ListTestsRequest, Sippy_ListTestsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTests not implemented")
+}
+func (UnimplementedSippyServer) ListJobRuns(*ListJobRunsRequest, Sippy_ListJobRunsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListJobRuns not implemented")
+}
+func (UnimplementedSippyServer) mustEmbedUnimplementedSippyServer() {}
+
+// UnsafeSippyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SippyServer will
+// result in compilation errors.
+type UnsafeSippyServer interface {
+	mustEmbedUnimplementedSippyServer()
+}
+
+func RegisterSippyServer(s grpc.ServiceRegistrar, srv SippyServer) {
+	s.RegisterService(&Sippy_ServiceDesc, srv)
+}
+
+func _Sippy_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SippyServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sippy_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SippyServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sippy_ListTests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTestsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SippyServer).ListTests(m, &sippyListTestsServer{stream})
+}
+
+type Sippy_ListTestsServer interface {
+	Send(*Test) error
+	grpc.ServerStream
+}
+
+type sippyListTestsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sippyListTestsServer) Send(m *Test) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sippy_ListJobRuns_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListJobRunsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SippyServer).ListJobRuns(m, &sippyListJobRunsServer{stream})
+}
+
+type Sippy_ListJobRunsServer interface {
+	Send(*JobRun) error
+	grpc.ServerStream
+}
+
+type sippyListJobRunsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sippyListJobRunsServer) Send(m *JobRun) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Sippy_ServiceDesc is the grpc.ServiceDesc for Sippy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sippy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sippy.v1.Sippy",
+	HandlerType: (*SippyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _Sippy_Health_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListTests",
+			Handler:       _Sippy_ListTests_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListJobRuns",
+			Handler:       _Sippy_ListJobRuns_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sippy.proto",
+}
@@ -0,0 +1,94 @@
+package installhtml
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api"
+)
+
+// InstallReport is the data for the printable install health report, usually attached to status emails.
+type InstallReport struct {
+	Release string
+	Start   time.Time
+	End     time.Time
+	// Columns are the variant columns of the install rates, including "All".
+	Columns []string
+	// InstallRates maps each install test to its results by variant column.
+	InstallRates map[string]map[string]api.Test
+	Operators    []api.InstallOperatorHealth
+}
+
+var installReportTemplate = template.Must(template.New("install").Funcs(template.FuncMap{
+	"rate": func(tests map[string]api.Test, column string) string {
+		t, ok := tests[column]
+		if !ok || t.CurrentRuns == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%% (%d)", t.CurrentPassPercentage, t.CurrentRuns)
+	},
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Release }} Install Health</title>
+<style>
+body { font-family: sans-serif; font-size: 10pt; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #999; padding: 2px 6px; text-align: left; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>{{ .Release }} Install Health</h1>
+<p>{{ date .Start }} to {{ date .End }}</p>
+
+<h2>Install Rates by Operator</h2>
+<table>
+<tr><th>Test</th>{{ range .Columns }}<th>{{ . }}</th>{{ end }}</tr>
+{{- $columns := .Columns }}
+{{- range $name := .TestNames }}
+<tr><td>{{ $name }}</td>{{ $tests := index $.InstallRates $name }}{{ range $columns }}<td>{{ rate $tests . }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+
+<h2>Operators Failing to Install</h2>
+{{- if .Operators }}
+<table>
+<tr><th>Platform</th><th>Operator</th><th>Failed installs</th><th>Share of failed installs</th><th>Conditions</th></tr>
+{{- range .Operators }}
+<tr><td>{{ .Platform }}</td><td>{{ .Operator }}</td><td>{{ .FailedInstalls }}</td><td>{{ printf "%.1f%%" .PercentageOfFailedInstalls }}</td>
+<td>{{ range .Conditions }}{{ .Condition }}{{ if .Reason }} ({{ .Reason }}){{ end }}: {{ .Count }}<br>{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No operator install failures.</p>
+{{- end }}
+</body>
+</html>
+`))
+
+// TestNames returns the install tests in name order.
+func (r InstallReport) TestNames() []string {
+	names := make([]string, 0, len(r.InstallRates))
+	for name := range r.InstallRates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HTML renders the report as a standalone page.
+func (r InstallReport) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := installReportTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
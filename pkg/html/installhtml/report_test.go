@@ -0,0 +1,36 @@
+package installhtml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestInstallReportHTML(t *testing.T) {
+	report := InstallReport{
+		Release: "4.16",
+		Start:   time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+		End:     time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		Columns: []string{"All", "aws"},
+		InstallRates: map[string]map[string]api.Test{
+			"operator install etcd": {
+				"All": {CurrentPassPercentage: 97.5, CurrentRuns: 40},
+			},
+		},
+		Operators: []api.InstallOperatorHealth{
+			{Platform: "aws", Operator: "etcd", FailedInstalls: 1, PercentageOfFailedInstalls: 50,
+				Conditions: []api.InstallOperatorCondition{{Condition: "degraded", Reason: "<quorum>", Count: 1}}},
+		},
+	}
+
+	html, err := report.HTML()
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "<h1>4.16 Install Health</h1>")
+	assert.Contains(t, string(html), "2024-03-13 to 2024-03-20")
+	assert.Contains(t, string(html), "<tr><td>operator install etcd</td><td>97.5% (40)</td><td>-</td></tr>")
+	assert.Contains(t, string(html), "degraded (&lt;quorum&gt;): 1")
+}
@@ -0,0 +1,49 @@
+// Package pdf renders HTML reports to PDF with an external headless renderer, such as wkhtmltopdf or chromium.
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Renderer runs a headless renderer command to print HTML to PDF. Chromium based commands are given the arguments
+// for headless printing, anything else is called like wkhtmltopdf, with the input and output files.
+type Renderer struct {
+	Command string
+}
+
+// Render returns the PDF for a standalone HTML page.
+func (r Renderer) Render(ctx context.Context, html []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "sippy-pdf")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "report.html")
+	out := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(in, html, 0600); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.Command, r.args(in, out)...) // nolint:gosec
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "error rendering pdf with %s: %s", r.Command, strings.TrimSpace(stderr.String()))
+	}
+	return os.ReadFile(out)
+}
+
+func (r Renderer) args(in, out string) []string {
+	if strings.Contains(filepath.Base(r.Command), "chrom") {
+		return []string{"--headless", "--disable-gpu", "--no-sandbox", "--no-pdf-header-footer", "--print-to-pdf=" + out, "file://" + in}
+	}
+	return []string{"--quiet", in, out}
+}
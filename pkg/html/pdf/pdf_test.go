@@ -0,0 +1,45 @@
+package pdf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRendererArgs(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{
+			command: "/usr/bin/wkhtmltopdf",
+			want:    []string{"--quiet", "in.html", "out.pdf"},
+		},
+		{
+			command: "chromium-browser",
+			want:    []string{"--headless", "--disable-gpu", "--no-sandbox", "--no-pdf-header-footer", "--print-to-pdf=out.pdf", "file://in.html"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			assert.Equal(t, tt.want, Renderer{Command: tt.command}.args("in.html", "out.pdf"))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	// A stand-in renderer that "prints" by copying its input.
+	script := filepath.Join(t.TempDir(), "fake-renderer")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\ncp \"$2\" \"$3\"\n"), 0700)) // nolint:gosec
+
+	out, err := Renderer{Command: script}.Render(context.Background(), []byte("<html></html>"))
+	require.NoError(t, err)
+	assert.Equal(t, "<html></html>", string(out))
+
+	_, err = Renderer{Command: filepath.Join(t.TempDir(), "missing")}.Render(context.Background(), []byte("<html></html>"))
+	assert.Error(t, err)
+}
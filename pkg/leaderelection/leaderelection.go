@@ -0,0 +1,140 @@
+// Package leaderelection picks one of several sippy replicas sharing a database to run background work, such as
+// scheduled data loads and matview refreshes, using a postgres advisory lock.
+package leaderelection
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+var leaderMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "sippy_leader",
+	Help: "1 if this replica is the leader of the election, 0 otherwise",
+}, []string{"election", "identity"})
+
+// retryInterval is how often followers try to take the lock, and the leader checks it still holds it.
+const retryInterval = 15 * time.Second
+
+// Elector holds a session level advisory lock while it is the leader. The lock is tied to a dedicated database
+// connection, so if the replica dies or loses its connection postgres releases the lock and another replica takes
+// over on its next attempt.
+type Elector struct {
+	db       *sql.DB
+	name     string
+	identity string
+	lockID   int64
+
+	leading atomic.Bool
+	// mu guards conn, checks run from Start and the Run loop.
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// New creates an elector for the named election. Every replica must use the same name; identity, typically the
+// hostname, distinguishes replicas in logs and metrics.
+func New(db *sql.DB, name, identity string) *Elector {
+	e := &Elector{
+		db:       db,
+		name:     name,
+		identity: identity,
+		lockID:   lockID(name),
+	}
+	leaderMetric.WithLabelValues(name, identity).Set(0)
+	return e
+}
+
+// lockID maps the election name to the advisory lock key.
+func lockID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("sippy-leader-" + name))
+	return int64(h.Sum64())
+}
+
+// IsLeader returns true if this replica currently holds the lock.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Start makes a first attempt to become the leader, so work started right after it knows whether to run, then
+// keeps trying in the background until the context is canceled.
+func (e *Elector) Start(ctx context.Context) {
+	e.check(ctx)
+	go e.run(ctx)
+}
+
+func (e *Elector) run(ctx context.Context) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.check(ctx)
+		case <-ctx.Done():
+			e.mu.Lock()
+			e.resign()
+			e.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (e *Elector) check(ctx context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	logger := log.WithFields(log.Fields{"election": e.name, "identity": e.identity})
+
+	if e.conn == nil {
+		conn, err := e.db.Conn(ctx)
+		if err != nil {
+			logger.WithError(err).Warning("error getting a connection for leader election")
+			return
+		}
+		e.conn = conn
+	}
+
+	if e.IsLeader() {
+		// The lock lives as long as the session, so a healthy connection means we still hold it.
+		if err := e.conn.PingContext(ctx); err != nil {
+			logger.WithError(err).Warning("lost leader election connection, no longer the leader")
+			e.resign()
+		}
+		return
+	}
+
+	var acquired bool
+	if err := e.conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", e.lockID).Scan(&acquired); err != nil {
+		logger.WithError(err).Warning("error trying to acquire leader lock")
+		e.resign()
+		return
+	}
+	if acquired {
+		logger.Info("became the leader")
+		e.leading.Store(true)
+		leaderMetric.WithLabelValues(e.name, e.identity).Set(1)
+	}
+}
+
+// resign gives up leadership by closing the session that holds the lock. Callers must hold mu.
+func (e *Elector) resign() {
+	if e.IsLeader() {
+		log.WithFields(log.Fields{"election": e.name, "identity": e.identity}).Info("resigning as leader")
+	}
+	e.leading.Store(false)
+	leaderMetric.WithLabelValues(e.name, e.identity).Set(0)
+	if e.conn != nil {
+		// Closing returns the connection to the pool, unlock first so the pooled session doesn't keep the lock.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, _ = e.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", e.lockID)
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+}
@@ -0,0 +1,12 @@
+package leaderelection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockID(t *testing.T) {
+	assert.Equal(t, lockID("auto-load"), lockID("auto-load"), "every replica must contend for the same lock")
+	assert.NotEqual(t, lockID("auto-load"), lockID("other"), "elections must not share a lock")
+}
@@ -0,0 +1,182 @@
+// Package notify delivers messages from sippy subsystems, such as firing alerts or failed loads, to humans
+// through chat and paging services. Which senders receive a message is decided by the routes in the sippy config.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+// Sources of notifications.
+const (
+	SourceAlerts     = "alerts"
+	SourceLoad       = "load"
+	SourceWatchlists = "watchlists"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+var severityRank = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityWarning:  1,
+	SeverityCritical: 2,
+}
+
+// Message is a single notification.
+type Message struct {
+	// Source is the subsystem sending the message, e.g. alerts.
+	Source string `json:"source"`
+	// Name identifies what the message is about within the source, e.g. the alert rule.
+	Name     string   `json:"name"`
+	Severity Severity `json:"severity"`
+	// Summary is a human readable, one line description.
+	Summary string `json:"summary"`
+	// Details are included by senders that can carry structured data, such as webhooks.
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Sender delivers messages to one destination.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type route struct {
+	sources     map[string]bool
+	names       []*regexp.Regexp
+	minSeverity Severity
+	senders     []string
+}
+
+func (r route) matches(msg Message) bool {
+	if len(r.sources) > 0 && !r.sources[msg.Source] {
+		return false
+	}
+	if r.minSeverity != "" && severityRank[msg.Severity] < severityRank[r.minSeverity] {
+		return false
+	}
+	if len(r.names) == 0 {
+		return true
+	}
+	for _, re := range r.names {
+		if re.MatchString(msg.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier sends messages to the senders of every route they match.
+type Notifier struct {
+	senders map[string]Sender
+	routes  []route
+}
+
+// New returns a Notifier for the sippy config's notification senders and routes. Notifiers listed in the alerting
+// config receive every alert.
+func New(config *v1.SippyConfig) (*Notifier, error) {
+	n := &Notifier{senders: map[string]Sender{}}
+
+	for _, sc := range config.Notifications.Senders {
+		if sc.Name == "" {
+			return nil, fmt.Errorf("%s notification sender is missing a name", sc.Type)
+		}
+		if _, ok := n.senders[sc.Name]; ok {
+			return nil, fmt.Errorf("duplicate notification sender name %q", sc.Name)
+		}
+		s, err := NewSender(sc)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "notification sender %q", sc.Name)
+		}
+		n.senders[sc.Name] = s
+	}
+
+	for i, rc := range config.Notifications.Routes {
+		r, err := n.newRoute(rc)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "notification route %d", i)
+		}
+		n.routes = append(n.routes, r)
+	}
+
+	alerting := route{sources: map[string]bool{SourceAlerts: true}}
+	for i, nc := range config.Alerting.Notifiers {
+		s, err := NewSender(nc)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("alerting-%d", i)
+		n.senders[name] = s
+		alerting.senders = append(alerting.senders, name)
+	}
+	if len(alerting.senders) > 0 {
+		n.routes = append(n.routes, alerting)
+	}
+
+	return n, nil
+}
+
+func (n *Notifier) newRoute(rc v1.NotificationRoute) (route, error) {
+	r := route{sources: map[string]bool{}, minSeverity: Severity(rc.MinSeverity)}
+	for _, s := range rc.Sources {
+		r.sources[s] = true
+	}
+	for _, name := range rc.Names {
+		re, err := regexp.Compile(name)
+		if err != nil {
+			return r, err
+		}
+		r.names = append(r.names, re)
+	}
+	if _, ok := severityRank[r.minSeverity]; r.minSeverity != "" && !ok {
+		return r, fmt.Errorf("unknown severity %q", rc.MinSeverity)
+	}
+	if len(rc.Senders) == 0 {
+		return r, fmt.Errorf("no senders")
+	}
+	for _, s := range rc.Senders {
+		if _, ok := n.senders[s]; !ok {
+			return r, fmt.Errorf("unknown sender %q", s)
+		}
+	}
+	r.senders = rc.Senders
+	return r, nil
+}
+
+// Notify sends the message to the senders of every route it matches, each sender at most once. All senders are
+// tried even if some fail.
+func (n *Notifier) Notify(ctx context.Context, msg Message) error {
+	sent := map[string]bool{}
+	failed := 0
+	for _, r := range n.routes {
+		if !r.matches(msg) {
+			continue
+		}
+		for _, name := range r.senders {
+			if sent[name] {
+				continue
+			}
+			sent[name] = true
+			if err := n.senders[name].Send(ctx, msg); err != nil {
+				log.WithError(err).WithFields(log.Fields{"sender": name, "source": msg.Source, "name": msg.Name}).
+					Error("error sending notification")
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notification senders failed, see logs for details", failed, len(sent))
+	}
+	return nil
+}
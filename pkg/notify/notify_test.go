@@ -0,0 +1,239 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+type fakeSender struct {
+	sent []Message
+}
+
+func (f *fakeSender) Send(_ context.Context, msg Message) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	chat, pager := &fakeSender{}, &fakeSender{}
+	n := &Notifier{senders: map[string]Sender{"chat": chat, "pager": pager}}
+	for _, rc := range []v1.NotificationRoute{
+		{Sources: []string{SourceAlerts}, Senders: []string{"chat"}},
+		{Names: []string{"^metal-"}, MinSeverity: string(SeverityCritical), Senders: []string{"chat", "pager"}},
+		{Sources: []string{SourceLoad}, Senders: []string{"pager"}},
+	} {
+		r, err := n.newRoute(rc)
+		require.NoError(t, err)
+		n.routes = append(n.routes, r)
+	}
+
+	tests := []struct {
+		name      string
+		msg       Message
+		wantChat  int
+		wantPager int
+	}{
+		{
+			name:     "routed by source",
+			msg:      Message{Source: SourceAlerts, Name: "aws-install", Severity: SeverityWarning},
+			wantChat: 1,
+		},
+		{
+			name:      "matches several routes but is sent once per sender",
+			msg:       Message{Source: SourceAlerts, Name: "metal-install", Severity: SeverityCritical},
+			wantChat:  1,
+			wantPager: 1,
+		},
+		{
+			name:     "below minimum severity",
+			msg:      Message{Source: SourceAlerts, Name: "metal-install", Severity: SeverityWarning},
+			wantChat: 1,
+		},
+		{
+			name:      "other source",
+			msg:       Message{Source: SourceLoad, Name: "load", Severity: SeverityInfo},
+			wantPager: 1,
+		},
+		{
+			name: "no matching route",
+			msg:  Message{Source: "regressions", Name: "aws", Severity: SeverityWarning},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat.sent, pager.sent = nil, nil
+			assert.NoError(t, n.Notify(context.Background(), tt.msg))
+			assert.Len(t, chat.sent, tt.wantChat)
+			assert.Len(t, pager.sent, tt.wantPager)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	slack := v1.NotifierConfig{Name: "slack", Type: SenderSlack, URL: "https://hooks.slack.com/x"}
+	tests := []struct {
+		name        string
+		config      v1.SippyConfig
+		expectError bool
+	}{
+		{
+			name: "valid",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{slack, {Name: "pd", Type: SenderPagerDuty, RoutingKey: "key"}},
+				Routes:  []v1.NotificationRoute{{Sources: []string{SourceAlerts}, Senders: []string{"slack", "pd"}}},
+			}},
+		},
+		{
+			name: "alerting notifiers without names",
+			config: v1.SippyConfig{Alerting: v1.AlertingConfig{
+				Notifiers: []v1.NotifierConfig{{Type: SenderWebhook, URL: "https://example.com"}},
+			}},
+		},
+		{
+			name: "sender without name",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{{Type: SenderSlack, URL: "https://hooks.slack.com/x"}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "pagerduty without routing key",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{{Name: "pd", Type: SenderPagerDuty}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "route to unknown sender",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{slack},
+				Routes:  []v1.NotificationRoute{{Senders: []string{"email"}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "webhook with unknown payload version",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{{Name: "hook", Type: SenderWebhook, URL: "https://example.com", PayloadVersion: "v3"}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "route with unknown severity",
+			config: v1.SippyConfig{Notifications: v1.NotificationConfig{
+				Senders: []v1.NotifierConfig{slack},
+				Routes:  []v1.NotificationRoute{{MinSeverity: "page", Senders: []string{"slack"}}},
+			}},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSenders(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+
+	msg := Message{Source: SourceAlerts, Name: "metal-install", Severity: SeverityCritical, Summary: "metal install is failing",
+		Details: models.AlertResult{Rule: "metal-install", Release: "4.16", Firing: true}}
+
+	tests := []struct {
+		name   string
+		config v1.NotifierConfig
+		check  func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:   "google chat",
+			config: v1.NotifierConfig{Type: SenderGoogleChat, URL: server.URL},
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, map[string]interface{}{"text": "metal install is failing"}, body)
+			},
+		},
+		{
+			name:   "webhook",
+			config: v1.NotifierConfig{Type: SenderWebhook, URL: server.URL},
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "metal install is failing", body["message"])
+				assert.Equal(t, "metal-install", body["rule"])
+				assert.Equal(t, "4.16", body["release"])
+				assert.NotContains(t, body, "severity")
+			},
+		},
+		{
+			name:   "webhook v2",
+			config: v1.NotifierConfig{Type: SenderWebhook, URL: server.URL, PayloadVersion: WebhookPayloadV2},
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "metal-install", body["name"])
+				assert.Equal(t, "critical", body["severity"])
+				assert.Equal(t, "metal install is failing", body["summary"])
+				assert.Equal(t, "4.16", body["details"].(map[string]interface{})["release"])
+			},
+		},
+		{
+			name:   "pagerduty",
+			config: v1.NotifierConfig{Type: SenderPagerDuty, URL: server.URL, RoutingKey: "key"},
+			check: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "key", body["routing_key"])
+				assert.Equal(t, "trigger", body["event_action"])
+				assert.Equal(t, "sippy/alerts/metal-install", body["dedup_key"])
+				payload := body["payload"].(map[string]interface{})
+				assert.Equal(t, "metal install is failing", payload["summary"])
+				assert.Equal(t, "critical", payload["severity"])
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSender(tt.config)
+			require.NoError(t, err)
+			require.NoError(t, s.Send(context.Background(), msg))
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestV1WebhookPayload(t *testing.T) {
+	result := models.AlertResult{Rule: "metal-install", Release: "4.16", Metric: "pass_percentage", Value: 42, Firing: true}
+
+	// Alerts are sent exactly as they were before notifications were routed: the alert result and its message.
+	legacy, err := json.Marshal(struct {
+		models.AlertResult
+		Message string `json:"message"`
+	}{result, "metal install is failing"})
+	require.NoError(t, err)
+	body, err := v1WebhookPayload(Message{Source: SourceAlerts, Summary: "metal install is failing", Details: result})
+	require.NoError(t, err)
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(legacy), string(data))
+
+	body, err = v1WebhookPayload(Message{Source: SourceLoad, Summary: "load failed", Details: []string{"timeout"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"message": "load failed", "details": []string{"timeout"}}, body)
+
+	body, err = v1WebhookPayload(Message{Source: SourceLoad, Summary: "load failed"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"message": "load failed"}, body)
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+// Types of notification senders.
+const (
+	SenderSlack      = "slack"
+	SenderGoogleChat = "googlechat"
+	SenderPagerDuty  = "pagerduty"
+	SenderWebhook    = "webhook"
+)
+
+// Webhook payload versions.
+const (
+	WebhookPayloadV1 = "v1"
+	WebhookPayloadV2 = "v2"
+)
+
+// DefaultPagerDutyURL is the PagerDuty Events API v2 endpoint.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// NewSender returns the Sender described by the config.
+func NewSender(config v1.NotifierConfig) (Sender, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if config.Type == SenderPagerDuty {
+		if config.RoutingKey == "" {
+			return nil, fmt.Errorf("pagerduty notifier is missing a routingKey")
+		}
+		url := config.URL
+		if url == "" {
+			url = DefaultPagerDutyURL
+		}
+		return &pagerDutySender{url: url, routingKey: config.RoutingKey, client: client}, nil
+	}
+
+	if config.URL == "" {
+		return nil, fmt.Errorf("%s notifier is missing a url", config.Type)
+	}
+	switch config.Type {
+	case SenderSlack, SenderGoogleChat:
+		// Slack and Google Chat incoming webhooks both accept a plain text message.
+		return &textSender{url: config.URL, client: client}, nil
+	case SenderWebhook:
+		switch config.PayloadVersion {
+		case "", WebhookPayloadV1:
+			return &webhookSender{url: config.URL, client: client, payloadVersion: WebhookPayloadV1}, nil
+		case WebhookPayloadV2:
+			return &webhookSender{url: config.URL, client: client, payloadVersion: WebhookPayloadV2}, nil
+		}
+		return nil, fmt.Errorf("unknown webhook payload version %q", config.PayloadVersion)
+	}
+	return nil, fmt.Errorf("unknown notifier type %q", config.Type)
+}
+
+type textSender struct {
+	url    string
+	client *http.Client
+}
+
+func (s *textSender) Send(ctx context.Context, msg Message) error {
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": msg.Summary})
+}
+
+type webhookSender struct {
+	url            string
+	client         *http.Client
+	payloadVersion string
+}
+
+func (s *webhookSender) Send(ctx context.Context, msg Message) error {
+	if s.payloadVersion == WebhookPayloadV2 {
+		return postJSON(ctx, s.client, s.url, msg)
+	}
+	body, err := v1WebhookPayload(msg)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, s.client, s.url, body)
+}
+
+// v1WebhookPayload is the details of the message with its summary as the message field. Details that aren't a JSON
+// object, or none at all, are sent under a details field instead.
+func v1WebhookPayload(msg Message) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if msg.Details != nil {
+		data, err := json.Marshal(msg.Details)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			body = map[string]interface{}{"details": msg.Details}
+		}
+	}
+	body["message"] = msg.Summary
+	return body, nil
+}
+
+type pagerDutySender struct {
+	url        string
+	routingKey string
+	client     *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string      `json:"summary"`
+	Source        string      `json:"source"`
+	Severity      Severity    `json:"severity"`
+	Component     string      `json:"component,omitempty"`
+	CustomDetails interface{} `json:"custom_details,omitempty"`
+}
+
+func (s *pagerDutySender) Send(ctx context.Context, msg Message) error {
+	severity := msg.Severity
+	if severity == "" {
+		severity = SeverityWarning
+	}
+	return postJSON(ctx, s.client, s.url, pagerDutyEvent{
+		RoutingKey:  s.routingKey,
+		EventAction: "trigger",
+		// Repeated messages about the same thing are grouped into one incident.
+		DedupKey: "sippy/" + msg.Source + "/" + msg.Name,
+		Payload: pagerDutyPayload{
+			Summary:       msg.Summary,
+			Source:        "sippy",
+			Severity:      severity,
+			Component:     msg.Source,
+			CustomDetails: msg.Details,
+		},
+	})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification to %s failed with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Package sippyclient queries the API of a remote sippy server, for scripting against sippy from the command line.
+package sippyclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pageSize is requested from list endpoints that support pagination, others ignore it and return every row.
+const pageSize = 1000
+
+// Row is one result of a list endpoint, keyed by JSON field name.
+type Row map[string]interface{}
+
+// Client queries the API of the sippy server at URL.
+type Client struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the sippy server at sippyURL, i.e. https://sippy.dptools.openshift.org.
+func New(sippyURL string, timeout time.Duration) *Client {
+	return &Client{
+		URL:        strings.TrimSuffix(sippyURL, "/"),
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// page is the response of a paginated list endpoint.
+type page struct {
+	Rows       []Row  `json:"rows"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// List GETs the rows of a list endpoint such as /api/tests, following pagination cursors until every row, or the
+// limit param's worth of them, has been fetched.
+func (c *Client) List(ctx context.Context, path string, params url.Values) ([]Row, error) {
+	params = cloneValues(params)
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	if limit == 0 {
+		params.Set("pageSize", strconv.Itoa(pageSize))
+	}
+
+	rows := []Row{}
+	for {
+		body, err := c.get(ctx, path, params)
+		if err != nil {
+			return nil, err
+		}
+		if trimmed := strings.TrimSpace(string(body)); strings.HasPrefix(trimmed, "[") {
+			var all []Row
+			if err := json.Unmarshal(body, &all); err != nil {
+				return nil, fmt.Errorf("error decoding response from %s: %w", path, err)
+			}
+			return append(rows, all...), nil
+		}
+
+		var p page
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("error decoding response from %s: %w", path, err)
+		}
+		rows = append(rows, p.Rows...)
+		if p.NextCursor == "" {
+			return rows, nil
+		}
+		params.Set("cursor", p.NextCursor)
+	}
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	u := c.URL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	log.WithField("url", u).Debug("GET")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", path, resp.Status, errorMessage(body))
+	}
+	return body, nil
+}
+
+// errorMessage returns the message of a sippy error response, or the body as is if it isn't one.
+func errorMessage(body []byte) string {
+	var errResp struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+		return errResp.Message
+	}
+	return strings.TrimSpace(string(body))
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := url.Values{}
+	for k, v := range values {
+		clone[k] = append([]string(nil), v...)
+	}
+	return clone
+}
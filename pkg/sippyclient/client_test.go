@@ -0,0 +1,80 @@
+package sippyclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := req.URL.Query()
+		switch {
+		case req.URL.Path == "/api/variants":
+			fmt.Fprint(w, `[{"name":"aws"},{"name":"gcp"}]`)
+		case req.URL.Path == "/api/tests" && query.Get("release") != "4.14":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":400,"message":"release is required"}`)
+		case req.URL.Path == "/api/tests" && query.Get("cursor") == "":
+			assert.Equal(t, "1000", query.Get("pageSize"))
+			fmt.Fprint(w, `{"rows":[{"name":"a"},{"name":"b"}],"next_cursor":"abc"}`)
+		case req.URL.Path == "/api/tests" && query.Get("cursor") == "abc":
+			fmt.Fprint(w, `{"rows":[{"name":"c"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := New(server.URL+"/", time.Minute)
+
+	tests := []struct {
+		name      string
+		path      string
+		params    url.Values
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:      "unpaginated endpoint",
+			path:      "/api/variants",
+			params:    url.Values{"release": {"4.14"}},
+			wantNames: []string{"aws", "gcp"},
+		},
+		{
+			name:      "follows cursors",
+			path:      "/api/tests",
+			params:    url.Values{"release": {"4.14"}},
+			wantNames: []string{"a", "b", "c"},
+		},
+		{
+			name:    "error message",
+			path:    "/api/tests",
+			params:  url.Values{"release": {"4.13"}},
+			wantErr: "/api/tests returned 400 Bad Request: release is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := client.List(context.Background(), tt.path, tt.params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, row := range rows {
+				names = append(names, row["name"].(string))
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Empty(t, tt.params.Get("cursor"), "the caller's params are left alone")
+		})
+	}
+}
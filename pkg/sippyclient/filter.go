@@ -0,0 +1,74 @@
+package sippyclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+// operators are the filter operators, longest first so ">=" isn't taken for ">".
+var operators = []filter.Operator{
+	filter.OperatorIsNotEmpty,
+	filter.OperatorStartsWith,
+	filter.OperatorEndsWith,
+	filter.OperatorIsEmpty,
+	filter.OperatorContains,
+	filter.OperatorEquals,
+	filter.OperatorArithmeticGreaterThanOrEquals,
+	filter.OperatorArithmeticLessThanOrEquals,
+	filter.OperatorArithmeticNotEquals,
+	filter.OperatorArithmeticEquals,
+	filter.OperatorArithmeticGreaterThan,
+	filter.OperatorArithmeticLessThan,
+}
+
+// ParseFilterItem parses an expression of the form "<field> [not] <operator> [value]", the way filters read in the
+// UI, i.e. "name contains aws", "name not starts with [sig-arch]", "current_runs >= 10" or "variants is empty".
+func ParseFilterItem(expr string) (filter.FilterItem, error) {
+	field, rest, ok := strings.Cut(strings.TrimSpace(expr), " ")
+	if !ok || field == "" {
+		return filter.FilterItem{}, fmt.Errorf("filter %q must be of the form '<field> [not] <operator> [value]'", expr)
+	}
+	item := filter.FilterItem{Field: field}
+	rest = strings.TrimLeft(rest, " ")
+	if after, found := strings.CutPrefix(rest, "not "); found {
+		item.Not = true
+		rest = strings.TrimLeft(after, " ")
+	}
+
+	for _, op := range operators {
+		after, found := strings.CutPrefix(rest, string(op))
+		if !found || (after != "" && after[0] != ' ') {
+			continue
+		}
+		item.Operator = op
+		item.Value = strings.TrimSpace(after)
+		if item.Value == "" && op != filter.OperatorIsEmpty && op != filter.OperatorIsNotEmpty {
+			return filter.FilterItem{}, fmt.Errorf("filter %q is missing a value", expr)
+		}
+		return item, nil
+	}
+	return filter.FilterItem{}, fmt.Errorf("filter %q has no known operator", expr)
+}
+
+// FilterParam returns the filter query param matching all, or with or any, of exprs.
+func FilterParam(exprs []string, or bool) (string, error) {
+	f := filter.Filter{LinkOperator: filter.LinkOperatorAnd}
+	if or {
+		f.LinkOperator = filter.LinkOperatorOr
+	}
+	for _, expr := range exprs {
+		item, err := ParseFilterItem(expr)
+		if err != nil {
+			return "", err
+		}
+		f.Items = append(f.Items, item)
+	}
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,71 @@
+package sippyclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/filter"
+)
+
+func TestParseFilterItem(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    filter.FilterItem
+		wantErr string
+	}{
+		{
+			expr: "name contains [sig-network] services",
+			want: filter.FilterItem{Field: "name", Operator: filter.OperatorContains, Value: "[sig-network] services"},
+		},
+		{
+			expr: "name not starts with [sig-arch]",
+			want: filter.FilterItem{Field: "name", Not: true, Operator: filter.OperatorStartsWith, Value: "[sig-arch]"},
+		},
+		{
+			expr: "current_runs >= 10",
+			want: filter.FilterItem{Field: "current_runs", Operator: filter.OperatorArithmeticGreaterThanOrEquals, Value: "10"},
+		},
+		{
+			expr: "current_runs > 10",
+			want: filter.FilterItem{Field: "current_runs", Operator: filter.OperatorArithmeticGreaterThan, Value: "10"},
+		},
+		{
+			expr: "variants is not empty",
+			want: filter.FilterItem{Field: "variants", Operator: filter.OperatorIsNotEmpty},
+		},
+		{
+			expr:    "name",
+			wantErr: "must be of the form",
+		},
+		{
+			expr:    "name equals",
+			wantErr: "is missing a value",
+		},
+		{
+			expr:    "name resembles aws",
+			wantErr: "has no known operator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseFilterItem(tt.expr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterParam(t *testing.T) {
+	got, err := FilterParam([]string{"name contains aws", "current_runs > 5"}, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[
+		{"columnField":"name","not":false,"operatorValue":"contains","value":"aws"},
+		{"columnField":"current_runs","not":false,"operatorValue":">","value":"5"}
+	],"linkOperator":"or"}`, got)
+}
@@ -0,0 +1,67 @@
+package sippyclient
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// maxCellWidth truncates long cells such as test names so tables stay readable in a terminal.
+const maxCellWidth = 100
+
+// WriteTable writes rows as a table of columns, or of every field of the first row, sorted, if columns is empty.
+func WriteTable(w io.Writer, rows []Row, columns []string) error {
+	if len(columns) == 0 && len(rows) > 0 {
+		for field := range rows[0] {
+			columns = append(columns, field)
+		}
+		sort.Strings(columns)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(c)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = formatCell(row[c])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatCell renders a JSON value: whole numbers without decimals, percentages and other fractions to two
+// places, and arrays comma separated.
+func formatCell(value interface{}) string {
+	var cell string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		if v == float64(int64(v)) {
+			cell = strconv.FormatInt(int64(v), 10)
+		} else {
+			cell = strconv.FormatFloat(v, 'f', 2, 64)
+		}
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatCell(item)
+		}
+		cell = strings.Join(parts, ",")
+	default:
+		cell = fmt.Sprint(v)
+	}
+	cell = strings.NewReplacer("\t", " ", "\n", " ").Replace(cell)
+	if runes := []rune(cell); len(runes) > maxCellWidth {
+		cell = string(runes[:maxCellWidth-3]) + "..."
+	}
+	return cell
+}
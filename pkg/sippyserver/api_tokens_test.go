@@ -0,0 +1,94 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/api"
+)
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		require bool
+		// tokenRequired is set for endpoints that always require a token to write.
+		tokenRequired bool
+		method        string
+		token         string
+		wantCode      int
+	}{
+		{
+			name:     "reads of write endpoints are open",
+			scope:    api.APITokenScopeWrite,
+			require:  true,
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "writes allowed without a token when not required",
+			scope:    api.APITokenScopeWrite,
+			method:   http.MethodPost,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "writes need a token when required",
+			scope:    api.APITokenScopeWrite,
+			require:  true,
+			method:   http.MethodPost,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "writes need a token when the endpoint requires one",
+			scope:         api.APITokenScopeWrite,
+			tokenRequired: true,
+			method:        http.MethodPost,
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:     "admin reads need a token when required",
+			scope:    api.APITokenScopeAdmin,
+			require:  true,
+			method:   http.MethodGet,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "admin writes need a token even when not required",
+			scope:    api.APITokenScopeAdmin,
+			method:   http.MethodPost,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "admin reads need a token even when not required",
+			scope:    api.APITokenScopeAdmin,
+			method:   http.MethodGet,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "tokens cannot be checked without a database",
+			scope:    api.APITokenScopeAdmin,
+			method:   http.MethodPost,
+			token:    "sippy_abc",
+			wantCode: http.StatusNotImplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{requireAPITokens: tt.require}
+			handler := s.requireScope(tt.scope, tt.tokenRequired, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/admin/reload", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package sippyserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/api"
+)
+
+type memoryCache map[string][]byte
+
+func (c memoryCache) Get(_ context.Context, key string, _ time.Duration) ([]byte, error) {
+	content, ok := c[key]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return content, nil
+}
+
+func (c memoryCache) Set(_ context.Context, key string, content []byte, _ time.Duration) error {
+	c[key] = content
+	return nil
+}
+
+func (c memoryCache) Purge(_ context.Context, prefix string) (int, error) {
+	purged := 0
+	for k := range c {
+		if strings.HasPrefix(k, prefix) {
+			delete(c, k)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func TestCachedHandler(t *testing.T) {
+	c := memoryCache{}
+	s := &Server{cache: c}
+	calls := 0
+	status := http.StatusOK
+	handler := s.cached("/api/test", time.Hour, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+		fmt.Fprint(w, "result")
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(method, url, nil))
+		return w
+	}
+
+	w := serve(http.MethodGet, "/api/test?release=4.16&period=default")
+	assert.Equal(t, "result", w.Body.String())
+	assert.Equal(t, 1, calls)
+
+	w = serve(http.MethodGet, "/api/test?period=default&release=4.16")
+	assert.Equal(t, "result", w.Body.String())
+	assert.Equal(t, "true", w.Header().Get("X-Sippy-Cached"), "reordered params should be served from the cache")
+	assert.Equal(t, 1, calls)
+
+	serve(http.MethodPost, "/api/test?period=default&release=4.16")
+	assert.Equal(t, 2, calls, "only GET requests should use the cache")
+
+	status = http.StatusInternalServerError
+	serve(http.MethodGet, "/api/test?release=4.15")
+	serve(http.MethodGet, "/api/test?release=4.15")
+	assert.Equal(t, 4, calls, "errors should not be cached")
+}
+
+func TestCachedHandlerErrorRequestID(t *testing.T) {
+	s := &Server{cache: memoryCache{}}
+	handler := s.cached("/api/test", time.Hour, func(w http.ResponseWriter, r *http.Request) {
+		api.RespondWithError(w, http.StatusInternalServerError, "failed")
+	})
+
+	w := httptest.NewRecorder()
+	w.Header().Set(api.RequestIDHeader, "abc123")
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/test", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var body api.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "abc123", body.RequestID, "errors from cached endpoints should carry the request ID")
+	assert.Equal(t, "abc123", w.Header().Get(api.RequestIDHeader))
+}
+
+func TestPurgeCache(t *testing.T) {
+	c := memoryCache{
+		apiCacheKeyPrefix + "/api/tests?release=4.16": []byte("a"),
+		apiCacheKeyPrefix + "/api/jobs?release=4.16":  []byte("b"),
+		"other": []byte("c"),
+	}
+	s := &Server{cache: c}
+
+	w := httptest.NewRecorder()
+	s.jsonPurgeCache(w, httptest.NewRequest(http.MethodPost, "/api/admin/cache/purge?path=/api/tests", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, c, 2)
+
+	w = httptest.NewRecorder()
+	s.jsonPurgeCache(w, httptest.NewRequest(http.MethodPost, "/api/admin/cache/purge", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, memoryCache{"other": []byte("c")}, c)
+}
@@ -0,0 +1,129 @@
+package sippyserver
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// compressHandler gzips responses for clients accepting it.
+func compressHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) || r.Method == http.MethodHead {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w}
+		defer cw.close()
+		h.ServeHTTP(cw, r)
+	})
+}
+
+// acceptsGzip is true if an Accept-Encoding header accepts gzip, by name or wildcard, with a non-zero quality value.
+func acceptsGzip(acceptEncoding string) bool {
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+	if quality, ok := qualities["gzip"]; ok {
+		return quality > 0
+	}
+	return qualities["*"] > 0
+}
+
+// compressWriter compresses a response once its status and headers show it is worth compressing.
+type compressWriter struct {
+	http.ResponseWriter
+	encoder *gzip.Writer
+	decided bool
+}
+
+func (c *compressWriter) WriteHeader(status int) {
+	if !c.decided {
+		c.decide(status)
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *compressWriter) Write(b []byte) (int, error) {
+	if !c.decided {
+		// net/http sniffs the content type of the first write, which it can't once the body is compressed.
+		if c.Header().Get("Content-Type") == "" {
+			c.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.encoder == nil {
+		return c.ResponseWriter.Write(b)
+	}
+	return c.encoder.Write(b)
+}
+
+// Flush passes flushes through for streaming responses, flushing what was compressed so far first.
+func (c *compressWriter) Flush() {
+	if c.encoder != nil {
+		if err := c.encoder.Flush(); err != nil {
+			log.WithError(err).Debug("error flushing compressed response")
+		}
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// decide leaves responses without a body, partial content, event streams, already compressed content and
+// formats that don't compress as they are.
+func (c *compressWriter) decide(status int) {
+	c.decided = true
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent ||
+		status == http.StatusNotModified {
+		return
+	}
+	header := c.Header()
+	if header.Get("Content-Encoding") != "" || !compressible(header.Get("Content-Type")) {
+		return
+	}
+	c.encoder = gzip.NewWriter(c.ResponseWriter)
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+}
+
+func (c *compressWriter) close() {
+	if c.encoder == nil {
+		return
+	}
+	if err := c.encoder.Close(); err != nil {
+		log.WithError(err).Debug("error closing compressed response")
+	}
+}
+
+// compressible is false for event streams, which are flushed as they go, and formats that are compressed already.
+func compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "image/"):
+		return mediaType == "image/svg+xml"
+	case strings.HasPrefix(mediaType, "video/"), strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "font/woff"):
+		return false
+	}
+	switch mediaType {
+	case "application/gzip", "application/x-gzip", "application/zip", "application/pdf", "application/octet-stream":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package sippyserver
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           bool
+	}{
+		{acceptEncoding: "", want: false},
+		{acceptEncoding: "gzip, deflate, br", want: true},
+		{acceptEncoding: "br;q=1.0, gzip;q=0.8", want: true},
+		{acceptEncoding: "gzip;q=0", want: false},
+		{acceptEncoding: "*", want: true},
+		{acceptEncoding: "*, gzip;q=0", want: false},
+		{acceptEncoding: "identity", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			assert.Equal(t, tt.want, acceptsGzip(tt.acceptEncoding))
+		})
+	}
+}
+
+func TestCompressHandler(t *testing.T) {
+	body := strings.Repeat(`{"name":"test","runs":100}`, 100)
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		wantEncoding   string
+	}{
+		{
+			name:           "json",
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+			status:         http.StatusOK,
+			wantEncoding:   "gzip",
+		},
+		{
+			name:           "error",
+			acceptEncoding: "gzip",
+			contentType:    "application/json",
+			status:         http.StatusInternalServerError,
+			wantEncoding:   "gzip",
+		},
+		{
+			name:        "not accepted",
+			contentType: "application/json",
+			status:      http.StatusOK,
+		},
+		{
+			name:           "image",
+			acceptEncoding: "gzip",
+			contentType:    "image/png",
+			status:         http.StatusOK,
+		},
+		{
+			name:           "event stream",
+			acceptEncoding: "gzip",
+			contentType:    "text/event-stream",
+			status:         http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := compressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(body))
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/api/tests", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			got := w.Body.Bytes()
+			if tt.wantEncoding == "gzip" {
+				assert.Less(t, len(got), len(body))
+				reader, err := gzip.NewReader(w.Body)
+				require.NoError(t, err)
+				got, err = io.ReadAll(reader)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, body, string(got))
+		})
+	}
+}
+
+func TestCompressHandlerSniffsContentType(t *testing.T) {
+	handler := compressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>sippy</body></html>"))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+}
@@ -0,0 +1,122 @@
+package sippyserver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+// dataVersionTTL is how long the last refresh time is reused before it's read from the database again.
+const dataVersionTTL = time.Minute
+
+// dataVersion returns when the reports were last refreshed, which versions every response derived from them, or
+// false if it isn't known.
+func (s *Server) dataVersion() (time.Time, bool) {
+	s.dataVersionLock.Lock()
+	defer s.dataVersionLock.Unlock()
+	if time.Since(s.dataVersionChecked) < dataVersionTTL {
+		return s.lastRefresh, !s.lastRefresh.IsZero()
+	}
+	if s.db == nil {
+		return time.Time{}, false
+	}
+
+	last, err := query.LastMatViewRefresh(s.db)
+	if err != nil {
+		log.WithError(err).Warning("error querying last matview refresh")
+		return time.Time{}, false
+	}
+	s.dataVersionChecked = time.Now()
+	s.lastRefresh = time.Time{}
+	if last != nil {
+		s.lastRefresh = *last
+	}
+	return s.lastRefresh, !s.lastRefresh.IsZero()
+}
+
+// conditional tags successful GET responses with an ETag derived from the request and when the reports were last
+// refreshed, answering requests whose If-None-Match still matches with 304 Not Modified without running the handler.
+// The ETag also changes every cacheTime, endpoints cached for less than the time between refreshes serve data that
+// changes in between.
+func (s *Server) conditional(cacheTime time.Duration, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handler(w, r)
+			return
+		}
+		version, ok := s.dataVersion()
+		if !ok {
+			handler(w, r)
+			return
+		}
+
+		etag := responseETag(r, version, time.Now().Truncate(cacheTime))
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			setETag(w.Header(), etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		handler(&etagWriter{ResponseWriter: w, etag: etag}, r)
+	}
+}
+
+// responseETag is weak, the same response may be compressed differently.
+func responseETag(r *http.Request, version, period time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d", apiCacheKey(r), version.UnixNano(), period.Unix())))
+	return fmt.Sprintf(`W/"%x"`, sum[:12])
+}
+
+// etagMatches compares ETags weakly, as If-None-Match requires.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// setETag also has clients revalidate the response every time, so they don't keep using it once the reports are
+// refreshed.
+func setETag(header http.Header, etag string) {
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", "no-cache")
+}
+
+// etagWriter sets the ETag on successful responses only, errors shouldn't be reused.
+type etagWriter struct {
+	http.ResponseWriter
+	etag        string
+	wroteHeader bool
+}
+
+func (e *etagWriter) WriteHeader(status int) {
+	if !e.wroteHeader {
+		e.wroteHeader = true
+		if status == http.StatusOK {
+			setETag(e.Header(), e.etag)
+		}
+	}
+	e.ResponseWriter.WriteHeader(status)
+}
+
+func (e *etagWriter) Write(b []byte) (int, error) {
+	if !e.wroteHeader {
+		e.WriteHeader(http.StatusOK)
+	}
+	return e.ResponseWriter.Write(b)
+}
+
+// Flush passes flushes through for streaming responses.
+func (e *etagWriter) Flush() {
+	if f, ok := e.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
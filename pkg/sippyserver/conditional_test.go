@@ -0,0 +1,66 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditional(t *testing.T) {
+	refreshed := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	s := &Server{lastRefresh: refreshed, dataVersionChecked: time.Now()}
+
+	calls := 0
+	status := http.StatusOK
+	handler := s.conditional(time.Hour, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"release":"4.16"}`))
+	})
+	serve := func(url, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	first := serve("/api/tests?release=4.16&period=default", "")
+	assert.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "no-cache", first.Header().Get("Cache-Control"))
+
+	notModified := serve("/api/tests?period=default&release=4.16", etag)
+	assert.Equal(t, http.StatusNotModified, notModified.Code, "parameter order doesn't change the ETag")
+	assert.Empty(t, notModified.Body.String())
+	assert.Equal(t, 1, calls)
+
+	otherQuery := serve("/api/tests?release=4.15&period=default", etag)
+	assert.Equal(t, http.StatusOK, otherQuery.Code)
+	assert.NotEqual(t, etag, otherQuery.Header().Get("ETag"))
+
+	s.lastRefresh = refreshed.Add(time.Hour)
+	afterRefresh := serve("/api/tests?release=4.16&period=default", etag)
+	assert.Equal(t, http.StatusOK, afterRefresh.Code)
+	assert.NotEqual(t, etag, afterRefresh.Header().Get("ETag"))
+
+	status = http.StatusInternalServerError
+	failed := serve("/api/tests?release=4.14", "")
+	assert.Empty(t, failed.Header().Get("ETag"), "errors aren't tagged")
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`W/"abc"`, `W/"abc"`))
+	assert.True(t, etagMatches(`"abc"`, `W/"abc"`))
+	assert.True(t, etagMatches(`"xyz", W/"abc"`, `W/"abc"`))
+	assert.True(t, etagMatches(`*`, `W/"abc"`))
+	assert.False(t, etagMatches(``, `W/"abc"`))
+	assert.False(t, etagMatches(`W/"xyz"`, `W/"abc"`))
+}
@@ -0,0 +1,52 @@
+package sippyserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+func TestReloadConfig(t *testing.T) {
+	s := &Server{views: &apitype.SippyViews{}}
+	require.NoError(t, s.SetReportTemplates(nil, ""))
+	require.NoError(t, s.SetSLOs(nil))
+
+	views := &apitype.SippyViews{ComponentReadiness: []crtype.View{{Name: "4.17-main"}}}
+	config := &v1config.SippyConfig{
+		Indicators: []v1config.IndicatorConfig{{Name: "infrastructure"}},
+		ReportTemplates: []v1config.ReportTemplate{
+			{Name: "job-count", SQL: "SELECT COUNT(*) FROM prow_jobs"},
+		},
+	}
+	reloaded := make(chan struct{}, 2)
+	s.SetConfigReloader(func() (*apitype.SippyViews, *v1config.SippyConfig, error) {
+		return views, config, nil
+	}, func() {
+		reloaded <- struct{}{}
+	})
+	require.NoError(t, s.ReloadConfig())
+	assert.Equal(t, views, s.GetViews())
+	assert.Equal(t, config, s.GetConfig())
+	assert.Equal(t, config.Indicators, s.GetIndicators())
+	assert.True(t, s.getReportTemplates().Has("job-count"))
+	<-reloaded
+
+	// An invalid configuration is rejected as a whole, leaving what was loaded before.
+	s.SetConfigReloader(func() (*apitype.SippyViews, *v1config.SippyConfig, error) {
+		return &apitype.SippyViews{}, &v1config.SippyConfig{
+			ReportTemplates: []v1config.ReportTemplate{{Name: "bad"}},
+		}, nil
+	}, func() {
+		reloaded <- struct{}{}
+	})
+	assert.Error(t, s.ReloadConfig())
+	assert.Equal(t, views, s.GetViews())
+	assert.Equal(t, config, s.GetConfig())
+	assert.True(t, s.getReportTemplates().Has("job-count"))
+	assert.Empty(t, reloaded, "reload hook called for a rejected configuration")
+}
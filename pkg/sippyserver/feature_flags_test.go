@@ -0,0 +1,41 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/api"
+)
+
+func TestRequireFeatureFlag(t *testing.T) {
+	s := &Server{}
+	assert.NoError(t, s.SetFeatureFlags(map[string]int{api.FeaturePromotionRecommendation: 100}))
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name     string
+		feature  string
+		optIn    string
+		wantCode int
+	}{
+		{name: "rolled out", feature: api.FeaturePromotionRecommendation, wantCode: http.StatusOK},
+		{name: "dark", feature: api.FeatureReleaseScorecard, wantCode: http.StatusNotFound},
+		{name: "opted in", feature: api.FeatureReleaseScorecard, optIn: api.FeatureReleaseScorecard, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/experimental", nil)
+			if tt.optIn != "" {
+				req.Header.Set(api.FeatureFlagsHeader, tt.optIn)
+			}
+			w := httptest.NewRecorder()
+			s.requireFeatureFlag(tt.feature, ok)(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
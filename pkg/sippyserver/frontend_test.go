@@ -0,0 +1,85 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFrontend(t *testing.T) {
+	devServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("dev server " + r.URL.Path))
+	}))
+	defer devServer.Close()
+	devServerURL, err := url.Parse(devServer.URL)
+	assert.NoError(t, err)
+
+	files := fstest.MapFS{
+		"index.html":    {Data: []byte("embedded index")},
+		"static/app.js": {Data: []byte("embedded app")},
+	}
+
+	tests := []struct {
+		name       string
+		uiDevProxy *url.URL
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "embedded file",
+			path:       "/sippy-ng/static/app.js",
+			wantStatus: http.StatusOK,
+			wantBody:   "embedded app",
+		},
+		{
+			name:       "embedded browser route",
+			path:       "/sippy-ng/jobs/4.16",
+			wantStatus: http.StatusOK,
+			wantBody:   "embedded index",
+		},
+		{
+			name:       "embedded unknown path",
+			path:       "/ws",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "proxied route",
+			uiDevProxy: devServerURL,
+			path:       "/sippy-ng/jobs/4.16",
+			wantStatus: http.StatusOK,
+			wantBody:   "dev server /sippy-ng/jobs/4.16",
+		},
+		{
+			name:       "proxied live reload",
+			uiDevProxy: devServerURL,
+			path:       "/ws",
+			wantStatus: http.StatusOK,
+			wantBody:   "dev server /ws",
+		},
+		{
+			name:       "root redirects when proxying",
+			uiDevProxy: devServerURL,
+			path:       "/",
+			wantStatus: http.StatusMovedPermanently,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{sippyNG: files, static: fstest.MapFS{}, uiDevProxy: tt.uiDevProxy}
+			serveMux := http.NewServeMux()
+			s.registerFrontend(serveMux)
+
+			w := httptest.NewRecorder()
+			serveMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
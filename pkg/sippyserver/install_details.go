@@ -1,9 +1,14 @@
 package sippyserver
 
 import (
+	"fmt"
 	"net/http"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/openshift/sippy/pkg/api"
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/html/installhtml"
 	"github.com/openshift/sippy/pkg/util/param"
 )
 
@@ -18,3 +23,84 @@ func (s *Server) jsonInstallReportFromDB(w http.ResponseWriter, req *http.Reques
 
 	api.PrintInstallJSONReportFromDB(w, s.db, release)
 }
+
+func (s *Server) jsonInstallOperatorHealthFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	_, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	results, err := api.GetInstallOperatorHealthFromDB(s.db, release, s.variantManager.AllPlatforms(),
+		param.SafeRead(req, "platform"), boundary, end)
+	if err != nil {
+		log.WithError(err).Error("error querying install operator health")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying install operator health: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// installReportExport renders the install rates and operator install failures as a standalone page, to attach to
+// status emails. PDFs need a renderer configured with --pdf-renderer.
+func (s *Server) installReportExport(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	format := param.SafeRead(req, "format")
+	if format == "" {
+		format = "pdf"
+	}
+	if format == "pdf" && s.pdfRenderer == nil {
+		api.RespondWithError(w, http.StatusNotImplemented, "pdf export is disabled, enable it with --pdf-renderer or request format=html")
+		return
+	}
+
+	reportType := v1.CurrentReport
+	if getPeriod(req, "default") == "twoDay" {
+		reportType = v1.TwoDayReport
+	}
+	_, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	columns, installRates, err := api.InstallReport(s.db, release, reportType)
+	if err != nil {
+		log.WithError(err).Error("error querying install report")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying install report: "+err.Error())
+		return
+	}
+	operators, err := api.GetInstallOperatorHealthFromDB(s.db, release, s.variantManager.AllPlatforms(), "", boundary, end)
+	if err != nil {
+		log.WithError(err).Error("error querying install operator health")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying install operator health: "+err.Error())
+		return
+	}
+
+	report := installhtml.InstallReport{
+		Release:      release,
+		Start:        boundary,
+		End:          end,
+		Columns:      columns.List(),
+		InstallRates: installRates,
+		Operators:    operators,
+	}
+	content, err := report.HTML()
+	if err != nil {
+		log.WithError(err).Error("error rendering install report")
+		api.RespondWithError(w, http.StatusInternalServerError, "error rendering install report: "+err.Error())
+		return
+	}
+
+	contentType := "text/html; charset=utf-8"
+	if format == "pdf" {
+		if content, err = s.pdfRenderer.Render(req.Context(), content); err != nil {
+			log.WithError(err).Error("error rendering install report pdf")
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		contentType = "application/pdf"
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("install-health-%s.pdf", release)))
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content)
+}
@@ -93,6 +93,10 @@ var (
 		Name: "sippy_hours_since_last_update",
 		Help: "Number of hours since Sippy last successfully fetched new data.",
 	}, []string{})
+	hoursSinceLastBugSync = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_hours_since_last_bug_sync",
+		Help: "Number of hours since Sippy last synced bugs from Jira.",
+	}, []string{})
 	componentReadinessMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "sippy_component_readiness",
 		Help: "Regression score for components",
@@ -117,6 +121,26 @@ var (
 		Name: "sippy_disruption_vs_two_weeks_ago",
 		Help: "Delta of percentiles now vs two weeks ago for a given release",
 	}, []string{"delta", "release", "platform", "backend", "upgrade_type", "master_nodes_updated", "network", "topology", "architecture", "releaseStatus"})
+	databaseBytesMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_db_bytes",
+		Help: "Disk usage of the database in bytes",
+	}, []string{})
+	databaseDaysUntilFullMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_db_days_until_full",
+		Help: "Days until the database is projected to exceed its disk budget at its growth over the last week, only set with a budget and growth",
+	}, []string{})
+	databaseTableBytesMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_db_table_bytes",
+		Help: "Disk usage in bytes of a table, including its partitions and indexes, or materialized view",
+	}, []string{"name", "kind"})
+	databaseTableBloatMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_db_table_bloat_bytes",
+		Help: "Estimated bytes taken by dead rows of a table or materialized view",
+	}, []string{"name", "kind"})
+	databaseTableGrowthMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_db_table_growth_bytes_per_day",
+		Help: "Bytes a day a table or materialized view has grown over the last week",
+	}, []string{"name", "kind"})
 	disruptionVsTwoWeeksAgoRelevanceMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "sippy_disruption_vs_two_weeks_ago_relevance",
 		Help: "Rating of how relevant we feel our data is for regression detection.",
@@ -136,7 +160,7 @@ func getReleaseStatus(releases []v1.Release, release string) string {
 
 // presume in a historical context there won't be scraping of these metrics
 // pinning the time just to be consistent
-func RefreshMetricsDB(ctx context.Context, dbc *db.DB, bqc *bqclient.Client, prowURL, gcsBucket string, variantManager testidentification.VariantManager, reportEnd time.Time, cacheOptions cache.RequestOptions, views []crtype.View) error {
+func RefreshMetricsDB(ctx context.Context, dbc *db.DB, bqc *bqclient.Client, prowURL, gcsBucket string, variantManager testidentification.VariantManager, promotionWarningThreshold time.Duration, reportEnd time.Time, cacheOptions cache.RequestOptions, views []crtype.View) error {
 	start := time.Now()
 	log.Info("beginning refresh metrics")
 	releases, err := api.GetReleases(context.Background(), bqc)
@@ -155,6 +179,14 @@ func RefreshMetricsDB(ctx context.Context, dbc *db.DB, bqc *bqclient.Client, pro
 		}
 		hoursSinceLastUpdate.WithLabelValues().Set(time.Since(lastUpdated).Hours())
 
+		var lastBugSync *time.Time
+		if r := dbc.DB.Raw("SELECT MAX(last_sync_time) FROM bugs").Scan(&lastBugSync); r.Error != nil {
+			return errors.Wrapf(r.Error, "could not fetch last bug sync time")
+		}
+		if lastBugSync != nil {
+			hoursSinceLastBugSync.WithLabelValues().Set(time.Since(*lastBugSync).Hours())
+		}
+
 		for _, pType := range promReportTypes {
 			// start, boundary and end will just be defaults
 			// the api will decide based on the period
@@ -180,7 +212,7 @@ func RefreshMetricsDB(ctx context.Context, dbc *db.DB, bqc *bqclient.Client, pro
 		// Add a metric for any warnings for each release. We can't convey exact details with prom, but we can
 		// tell you x warnings are present and link you to the overview in the alert.
 		for _, release := range releases {
-			releaseWarnings := api.ScanForReleaseWarnings(dbc, release.Release, reportEnd)
+			releaseWarnings := api.ScanForReleaseWarnings(dbc, release.Release, promotionWarningThreshold, reportEnd)
 			releaseStatus := getReleaseStatus(releases, release.Release)
 			releaseWarningsMetric.WithLabelValues(release.Release, releaseStatus).Set(float64(len(releaseWarnings)))
 		}
@@ -200,15 +232,18 @@ func RefreshMetricsDB(ctx context.Context, dbc *db.DB, bqc *bqclient.Client, pro
 		if err := refreshInfraMetrics(dbc, variantManager); err != nil {
 			log.WithError(err).Error("error refreshing infrastructure success metrics")
 		}
+		if err := refreshDatabaseUsageMetrics(dbc); err != nil {
+			log.WithError(err).Error("error refreshing database usage metrics")
+		}
 	}
 
 	// BigQuery metrics
 	if bqc != nil {
 		refreshComponentReadinessMetrics(ctx, bqc, prowURL, gcsBucket, cacheOptions, views, releases)
+	}
 
-		if err := refreshDisruptionMetrics(bqc, releases); err != nil {
-			log.WithError(err).Error("error refreshing disruption metrics")
-		}
+	if err := refreshDisruptionMetrics(dbc, bqc, releases); err != nil {
+		log.WithError(err).Error("error refreshing disruption metrics")
 	}
 
 	log.Infof("refresh metrics completed in %s", time.Since(start))
@@ -332,6 +367,28 @@ func refreshInfraMetrics(dbc *db.DB, variantManager testidentification.VariantMa
 	return nil
 }
 
+func refreshDatabaseUsageMetrics(dbc *db.DB) error {
+	usage, err := api.GetDatabaseUsageFromDB(dbc, time.Now())
+	if err != nil {
+		return err
+	}
+
+	databaseBytesMetric.WithLabelValues().Set(float64(usage.DatabaseBytes))
+	databaseDaysUntilFullMetric.Reset()
+	if usage.DaysUntilFull != nil {
+		databaseDaysUntilFullMetric.WithLabelValues().Set(*usage.DaysUntilFull)
+	}
+	databaseTableBytesMetric.Reset()
+	databaseTableBloatMetric.Reset()
+	databaseTableGrowthMetric.Reset()
+	for _, t := range usage.Tables {
+		databaseTableBytesMetric.WithLabelValues(t.Name, t.Kind).Set(float64(t.TotalBytes))
+		databaseTableBloatMetric.WithLabelValues(t.Name, t.Kind).Set(float64(t.BloatBytes))
+		databaseTableGrowthMetric.WithLabelValues(t.Name, t.Kind).Set(t.GrowthBytesPerDay)
+	}
+	return nil
+}
+
 func refreshBuildClusterMetrics(dbc *db.DB, reportEnd time.Time) error {
 	for _, period := range []string{"current", "twoDay"} {
 		start, boundary, end := util.PeriodToDates(period, reportEnd)
@@ -410,18 +467,46 @@ func refreshPayloadMetrics(dbc *db.DB, reportEnd time.Time, releases []v1.Releas
 
 // refreshDisruptionMetrics queries our BigQuery views for current release vs two weeks ago, and previous release GA.
 // Metrics are published for the delta for each NURP which can then be alerted on if certain thresholds are exceeded.
-// The previous GA view should have its release and GA date updated on each release GA.
-func refreshDisruptionMetrics(client *bqclient.Client, releases []v1.Release) error {
-	if client == nil || client.BQ == nil {
-		log.Warningf("not generating disruption metrics as we don't have a bigquery client")
+// The previous GA view should have its release and GA date updated on each release GA. The two weeks ago comparison
+// is made from the disruption percentiles loaded into postgres when there are any, BigQuery is only queried for it
+// when they haven't been loaded.
+func refreshDisruptionMetrics(dbc *db.DB, client *bqclient.Client, releases []v1.Release) error {
+	haveBigQuery := client != nil && client.BQ != nil && client.Cache != nil
+	if !haveBigQuery && dbc == nil {
+		log.Warningf("not generating disruption metrics as we don't have a bigquery client or database")
 		return nil
 	}
 
-	if client.Cache == nil {
-		log.Warningf("not generating disruption metrics as we don't have a cache configured")
+	if haveBigQuery {
+		if err := refreshDisruptionVsPrevGAMetrics(client, releases); err != nil {
+			return err
+		}
+	}
+
+	if dbc != nil {
+		disruptionReport, err := api.GetDisruptionVsTwoWeeksAgoReportFromDB(dbc)
+		if err != nil {
+			return err
+		}
+		if len(disruptionReport.Rows) > 0 {
+			refreshDisruptionVsTwoWeeksAgoMetrics(dbc, disruptionReport, releases)
+			return nil
+		}
+		log.Infof("no disruption percentiles loaded into postgres")
+	}
+	if !haveBigQuery {
 		return nil
 	}
 
+	disruptionReport, err := api.GetDisruptionVsTwoWeeksAgoReportFromBigQuery(context.Background(), client)
+	if err != nil {
+		return fmt.Errorf("errors returned: %v", err)
+	}
+	refreshDisruptionVsTwoWeeksAgoMetrics(dbc, disruptionReport, releases)
+	return nil
+}
+
+func refreshDisruptionVsPrevGAMetrics(client *bqclient.Client, releases []v1.Release) error {
 	disruptionReport, err := api.GetDisruptionVsPrevGAReportFromBigQuery(context.Background(), client)
 	if err != nil {
 		return fmt.Errorf("errors returned: %v", err)
@@ -445,10 +530,15 @@ func refreshDisruptionMetrics(client *bqclient.Client, releases []v1.Release) er
 			row.Release, row.CompareRelease, row.Platform, row.BackendName, row.UpgradeType,
 			row.MasterNodesUpdated, row.Network, row.Topology, row.Architecture, releaseStatus).Set(float64(row.Relevance))
 	}
+	return nil
+}
 
-	disruptionReport, err = api.GetDisruptionVsTwoWeeksAgoReportFromBigQuery(context.Background(), client)
-	if err != nil {
-		return fmt.Errorf("errors returned: %v", err)
+func refreshDisruptionVsTwoWeeksAgoMetrics(dbc *db.DB, disruptionReport apitype.DisruptionReport, releases []v1.Release) {
+	// Track how long each NURP has been worse than two weeks ago, so sustained regressions can be reported.
+	if dbc != nil {
+		if err := api.UpdateDisruptionRegressionStates(dbc, disruptionReport.Rows, time.Now()); err != nil {
+			log.WithError(err).Error("error updating disruption regression state")
+		}
 	}
 
 	for _, row := range disruptionReport.Rows {
@@ -469,8 +559,6 @@ func refreshDisruptionMetrics(client *bqclient.Client, releases []v1.Release) er
 			row.Release, row.CompareRelease, row.Platform, row.BackendName, row.UpgradeType,
 			row.MasterNodesUpdated, row.Network, row.Topology, row.Architecture, releaseStatus).Set(float64(row.Relevance))
 	}
-
-	return nil
 }
 
 type promReportType struct {
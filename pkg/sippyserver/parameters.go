@@ -74,32 +74,6 @@ func getLimitParam(req *http.Request) int {
 	return limit
 }
 
-func getPaginationParams(req *http.Request) (*apitype.Pagination, error) {
-	perPage := req.URL.Query().Get("perPage")
-	page := req.URL.Query().Get("page")
-	if perPage != "" {
-		perPageInt, err := strconv.Atoi(perPage)
-		if err != nil {
-			return nil, err
-		}
-
-		pageNo := 0
-		if page != "" {
-			pageNo, err = strconv.Atoi(page)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		return &apitype.Pagination{
-			PerPage: perPageInt,
-			Page:    pageNo,
-		}, nil
-	}
-
-	return nil, nil
-}
-
 func getSortParams(req *http.Request) (string, apitype.Sort) {
 	sortField := param.SafeRead(req, "sortField")
 	sort := apitype.Sort(param.SafeRead(req, "sort"))
@@ -0,0 +1,27 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsHandler returns the handler for the metrics listener. If profiling is enabled, the pprof endpoints
+// are served alongside the metrics under /debug/pprof/. The metrics listener is not exposed publicly, unlike the
+// API, so this is where we diagnose memory and CPU use in production.
+//
+// Importing net/http/pprof registers its handlers on http.DefaultServeMux, so listeners must use this handler
+// rather than the default mux to keep profiling off unless asked for.
+func NewMetricsHandler(enableProfiling bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	if enableProfiling {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	return mux
+}
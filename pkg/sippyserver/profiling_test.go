@@ -0,0 +1,48 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricsHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		enableProfiling bool
+		path            string
+		wantStatus      int
+	}{
+		{
+			name:       "metrics are always served",
+			path:       "/metrics",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "pprof is not served by default",
+			path:       "/debug/pprof/",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:            "pprof is served when profiling is enabled",
+			enableProfiling: true,
+			path:            "/debug/pprof/",
+			wantStatus:      http.StatusOK,
+		},
+		{
+			name:            "named profiles are served through the index",
+			enableProfiling: true,
+			path:            "/debug/pprof/heap",
+			wantStatus:      http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewMetricsHandler(tt.enableProfiling).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package sippyserver
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// recalculation lists the materialized views and cached API responses derived from some metadata, so they can be
+// brought up to date when an admin changes it rather than at the next full refresh.
+type recalculation struct {
+	reason string
+	// matViews are refreshed first, so responses cached after the purge use the new data.
+	matViews []string
+	// cachePaths are the API path prefixes whose cached responses are purged.
+	cachePaths []string
+}
+
+// testReportRecalculation covers everything derived from the test report materialized views, which exclude
+// suppressed test results.
+func testReportRecalculation(reason string) recalculation {
+	return recalculation{
+		reason:   reason,
+		matViews: []string{"prow_test_report_7d_matview", "prow_test_report_2d_matview"},
+		cachePaths: []string{
+			"/api/tests",
+			"/api/health",
+			"/api/install",
+			"/api/upgrade",
+			"/api/feature_gates",
+			"/api/releases/",
+		},
+	}
+}
+
+// jobLineageRecalculation covers the job lineage reports. Lineage is read directly from the jobs, so there is no
+// view to refresh.
+func jobLineageRecalculation(reason string) recalculation {
+	return recalculation{
+		reason:     reason,
+		cachePaths: []string{"/api/jobs/lineage"},
+	}
+}
+
+// variantRecalculation covers everything derived from job variants, which nearly every view and report groups
+// or filters by.
+func variantRecalculation(reason string) recalculation {
+	r := recalculation{
+		reason:     reason,
+		cachePaths: []string{"/api/"},
+	}
+	for _, pmv := range db.PostgresMatViews {
+		r.matViews = append(r.matViews, pmv.Name)
+	}
+	return r
+}
+
+// recalculate refreshes the views and purges the cached responses of a recalculation in the background, as
+// refreshing a view can take minutes. Recalculations run one at a time.
+func (s *Server) recalculate(r recalculation) {
+	go func() {
+		s.recalculationLock.Lock()
+		defer s.recalculationLock.Unlock()
+		s.runRecalculation(context.Background(), r)
+	}()
+}
+
+func (s *Server) runRecalculation(ctx context.Context, r recalculation) {
+	logger := log.WithField("reason", r.reason)
+	logger.WithField("matviews", r.matViews).Info("recalculating after metadata change")
+
+	if err := refreshMatViews(ctx, s.db, r.matViews, s.GetRefreshOptions()); err != nil {
+		logger.WithError(err).Error("error recalculating after metadata change")
+	}
+
+	purger, ok := s.cache.(cache.Purger)
+	if !ok {
+		return
+	}
+	for _, path := range r.cachePaths {
+		purged, err := purger.Purge(ctx, apiCacheKeyPrefix+path)
+		if err != nil {
+			logger.WithError(err).WithField("path", path).Warn("error purging api cache")
+			continue
+		}
+		logger.WithFields(log.Fields{"path": path, "entries": purged}).Info("purged api cache")
+	}
+}
@@ -0,0 +1,23 @@
+package sippyserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunRecalculation(t *testing.T) {
+	c := memoryCache{
+		apiCacheKeyPrefix + "/api/jobs/lineage?job=periodic-ci-e2e-aws": []byte("a"),
+		apiCacheKeyPrefix + "/api/jobs?release=4.16":                    []byte("b"),
+		"other": []byte("c"),
+	}
+	s := &Server{cache: c}
+
+	s.runRecalculation(context.Background(), jobLineageRecalculation("test"))
+	assert.Equal(t, memoryCache{
+		apiCacheKeyPrefix + "/api/jobs?release=4.16": []byte("b"),
+		"other": []byte("c"),
+	}, c)
+}
@@ -0,0 +1,250 @@
+package sippyserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/util/param"
+)
+
+// maxRefreshJobs is how many refresh jobs are remembered, the oldest finished jobs are forgotten first.
+const maxRefreshJobs = 100
+
+// refreshJobQueued is the status of a refresh job waiting for another refresh or recalculation to finish, the
+// other statuses are those of the refresh history.
+const refreshJobQueued = "queued"
+
+var (
+	errRefreshJobNotFound = errors.New("no refresh job with that id")
+	errRefreshJobFinished = errors.New("refresh job has already finished")
+)
+
+// refreshJob is a materialized view refresh requested through the API, run in the background because refreshing
+// every view can take an hour.
+type refreshJob struct {
+	ID        int        `json:"id"`
+	MatViews  []string   `json:"matviews"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Error     string     `json:"error,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+// refreshJobs tracks the refresh jobs requested through the API.
+type refreshJobs struct {
+	lock   sync.Mutex
+	nextID int
+	jobs   map[int]*refreshJob
+	// run refreshes the views, calling started once it is no longer waiting on other refreshes, and returns the
+	// context's error if it was canceled.
+	run refreshFunc
+}
+
+type refreshFunc func(ctx context.Context, matViews []string, started func()) error
+
+func newRefreshJobs(run refreshFunc) *refreshJobs {
+	return &refreshJobs{
+		nextID: 1,
+		jobs:   map[int]*refreshJob{},
+		run:    run,
+	}
+}
+
+// submit starts a job refreshing the views in the background and returns it.
+func (r *refreshJobs) submit(matViews []string) refreshJob {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r.lock.Lock()
+	job := &refreshJob{
+		ID:        r.nextID,
+		MatViews:  matViews,
+		Status:    refreshJobQueued,
+		CreatedAt: time.Now(),
+		cancel:    cancel,
+	}
+	r.nextID++
+	r.jobs[job.ID] = job
+	r.prune()
+	submitted := *job
+	r.lock.Unlock()
+
+	go func() {
+		defer cancel()
+		err := r.run(ctx, matViews, func() { r.started(job.ID) })
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		ended := time.Now()
+		job.EndedAt = &ended
+		switch {
+		case ctx.Err() != nil:
+			job.Status = models.MatViewRefreshCanceled
+			job.Error = ctx.Err().Error()
+		case err != nil:
+			log.WithError(err).WithField("job", job.ID).Error("materialized view refresh job failed")
+			job.Status = models.MatViewRefreshFailed
+			job.Error = err.Error()
+		default:
+			job.Status = models.MatViewRefreshSucceeded
+		}
+	}()
+	return submitted
+}
+
+// started marks a queued job as running.
+func (r *refreshJobs) started(id int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if job, ok := r.jobs[id]; ok && job.Status == refreshJobQueued {
+		job.Status = models.MatViewRefreshRunning
+	}
+}
+
+// get returns the job with the id.
+func (r *refreshJobs) get(id int) (refreshJob, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return refreshJob{}, errRefreshJobNotFound
+	}
+	return *job, nil
+}
+
+// list returns the jobs, newest first.
+func (r *refreshJobs) list() []refreshJob {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	jobs := make([]refreshJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].ID > jobs[j].ID
+	})
+	return jobs
+}
+
+// cancelJob cancels the job with the id. The job reports canceled once the refresh in progress has stopped.
+func (r *refreshJobs) cancelJob(id int) (refreshJob, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return refreshJob{}, errRefreshJobNotFound
+	}
+	if job.EndedAt != nil {
+		return *job, errRefreshJobFinished
+	}
+	job.cancel()
+	return *job, nil
+}
+
+// prune forgets the oldest finished jobs beyond maxRefreshJobs. The lock must be held.
+func (r *refreshJobs) prune() {
+	ids := make([]int, 0, len(r.jobs))
+	for id := range r.jobs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if len(r.jobs) <= maxRefreshJobs {
+			return
+		}
+		if r.jobs[id].EndedAt != nil {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// jsonMatViewRefreshJobs starts a background refresh of the materialized views on POST, and reports the status of
+// the refreshes started on GET.
+func (s *Server) jsonMatViewRefreshJobs(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("id") == "" {
+			api.RespondWithJSON(http.StatusOK, w, s.refreshJobs.list())
+			return
+		}
+		id, ok := refreshJobID(w, req)
+		if !ok {
+			return
+		}
+		job, err := s.refreshJobs.get(id)
+		if err != nil {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, job)
+	case http.MethodPost:
+		var matViews []string
+		if matView := param.SafeRead(req, "matview"); matView != "" {
+			if !isPostgresMatView(matView) {
+				api.RespondWithError(w, http.StatusBadRequest, "unknown matview "+matView)
+				return
+			}
+			matViews = []string{matView}
+		} else {
+			for _, pmv := range db.PostgresMatViews {
+				matViews = append(matViews, pmv.Name)
+			}
+		}
+		job := s.refreshJobs.submit(matViews)
+		log.WithFields(log.Fields{"job": job.ID, "matviews": matViews}).Info("started materialized view refresh job")
+		api.RespondWithJSON(http.StatusAccepted, w, job)
+	default:
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "refreshes are started with a POST")
+	}
+}
+
+// jsonCancelMatViewRefreshJob cancels a background refresh of the materialized views.
+func (s *Server) jsonCancelMatViewRefreshJob(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "refreshes are canceled with a POST")
+		return
+	}
+	id, ok := refreshJobID(w, req)
+	if !ok {
+		return
+	}
+	job, err := s.refreshJobs.cancelJob(id)
+	switch {
+	case errors.Is(err, errRefreshJobNotFound):
+		api.RespondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, errRefreshJobFinished):
+		api.RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		log.WithField("job", id).Info("canceled materialized view refresh job")
+		api.RespondWithJSON(http.StatusAccepted, w, job)
+	}
+}
+
+func refreshJobID(w http.ResponseWriter, req *http.Request) (int, bool) {
+	id, err := strconv.Atoi(param.SafeRead(req, "id"))
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "id must be a refresh job id")
+		return 0, false
+	}
+	return id, true
+}
+
+func isPostgresMatView(name string) bool {
+	for _, pmv := range db.PostgresMatViews {
+		if pmv.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package sippyserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// fakeRefresh records the views it was asked to refresh and blocks until released or canceled.
+type fakeRefresh struct {
+	matViews chan []string
+	release  chan error
+}
+
+func newFakeRefresh() *fakeRefresh {
+	return &fakeRefresh{matViews: make(chan []string, 10), release: make(chan error)}
+}
+
+func (f *fakeRefresh) run(ctx context.Context, matViews []string, started func()) error {
+	started()
+	f.matViews <- matViews
+	select {
+	case err := <-f.release:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func serveRefreshJobs(t *testing.T, s *Server, method, target string) (int, refreshJob) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, nil)
+	if req.URL.Path == "/api/admin/matviews/refresh/cancel" {
+		s.jsonCancelMatViewRefreshJob(rec, req)
+	} else {
+		s.jsonMatViewRefreshJobs(rec, req)
+	}
+	job := refreshJob{}
+	if rec.Code < 300 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	}
+	return rec.Code, job
+}
+
+func waitForRefreshJobStatus(t *testing.T, s *Server, id int, status string) refreshJob {
+	var job refreshJob
+	assert.Eventually(t, func() bool {
+		var err error
+		job, err = s.refreshJobs.get(id)
+		return err == nil && job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestMatViewRefreshJobSubmit(t *testing.T) {
+	refresh := newFakeRefresh()
+	s := &Server{refreshJobs: newRefreshJobs(refresh.run)}
+
+	code, job := serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh?matview=prow_test_report_7d_matview")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, 1, job.ID)
+	assert.Equal(t, []string{"prow_test_report_7d_matview"}, <-refresh.matViews)
+
+	code, job = serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, 2, job.ID)
+	assert.Len(t, <-refresh.matViews, len(db.PostgresMatViews))
+
+	code, _ = serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh?matview=prow_jobs")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	refresh.release <- nil
+	refresh.release <- nil
+	waitForRefreshJobStatus(t, s, 1, models.MatViewRefreshSucceeded)
+	waitForRefreshJobStatus(t, s, 2, models.MatViewRefreshSucceeded)
+}
+
+func TestMatViewRefreshJobStatus(t *testing.T) {
+	refresh := newFakeRefresh()
+	s := &Server{refreshJobs: newRefreshJobs(refresh.run)}
+
+	_, submitted := serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh?matview=prow_test_report_7d_matview")
+	<-refresh.matViews
+
+	code, job := serveRefreshJobs(t, s, http.MethodGet, "/api/admin/matviews/refresh?id=1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, submitted.ID, job.ID)
+	assert.Equal(t, models.MatViewRefreshRunning, job.Status)
+	assert.Nil(t, job.EndedAt)
+
+	refresh.release <- nil
+	job = waitForRefreshJobStatus(t, s, 1, models.MatViewRefreshSucceeded)
+	assert.NotNil(t, job.EndedAt)
+	assert.Empty(t, job.Error)
+
+	rec := httptest.NewRecorder()
+	s.jsonMatViewRefreshJobs(rec, httptest.NewRequest(http.MethodGet, "/api/admin/matviews/refresh", nil))
+	jobs := []refreshJob{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jobs))
+	assert.Len(t, jobs, 1)
+
+	code, _ = serveRefreshJobs(t, s, http.MethodGet, "/api/admin/matviews/refresh?id=2")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = serveRefreshJobs(t, s, http.MethodGet, "/api/admin/matviews/refresh?id=first")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMatViewRefreshJobFailure(t *testing.T) {
+	refresh := newFakeRefresh()
+	s := &Server{refreshJobs: newRefreshJobs(refresh.run)}
+
+	serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh?matview=prow_test_report_7d_matview")
+	<-refresh.matViews
+	refresh.release <- errors.New("error refreshing prow_test_report_7d_matview")
+
+	job := waitForRefreshJobStatus(t, s, 1, models.MatViewRefreshFailed)
+	assert.Equal(t, "error refreshing prow_test_report_7d_matview", job.Error)
+	assert.NotNil(t, job.EndedAt)
+}
+
+func TestMatViewRefreshJobCancel(t *testing.T) {
+	refresh := newFakeRefresh()
+	s := &Server{refreshJobs: newRefreshJobs(refresh.run)}
+
+	serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh?matview=prow_test_report_7d_matview")
+	<-refresh.matViews
+
+	code, _ := serveRefreshJobs(t, s, http.MethodGet, "/api/admin/matviews/refresh/cancel?id=1")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, _ = serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh/cancel?id=1")
+	assert.Equal(t, http.StatusAccepted, code)
+	job := waitForRefreshJobStatus(t, s, 1, models.MatViewRefreshCanceled)
+	assert.Equal(t, context.Canceled.Error(), job.Error)
+
+	code, _ = serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh/cancel?id=1")
+	assert.Equal(t, http.StatusConflict, code)
+	code, _ = serveRefreshJobs(t, s, http.MethodPost, "/api/admin/matviews/refresh/cancel?id=2")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestRefreshJobsPrune(t *testing.T) {
+	r := newRefreshJobs(func(context.Context, []string, func()) error { return nil })
+	for i := 0; i < maxRefreshJobs+10; i++ {
+		job := r.submit(nil)
+		assert.Eventually(t, func() bool {
+			j, _ := r.get(job.ID)
+			return j.EndedAt != nil
+		}, 5*time.Second, time.Millisecond)
+	}
+	jobs := r.list()
+	assert.Len(t, jobs, maxRefreshJobs)
+	assert.Equal(t, maxRefreshJobs+10, jobs[0].ID)
+}
+
+func TestLockMatView(t *testing.T) {
+	unlock, err := lockMatView(context.Background(), "prow_test_report_7d_matview")
+	require.NoError(t, err)
+
+	// Other views are not blocked.
+	unlockOther, err := lockMatView(context.Background(), "prow_test_report_2d_matview")
+	require.NoError(t, err)
+	unlockOther()
+
+	// A second refresh of the same view waits, until canceled or the first is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = lockMatView(ctx, "prow_test_report_7d_matview")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+	unlock, err = lockMatView(context.Background(), "prow_test_report_7d_matview")
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestSetRefreshOptions(t *testing.T) {
+	s := &Server{}
+	s.SetRefreshOptions(RefreshOptions{OnlyIfEmpty: true, Parallelism: 4, Timeout: time.Minute})
+	assert.Equal(t, RefreshOptions{Parallelism: 4, Timeout: time.Minute}, s.GetRefreshOptions())
+}
@@ -0,0 +1,69 @@
+package sippyserver
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var apiRequestsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sippy_api_requests_total",
+	Help: "API requests by endpoint, method and response status code",
+}, []string{"endpoint", "method", "code"})
+
+var apiRequestDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sippy_api_request_duration_seconds",
+	Help:    "Seconds taken to respond to API requests by endpoint",
+	Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+}, []string{"endpoint"})
+
+var apiResponseSizeMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "sippy_api_response_size_bytes",
+	Help:    "Size of API response bodies by endpoint",
+	Buckets: prometheus.ExponentialBuckets(256, 4, 10),
+}, []string{"endpoint"})
+
+// responseRecorder passes a response through, noting its status code and size.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush passes flushes through for streaming responses.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// instrumented records the count, latency, status and response size of requests to an API endpoint. The
+// endpoint is labeled by its registered path rather than the request's, to bound the metrics' cardinality.
+func instrumented(endpoint string, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := newResponseRecorder(w)
+		handler(recorder, r)
+		apiRequestsMetric.WithLabelValues(endpoint, r.Method, strconv.Itoa(recorder.status)).Inc()
+		apiRequestDurationMetric.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+		apiResponseSizeMetric.WithLabelValues(endpoint).Observe(float64(recorder.bytes))
+	}
+}
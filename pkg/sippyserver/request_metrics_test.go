@@ -0,0 +1,55 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumented(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoint    string
+		handler     func(w http.ResponseWriter, r *http.Request)
+		wantMetrics []string
+	}{
+		{
+			name:     "implicit ok",
+			endpoint: "/api/test/ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("hello"))
+			},
+			wantMetrics: []string{
+				`sippy_api_requests_total{code="200",endpoint="/api/test/ok",method="GET"} 1`,
+				`sippy_api_response_size_bytes_sum{endpoint="/api/test/ok"} 5`,
+				`sippy_api_request_duration_seconds_count{endpoint="/api/test/ok"} 1`,
+			},
+		},
+		{
+			name:     "error status",
+			endpoint: "/api/test/missing",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "missing", http.StatusNotFound)
+			},
+			wantMetrics: []string{
+				`sippy_api_requests_total{code="404",endpoint="/api/test/missing",method="GET"} 1`,
+				`sippy_api_response_size_bytes_sum{endpoint="/api/test/missing"} 8`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			instrumented(tt.endpoint, tt.handler)(w, httptest.NewRequest(http.MethodGet, tt.endpoint+"?release=4.16", nil))
+
+			metrics := httptest.NewRecorder()
+			promhttp.Handler().ServeHTTP(metrics, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			for _, want := range tt.wantMetrics {
+				assert.Contains(t, metrics.Body.String(), want)
+			}
+		})
+	}
+}
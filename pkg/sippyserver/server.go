@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,22 +32,28 @@ import (
 	apitype "github.com/openshift/sippy/pkg/apis/api"
 	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
 	"github.com/openshift/sippy/pkg/apis/cache"
+	v1config "github.com/openshift/sippy/pkg/apis/config/v1"
 	"github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/dataloader/variantsyncer"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/db/query"
 	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/html/pdf"
+	"github.com/openshift/sippy/pkg/slo"
 	"github.com/openshift/sippy/pkg/synthetictests"
 	"github.com/openshift/sippy/pkg/testidentification"
 	"github.com/openshift/sippy/pkg/util"
+	"github.com/openshift/sippy/pkg/util/pagination"
 	"github.com/openshift/sippy/pkg/util/param"
 )
 
-// Mode defines the server mode of operation, OpenShift or upstream Kubernetes.
+// Mode defines the server mode of operation, OpenShift, OKD or upstream Kubernetes.
 type Mode string
 
 const (
 	ModeOpenShift  Mode = "openshift"
+	ModeOKD        Mode = "okd"
 	ModeKubernetes Mode = "kube"
 )
 
@@ -66,30 +76,44 @@ func NewServer(
 ) *Server {
 
 	server := &Server{
-		mode:                 mode,
-		listenAddr:           listenAddr,
-		syntheticTestManager: syntheticTestManager,
-		variantManager:       variantManager,
-		sippyNG:              sippyNG,
-		static:               static,
-		db:                   dbClient,
-		bigQueryClient:       bigQueryClient,
-		pinnedDateTime:       pinnedDateTime,
-		prowURL:              prowURL,
-		gcsBucket:            gcsBucket,
-		gcsClient:            gcsClient,
-		cache:                cacheClient,
-		crTimeRoundingFactor: crTimeRoundingFactor,
-		views:                views,
-	}
-
-	if bigQueryClient != nil {
-		go componentreadiness.GetComponentTestVariantsFromBigQuery(context.Background(), bigQueryClient, gcsBucket)
+		mode:                      mode,
+		listenAddr:                listenAddr,
+		syntheticTestManager:      syntheticTestManager,
+		variantManager:            variantManager,
+		sippyNG:                   sippyNG,
+		static:                    static,
+		db:                        dbClient,
+		bigQueryClient:            bigQueryClient,
+		pinnedDateTime:            pinnedDateTime,
+		prowURL:                   prowURL,
+		gcsBucket:                 gcsBucket,
+		gcsClient:                 gcsClient,
+		cache:                     cacheClient,
+		crTimeRoundingFactor:      crTimeRoundingFactor,
+		views:                     views,
+		refreshOptions:            DefaultRefreshOptions(),
+		promotionWarningThreshold: api.DefaultPromotionWarningThreshold,
 	}
+	server.refreshJobs = newRefreshJobs(func(ctx context.Context, matViews []string, started func()) error {
+		// Requested refreshes run one at a time with recalculations. Each view is also locked while it refreshes,
+		// so one being refreshed on schedule is waited for rather than refreshed twice at once.
+		server.recalculationLock.Lock()
+		defer server.recalculationLock.Unlock()
+		started()
+		return refreshMatViews(ctx, dbClient, matViews, server.GetRefreshOptions())
+	})
 
 	return server
 }
 
+// ScanComponentTestVariants warms the cache of the component readiness test variants from BigQuery. With several
+// replicas, only the leader needs to.
+func (s *Server) ScanComponentTestVariants(ctx context.Context) {
+	if s.bigQueryClient != nil {
+		componentreadiness.GetComponentTestVariantsFromBigQuery(ctx, s.bigQueryClient, s.gcsBucket)
+	}
+}
+
 var matViewRefreshMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Name:    "sippy_matview_refresh_millis",
 	Help:    "Milliseconds to refresh our postgresql materialized views",
@@ -102,6 +126,22 @@ var allMatViewsRefreshMetric = promauto.NewHistogram(prometheus.HistogramOpts{
 	Buckets: []float64{5000, 10000, 30000, 60000, 300000, 600000, 1200000, 1800000, 2400000, 3000000, 3600000},
 })
 
+var apiCacheRequestsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sippy_api_cache_requests_total",
+	Help: "API requests to endpoints with response caching, by whether they were served from the cache",
+}, []string{"endpoint", "result"})
+
+// apiCacheKeyPrefix namespaces cached API responses, so they can be purged without affecting other cache users.
+const apiCacheKeyPrefix = "api:"
+
+const (
+	defaultTestSearchResults = 25
+	maxTestSearchResults     = 500
+)
+
+// liveIntervalsMaxDuration bounds how long a job run is tailed, longer than any job is allowed to run.
+const liveIntervalsMaxDuration = 8 * time.Hour
+
 type Server struct {
 	mode                 Mode
 	listenAddr           string
@@ -110,6 +150,7 @@ type Server struct {
 	sippyNG              fs.FS
 	static               fs.FS
 	httpServer           *http.Server
+	httpServerLock       sync.Mutex
 	db                   *db.DB
 	bigQueryClient       *bigquery.Client
 	pinnedDateTime       *time.Time
@@ -120,19 +161,255 @@ type Server struct {
 	crTimeRoundingFactor time.Duration
 	capabilities         []string
 	views                *apitype.SippyViews
+	// configLock guards the configuration that can be swapped by ReloadConfig: views, the sippy config and the
+	// indicators, report templates and SLOs built from it.
+	configLock sync.RWMutex
+	// config is the sippy configuration, including the releases and prow deployments scheduled loads import.
+	config *v1config.SippyConfig
+	// configReloader, if set, re-reads configuration from disk so it can be applied without a restart.
+	configReloader func() (*apitype.SippyViews, *v1config.SippyConfig, error)
+	// configReloaded, if set, is called in the background after a reloaded configuration is swapped in.
+	configReloaded func()
+	// requireAPITokens restricts the write endpoints to requests bearing an API token with the write scope, admin
+	// endpoints always require one.
+	requireAPITokens bool
+	// indicators are the configured top level health indicators, the mode's defaults are used if empty.
+	indicators []v1config.IndicatorConfig
+	// uiDevProxy, if set, is a frontend dev server the UI is proxied to instead of being served from sippyNG.
+	uiDevProxy *url.URL
+	// pdfRenderer, if set, renders the printable reports to PDF.
+	pdfRenderer *pdf.Renderer
+	// featureFlags gate the experimental endpoints.
+	featureFlags *api.FeatureFlagSet
+	// reportTemplates are the configured parameterized queries runnable through the API.
+	reportTemplates *api.ReportTemplates
+	// reportTemplateRole is the database role report templates run as, empty to run as sippy's own role.
+	reportTemplateRole string
+	// slos tracks the error budgets of the configured job SLOs.
+	slos *slo.Tracker
+	// recalculationLock serializes the recalculations triggered by admin changes to metadata.
+	recalculationLock sync.Mutex
+	// refreshJobs are the materialized view refreshes requested through the API.
+	refreshJobs *refreshJobs
+	// refreshOptions configure the materialized view refreshes requested through the API and by recalculations.
+	refreshOptions RefreshOptions
+	// promotionWarningThreshold is how long a payload stream can go without promoting before it is reported stale.
+	promotionWarningThreshold time.Duration
+	// lastRefresh is when the reports were last refreshed, read at dataVersionChecked.
+	lastRefresh        time.Time
+	dataVersionChecked time.Time
+	dataVersionLock    sync.Mutex
+}
+
+// SetConfigReloader configures how ReloadConfig obtains fresh configuration, and what to do once it is served.
+func (s *Server) SetConfigReloader(reloader func() (*apitype.SippyViews, *v1config.SippyConfig, error), reloaded func()) {
+	s.configReloader = reloader
+	s.configReloaded = reloaded
+}
+
+// SetConfig configures the sippy configuration returned by GetConfig.
+func (s *Server) SetConfig(config *v1config.SippyConfig) {
+	s.configLock.Lock()
+	s.config = config
+	s.configLock.Unlock()
+}
+
+// GetConfig returns the currently loaded sippy configuration, which may change if configuration is reloaded.
+func (s *Server) GetConfig() *v1config.SippyConfig {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.config
+}
+
+// SetRefreshOptions configures the materialized view refreshes requested through the API and by recalculations.
+// OnlyIfEmpty is ignored, as these refreshes are requested because the data changed.
+func (s *Server) SetRefreshOptions(opts RefreshOptions) {
+	opts.OnlyIfEmpty = false
+	s.refreshOptions = opts
+}
+
+// GetRefreshOptions returns the options of the materialized view refreshes the server runs.
+func (s *Server) GetRefreshOptions() RefreshOptions {
+	return s.refreshOptions
+}
+
+// SetPromotionWarningThreshold configures how long a payload stream can go without promoting before the release
+// health and promotion reports warn about it.
+func (s *Server) SetPromotionWarningThreshold(threshold time.Duration) {
+	s.promotionWarningThreshold = threshold
+}
+
+// SetRequireAPITokens configures whether write endpoints require an API token, admin endpoints always do.
+func (s *Server) SetRequireAPITokens(require bool) {
+	s.requireAPITokens = require
+}
+
+// SetUIDevProxy proxies the frontend to a dev server, so UI changes show up without rebuilding sippy.
+func (s *Server) SetUIDevProxy(target *url.URL) {
+	s.uiDevProxy = target
+}
+
+// SetPDFRenderer configures the headless renderer command used to export reports as PDF.
+func (s *Server) SetPDFRenderer(command string) {
+	s.pdfRenderer = &pdf.Renderer{Command: command}
+}
+
+// SetFeatureFlags configures which clients experimental features are rolled out to, by feature, as a percentage.
+func (s *Server) SetFeatureFlags(rollouts map[string]int) error {
+	featureFlags, err := api.NewFeatureFlagSet(s.db, rollouts)
+	if err != nil {
+		return err
+	}
+	s.featureFlags = featureFlags
+	return nil
+}
+
+// SetReportTemplates configures the report templates that can be run through the API, and the database role they
+// run as, which should be granted SELECT on the materialized views only.
+func (s *Server) SetReportTemplates(templates []v1config.ReportTemplate, role string) error {
+	reportTemplates, err := api.NewReportTemplates(templates, role)
+	if err != nil {
+		return err
+	}
+	s.configLock.Lock()
+	s.reportTemplates = reportTemplates
+	s.reportTemplateRole = role
+	s.configLock.Unlock()
+	return nil
+}
+
+func (s *Server) getReportTemplates() *api.ReportTemplates {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.reportTemplates
+}
+
+// SetSLOs configures the job SLOs whose error budgets are reported through the API.
+func (s *Server) SetSLOs(slos []v1config.SLOConfig) error {
+	tracker, err := slo.New(s.db, slos)
+	if err != nil {
+		return err
+	}
+	s.configLock.Lock()
+	s.slos = tracker
+	s.configLock.Unlock()
+	return nil
+}
+
+func (s *Server) getSLOs() *slo.Tracker {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.slos
+}
+
+// RefreshSLOMetrics updates the error budget metrics of the configured SLOs.
+func (s *Server) RefreshSLOMetrics() {
+	if slos := s.getSLOs(); slos != nil {
+		slos.RefreshMetrics(s.GetReportEnd())
+	}
+}
+
+// SetIndicators configures the top level health indicators reported for each release.
+func (s *Server) SetIndicators(indicators []v1config.IndicatorConfig) {
+	s.configLock.Lock()
+	s.indicators = indicators
+	s.configLock.Unlock()
+}
+
+// GetIndicators returns the configured health indicators, which may change if configuration is reloaded.
+func (s *Server) GetIndicators() []v1config.IndicatorConfig {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.indicators
+}
+
+// releaseIndicators returns the health indicators for a release, the OpenShift ones are the default in
+// OpenShift and OKD modes.
+func (s *Server) releaseIndicators(release string) []v1config.IndicatorConfig {
+	return api.ReleaseIndicators(s.GetIndicators(), s.mode == ModeOpenShift || s.mode == ModeOKD, release)
+}
+
+// GetViews returns the currently loaded views, which may change if configuration is reloaded.
+func (s *Server) GetViews() *apitype.SippyViews {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+	return s.views
+}
+
+// ReloadConfig re-reads configuration using the configured reloader and begins serving it. The existing
+// configuration is kept if the new one cannot be loaded.
+func (s *Server) ReloadConfig() error {
+	if s.configReloader == nil {
+		return fmt.Errorf("configuration reload is not supported by this server")
+	}
+	views, config, err := s.configReloader()
+	if err != nil {
+		return errors.WithMessage(err, "error reloading configuration")
+	}
+	// Build everything before swapping any of it in, so an invalid configuration leaves the old one in place.
+	s.configLock.RLock()
+	role := s.reportTemplateRole
+	s.configLock.RUnlock()
+	reportTemplates, err := api.NewReportTemplates(config.ReportTemplates, role)
+	if err != nil {
+		return errors.WithMessage(err, "invalid report templates")
+	}
+	slos, err := slo.New(s.db, config.SLOs)
+	if err != nil {
+		return errors.WithMessage(err, "invalid SLOs")
+	}
+
+	s.configLock.Lock()
+	s.views = views
+	s.config = config
+	s.indicators = config.Indicators
+	s.reportTemplates = reportTemplates
+	s.slos = slos
+	s.configLock.Unlock()
+	log.WithFields(log.Fields{
+		"componentReadinessViews": len(views.ComponentReadiness),
+		"indicators":              len(config.Indicators),
+		"reportTemplates":         len(config.ReportTemplates),
+		"slos":                    len(config.SLOs),
+		"releases":                len(config.Releases),
+	}).Info("reloaded configuration")
+	if s.configReloaded != nil {
+		go s.configReloaded()
+	}
+	return nil
 }
 
 func (s *Server) GetReportEnd() time.Time {
 	return util.GetReportEnd(s.pinnedDateTime)
 }
 
+// RefreshOptions controls how the materialized views are refreshed.
+type RefreshOptions struct {
+	// OnlyIfEmpty is used on startup to indicate that we want to do an initial refresh *only* if
+	// the views appear to be empty.
+	OnlyIfEmpty bool
+	// Parallelism is the number of views refreshed at once.
+	Parallelism int
+	// Timeout is the maximum time a single view may take to refresh before it is canceled. Zero means no limit.
+	Timeout time.Duration
+}
+
+// DefaultRefreshOptions returns the refresh options used when none are configured.
+func DefaultRefreshOptions() RefreshOptions {
+	return RefreshOptions{
+		Parallelism: 2,
+		Timeout:     time.Hour,
+	}
+}
+
 // refreshMaterializedViews updates the postgresql materialized views backing our reports. It is called by the handler
 // for the /refresh API endpoint, which is called by the sidecar script which loads the new data from testgrid into the
 // main postgresql tables.
 //
-// refreshMatviewOnlyIfEmpty is used on startup to indicate that we want to do an initial refresh *only* if
-// the views appear to be empty.
-func refreshMaterializedViews(dbc *db.DB, refreshMatviewOnlyIfEmpty bool) {
+// Our materialized views are independent of each other, so they are refreshed concurrently up to the configured
+// parallelism. Each refresh is recorded in the mat_view_refreshes table, and is canceled if it exceeds the
+// configured timeout or the context is canceled.
+func refreshMaterializedViews(ctx context.Context, dbc *db.DB, opts RefreshOptions) {
 	var promPusher *push.Pusher
 	if pushgateway := os.Getenv("SIPPY_PROMETHEUS_PUSHGATEWAY"); pushgateway != "" {
 		promPusher = push.New(pushgateway, "sippy-matviews")
@@ -147,23 +424,12 @@ func refreshMaterializedViews(dbc *db.DB, refreshMatviewOnlyIfEmpty bool) {
 		log.Info("skipping materialized view refresh as server has no db connection provided")
 		return
 	}
-	// create a channel for work "tasks"
-	ch := make(chan string)
-
-	wg := sync.WaitGroup{}
-
-	// allow concurrent workers for refreshing matviews in parallel
-	for t := 0; t < 2; t++ {
-		wg.Add(1)
-		go refreshMatview(dbc, refreshMatviewOnlyIfEmpty, ch, &wg)
-	}
-
+	matViews := make([]string, 0, len(db.PostgresMatViews))
 	for _, pmv := range db.PostgresMatViews {
-		ch <- pmv.Name
+		matViews = append(matViews, pmv.Name)
 	}
-
-	close(ch)
-	wg.Wait()
+	// Failures are logged and recorded in the refresh history.
+	refreshMatViewList(ctx, dbc, matViews, opts)
 
 	allElapsed := time.Since(allStart)
 	log.WithField("elapsed", allElapsed).Info("refreshed all materialized views")
@@ -179,53 +445,185 @@ func refreshMaterializedViews(dbc *db.DB, refreshMatviewOnlyIfEmpty bool) {
 	}
 }
 
-func refreshMatview(dbc *db.DB, refreshMatviewOnlyIfEmpty bool, ch chan string, wg *sync.WaitGroup) {
+// refreshMatViewList refreshes the views concurrently up to the parallelism of opts, each canceled if it exceeds
+// the timeout of opts, and returns those that failed.
+func refreshMatViewList(ctx context.Context, dbc *db.DB, matViews []string, opts RefreshOptions) []string {
+	// create a channel for work "tasks"
+	ch := make(chan string)
 
-	for matView := range ch {
-		start := time.Now()
-		tmpLog := log.WithField("matview", matView)
-
-		// If requested, we only refresh the materialized view if it has no rows
-		if refreshMatviewOnlyIfEmpty {
-			var count int
-			if res := dbc.DB.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s", matView)).Scan(&count); res.Error != nil {
-				tmpLog.WithError(res.Error).Warn("proceeding with refresh of matview that appears to be empty")
-			} else if count > 0 {
-				tmpLog.Info("skipping matview refresh as it appears to be populated")
-				continue
+	wg := sync.WaitGroup{}
+	failedLock := sync.Mutex{}
+	failed := []string{}
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	// allow concurrent workers for refreshing matviews in parallel
+	for t := 0; t < parallelism; t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for matView := range ch {
+				if err := refreshMatview(ctx, dbc, opts, matView); err != nil {
+					failedLock.Lock()
+					failed = append(failed, matView)
+					failedLock.Unlock()
+				}
 			}
+		}()
+	}
+
+	for _, matView := range matViews {
+		ch <- matView
+	}
+
+	close(ch)
+	wg.Wait()
+	sort.Strings(failed)
+	return failed
+}
+
+// matViewLocks holds a lock for each view, so this process refreshes a view once at a time whether the refresh was
+// scheduled, requested through the API or part of a recalculation. Postgres itself serializes refreshes of a view
+// from other processes, such as sippy load.
+var matViewLocks = struct {
+	sync.Mutex
+	views map[string]chan struct{}
+}{views: map[string]chan struct{}{}}
+
+// lockMatView waits until no other refresh of the view is running, returning a func to call when the refresh is
+// done, or the context's error if it is canceled while waiting.
+func lockMatView(ctx context.Context, matView string) (func(), error) {
+	matViewLocks.Lock()
+	lock, ok := matViewLocks.views[matView]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		matViewLocks.views[matView] = lock
+	}
+	matViewLocks.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func refreshMatview(ctx context.Context, dbc *db.DB, opts RefreshOptions, matView string) error {
+	tmpLog := log.WithField("matview", matView)
+
+	// If requested, we only refresh the materialized view if it has no rows
+	if opts.OnlyIfEmpty {
+		var count int
+		if res := dbc.DB.WithContext(ctx).Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s", matView)).Scan(&count); res.Error != nil {
+			tmpLog.WithError(res.Error).Warn("proceeding with refresh of matview that appears to be empty")
+		} else if count > 0 {
+			tmpLog.Info("skipping matview refresh as it appears to be populated")
+			return nil
 		}
+	}
 
-		// Try to refresh concurrently, if we get an error that likely means the view has never been
-		// populated (could be a developer env, or a schema migration on the view), fall back to the normal
-		// refresh which locks reads.
-		tmpLog.Info("refreshing materialized view")
-		if res := dbc.DB.Exec(
-			fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", matView)); res.Error != nil {
-			tmpLog.WithError(res.Error).Warn("error refreshing materialized view concurrently, falling back to regular refresh")
-
-			if res := dbc.DB.Exec(
-				fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", matView)); res.Error != nil {
-				tmpLog.WithError(res.Error).Error("error refreshing materialized view")
-			} else {
-				elapsed := time.Since(start)
-				tmpLog.WithField("elapsed", elapsed).Info("refreshed materialized view")
-				matViewRefreshMetric.WithLabelValues(matView).Observe(float64(elapsed.Milliseconds()))
-			}
+	if ctx.Err() != nil {
+		tmpLog.WithError(ctx.Err()).Warn("skipping matview refresh as refresh was canceled")
+		return ctx.Err()
+	}
 
-		} else {
-			elapsed := time.Since(start)
-			tmpLog.WithField("elapsed", elapsed).Info("refreshed materialized view concurrently")
-			matViewRefreshMetric.WithLabelValues(matView).Observe(float64(elapsed.Milliseconds()))
+	unlock, err := lockMatView(ctx, matView)
+	if err != nil {
+		tmpLog.WithError(err).Warn("skipping matview refresh as refresh was canceled")
+		return err
+	}
+	defer unlock()
+
+	refreshCtx := ctx
+	cancel := func() {}
+	if opts.Timeout > 0 {
+		refreshCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+	defer cancel()
+	return refreshSingleMatview(refreshCtx, dbc, matView)
+}
+
+// refreshSingleMatview refreshes one view and records the attempt in the refresh history. The error is that of the
+// refresh, or the context's if it was canceled.
+func refreshSingleMatview(ctx context.Context, dbc *db.DB, matView string) error {
+	start := time.Now()
+	tmpLog := log.WithField("matview", matView)
+
+	history := models.MatViewRefresh{
+		Name:      matView,
+		Status:    models.MatViewRefreshRunning,
+		StartedAt: start,
+	}
+	if res := dbc.DB.Create(&history); res.Error != nil {
+		tmpLog.WithError(res.Error).Warn("unable to record matview refresh history")
+	}
+
+	// Try to refresh concurrently, if we get an error that likely means the view has never been
+	// populated (could be a developer env, or a schema migration on the view), fall back to the normal
+	// refresh which locks reads.
+	tmpLog.Info("refreshing materialized view")
+	history.Concurrent = true
+	err := dbc.DB.WithContext(ctx).Exec(fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", matView)).Error
+	if err != nil && ctx.Err() == nil {
+		tmpLog.WithError(err).Warn("error refreshing materialized view concurrently, falling back to regular refresh")
+		history.Concurrent = false
+		err = dbc.DB.WithContext(ctx).Exec(fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", matView)).Error
+	}
+
+	elapsed := time.Since(start)
+	end := start.Add(elapsed)
+	history.EndedAt = &end
+	history.DurationMillis = elapsed.Milliseconds()
+	switch {
+	case ctx.Err() != nil:
+		tmpLog.WithError(ctx.Err()).WithField("elapsed", elapsed).Error("materialized view refresh canceled")
+		history.Status = models.MatViewRefreshCanceled
+		history.Error = ctx.Err().Error()
+	case err != nil:
+		tmpLog.WithError(err).Error("error refreshing materialized view")
+		history.Status = models.MatViewRefreshFailed
+		history.Error = err.Error()
+	default:
+		tmpLog.WithFields(log.Fields{"elapsed": elapsed, "concurrent": history.Concurrent}).Info("refreshed materialized view")
+		matViewRefreshMetric.WithLabelValues(matView).Observe(float64(elapsed.Milliseconds()))
+		history.Status = models.MatViewRefreshSucceeded
+		if res := dbc.DB.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s", matView)).Scan(&history.Rows); res.Error != nil {
+			tmpLog.WithError(res.Error).Warn("unable to count matview rows")
 		}
 	}
-	wg.Done()
+
+	if history.ID != 0 {
+		if res := dbc.DB.Save(&history); res.Error != nil {
+			tmpLog.WithError(res.Error).Warn("unable to record matview refresh history")
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
 }
 
-func RefreshData(dbc *db.DB, pinnedDateTime *time.Time, refreshMatviewsOnlyIfEmpty bool) {
+// refreshMatViews refreshes the views as configured by opts, stopping early if the context is canceled. The views
+// that failed are returned in the error.
+func refreshMatViews(ctx context.Context, dbc *db.DB, matViews []string, opts RefreshOptions) error {
+	failed := refreshMatViewList(ctx, dbc, matViews, opts)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("error refreshing %s, see the refresh history for details", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func RefreshData(ctx context.Context, dbc *db.DB, pinnedDateTime *time.Time, opts RefreshOptions) {
 	log.Infof("Refreshing data")
 
-	refreshMaterializedViews(dbc, refreshMatviewsOnlyIfEmpty)
+	refreshMaterializedViews(ctx, dbc, opts)
 
 	log.Infof("Refresh complete")
 }
@@ -248,7 +646,8 @@ func (s *Server) hasCapabilities(capabilities []string) bool {
 
 func (s *Server) determineCapabilities() {
 	capabilities := make([]string, 0)
-	if s.mode == ModeOpenShift {
+	// OKD publishes release payloads the same way as OCP
+	if s.mode == ModeOpenShift || s.mode == ModeOKD {
 		capabilities = append(capabilities, OpenshiftCapability)
 	}
 
@@ -268,13 +667,6 @@ func (s *Server) determineCapabilities() {
 	s.capabilities = capabilities
 }
 
-func failureResponse(w http.ResponseWriter, code int, message string) {
-	api.RespondWithJSON(code, w, map[string]interface{}{
-		"code":    code,
-		"message": message,
-	})
-}
-
 func (s *Server) jsonCapabilitiesReport(w http.ResponseWriter, _ *http.Request) {
 	api.RespondWithJSON(http.StatusOK, w, s.capabilities)
 }
@@ -290,19 +682,19 @@ func (s *Server) jsonReleaseTagsReport(w http.ResponseWriter, req *http.Request)
 func (s *Server) jsonIncidentEvent(w http.ResponseWriter, req *http.Request) {
 	start, err := getISO8601Date("start", req)
 	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, "couldn't parse start param: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse start param: "+err.Error())
 		return
 	}
 
 	end, err := getISO8601Date("end", req)
 	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, "couldn't parse end param: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse end param: "+err.Error())
 		return
 	}
 
 	results, err := api.GetJIRAIncidentsFromDB(s.db, start, end)
 	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, "couldn't fetch events: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "couldn't fetch events: "+err.Error())
 		return
 	}
 
@@ -314,25 +706,25 @@ func (s *Server) jsonReleaseTagsEvent(w http.ResponseWriter, req *http.Request)
 	if release != "" {
 		filterOpts, err := filter.FilterOptionsFromRequest(req, "release_time", apitype.SortDescending)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
 			return
 		}
 
 		start, err := getISO8601Date("start", req)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse start param: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse start param: "+err.Error())
 			return
 		}
 
 		end, err := getISO8601Date("end", req)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse end param: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse end param: "+err.Error())
 			return
 		}
 
 		results, err := api.GetPayloadEvents(s.db, release, filterOpts, start, end)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't get payload events: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't get payload events: "+err.Error())
 			return
 		}
 
@@ -352,14 +744,14 @@ func (s *Server) jsonListPayloadJobRuns(w http.ResponseWriter, req *http.Request
 	filterOpts, err := filter.FilterOptionsFromRequest(req, "id", apitype.SortDescending)
 	if err != nil {
 		log.WithError(err).Error("error")
-		failureResponse(w, http.StatusInternalServerError, "Error building job run report: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "Error building job run report: "+err.Error())
 		return
 	}
 
 	payloadJobRuns, err := api.ListPayloadJobRuns(s.db, filterOpts, param.SafeRead(req, "release"))
 	if err != nil {
 		log.WithError(err).Error("error listing payload job runs")
-		failureResponse(w, http.StatusBadRequest, "error listing payload job runs: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "error listing payload job runs: "+err.Error())
 		return
 	}
 	api.RespondWithJSON(http.StatusOK, w, payloadJobRuns)
@@ -385,7 +777,7 @@ func (s *Server) jsonGetPayloadAnalysis(w http.ResponseWriter, req *http.Request
 
 	filterOpts, err := filter.FilterOptionsFromRequest(req, "id", apitype.SortDescending)
 	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -398,7 +790,7 @@ func (s *Server) jsonGetPayloadAnalysis(w http.ResponseWriter, req *http.Request
 	result, err := api.GetPayloadStreamTestFailures(s.db, release, stream, arch, filterOpts, s.GetReportEnd())
 	if err != nil {
 		log.WithError(err).Error("error")
-		failureResponse(w, http.StatusInternalServerError, "Error analyzing payload: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "Error analyzing payload: "+err.Error())
 		return
 	}
 
@@ -421,13 +813,34 @@ func (s *Server) jsonGetPayloadTestFailures(w http.ResponseWriter, req *http.Req
 	result, err := api.GetPayloadTestFailures(s.db, payload, logger)
 	if err != nil {
 		log.WithError(err).Error("error")
-		failureResponse(w, http.StatusInternalServerError, "Error looking up test failures for payload: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "Error looking up test failures for payload: "+err.Error())
 		return
 	}
 
 	api.RespondWithJSON(http.StatusOK, w, result)
 }
 
+// jsonGetPayloadRejection explains why a payload was rejected, listing its failed blocking jobs and their failed
+// tests.
+func (s *Server) jsonGetPayloadRejection(w http.ResponseWriter, req *http.Request) {
+	payload := s.getParamOrFail(w, req, "payload")
+	if payload == "" {
+		return
+	}
+
+	result, err := api.GetPayloadRejection(s.db, payload)
+	if err != nil {
+		if errors.Is(err, api.ErrPayloadNotFound) {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		log.WithError(err).WithField("payload", payload).Error("error looking up payload rejection")
+		api.RespondWithError(w, http.StatusInternalServerError, "error looking up payload rejection")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
 func (s *Server) jsonReleaseHealthReport(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release == "" {
@@ -437,155 +850,1199 @@ func (s *Server) jsonReleaseHealthReport(w http.ResponseWriter, req *http.Reques
 	results, err := api.ReleaseHealthReports(s.db, release, s.GetReportEnd())
 	if err != nil {
 		log.WithError(err).Error("error generating release health report")
-		failureResponse(w, http.StatusInternalServerError, err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	api.RespondWithJSON(http.StatusOK, w, results)
 }
 
-func (s *Server) jsonPayloadDiff(w http.ResponseWriter, req *http.Request) {
-	fromPayload := param.SafeRead(req, "fromPayload")
-	toPayload := param.SafeRead(req, "toPayload")
-	results, err := api.GetPayloadDiffPullRequests(s.db, fromPayload, toPayload)
+func (s *Server) jsonReleaseScorecard(w http.ResponseWriter, req *http.Request) {
+	release := param.SafePathValue(req, "release")
+	if release == "" {
+		api.RespondWithError(w, http.StatusBadRequest, "a valid release is required")
+		return
+	}
 
+	scorecard, err := api.GetReleaseScorecardFromDB(s.db, release, s.releaseIndicators(release), s.GetReportEnd())
 	if err != nil {
-		log.WithError(err).Error("error generating payload diff")
-		failureResponse(w, http.StatusInternalServerError, err.Error())
+		log.WithError(err).Error("error generating release scorecard")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	api.RespondWithJSON(http.StatusOK, w, results)
+	api.RespondWithJSON(http.StatusOK, w, scorecard)
 }
 
-func (s *Server) jsonFeatureGates(w http.ResponseWriter, req *http.Request) {
-	release := s.getParamOrFail(w, req, "release")
-	if release != "" {
-		gates, err := query.GetFeatureGatesFromDB(s.db.DB, release)
-		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
-			return
-		}
-		api.RespondWithJSON(http.StatusOK, w, gates)
+func (s *Server) jsonDisruptionRegressions(w http.ResponseWriter, req *http.Request) {
+	minDays := api.DefaultDisruptionRegressionMinDays
+	if minDaysParam := param.SafeRead(req, "minDays"); minDaysParam != "" {
+		minDays, _ = strconv.Atoi(minDaysParam)
 	}
-}
 
-func (s *Server) jsonTestAnalysis(w http.ResponseWriter, req *http.Request, dbFN func(*db.DB, *filter.Filter, string, string, time.Time) (map[string][]api.CountByDate, error)) {
-	testName := s.getParamOrFail(w, req, "test")
-	if testName == "" {
+	results, err := api.GetDisruptionRegressionsFromDB(s.db, param.SafeRead(req, "release"), minDays)
+	if err != nil {
+		log.WithError(err).Error("error querying disruption regressions")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying disruption regressions")
 		return
 	}
-	release := s.getParamOrFail(w, req, "release")
-	if release != "" {
-		filters, err := filter.ExtractFilters(req)
-		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
-			return
-		}
-		results, err := dbFN(s.db, filters, release, testName, s.GetReportEnd())
-		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		api.RespondWithJSON(200, w, results)
-	}
-}
-
-func (s *Server) jsonTestAnalysisByJobFromDB(w http.ResponseWriter, req *http.Request) {
-	s.jsonTestAnalysis(w, req, api.GetTestAnalysisByJobFromDB)
-}
-
-func (s *Server) jsonTestAnalysisByVariantFromDB(w http.ResponseWriter, req *http.Request) {
-	s.jsonTestAnalysis(w, req, api.GetTestAnalysisByVariantFromDB)
-}
-
-func (s *Server) jsonTestAnalysisOverallFromDB(w http.ResponseWriter, req *http.Request) {
-	s.jsonTestAnalysis(w, req, api.GetTestAnalysisOverallFromDB)
-}
-
-func (s *Server) jsonTestBugsFromDB(w http.ResponseWriter, req *http.Request) {
-	testName := s.getParamOrFail(w, req, "test")
-	if testName == "" {
+	if err := api.AcknowledgeDisruptionRegressions(s.db, results, time.Now()); err != nil {
+		log.WithError(err).Error("error querying disruption regression acknowledgements")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying disruption regression acknowledgements")
 		return
 	}
-
-	bugs, err := query.LoadBugsForTest(s.db, testName, false)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			api.RespondWithJSON(http.StatusOK, w, []models.Bug{})
-			return
+	if acknowledged := param.SafeRead(req, "acknowledged"); acknowledged != "" {
+		filtered := make([]models.DisruptionRegressionState, 0, len(results))
+		for _, r := range results {
+			if (r.Acknowledgement != nil) == (acknowledged == "true") {
+				filtered = append(filtered, r)
+			}
 		}
-		log.WithError(err).Error("error querying test bugs from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying test bugs from db")
-		return
+		results = filtered
 	}
-	api.RespondWithJSON(http.StatusOK, w, bugs)
+	api.RespondWithJSON(http.StatusOK, w, results)
 }
 
-func (s *Server) jsonTestDurationsFromDB(w http.ResponseWriter, req *http.Request) {
+func (s *Server) jsonDisruptionPercentiles(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release == "" {
 		return
 	}
 
-	testName := s.getParamOrFail(w, req, "test")
-	if testName == "" {
+	start := s.GetReportEnd().AddDate(0, 0, -api.DisruptionPercentilesDays)
+	results, err := query.DisruptionPercentiles(s.db, release, param.SafeRead(req, "backend"), start)
+	if err != nil {
+		log.WithError(err).Error("error querying disruption percentiles")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying disruption percentiles")
 		return
 	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
 
-	filters, err := filter.ExtractFilters(req)
-	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, "error processing filter options")
-		return
+func (s *Server) jsonDisruptionJobRuns(w http.ResponseWriter, req *http.Request) {
+	limit := getLimitParam(req)
+	if limit <= 0 {
+		limit = api.DefaultDisruptionJobRunsLimit
 	}
 
-	outputs, err := api.GetTestDurationsFromDB(s.db, release, testName, filters)
+	end := s.GetReportEnd()
+	start := end.AddDate(0, 0, -api.DisruptionJobRunsDays)
+	results, err := query.WorstDisruptionJobRuns(s.db, param.SafeRead(req, "release"), param.SafeRead(req, "backend"), start, end, limit)
 	if err != nil {
-		log.WithError(err).Error("error querying test outputs from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying test outputs from db")
+		log.WithError(err).Error("error querying disruption job runs")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying disruption job runs")
 		return
 	}
-	api.RespondWithJSON(http.StatusOK, w, outputs)
+	api.RespondWithJSON(http.StatusOK, w, results)
 }
 
-func (s *Server) jsonTestOutputsFromDB(w http.ResponseWriter, req *http.Request) {
+func (s *Server) jsonJobArtifactSizes(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release == "" {
 		return
 	}
 
-	testName := s.getParamOrFail(w, req, "test")
-	if testName == "" {
+	start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	results, err := api.GetJobArtifactSizesFromDB(s.db, release, start, boundary, end)
+	if err != nil {
+		log.WithError(err).Error("error querying job artifact sizes")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying job artifact sizes")
 		return
 	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
 
-	filters, err := filter.ExtractFilters(req)
-	if err != nil {
-		failureResponse(w, http.StatusInternalServerError, "error processing filter options")
+// jsonCloudLocationHealth reports the failure rates of a release's job runs by the cloud region, or zone, their
+// clusters were installed in.
+func (s *Server) jsonCloudLocationHealth(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	groupBy := param.SafeRead(req, "groupBy")
+	if groupBy != "" && groupBy != "region" && groupBy != "zone" {
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, "groupBy must be region or zone", map[string]string{"param": "groupBy"})
 		return
 	}
 
-	outputs, err := api.GetTestOutputsFromDB(s.db, release, testName, filters, 10)
+	start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	results, err := api.GetCloudLocationHealthFromDB(s.db, release, groupBy == "zone", start, boundary, end)
 	if err != nil {
-		log.WithError(err).Error("error querying test outputs from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying test outputs from db")
+		log.WithError(err).Error("error querying cloud location health")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying cloud location health")
 		return
 	}
-	api.RespondWithJSON(http.StatusOK, w, outputs)
+	api.RespondWithJSON(http.StatusOK, w, results)
 }
 
-func (s *Server) jsonComponentTestVariantsFromBigQuery(w http.ResponseWriter, req *http.Request) {
-	if s.bigQueryClient == nil {
-		failureResponse(w, http.StatusBadRequest, "component report API is only available when google-service-account-credential-file is configured")
+func (s *Server) jsonArtifactSizeTrend(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
 		return
 	}
-	outputs, errs := componentreadiness.GetComponentTestVariantsFromBigQuery(req.Context(), s.bigQueryClient, s.gcsBucket)
-	if len(errs) > 0 {
-		log.Warningf("%d errors were encountered while querying test variants from big query:", len(errs))
-		for _, err := range errs {
-			log.Error(err.Error())
-		}
-		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error querying test variants from big query: %v", errs))
+
+	end := s.GetReportEnd()
+	start := end.AddDate(0, 0, -api.ArtifactTrendDays)
+	results, err := query.ArtifactSizeTrend(s.db, release, param.SafeRead(req, "job"), start, end)
+	if err != nil {
+		log.WithError(err).Error("error querying artifact size trend")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying artifact size trend")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+func (s *Server) jsonAPITokens(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		tokenReq := api.CreateAPITokenRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&tokenReq); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode api token request: "+err.Error())
+			return
+		}
+		if err := tokenReq.Validate(); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		created, err := api.CreateAPIToken(s.db, tokenReq)
+		if err != nil {
+			log.WithError(err).Error("error creating api token")
+			api.RespondWithError(w, http.StatusInternalServerError, "error creating api token: "+err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusCreated, w, created)
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+			return
+		}
+		if err := api.DeleteAPIToken(s.db, uint(id)); err != nil {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "api token revoked",
+		})
+	default:
+		tokens, err := api.ListAPITokens(s.db)
+		if err != nil {
+			log.WithError(err).Error("error listing api tokens")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing api tokens")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, tokens)
+	}
+}
+
+// jsonReportTemplates lists the configured report templates and their parameters.
+func (s *Server) jsonReportTemplates(w http.ResponseWriter, req *http.Request) {
+	api.RespondWithJSON(http.StatusOK, w, s.getReportTemplates().List())
+}
+
+// jsonRunReportTemplate runs a report template, with its parameters taken from query parameters of the same name.
+func (s *Server) jsonRunReportTemplate(w http.ResponseWriter, req *http.Request) {
+	name := s.getParamOrFail(w, req, "template")
+	if name == "" {
+		return
+	}
+	reportTemplates := s.getReportTemplates()
+	if !reportTemplates.Has(name) {
+		api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("no report template named %s", name))
+		return
+	}
+	args, err := reportTemplates.Bind(name, req.URL.Query())
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := reportTemplates.Run(req.Context(), s.db, name, args)
+	if err != nil {
+		if errors.Is(err, api.ErrReportTemplateNotAllowed) {
+			api.RespondWithError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		log.WithError(err).WithField("template", name).Error("error running report template")
+		api.RespondWithError(w, http.StatusInternalServerError, "error running report template: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonFeatureFlags lists the experimental features, and whether each is enabled for the caller.
+func (s *Server) jsonFeatureFlags(w http.ResponseWriter, req *http.Request) {
+	flags, err := s.featureFlags.List(req)
+	if err != nil {
+		log.WithError(err).Error("error listing feature flags")
+		api.RespondWithError(w, http.StatusInternalServerError, "error listing feature flags")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, flags)
+}
+
+// jsonAdminFeatureFlags sets (POST) or clears (DELETE with flag) the rollout of an experimental feature in the
+// database.
+func (s *Server) jsonAdminFeatureFlags(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		rollout := models.FeatureFlagRollout{}
+		if err := json.NewDecoder(req.Body).Decode(&rollout); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode feature flag rollout: "+err.Error())
+			return
+		}
+		saved, err := s.featureFlags.SetRollout(rollout)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, saved)
+	case http.MethodDelete:
+		name := param.SafeRead(req, "flag")
+		if name == "" {
+			api.RespondWithError(w, http.StatusBadRequest, "a flag param is required")
+			return
+		}
+		if err := s.featureFlags.ClearRollout(name); err != nil {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "feature flag rollout cleared",
+		})
+	default:
+		s.jsonFeatureFlags(w, req)
+	}
+}
+
+// requireFeatureFlag hides an experimental endpoint from the clients its feature isn't enabled for.
+func (s *Server) requireFeatureFlag(name string, implFn func(w http.ResponseWriter, req *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		enabled, err := s.featureFlags.Enabled(name, req)
+		if err != nil {
+			log.WithError(err).Warningf("could not check feature flag %s", name)
+		}
+		if !enabled {
+			api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("the experimental %s feature is not enabled", name))
+			return
+		}
+		implFn(w, req)
+	}
+}
+
+func (s *Server) jsonReloadConfig(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "configuration reload requires a POST")
+		return
+	}
+	if err := s.ReloadConfig(); err != nil {
+		log.WithError(err).Error("error reloading configuration")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+		"code":    http.StatusOK,
+		"message": "configuration reloaded",
+	})
+}
+
+func (s *Server) jsonSlowQueries(w http.ResponseWriter, req *http.Request) {
+	if s.db.SlowQueries == nil {
+		api.RespondWithError(w, http.StatusNotFound, "slow query tracking is disabled, enable it with --db-slow-query-threshold")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, s.db.SlowQueries.Worst(getLimitParam(req)))
+}
+
+// jsonDatabaseUsage reports the disk usage of each table and materialized view, and warns when the database is
+// projected to exceed its disk budget.
+func (s *Server) jsonDatabaseUsage(w http.ResponseWriter, req *http.Request) {
+	usage, err := api.GetDatabaseUsageFromDB(s.db, time.Now())
+	if err != nil {
+		log.WithError(err).Error("error querying database usage")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying database usage")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, usage)
+}
+
+// jsonRevariant lists the jobs whose variants differ from what the current variant rules identify, and on a POST
+// updates them and recalculates the views derived from them.
+func (s *Server) jsonRevariant(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodPost {
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "revariant requires a GET or POST")
+		return
+	}
+
+	syncer := variantsyncer.NewWithManager(s.db, s.variantManager)
+	changes := syncer.Diff()
+	if req.Method == http.MethodPost && len(changes) > 0 {
+		if err := syncer.Apply(req.Context(), changes); err != nil {
+			log.WithError(err).Error("error updating job variants")
+			api.RespondWithError(w, http.StatusInternalServerError, "error updating job variants")
+			return
+		}
+		s.recalculate(variantRecalculation("job variants recomputed"))
+	}
+	api.RespondWithJSON(http.StatusOK, w, changes)
+}
+
+// jsonSLOs reports how much of each configured SLO's error budget remains.
+func (s *Server) jsonSLOs(w http.ResponseWriter, req *http.Request) {
+	statuses, err := s.getSLOs().Statuses(s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error computing SLO error budgets")
+		api.RespondWithError(w, http.StatusInternalServerError, "error computing SLO error budgets")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, statuses)
+}
+
+func (s *Server) jsonAlerts(w http.ResponseWriter, req *http.Request) {
+	results, err := query.LatestAlertResults(s.db)
+	if err != nil {
+		log.WithError(err).Error("error querying alert results")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying alert results")
+		return
+	}
+	if err := api.AcknowledgeAlerts(s.db, results, time.Now()); err != nil {
+		log.WithError(err).Error("error querying alert acknowledgements")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying alert acknowledgements")
+		return
+	}
+	firingOnly := param.SafeRead(req, "firing") == "true"
+	acknowledged := param.SafeRead(req, "acknowledged")
+	filtered := make([]models.AlertResult, 0, len(results))
+	for _, r := range results {
+		if firingOnly && !r.Firing {
+			continue
+		}
+		if acknowledged != "" && (r.Acknowledgement != nil) != (acknowledged == "true") {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	api.RespondWithJSON(http.StatusOK, w, filtered)
+}
+
+// jsonAcknowledgements lists (GET), creates (POST) or deletes (DELETE with id) the acknowledgements and snoozes of
+// firing alerts and disruption regressions.
+func (s *Server) jsonAcknowledgements(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		ack := models.Acknowledgement{}
+		if err := json.NewDecoder(req.Body).Decode(&ack); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode acknowledgement: "+err.Error())
+			return
+		}
+		created, err := api.CreateAcknowledgement(s.db, ack, time.Now())
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusCreated, w, created)
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+			return
+		}
+		if _, err := api.DeleteAcknowledgement(s.db, uint(id)); err != nil {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "acknowledgement deleted",
+		})
+	default:
+		acks, err := query.ListAcknowledgements(s.db, param.SafeRead(req, "kind"), time.Now())
+		if err != nil {
+			log.WithError(err).Error("error listing acknowledgements")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing acknowledgements")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, acks)
+	}
+}
+
+// feedEvents returns a handler listing notable events, such as fired alerts and rejected payloads, as a feed in the
+// given format, for teams to subscribe to with their feed readers or feed-to-chat tooling.
+func (s *Server) feedEvents(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		base := &url.URL{Scheme: "http", Host: req.Host}
+		if req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https" {
+			base.Scheme = "https"
+		}
+		release, kind := param.SafeRead(req, "release"), param.SafeRead(req, "kind")
+		events, err := api.GetFeedEventsFromDB(s.db, release, kind, base, s.GetReportEnd())
+		if err != nil {
+			log.WithError(err).Error("error querying feed events")
+			api.RespondWithError(w, http.StatusInternalServerError, "error querying feed events")
+			return
+		}
+
+		title := "Sippy events"
+		if release != "" {
+			title = "Sippy " + release + " events"
+		}
+		self := base.ResolveReference(&url.URL{Path: req.URL.Path, RawQuery: req.URL.RawQuery})
+		api.RespondWithFeed(w, format, title, self.String(), events, time.Now())
+	}
+}
+
+func (s *Server) jsonMatViewRefreshes(w http.ResponseWriter, req *http.Request) {
+	refreshes, err := query.ListMatViewRefreshes(s.db, param.SafeRead(req, "matview"), getLimitParam(req))
+	if err != nil {
+		log.WithError(err).Error("error querying materialized view refresh history")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying materialized view refresh history")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, refreshes)
+}
+
+func (s *Server) jsonLoadEvents(w http.ResponseWriter, req *http.Request) {
+	limit := getLimitParam(req)
+	if limit == 0 {
+		limit = 50
+	}
+	events, err := query.ListLoadEvents(s.db, param.SafeRead(req, "release"), limit)
+	if err != nil {
+		log.WithError(err).Error("error querying data load history")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying data load history")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, events)
+}
+
+func (s *Server) jsonReleasePromotions(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	results, err := api.GetReleasePromotions(s.db, release, s.promotionWarningThreshold, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error generating release promotions report")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonPromotionRecommendation recommends whether to accept the latest payload of a stream. Open component readiness
+// regressions are only considered when BigQuery is configured.
+func (s *Server) jsonPromotionRecommendation(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	stream := param.SafeRead(req, "stream")
+	if stream == "" {
+		stream = "nightly"
+	}
+	arch := param.SafeRead(req, "arch")
+	if arch == "" {
+		arch = "amd64"
+	}
+	minPassPercentage := api.DefaultPromotionMinPassPercentage
+	if v := param.SafeRead(req, "threshold"); v != "" {
+		minPassPercentage, _ = strconv.ParseFloat(v, 64)
+	}
+
+	var regressions []*crtype.TestRegression
+	if s.bigQueryClient != nil {
+		var err error
+		regressions, err = componentreadiness.NewBigQueryRegressionStore(s.bigQueryClient).ListCurrentRegressions(req.Context())
+		if err != nil {
+			log.WithError(err).Error("error listing component readiness regressions")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing regressions: "+err.Error())
+			return
+		}
+	}
+
+	result, err := api.GetPromotionRecommendation(s.db, release, stream, arch, minPassPercentage, regressions, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error generating promotion recommendation")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if result == nil {
+		api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("no %s %s payloads found for %s", stream, arch, release))
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+func (s *Server) jsonPayloadDiff(w http.ResponseWriter, req *http.Request) {
+	fromPayload := param.SafeRead(req, "fromPayload")
+	toPayload := param.SafeRead(req, "toPayload")
+	results, err := api.GetPayloadDiffPullRequests(s.db, fromPayload, toPayload)
+
+	if err != nil {
+		log.WithError(err).Error("error generating payload diff")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+func (s *Server) jsonPayloadChanges(w http.ResponseWriter, req *http.Request) {
+	payload := s.getParamOrFail(w, req, "payload")
+	if payload == "" {
+		return
+	}
+
+	changes, err := api.GetPayloadChangesFromDB(s.db, payload)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("no payload found with tag %s", payload))
+			return
+		}
+		log.WithError(err).Error("error generating payload changes")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, changes)
+}
+
+func (s *Server) jsonFeatureGates(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release != "" {
+		gates, err := query.GetFeatureGatesFromDB(s.db.DB, release)
+		if err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, gates)
+	}
+}
+
+func (s *Server) jsonTestAnalysis(w http.ResponseWriter, req *http.Request, dbFN func(*db.DB, *filter.Filter, string, string, time.Time) (map[string][]api.CountByDate, error)) {
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+	release := s.getParamOrFail(w, req, "release")
+	if release != "" {
+		filters, err := filter.ExtractFilters(req)
+		if err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
+			return
+		}
+		results, err := dbFN(s.db, filters, release, testName, s.GetReportEnd())
+		if err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		api.RespondWithJSON(200, w, results)
+	}
+}
+
+func (s *Server) jsonTestAnalysisByJobFromDB(w http.ResponseWriter, req *http.Request) {
+	s.jsonTestAnalysis(w, req, api.GetTestAnalysisByJobFromDB)
+}
+
+func (s *Server) jsonTestAnalysisByVariantFromDB(w http.ResponseWriter, req *http.Request) {
+	s.jsonTestAnalysis(w, req, api.GetTestAnalysisByVariantFromDB)
+}
+
+func (s *Server) jsonTestAnalysisByVariantCombinationFromDB(w http.ResponseWriter, req *http.Request) {
+	s.jsonTestAnalysis(w, req, api.GetTestAnalysisByVariantCombinationFromDB)
+}
+
+func (s *Server) jsonTestAnalysisOverallFromDB(w http.ResponseWriter, req *http.Request) {
+	s.jsonTestAnalysis(w, req, api.GetTestAnalysisOverallFromDB)
+}
+
+func (s *Server) jsonTestSearch(w http.ResponseWriter, req *http.Request) {
+	search := s.getParamOrFail(w, req, "q")
+	if search == "" {
+		return
+	}
+
+	limit := getLimitParam(req)
+	if limit <= 0 || limit > maxTestSearchResults {
+		limit = defaultTestSearchResults
+	}
+
+	results, err := query.SearchTests(s.db, search, limit)
+	if err != nil {
+		log.WithError(err).Error("error searching tests")
+		api.RespondWithError(w, http.StatusInternalServerError, "error searching tests")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+func (s *Server) jsonTestBugsFromDB(w http.ResponseWriter, req *http.Request) {
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	page, err := pagination.FromRequest(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
+		return
+	}
+
+	bugs, err := query.LoadBugsForTest(s.db, testName, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			api.RespondWithJSON(http.StatusOK, w, pagination.Slice(page, []models.Bug{}))
+			return
+		}
+		log.WithError(err).Error("error querying test bugs from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test bugs from db")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, pagination.Slice(page, bugs))
+}
+
+func (s *Server) jsonTestDurationsFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	sample, err := api.SamplePercent(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	outputs, err := api.GetTestDurationsFromDB(s.db, release, testName, filters, sample)
+	if err != nil {
+		log.WithError(err).Error("error querying test outputs from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test outputs from db")
+		return
+	}
+	api.SetSampleHeader(w, sample)
+	api.RespondWithJSON(http.StatusOK, w, outputs)
+}
+
+func (s *Server) jsonTestSuppressions(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		suppression := models.TestSuppression{}
+		if err := json.NewDecoder(req.Body).Decode(&suppression); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode test suppression: "+err.Error())
+			return
+		}
+		created, err := api.CreateTestSuppression(s.db, suppression)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if api.SuppressionAffectsTestReports(created, s.GetReportEnd()) {
+			s.recalculate(testReportRecalculation("test suppression created"))
+		}
+		api.RespondWithJSON(http.StatusCreated, w, created)
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+			return
+		}
+		deleted, err := api.DeleteTestSuppression(s.db, uint(id))
+		if err != nil {
+			api.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if api.SuppressionAffectsTestReports(deleted, s.GetReportEnd()) {
+			s.recalculate(testReportRecalculation("test suppression deleted"))
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "test suppression deleted",
+		})
+	default:
+		suppressions, err := query.ListTestSuppressions(s.db, nil)
+		if err != nil {
+			log.WithError(err).Error("error listing test suppressions")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing test suppressions")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, suppressions)
+	}
+}
+
+// jsonSavedViews lists (GET), creates (POST), replaces (PUT with id) or deletes (DELETE with id and owner) the
+// filters saved from the UI. GET with id returns that view, whether or not it's shared.
+func (s *Server) jsonSavedViews(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut:
+		view := models.SavedView{}
+		if err := json.NewDecoder(req.Body).Decode(&view); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode saved view: "+err.Error())
+			return
+		}
+		status := http.StatusCreated
+		var saved models.SavedView
+		var err error
+		if req.Method == http.MethodPost {
+			saved, err = api.CreateSavedView(s.db, view)
+		} else {
+			id, parseErr := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+			if parseErr != nil {
+				api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+				return
+			}
+			status = http.StatusOK
+			saved, err = api.UpdateSavedView(s.db, uint(id), view)
+		}
+		if err != nil {
+			api.RespondWithError(w, savedViewErrorStatus(err), err.Error())
+			return
+		}
+		api.RespondWithJSON(status, w, saved)
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+			return
+		}
+		owner := s.getParamOrFail(w, req, "owner")
+		if owner == "" {
+			return
+		}
+		if err := api.DeleteSavedView(s.db, uint(id), owner); err != nil {
+			api.RespondWithError(w, savedViewErrorStatus(err), err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "saved view deleted",
+		})
+	default:
+		if idParam := param.SafeRead(req, "id"); idParam != "" {
+			id, _ := strconv.ParseUint(idParam, 10, 64)
+			view, err := api.GetSavedView(s.db, uint(id))
+			if err != nil {
+				api.RespondWithError(w, savedViewErrorStatus(err), err.Error())
+				return
+			}
+			api.RespondWithJSON(http.StatusOK, w, view)
+			return
+		}
+		views, err := api.ListSavedViews(s.db, param.SafeRead(req, "owner"), param.SafeRead(req, "page"))
+		if err != nil {
+			log.WithError(err).Error("error listing saved views")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing saved views")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, views)
+	}
+}
+
+func savedViewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, api.ErrSavedViewNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, api.ErrSavedViewNotOwner):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// jsonWatchlists lists (GET), creates (POST), replaces (PUT with id) or deletes (DELETE with id and owner) the
+// watchlists of tests teams follow. GET with id returns that watchlist.
+func (s *Server) jsonWatchlists(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut:
+		watchlist := models.Watchlist{}
+		if err := json.NewDecoder(req.Body).Decode(&watchlist); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode watchlist: "+err.Error())
+			return
+		}
+		status := http.StatusCreated
+		var saved models.Watchlist
+		var err error
+		if req.Method == http.MethodPost {
+			saved, err = api.CreateWatchlist(s.db, watchlist)
+		} else {
+			id, parseErr := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+			if parseErr != nil {
+				api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+				return
+			}
+			status = http.StatusOK
+			saved, err = api.UpdateWatchlist(s.db, uint(id), watchlist)
+		}
+		if err != nil {
+			api.RespondWithError(w, watchlistErrorStatus(err), err.Error())
+			return
+		}
+		api.RespondWithJSON(status, w, saved)
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+			return
+		}
+		owner := s.getParamOrFail(w, req, "owner")
+		if owner == "" {
+			return
+		}
+		if err := api.DeleteWatchlist(s.db, uint(id), owner); err != nil {
+			api.RespondWithError(w, watchlistErrorStatus(err), err.Error())
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": "watchlist deleted",
+		})
+	default:
+		if idParam := param.SafeRead(req, "id"); idParam != "" {
+			id, _ := strconv.ParseUint(idParam, 10, 64)
+			watchlist, err := api.GetWatchlist(s.db, uint(id))
+			if err != nil {
+				api.RespondWithError(w, watchlistErrorStatus(err), err.Error())
+				return
+			}
+			api.RespondWithJSON(http.StatusOK, w, watchlist)
+			return
+		}
+		watchlists, err := api.ListWatchlists(s.db, param.SafeRead(req, "owner"))
+		if err != nil {
+			log.WithError(err).Error("error listing watchlists")
+			api.RespondWithError(w, http.StatusInternalServerError, "error listing watchlists")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, watchlists)
+	}
+}
+
+// jsonWatchlistReport returns the current pass rates and regressions of only the tests on the watchlist with id.
+func (s *Server) jsonWatchlistReport(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseUint(param.SafeRead(req, "id"), 10, 64)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "a numeric id param is required")
+		return
+	}
+	watchlist, err := api.GetWatchlist(s.db, uint(id))
+	if err != nil {
+		api.RespondWithError(w, watchlistErrorStatus(err), err.Error())
+		return
+	}
+	report, err := api.GetWatchlistReportFromDB(s.db, watchlist)
+	if err != nil {
+		log.WithError(err).Error("error building watchlist report")
+		api.RespondWithError(w, http.StatusInternalServerError, "error building watchlist report")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, report)
+}
+
+func watchlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, api.ErrWatchlistNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, api.ErrWatchlistNotOwner):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (s *Server) jsonTestBuildClustersFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	sample, err := api.SamplePercent(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := api.GetTestBuildClustersFromDB(s.db, release, testName, filters, sample)
+	if err != nil {
+		log.WithError(err).Error("error querying test results by build cluster from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test results by build cluster from db")
+		return
+	}
+	api.SetSampleHeader(w, sample)
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonTestExplainFromDB lists the job runs behind a test's reported pass percentage.
+func (s *Server) jsonTestExplainFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	result, err := api.ExplainTestReport(s.db, release, testName, param.SafeRead(req, "period"), param.SafeRead(req, "window"), filters, s.GetReportEnd())
+	if errors.Is(err, api.ErrUnknownExplainWindow) {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		log.WithError(err).Error("error explaining test report")
+		api.RespondWithError(w, http.StatusInternalServerError, "error explaining test report")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonTestReleaseMatrixFromDB reports a test's pass rates in every release, overall and by variant.
+func (s *Server) jsonTestReleaseMatrixFromDB(w http.ResponseWriter, req *http.Request) {
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	period := param.SafeRead(req, "period")
+	if period != "" && period != "default" && period != "twoDay" {
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, "period must be default or twoDay", map[string]string{"param": "period"})
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	result, err := api.GetTestReleaseMatrixFromDB(s.db, testName, period, filters)
+	if err != nil {
+		log.WithError(err).Error("error querying test results across releases")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test results across releases")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonTestSeasonalityFromDB breaks a test's failure rate down by hour of day and day of the week, or without a test
+// lists the tests in the release whose failures concentrate in a few hours of the day.
+func (s *Server) jsonTestSeasonalityFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	if param.SafeRead(req, "test") == "" && param.SafeRead(req, "testHash") == "" {
+		results, err := api.GetSeasonalTestsFromDB(s.db, release, s.GetReportEnd())
+		if err != nil {
+			log.WithError(err).Error("error querying seasonal tests")
+			api.RespondWithError(w, http.StatusInternalServerError, "error querying seasonal tests")
+			return
+		}
+		api.RespondWithJSON(http.StatusOK, w, results)
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+	result, err := api.GetTestSeasonalityFromDB(s.db, release, testName, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying test seasonality")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test seasonality")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonRemovedTestsFromDB lists the tests that ran in baseRelease, by default the previous minor release, but no
+// longer run in release, and those new in release.
+func (s *Server) jsonRemovedTestsFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	baseRelease := param.SafeRead(req, "baseRelease")
+	if baseRelease == "" {
+		if baseRelease = api.PreviousMinorRelease(release); baseRelease == "" {
+			api.RespondWithErrorDetails(w, http.StatusBadRequest, "baseRelease is required when release has no previous minor release",
+				map[string]string{"param": "baseRelease"})
+			return
+		}
+	}
+
+	result, err := api.GetTestPresenceDiffFromDB(s.db, release, baseRelease)
+	if err != nil {
+		log.WithError(err).Error("error diffing tests between releases")
+		api.RespondWithError(w, http.StatusInternalServerError, "error diffing tests between releases")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonCollapsedTestsFromDB lists the tests the configured test name rules collapsed other test names into.
+func (s *Server) jsonCollapsedTestsFromDB(w http.ResponseWriter, req *http.Request) {
+	results, err := api.GetCollapsedTestsFromDB(s.db, getLimitParam(req))
+	if err != nil {
+		log.WithError(err).Error("error querying collapsed tests")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying collapsed tests")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonTestComparisonFromDB compares a test's results over the last week against a basis in baseRelease, by
+// default the four weeks up to the report end or up to the baseEnd date if given.
+func (s *Server) jsonTestComparisonFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	baseRelease := s.getParamOrFail(w, req, "baseRelease")
+	if baseRelease == "" {
+		return
+	}
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	baseEnd := s.GetReportEnd()
+	if v := param.SafeRead(req, "baseEnd"); v != "" {
+		if baseEnd, err = time.Parse("2006-01-02", v); err != nil {
+			api.RespondWithErrorDetails(w, http.StatusBadRequest, "invalid baseEnd: "+err.Error(), map[string]interface{}{"param": "baseEnd"})
+			return
+		}
+	}
+
+	opts := api.TestComparisonOptions{}
+	if opts.Confidence, err = componentreadiness.ParseIntArg(req, "confidence", 95,
+		func(v int) bool { return v >= 0 && v <= 100 }); err != nil {
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, err.Error(), map[string]interface{}{"param": "confidence"})
+		return
+	}
+	if opts.PityFactor, err = componentreadiness.ParseIntArg(req, "pity", 5,
+		func(v int) bool { return v >= 0 && v <= 100 }); err != nil {
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, err.Error(), map[string]interface{}{"param": "pity"})
+		return
+	}
+	if opts.MinimumFailure, err = componentreadiness.ParseIntArg(req, "minFail", 3,
+		func(v int) bool { return v >= 0 }); err != nil {
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, err.Error(), map[string]interface{}{"param": "minFail"})
+		return
+	}
+
+	result, err := api.CompareTestFromDB(s.db, testName, release, baseRelease, filters, opts, baseEnd, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error comparing test results")
+		api.RespondWithError(w, http.StatusInternalServerError, "error comparing test results: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+func (s *Server) jsonTestOutputsFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	outputs, err := api.GetTestOutputsFromDB(s.db, release, testName, filters, 10)
+	if err != nil {
+		log.WithError(err).Error("error querying test outputs from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test outputs from db")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, outputs)
+}
+
+func (s *Server) jsonTestFailureGroupsFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	testName := s.getTestNameOrFail(w, req)
+	if testName == "" {
+		return
+	}
+
+	filters, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusInternalServerError, "error processing filter options")
+		return
+	}
+
+	groups, err := api.GetTestFailureGroupsFromDB(s.db, release, testName, param.SafeRead(req, "q"), filters)
+	if err != nil {
+		log.WithError(err).Error("error querying test failure groups from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying test failure groups from db")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, groups)
+}
+
+func (s *Server) jsonComponentTestVariantsFromBigQuery(w http.ResponseWriter, req *http.Request) {
+	if s.bigQueryClient == nil {
+		api.RespondWithError(w, http.StatusBadRequest, "component report API is only available when google-service-account-credential-file is configured")
+		return
+	}
+	outputs, errs := componentreadiness.GetComponentTestVariantsFromBigQuery(req.Context(), s.bigQueryClient, s.gcsBucket)
+	if len(errs) > 0 {
+		log.Warningf("%d errors were encountered while querying test variants from big query:", len(errs))
+		for _, err := range errs {
+			log.Error(err.Error())
+		}
+		api.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("error querying test variants from big query: %v", errs))
 		return
 	}
 	api.RespondWithJSON(http.StatusOK, w, outputs)
@@ -593,7 +2050,7 @@ func (s *Server) jsonComponentTestVariantsFromBigQuery(w http.ResponseWriter, re
 
 func (s *Server) jsonJobVariantsFromBigQuery(w http.ResponseWriter, req *http.Request) {
 	if s.bigQueryClient == nil {
-		failureResponse(w, http.StatusBadRequest, "job variants API is only available when google-service-account-credential-file is configured")
+		api.RespondWithError(w, http.StatusBadRequest, "job variants API is only available when google-service-account-credential-file is configured")
 		return
 	}
 	outputs, errs := componentreadiness.GetJobVariantsFromBigQuery(req.Context(), s.bigQueryClient, s.gcsBucket)
@@ -602,7 +2059,7 @@ func (s *Server) jsonJobVariantsFromBigQuery(w http.ResponseWriter, req *http.Re
 		for _, err := range errs {
 			log.Error(err.Error())
 		}
-		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error querying job variants from big query: %v", errs))
+		api.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("error querying job variants from big query: %v", errs))
 		return
 	}
 	api.RespondWithJSON(http.StatusOK, w, outputs)
@@ -611,18 +2068,19 @@ func (s *Server) jsonJobVariantsFromBigQuery(w http.ResponseWriter, req *http.Re
 func (s *Server) jsonComponentReadinessViews(w http.ResponseWriter, req *http.Request) {
 	allReleases, err := api.GetReleases(req.Context(), s.bigQueryClient)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// deep copy the views and then we'll inject a fixed start/end time using the relative times
 	// the view is configured with, so the UI can pre-populate the pickers
-	viewsCopy := make([]crtype.View, len(s.views.ComponentReadiness))
-	copy(viewsCopy, s.views.ComponentReadiness)
+	views := s.GetViews()
+	viewsCopy := make([]crtype.View, len(views.ComponentReadiness))
+	copy(viewsCopy, views.ComponentReadiness)
 	for i := range viewsCopy {
 		rro, err := componentreadiness.GetViewReleaseOptions(allReleases, "basis", viewsCopy[i].BaseRelease, s.crTimeRoundingFactor)
 		if err != nil {
-			failureResponse(w, http.StatusBadRequest, err.Error())
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		viewsCopy[i].BaseRelease.Start = rro.Start
@@ -630,7 +2088,7 @@ func (s *Server) jsonComponentReadinessViews(w http.ResponseWriter, req *http.Re
 
 		rro, err = componentreadiness.GetViewReleaseOptions(allReleases, "sample", viewsCopy[i].SampleRelease, s.crTimeRoundingFactor)
 		if err != nil {
-			failureResponse(w, http.StatusBadRequest, err.Error())
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		viewsCopy[i].SampleRelease.Start = rro.Start
@@ -641,24 +2099,24 @@ func (s *Server) jsonComponentReadinessViews(w http.ResponseWriter, req *http.Re
 
 func (s *Server) jsonComponentReportFromBigQuery(w http.ResponseWriter, req *http.Request) {
 	if s.bigQueryClient == nil {
-		failureResponse(w, http.StatusBadRequest, "component report API is only available when google-service-account-credential-file is configured")
+		api.RespondWithError(w, http.StatusBadRequest, "component report API is only available when google-service-account-credential-file is configured")
 		return
 	}
 	allJobVariants, errs := componentreadiness.GetJobVariantsFromBigQuery(req.Context(), s.bigQueryClient, s.gcsBucket)
 	if len(errs) > 0 {
-		failureResponse(w, http.StatusBadRequest, "failed to get variants from bigquery")
+		api.RespondWithError(w, http.StatusBadRequest, "failed to get variants from bigquery")
 		return
 	}
 
 	allReleases, err := api.GetReleases(req.Context(), s.bigQueryClient)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	options, err := componentreadiness.ParseComponentReportRequest(s.views.ComponentReadiness, allReleases, req, allJobVariants, s.crTimeRoundingFactor)
+	options, err := componentreadiness.ParseComponentReportRequest(s.GetViews().ComponentReadiness, allReleases, req, allJobVariants, s.crTimeRoundingFactor)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -674,7 +2132,7 @@ func (s *Server) jsonComponentReportFromBigQuery(w http.ResponseWriter, req *htt
 		for _, err := range errs {
 			log.Error(err.Error())
 		}
-		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error querying component from big query: %v", errs))
+		api.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("error querying component from big query: %v", errs))
 		return
 	}
 	api.RespondWithJSON(http.StatusOK, w, outputs)
@@ -683,24 +2141,24 @@ func (s *Server) jsonComponentReportFromBigQuery(w http.ResponseWriter, req *htt
 func (s *Server) jsonComponentReportTestDetailsFromBigQuery(w http.ResponseWriter, req *http.Request) {
 	if s.bigQueryClient == nil {
 		err := fmt.Errorf("component report API is only available when google-service-account-credential-file is configured")
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	allJobVariants, errs := componentreadiness.GetJobVariantsFromBigQuery(req.Context(), s.bigQueryClient, s.gcsBucket)
 	if len(errs) > 0 {
 		err := fmt.Errorf("failed to get variants from bigquery")
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	allReleases, err := api.GetReleases(req.Context(), s.bigQueryClient)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	reqOptions, err := componentreadiness.ParseComponentReportRequest(s.views.ComponentReadiness, allReleases, req, allJobVariants, s.crTimeRoundingFactor)
+	reqOptions, err := componentreadiness.ParseComponentReportRequest(s.GetViews().ComponentReadiness, allReleases, req, allJobVariants, s.crTimeRoundingFactor)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	outputs, errs := componentreadiness.GetTestDetails(req.Context(), s.bigQueryClient, s.prowURL, s.gcsBucket, reqOptions)
@@ -709,23 +2167,69 @@ func (s *Server) jsonComponentReportTestDetailsFromBigQuery(w http.ResponseWrite
 		for _, err := range errs {
 			log.Error(err.Error())
 		}
-		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error querying component test details from big query: %v", errs))
+		api.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("error querying component test details from big query: %v", errs))
 		return
 	}
 	api.RespondWithJSON(http.StatusOK, w, outputs)
 }
 
+// jsonBugImpactFromDB lists the job runs in the period that failed a test linked to the bug, grouped by job.
+func (s *Server) jsonBugImpactFromDB(w http.ResponseWriter, req *http.Request) {
+	bug := s.getParamOrFail(w, req, "bug")
+	if bug == "" {
+		return
+	}
+	release := param.SafeRead(req, "release")
+	start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+
+	impact, err := api.GetBugImpactFromDB(s.db, bug, release, start, boundary, end)
+	if errors.Is(err, api.ErrBugNotFound) {
+		api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("bug %s not found", bug))
+		return
+	} else if err != nil {
+		log.WithError(err).Error("error querying bug impact from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying bug impact from db")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, impact)
+}
+
+func (s *Server) jsonBugResolutionStats(w http.ResponseWriter, req *http.Request) {
+	groupBy := param.SafeRead(req, "groupBy")
+	if groupBy == "" {
+		groupBy = api.BugResolutionByComponent
+	}
+	if groupBy != api.BugResolutionByComponent && groupBy != api.BugResolutionByRelease {
+		api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("groupBy must be %s or %s", api.BugResolutionByComponent, api.BugResolutionByRelease))
+		return
+	}
+
+	results, err := api.GetBugResolutionStatsFromDB(s.db, groupBy, param.SafeRead(req, "release"), s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying bug resolution stats from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying bug resolution stats from db")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
 func (s *Server) jsonJobBugsFromDB(w http.ResponseWriter, req *http.Request) {
 	release := param.SafeRead(req, "release")
 
 	fil, err := filter.ExtractFilters(req)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
 		return
 	}
 	jobFilter, _, err := splitJobAndJobRunFilters(fil)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		return
+	}
+
+	page, err := pagination.FromRequest(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
 		return
 	}
 
@@ -736,23 +2240,37 @@ func (s *Server) jsonJobBugsFromDB(w http.ResponseWriter, req *http.Request) {
 	jobIDs, err := query.ListFilteredJobIDs(s.db, release, jobFilter, start, boundary, end, limit, sortField, sort)
 	if err != nil {
 		log.WithError(err).Error("error querying jobs")
-		failureResponse(w, http.StatusInternalServerError, "error querying jobs")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying jobs")
 		return
 	}
 
 	bugs, err := query.LoadBugsForJobs(s.db, jobIDs, false)
 	if err != nil {
 		log.WithError(err).Error("error querying job bugs from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying job bugs from db")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying job bugs from db")
 		return
 	}
-	api.RespondWithJSON(http.StatusOK, w, bugs)
+	api.RespondWithJSON(http.StatusOK, w, pagination.Slice(page, bugs))
 }
 
 func (s *Server) jsonTestsReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release != "" {
-		api.PrintTestsJSONFromDB(release, w, req, s.db)
+		api.PrintTestsJSONFromDB(release, w, req, s.db, s.GetReportEnd())
+	}
+}
+
+func (s *Server) jsonTestSigsReportFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release != "" {
+		api.PrintTestGroupsJSONFromDB(w, s.db, release, param.SafeRead(req, "period"), query.TestGroupSig)
+	}
+}
+
+func (s *Server) jsonTestFeaturesReportFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release != "" {
+		api.PrintTestGroupsJSONFromDB(w, s.db, release, param.SafeRead(req, "period"), query.TestGroupFeature)
 	}
 }
 
@@ -773,7 +2291,7 @@ func (s *Server) jsonReleasesReportFromDB(w http.ResponseWriter, req *http.Reque
 	releases, err := api.GetReleases(req.Context(), s.bigQueryClient)
 	if err != nil {
 		log.WithError(err).Error("error querying releases")
-		failureResponse(w, http.StatusInternalServerError, "error querying releases")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying releases")
 		return
 	}
 
@@ -794,7 +2312,7 @@ func (s *Server) jsonReleasesReportFromDB(w http.ResponseWriter, req *http.Reque
 		res := s.db.DB.Raw("SELECT MAX(created_at) FROM prow_job_runs").Scan(&lastUpdated)
 		if res.Error != nil {
 			log.WithError(res.Error).Error("error querying last updated from db")
-			failureResponse(w, http.StatusInternalServerError, "error querying last updated from db")
+			api.RespondWithError(w, http.StatusInternalServerError, "error querying last updated from db")
 			return
 		}
 
@@ -807,8 +2325,23 @@ func (s *Server) jsonReleasesReportFromDB(w http.ResponseWriter, req *http.Reque
 func (s *Server) jsonHealthReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release != "" {
-		api.PrintOverallReleaseHealthFromDB(w, s.db, release, s.GetReportEnd())
+		api.PrintOverallReleaseHealthFromDB(w, s.db, release, param.SafeRead(req, "arch"), s.releaseIndicators(release), s.promotionWarningThreshold, s.GetReportEnd())
+	}
+}
+
+// jsonHostedControlPlaneHealth reports the health of the release's hypershift jobs apart from the standalone ones.
+func (s *Server) jsonHostedControlPlaneHealth(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	health, err := api.GetHostedControlPlaneHealthFromDB(s.db, release, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error building hosted control plane health report")
+		api.RespondWithError(w, http.StatusInternalServerError, "error building hosted control plane health report")
+		return
 	}
+	api.RespondWithJSON(http.StatusOK, w, health)
 }
 
 func (s *Server) jsonBuildClusterHealth(w http.ResponseWriter, req *http.Request) {
@@ -817,7 +2350,7 @@ func (s *Server) jsonBuildClusterHealth(w http.ResponseWriter, req *http.Request
 	results, err := api.GetBuildClusterHealthReport(s.db, start, boundary, end)
 	if err != nil {
 		log.WithError(err).Error("error querying build cluster health from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying build cluster health from db: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying build cluster health from db: "+err.Error())
 		return
 	}
 
@@ -830,7 +2363,7 @@ func (s *Server) jsonBuildClusterHealthAnalysis(w http.ResponseWriter, req *http
 	results, err := api.GetBuildClusterHealthAnalysis(s.db, period)
 	if err != nil {
 		log.WithError(err).Error("error querying build cluster health from db")
-		failureResponse(w, http.StatusInternalServerError, "error querying build cluster health from db: "+err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying build cluster health from db: "+err.Error())
 		return
 	}
 
@@ -840,11 +2373,32 @@ func (s *Server) jsonBuildClusterHealthAnalysis(w http.ResponseWriter, req *http
 func (s *Server) getParamOrFail(w http.ResponseWriter, req *http.Request, name string) string {
 	release := param.SafeRead(req, name)
 	if release == "" {
-		failureResponse(w, http.StatusBadRequest, fmt.Sprintf("param '%s' is required", name))
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, fmt.Sprintf("param '%s' is required", name), map[string]string{"param": name})
 	}
 	return release
 }
 
+// getTestNameOrFail returns the test name from the request, which may be given directly with the test param, or
+// with the stable testHash param.
+func (s *Server) getTestNameOrFail(w http.ResponseWriter, req *http.Request) string {
+	testHash := param.SafeRead(req, "testHash")
+	if testHash == "" {
+		return s.getParamOrFail(w, req, "test")
+	}
+
+	testName, err := query.TestNameByHash(s.db, testHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("no test found with hash %s", testHash))
+			return ""
+		}
+		log.WithError(err).Error("error looking up test by hash")
+		api.RespondWithError(w, http.StatusInternalServerError, "error looking up test by hash")
+		return ""
+	}
+	return testName
+}
+
 func (s *Server) jsonJobsDetailsReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	jobName := s.getParamOrFail(w, req, "job")
@@ -867,7 +2421,7 @@ func (s *Server) printReportDate(w http.ResponseWriter, req *http.Request) {
 func (s *Server) printCanaryReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release != "" {
-		api.PrintCanaryTestsFromDB(release, w, s.db)
+		api.PrintCanaryTestsFromDB(release, w, s.db, s.GetReportEnd())
 	}
 }
 
@@ -885,19 +2439,51 @@ func (s *Server) jsonJobsReportFromDB(w http.ResponseWriter, req *http.Request)
 	}
 }
 
+func (s *Server) jsonJobSpotlight(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	minRuns := api.DefaultSpotlightMinRuns
+	if v := param.SafeRead(req, "minRuns"); v != "" {
+		minRuns, _ = strconv.Atoi(v)
+	}
+	limit := getLimitParam(req)
+	if limit <= 0 {
+		limit = api.DefaultSpotlightLimit
+	}
+
+	start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	spotlight, err := api.GetJobSpotlightFromDB(s.db, release, minRuns, limit, start, boundary, end)
+	if err != nil {
+		log.WithError(err).Error("error querying job spotlight")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying job spotlight: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, spotlight)
+}
+
+func (s *Server) jsonChaosJobsReportFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release != "" {
+		start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+		api.PrintChaosJobsReportFromDB(w, req, s.db, release, start, boundary, end)
+	}
+}
+
 func (s *Server) jsonRepositoriesReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release != "" {
 		filterOpts, err := filter.FilterOptionsFromRequest(req, "premerge_job_failures", apitype.SortDescending)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
 			return
 		}
 
 		results, err := api.GetRepositoriesReportFromDB(s.db, release, filterOpts, s.GetReportEnd())
 		if err != nil {
 			log.WithError(err).Error("error")
-			failureResponse(w, http.StatusInternalServerError, "Error fetching repositories: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "Error fetching repositories: "+err.Error())
 			return
 		}
 
@@ -905,19 +2491,62 @@ func (s *Server) jsonRepositoriesReportFromDB(w http.ResponseWriter, req *http.R
 	}
 }
 
+func (s *Server) jsonPullRequestFailureRates(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	var byAuthor bool
+	switch groupBy := param.SafeRead(req, "groupBy"); groupBy {
+	case "", "repo":
+	case "author":
+		byAuthor = true
+	default:
+		api.RespondWithErrorDetails(w, http.StatusBadRequest, "groupBy must be repo or author", map[string]interface{}{"param": "groupBy"})
+		return
+	}
+
+	results, err := api.GetPullRequestFailureRatesFromDB(s.db, release, byAuthor,
+		param.SafeRead(req, "org"), param.SafeRead(req, "repo"), s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying pull request failure rates")
+		api.RespondWithError(w, http.StatusInternalServerError, "Error fetching pull request failure rates: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonJobRetestsFromDB reports how often presubmit jobs are rerun on the same pull request commit, and how often the
+// reruns pass.
+func (s *Server) jsonJobRetestsFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	results, err := api.GetJobRetestStatsFromDB(s.db, release,
+		param.SafeRead(req, "org"), param.SafeRead(req, "repo"), s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying job retests")
+		api.RespondWithError(w, http.StatusInternalServerError, "Error fetching job retests: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
 func (s *Server) jsonPullRequestsReportFromDB(w http.ResponseWriter, req *http.Request) {
 	release := s.getParamOrFail(w, req, "release")
 	if release != "" {
 		filterOpts, err := filter.FilterOptionsFromRequest(req, "merged_at", apitype.SortDescending)
 		if err != nil {
-			failureResponse(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "couldn't parse filter opts: "+err.Error())
 			return
 		}
 
 		results, err := api.GetPullRequestsReportFromDB(s.db, release, filterOpts)
 		if err != nil {
 			log.WithError(err).Error("error")
-			failureResponse(w, http.StatusInternalServerError, "Error fetching pull requests: "+err.Error())
+			api.RespondWithError(w, http.StatusInternalServerError, "Error fetching pull requests: "+err.Error())
 			return
 		}
 
@@ -930,25 +2559,61 @@ func (s *Server) jsonJobRunsReportFromDB(w http.ResponseWriter, req *http.Reques
 
 	filterOpts, err := filter.FilterOptionsFromRequest(req, "timestamp", "desc")
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
 		return
 	}
 
-	pagination, err := getPaginationParams(req)
+	page, err := pagination.FromRequest(req)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not parse pagination options: "+err.Error())
 		return
 	}
 
-	result, err := api.JobsRunsReportFromDB(s.db, filterOpts, release, pagination, s.GetReportEnd())
+	result, err := api.JobsRunsReportFromDB(s.db, filterOpts, release, page, s.GetReportEnd())
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	api.RespondWithJSON(http.StatusOK, w, result)
 }
 
+// jsonJobRunsBatchFromDB returns several job runs, with their failed and flaked tests, in one response. Runs are
+// selected by a JSON JobRunsBatchRequest body on POST, or with a comma separated ids param on GET.
+func (s *Server) jsonJobRunsBatchFromDB(w http.ResponseWriter, req *http.Request) {
+	batch := api.JobRunsBatchRequest{}
+	if req.Method == http.MethodPost {
+		if err := json.NewDecoder(req.Body).Decode(&batch); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode batch request: "+err.Error())
+			return
+		}
+	} else {
+		batch.Release = param.SafeRead(req, "release")
+		if ids := param.SafeRead(req, "ids"); ids != "" {
+			for _, id := range strings.Split(ids, ",") {
+				jobRunID, err := strconv.ParseInt(id, 10, 64)
+				if err != nil {
+					api.RespondWithError(w, http.StatusBadRequest, "invalid job run id: "+id)
+					return
+				}
+				batch.IDs = append(batch.IDs, jobRunID)
+			}
+		}
+	}
+
+	if err := batch.Validate(); err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := api.StreamJobRunsBatchFromDB(w, s.db, batch, s.GetReportEnd()); errors.Is(err, api.ErrInvalidJobRunsBatchFilter) {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+	} else if err != nil {
+		log.WithError(err).Error("error in StreamJobRunsBatchFromDB")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying job runs")
+	}
+}
+
 // jsonJobRunRiskAnalysis is an API to make a guess at the severity of failures in a prow job run, based on historical
 // pass rates for each failed test, on-going incidents, and other factors.
 //
@@ -972,7 +2637,7 @@ func (s *Server) jsonJobRunRiskAnalysis(w http.ResponseWriter, req *http.Request
 
 		jobRunID, err := strconv.ParseInt(jobRunIDStr, 10, 64)
 		if err != nil {
-			failureResponse(w, http.StatusBadRequest, "unable to parse prow_job_run_id: "+err.Error())
+			api.RespondWithError(w, http.StatusBadRequest, "unable to parse prow_job_run_id: "+err.Error())
 		}
 
 		logger = logger.WithField("jobRunID", jobRunID)
@@ -981,14 +2646,14 @@ func (s *Server) jsonJobRunRiskAnalysis(w http.ResponseWriter, req *http.Request
 		jobRun, jobRunTestCount, err = api.FetchJobRun(s.db, jobRunID, logger)
 
 		if err != nil {
-			failureResponse(w, http.StatusBadRequest, err.Error())
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 	} else {
 		err := json.NewDecoder(req.Body).Decode(&jobRun)
 		if err != nil {
-			failureResponse(w, http.StatusBadRequest, fmt.Sprintf("error decoding prow job run json in request body: %s", err))
+			api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error decoding prow job run json in request body: %s", err))
 		}
 
 		// validate the jobRun isn't empty
@@ -1004,89 +2669,289 @@ func (s *Server) jsonJobRunRiskAnalysis(w http.ResponseWriter, req *http.Request
 				},
 			}
 
-			// respond ok since we handle it
-			api.RespondWithJSON(http.StatusOK, w, result)
+			// respond ok since we handle it
+			api.RespondWithJSON(http.StatusOK, w, result)
+			return
+		}
+
+		jobRunTestCount = jobRun.TestCount
+
+		// We don't expect the caller to fully populate the ProwJob, just it's name,
+		// override the input by looking up the actual ProwJob so we have access to release and variants.
+		job := &models.ProwJob{}
+		res := s.db.DB.Where("name = ?", jobRun.ProwJob.Name).First(job)
+		if res.Error != nil {
+			api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to find ProwJob: %s", jobRun.ProwJob.Name))
+		}
+		jobRun.ProwJob = *job
+
+		jobRun.ProwJob.Variants = s.variantManager.IdentifyVariants(jobRun.ProwJob.Name)
+		logger = logger.WithField("jobRunID", jobRun.ID)
+	}
+
+	logger.Infof("job run = %+v", *jobRun)
+	result, err := api.JobRunRiskAnalysis(s.db, jobRun, jobRunTestCount, logger.WithField("func", "JobRunRiskAnalysis"))
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonJobRunRiskAnalysis is an API to return the intervals origin builds for interesting things that occurred during
+// the test run.
+//
+// This API is used by the job run intervals chart in the UI.
+func (s *Server) jsonJobRunIntervals(w http.ResponseWriter, req *http.Request) {
+
+	logger := log.WithField("func", "jsonJobRunIntervals")
+
+	if s.gcsClient == nil {
+		api.RespondWithError(w, http.StatusBadRequest, "server not configured for GCS, unable to use this API")
+	}
+
+	jobRunIDStr := s.getParamOrFail(w, req, "prow_job_run_id")
+	if jobRunIDStr == "" {
+		return
+	}
+
+	jobRunID, err := strconv.ParseInt(jobRunIDStr, 10, 64)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "unable to parse prow_job_run_id: "+err.Error())
+	}
+	logger = logger.WithField("jobRunID", jobRunID)
+
+	intervalFile := param.SafeRead(req, "file")
+
+	// Attempt to calculate a GCS path based on a passed in jobName.
+	gcsPath := jobRunGCSPath(req, jobRunIDStr)
+	result, err := jobrunintervals.JobRunIntervals(s.gcsClient, s.db, jobRunID, s.gcsBucket, gcsPath,
+		intervalFile, logger.WithField("func", "JobRunIntervals"))
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonJobRunIntervalsDiff compares the intervals of two runs of the same job, typically a passing base run and a
+// failing run, returning the intervals only one of them has.
+func (s *Server) jsonJobRunIntervalsDiff(w http.ResponseWriter, req *http.Request) {
+	if s.gcsClient == nil {
+		api.RespondWithError(w, http.StatusBadRequest, "server not configured for GCS, unable to use this API")
+		return
+	}
+
+	var runIDs []int64
+	for _, name := range []string{"base_prow_job_run_id", "prow_job_run_id"} {
+		value := s.getParamOrFail(w, req, name)
+		if value == "" {
+			return
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to parse %s: %s", name, err))
+			return
+		}
+		runIDs = append(runIDs, id)
+	}
+
+	logger := log.WithFields(log.Fields{"func": "jsonJobRunIntervalsDiff", "baseJobRunID": runIDs[0], "jobRunID": runIDs[1]})
+	result, err := jobrunintervals.DiffJobRunIntervals(s.gcsClient, s.db, runIDs[0], runIDs[1], s.gcsBucket, logger)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonJobRunSteps reports the steps a job run ran from its step graph, mapping failed steps to the other jobs
+// sharing them.
+func (s *Server) jsonJobRunSteps(w http.ResponseWriter, req *http.Request) {
+	if s.gcsClient == nil {
+		api.RespondWithError(w, http.StatusBadRequest, "server not configured for GCS, unable to use this API")
+		return
+	}
+	jobRunIDStr := s.getParamOrFail(w, req, "prow_job_run_id")
+	if jobRunIDStr == "" {
+		return
+	}
+	jobRunID, err := strconv.ParseInt(jobRunIDStr, 10, 64)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "unable to parse prow_job_run_id: "+err.Error())
+		return
+	}
+
+	logger := log.WithFields(log.Fields{"func": "jsonJobRunSteps", "jobRunID": jobRunID})
+	result, err := api.GetJobRunStepsFromGCS(req.Context(), s.gcsClient, s.db, jobRunID, s.gcsBucket, logger)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
+
+// jsonJobGrid reports a testgrid-like grid of the results of each test in a job's latest runs.
+func (s *Server) jsonJobGrid(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseUint(param.SafePathValue(req, "id"), 10, 64)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "a numeric job id is required")
+		return
+	}
+	runs := api.DefaultJobGridRuns
+	if runsParam := param.SafeRead(req, "runs"); runsParam != "" {
+		runs, _ = strconv.Atoi(runsParam)
+		if runs < 1 || runs > api.MaxJobGridRuns {
+			api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("runs must be between 1 and %d", api.MaxJobGridRuns))
 			return
 		}
+	}
 
-		jobRunTestCount = jobRun.TestCount
-
-		// We don't expect the caller to fully populate the ProwJob, just it's name,
-		// override the input by looking up the actual ProwJob so we have access to release and variants.
-		job := &models.ProwJob{}
-		res := s.db.DB.Where("name = ?", jobRun.ProwJob.Name).First(job)
-		if res.Error != nil {
-			failureResponse(w, http.StatusBadRequest, fmt.Sprintf("unable to find ProwJob: %s", jobRun.ProwJob.Name))
-		}
-		jobRun.ProwJob = *job
-
-		jobRun.ProwJob.Variants = s.variantManager.IdentifyVariants(jobRun.ProwJob.Name)
-		logger = logger.WithField("jobRunID", jobRun.ID)
+	grid, err := api.GetJobGridFromDB(s.db, uint(id), runs, param.SafeRead(req, "failing") == "true")
+	if errors.Is(err, api.ErrJobNotFound) {
+		api.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		log.WithError(err).Error("error building job grid")
+		api.RespondWithError(w, http.StatusInternalServerError, "error building job grid")
+		return
 	}
+	api.RespondWithJSON(http.StatusOK, w, grid)
+}
 
-	logger.Infof("job run = %+v", *jobRun)
-	result, err := api.JobRunRiskAnalysis(s.db, jobRun, jobRunTestCount, logger.WithField("func", "JobRunRiskAnalysis"))
+// jsonStepJobs lists the jobs composed of a step in the step registry.
+func (s *Server) jsonStepJobs(w http.ResponseWriter, req *http.Request) {
+	step := s.getParamOrFail(w, req, "step")
+	if step == "" {
+		return
+	}
+	jobs, err := api.GetStepJobsFromDB(s.db, step, param.SafeRead(req, "release"))
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+		log.WithError(err).Error("error querying step jobs")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying step jobs")
+		return
 	}
-
-	api.RespondWithJSON(http.StatusOK, w, result)
+	api.RespondWithJSON(http.StatusOK, w, jobs)
 }
 
-// jsonJobRunRiskAnalysis is an API to return the intervals origin builds for interesting things that occurred during
-// the test run.
-//
-// This API is used by the job run intervals chart in the UI.
-func (s *Server) jsonJobRunIntervals(w http.ResponseWriter, req *http.Request) {
-
-	logger := log.WithField("func", "jsonJobRunIntervals")
-
-	if s.gcsClient == nil {
-		failureResponse(w, http.StatusBadRequest, "server not configured for GCS, unable to use this API")
+// jsonStepFailures lists the steps failing most often in the release's jobs, or in one job's runs.
+func (s *Server) jsonStepFailures(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
 	}
+	results, err := api.GetStepFailuresFromDB(s.db, release, param.SafeRead(req, "job"), s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying step failures")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying step failures")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
 
-	jobRunIDStr := s.getParamOrFail(w, req, "prow_job_run_id")
-	if jobRunIDStr == "" {
+// jsonEnvironmentDrift lists the environment fingerprint changes after which jobs of the release failed more often.
+func (s *Server) jsonEnvironmentDrift(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	minRuns := api.DefaultEnvironmentDriftMinRuns
+	if v := param.SafeRead(req, "minRuns"); v != "" {
+		minRuns, _ = strconv.Atoi(v)
+	}
+	threshold := api.DefaultEnvironmentDriftThreshold
+	if v := param.SafeRead(req, "threshold"); v != "" {
+		threshold, _ = strconv.ParseFloat(v, 64)
+	}
+	results, err := api.GetEnvironmentDriftFromDB(s.db, release, minRuns, threshold, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error querying environment drift")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying environment drift")
 		return
 	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
 
-	jobRunID, err := strconv.ParseInt(jobRunIDStr, 10, 64)
+// jsonPlatformStepFailures lists the steps failing most often on each platform in the release.
+func (s *Server) jsonPlatformStepFailures(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	results, err := api.GetPlatformStepFailuresFromDB(s.db, release, s.GetReportEnd())
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "unable to parse prow_job_run_id: "+err.Error())
+		log.WithError(err).Error("error querying platform step failures")
+		api.RespondWithError(w, http.StatusInternalServerError, "error querying platform step failures")
+		return
 	}
-	logger = logger.WithField("jobRunID", jobRunID)
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
 
+// jobRunGCSPath calculates the GCS path of a job run's artifacts from the job_name, repo_info and pull_number
+// params, or returns an empty string if no job_name was passed.
+func jobRunGCSPath(req *http.Request, jobRunID string) string {
 	jobName := param.SafeRead(req, "job_name")
 	repoInfo := param.SafeRead(req, "repo_info")
 	pullNumber := param.SafeRead(req, "pull_number")
-	intervalFile := param.SafeRead(req, "file")
-
-	// Attempt to calculate a GCS path based on a passed in jobName.
-	var gcsPath string
-	if len(jobName) > 0 {
-		if len(repoInfo) > 0 {
-			if repoInfo == "openshift_origin" {
-				// GCS bucket path for openshift/origin PRs
-				gcsPath = fmt.Sprintf("pr-logs/pull/%s/%s/%s", pullNumber, jobName, jobRunIDStr)
-			} else {
-				// GCS bucket path for repos other than origin PRs.
-				gcsPath = fmt.Sprintf("pr-logs/pull/%s/%s/%s/%s", repoInfo, pullNumber, jobName, jobRunIDStr)
-			}
-		} else {
-			// GCS bucket for periodics
-			gcsPath = fmt.Sprintf("logs/%s/%s", jobName, jobRunIDStr)
+	if len(jobName) == 0 {
+		return ""
+	}
+	if len(repoInfo) > 0 {
+		if repoInfo == "openshift_origin" {
+			// GCS bucket path for openshift/origin PRs
+			return fmt.Sprintf("pr-logs/pull/%s/%s/%s", pullNumber, jobName, jobRunID)
 		}
-	} else {
-		// JobName was not passed.
-		gcsPath = ""
+		// GCS bucket path for repos other than origin PRs.
+		return fmt.Sprintf("pr-logs/pull/%s/%s/%s/%s", repoInfo, pullNumber, jobName, jobRunID)
 	}
-	result, err := jobrunintervals.JobRunIntervals(s.gcsClient, s.db, jobRunID, s.gcsBucket, gcsPath,
-		intervalFile, logger.WithField("func", "JobRunIntervals"))
-	if err != nil {
-		failureResponse(w, http.StatusBadRequest, err.Error())
+	// GCS bucket for periodics
+	return fmt.Sprintf("logs/%s/%s", jobName, jobRunID)
+}
+
+// streamJobRunIntervals streams the intervals and test failures of an in-progress job run as server-sent events,
+// so disruption and failures can be watched as they develop during long jobs. The run is not in the database yet,
+// so its job_name is required to find its artifacts.
+func (s *Server) streamJobRunIntervals(w http.ResponseWriter, req *http.Request) {
+	if s.gcsClient == nil {
+		api.RespondWithError(w, http.StatusBadRequest, "server not configured for GCS, unable to use this API")
+		return
+	}
+	jobRunID := s.getParamOrFail(w, req, "prow_job_run_id")
+	if jobRunID == "" {
+		return
+	}
+	if s.getParamOrFail(w, req, "job_name") == "" {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		api.RespondWithError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
 	}
 
-	api.RespondWithJSON(http.StatusOK, w, result)
+	gcsPath := jobRunGCSPath(req, jobRunID)
+	logger := log.WithField("gcsPath", gcsPath)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	ctx, cancel := context.WithTimeout(req.Context(), liveIntervalsMaxDuration)
+	defer cancel()
+	err := jobrunintervals.TailJobRunIntervals(ctx, s.gcsClient, s.gcsBucket, gcsPath, jobrunintervals.DefaultLivePollInterval,
+		func(update apitype.LiveJobRunUpdate) error {
+			data, err := json.Marshal(update)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: update\ndata: %s\n\n", data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+	if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		logger.WithError(err).Warning("error streaming job run intervals")
+	}
 }
 
 func isValidProwJobRun(jobRun *models.ProwJobRun) (bool, string) {
@@ -1115,12 +2980,12 @@ func (s *Server) jsonJobsAnalysisFromDB(w http.ResponseWriter, req *http.Request
 
 	fil, err := filter.ExtractFilters(req)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
 		return
 	}
 	jobFilter, jobRunsFilter, err := splitJobAndJobRunFilters(fil)
 	if err != nil {
-		failureResponse(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
 		return
 	}
 
@@ -1133,7 +2998,146 @@ func (s *Server) jsonJobsAnalysisFromDB(w http.ResponseWriter, req *http.Request
 		start, boundary, end, limit, sortField, sort, period, s.GetReportEnd())
 	if err != nil {
 		log.WithError(err).Error("error in PrintJobAnalysisJSONFromDB")
-		failureResponse(w, http.StatusInternalServerError, err.Error())
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+func (s *Server) jsonJobResultsByPeriodFromDB(w http.ResponseWriter, req *http.Request) {
+	release := param.SafeRead(req, "release")
+
+	fil, err := filter.ExtractFilters(req)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		return
+	}
+	jobFilter, _, err := splitJobAndJobRunFilters(fil)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "Could not marshal query: "+err.Error())
+		return
+	}
+
+	start, boundary, end := getPeriodDates("default", req, s.GetReportEnd())
+	period := getPeriod(req, api.PeriodDay)
+
+	results, err := api.GetJobResultsByPeriodFromDB(s.db, release, jobFilter, start, boundary, end, period)
+	if err != nil {
+		log.WithError(err).Error("error in GetJobResultsByPeriodFromDB")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonExternalJobRuns stores a job run reported by a CI system other than prow. The run is either a JSON
+// ExternalJobRun, or junit XML with the source, job, release and build_id params.
+func (s *Server) jsonExternalJobRuns(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "job runs must be reported with a POST")
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, api.MaxExternalJobRunBytes)
+	run := apitype.ExternalJobRun{}
+	if strings.Contains(req.Header.Get("Content-Type"), "xml") {
+		run = apitype.ExternalJobRun{
+			Source:  param.SafeRead(req, "source"),
+			Job:     param.SafeRead(req, "job"),
+			Release: param.SafeRead(req, "release"),
+			BuildID: param.SafeRead(req, "build_id"),
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not read junit: "+err.Error())
+			return
+		}
+		run.JUnit = string(body)
+	} else if err := json.NewDecoder(req.Body).Decode(&run); err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, "could not decode job run: "+err.Error())
+		return
+	}
+	if run.JUnit != "" {
+		var err error
+		if run, err = api.ExternalJobRunFromJUnit(run, []byte(run.JUnit)); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		run.JUnit = ""
+	}
+	if err := api.ValidateExternalJobRun(run); err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jobRun, err := api.StoreExternalJobRun(s.db, s.variantManager, run, time.Now())
+	switch {
+	case errors.Is(err, api.ErrExternalJobRunExists), errors.Is(err, api.ErrExternalJobSource):
+		api.RespondWithError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		log.WithError(err).Error("error storing external job run")
+		api.RespondWithError(w, http.StatusInternalServerError, "error storing job run: "+err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusCreated, w, map[string]interface{}{
+		"code":          http.StatusCreated,
+		"id":            jobRun.ID,
+		"test_failures": jobRun.TestFailures,
+		"succeeded":     jobRun.Succeeded,
+	})
+}
+
+// jsonJobLineageFromDB lists the instances of a job across releases. POSTing a JobLineageOverride moves a job
+// into a different lineage.
+func (s *Server) jsonJobLineageFromDB(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodPost {
+		override := models.JobLineageOverride{}
+		if err := json.NewDecoder(req.Body).Decode(&override); err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, "could not decode job lineage override: "+err.Error())
+			return
+		}
+		saved, err := api.SetJobLineageOverride(s.db, override)
+		if err != nil {
+			api.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.recalculate(jobLineageRecalculation("job lineage override set"))
+		api.RespondWithJSON(http.StatusOK, w, saved)
+		return
+	}
+
+	jobName := s.getParamOrFail(w, req, "job")
+	if jobName == "" {
+		return
+	}
+	results, err := api.GetJobLineageFromDB(s.db, jobName)
+	if err != nil {
+		log.WithError(err).Error("error in GetJobLineageFromDB")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, results)
+}
+
+// jsonJobDurationIncreasesFromDB reports jobs whose median runtime increased significantly week-over-week.
+func (s *Server) jsonJobDurationIncreasesFromDB(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	threshold := api.DefaultJobDurationIncreaseThreshold
+	if t := param.SafeRead(req, "minIncrease"); t != "" {
+		threshold, _ = strconv.ParseFloat(t, 64)
+	}
+
+	results, err := api.GetJobDurationIncreasesFromDB(s.db, release, threshold, s.GetReportEnd())
+	if err != nil {
+		log.WithError(err).Error("error in GetJobDurationIncreasesFromDB")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -1146,54 +3150,128 @@ func (s *Server) requireCapabilities(capabilities []string, implFn func(w http.R
 	}
 
 	return func(w http.ResponseWriter, req *http.Request) {
-		failureResponse(w, http.StatusNotImplemented, "This Sippy server is not capable of responding to this request.")
+		api.RespondWithError(w, http.StatusNotImplemented, "This Sippy server is not capable of responding to this request.")
 	}
 }
 
-func (s *Server) Serve() {
-	s.determineCapabilities()
+// requireScope checks the API token on requests to endpoints that require a scope. Admin endpoints always need an
+// admin token, for every request. Other endpoints only check the token of requests that change state, which is
+// only required if tokenRequired or the server was configured to require API tokens, but is always checked when
+// presented.
+func (s *Server) requireScope(scope string, tokenRequired bool, implFn func(w http.ResponseWriter, req *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if scope != api.APITokenScopeAdmin && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+			implFn(w, req)
+			return
+		}
 
-	// Use private ServeMux to prevent tests from stomping on http.DefaultServeMux
-	serveMux := http.NewServeMux()
+		token := api.BearerToken(req)
+		if token == "" {
+			// admin endpoints can mint tokens and change the server, they are never open
+			if s.requireAPITokens || tokenRequired || scope == api.APITokenScopeAdmin {
+				api.RespondWithError(w, http.StatusUnauthorized, "an API token is required")
+				return
+			}
+			implFn(w, req)
+			return
+		}
+		if s.db == nil {
+			api.RespondWithError(w, http.StatusNotImplemented, "API tokens require a database")
+			return
+		}
 
-	// Handle serving React version of frontend with support for browser router, i.e. anything not found
-	// goes to index.html
-	serveMux.HandleFunc("/sippy-ng/", func(w http.ResponseWriter, r *http.Request) {
-		fs := s.sippyNG
-		if r.URL.Path != "/sippy-ng/" {
-			fullPath := strings.TrimPrefix(r.URL.Path, "/sippy-ng/")
-			if _, err := fs.Open(fullPath); err != nil {
-				if !os.IsNotExist(err) {
-					w.WriteHeader(http.StatusNotFound)
-					w.Header().Set("Content-Type", "text/plain")
-					if _, err := w.Write([]byte(fmt.Sprintf("404 Not Found: %s", fullPath))); err != nil {
-						log.WithError(err).Warningf("could not write response")
+		apiToken, err := api.AuthenticateAPIToken(s.db, token)
+		if err != nil {
+			api.RespondWithError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if !api.APITokenHasScope(apiToken, scope) {
+			api.RespondWithError(w, http.StatusForbidden, fmt.Sprintf("API token %s does not have the %s scope", apiToken.Name, scope))
+			return
+		}
+		log.WithFields(log.Fields{
+			"request_id": w.Header().Get(api.RequestIDHeader),
+			"token":      apiToken.Name,
+		}).Info("authenticated API token")
+		implFn(w, req)
+	}
+}
+
+// registerFrontend serves the React frontend, sippy-ng, either from its files or, for frontend development, by
+// proxying to a dev server.
+func (s *Server) registerFrontend(serveMux *http.ServeMux) {
+	var uiProxy *httputil.ReverseProxy
+	if s.uiDevProxy != nil {
+		log.Infof("proxying the frontend to %s", s.uiDevProxy)
+		uiProxy = httputil.NewSingleHostReverseProxy(s.uiDevProxy)
+		serveMux.Handle("/sippy-ng/", uiProxy)
+	} else {
+		// Handle serving React version of frontend with support for browser router, i.e. anything not found
+		// goes to index.html
+		serveMux.HandleFunc("/sippy-ng/", func(w http.ResponseWriter, r *http.Request) {
+			fs := s.sippyNG
+			if r.URL.Path != "/sippy-ng/" {
+				fullPath := strings.TrimPrefix(r.URL.Path, "/sippy-ng/")
+				if _, err := fs.Open(fullPath); err != nil {
+					if !os.IsNotExist(err) {
+						w.WriteHeader(http.StatusNotFound)
+						w.Header().Set("Content-Type", "text/plain")
+						if _, err := w.Write([]byte(fmt.Sprintf("404 Not Found: %s", fullPath))); err != nil {
+							log.WithError(err).Warningf("could not write response")
+						}
+						return
 					}
-					return
+					r.URL.Path = "/sippy-ng/"
 				}
-				r.URL.Path = "/sippy-ng/"
 			}
-		}
-		http.StripPrefix("/sippy-ng/", http.FileServer(http.FS(fs))).ServeHTTP(w, r)
-	})
+			http.StripPrefix("/sippy-ng/", http.FileServer(http.FS(fs))).ServeHTTP(w, r)
+		})
+	}
 
 	serveMux.Handle("/static/", http.FileServer(http.FS(s.static)))
 
-	// Re-direct "/" to sippy-ng
+	// Re-direct "/" to sippy-ng, when proxying to a dev server anything else unknown goes to it, such as its
+	// live reload websocket.
 	serveMux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
 		if req.URL.Path != "/" {
+			if uiProxy != nil {
+				uiProxy.ServeHTTP(w, req)
+				return
+			}
 			http.NotFound(w, req)
 			return
 		}
 		http.Redirect(w, req, "/sippy-ng/", 301)
 	})
+}
+
+func (s *Server) Serve() {
+	s.determineCapabilities()
+	if s.featureFlags == nil {
+		s.featureFlags, _ = api.NewFeatureFlagSet(s.db, nil)
+	}
+	s.configLock.Lock()
+	if s.reportTemplates == nil {
+		s.reportTemplates, _ = api.NewReportTemplates(nil, "")
+	}
+	if s.slos == nil {
+		s.slos, _ = slo.New(s.db, nil)
+	}
+	s.configLock.Unlock()
+
+	// Use private ServeMux to prevent tests from stomping on http.DefaultServeMux
+	serveMux := http.NewServeMux()
+	s.registerFrontend(serveMux)
 
 	type apiEndpoints struct {
-		EndpointPath string                                       `json:"path"`
-		Description  string                                       `json:"description"`
-		Capabilities []string                                     `json:"required_capabilities"`
-		CacheTime    time.Duration                                `json:"cache_time"`
-		HandlerFunc  func(w http.ResponseWriter, r *http.Request) `json:"-"`
+		EndpointPath  string                                       `json:"path"`
+		Description   string                                       `json:"description"`
+		Capabilities  []string                                     `json:"required_capabilities"`
+		CacheTime     time.Duration                                `json:"cache_time"`
+		Scope         string                                       `json:"required_scope,omitempty"`
+		TokenRequired bool                                         `json:"token_required,omitempty"`
+		FeatureFlag   string                                       `json:"feature_flag,omitempty"`
+		HandlerFunc   func(w http.ResponseWriter, r *http.Request) `json:"-"`
 	}
 
 	var endpoints []apiEndpoints
@@ -1204,9 +3282,15 @@ func (s *Server) Serve() {
 			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
 				var availableEndpoints []apiEndpoints
 				for _, ep := range endpoints {
-					if s.hasCapabilities(ep.Capabilities) {
-						availableEndpoints = append(availableEndpoints, ep)
+					if !s.hasCapabilities(ep.Capabilities) {
+						continue
+					}
+					if ep.FeatureFlag != "" {
+						if enabled, _ := s.featureFlags.Enabled(ep.FeatureFlag, r); !enabled {
+							continue
+						}
 					}
+					availableEndpoints = append(availableEndpoints, ep)
 				}
 				api.RespondWithJSON(http.StatusOK, w, availableEndpoints)
 			},
@@ -1218,35 +3302,113 @@ func (s *Server) Serve() {
 			HandlerFunc:  s.jsonAutocompleteFromDB,
 		},
 		{
-			EndpointPath: "/api/jobs",
-			Description:  "Returns a list of jobs",
-			Capabilities: []string{LocalDBCapability},
-			HandlerFunc:  s.jsonJobsReportFromDB,
+			EndpointPath: "/api/jobs",
+			Description:  "Returns a list of jobs",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonJobsReportFromDB,
+		},
+		{
+			EndpointPath: "/api/jobs/spotlight",
+			Description:  "Returns the jobs whose pass rate improved and degraded the most since the previous period",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonJobSpotlight,
+		},
+		{
+			EndpointPath: "/api/jobs/chaos",
+			Description:  "Returns a list of chaos and other fault injection jobs, which are excluded from job health statistics",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonChaosJobsReportFromDB,
+		},
+		{
+			EndpointPath: "/api/jobs/runs",
+			Description:  "Returns a report of job runs",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonJobRunsReportFromDB,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/batch",
+			Description:  "Returns multiple job runs with their failed and flaked tests",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonJobRunsBatchFromDB,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/risk_analysis",
+			Description:  "Analyzes risks of job runs",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonJobRunRiskAnalysis,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/intervals",
+			Description:  "Reports intervals of job runs",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    4 * time.Hour,
+			HandlerFunc:  s.jsonJobRunIntervals,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/intervals/diff",
+			Description:  "Diffs the intervals of two runs of a job, listing those only one of them has",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    4 * time.Hour,
+			HandlerFunc:  s.jsonJobRunIntervalsDiff,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/steps",
+			Description:  "Reports the steps of a job run, mapping failed steps to the other jobs composed of them",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    4 * time.Hour,
+			HandlerFunc:  s.jsonJobRunSteps,
+		},
+		{
+			EndpointPath: "/api/steps/jobs",
+			Description:  "Lists the jobs composed of a step in the step registry",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonStepJobs,
+		},
+		{
+			EndpointPath: "/api/steps/failures",
+			Description:  "Reports the steps failing most often per job in a release",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonStepFailures,
+		},
+		{
+			EndpointPath: "/api/steps/failures/platforms",
+			Description:  "Reports the steps failing most often per platform in a release",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonPlatformStepFailures,
+		},
+		{
+			EndpointPath: "/api/jobs/runs/intervals/live",
+			Description:  "Streams the intervals and test failures of an in-progress job run",
+			HandlerFunc:  s.streamJobRunIntervals,
 		},
 		{
-			EndpointPath: "/api/jobs/runs",
-			Description:  "Returns a report of job runs",
+			EndpointPath: "/api/jobs/analysis",
+			Description:  "Analyzes jobs from the database",
 			Capabilities: []string{LocalDBCapability},
-			HandlerFunc:  s.jsonJobRunsReportFromDB,
+			HandlerFunc:  s.jsonJobsAnalysisFromDB,
 		},
 		{
-			EndpointPath: "/api/jobs/runs/risk_analysis",
-			Description:  "Analyzes risks of job runs",
+			EndpointPath: "/api/jobs/results_by_period",
+			Description:  "Returns job results summed by day or hour",
 			Capabilities: []string{LocalDBCapability},
-			HandlerFunc:  s.jsonJobRunRiskAnalysis,
+			HandlerFunc:  s.jsonJobResultsByPeriodFromDB,
 		},
 		{
-			EndpointPath: "/api/jobs/runs/intervals",
-			Description:  "Reports intervals of job runs",
+			EndpointPath: "/api/jobs/lineage",
+			Description:  "Lists a job's renamed instances across releases, or overrides a job's lineage",
 			Capabilities: []string{LocalDBCapability},
-			CacheTime:    4 * time.Hour,
-			HandlerFunc:  s.jsonJobRunIntervals,
+			Scope:        api.APITokenScopeWrite,
+			HandlerFunc:  s.jsonJobLineageFromDB,
 		},
 		{
-			EndpointPath: "/api/jobs/analysis",
-			Description:  "Analyzes jobs from the database",
+			EndpointPath: "/api/jobs/duration_increases",
+			Description:  "Reports jobs whose runtime increased significantly week-over-week",
 			Capabilities: []string{LocalDBCapability},
-			HandlerFunc:  s.jsonJobsAnalysisFromDB,
+			HandlerFunc:  s.jsonJobDurationIncreasesFromDB,
 		},
 		{
 			EndpointPath: "/api/jobs/details",
@@ -1254,12 +3416,39 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobsDetailsReportFromDB,
 		},
+		{
+			EndpointPath: "/api/jobs/{id}/grid",
+			Description:  "Reports a testgrid-like grid of the results of each test in a job's latest runs",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonJobGrid,
+		},
+		{
+			EndpointPath: "/api/jobs/environment_drift",
+			Description:  "Reports changes of the dependencies of jobs' runs, such as the installer version, after which the jobs failed more often",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonEnvironmentDrift,
+		},
 		{
 			EndpointPath: "/api/jobs/bugs",
 			Description:  "Reports bugs related to jobs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobBugsFromDB,
 		},
+		{
+			EndpointPath: "/api/bugs/job_runs",
+			Description:  "Reports job runs impacted by a bug",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonBugImpactFromDB,
+		},
+		{
+			EndpointPath: "/api/bugs/mttr",
+			Description:  "Reports the mean time to resolve bugs linked to tests or jobs, by component or release",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonBugResolutionStats,
+		},
 		{
 			EndpointPath: "/api/job_variants",
 			Description:  "Reports all job variants defined in BigQuery",
@@ -1273,6 +3462,20 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonPullRequestsReportFromDB,
 		},
+		{
+			EndpointPath: "/api/pull_requests/failure_rates",
+			Description:  "Reports weekly presubmit failure rates by repository or author against the release baseline",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonPullRequestFailureRates,
+		},
+		{
+			EndpointPath: "/api/pull_requests/retests",
+			Description:  "Reports how often presubmit jobs are retested on the same pull request commit, how often retests pass, and the compute spent on them",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonJobRetestsFromDB,
+		},
 		{
 			EndpointPath: "/api/repositories",
 			Description:  "Reports on repositories",
@@ -1286,6 +3489,20 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestsReportFromDB,
 		},
+		{
+			EndpointPath: "/api/tests/sigs",
+			Description:  "Reports test pass rates combined by sig",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestSigsReportFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/features",
+			Description:  "Reports test pass rates combined by feature",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestFeaturesReportFromDB,
+		},
 		{
 			EndpointPath: "/api/tests/details",
 			Description:  "Details of tests",
@@ -1307,6 +3524,13 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestAnalysisByVariantFromDB,
 		},
+		{
+			EndpointPath: "/api/tests/analysis/variant_combinations",
+			Description:  "Analysis of test by the full combination of variants a job runs with",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestAnalysisByVariantCombinationFromDB,
+		},
 		{
 			EndpointPath: "/api/tests/analysis/jobs",
 			Description:  "Analysis of tests by job",
@@ -1314,6 +3538,12 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestAnalysisByJobFromDB,
 		},
+		{
+			EndpointPath: "/api/tests/search",
+			Description:  "Searches test names by substring and similarity",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonTestSearch,
+		},
 		{
 			EndpointPath: "/api/tests/bugs",
 			Description:  "Reports bugs in tests",
@@ -1328,6 +3558,67 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestOutputsFromDB,
 		},
+		{
+			EndpointPath: "/api/tests/outputs/search",
+			Description:  "Groups recent failures of a test by similar error message, optionally only those containing q",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestFailureGroupsFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/suppressions",
+			Description:  "Lists (GET), creates (POST), or deletes (DELETE with id) known issue suppression windows for tests",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeWrite,
+			HandlerFunc:  s.jsonTestSuppressions,
+		},
+		{
+			EndpointPath: "/api/tests/build_clusters",
+			Description:  "Reports test results by build cluster",
+			Capabilities: []string{LocalDBCapability, BuildClusterCapability},
+			HandlerFunc:  s.jsonTestBuildClustersFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/explain",
+			Description:  "Lists the job runs counted in a test's pass percentage",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonTestExplainFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/compare",
+			Description:  "Compares a test's pass rate against a basis release using Fisher's exact test",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestComparisonFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/releases",
+			Description:  "Reports a test's pass rates in every release, overall and by variant",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestReleaseMatrixFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/removed",
+			Description:  "Lists the tests that ran in a base release but no longer run in a release, and the new ones, with counts per sig",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonRemovedTestsFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/collapsed",
+			Description:  "Lists the tests the configured test name rules collapsed other test names into",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonCollapsedTestsFromDB,
+		},
+		{
+			EndpointPath: "/api/tests/seasonality",
+			Description:  "Breaks a test's failure rate down by hour of day and day of the week, or lists tests whose failures concentrate in a few hours",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonTestSeasonalityFromDB,
+		},
 		{
 			EndpointPath: "/api/tests/durations",
 			Description:  "Durations of tests",
@@ -1342,6 +3633,19 @@ func (s *Server) Serve() {
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonInstallReportFromDB,
 		},
+		{
+			EndpointPath: "/api/install/operators",
+			Description:  "Reports which cluster operators most often cause install failures on each platform, and their conditions",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonInstallOperatorHealthFromDB,
+		},
+		{
+			EndpointPath: "/api/install/export",
+			Description:  "Exports the install and operator health report as a printable HTML page or PDF",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.installReportExport,
+		},
 		{
 			EndpointPath: "/api/upgrade",
 			Description:  "Reports on upgrades",
@@ -1373,6 +3677,12 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonHealthReportFromDB,
 		},
+		{
+			EndpointPath: "/api/hypershift/health",
+			Description:  "Reports the health of hypershift (hosted control plane) jobs apart from standalone ones",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonHostedControlPlaneHealth,
+		},
 		{
 			EndpointPath: "/api/variants",
 			Description:  "Reports on variants",
@@ -1426,6 +3736,214 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonReleaseHealthReport,
 		},
+		{
+			EndpointPath: "/api/releases/{release}/scorecard",
+			Description:  "Reports a weighted readiness score for a release and the contribution of each measure to it",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			FeatureFlag:  api.FeatureReleaseScorecard,
+			HandlerFunc:  s.jsonReleaseScorecard,
+		},
+		{
+			EndpointPath: "/api/disruption/regressions",
+			Description:  "Reports backends whose disruption has been worse than two weeks ago for several consecutive days",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonDisruptionRegressions,
+		},
+		{
+			EndpointPath: "/api/disruption/percentiles",
+			Description:  "Reports daily disruption percentiles loaded from BigQuery, optionally for one backend",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonDisruptionPercentiles,
+		},
+		{
+			EndpointPath: "/api/disruption/job_runs",
+			Description:  "Reports the most disrupted job runs of the last week for each backend",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonDisruptionJobRuns,
+		},
+		{
+			EndpointPath: "/api/jobs/artifacts",
+			Description:  "Reports the size of the artifacts uploaded by each job's runs, fastest growing first",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonJobArtifactSizes,
+		},
+		{
+			EndpointPath: "/api/jobs/artifacts/trend",
+			Description:  "Reports the daily size of the artifacts uploaded by a release's job runs, optionally for one job",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonArtifactSizeTrend,
+		},
+		{
+			EndpointPath: "/api/jobs/regions",
+			Description:  "Reports job run failure rates by the cloud region or zone their clusters were installed in",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonCloudLocationHealth,
+		},
+		{
+			EndpointPath: "/api/admin/cache/purge",
+			Description:  "Purges cached API responses, optionally only those under the path param (POST)",
+			Capabilities: []string{},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonPurgeCache,
+		},
+		{
+			EndpointPath: "/api/admin/matviews/refresh",
+			Description:  "Starts a background refresh of all materialized views, or only the matview param (POST), or reports the status of refreshes started, optionally only the id param (GET)",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonMatViewRefreshJobs,
+		},
+		{
+			EndpointPath: "/api/admin/matviews/refresh/cancel",
+			Description:  "Cancels the background materialized view refresh with the id param (POST)",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonCancelMatViewRefreshJob,
+		},
+		{
+			EndpointPath: "/api/admin/reload",
+			Description:  "Reloads server configuration, such as component readiness views, without a restart (POST)",
+			Capabilities: []string{},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonReloadConfig,
+		},
+		{
+			EndpointPath: "/api/admin/revariant",
+			Description:  "Lists jobs whose variants differ from the current variant rules (GET), or updates them and refreshes dependent views (POST)",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonRevariant,
+		},
+		{
+			EndpointPath: "/api/admin/slow_queries",
+			Description:  "Returns the slowest recent database queries, when the server tracks them with --db-slow-query-threshold",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonSlowQueries,
+		},
+		{
+			EndpointPath: "/api/admin/database_usage",
+			Description:  "Reports the disk usage, bloat and growth of each table and materialized view, warning when the database is projected to exceed --db-disk-budget",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonDatabaseUsage,
+		},
+		{
+			EndpointPath: "/api/reports/templates",
+			Description:  "Lists the configured report templates and their parameters",
+			HandlerFunc:  s.jsonReportTemplates,
+		},
+		{
+			EndpointPath: "/api/reports/run",
+			Description:  "Runs a report template against the materialized views",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonRunReportTemplate,
+		},
+		{
+			EndpointPath: "/api/views",
+			Description:  "Lists (GET), creates (POST), replaces (PUT with id) or deletes (DELETE with id and owner) filters saved from the UI",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeWrite,
+			HandlerFunc:  s.jsonSavedViews,
+		},
+		{
+			EndpointPath: "/api/watchlists",
+			Description:  "Lists (GET), creates (POST), replaces (PUT with id) or deletes (DELETE with id and owner) watchlists of tests",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeWrite,
+			HandlerFunc:  s.jsonWatchlists,
+		},
+		{
+			EndpointPath: "/api/watchlists/report",
+			Description:  "Returns the current pass rates and regressions of the tests on a watchlist",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonWatchlistReport,
+		},
+		{
+			EndpointPath: "/api/flags",
+			Description:  "Lists the experimental features, their rollout, and whether each is enabled for the caller",
+			HandlerFunc:  s.jsonFeatureFlags,
+		},
+		{
+			EndpointPath: "/api/admin/flags",
+			Description:  "Lists (GET), sets (POST) or clears (DELETE with flag) the database rollout of experimental features",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonAdminFeatureFlags,
+		},
+		{
+			EndpointPath: "/api/admin/tokens",
+			Description:  "Lists (GET), creates (POST), or revokes (DELETE with id) API tokens for automation",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeAdmin,
+			HandlerFunc:  s.jsonAPITokens,
+		},
+		{
+			EndpointPath: "/api/alerts",
+			Description:  "Returns the latest evaluation of each configured alert rule",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonAlerts,
+		},
+		{
+			EndpointPath: "/api/acknowledgements",
+			Description:  "Lists (GET), creates (POST), or deletes (DELETE with id) acknowledgements and snoozes of firing alerts and disruption regressions",
+			Capabilities: []string{LocalDBCapability},
+			Scope:        api.APITokenScopeWrite,
+			HandlerFunc:  s.jsonAcknowledgements,
+		},
+		{
+			EndpointPath: "/api/feeds/atom",
+			Description:  "Lists notable events, such as fired alerts, rejected payloads and disruption regressions, as an Atom feed",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    15 * time.Minute,
+			HandlerFunc:  s.feedEvents(api.FeedFormatAtom),
+		},
+		{
+			EndpointPath: "/api/feeds/rss",
+			Description:  "Lists notable events, such as fired alerts, rejected payloads and disruption regressions, as an RSS feed",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    15 * time.Minute,
+			HandlerFunc:  s.feedEvents(api.FeedFormatRSS),
+		},
+		{
+			EndpointPath: "/api/slos",
+			Description:  "Reports the error budget remaining and burn rate of each configured job SLO",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonSLOs,
+		},
+		{
+			EndpointPath: "/api/matviews/refreshes",
+			Description:  "Returns the history of materialized view refreshes, including any in progress",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonMatViewRefreshes,
+		},
+		{
+			EndpointPath: "/api/loads",
+			Description:  "Returns the history of data loads, optionally only those including a release",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonLoadEvents,
+		},
+		{
+			EndpointPath: "/api/releases/promotions",
+			Description:  "Reports the last accepted payload and time since promotion for each payload stream",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonReleasePromotions,
+		},
+		{
+			EndpointPath: "/api/releases/promotion_recommendation",
+			Description:  "Recommends accepting or rejecting the latest payload of a stream, with the reasons against it",
+			Capabilities: []string{LocalDBCapability},
+			FeatureFlag:  api.FeaturePromotionRecommendation,
+			HandlerFunc:  s.jsonPromotionRecommendation,
+		},
 		{
 			EndpointPath: "/api/releases/tags/events",
 			Description:  "Lists events for release tags",
@@ -1450,6 +3968,14 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonListPayloadJobRuns,
 		},
+		{
+			EndpointPath:  "/api/external/job_runs",
+			Description:   "Stores a job run from a CI system other than prow, as a JSON summary or junit XML (POST)",
+			Capabilities:  []string{LocalDBCapability},
+			Scope:         api.APITokenScopeWrite,
+			TokenRequired: true,
+			HandlerFunc:   s.jsonExternalJobRuns,
+		},
 		{
 			EndpointPath: "/api/incidents",
 			Description:  "Reports incident events",
@@ -1468,12 +3994,24 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonGetPayloadTestFailures,
 		},
+		{
+			EndpointPath: "/api/payloads/rejection",
+			Description:  "Explains a payload's rejection with its failed blocking jobs and their failed tests",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonGetPayloadRejection,
+		},
 		{
 			EndpointPath: "/api/payloads/diff",
 			Description:  "Reports pull requests that differ between payloads",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonPayloadDiff,
 		},
+		{
+			EndpointPath: "/api/payloads/changes",
+			Description:  "Reports component, image and pull request changes in a payload since the last accepted payload",
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonPayloadChanges,
+		},
 		{
 			EndpointPath: "/api/feature_gates",
 			Description:  "Reports feature gates and their test counts for a particular release",
@@ -1485,42 +4023,61 @@ func (s *Server) Serve() {
 	for _, ep := range endpoints {
 		fn := ep.HandlerFunc
 		if ep.CacheTime > 0 {
-			fn = s.cached(ep.CacheTime, fn)
+			fn = s.conditional(ep.CacheTime, s.cached(ep.EndpointPath, ep.CacheTime, fn))
+		}
+		if ep.Scope != "" {
+			fn = s.requireScope(ep.Scope, ep.TokenRequired, fn)
+		}
+		if ep.FeatureFlag != "" {
+			fn = s.requireFeatureFlag(ep.FeatureFlag, fn)
 		}
 		if len(ep.Capabilities) > 0 {
 			fn = s.requireCapabilities(ep.Capabilities, fn)
 		}
-		serveMux.HandleFunc(ep.EndpointPath, fn)
+		serveMux.HandleFunc(ep.EndpointPath, instrumented(ep.EndpointPath, fn))
 	}
 
 	var handler http.Handler = serveMux
+	handler = compressHandler(handler)
 	// wrap mux with our logger. this will
 	handler = logRequestHandler(handler)
 	// ... potentially add more middleware handlers
 
 	// Store a pointer to the HTTP server for later retrieval.
-	s.httpServer = &http.Server{
+	httpServer := &http.Server{
 		Addr:              s.listenAddr,
 		Handler:           handler,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
+	s.httpServerLock.Lock()
+	s.httpServer = httpServer
+	s.httpServerLock.Unlock()
 
 	log.Infof("Serving reports on %s ", s.listenAddr)
 
-	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 		log.WithError(err).Error("Server exited")
 	}
 }
 
+// logRequestHandler logs every request, tagged with a request ID that is also returned in the response headers
+// and error responses.
 func logRequestHandler(h http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		h.ServeHTTP(w, r)
+		requestID := api.RequestID(r)
+		w.Header().Set(api.RequestIDHeader, requestID)
+		recorder := newResponseRecorder(w)
+		h.ServeHTTP(recorder, r)
 		log.WithFields(log.Fields{
-			"uri":       r.URL.String(),
-			"method":    r.Method,
-			"elapsed":   time.Since(start),
-			"requestor": getRequestorIP(r),
+			"request_id": requestID,
+			"uri":        r.URL.String(),
+			"method":     r.Method,
+			"status":     recorder.status,
+			"bytes":      recorder.bytes,
+			"elapsed":    time.Since(start),
+			"requestor":  getRequestorIP(r),
+			"user_agent": r.UserAgent(),
 		}).Info("responded to request")
 	}
 	return http.HandlerFunc(fn)
@@ -1550,21 +4107,79 @@ func getRequestorIP(r *http.Request) string {
 	return "unknown"
 }
 
-func (s *Server) cached(duration time.Duration, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
+// cached serves GET requests from the configured cache when possible, storing successful responses for duration.
+func (s *Server) cached(endpoint string, duration time.Duration, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
 	if s.cache == nil {
 		log.Debugf("no cache configured, making live api call")
 		return handler
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		content, err := s.cache.Get(context.TODO(), r.RequestURI, duration)
+		if r.Method != http.MethodGet {
+			handler(w, r)
+			return
+		}
+
+		key := apiCacheKey(r)
+		content, err := s.cache.Get(context.TODO(), key, duration)
 		if err != nil { // cache miss
-			log.WithError(err).Debugf("cache miss: could not fetch data from cache for %q", r.RequestURI)
+			log.WithError(err).Debugf("cache miss: could not fetch data from cache for %q", key)
 		} else if content != nil && respondFromCache(content, w, r) == nil { // cache hit
+			apiCacheRequestsMetric.WithLabelValues(endpoint, "hit").Inc()
+			return
+		}
+		apiCacheRequestsMetric.WithLabelValues(endpoint, "miss").Inc()
+
+		// concurrent misses for the same key wait on one request to the handler
+		content, err = cache.Refresh(r.Context(), s.cache, key, duration, func(ctx context.Context) ([]byte, error) {
+			return recordResponse(r.WithContext(ctx), w.Header().Get(api.RequestIDHeader), handler)
+		})
+		var uncached *uncachedResponse
+		if errors.As(err, &uncached) {
+			writeAPIResponse(w, uncached.status, uncached.response)
+			return
+		} else if err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		apiResponse := cache.APIResponse{}
+		if err := json.Unmarshal(content, &apiResponse); err != nil {
+			api.RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		recordResponse(s.cache, duration, w, r, handler)
+		writeAPIResponse(w, http.StatusOK, apiResponse)
+	}
+}
+
+// apiCacheKey normalizes the request URL, so the same query with its parameters in a different order is
+// cached once.
+func apiCacheKey(r *http.Request) string {
+	return apiCacheKeyPrefix + r.URL.Path + "?" + r.URL.Query().Encode()
+}
+
+// jsonPurgeCache removes cached API responses, optionally only those for endpoints under the path param.
+func (s *Server) jsonPurgeCache(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		api.RespondWithError(w, http.StatusMethodNotAllowed, "cache purge requires a POST")
+		return
+	}
+	purger, ok := s.cache.(cache.Purger)
+	if !ok {
+		api.RespondWithError(w, http.StatusNotImplemented, "the configured cache does not support purging")
+		return
+	}
+
+	purged, err := purger.Purge(req.Context(), apiCacheKeyPrefix+param.SafeRead(req, "path"))
+	if err != nil {
+		log.WithError(err).Error("error purging api cache")
+		api.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
+	log.WithField("entries", purged).Info("purged api cache")
+	api.RespondWithJSON(http.StatusOK, w, map[string]interface{}{
+		"code":   http.StatusOK,
+		"purged": purged,
+	})
 }
 
 func respondFromCache(content []byte, w http.ResponseWriter, r *http.Request) error {
@@ -1574,46 +4189,65 @@ func respondFromCache(content []byte, w http.ResponseWriter, r *http.Request) er
 		return err
 	}
 	log.Debugf("cache hit for %q", r.RequestURI)
+	w.Header().Set("X-Sippy-Cached", "true")
+	writeAPIResponse(w, http.StatusOK, apiResponse)
+	return nil
+}
+
+// writeAPIResponse writes a recorded response, which may have been recorded for a different request.
+func writeAPIResponse(w http.ResponseWriter, status int, apiResponse cache.APIResponse) {
 	for k, v := range apiResponse.Headers {
+		// the request ID belongs to this request, not the one that was recorded
+		if k == api.RequestIDHeader {
+			continue
+		}
 		w.Header()[k] = v
 	}
-	w.Header().Set("X-Sippy-Cached", "true")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 
 	if _, err := w.Write(apiResponse.Response); err != nil {
 		log.WithError(err).Debugf("error writing http response")
-		return err
 	}
+}
 
-	return nil
+// uncachedResponse is an unsuccessful response, shared with concurrent requests for the same page but not cached,
+// so it can be retried.
+type uncachedResponse struct {
+	status   int
+	response cache.APIResponse
 }
 
-func recordResponse(c cache.Cache, duration time.Duration, w http.ResponseWriter, r *http.Request, handler func(w http.ResponseWriter, r *http.Request)) {
-	apiResponse := cache.APIResponse{}
+func (u *uncachedResponse) Error() string {
+	return fmt.Sprintf("uncached response with status %d", u.status)
+}
+
+// recordResponse runs the handler, returning its response to cache if it was successful.
+// recordResponse runs handler against a recorder, tagged with the live request's ID so errors it reports carry it.
+func recordResponse(r *http.Request, requestID string, handler func(w http.ResponseWriter, r *http.Request)) ([]byte, error) {
 	recorder := httptest.NewRecorder()
+	recorder.Header().Set(api.RequestIDHeader, requestID)
 	handler(recorder, r)
-	apiResponse.Headers = w.Header()
-	for k, v := range recorder.Result().Header {
-		w.Header()[k] = v
-	}
-	w.WriteHeader(recorder.Code)
-	content := recorder.Body.Bytes()
-	apiResponse.Response = content
-
-	log.Debugf("caching new page: %s for %s\n", r.RequestURI, duration)
-	apiResponseBytes, err := json.Marshal(apiResponse)
-	if err != nil {
-		log.WithError(err).Warningf("couldn't marshal api response")
-	}
-
-	if err := c.Set(context.TODO(), r.RequestURI, apiResponseBytes, duration); err != nil {
-		log.WithError(err).Warningf("could not cache page")
+	apiResponse := cache.APIResponse{
+		Headers:  recorder.Result().Header,
+		Response: recorder.Body.Bytes(),
 	}
-	if _, err := w.Write(content); err != nil {
-		log.WithError(err).Debugf("error writing http response")
+	if recorder.Code != http.StatusOK {
+		return nil, &uncachedResponse{status: recorder.Code, response: apiResponse}
 	}
+	return json.Marshal(apiResponse)
 }
 
 func (s *Server) GetHTTPServer() *http.Server {
+	s.httpServerLock.Lock()
+	defer s.httpServerLock.Unlock()
 	return s.httpServer
 }
+
+// Shutdown stops serving, letting in flight requests finish until ctx is done, so Serve returns.
+func (s *Server) Shutdown(ctx context.Context) error {
+	httpServer := s.GetHTTPServer()
+	if httpServer == nil {
+		return nil
+	}
+	return httpServer.Shutdown(ctx)
+}
@@ -2,6 +2,8 @@ package sippyserver
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -120,3 +122,38 @@ func TestEncodeDefaultHighRisk(t *testing.T) {
 		t.Fatal("Invalid overall risk analysis after decoding")
 	}
 }
+
+func TestJobRunGCSPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "periodic",
+			query: "job_name=periodic-ci-openshift-release-master-ci-4.16-e2e-aws-ovn-upgrade",
+			want:  "logs/periodic-ci-openshift-release-master-ci-4.16-e2e-aws-ovn-upgrade/1234",
+		},
+		{
+			name:  "origin presubmit",
+			query: "job_name=pull-ci-openshift-origin-master-e2e-aws&repo_info=openshift_origin&pull_number=42",
+			want:  "pr-logs/pull/42/pull-ci-openshift-origin-master-e2e-aws/1234",
+		},
+		{
+			name:  "other presubmit",
+			query: "job_name=pull-ci-openshift-api-master-e2e-aws&repo_info=openshift_api&pull_number=42",
+			want:  "pr-logs/pull/openshift_api/42/pull-ci-openshift-api-master-e2e-aws/1234",
+		},
+		{
+			name: "no job name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/runs/intervals?"+tt.query, nil)
+			if got := jobRunGCSPath(req, "1234"); got != tt.want {
+				t.Errorf("jobRunGCSPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Package slo tracks the error budgets of the configured job SLOs, how many more failures their jobs can have
+// before missing the objective.
+package slo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+const (
+	StatusOK        = "ok"
+	StatusAtRisk    = "at_risk"
+	StatusExhausted = "exhausted"
+	StatusNoData    = "no_data"
+
+	// defaultWindowDays is the window of SLOs that don't set one.
+	defaultWindowDays = 28
+	// atRiskRemaining is the fraction of the budget below which an SLO is at risk.
+	atRiskRemaining = 0.25
+)
+
+var (
+	budgetRemainingMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_slo_error_budget_remaining",
+		Help: "Fraction of an SLO's error budget remaining over its window, negative once the objective is missed",
+	}, []string{"slo", "release"})
+	burnRateMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sippy_slo_burn_rate",
+		Help: "Rate an SLO's error budget is spent at relative to exactly spending it over the window, by window (1d or the SLO's)",
+	}, []string{"slo", "release", "window"})
+)
+
+// Tracker computes the status of the configured SLOs.
+type Tracker struct {
+	dbc  *db.DB
+	slos []slo
+}
+
+// slo is a validated SLO with its job regexes compiled.
+type slo struct {
+	v1.SLOConfig
+	regexes []*regexp.Regexp
+}
+
+// New returns a Tracker for the SLOs, or an error if any is invalid.
+func New(dbc *db.DB, configs []v1.SLOConfig) (*Tracker, error) {
+	t := &Tracker{dbc: dbc}
+	names := map[string]bool{}
+	for _, config := range configs {
+		if err := Validate(config); err != nil {
+			return nil, err
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate SLO name %q", config.Name)
+		}
+		names[config.Name] = true
+
+		s := slo{SLOConfig: config}
+		if s.WindowDays == 0 {
+			s.WindowDays = defaultWindowDays
+		}
+		for _, r := range config.JobRegexes {
+			s.regexes = append(s.regexes, regexp.MustCompile(r))
+		}
+		t.slos = append(t.slos, s)
+	}
+	return t, nil
+}
+
+// Validate returns an error if the SLO is missing required fields, covers no jobs, or has an invalid target,
+// window or job regex.
+func Validate(config v1.SLOConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("SLO is missing a name")
+	}
+	if config.Release == "" {
+		return fmt.Errorf("SLO %q must specify a release", config.Name)
+	}
+	if len(config.Jobs) == 0 && len(config.JobRegexes) == 0 && len(config.Variants) == 0 {
+		return fmt.Errorf("SLO %q must specify jobs, job regexes or variants", config.Name)
+	}
+	if config.Target <= 0 || config.Target >= 100 {
+		return fmt.Errorf("SLO %q target must be a pass percentage between 0 and 100", config.Name)
+	}
+	if config.WindowDays < 0 {
+		return fmt.Errorf("SLO %q window must be a positive number of days", config.Name)
+	}
+	for _, r := range config.JobRegexes {
+		if _, err := regexp.Compile(r); err != nil {
+			return errors.WithMessagef(err, "SLO %q has an invalid job regex", config.Name)
+		}
+	}
+	return nil
+}
+
+// Statuses returns the status of every SLO as of reportEnd, and updates the SLO metrics.
+func (t *Tracker) Statuses(reportEnd time.Time) ([]apitype.SLOStatus, error) {
+	statuses := make([]apitype.SLOStatus, 0, len(t.slos))
+	for _, s := range t.slos {
+		status, err := t.status(s, reportEnd)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error computing SLO %q", s.Name)
+		}
+		budgetRemainingMetric.WithLabelValues(s.Name, s.Release).Set(status.BudgetRemaining)
+		burnRateMetric.WithLabelValues(s.Name, s.Release, fmt.Sprintf("%dd", s.WindowDays)).Set(status.BurnRate)
+		burnRateMetric.WithLabelValues(s.Name, s.Release, "1d").Set(status.DayBurnRate)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// RefreshMetrics updates the SLO metrics, logging rather than returning any error.
+func (t *Tracker) RefreshMetrics(reportEnd time.Time) {
+	if _, err := t.Statuses(reportEnd); err != nil {
+		log.WithError(err).Error("error refreshing SLO metrics")
+	}
+}
+
+func (t *Tracker) status(s slo, reportEnd time.Time) (apitype.SLOStatus, error) {
+	var jobs []models.ProwJob
+	if res := t.dbc.DB.Select("id, name, variants").Where("release = ?", s.Release).Find(&jobs); res.Error != nil {
+		return apitype.SLOStatus{}, res.Error
+	}
+	var jobIDs []uint
+	for _, job := range jobs {
+		if s.covers(job) {
+			jobIDs = append(jobIDs, job.ID)
+		}
+	}
+
+	window, err := query.JobRunFailureCounts(t.dbc, jobIDs, reportEnd.Add(-time.Duration(s.WindowDays)*24*time.Hour), reportEnd)
+	if err != nil {
+		return apitype.SLOStatus{}, err
+	}
+	day, err := query.JobRunFailureCounts(t.dbc, jobIDs, reportEnd.Add(-24*time.Hour), reportEnd)
+	if err != nil {
+		return apitype.SLOStatus{}, err
+	}
+	status := errorBudget(s.SLOConfig, window, day)
+	status.Jobs = len(jobIDs)
+	return status, nil
+}
+
+// covers returns true if the job is one of the SLO's.
+func (s slo) covers(job models.ProwJob) bool {
+	for _, v := range s.Variants {
+		if !slices.Contains(job.Variants, v) {
+			return false
+		}
+	}
+	if len(s.Jobs) == 0 && len(s.regexes) == 0 {
+		return true
+	}
+	if slices.Contains(s.Jobs, job.Name) {
+		return true
+	}
+	for _, r := range s.regexes {
+		if r.MatchString(job.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorBudget works out how much of the SLO's budget the failures over its window and the last day have spent.
+func errorBudget(config v1.SLOConfig, window, day query.JobRunFailures) apitype.SLOStatus {
+	status := apitype.SLOStatus{
+		Name:            config.Name,
+		Release:         config.Release,
+		Target:          config.Target,
+		WindowDays:      config.WindowDays,
+		Runs:            window.Runs,
+		Failures:        window.Failures,
+		BudgetRemaining: 1,
+		Status:          StatusNoData,
+	}
+	if window.Runs == 0 {
+		return status
+	}
+
+	allowedFailureRate := 1 - config.Target/100
+	status.PassPercentage = float64(window.Runs-window.Failures) * 100 / float64(window.Runs)
+	status.ErrorBudget = allowedFailureRate * float64(window.Runs)
+	status.BudgetRemaining = 1 - float64(window.Failures)/status.ErrorBudget
+	status.BurnRate = float64(window.Failures) / float64(window.Runs) / allowedFailureRate
+	if day.Runs > 0 {
+		status.DayBurnRate = float64(day.Failures) / float64(day.Runs) / allowedFailureRate
+	}
+
+	switch {
+	case status.BudgetRemaining <= 0:
+		status.Status = StatusExhausted
+	case status.BudgetRemaining < atRiskRemaining:
+		status.Status = StatusAtRisk
+	default:
+		status.Status = StatusOK
+	}
+	return status
+}
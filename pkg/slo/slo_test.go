@@ -0,0 +1,150 @@
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/query"
+)
+
+func TestValidate(t *testing.T) {
+	valid := v1.SLOConfig{
+		Name:       "blocking",
+		Release:    "4.16",
+		JobRegexes: []string{"^periodic-ci-openshift-release-master-nightly-4.16-e2e-"},
+		Target:     92,
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(c *v1.SLOConfig)
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			mutate: func(c *v1.SLOConfig) {},
+		},
+		{
+			name:        "missing name",
+			mutate:      func(c *v1.SLOConfig) { c.Name = "" },
+			expectError: true,
+		},
+		{
+			name:        "missing release",
+			mutate:      func(c *v1.SLOConfig) { c.Release = "" },
+			expectError: true,
+		},
+		{
+			name:        "no jobs",
+			mutate:      func(c *v1.SLOConfig) { c.JobRegexes = nil },
+			expectError: true,
+		},
+		{
+			name: "variants only",
+			mutate: func(c *v1.SLOConfig) {
+				c.JobRegexes = nil
+				c.Variants = []string{"metal"}
+			},
+		},
+		{
+			name:        "target out of range",
+			mutate:      func(c *v1.SLOConfig) { c.Target = 100 },
+			expectError: true,
+		},
+		{
+			name:        "invalid regex",
+			mutate:      func(c *v1.SLOConfig) { c.JobRegexes = []string{"e2e-("} },
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.mutate(&config)
+			err := Validate(config)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	_, err := New(nil, []v1.SLOConfig{valid, valid})
+	assert.ErrorContains(t, err, "duplicate SLO name")
+}
+
+func TestCovers(t *testing.T) {
+	tracker, err := New(nil, []v1.SLOConfig{{
+		Name:       "metal",
+		Release:    "4.16",
+		Jobs:       []string{"periodic-metal-ipi"},
+		JobRegexes: []string{"-metal-upi$"},
+		Variants:   []string{"metal"},
+		Target:     90,
+	}})
+	assert.NoError(t, err)
+	s := tracker.slos[0]
+	assert.Equal(t, defaultWindowDays, s.WindowDays)
+
+	assert.True(t, s.covers(models.ProwJob{Name: "periodic-metal-ipi", Variants: []string{"metal", "amd64"}}))
+	assert.True(t, s.covers(models.ProwJob{Name: "periodic-e2e-metal-upi", Variants: []string{"metal"}}))
+	assert.False(t, s.covers(models.ProwJob{Name: "periodic-metal-ipi", Variants: []string{"aws"}}), "missing variant")
+	assert.False(t, s.covers(models.ProwJob{Name: "periodic-e2e-aws", Variants: []string{"metal"}}), "not listed or matched")
+}
+
+func TestErrorBudget(t *testing.T) {
+	config := v1.SLOConfig{Name: "blocking", Release: "4.16", Target: 90, WindowDays: 28}
+
+	tests := []struct {
+		name          string
+		window        query.JobRunFailures
+		day           query.JobRunFailures
+		wantStatus    string
+		wantRemaining float64
+		wantBurn      float64
+		wantDayBurn   float64
+	}{
+		{
+			name:          "no runs",
+			wantStatus:    StatusNoData,
+			wantRemaining: 1,
+		},
+		{
+			name:          "half the budget spent",
+			window:        query.JobRunFailures{Runs: 200, Failures: 10},
+			day:           query.JobRunFailures{Runs: 10, Failures: 0},
+			wantStatus:    StatusOK,
+			wantRemaining: 0.5,
+			wantBurn:      0.5,
+		},
+		{
+			name:          "burning fast today",
+			window:        query.JobRunFailures{Runs: 200, Failures: 16},
+			day:           query.JobRunFailures{Runs: 10, Failures: 4},
+			wantStatus:    StatusAtRisk,
+			wantRemaining: 0.2,
+			wantBurn:      0.8,
+			wantDayBurn:   4,
+		},
+		{
+			name:          "objective missed",
+			window:        query.JobRunFailures{Runs: 100, Failures: 15},
+			wantStatus:    StatusExhausted,
+			wantRemaining: -0.5,
+			wantBurn:      1.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorBudget(config, tt.window, tt.day)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.InDelta(t, tt.wantRemaining, got.BudgetRemaining, 0.0001)
+			assert.InDelta(t, tt.wantBurn, got.BurnRate, 0.0001)
+			assert.InDelta(t, tt.wantDayBurn, got.DayBurnRate, 0.0001)
+		})
+	}
+}
@@ -21,6 +21,9 @@ func (k emptySyntheticManager) CreateSyntheticTests(jrr *sippyprocessingv1.RawJo
 
 func emptyJobRunStatus(result *sippyprocessingv1.RawJobRunResult) sippyprocessingv1.JobOverallResult {
 	if result.Succeeded {
+		if result.TestFlakes > 0 {
+			return sippyprocessingv1.JobSucceededWithFlakes
+		}
 		return sippyprocessingv1.JobSucceeded
 	}
 
@@ -35,6 +35,7 @@ func (openshiftSyntheticManager) CreateSyntheticTests(jrr *sippyprocessingv1.Raw
 	// upgrades should only be indicated on jobs that run upgrades
 	if jrr.UpgradeStarted {
 		syntheticTests[testidentification.UpgradeTestName] = &syntheticTestResult{name: testidentification.UpgradeTestName}
+		syntheticTests[testidentification.UpgradeRollbackTestName] = &syntheticTestResult{name: testidentification.UpgradeRollbackTestName}
 	}
 
 	hasFinalOperatorResults := len(jrr.FinalOperatorStates) > 0
@@ -147,6 +148,29 @@ func (openshiftSyntheticManager) CreateSyntheticTests(jrr *sippyprocessingv1.Raw
 		}
 	}
 
+	// set the rollback status, an upgrade that was aborted or rolled back fails regardless of how the job ended
+	switch {
+	case !jrr.UpgradeStarted:
+	// do nothing
+	case jrr.UpgradeRolledBack:
+		syntheticTests[testidentification.UpgradeRollbackTestName].fail = 1
+	default:
+		syntheticTests[testidentification.UpgradeRollbackTestName].pass = 1
+	}
+
+	// hosted cluster tests are only indicated on hypershift jobs that ran the steps creating or tearing one down
+	for testName, status := range map[string]string{
+		testidentification.HostedClusterCreateTestName:   jrr.HostedClusterCreateStatus,
+		testidentification.HostedClusterTeardownTestName: jrr.HostedClusterTeardownStatus,
+	} {
+		switch status {
+		case testidentification.Success:
+			syntheticTests[testName] = &syntheticTestResult{name: testName, pass: 1}
+		case testidentification.Failure:
+			syntheticTests[testName] = &syntheticTestResult{name: testName, fail: 1}
+		}
+	}
+
 	switch {
 	case jrr.Failed && jrr.OpenShiftTestsStatus == testidentification.Failure:
 		syntheticTests[testidentification.OpenShiftTestsName].fail = 1
@@ -200,6 +224,9 @@ const failure string = "Failure"
 
 func jobRunStatus(result *sippyprocessingv1.RawJobRunResult) sippyprocessingv1.JobOverallResult {
 	if result.Succeeded {
+		if result.TestFlakes > 0 {
+			return sippyprocessingv1.JobSucceededWithFlakes
+		}
 		return sippyprocessingv1.JobSucceeded
 	}
 	if result.Aborted {
@@ -218,6 +245,9 @@ func jobRunStatus(result *sippyprocessingv1.RawJobRunResult) sippyprocessingv1.J
 		}
 		return sippyprocessingv1.JobInstallFailure
 	}
+	if result.HostedClusterCreateStatus == failure {
+		return sippyprocessingv1.JobInstallFailure
+	}
 	if result.UpgradeStarted && (result.UpgradeForOperatorsStatus == failure || result.UpgradeForMachineConfigPoolsStatus == failure) {
 		return sippyprocessingv1.JobUpgradeFailure
 	}
@@ -61,6 +61,62 @@ func TestSyntheticSippyTestGeneration(t *testing.T) {
 				testidentification.InstallTestName,
 			},
 		},
+		{
+			name: "completed upgrade passes rollback test",
+			rawJobResults: v1.RawJobResult{
+				JobName: job1Name,
+				JobRunResults: map[string]*v1.RawJobRunResult{
+					job1RunURL1: buildFakeUpgradeJobRunResult(false),
+				},
+			},
+			expectedTestResults: []v1.RawJobRunTestResult{
+				{Name: testidentification.UpgradeTestName, Status: v1.TestStatusSuccess},
+				{Name: testidentification.UpgradeRollbackTestName, Status: v1.TestStatusSuccess},
+			},
+		},
+		{
+			name: "rolled back upgrade fails rollback test",
+			rawJobResults: v1.RawJobResult{
+				JobName: job1Name,
+				JobRunResults: map[string]*v1.RawJobRunResult{
+					job1RunURL1: buildFakeUpgradeJobRunResult(true),
+				},
+			},
+			expectedTestResults: []v1.RawJobRunTestResult{
+				{Name: testidentification.UpgradeTestName, Status: v1.TestStatusSuccess},
+			},
+			expectedFailedTestNames: []string{
+				testidentification.UpgradeRollbackTestName,
+			},
+		},
+		{
+			name: "hosted cluster created and torn down",
+			rawJobResults: v1.RawJobResult{
+				JobName: job1Name,
+				JobRunResults: map[string]*v1.RawJobRunResult{
+					job1RunURL1: buildFakeHostedClusterJobRunResult(testidentification.Success, testidentification.Success),
+				},
+			},
+			expectedTestResults: []v1.RawJobRunTestResult{
+				{Name: testidentification.HostedClusterCreateTestName, Status: v1.TestStatusSuccess},
+				{Name: testidentification.HostedClusterTeardownTestName, Status: v1.TestStatusSuccess},
+			},
+		},
+		{
+			name: "hosted cluster not created fails creation but still tears down",
+			rawJobResults: v1.RawJobResult{
+				JobName: job1Name,
+				JobRunResults: map[string]*v1.RawJobRunResult{
+					job1RunURL1: buildFakeHostedClusterJobRunResult(testidentification.Failure, testidentification.Success),
+				},
+			},
+			expectedTestResults: []v1.RawJobRunTestResult{
+				{Name: testidentification.HostedClusterTeardownTestName, Status: v1.TestStatusSuccess},
+			},
+			expectedFailedTestNames: []string{
+				testidentification.HostedClusterCreateTestName,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		testMgr := NewOpenshiftSyntheticTestManager()
@@ -138,3 +194,55 @@ func buildFakeRawJobRunResult(
 		//Timestamp:            0,
 	}
 }
+
+func buildFakeUpgradeJobRunResult(rolledBack bool) *v1.RawJobRunResult {
+	jrr := buildFakeRawJobRunResult(true, true, v1.JobSucceeded, []v1.OperatorState{})
+	jrr.UpgradeStarted = true
+	jrr.UpgradeForOperatorsStatus = testidentification.Success
+	jrr.UpgradeForMachineConfigPoolsStatus = testidentification.Success
+	jrr.UpgradeRolledBack = rolledBack
+	return jrr
+}
+
+func buildFakeHostedClusterJobRunResult(createStatus, teardownStatus string) *v1.RawJobRunResult {
+	jrr := buildFakeRawJobRunResult(true, true, v1.JobSucceeded, []v1.OperatorState{})
+	jrr.HostedClusterCreateStatus = createStatus
+	jrr.HostedClusterTeardownStatus = teardownStatus
+	return jrr
+}
+
+func TestJobRunStatusFlakes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   v1.RawJobRunResult
+		expected v1.JobOverallResult
+	}{
+		{
+			name:     "clean success",
+			result:   v1.RawJobRunResult{Succeeded: true},
+			expected: v1.JobSucceeded,
+		},
+		{
+			name:     "success with flakes",
+			result:   v1.RawJobRunResult{Succeeded: true, TestFlakes: 2},
+			expected: v1.JobSucceededWithFlakes,
+		},
+		{
+			name:     "flakes do not change a failure",
+			result:   v1.RawJobRunResult{Failed: true, TestFlakes: 2, InstallStatus: testidentification.Success, OpenShiftTestsStatus: testidentification.Failure},
+			expected: v1.JobTestFailure,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.result
+			assert.Equal(t, tc.expected, jobRunStatus(&result))
+			assert.Equal(t, tc.expected.IsSuccess(), result.Succeeded)
+		})
+	}
+}
+
+func TestJobRunStatusHostedClusterNotCreated(t *testing.T) {
+	result := v1.RawJobRunResult{Failed: true, HostedClusterCreateStatus: testidentification.Failure}
+	assert.Equal(t, v1.JobInstallFailure, jobRunStatus(&result))
+}
@@ -0,0 +1,7 @@
+package synthetictests
+
+// NewOKDSyntheticTestManager returns the synthetic test manager for OKD job runs. OKD is built with the same
+// installer and openshift-tests as OCP, so install, upgrade and operator health are judged the same way.
+func NewOKDSyntheticTestManager() SyntheticTestManager {
+	return openshiftSyntheticManager{}
+}
@@ -15,7 +15,7 @@ func (noVariants) AllPlatforms() sets.String {
 }
 
 func (v noVariants) IdentifyVariants(jobName string) []string {
-	return []string{}
+	return withChaosVariant(jobName, []string{})
 }
 func (noVariants) IsJobNeverStable(jobName string) bool {
 	return false
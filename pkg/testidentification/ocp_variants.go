@@ -78,7 +78,7 @@ func NewOpenshiftVariantManager(ctx context.Context, bqc *bqcachedclient.Client)
 	// Read variants mapping from bigquery
 	variantsQuery := strings.ReplaceAll(jobVariantsQuery, "$$DATASET$$", bqc.Dataset)
 	log.Debugf("variant query is %+v", variantsQuery)
-	it, err := bqc.BQ.Query(variantsQuery).Read(ctx)
+	it, err := bqc.Read(ctx, bqc.BQ.Query(variantsQuery), "variants")
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +125,7 @@ func (v *openshiftVariants) IdentifyVariants(jobName string) []string {
 	// Ensure filtered by important variants; including them all
 	// significantly increases cardinality and slows matview refreshes
 	// to a crawl.
-	return filterVariants(allVariants, importantVariants)
+	return withChaosVariant(jobName, filterVariants(allVariants, importantVariants))
 }
 
 func (*openshiftVariants) IsJobNeverStable(jobName string) bool {
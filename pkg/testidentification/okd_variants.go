@@ -0,0 +1,105 @@
+package testidentification
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/sippy/pkg/util/sets"
+)
+
+// okdPlatforms are the platforms OKD jobs run on, matched against the dash separated segments of the job name.
+var okdPlatforms = []string{"aws", "azure", "gcp", "metal", "openstack", "ovirt", "vsphere"}
+
+// okdReleaseRegex matches the releases in a job name, such as 4.15 and 4.14 in
+// periodic-ci-openshift-release-master-okd-4.15-upgrade-from-okd-4.14-e2e-upgrade-gcp.
+var okdReleaseRegex = regexp.MustCompile(`\d+\.\d+`)
+
+// okdVariants identifies variants from OKD job names. Unlike OCP, OKD jobs are not part of the BigQuery
+// job_variants registry, so variants are derived from naming conventions.
+type okdVariants struct{}
+
+func NewOKDVariantManager() VariantManager {
+	return okdVariants{}
+}
+
+func (okdVariants) AllPlatforms() sets.String {
+	return sets.NewString(okdPlatforms...)
+}
+
+func (okdVariants) IdentifyVariants(jobName string) []string {
+	segments := sets.NewString(strings.Split(jobName, "-")...)
+
+	variants := []string{}
+	for _, p := range okdPlatforms {
+		if segments.Has(p) {
+			variants = append(variants, "Platform:"+p)
+			break
+		}
+	}
+
+	arch := "amd64"
+	if segments.Has("arm64") {
+		arch = "arm64"
+	}
+	variants = append(variants, "Architecture:"+arch)
+
+	network := "ovn"
+	if segments.Has("sdn") {
+		network = "sdn"
+	}
+	variants = append(variants, "Network:"+network)
+
+	topology := "ha"
+	if segments.Has("sno") || strings.Contains(jobName, "single-node") {
+		topology = "single"
+	}
+	variants = append(variants, "Topology:"+topology)
+
+	upgrade := "none"
+	if segments.Has("upgrade") {
+		upgrade = okdUpgradeType(jobName)
+	}
+	variants = append(variants, "Upgrade:"+upgrade)
+
+	// OKD ships on both Fedora CoreOS and CentOS Stream CoreOS
+	os := "fcos"
+	if segments.Has("scos") {
+		os = "scos"
+	}
+	variants = append(variants, "OS:"+os)
+
+	return withChaosVariant(jobName, variants)
+}
+
+// okdUpgradeType classifies an upgrade job by the minor version delta between the newest and oldest releases in
+// its name, the same way the OCP variant registry does: micro within a release, minor across one release and multi
+// across several.
+func okdUpgradeType(jobName string) string {
+	release, fromRelease := -1, -1
+	for _, match := range okdReleaseRegex.FindAllString(jobName, -1) {
+		minor, err := strconv.Atoi(strings.Split(match, ".")[1])
+		if err != nil {
+			continue
+		}
+		if release == -1 || minor > release {
+			release = minor
+		}
+		if fromRelease == -1 || minor < fromRelease {
+			fromRelease = minor
+		}
+	}
+
+	switch {
+	case release-fromRelease > 1:
+		return "multi"
+	case release-fromRelease == 1:
+		return "minor"
+	default:
+		return "micro"
+	}
+}
+
+func (okdVariants) IsJobNeverStable(jobName string) bool {
+	return false
+}
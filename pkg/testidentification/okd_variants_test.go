@@ -0,0 +1,52 @@
+package testidentification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOKDIdentifyVariants(t *testing.T) {
+	tests := []struct {
+		jobName  string
+		expected []string
+	}{
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-scos-4.16-e2e-aws-ovn",
+			expected: []string{"Platform:aws", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:none", "OS:scos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.15-upgrade-from-okd-4.14-e2e-upgrade-gcp",
+			expected: []string{"Platform:gcp", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:minor", "OS:fcos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-scos-4.16-e2e-aws-ovn-upgrade",
+			expected: []string{"Platform:aws", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:micro", "OS:scos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.16-upgrade-from-okd-4.14-e2e-upgrade-gcp",
+			expected: []string{"Platform:gcp", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:multi", "OS:fcos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.10-upgrade-from-okd-4.9-e2e-upgrade-aws",
+			expected: []string{"Platform:aws", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:minor", "OS:fcos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.15-e2e-vsphere-sdn-sno",
+			expected: []string{"Platform:vsphere", "Architecture:amd64", "Network:sdn", "Topology:single", "Upgrade:none", "OS:fcos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.15-e2e-arm64",
+			expected: []string{"Architecture:arm64", "Network:ovn", "Topology:ha", "Upgrade:none", "OS:fcos"},
+		},
+		{
+			jobName:  "periodic-ci-openshift-release-master-okd-4.15-e2e-aws-chaos",
+			expected: []string{"Platform:aws", "Architecture:amd64", "Network:ovn", "Topology:ha", "Upgrade:none", "OS:fcos", ChaosVariant},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.jobName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewOKDVariantManager().IdentifyVariants(tt.jobName))
+		})
+	}
+}
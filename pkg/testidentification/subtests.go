@@ -0,0 +1,40 @@
+package testidentification
+
+import (
+	"regexp"
+	"strings"
+)
+
+// subTestPrefixes are tests reported once per resource, such as each operator, whose parent is the prefix
+// without its trailing space.
+var subTestPrefixes = []string{
+	OperatorInstallPrefix,
+	SippyOperatorUpgradePrefix,
+	OperatorFinalHealthPrefix,
+	"operator conditions ",
+}
+
+// subTestSeparators split a parent test from the subtest that follows it.
+var subTestSeparators = []string{" / ", " :: "}
+
+// goSubTestRegexp matches go test subtests, i.e. TestRouter/reload_on_change.
+var goSubTestRegexp = regexp.MustCompile(`^(Test[A-Za-z0-9_]+)/\S`)
+
+// ParentTestName returns the parent of a subtest, i.e. "operator install" for "operator install etcd", and false if
+// the test is not a subtest.
+func ParentTestName(name string) (string, bool) {
+	for _, prefix := range subTestPrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return strings.TrimSuffix(prefix, " "), true
+		}
+	}
+	for _, sep := range subTestSeparators {
+		if i := strings.Index(name, sep); i > 0 && i+len(sep) < len(name) {
+			return name[:i], true
+		}
+	}
+	if m := goSubTestRegexp.FindStringSubmatch(name); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
@@ -0,0 +1,32 @@
+package testidentification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentTestName(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantParent string
+		wantOK     bool
+	}{
+		{name: "operator install etcd", wantParent: "operator install", wantOK: true},
+		{name: "[sig-sippy] operator upgrade kube-apiserver", wantParent: "[sig-sippy] operator upgrade", wantOK: true},
+		{name: "operator conditions network", wantParent: "operator conditions", wantOK: true},
+		{name: "operator conditions"},
+		{name: "disruption / kube-api-new-connections", wantParent: "disruption", wantOK: true},
+		{name: "e2e suite :: image registry", wantParent: "e2e suite", wantOK: true},
+		{name: "TestRouter/reload_on_change", wantParent: "TestRouter", wantOK: true},
+		{name: "[sig-storage] In-tree Volumes [Suite:openshift/conformance/parallel] [Suite:k8s]"},
+		{name: "[sig-sippy] install should work"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, ok := ParentTestName(tt.name)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantParent, parent)
+		})
+	}
+}
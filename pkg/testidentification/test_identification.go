@@ -31,6 +31,14 @@ const (
 	UpgradeTestName        = `[sig-sippy] upgrade should work`
 	OpenShiftTestsName     = `[sig-sippy] openshift-tests should work`
 
+	// UpgradeRollbackTestName fails for job runs whose upgrade was aborted or rolled back.
+	UpgradeRollbackTestName = `[sig-sippy] upgrade should not roll back`
+
+	// HostedClusterCreateTestName and HostedClusterTeardownTestName report whether the steps creating and tearing
+	// down a hypershift hosted cluster succeeded, only for the job runs that ran them.
+	HostedClusterCreateTestName   = `[sig-sippy] hosted cluster should be created`
+	HostedClusterTeardownTestName = `[sig-sippy] hosted cluster should be torn down`
+
 	InstallTestNamePrefix     = `install should succeed: `
 	InstallConfigTestName     = `install should succeed: configuration`
 	InstallBootstrapTestName  = `install should succeed: cluster bootstrap`
@@ -41,11 +49,23 @@ const (
 	Success = "Success"
 	Failure = "Failure"
 	Unknown = "Unknown"
+
+	// ChaosVariant marks jobs that deliberately inject faults, such as chaos and disruptive suites. Their failures
+	// are expected, so they are excluded from pass rate baselines by default.
+	ChaosVariant = "chaos"
+
+	// HostedControlPlaneVariant marks hypershift jobs, whose clusters have their control plane hosted on a
+	// management cluster rather than standalone.
+	HostedControlPlaneVariant = "Topology:external"
 )
 
 var (
 	// DefaultExcludedVariants is used to exclude particular variants in reporting
-	DefaultExcludedVariants = []string{"aggregated", "never-stable"}
+	DefaultExcludedVariants = []string{"aggregated", "never-stable", ChaosVariant}
+
+	// chaosJobRegexp matches the names of fault injection jobs, i.e. periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn-chaos
+	// or the krkn chaos suites.
+	chaosJobRegexp = regexp.MustCompile(`(^|-)(chaos|krkn|disruptive)(-|$)`)
 
 	// TODO: add [sig-sippy] here as well so we can more clearly identify and substring search
 	// OperatorInstallPrefix is used when sippy adds synthetic tests to report if each operator installed correct.
@@ -153,6 +173,23 @@ var (
 	ignoreTestRegex             = regexp.MustCompile(`^$|Run multi-stage test|operator.Import the release payload|operator.Import a release payload|operator.Run template|operator.Build image|Monitor cluster while tests execute|Overall|job.initialize|\[sig-arch\]\[Feature:ClusterUpgrade\] Cluster should remain functional during upgrade`)
 )
 
+var (
+	hostedClusterCreateStepRegex   = regexp.MustCompile(`^hypershift-.*create`)
+	hostedClusterTeardownStepRegex = regexp.MustCompile(`^hypershift-.*(destroy|delete)`)
+)
+
+// IsHostedClusterCreateStep returns true for the steps of the step registry creating a hypershift hosted cluster,
+// i.e. hypershift-aws-create or hypershift-kubevirt-create.
+func IsHostedClusterCreateStep(step string) bool {
+	return hostedClusterCreateStepRegex.MatchString(step) && !IsHostedClusterTeardownStep(step)
+}
+
+// IsHostedClusterTeardownStep returns true for the steps of the step registry tearing down a hypershift hosted
+// cluster, i.e. hypershift-aws-destroy.
+func IsHostedClusterTeardownStep(step string) bool {
+	return hostedClusterTeardownStepRegex.MatchString(step)
+}
+
 func IsOldInstallOperatorTest(testName string) bool {
 	return OperatorConditionsTestCaseName.MatchString(testName)
 }
@@ -259,3 +296,16 @@ func IsTestOnWatchlist(test *models.Test) bool {
 	}
 	return false
 }
+
+// IsChaosJob returns true if the job injects faults on purpose, judging by its name.
+func IsChaosJob(jobName string) bool {
+	return chaosJobRegexp.MatchString(jobName)
+}
+
+// withChaosVariant adds the ChaosVariant to the variants of chaos jobs.
+func withChaosVariant(jobName string, variants []string) []string {
+	if IsChaosJob(jobName) {
+		return append(variants, ChaosVariant)
+	}
+	return variants
+}
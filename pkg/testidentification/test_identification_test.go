@@ -28,3 +28,63 @@ func TestIsIgnoredTest(t *testing.T) {
 		})
 	}
 }
+
+func TestIsChaosJob(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{
+			name: "periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn-chaos",
+			want: true,
+		},
+		{
+			name: "periodic-ci-redhat-chaos-prow-scripts-main-4.16-nightly-krkn-hub-tests",
+			want: true,
+		},
+		{
+			name: "periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-disruptive",
+			want: true,
+		},
+		{
+			name: "periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn-serial",
+			want: false,
+		},
+		{
+			name: "periodic-ci-openshift-release-master-nightly-4.16-e2e-chaosmonkey-dashboard",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsChaosJob(tt.name); got != tt.want {
+				t.Errorf("IsChaosJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostedClusterSteps(t *testing.T) {
+	tests := []struct {
+		step     string
+		create   bool
+		teardown bool
+	}{
+		{step: "hypershift-aws-create", create: true},
+		{step: "hypershift-kubevirt-create", create: true},
+		{step: "hypershift-aws-destroy", teardown: true},
+		{step: "hypershift-hostedcluster-delete", teardown: true},
+		{step: "ipi-install-install"},
+		{step: "hypershift-dump"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			if got := IsHostedClusterCreateStep(tt.step); got != tt.create {
+				t.Errorf("IsHostedClusterCreateStep() = %v, want %v", got, tt.create)
+			}
+			if got := IsHostedClusterTeardownStep(tt.step); got != tt.teardown {
+				t.Errorf("IsHostedClusterTeardownStep() = %v, want %v", got, tt.teardown)
+			}
+		})
+	}
+}
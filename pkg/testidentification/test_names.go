@@ -0,0 +1,62 @@
+package testidentification
+
+import (
+	"fmt"
+	"regexp"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+// TestNameNormalizer rewrites test names with the configured rules, in order, so names that differ only by
+// generated parts are loaded as one test.
+type TestNameNormalizer struct {
+	rules []testNameRule
+}
+
+type testNameRule struct {
+	name        string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// NewTestNameNormalizer compiles the rules, returning an error if any is unnamed, duplicated or has an invalid
+// pattern.
+func NewTestNameNormalizer(rules []v1.TestNameRule) (*TestNameNormalizer, error) {
+	n := &TestNameNormalizer{}
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("test name rule with pattern %q is missing a name", rule.Pattern)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate test name rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("test name rule %q is missing a pattern", rule.Name)
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("test name rule %q has an invalid pattern: %w", rule.Name, err)
+		}
+		n.rules = append(n.rules, testNameRule{name: rule.Name, pattern: pattern, replacement: rule.Replacement})
+	}
+	return n, nil
+}
+
+// Normalize returns the name rewritten by every rule and the names of the rules that changed it, none if it was
+// left as it is.
+func (n *TestNameNormalizer) Normalize(name string) (string, []string) {
+	if n == nil {
+		return name, nil
+	}
+	var applied []string
+	for _, rule := range n.rules {
+		rewritten := rule.pattern.ReplaceAllString(name, rule.replacement)
+		if rewritten != name {
+			applied = append(applied, rule.name)
+			name = rewritten
+		}
+	}
+	return name, applied
+}
@@ -0,0 +1,76 @@
+package testidentification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+func TestTestNameNormalizer(t *testing.T) {
+	normalizer, err := NewTestNameNormalizer([]v1.TestNameRule{
+		{
+			Name:        "timestamps",
+			Pattern:     `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`,
+			Replacement: "<timestamp>",
+		},
+		{
+			Name:        "e2e-namespaces",
+			Pattern:     `(e2e-[a-z-]+)-\d{4,}`,
+			Replacement: "$1-<id>",
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		testName    string
+		want        string
+		wantApplied []string
+	}{
+		{
+			name:     "unchanged",
+			testName: "[sig-network] Services should serve endpoints",
+			want:     "[sig-network] Services should serve endpoints",
+		},
+		{
+			name:        "timestamp",
+			testName:    "[sig-cli] oc adm must-gather at 2024-05-01T12:00:00Z",
+			want:        "[sig-cli] oc adm must-gather at <timestamp>",
+			wantApplied: []string{"timestamps"},
+		},
+		{
+			name:        "both",
+			testName:    "[sig-apps] pods in e2e-statefulset-1234 ready by 2024-05-01T12:00:00Z",
+			want:        "[sig-apps] pods in e2e-statefulset-<id> ready by <timestamp>",
+			wantApplied: []string{"timestamps", "e2e-namespaces"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied := normalizer.Normalize(tt.testName)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantApplied, applied)
+		})
+	}
+}
+
+func TestNewTestNameNormalizerInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []v1.TestNameRule
+	}{
+		{name: "unnamed", rules: []v1.TestNameRule{{Pattern: `\d+`}}},
+		{name: "no pattern", rules: []v1.TestNameRule{{Name: "ids"}}},
+		{name: "invalid pattern", rules: []v1.TestNameRule{{Name: "ids", Pattern: `(\d+`}}},
+		{name: "duplicate", rules: []v1.TestNameRule{{Name: "ids", Pattern: `\d+`}, {Name: "ids", Pattern: `x`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTestNameNormalizer(tt.rules)
+			assert.Error(t, err)
+		})
+	}
+}
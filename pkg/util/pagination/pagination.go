@@ -0,0 +1,151 @@
+// Package pagination pages the results of list endpoints with opaque cursors.
+//
+// A client asks for the first page with pageSize, and follows the next_cursor of each response, passing it as
+// cursor with the same query parameters, until a response has no next_cursor. Endpoints return every result as
+// they always have when neither parameter is given.
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+const (
+	// DefaultPageSize is the page size when a cursor is given without pageSize.
+	DefaultPageSize = 100
+	// MaxPageSize caps pageSize.
+	MaxPageSize = 1000
+)
+
+// ErrInvalid is returned for a malformed pageSize or cursor, or a cursor from a different query.
+var ErrInvalid = errors.New("invalid pagination")
+
+// Request is a requested page of results.
+type Request struct {
+	PageSize int
+	Offset   int
+	// Total is the number of results counted for the first page, carried by the cursor so endpoints needn't count
+	// them again for every page. It is 0 on the first page.
+	Total int64
+
+	scope string
+}
+
+// cursor is what the opaque cursor encodes.
+type cursor struct {
+	Offset int    `json:"o"`
+	Total  int64  `json:"t"`
+	Scope  string `json:"s"`
+}
+
+// FromRequest returns the page requested with the pageSize and cursor params, or nil if neither was given. The
+// legacy perPage and page params are accepted as well, for endpoints that paged by offset before.
+func FromRequest(req *http.Request) (*Request, error) {
+	query := req.URL.Query()
+	r := &Request{PageSize: DefaultPageSize, scope: scopeOf(query)}
+
+	pageSize, perPage := query.Get("pageSize"), query.Get("perPage")
+	if pageSize == "" {
+		pageSize = perPage
+	}
+	if pageSize != "" {
+		size, err := strconv.Atoi(pageSize)
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("%w: page size must be a positive integer", ErrInvalid)
+		}
+		r.PageSize = min(size, MaxPageSize)
+	}
+
+	if encoded := query.Get("cursor"); encoded != "" {
+		c, err := decodeCursor(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if c.Scope != r.scope {
+			return nil, fmt.Errorf("%w: cursor is for a different query", ErrInvalid)
+		}
+		r.Offset, r.Total = c.Offset, c.Total
+		return r, nil
+	}
+
+	if page := query.Get("page"); page != "" && perPage != "" {
+		number, err := strconv.Atoi(page)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("%w: page must be a non-negative integer", ErrInvalid)
+		}
+		r.Offset = number * r.PageSize
+	}
+	if pageSize == "" {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// scopeOf fingerprints the query params other than those choosing the page. A cursor is only valid for the query
+// it came from, following it with different filters or sorting would silently skip or repeat results.
+func scopeOf(query url.Values) string {
+	values := url.Values{}
+	for k, v := range query {
+		switch k {
+		case "cursor", "pageSize", "perPage", "page":
+		default:
+			values[k] = v
+		}
+	}
+	sum := sha256.Sum256([]byte(values.Encode()))
+	return hex.EncodeToString(sum[:8])
+}
+
+func decodeCursor(encoded string) (cursor, error) {
+	c := cursor{}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalid)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.Offset < 0 {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalid)
+	}
+	return c, nil
+}
+
+func (r *Request) encodeCursor(offset int, total int64) string {
+	raw, _ := json.Marshal(cursor{Offset: offset, Total: total, Scope: r.scope})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Result wraps a page of rows, count of them, starting at the requested offset. total is the number of results
+// across all pages, an estimate if it's the request's Total from the first page rather than counted again.
+func (r *Request) Result(rows interface{}, count int, total int64) apitype.PaginationResult {
+	result := apitype.PaginationResult{
+		Rows:           rows,
+		PageSize:       r.PageSize,
+		Page:           r.Offset / r.PageSize,
+		TotalRows:      total,
+		TotalEstimated: r.Total > 0 && total == r.Total,
+	}
+	if next := r.Offset + count; count > 0 && int64(next) < total {
+		result.NextCursor = r.encodeCursor(next, total)
+	}
+	return result
+}
+
+// Slice returns the requested page of rows already loaded in full, or all of them if r is nil.
+func Slice[T any](r *Request, rows []T) interface{} {
+	if r == nil {
+		return rows
+	}
+	start := min(r.Offset, len(rows))
+	end := min(start+r.PageSize, len(rows))
+	result := r.Result(rows[start:end], end-start, int64(len(rows)))
+	result.TotalEstimated = false
+	return result
+}
@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+)
+
+func TestFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *Request
+		wantErr string
+	}{
+		{
+			name:  "unpaginated",
+			query: "release=4.16",
+		},
+		{
+			name:  "page size",
+			query: "release=4.16&pageSize=25",
+			want:  &Request{PageSize: 25},
+		},
+		{
+			name:  "page size capped",
+			query: "pageSize=100000",
+			want:  &Request{PageSize: MaxPageSize},
+		},
+		{
+			name:  "legacy page",
+			query: "perPage=25&page=3",
+			want:  &Request{PageSize: 25, Offset: 75},
+		},
+		{
+			name:    "invalid page size",
+			query:   "pageSize=lots",
+			wantErr: "page size must be a positive integer",
+		},
+		{
+			name:    "malformed cursor",
+			query:   "cursor=not-a-cursor",
+			wantErr: "malformed cursor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromRequest(httptest.NewRequest("GET", "/api/tests?"+tt.query, nil))
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalid)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.PageSize, got.PageSize)
+			assert.Equal(t, tt.want.Offset, got.Offset)
+		})
+	}
+}
+
+func TestSliceFollowsCursors(t *testing.T) {
+	rows := []int{1, 2, 3, 4, 5, 6, 7}
+	query := "release=4.16&sortField=name&pageSize=3"
+
+	var got []int
+	var cursors int
+	for {
+		r, err := FromRequest(httptest.NewRequest("GET", "/api/tests?"+query, nil))
+		require.NoError(t, err)
+		result, ok := Slice(r, rows).(apitype.PaginationResult)
+		require.True(t, ok)
+		assert.Equal(t, int64(7), result.TotalRows)
+		assert.False(t, result.TotalEstimated)
+		got = append(got, result.Rows.([]int)...)
+		if result.NextCursor == "" {
+			break
+		}
+		cursors++
+		query = "release=4.16&sortField=name&pageSize=3&cursor=" + result.NextCursor
+	}
+	assert.Equal(t, rows, got)
+	assert.Equal(t, 2, cursors)
+
+	assert.Equal(t, rows, Slice(nil, rows), "unpaginated requests get every row")
+}
+
+func TestCursorScope(t *testing.T) {
+	r, err := FromRequest(httptest.NewRequest("GET", "/api/jobs/runs?release=4.16&pageSize=2", nil))
+	require.NoError(t, err)
+	result := r.Result([]int{1, 2}, 2, 10)
+	require.NotEmpty(t, result.NextCursor)
+
+	next, err := FromRequest(httptest.NewRequest("GET", "/api/jobs/runs?release=4.16&pageSize=2&cursor="+result.NextCursor, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.Offset)
+	assert.Equal(t, int64(10), next.Total)
+	assert.True(t, next.Result([]int{3, 4}, 2, next.Total).TotalEstimated, "the carried total is an estimate")
+
+	_, err = FromRequest(httptest.NewRequest("GET", "/api/jobs/runs?release=4.15&pageSize=2&cursor="+result.NextCursor, nil))
+	assert.ErrorContains(t, err, "cursor is for a different query")
+}
@@ -26,24 +26,60 @@ var nameRegexp = regexp.MustCompile(`^[-.\w]+$`)
 var releaseRegexp = regexp.MustCompile(`^[\d]+\.[\d]+$`)
 var paramRegexp = map[string]*regexp.Regexp{
 	// sippy classic params
-	"release":         regexp.MustCompile(`^(Presubmits|[\d]+\.[\d]+)$`),
-	"period":          wordRegexp,
-	"stream":          wordRegexp,
-	"arch":            wordRegexp,
-	"payload":         nameRegexp,
-	"fromPayload":     nameRegexp,
-	"toPayload":       nameRegexp,
-	"job":             nameRegexp,
-	"job_name":        nameRegexp,
-	"test":            regexp.MustCompile(`^.+$`), // tests can be anything, so always parameterize in sql
-	"prow_job_run_id": numRegexp,
-	"file":            nameRegexp,
-	"repo_info":       nameRegexp,
-	"pull_number":     numRegexp,
-	"sort":            wordRegexp,
-	"sortField":       wordRegexp,
+	"release":              regexp.MustCompile(`^(Presubmits|[\d]+\.[\d]+)$`),
+	"period":               wordRegexp,
+	"window":               wordRegexp,
+	"stream":               wordRegexp,
+	"arch":                 wordRegexp,
+	"payload":              nameRegexp,
+	"fromPayload":          nameRegexp,
+	"toPayload":            nameRegexp,
+	"job":                  nameRegexp,
+	"job_name":             nameRegexp,
+	"test":                 regexp.MustCompile(`^.+$`), // tests can be anything, so always parameterize in sql
+	"testHash":             regexp.MustCompile(`^[0-9a-f]{64}$`),
+	"q":                    regexp.MustCompile(`^.+$`), // free text search, always parameterize in sql
+	"prow_job_run_id":      numRegexp,
+	"base_prow_job_run_id": numRegexp,
+	"step":                 nameRegexp,
+	"file":                 nameRegexp,
+	"path":                 regexp.MustCompile(`^/api[-./\w]*$`),
+	"matview":              nameRegexp,
+	"firing":               wordRegexp,
+	"failing":              wordRegexp,
+	"runs":                 numRegexp,
+	"acknowledged":         wordRegexp,
+	"kind":                 wordRegexp,
+	"minDays":              numRegexp,
+	"minRuns":              numRegexp,
+	"platform":             nameRegexp,
+	"minIncrease":          regexp.MustCompile(`^\d+(\.\d+)?$`),
+	"id":                   numRegexp,
+	"ids":                  regexp.MustCompile(`^\d+(,\d+)*$`),
+	"repo_info":            nameRegexp,
+	"groupBy":              wordRegexp,
+	"org":                  nameRegexp,
+	"repo":                 nameRegexp,
+	"pull_number":          numRegexp,
+	"sort":                 wordRegexp,
+	"sortField":            wordRegexp,
+	"backend":              nameRegexp,
+	"smoothing":            regexp.MustCompile(`^(bayes|wilson)$`),
+	"maxInterval":          regexp.MustCompile(`^\d+(\.\d+)?$`),
+	"threshold":            regexp.MustCompile(`^\d+(\.\d+)?$`),
+	"sample":               numRegexp,
+	"flag":                 nameRegexp,
+	"format":               regexp.MustCompile(`^(html|pdf)$`),
+	"source":               nameRegexp,
+	"build_id":             nameRegexp,
+	"bug":                  nameRegexp,
+	"template":             regexp.MustCompile(`^[\w-]+$`),
+	"owner":                regexp.MustCompile(`^[-.@\w]+$`),
+	"page":                 wordRegexp,
+	"compareRelease":       regexp.MustCompile(`^(previous|[\d]+\.[\d]+)$`),
 	// component readiness params
 	"baseRelease":      releaseRegexp,
+	"baseEnd":          regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
 	"sampleRelease":    releaseRegexp,
 	"testBasisRelease": releaseRegexp,
 	"samplePROrg":      nameRegexp,
@@ -65,3 +101,18 @@ func SafeRead(req *http.Request, name string) string {
 	log.Warnf("invalid value for %s param: %q", name, value)
 	return ""
 }
+
+// SafePathValue returns the value of a path wildcard only if it matches the regexp of the query parameter with
+// the same name.
+func SafePathValue(req *http.Request, name string) string {
+	re, ok := paramRegexp[name]
+	if !ok {
+		log.Fatalf("code BUG: request for unknown param %s", name) // revive:disable-line:deep-exit
+	}
+	value := req.PathValue(name)
+	if value == "" || re.MatchString(value) {
+		return value
+	}
+	log.Warnf("invalid value for %s path value: %q", name, value)
+	return ""
+}
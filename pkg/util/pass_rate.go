@@ -0,0 +1,32 @@
+package util
+
+import "math"
+
+// Z95 is the z score for a two sided 95% confidence interval.
+const Z95 = 1.96
+
+// WilsonInterval returns the Wilson score confidence interval, as percentages, for successes out of runs. Unlike
+// the raw pass percentage the interval stays wide when a test has only run a few times, so it can be used to
+// tell when a pass rate is meaningful.
+func WilsonInterval(successes, runs int, z float64) (lower, upper float64) {
+	if runs <= 0 {
+		return 0, 100
+	}
+	n := float64(runs)
+	p := float64(successes) / n
+	z2 := z * z
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return math.Max(0, center-margin) * 100, math.Min(1, center+margin) * 100
+}
+
+// BetaSmoothedPercentage returns the pass percentage shrunk towards priorPercentage, by treating the prior as a
+// beta distribution worth priorRuns runs. A test with few runs stays close to the prior, a test with many runs
+// converges on its raw pass percentage.
+func BetaSmoothedPercentage(successes, runs int, priorPercentage, priorRuns float64) float64 {
+	alpha := priorPercentage / 100 * priorRuns
+	if runs <= 0 && priorRuns <= 0 {
+		return 0
+	}
+	return (float64(successes) + alpha) / (float64(runs) + priorRuns) * 100
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		runs      int
+		lower     float64
+		upper     float64
+	}{
+		{
+			name:  "no runs",
+			lower: 0,
+			upper: 100,
+		},
+		{
+			name:      "three passes",
+			successes: 3,
+			runs:      3,
+			lower:     43.85,
+			upper:     100,
+		},
+		{
+			name:      "many runs",
+			successes: 950,
+			runs:      1000,
+			lower:     93.47,
+			upper:     96.19,
+		},
+		{
+			name:      "all failures",
+			successes: 0,
+			runs:      10,
+			lower:     0,
+			upper:     27.75,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, upper := WilsonInterval(tt.successes, tt.runs, Z95)
+			assert.InDelta(t, tt.lower, lower, 0.01)
+			assert.InDelta(t, tt.upper, upper, 0.01)
+		})
+	}
+}
+
+func TestBetaSmoothedPercentage(t *testing.T) {
+	tests := []struct {
+		name       string
+		successes  int
+		runs       int
+		prior      float64
+		priorRuns  float64
+		percentage float64
+	}{
+		{
+			name:       "no runs is the prior",
+			prior:      90,
+			priorRuns:  10,
+			percentage: 90,
+		},
+		{
+			name:       "few failures stay near the prior",
+			successes:  0,
+			runs:       2,
+			prior:      90,
+			priorRuns:  10,
+			percentage: 75,
+		},
+		{
+			name:       "many runs converge on the raw rate",
+			successes:  500,
+			runs:       1000,
+			prior:      90,
+			priorRuns:  10,
+			percentage: 50.40,
+		},
+		{
+			name:       "no prior is the raw rate",
+			successes:  1,
+			runs:       4,
+			percentage: 25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.percentage, BetaSmoothedPercentage(tt.successes, tt.runs, tt.prior, tt.priorRuns), 0.01)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package util
+
+import "runtime/debug"
+
+// SippyVersion returns the git commit sippy was built from, with a -dirty suffix if the tree had local changes,
+// or "unknown" if the build did not record it.
+func SippyVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	var revision string
+	var modified bool
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if revision == "" {
+		return "unknown"
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return revision
+}
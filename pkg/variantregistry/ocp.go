@@ -261,6 +261,10 @@ var (
 	etcdScaling     = regexp.MustCompile(`(?i)-etcd-scaling`)
 	fipsRegex       = regexp.MustCompile(`(?i)-fips`)
 	hypershiftRegex = regexp.MustCompile(`(?i)-hypershift`)
+	// hosted control plane jobs outside the hypershift repo, i.e. rosa-hcp or e2e-aws-ovn-hcp
+	hcpRegex        = regexp.MustCompile(`(?i)-hcp(-|$)`)
+	kubevirtRegex   = regexp.MustCompile(`(?i)-kubevirt`)
+	powervsRegex    = regexp.MustCompile(`(?i)-powervs`)
 	upiRegex        = regexp.MustCompile(`(?i)-upi`)
 	libvirtRegex    = regexp.MustCompile(`(?i)-libvirt`)
 	metalRegex      = regexp.MustCompile(`(?i)-metal`)
@@ -491,6 +495,15 @@ func (v *OCPVariantLoader) IdentifyVariants(jLog logrus.FieldLogger, jobName str
 		variants[VariantLayeredProduct] = VariantNoValue
 	}
 
+	// A hosted cluster's platform can differ from its management cluster's, i.e. kubevirt hosted clusters on metal.
+	if topology == "external" {
+		if platform := determineHostedPlatform(jobName); platform != "" {
+			variants[VariantPlatform] = platform
+			// kubevirt here is where the hosted cluster runs, not OpenShift Virtualization tested as a layered product
+			variants[VariantLayeredProduct] = VariantNoValue
+		}
+	}
+
 	if len(variants) == 0 {
 		jLog.WithField("job", jobName).Warn("unable to determine any variants for job")
 		return map[string]string{}
@@ -504,7 +517,7 @@ func determineTopology(jobName string) string {
 	// external == hypershift hosted control plane
 	if singleNodeRegex.MatchString(jobName) {
 		return "single" // previously single-node
-	} else if hypershiftRegex.MatchString(jobName) {
+	} else if hypershiftRegex.MatchString(jobName) || hcpRegex.MatchString(jobName) {
 		return "external"
 	} else if compactRegex.MatchString(jobName) {
 		return "compact"
@@ -524,6 +537,8 @@ func determineInstallation(jobName string) string {
 		return "upi"
 	} else if rosaRegex.MatchString(jobName) {
 		return "rosa"
+	} else if hcpRegex.MatchString(jobName) {
+		return "hypershift"
 	}
 
 	return "ipi" // assume ipi by default
@@ -554,6 +569,17 @@ func isMultiUpgrade(release, fromRelease string) bool {
 	return false
 }
 
+// determineHostedPlatform returns the platform of a hypershift job's hosted cluster when it isn't the platform of
+// the management cluster named in the job.
+func determineHostedPlatform(jobName string) string {
+	if kubevirtRegex.MatchString(jobName) {
+		return "kubevirt"
+	} else if powervsRegex.MatchString(jobName) {
+		return "powervs"
+	}
+	return ""
+}
+
 func determinePlatform(jLog logrus.FieldLogger, variants map[string]string, jobName string) {
 	platform := ""
 
@@ -130,6 +130,60 @@ func TestVariantSyncer(t *testing.T) {
 				VariantLayeredProduct:   VariantNoValue,
 			},
 		},
+		{
+			job: "periodic-ci-openshift-hypershift-release-4.16-periodics-e2e-kubevirt-metal-ovn",
+			expected: map[string]string{
+				VariantRelease:          "4.16",
+				VariantReleaseMajor:     "4",
+				VariantReleaseMinor:     "16",
+				VariantArch:             "amd64",
+				VariantInstaller:        "hypershift",
+				VariantPlatform:         "kubevirt", // the hosted cluster's platform, not the metal management cluster's
+				VariantProcedure:        "none",
+				VariantJobTier:          "standard",
+				VariantNetwork:          "ovn",
+				VariantNetworkStack:     "ipv4",
+				VariantOwner:            "eng",
+				VariantTopology:         "external",
+				VariantSuite:            "unknown",
+				VariantUpgrade:          VariantNoValue,
+				VariantAggregation:      VariantNoValue,
+				VariantFeatureSet:       VariantDefaultValue,
+				VariantNetworkAccess:    VariantDefaultValue,
+				VariantScheduler:        VariantDefaultValue,
+				VariantSecurityMode:     VariantDefaultValue,
+				VariantContainerRuntime: "runc",
+				VariantCGroupMode:       "v2",
+				VariantLayeredProduct:   VariantNoValue,
+			},
+		},
+		{
+			job: "periodic-ci-openshift-release-master-nightly-4.17-e2e-aws-ovn-hcp",
+			expected: map[string]string{
+				VariantRelease:          "4.17",
+				VariantReleaseMajor:     "4",
+				VariantReleaseMinor:     "17",
+				VariantArch:             "amd64",
+				VariantInstaller:        "hypershift",
+				VariantPlatform:         "aws",
+				VariantProcedure:        "none",
+				VariantJobTier:          "standard",
+				VariantNetwork:          "ovn",
+				VariantNetworkStack:     "ipv4",
+				VariantOwner:            "eng",
+				VariantTopology:         "external",
+				VariantSuite:            "unknown",
+				VariantUpgrade:          VariantNoValue,
+				VariantAggregation:      VariantNoValue,
+				VariantFeatureSet:       VariantDefaultValue,
+				VariantNetworkAccess:    VariantDefaultValue,
+				VariantScheduler:        VariantDefaultValue,
+				VariantSecurityMode:     VariantDefaultValue,
+				VariantContainerRuntime: "runc",
+				VariantCGroupMode:       "v2",
+				VariantLayeredProduct:   VariantNoValue,
+			},
+		},
 		{
 			job: "periodic-ci-openshift-release-master-nightly-4.16-e2e-aws-ovn-single-node-serial",
 			variantsFile: map[string]string{
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -150,9 +151,9 @@ func testNameWithoutSuite(dbc *gorm.DB) error {
 	log.Infof("update complete, total rows updated %d", rowsUpdated)
 
 	// Refresh materialized views
-	sippyserver.RefreshData(&db.DB{
+	sippyserver.RefreshData(context.Background(), &db.DB{
 		DB: dbc,
-	}, nil, false)
+	}, nil, sippyserver.DefaultRefreshOptions())
 
 	return nil
 }